@@ -3,12 +3,16 @@ package backup
 import (
 	"context"
 	"fmt"
+	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 
 	"github.com/alcionai/clues"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
+	"github.com/alcionai/corso/src/cli/config"
 	"github.com/alcionai/corso/src/cli/flags"
 	. "github.com/alcionai/corso/src/cli/print"
 	"github.com/alcionai/corso/src/cli/utils"
@@ -16,9 +20,13 @@ import (
 	"github.com/alcionai/corso/src/internal/data"
 	"github.com/alcionai/corso/src/internal/m365/graph"
 	"github.com/alcionai/corso/src/pkg/backup"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/health"
 	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/metrics"
 	"github.com/alcionai/corso/src/pkg/path"
 	"github.com/alcionai/corso/src/pkg/repository"
+	"github.com/alcionai/corso/src/pkg/schedule"
 	"github.com/alcionai/corso/src/pkg/selectors"
 	"github.com/alcionai/corso/src/pkg/store"
 )
@@ -32,6 +40,7 @@ var subCommandFuncs = []func() *cobra.Command{
 	listCmd,
 	detailsCmd,
 	deleteCmd,
+	previewCmd,
 }
 
 var serviceCommands = []func(cmd *cobra.Command) *cobra.Command{
@@ -46,6 +55,16 @@ func AddCommands(cmd *cobra.Command) {
 	backupC := backupCmd()
 	cmd.AddCommand(backupC)
 
+	// pinning and lineage work on backups of any service, so they take no
+	// service subcommand.
+	for _, pc := range []*cobra.Command{pinCmd(), unpinCmd(), lineageCmd()} {
+		c, _ := utils.AddCommand(backupC, pc)
+
+		flags.AddCorsoPassphaseFlags(c)
+		flags.AddAWSCredsFlags(c)
+		flags.AddAzureCredsFlags(c)
+	}
+
 	for _, sc := range subCommandFuncs {
 		subCommand := sc()
 		backupC.AddCommand(subCommand)
@@ -176,10 +195,150 @@ func handleDeleteCmd(cmd *cobra.Command, args []string) error {
 	return cmd.Help()
 }
 
+// The backup preview subcommand.
+// `corso backup preview <service> [<flag>...]`
+var previewCommand = "preview"
+
+func previewCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   previewCommand,
+		Short: "Lists the data a backup would include, without backing it up",
+		Long: `Lists the data a backup would include, without backing it up.
+Only Exchange backups can be previewed.  OneDrive, SharePoint, and Groups
+backups are not supported.`,
+		RunE: handlePreviewCmd,
+		Args: cobra.NoArgs,
+	}
+}
+
+// Handler for calls to `corso backup preview`.
+// Produces the same output as `corso backup preview --help`.
+func handlePreviewCmd(cmd *cobra.Command, args []string) error {
+	return cmd.Help()
+}
+
+// The backup pin subcommand.
+// `corso backup pin <backup-id> [<flag>...]`
+var pinCommand = "pin"
+
+func pinCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   pinCommand + " <backup-id>",
+		Short: "Protects a backup from deletion",
+		Long: `Pins a backup, so that it can't be deleted unless it is unpinned first,
+or the deletion is forced.`,
+		RunE: handlePinCmd,
+		Args: cobra.ExactArgs(1),
+	}
+}
+
+// Handler for calls to `corso backup pin`.
+func handlePinCmd(cmd *cobra.Command, args []string) error {
+	return genericPinCommand(cmd, args[0], true)
+}
+
+// The backup unpin subcommand.
+// `corso backup unpin <backup-id> [<flag>...]`
+var unpinCommand = "unpin"
+
+func unpinCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   unpinCommand + " <backup-id>",
+		Short: "Allows a pinned backup to be deleted",
+		RunE:  handleUnpinCmd,
+		Args:  cobra.ExactArgs(1),
+	}
+}
+
+// Handler for calls to `corso backup unpin`.
+func handleUnpinCmd(cmd *cobra.Command, args []string) error {
+	return genericPinCommand(cmd, args[0], false)
+}
+
+// The backup lineage subcommand.
+// `corso backup lineage <backup-id> [<flag>...]`
+var lineageCommand = "lineage"
+
+func lineageCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   lineageCommand + " <backup-id>",
+		Short: "Shows the backups that a backup was built from",
+		Long: `Shows the chain of merge and assist bases behind an incremental backup.
+Each backup is followed by the bases it was built from, indented by their
+distance from the requested backup.`,
+		RunE: handleLineageCmd,
+		Args: cobra.ExactArgs(1),
+	}
+}
+
+// Handler for calls to `corso backup lineage`.
+func handleLineageCmd(cmd *cobra.Command, args []string) error {
+	bID := args[0]
+	ctx := clues.Add(cmd.Context(), "backup_id", bID)
+
+	r, _, _, _, err := utils.GetAccountAndConnectWithOverrides(
+		ctx,
+		cmd,
+		// Need to give it a valid service so it won't error out on us even though
+		// we don't need the graph client.
+		path.OneDriveService)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	les, err := r.BackupLineage(ctx, bID)
+	if err != nil {
+		if errors.Is(err, repository.ErrorBackupNotFound) {
+			return Only(ctx, clues.New("No backup exists with the id "+bID))
+		}
+
+		return Only(ctx, clues.Wrap(err, "Failed to find lineage of backup "+bID))
+	}
+
+	backup.PrintLineage(ctx, les)
+
+	return nil
+}
+
 // ---------------------------------------------------------------------------
 // common handlers
 // ---------------------------------------------------------------------------
 
+// genericPinCommand pins or unpins the backup.
+func genericPinCommand(cmd *cobra.Command, bID string, pinned bool) error {
+	ctx := clues.Add(cmd.Context(), "backup_id", bID)
+
+	r, _, _, _, err := utils.GetAccountAndConnectWithOverrides(
+		ctx,
+		cmd,
+		// Need to give it a valid service so it won't error out on us even though
+		// we don't need the graph client.
+		path.OneDriveService)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	if err := r.SetBackupPinned(ctx, bID, pinned); err != nil {
+		if errors.Is(err, repository.ErrorBackupNotFound) {
+			return Only(ctx, clues.New("No backup exists with the id "+bID))
+		}
+
+		return Only(ctx, clues.Wrap(err, "Updating backup "+bID))
+	}
+
+	if pinned {
+		Infof(ctx, "Pinned backup %s", bID)
+	} else {
+		Infof(ctx, "Unpinned backup %s", bID)
+	}
+
+	return nil
+}
+
 // standard set of selector behavior that we want used in the cli
 var defaultSelectorConfig = selectors.Config{OnlyMatchItemNames: true}
 
@@ -189,6 +348,56 @@ func runBackups(
 	serviceName string,
 	selectorSet []selectors.Selector,
 	ins idname.Cacher,
+) error {
+	var mr *metrics.Registry
+
+	if len(flags.MetricsAddrFV) > 0 {
+		mr = metrics.NewRegistry()
+
+		mctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		go func() {
+			if err := mr.Serve(mctx, flags.MetricsAddrFV); err != nil {
+				logger.CtxErr(mctx, err).Error("serving metrics")
+				Errf(mctx, "Unable to serve metrics: %v\n", err)
+			}
+		}()
+	}
+
+	var hm *health.Monitor
+
+	if len(flags.HealthAddrFV) > 0 {
+		hm = health.NewMonitor(r)
+
+		hctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		go func() {
+			if err := hm.Serve(hctx, flags.HealthAddrFV); err != nil {
+				logger.CtxErr(hctx, err).Error("serving health checks")
+				Errf(hctx, "Unable to serve health checks: %v\n", err)
+			}
+		}()
+	}
+
+	if len(flags.CronFV) > 0 {
+		return runScheduledBackups(ctx, r, serviceName, selectorSet, ins, mr, hm)
+	}
+
+	return runBackupSet(ctx, r, serviceName, selectorSet, ins, mr, hm)
+}
+
+// runBackupSet backs up each selector once.  The results of each backup
+// are recorded in the metrics registry and health monitor, if provided.
+func runBackupSet(
+	ctx context.Context,
+	r repository.Repository,
+	serviceName string,
+	selectorSet []selectors.Selector,
+	ins idname.Cacher,
+	mr *metrics.Registry,
+	hm *health.Monitor,
 ) error {
 	var (
 		bIDs []string
@@ -216,7 +425,13 @@ func runBackups(
 			"resource_owner_id", bo.ResourceOwner.ID(),
 			"resource_owner_name", bo.ResourceOwner.Name())
 
+		done := hm.Track(health.BackupOp)
+
 		err = bo.Run(ictx)
+
+		done(err)
+		mr.RecordBackup(&bo)
+
 		if err != nil {
 			if errors.Is(err, graph.ErrServiceNotEnabled) {
 				logger.Ctx(ctx).Infow("service not enabled",
@@ -267,6 +482,55 @@ func runBackups(
 	return nil
 }
 
+// runScheduledBackups blocks until the process is interrupted, backing up
+// each selector whenever the --cron schedule fires.
+func runScheduledBackups(
+	ctx context.Context,
+	r repository.Repository,
+	serviceName string,
+	selectorSet []selectors.Selector,
+	ins idname.Cacher,
+	mr *metrics.Registry,
+	hm *health.Monitor,
+) error {
+	sched, err := schedule.Parse(flags.CronFV)
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Invalid --"+flags.CronFN+" schedule"))
+	}
+
+	jobs := make([]schedule.Job, 0, len(selectorSet))
+
+	for _, discSel := range selectorSet {
+		sel := discSel
+
+		jobs = append(jobs, schedule.Job{
+			// job names persist between runs, so the name needs to identify
+			// the repo as well as the backup.
+			Name:     r.GetID() + "/" + sel.PathService().String() + "/" + sel.DiscreteOwner,
+			Schedule: sched,
+			Run: func(ctx context.Context) error {
+				return runBackupSet(ctx, r, serviceName, []selectors.Selector{sel}, ins, mr, hm)
+			},
+		})
+	}
+
+	runner, err := schedule.NewRunner(schedule.NewFileState(config.ScheduleStatePath()), jobs...)
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Scheduling backups"))
+	}
+
+	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	Infof(ctx, "Scheduled %d %s backups to run at %q\n", len(jobs), serviceName, sched.String())
+
+	if err := runner.Run(ctx); err != nil {
+		return Only(ctx, clues.Wrap(err, "Running scheduled backups"))
+	}
+
+	return nil
+}
+
 // genericDeleteCommand is a helper function that all services can use
 // for the removal of an entry from the repository
 func genericDeleteCommand(
@@ -291,7 +555,16 @@ func genericDeleteCommand(
 
 	defer utils.CloseRepo(ctx, r)
 
-	if err := r.DeleteBackups(ctx, true, bID); err != nil {
+	del := r.DeleteBackups
+	if flags.ForceDeleteFV {
+		del = r.ForceDeleteBackups
+	}
+
+	if err := del(ctx, true, bID); err != nil {
+		if errors.Is(err, repository.ErrorBackupPinned) {
+			return Only(ctx, clues.Wrap(err, "Backup "+bID+" is pinned; unpin it or delete it with --"+flags.ForceDeleteFN))
+		}
+
 		return Only(ctx, clues.Wrap(err, "Deleting backup "+bID))
 	}
 
@@ -337,6 +610,10 @@ func genericListCommand(
 			!ifShow(flags.ListSkippedItemsFV),
 			!ifShow(flags.ListRecoveredErrorsFV))
 
+		if ifShow(flags.ListFailedItemsFV) {
+			fe.PrintItemsByCause(ctx)
+		}
+
 		return nil
 	}
 
@@ -345,11 +622,57 @@ func genericListCommand(
 		return Only(ctx, clues.Wrap(err, "Failed to list backups in the repository"))
 	}
 
+	if flags.LatestBackupFV {
+		bs = backup.LatestByResource(bs)
+	}
+
+	if flags.LegacySizesFV {
+		fillLegacyBackupSizes(ctx, r, bs)
+	}
+
 	backup.PrintAll(ctx, bs)
 
 	return nil
 }
 
+// fillLegacyBackupSizes computes the total item bytes of backups created
+// before that value was recorded in the backup model.  Those backups are
+// identified by the absence of the value.  The total is summed from the
+// backup details, which is costly, so it's only done on request.  Sizes
+// that can't be computed are left at zero.
+func fillLegacyBackupSizes(
+	ctx context.Context,
+	bg repository.BackupGetter,
+	bs []*backup.Backup,
+) {
+	for _, b := range bs {
+		// the size is omitted from the model when zero, so empty backups
+		// look the same as legacy ones, and sum to zero from their details.
+		if b.TotalItemBytes > 0 {
+			continue
+		}
+
+		ictx := clues.Add(ctx, "backup_id", b.ID)
+
+		d, _, errs := bg.GetBackupDetails(ictx, string(b.ID))
+		if errs.Failure() != nil {
+			logger.CtxErr(ictx, errs.Failure()).Info("computing backup size from details")
+			continue
+		}
+
+		b.TotalItemBytes = d.SumNonMetaFileSizes()
+	}
+}
+
+// pageDetails applies the --filter-path, --search, --offset, and --limit flags
+// to the details entries ahead of printing.
+func pageDetails(dm details.DetailsModel) details.DetailsModel {
+	return dm.
+		FilterPathPrefix(flags.FilterPathFV).
+		Search(flags.SearchFV).
+		Window(flags.OffsetFV, flags.LimitFV)
+}
+
 func ifShow(flag string) bool {
 	return strings.ToLower(strings.TrimSpace(flag)) == "show"
 }
@@ -0,0 +1,173 @@
+package backup
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	"github.com/alcionai/corso/src/cli/utils/testdata"
+	"github.com/alcionai/corso/src/internal/model"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/fault"
+)
+
+type BackupUnitSuite struct {
+	tester.Suite
+}
+
+func TestBackupUnitSuite(t *testing.T) {
+	suite.Run(t, &BackupUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+type detailsCountingGetter struct {
+	*testdata.MockBackupGetter
+	deets *details.Details
+	err   error
+	calls int
+}
+
+func (bg *detailsCountingGetter) GetBackupDetails(
+	context.Context,
+	string,
+) (*details.Details, *backup.Backup, *fault.Bus) {
+	bg.calls++
+
+	if bg.err != nil {
+		return nil, nil, fault.New(false).Fail(bg.err)
+	}
+
+	return bg.deets, nil, fault.New(true)
+}
+
+func (suite *BackupUnitSuite) TestAddPinCommands() {
+	table := []struct {
+		name       string
+		use        string
+		expectRunE func(*cobra.Command, []string) error
+	}{
+		{
+			name:       "pin",
+			use:        pinCommand,
+			expectRunE: handlePinCmd,
+		},
+		{
+			name:       "unpin",
+			use:        unpinCommand,
+			expectRunE: handleUnpinCmd,
+		},
+		{
+			name:       "lineage",
+			use:        lineageCommand,
+			expectRunE: handleLineageCmd,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			cmd := &cobra.Command{Use: "corso"}
+			AddCommands(cmd)
+
+			c, _, err := cmd.Find([]string{"backup", test.use})
+			require.NoError(t, err, clues.ToCore(err))
+
+			assert.Equal(t, test.use+" <backup-id>", c.Use)
+			tester.AreSameFunc(t, test.expectRunE, c.RunE)
+			assert.NotNil(t, c.Flags().Lookup(flags.CorsoPassphraseFN), "passphrase flag")
+
+			assert.Error(t, c.Args(c, nil), "no backup id")
+			assert.NoError(t, c.Args(c, []string{"id"}), "one backup id")
+			assert.Error(t, c.Args(c, []string{"id", "id2"}), "two backup ids")
+		})
+	}
+}
+
+func (suite *BackupUnitSuite) TestFillLegacyBackupSizes() {
+	deets := &details.Details{
+		DetailsModel: details.DetailsModel{
+			Entries: []details.Entry{
+				{
+					RepoRef: "file1",
+					ItemInfo: details.ItemInfo{
+						OneDrive: &details.OneDriveInfo{
+							ItemType: details.OneDriveItem,
+							Size:     100,
+						},
+					},
+				},
+				{
+					RepoRef: "file2",
+					ItemInfo: details.ItemInfo{
+						OneDrive: &details.OneDriveInfo{
+							ItemType: details.OneDriveItem,
+							Size:     50,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	table := []struct {
+		name        string
+		bup         *backup.Backup
+		deets       *details.Details
+		err         error
+		expect      int64
+		expectCalls int
+	}{
+		{
+			name:        "stored size",
+			bup:         &backup.Backup{BaseModel: model.BaseModel{ID: "new"}, TotalItemBytes: 42},
+			expect:      42,
+			expectCalls: 0,
+		},
+		{
+			name:        "legacy backup computes size from details",
+			bup:         &backup.Backup{BaseModel: model.BaseModel{ID: "legacy"}},
+			expect:      150,
+			expectCalls: 1,
+		},
+		{
+			name:        "empty backup computes size from details",
+			bup:         &backup.Backup{BaseModel: model.BaseModel{ID: "empty"}},
+			deets:       &details.Details{},
+			expect:      0,
+			expectCalls: 1,
+		},
+		{
+			name:        "details lookup failure",
+			bup:         &backup.Backup{BaseModel: model.BaseModel{ID: "legacy"}},
+			err:         assert.AnError,
+			expect:      0,
+			expectCalls: 1,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			d := deets
+			if test.deets != nil {
+				d = test.deets
+			}
+
+			bg := &detailsCountingGetter{deets: d, err: test.err}
+
+			fillLegacyBackupSizes(ctx, bg, []*backup.Backup{test.bup})
+			assert.Equal(t, test.expect, test.bup.TotalItemBytes, "total item bytes")
+			assert.Equal(t, test.expectCalls, bg.calls, "details lookups")
+		})
+	}
+}
@@ -34,6 +34,7 @@ const (
 	exchangeServiceCommandCreateUseSuffix  = "--mailbox <email> | '" + flags.Wildcard + "'"
 	exchangeServiceCommandDeleteUseSuffix  = "--backup <backupId>"
 	exchangeServiceCommandDetailsUseSuffix = "--backup <backupId>"
+	exchangeServiceCommandPreviewUseSuffix = "--mailbox <email> | '" + flags.Wildcard + "'"
 )
 
 const (
@@ -63,6 +64,12 @@ corso backup details exchange --backup 1234abcd-12ab-cd34-56de-1234abcd \
 # Explore contacts named Andy
 corso backup details exchange --backup 1234abcd-12ab-cd34-56de-1234abcd \
     --contact-name Andy`
+
+	exchangeServiceCommandPreviewExamples = `# Preview the Exchange data that a backup of Alice would include
+corso backup preview exchange --mailbox alice@example.com
+
+# Preview only the emails in Alice's Inbox, and its subfolders
+corso backup preview exchange --mailbox alice@example.com --data email --email-folder Inbox`
 )
 
 // called by backup.go to map subcommands to provider-specific handling.
@@ -83,12 +90,15 @@ func addExchangeCommands(cmd *cobra.Command) *cobra.Command {
 		// Flags addition ordering should follow the order we want them to appear in help and docs:
 		// More generic (ex: --user) and more frequently used flags take precedence.
 		flags.AddMailBoxFlag(c)
-		flags.AddDataFlag(c, []string{dataEmail, dataContacts, dataEvents}, false)
+		flags.AddDataFlag(c, exchangeDataTypes(), false)
 		flags.AddCorsoPassphaseFlags(c)
 		flags.AddAWSCredsFlags(c)
 		flags.AddAzureCredsFlags(c)
 		flags.AddFetchParallelismFlag(c)
 		flags.AddFailFastFlag(c)
+		flags.AddCronFlag(c)
+		flags.AddMetricsAddrFlag(c)
+		flags.AddHealthAddrFlag(c)
 		flags.AddDisableIncrementalsFlag(c)
 		flags.AddForceItemDataDownloadFlag(c)
 		flags.AddDisableDeltaFlag(c)
@@ -107,6 +117,8 @@ func addExchangeCommands(cmd *cobra.Command) *cobra.Command {
 		addFailedItemsFN(c)
 		addSkippedItemsFN(c)
 		addRecoveredErrorsFN(c)
+		flags.AddLatestBackupFlag(c)
+		flags.AddLegacySizesFlag(c)
 
 	case detailsCommand:
 		c, fs = utils.AddCommand(cmd, exchangeDetailsCmd())
@@ -116,6 +128,7 @@ func addExchangeCommands(cmd *cobra.Command) *cobra.Command {
 		c.Example = exchangeServiceCommandDetailsExamples
 
 		flags.AddSkipReduceFlag(c)
+		flags.AddDetailsPaginationFlags(c)
 
 		// Flags addition ordering should follow the order we want them to appear in help and docs:
 		// More generic (ex: --user) and more frequently used flags take precedence.
@@ -133,9 +146,25 @@ func addExchangeCommands(cmd *cobra.Command) *cobra.Command {
 		c.Example = exchangeServiceCommandDeleteExamples
 
 		flags.AddBackupIDFlag(c, true)
+		flags.AddForceDeleteFlag(c)
 		flags.AddCorsoPassphaseFlags(c)
 		flags.AddAWSCredsFlags(c)
 		flags.AddAzureCredsFlags(c)
+
+	case previewCommand:
+		c, fs = utils.AddCommand(cmd, exchangePreviewCmd())
+		fs.SortFlags = false
+
+		c.Use = c.Use + " " + exchangeServiceCommandPreviewUseSuffix
+		c.Example = exchangeServiceCommandPreviewExamples
+
+		flags.AddMailBoxFlag(c)
+		flags.AddDataFlag(c, exchangeDataTypes(), false)
+		flags.AddExchangeFolderFlags(c)
+		flags.AddCorsoPassphaseFlags(c)
+		flags.AddAWSCredsFlags(c)
+		flags.AddAzureCredsFlags(c)
+		flags.AddEnableImmutableIDFlag(c)
 	}
 
 	return c
@@ -221,6 +250,19 @@ func exchangeBackupCreateSelectors(userIDs, cats []string) *selectors.ExchangeBa
 	return sel
 }
 
+// exchangeDataTypes lists the values accepted by the data flag, which
+// are the names of the data categories supported by exchange.
+func exchangeDataTypes() []string {
+	cats := selectors.SupportedServiceCategories()[path.ExchangeService]
+	dts := make([]string, 0, len(cats))
+
+	for _, cat := range cats {
+		dts = append(dts, cat.String())
+	}
+
+	return dts
+}
+
 func validateExchangeBackupCreateFlags(userIDs, cats []string) error {
 	if len(userIDs) == 0 {
 		return clues.New("--user/--mailbox requires one or more email addresses or the wildcard '*'")
@@ -236,6 +278,118 @@ func validateExchangeBackupCreateFlags(userIDs, cats []string) error {
 	return nil
 }
 
+// ------------------------------------------------------------------------------------------------
+// backup preview
+// ------------------------------------------------------------------------------------------------
+
+// `corso backup preview exchange [<flag>...]`
+func exchangePreviewCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   exchangeServiceCommand,
+		Short: "Lists the M365 Exchange folders and items a backup would include",
+		RunE:  previewExchangeCmd,
+		Args:  cobra.NoArgs,
+	}
+}
+
+// resolves the selector against each mailbox, and prints the folders it
+// matches, without running a backup.
+func previewExchangeCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if utils.HasNoFlagsAndShownHelp(cmd) {
+		return nil
+	}
+
+	if err := validateExchangeBackupCreateFlags(flags.UserFV, flags.CategoryDataFV); err != nil {
+		return err
+	}
+
+	r, _, acct, _, err := utils.GetAccountAndConnectWithOverrides(
+		ctx,
+		cmd,
+		path.ExchangeService)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	sel := exchangeBackupPreviewSelectors(
+		flags.UserFV,
+		flags.CategoryDataFV,
+		flags.EmailFolderFV,
+		flags.ContactFolderFV,
+		flags.EventCalendarFV)
+
+	ins, err := utils.UsersMap(ctx, *acct, utils.Control(), fault.New(true))
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to retrieve M365 users"))
+	}
+
+	for _, discSel := range sel.SplitByResourceOwner(ins.IDs()) {
+		owner := discSel.DiscreteOwner
+		if name, ok := ins.NameOf(owner); ok {
+			owner = name
+		}
+
+		preview, err := r.PreviewBackup(ctx, discSel.Selector)
+		if err != nil {
+			return Only(ctx, clues.Wrap(err, "Failed to preview backup of "+owner))
+		}
+
+		Infof(ctx, "\nMailbox: %s", owner)
+
+		if len(preview.Containers) == 0 {
+			Info(ctx, selectors.ErrorNoMatchingItems)
+			continue
+		}
+
+		ps := make([]Printable, 0, len(preview.Containers))
+		for _, cp := range preview.Containers {
+			ps = append(ps, cp)
+		}
+
+		All(ctx, ps...)
+	}
+
+	return nil
+}
+
+// exchangeBackupPreviewSelectors produces the backup selector for the
+// preview.  Categories without a folder flag include every folder, while
+// folder flags include the named folders, and all of their subfolders.
+func exchangeBackupPreviewSelectors(
+	userIDs, cats, emailFolders, contactFolders, eventCalendars []string,
+) *selectors.ExchangeBackup {
+	sel := selectors.NewExchangeBackup(userIDs)
+
+	folders := func(fs []string) []string {
+		if len(fs) == 0 {
+			return selectors.Any()
+		}
+
+		return fs
+	}
+
+	if len(cats) == 0 {
+		cats = []string{dataEmail, dataContacts, dataEvents}
+	}
+
+	for _, d := range cats {
+		switch d {
+		case dataContacts:
+			sel.Include(sel.ContactFolders(folders(contactFolders), selectors.PrefixMatch()))
+		case dataEmail:
+			sel.Include(sel.MailFolders(folders(emailFolders), selectors.PrefixMatch()))
+		case dataEvents:
+			sel.Include(sel.EventCalendars(folders(eventCalendars), selectors.PrefixMatch()))
+		}
+	}
+
+	return sel
+}
+
 // ------------------------------------------------------------------------------------------------
 // backup list
 // ------------------------------------------------------------------------------------------------
@@ -294,12 +448,14 @@ func detailsExchangeCmd(cmd *cobra.Command, args []string) error {
 		return Only(ctx, err)
 	}
 
-	if len(ds.Entries) == 0 {
+	dm := pageDetails(ds.DetailsModel)
+
+	if len(dm.Entries) == 0 {
 		Info(ctx, selectors.ErrorNoMatchingItems)
 		return nil
 	}
 
-	ds.PrintEntries(ctx)
+	dm.PrintEntries(ctx)
 
 	return nil
 }
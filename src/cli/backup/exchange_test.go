@@ -15,6 +15,7 @@ import (
 	"github.com/alcionai/corso/src/internal/tester"
 	"github.com/alcionai/corso/src/internal/version"
 	dtd "github.com/alcionai/corso/src/pkg/backup/details/testdata"
+	"github.com/alcionai/corso/src/pkg/selectors"
 )
 
 type ExchangeUnitSuite struct {
@@ -47,6 +48,9 @@ func (suite *ExchangeUnitSuite) TestAddExchangeCommands() {
 				flags.DisableIncrementalsFN,
 				flags.DisableDeltaFN,
 				flags.FailFastFN,
+				flags.CronFN,
+				flags.MetricsAddrFN,
+				flags.HealthAddrFN,
 				flags.FetchParallelismFN,
 				flags.SkipReduceFN,
 				flags.NoStatsFN,
@@ -64,6 +68,8 @@ func (suite *ExchangeUnitSuite) TestAddExchangeCommands() {
 				flags.FailedItemsFN,
 				flags.SkippedItemsFN,
 				flags.RecoveredErrorsFN,
+				flags.LatestBackupFN,
+				flags.LegacySizesFN,
 			},
 			expectRunE: listExchangeCmd,
 		},
@@ -81,6 +87,7 @@ func (suite *ExchangeUnitSuite) TestAddExchangeCommands() {
 				flags.EmailFolderFN,
 				flags.EmailReceivedAfterFN,
 				flags.EmailReceivedBeforeFN,
+				flags.EmailRecipientFN,
 				flags.EmailSenderFN,
 				flags.EmailSubjectFN,
 				flags.EventFN,
@@ -98,9 +105,23 @@ func (suite *ExchangeUnitSuite) TestAddExchangeCommands() {
 			deleteCommand,
 			expectUse + " " + exchangeServiceCommandDeleteUseSuffix,
 			exchangeDeleteCmd().Short,
-			[]string{flags.BackupFN},
+			[]string{flags.BackupFN, flags.ForceDeleteFN},
 			deleteExchangeCmd,
 		},
+		{
+			name:        "preview exchange",
+			use:         previewCommand,
+			expectUse:   expectUse + " " + exchangeServiceCommandPreviewUseSuffix,
+			expectShort: exchangePreviewCmd().Short,
+			flags: []string{
+				flags.UserFN,
+				flags.CategoryDataFN,
+				flags.EmailFolderFN,
+				flags.ContactFolderFN,
+				flags.EventCalendarFN,
+			},
+			expectRunE: previewExchangeCmd,
+		},
 	}
 	for _, test := range table {
 		suite.Run(test.name, func() {
@@ -122,6 +143,13 @@ func (suite *ExchangeUnitSuite) TestAddExchangeCommands() {
 	}
 }
 
+func (suite *ExchangeUnitSuite) TestExchangeDataTypes() {
+	assert.Equal(
+		suite.T(),
+		[]string{dataEmail, dataContacts, dataEvents},
+		exchangeDataTypes())
+}
+
 func (suite *ExchangeUnitSuite) TestValidateBackupCreateFlags() {
 	table := []struct {
 		name       string
@@ -274,6 +302,121 @@ func (suite *ExchangeUnitSuite) TestExchangeBackupCreateSelectors() {
 	}
 }
 
+func (suite *ExchangeUnitSuite) TestExchangeBackupPreviewSelectors() {
+	table := []struct {
+		name           string
+		data           []string
+		emailFolders   []string
+		contactFolders []string
+		eventCalendars []string
+		expectCats     []string
+		expectMatch    map[string]string
+		expectNoMatch  map[string]string
+	}{
+		{
+			name: "no data, no folders",
+			expectCats: []string{
+				string(selectors.ExchangeMailFolder),
+				string(selectors.ExchangeContactFolder),
+				string(selectors.ExchangeEventCalendar),
+			},
+			expectMatch: map[string]string{
+				string(selectors.ExchangeMailFolder):    "Inbox/Sub",
+				string(selectors.ExchangeContactFolder): "Contacts",
+				string(selectors.ExchangeEventCalendar): "Calendar",
+			},
+		},
+		{
+			name:         "email folder",
+			data:         []string{dataEmail},
+			emailFolders: []string{"Inbox"},
+			expectCats:   []string{string(selectors.ExchangeMailFolder)},
+			expectMatch: map[string]string{
+				string(selectors.ExchangeMailFolder): "Inbox/Sub",
+			},
+			expectNoMatch: map[string]string{
+				string(selectors.ExchangeMailFolder): "Archive/Inbox",
+			},
+		},
+		{
+			name:           "folders without data",
+			contactFolders: []string{"Work"},
+			eventCalendars: []string{"Holidays"},
+			expectCats: []string{
+				string(selectors.ExchangeMailFolder),
+				string(selectors.ExchangeContactFolder),
+				string(selectors.ExchangeEventCalendar),
+			},
+			expectMatch: map[string]string{
+				string(selectors.ExchangeMailFolder):    "Inbox",
+				string(selectors.ExchangeContactFolder): "Work",
+				string(selectors.ExchangeEventCalendar): "Holidays",
+			},
+			expectNoMatch: map[string]string{
+				string(selectors.ExchangeContactFolder): "Contacts",
+				string(selectors.ExchangeEventCalendar): "Calendar",
+			},
+		},
+		{
+			name:         "folder flags of other data types are ignored",
+			data:         []string{dataContacts},
+			emailFolders: []string{"Inbox"},
+			expectCats:   []string{string(selectors.ExchangeContactFolder)},
+			expectMatch: map[string]string{
+				string(selectors.ExchangeContactFolder): "Contacts",
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			sel := exchangeBackupPreviewSelectors(
+				[]string{"u1"},
+				test.data,
+				test.emailFolders,
+				test.contactFolders,
+				test.eventCalendars)
+
+			scopes := map[string]selectors.ExchangeScope{}
+
+			for _, sc := range sel.Scopes() {
+				scopes[string(sc.Category())] = sc
+			}
+
+			cats := []string{}
+			for cat := range scopes {
+				cats = append(cats, cat)
+			}
+
+			assert.ElementsMatch(t, test.expectCats, cats)
+
+			for cat, loc := range test.expectMatch {
+				assert.True(t, scopeMatchesFolder(scopes[cat], loc), "matches %s %s", cat, loc)
+			}
+
+			for cat, loc := range test.expectNoMatch {
+				assert.False(t, scopeMatchesFolder(scopes[cat], loc), "doesn't match %s %s", cat, loc)
+			}
+		})
+	}
+}
+
+// scopeMatchesFolder checks the location against the folder filter of the
+// scope's category.
+func scopeMatchesFolder(sc selectors.ExchangeScope, loc string) bool {
+	switch sc.Category() {
+	case selectors.ExchangeMailFolder:
+		return sc.Matches(selectors.ExchangeMailFolder, loc)
+	case selectors.ExchangeContactFolder:
+		return sc.Matches(selectors.ExchangeContactFolder, loc)
+	case selectors.ExchangeEventCalendar:
+		return sc.Matches(selectors.ExchangeEventCalendar, loc)
+	default:
+		return false
+	}
+}
+
 func (suite *ExchangeUnitSuite) TestExchangeBackupDetailsSelectors() {
 	for v := 0; v <= version.Backup; v++ {
 		suite.Run(fmt.Sprintf("version%d", v), func() {
@@ -80,6 +80,9 @@ func addGroupsCommands(cmd *cobra.Command) *cobra.Command {
 		flags.AddAzureCredsFlags(c)
 		flags.AddFetchParallelismFlag(c)
 		flags.AddFailFastFlag(c)
+		flags.AddCronFlag(c)
+		flags.AddMetricsAddrFlag(c)
+		flags.AddHealthAddrFlag(c)
 		flags.AddDisableIncrementalsFlag(c)
 		flags.AddForceItemDataDownloadFlag(c)
 
@@ -94,6 +97,8 @@ func addGroupsCommands(cmd *cobra.Command) *cobra.Command {
 		addFailedItemsFN(c)
 		addSkippedItemsFN(c)
 		addRecoveredErrorsFN(c)
+		flags.AddLatestBackupFlag(c)
+		flags.AddLegacySizesFlag(c)
 
 	case detailsCommand:
 		c, fs = utils.AddCommand(cmd, groupsDetailsCmd(), utils.MarkPreviewCommand())
@@ -103,6 +108,7 @@ func addGroupsCommands(cmd *cobra.Command) *cobra.Command {
 		c.Example = groupsServiceCommandDetailsExamples
 
 		flags.AddSkipReduceFlag(c)
+		flags.AddDetailsPaginationFlags(c)
 
 		// Flags addition ordering should follow the order we want them to appear in help and docs:
 		// More generic (ex: --user) and more frequently used flags take precedence.
@@ -121,6 +127,7 @@ func addGroupsCommands(cmd *cobra.Command) *cobra.Command {
 		c.Example = groupsServiceCommandDeleteExamples
 
 		flags.AddBackupIDFlag(c, true)
+		flags.AddForceDeleteFlag(c)
 		flags.AddCorsoPassphaseFlags(c)
 		flags.AddAWSCredsFlags(c)
 		flags.AddAzureCredsFlags(c)
@@ -246,12 +253,14 @@ func detailsGroupsCmd(cmd *cobra.Command, args []string) error {
 		return Only(ctx, err)
 	}
 
-	if len(ds.Entries) == 0 {
+	dm := pageDetails(ds.DetailsModel)
+
+	if len(dm.Entries) == 0 {
 		Info(ctx, selectors.ErrorNoMatchingItems)
 		return nil
 	}
 
-	ds.PrintEntries(ctx)
+	dm.PrintEntries(ctx)
 
 	return nil
 }
@@ -40,6 +40,9 @@ func (suite *GroupsUnitSuite) TestAddGroupsCommands() {
 			[]string{
 				flags.CategoryDataFN,
 				flags.FailFastFN,
+				flags.CronFN,
+				flags.MetricsAddrFN,
+				flags.HealthAddrFN,
 				flags.FetchParallelismFN,
 				flags.SkipReduceFN,
 				flags.NoStatsFN,
@@ -58,6 +61,8 @@ func (suite *GroupsUnitSuite) TestAddGroupsCommands() {
 				flags.FailedItemsFN,
 				flags.SkippedItemsFN,
 				flags.RecoveredErrorsFN,
+				flags.LatestBackupFN,
+				flags.LegacySizesFN,
 			},
 			listGroupsCmd,
 		},
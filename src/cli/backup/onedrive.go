@@ -76,6 +76,9 @@ func addOneDriveCommands(cmd *cobra.Command) *cobra.Command {
 		flags.AddAzureCredsFlags(c)
 
 		flags.AddFailFastFlag(c)
+		flags.AddCronFlag(c)
+		flags.AddMetricsAddrFlag(c)
+		flags.AddHealthAddrFlag(c)
 		flags.AddDisableIncrementalsFlag(c)
 		flags.AddForceItemDataDownloadFlag(c)
 
@@ -90,6 +93,8 @@ func addOneDriveCommands(cmd *cobra.Command) *cobra.Command {
 		addFailedItemsFN(c)
 		addSkippedItemsFN(c)
 		addRecoveredErrorsFN(c)
+		flags.AddLatestBackupFlag(c)
+		flags.AddLegacySizesFlag(c)
 
 	case detailsCommand:
 		c, fs = utils.AddCommand(cmd, oneDriveDetailsCmd())
@@ -99,6 +104,7 @@ func addOneDriveCommands(cmd *cobra.Command) *cobra.Command {
 		c.Example = oneDriveServiceCommandDetailsExamples
 
 		flags.AddSkipReduceFlag(c)
+		flags.AddDetailsPaginationFlags(c)
 		flags.AddBackupIDFlag(c, true)
 		flags.AddCorsoPassphaseFlags(c)
 		flags.AddAWSCredsFlags(c)
@@ -113,6 +119,7 @@ func addOneDriveCommands(cmd *cobra.Command) *cobra.Command {
 		c.Example = oneDriveServiceCommandDeleteExamples
 
 		flags.AddBackupIDFlag(c, true)
+		flags.AddForceDeleteFlag(c)
 		flags.AddCorsoPassphaseFlags(c)
 		flags.AddAWSCredsFlags(c)
 		flags.AddAzureCredsFlags(c)
@@ -252,12 +259,14 @@ func detailsOneDriveCmd(cmd *cobra.Command, args []string) error {
 		return Only(ctx, err)
 	}
 
-	if len(ds.Entries) == 0 {
+	dm := pageDetails(ds.DetailsModel)
+
+	if len(dm.Entries) == 0 {
 		Info(ctx, selectors.ErrorNoMatchingItems)
 		return nil
 	}
 
-	ds.PrintEntries(ctx)
+	dm.PrintEntries(ctx)
 
 	return nil
 }
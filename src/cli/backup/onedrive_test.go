@@ -45,6 +45,9 @@ func (suite *OneDriveUnitSuite) TestAddOneDriveCommands() {
 				flags.UserFN,
 				flags.DisableIncrementalsFN,
 				flags.FailFastFN,
+				flags.CronFN,
+				flags.MetricsAddrFN,
+				flags.HealthAddrFN,
 			},
 			createOneDriveCmd,
 		},
@@ -58,6 +61,8 @@ func (suite *OneDriveUnitSuite) TestAddOneDriveCommands() {
 				flags.FailedItemsFN,
 				flags.SkippedItemsFN,
 				flags.RecoveredErrorsFN,
+				flags.LatestBackupFN,
+				flags.LegacySizesFN,
 			},
 			listOneDriveCmd,
 		},
@@ -74,6 +79,10 @@ func (suite *OneDriveUnitSuite) TestAddOneDriveCommands() {
 				flags.FileCreatedBeforeFN,
 				flags.FileModifiedAfterFN,
 				flags.FileModifiedBeforeFN,
+				flags.FilterPathFN,
+				flags.LimitFN,
+				flags.OffsetFN,
+				flags.SearchFN,
 			},
 			detailsOneDriveCmd,
 		},
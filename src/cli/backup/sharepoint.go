@@ -86,6 +86,9 @@ func addSharePointCommands(cmd *cobra.Command) *cobra.Command {
 		flags.AddAzureCredsFlags(c)
 		flags.AddDataFlag(c, []string{flags.DataLibraries}, true)
 		flags.AddFailFastFlag(c)
+		flags.AddCronFlag(c)
+		flags.AddMetricsAddrFlag(c)
+		flags.AddHealthAddrFlag(c)
 		flags.AddDisableIncrementalsFlag(c)
 		flags.AddForceItemDataDownloadFlag(c)
 
@@ -100,6 +103,8 @@ func addSharePointCommands(cmd *cobra.Command) *cobra.Command {
 		addFailedItemsFN(c)
 		addSkippedItemsFN(c)
 		addRecoveredErrorsFN(c)
+		flags.AddLatestBackupFlag(c)
+		flags.AddLegacySizesFlag(c)
 
 	case detailsCommand:
 		c, fs = utils.AddCommand(cmd, sharePointDetailsCmd())
@@ -109,6 +114,7 @@ func addSharePointCommands(cmd *cobra.Command) *cobra.Command {
 		c.Example = sharePointServiceCommandDetailsExamples
 
 		flags.AddSkipReduceFlag(c)
+		flags.AddDetailsPaginationFlags(c)
 		flags.AddBackupIDFlag(c, true)
 		flags.AddCorsoPassphaseFlags(c)
 		flags.AddAWSCredsFlags(c)
@@ -123,6 +129,7 @@ func addSharePointCommands(cmd *cobra.Command) *cobra.Command {
 		c.Example = sharePointServiceCommandDeleteExamples
 
 		flags.AddBackupIDFlag(c, true)
+		flags.AddForceDeleteFlag(c)
 		flags.AddCorsoPassphaseFlags(c)
 		flags.AddAWSCredsFlags(c)
 		flags.AddAzureCredsFlags(c)
@@ -336,12 +343,14 @@ func detailsSharePointCmd(cmd *cobra.Command, args []string) error {
 		return Only(ctx, err)
 	}
 
-	if len(ds.Entries) == 0 {
+	dm := pageDetails(ds.DetailsModel)
+
+	if len(dm.Entries) == 0 {
 		Info(ctx, selectors.ErrorNoMatchingItems)
 		return nil
 	}
 
-	ds.PrintEntries(ctx)
+	dm.PrintEntries(ctx)
 
 	return nil
 }
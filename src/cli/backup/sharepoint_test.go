@@ -47,6 +47,9 @@ func (suite *SharePointUnitSuite) TestAddSharePointCommands() {
 				flags.SiteFN,
 				flags.DisableIncrementalsFN,
 				flags.FailFastFN,
+				flags.CronFN,
+				flags.MetricsAddrFN,
+				flags.HealthAddrFN,
 			},
 			createSharePointCmd,
 		},
@@ -60,6 +63,8 @@ func (suite *SharePointUnitSuite) TestAddSharePointCommands() {
 				flags.FailedItemsFN,
 				flags.SkippedItemsFN,
 				flags.RecoveredErrorsFN,
+				flags.LatestBackupFN,
+				flags.LegacySizesFN,
 			},
 			listSharePointCmd,
 		},
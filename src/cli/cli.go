@@ -70,10 +70,11 @@ func preRun(cc *cobra.Command, args []string) error {
 		"Help about any command",
 		"Free, Secure, Open-Source Backup for M365.",
 		"env var guide",
+		"Inspect your Corso configuration",
 	}
 
 	if !slices.Contains(avoidTheseDescription, cc.Short) {
-		provider, overrides, err := utils.GetStorageProviderAndOverrides(ctx, cc)
+		provider, overrides, err := config.GetStorageProviderAndOverrides(ctx, cc)
 		if err != nil {
 			return err
 		}
@@ -163,6 +164,7 @@ func BuildCommandTree(cmd *cobra.Command) {
 	backup.AddCommands(cmd)
 	restore.AddCommands(cmd)
 	export.AddCommands(cmd)
+	config.AddCommands(cmd)
 	help.AddCommands(cmd)
 }
 
@@ -69,12 +69,24 @@ func init() {
 	}
 }
 
+// ScheduleStatePath returns the location of the file that records when
+// scheduled backups last ran.  The file sits alongside the config file.
+func ScheduleStatePath() string {
+	fp := configFilePathFlag
+	if len(fp) == 0 || fp == displayDefaultFP {
+		fp = configFilePath
+	}
+
+	return filepath.Join(filepath.Dir(fp), ".corso_schedule.json")
+}
+
 // adds the persistent flag --config-file to the provided command.
 func AddConfigFlags(cmd *cobra.Command) {
 	fs := cmd.PersistentFlags()
 	fs.StringVar(
 		&configFilePathFlag,
-		"config-file", displayDefaultFP, "config file location")
+		"config-file", displayDefaultFP,
+		"config file location; the file extension (ex: toml, json) sets the format")
 }
 
 // ---------------------------------------------------------------------------------------------------------
@@ -382,6 +382,185 @@ func (suite *ConfigSuite) TestReadFromFlags() {
 	assert.Equal(t, flags.CorsoPassphraseFV, pass)
 }
 
+func (suite *ConfigSuite) TestGetStorageAndAccount_explicitFilePrecedence() {
+	const (
+		fileVal = "file"
+		envVal  = "env"
+		flagVal = "flag"
+	)
+
+	fileCfg := map[string]string{
+		storage.BucketNameKey:          "bucket",
+		storage.StorageProviderTypeKey: storage.ProviderS3.String(),
+		account.AccountProviderTypeKey: account.ProviderM365.String(),
+		account.AzureTenantIDKey:       fileVal,
+		account.AzureClientID:          fileVal,
+		account.AzureSecret:            fileVal,
+		storage.AccessKey:              fileVal,
+		storage.SecretAccessKey:        fileVal,
+		CorsoPassphrase:                fileVal,
+	}
+
+	envs := []string{
+		account.AzureTenantID,
+		credentials.AzureClientID,
+		credentials.AzureClientSecret,
+		credentials.AWSAccessKeyID,
+		credentials.AWSSecretAccessKey,
+		credentials.AWSSessionToken,
+		credentials.CorsoPassphrase,
+	}
+
+	table := []struct {
+		name      string
+		ext       string
+		withEnv   bool
+		withFlags bool
+		expect    string
+	}{
+		{
+			name:   "toml file",
+			ext:    ".toml",
+			expect: fileVal,
+		},
+		{
+			name:   "json file",
+			ext:    ".json",
+			expect: fileVal,
+		},
+		{
+			name:    "env over file",
+			ext:     ".json",
+			withEnv: true,
+			expect:  envVal,
+		},
+		{
+			name:      "flag over env and file",
+			ext:       ".toml",
+			withEnv:   true,
+			withFlags: true,
+			expect:    flagVal,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			t.Cleanup(func() {
+				flags.AzureClientTenantFV = ""
+				flags.AzureClientIDFV = ""
+				flags.AzureClientSecretFV = ""
+				flags.CorsoPassphraseFV = ""
+			})
+
+			for _, env := range envs {
+				v := ""
+				if test.withEnv {
+					v = envVal
+				}
+
+				t.Setenv(env, v)
+			}
+
+			overrides := map[string]string{}
+
+			if test.withFlags {
+				flags.AzureClientTenantFV = flagVal
+				flags.AzureClientIDFV = flagVal
+				flags.AzureClientSecretFV = flagVal
+				flags.CorsoPassphraseFV = flagVal
+
+				overrides[credentials.AWSAccessKeyID] = flagVal
+				overrides[credentials.AWSSecretAccessKey] = flagVal
+			}
+
+			vpr := viper.New()
+			fp := filepath.Join(t.TempDir(), "repo"+test.ext)
+
+			err := initWithViper(vpr, fp)
+			require.NoError(t, err, clues.ToCore(err))
+
+			for k, v := range fileCfg {
+				vpr.Set(k, v)
+			}
+
+			err = vpr.WriteConfigAs(fp)
+			require.NoError(t, err, clues.ToCore(err))
+
+			// read the file with a fresh viper, so that no values
+			// linger from writing it.
+			vpr = viper.New()
+
+			err = initWithViper(vpr, fp)
+			require.NoError(t, err, clues.ToCore(err))
+
+			rd, err := getStorageAndAccountWithViper(vpr, storage.ProviderS3, true, false, overrides)
+			require.NoError(t, err, clues.ToCore(err))
+
+			m365, err := rd.Account.M365Config()
+			require.NoError(t, err, clues.ToCore(err))
+
+			assert.Equal(t, test.expect, m365.AzureTenantID, "tenant id")
+			assert.Equal(t, test.expect, m365.AzureClientID, "client id")
+			assert.Equal(t, test.expect, m365.AzureClientSecret, "client secret")
+
+			sc, err := rd.Storage.StorageConfig()
+			require.NoError(t, err, clues.ToCore(err))
+
+			s3Cfg := sc.(*storage.S3Config)
+			assert.Equal(t, "bucket", s3Cfg.Bucket, "bucket")
+			assert.Equal(t, test.expect, s3Cfg.AccessKey, "access key")
+			assert.Equal(t, test.expect, s3Cfg.SecretKey, "secret key")
+
+			cc, err := rd.Storage.CommonConfig()
+			require.NoError(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expect, cc.Corso.CorsoPassphrase, "passphrase")
+		})
+	}
+}
+
+func (suite *ConfigSuite) TestGetStorageAndAccount_missingExplicitFile() {
+	t := suite.T()
+
+	t.Setenv(credentials.CorsoPassphrase, "")
+
+	t.Cleanup(func() {
+		flags.AzureClientTenantFV = ""
+		flags.AzureClientIDFV = ""
+		flags.AzureClientSecretFV = ""
+		flags.CorsoPassphraseFV = ""
+	})
+
+	flags.AzureClientTenantFV = "flag-tenant"
+	flags.AzureClientIDFV = "flag-client"
+	flags.AzureClientSecretFV = "flag-secret"
+	flags.CorsoPassphraseFV = "flag-passphrase"
+
+	vpr := viper.New()
+
+	err := initWithViper(vpr, filepath.Join(t.TempDir(), "missing.toml"))
+	require.NoError(t, err, clues.ToCore(err))
+
+	// a file that doesn't exist yet, such as on repo init, falls
+	// back to the flag and env values.
+	rd, err := getStorageAndAccountWithViper(
+		vpr,
+		storage.ProviderS3,
+		true,
+		false,
+		map[string]string{
+			storage.Bucket:                 "flag-bucket",
+			credentials.AWSAccessKeyID:     "flag-access",
+			credentials.AWSSecretAccessKey: "flag-secret",
+		})
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Empty(t, rd.RepoID)
+
+	m365, err := rd.Account.M365Config()
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, "flag-tenant", m365.AzureTenantID)
+}
+
 // ------------------------------------------------------------
 // integration tests
 // ------------------------------------------------------------
@@ -0,0 +1,174 @@
+package config
+
+import (
+	"strconv"
+
+	"github.com/alcionai/clues"
+	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	. "github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/pkg/account"
+	"github.com/alcionai/corso/src/pkg/storage"
+)
+
+// AddCommands attaches all `corso config * *` commands to the parent.
+func AddCommands(cmd *cobra.Command) {
+	configC := configCmd()
+	cmd.AddCommand(configC)
+
+	c := showCmd()
+	configC.AddCommand(c)
+
+	fs := c.Flags()
+	fs.SortFlags = false
+
+	flags.AddCorsoPassphaseFlags(c)
+	flags.AddAWSCredsFlags(c)
+	flags.AddAzureCredsFlags(c)
+}
+
+// The config command.
+// `corso config [<subcommand>] [<flag>...]`
+func configCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "config",
+		Short: "Inspect your Corso configuration",
+		RunE:  handleConfigCmd,
+		Args:  cobra.NoArgs,
+	}
+}
+
+// Handler for flat calls to `corso config`.
+// Produces the same output as `corso config --help`.
+func handleConfigCmd(cmd *cobra.Command, args []string) error {
+	return cmd.Help()
+}
+
+// The config show subcommand.
+// `corso config show [<flag>...]`
+func showCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show",
+		Short: "Shows the resolved storage and account configuration",
+		Long: `Shows the storage and account configuration that commands use to connect
+to the repository.  Flags take precedence over environment variables, which
+take precedence over the config file.  Secrets are masked; an empty value
+means the secret isn't set.`,
+		RunE: showConfigCmd,
+		Args: cobra.NoArgs,
+	}
+}
+
+// prints the resolved configuration.
+func showConfigCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	provider, overrides, err := GetStorageProviderAndOverrides(ctx, cmd)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	rd, err := GetConfigRepoDetails(ctx, provider, true, false, overrides)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	cvs, err := resolvedConfigValues(rd)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	Infof(ctx, "Config file: %s", GetViper(ctx).ConfigFileUsed())
+
+	ps := make([]Printable, 0, len(cvs))
+	for _, cv := range cvs {
+		ps = append(ps, cv)
+	}
+
+	All(ctx, ps...)
+
+	return nil
+}
+
+// configValue is a single, resolved configuration setting.
+type configValue struct {
+	Setting string `json:"setting"`
+	Value   string `json:"value"`
+}
+
+// interface compliance check
+var _ Printable = configValue{}
+
+func (cv configValue) MinimumPrintable() any {
+	return cv
+}
+
+func (cv configValue) Headers() []string {
+	return []string{"Setting", "Value"}
+}
+
+func (cv configValue) Values() []string {
+	return []string{cv.Setting, cv.Value}
+}
+
+// hidden masks secret values, regardless of the configured clues hashing,
+// so that secrets never get printed.  Empty values stay empty, so that
+// unset secrets can be told apart from set ones.
+func hidden(v string) string {
+	return clues.ConcealWith(clues.Flatmask, v)
+}
+
+// resolvedConfigValues lists the settings of the repo details, keyed by
+// their config file names, with secrets masked.
+func resolvedConfigValues(rd RepoDetails) ([]configValue, error) {
+	cvs := []configValue{
+		{RepoID, rd.RepoID},
+		{CorsoUser, rd.RepoUser},
+		{CorsoHost, rd.RepoHost},
+		{storage.StorageProviderTypeKey, rd.Storage.Provider.String()},
+	}
+
+	sc, err := rd.Storage.StorageConfig()
+	if err != nil {
+		return nil, clues.Wrap(err, "reading storage configuration")
+	}
+
+	switch cfg := sc.(type) {
+	case *storage.S3Config:
+		cvs = append(
+			cvs,
+			configValue{storage.BucketNameKey, cfg.Bucket},
+			configValue{storage.EndpointKey, cfg.Endpoint},
+			configValue{storage.PrefixKey, cfg.Prefix},
+			configValue{storage.DisableTLSKey, strconv.FormatBool(cfg.DoNotUseTLS)},
+			configValue{storage.DisableTLSVerificationKey, strconv.FormatBool(cfg.DoNotVerifyTLS)},
+			configValue{storage.CABundlePathKey, cfg.CABundlePath},
+			configValue{storage.AccessKey, hidden(cfg.AccessKey)},
+			configValue{storage.SecretAccessKey, hidden(cfg.SecretKey)},
+			configValue{storage.SessionToken, hidden(cfg.SessionToken)})
+	case *storage.FilesystemConfig:
+		cvs = append(cvs, configValue{storage.FilesystemPath, cfg.Path})
+	}
+
+	cc, err := rd.Storage.CommonConfig()
+	if err != nil {
+		return nil, clues.Wrap(err, "reading common storage configuration")
+	}
+
+	cvs = append(cvs, configValue{CorsoPassphrase, hidden(cc.CorsoPassphrase)})
+
+	m365, err := rd.Account.M365Config()
+	if err != nil {
+		return nil, clues.Wrap(err, "reading account configuration")
+	}
+
+	cvs = append(
+		cvs,
+		configValue{account.AccountProviderTypeKey, rd.Account.Provider.String()},
+		configValue{account.AzureTenantIDKey, m365.AzureTenantID},
+		configValue{account.AzureClientID, m365.AzureClientID},
+		configValue{account.AzureSecret, hidden(m365.AzureClientSecret)})
+
+	return cvs, nil
+}
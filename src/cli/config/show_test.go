@@ -0,0 +1,126 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/account"
+	"github.com/alcionai/corso/src/pkg/credentials"
+	"github.com/alcionai/corso/src/pkg/storage"
+)
+
+type ShowUnitSuite struct {
+	tester.Suite
+}
+
+func TestShowUnitSuite(t *testing.T) {
+	suite.Run(t, &ShowUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *ShowUnitSuite) TestResolvedConfigValues() {
+	var (
+		t   = suite.T()
+		vpr = viper.New()
+	)
+
+	const (
+		fileBucket     = "file-bucket"
+		tenant         = "6f34ac30-8196-469b-bf8f-d83deadbbbba"
+		fileAccessKey  = "file-access-key"
+		fileSecret     = "file-secret-key"
+		fileToken      = "file-session-token"
+		filePassphrase = "file-passphrase"
+		clientID       = "file-client-id"
+		fileAzSecret   = "file-azure-secret"
+		envPassphrase  = "env-passphrase"
+		envAzSecret    = "env-azure-secret"
+		flagAzSecret   = "flag-azure-secret"
+		flagSecret     = "flag-secret-key"
+		flagPrefix     = "flag-prefix/"
+	)
+
+	t.Cleanup(func() {
+		flags.AzureClientSecretFV = ""
+	})
+
+	testConfigData := fmt.Sprintf(configFileTemplate, fileBucket, tenant, fileAccessKey, fileSecret, fileToken,
+		filePassphrase, clientID, fileAzSecret, "false", "false")
+
+	testConfigFilePath := filepath.Join(t.TempDir(), "corso.toml")
+	err := os.WriteFile(testConfigFilePath, []byte(testConfigData), 0o700)
+	require.NoError(t, err, clues.ToCore(err))
+
+	vpr.SetConfigFile(testConfigFilePath)
+
+	// env vars override the config file, and flags override env vars.
+	t.Setenv(credentials.CorsoPassphrase, envPassphrase)
+	t.Setenv(credentials.AzureClientSecret, envAzSecret)
+
+	flags.AzureClientSecretFV = flagAzSecret
+
+	overrides := map[string]string{
+		storage.Prefix:                 flagPrefix,
+		credentials.AWSSecretAccessKey: flagSecret,
+	}
+
+	rd, err := getStorageAndAccountWithViper(vpr, storage.ProviderS3, true, false, overrides)
+	require.NoError(t, err, clues.ToCore(err))
+
+	cvs, err := resolvedConfigValues(rd)
+	require.NoError(t, err, clues.ToCore(err))
+
+	values := map[string]string{}
+	for _, cv := range cvs {
+		values[cv.Setting] = cv.Value
+	}
+
+	expect := map[string]string{
+		storage.StorageProviderTypeKey:    storage.ProviderS3.String(),
+		storage.BucketNameKey:             fileBucket,
+		storage.EndpointKey:               "s3.amazonaws.com",
+		storage.PrefixKey:                 flagPrefix,
+		storage.DisableTLSKey:             "false",
+		storage.DisableTLSVerificationKey: "false",
+		storage.AccessKey:                 "***",
+		storage.SecretAccessKey:           "***",
+		storage.SessionToken:              "***",
+		CorsoPassphrase:                   "***",
+		account.AccountProviderTypeKey:    account.ProviderM365.String(),
+		account.AzureTenantIDKey:          tenant,
+		account.AzureClientID:             clientID,
+		account.AzureSecret:               "***",
+	}
+
+	for k, v := range expect {
+		assert.Equal(t, v, values[k], k)
+	}
+
+	// no secret, from any source, gets printed in plain text.
+	secrets := []string{
+		fileAccessKey, fileSecret, fileToken, filePassphrase, fileAzSecret,
+		envPassphrase, envAzSecret, flagAzSecret, flagSecret,
+	}
+
+	for _, cv := range cvs {
+		for _, s := range secrets {
+			assert.NotContains(t, cv.Value, s, cv.Setting)
+		}
+	}
+}
+
+func (suite *ShowUnitSuite) TestHidden() {
+	t := suite.T()
+
+	assert.Empty(t, hidden(""), "unset secrets stay empty")
+	assert.Equal(t, "***", hidden("secret"))
+}
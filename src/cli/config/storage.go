@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 
 	"github.com/alcionai/clues"
+	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	"github.com/alcionai/corso/src/cli/flags"
@@ -98,3 +99,24 @@ func GetStorageProviderFromConfigFile(ctx context.Context) (storage.ProviderType
 
 	return storage.StringToProviderType[provider], nil
 }
+
+// GetStorageProviderAndOverrides returns the storage provider type and
+// any flags specified on the command line which are storage provider specific.
+func GetStorageProviderAndOverrides(
+	ctx context.Context,
+	cmd *cobra.Command,
+) (storage.ProviderType, map[string]string, error) {
+	provider, err := GetStorageProviderFromConfigFile(ctx)
+	if err != nil {
+		return provider, nil, clues.Stack(err)
+	}
+
+	switch provider {
+	case storage.ProviderS3:
+		return provider, flags.S3FlagOverrides(cmd), nil
+	case storage.ProviderFilesystem:
+		return provider, flags.FilesystemFlagOverrides(cmd), nil
+	}
+
+	return provider, nil, clues.New("unknown storage provider: " + provider.String())
+}
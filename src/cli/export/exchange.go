@@ -0,0 +1,98 @@
+package export
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	"github.com/alcionai/corso/src/cli/utils"
+)
+
+// called by export.go to map subcommands to provider-specific handling.
+func addExchangeCommands(cmd *cobra.Command) *cobra.Command {
+	var (
+		c  *cobra.Command
+		fs *pflag.FlagSet
+	)
+
+	switch cmd.Use {
+	case exportCommand:
+		c, fs = utils.AddCommand(cmd, exchangeExportCmd(), utils.MarkPreviewCommand())
+
+		c.Use = c.Use + " " + exchangeServiceCommandUseSuffix
+
+		// Flags addition ordering should follow the order we want them to appear in help and docs:
+		// More generic (ex: --user) and more frequently used flags take precedence.
+		fs.SortFlags = false
+
+		flags.AddBackupIDFlag(c, true)
+		flags.AddExchangeDetailsAndRestoreFlags(c)
+		flags.AddExportConfigFlags(c)
+		flags.AddFailFastFlag(c)
+		flags.AddCorsoPassphaseFlags(c)
+		flags.AddAWSCredsFlags(c)
+	}
+
+	return c
+}
+
+const (
+	exchangeServiceCommand          = "exchange"
+	exchangeServiceCommandUseSuffix = "<destination> --backup <backupId>"
+
+	//nolint:lll
+	exchangeServiceCommandExportExamples = `# Export emails with ID 98765abcdef and 12345abcdef from Alice's last backup (1234abcd...) to /my-exports
+corso export exchange my-exports --backup 1234abcd-12ab-cd34-56de-1234abcd --email 98765abcdef,12345abcdef
+
+# Export all emails in the "Inbox" to the current directory as .eml files
+corso export exchange . --backup 1234abcd-12ab-cd34-56de-1234abcd --email-folder Inbox
+
+# Export all emails to /my-exports as a maildir tree that mirrors the mail folders
+corso export exchange my-exports --backup 1234abcd-12ab-cd34-56de-1234abcd --format maildir`
+)
+
+// `corso export exchange [<flag>...] <destination>`
+func exchangeExportCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   exchangeServiceCommand,
+		Short: "Export M365 Exchange service data",
+		RunE:  exportExchangeCmd,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("missing export destination")
+			}
+
+			return nil
+		},
+		Example: exchangeServiceCommandExportExamples,
+	}
+}
+
+// processes an exchange service export.
+func exportExchangeCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if utils.HasNoFlagsAndShownHelp(cmd) {
+		return nil
+	}
+
+	opts := utils.MakeExchangeOpts(cmd)
+
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	if err := utils.ValidateExchangeRestoreFlags(flags.BackupIDFV, opts); err != nil {
+		return err
+	}
+
+	if len(opts.Contact)+len(opts.ContactFolder)+len(opts.Event)+len(opts.EventCalendar) > 0 {
+		return errors.New("only emails can be exported from exchange")
+	}
+
+	sel := utils.IncludeExchangeExportDataSelectors(opts)
+	utils.FilterExchangeRestoreInfoSelectors(sel, opts)
+
+	return runExport(ctx, cmd, args, opts.ExportCfg, sel.Selector, flags.BackupIDFV, "Exchange")
+}
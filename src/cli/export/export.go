@@ -1,8 +1,11 @@
 package export
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"os"
+	"strings"
 
 	"github.com/alcionai/clues"
 	"github.com/spf13/cobra"
@@ -13,11 +16,14 @@ import (
 	"github.com/alcionai/corso/src/internal/data"
 	"github.com/alcionai/corso/src/internal/observe"
 	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
 	"github.com/alcionai/corso/src/pkg/export"
 	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/storage"
 )
 
 var exportCommands = []func(cmd *cobra.Command) *cobra.Command{
+	addExchangeCommands,
 	addOneDriveCommands,
 	addSharePointCommands,
 	addGroupsCommands,
@@ -61,7 +67,7 @@ func runExport(
 	sel selectors.Selector,
 	backupID, serviceName string,
 ) error {
-	r, _, _, _, err := utils.GetAccountAndConnectWithOverrides(
+	r, st, _, _, err := utils.GetAccountAndConnectWithOverrides(
 		ctx,
 		cmd,
 		sel.PathService())
@@ -77,6 +83,11 @@ func runExport(
 		exportLocation = control.DefaultRestoreLocation + dttm.FormatNow(dttm.HumanReadableDriveItem)
 	}
 
+	sink, err := exportSink(ctx, *st, exportLocation)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
 	Infof(ctx, "Exporting to folder %s", exportLocation)
 
 	eo, err := r.NewExport(
@@ -88,6 +99,13 @@ func runExport(
 		return Only(ctx, clues.Wrap(err, "Failed to initialize "+serviceName+" export"))
 	}
 
+	if len(ueco.SinceManifest) > 0 {
+		eo.PreviousManifest, err = readExportManifest(ueco.SinceManifest)
+		if err != nil {
+			return Only(ctx, err)
+		}
+	}
+
 	expColl, err := eo.Run(ctx)
 	if err != nil {
 		if errors.Is(err, data.ErrNotFound) {
@@ -99,13 +117,84 @@ func runExport(
 
 	// It would be better to give a progressbar than a spinner, but we
 	// have any way of knowing how many files are available as of now.
-	diskWriteComplete := observe.MessageWithCompletion(ctx, "Writing data to disk")
-	defer close(diskWriteComplete)
+	writeComplete := observe.MessageWithCompletion(ctx, "Writing exported data")
+	defer close(writeComplete)
 
-	err = export.ConsumeExportCollections(ctx, exportLocation, expColl, eo.Errors)
+	err = export.ConsumeExportCollectionsToSink(ctx, sink, expColl, eo.Errors)
 	if err != nil {
 		return Only(ctx, err)
 	}
 
+	if err := writeExportManifest(ctx, sink, eo.Manifest); err != nil {
+		return Only(ctx, err)
+	}
+
+	if eo.PreviousManifest != nil {
+		Infof(
+			ctx,
+			"Skipped %d items unchanged since the previous export",
+			eo.Counter.Get(count.ExportItemUnchanged))
+	}
+
+	if len(ueco.ExcludeExtensions) > 0 {
+		Infof(
+			ctx,
+			"Excluded %d items with the extensions: %s",
+			eo.Counter.Get(count.ExportItemExcluded),
+			strings.Join(ueco.ExcludeExtensions, ", "))
+	}
+
+	return nil
+}
+
+func readExportManifest(fpath string) (*export.Manifest, error) {
+	f, err := os.Open(fpath)
+	if err != nil {
+		return nil, clues.Wrap(err, "opening export manifest").With("manifest_path", fpath)
+	}
+
+	defer f.Close()
+
+	return export.ReadManifest(f)
+}
+
+func writeExportManifest(ctx context.Context, sink export.Sink, m *export.Manifest) error {
+	buf := &bytes.Buffer{}
+
+	if err := m.Write(buf); err != nil {
+		return clues.Wrap(err, "creating export manifest")
+	}
+
+	if err := sink.Write(ctx, "", export.ManifestFileName, buf); err != nil {
+		return clues.Wrap(err, "writing export manifest")
+	}
+
 	return nil
 }
+
+// s3DestinationScheme marks export destinations that are s3 buckets,
+// such as s3://bucket/prefix.
+const s3DestinationScheme = "s3://"
+
+// exportSink produces the sink for the export destination.  Destinations
+// using the s3:// scheme are streamed into that bucket using the
+// credentials of the repository's storage, while everything else is a
+// directory on local disk.
+func exportSink(
+	ctx context.Context,
+	st storage.Storage,
+	exportLocation string,
+) (export.Sink, error) {
+	if !strings.HasPrefix(exportLocation, s3DestinationScheme) {
+		return export.NewFilesystemSink(exportLocation), nil
+	}
+
+	bucket, prefix, _ := strings.Cut(strings.TrimPrefix(exportLocation, s3DestinationScheme), "/")
+
+	sink, err := export.NewS3Sink(ctx, st, bucket, prefix)
+	if err != nil {
+		return nil, clues.Wrap(err, "preparing s3 export destination")
+	}
+
+	return sink, nil
+}
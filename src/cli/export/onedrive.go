@@ -51,7 +51,10 @@ corso export onedrive . --backup 1234abcd-12ab-cd34-56de-1234abcd \
 
 # Export all files and folders in folder "Documents/Finance Reports" that were created before 2020 to /my-exports
 corso export onedrive my-exports --backup 1234abcd-12ab-cd34-56de-1234abcd \
-    --folder "Documents/Finance Reports" --file-created-before 2020-01-01T00:00:00`
+    --folder "Documents/Finance Reports" --file-created-before 2020-01-01T00:00:00
+
+# Export all files in Bob's last backup into the "exports" prefix of the my-bucket S3 bucket
+corso export onedrive s3://my-bucket/exports --backup 1234abcd-12ab-cd34-56de-1234abcd`
 )
 
 // `corso export onedrive [<flag>...] <destination>`
@@ -0,0 +1,45 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+)
+
+const (
+	FilterPathFN = "filter-path"
+	LimitFN      = "limit"
+	OffsetFN     = "offset"
+	SearchFN     = "search"
+)
+
+var (
+	FilterPathFV string
+	LimitFV      int
+	OffsetFV     int
+	SearchFV     string
+)
+
+// AddDetailsPaginationFlags adds the flags used to window and filter
+// the entries printed by the backup details commands.
+func AddDetailsPaginationFlags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+	fs.StringVar(
+		&FilterPathFV,
+		FilterPathFN,
+		"",
+		"Only show entries whose repo ref or location ref begins with the given prefix")
+	fs.StringVar(
+		&SearchFV,
+		SearchFN,
+		"",
+		"Only show entries whose item name or path contains the given text, ignoring case")
+	fs.IntVar(
+		&OffsetFV,
+		OffsetFN,
+		0,
+		"Skip the first N entries before printing")
+	fs.IntVar(
+		&LimitFV,
+		LimitFN,
+		0,
+		"Print at most N entries. A value of 0 prints all remaining entries")
+}
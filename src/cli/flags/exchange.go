@@ -13,6 +13,7 @@ const (
 	EmailFolderFN         = "email-folder"
 	EmailReceivedAfterFN  = "email-received-after"
 	EmailReceivedBeforeFN = "email-received-before"
+	EmailRecipientFN      = "email-recipient"
 	EmailSenderFN         = "email-sender"
 	EmailSubjectFN        = "email-subject"
 
@@ -35,6 +36,7 @@ var (
 	EmailFolderFV         []string
 	EmailReceivedAfterFV  string
 	EmailReceivedBeforeFV string
+	EmailRecipientFV      string
 	EmailSenderFV         string
 	EmailSubjectFV        string
 
@@ -69,6 +71,10 @@ func AddExchangeDetailsAndRestoreFlags(cmd *cobra.Command) {
 		&EmailSenderFV,
 		EmailSenderFN, "",
 		"Select emails from a specific sender.")
+	fs.StringVar(
+		&EmailRecipientFV,
+		EmailRecipientFN, "",
+		"Select emails sent to a specific recipient.")
 	fs.StringVar(
 		&EmailReceivedAfterFV,
 		EmailReceivedAfterFN, "",
@@ -122,3 +128,22 @@ func AddExchangeDetailsAndRestoreFlags(cmd *cobra.Command) {
 		ContactNameFN, "",
 		"Select contacts whose contact name contains this value.")
 }
+
+// AddExchangeFolderFlags adds the flags that narrow a backup preview to
+// specific exchange folders.
+func AddExchangeFolderFlags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+
+	fs.StringSliceVar(
+		&EmailFolderFV,
+		EmailFolderFN, nil,
+		"Select email folders, and their subfolders, by their path from the mailbox root.")
+	fs.StringSliceVar(
+		&ContactFolderFV,
+		ContactFolderFN, nil,
+		"Select contact folders, and their subfolders, by their path from the mailbox root.")
+	fs.StringSliceVar(
+		&EventCalendarFV,
+		EventCalendarFN, nil,
+		"Select calendars by name.")
+}
@@ -11,13 +11,21 @@ import (
 )
 
 const (
-	ArchiveFN = "archive"
-	FormatFN  = "format"
+	ArchiveFN            = "archive"
+	ExcludeExtensionsFN  = "exclude-extensions"
+	FormatFN             = "format"
+	IncludeErrorReportFN = "include-error-report"
+	PackageByFolderFN    = "package-by-folder"
+	SinceManifestFN      = "since-manifest"
 )
 
 var (
-	ArchiveFV bool
-	FormatFV  string
+	ArchiveFV            bool
+	ExcludeExtensionsFV  []string
+	FormatFV             string
+	IncludeErrorReportFV bool
+	PackageByFolderFV    bool
+	SinceManifestFV      string
 )
 
 // AddExportConfigFlags adds the restore config flag set.
@@ -26,6 +34,26 @@ func AddExportConfigFlags(cmd *cobra.Command) {
 	fs.BoolVar(&ArchiveFV, ArchiveFN, false, "Export data as an archive instead of individual files")
 	fs.StringVar(&FormatFV, FormatFN, "", "Specify the export file format")
 	cobra.CheckErr(fs.MarkHidden(FormatFN))
+	fs.BoolVar(
+		&IncludeErrorReportFV,
+		IncludeErrorReportFN,
+		false,
+		"Include a report of the backup's errors and skipped items in the export")
+	fs.BoolVar(
+		&PackageByFolderFV,
+		PackageByFolderFN,
+		false,
+		"Export each top-level folder as its own archive")
+	fs.StringSliceVar(
+		&ExcludeExtensionsFV,
+		ExcludeExtensionsFN,
+		nil,
+		"Leave items out of the export if their name ends in one of these extensions, ex: exe,dll")
+	fs.StringVar(
+		&SinceManifestFV,
+		SinceManifestFN,
+		"",
+		"Only export items that changed since the export which produced the given manifest file")
 }
 
 // ValidateExportConfigFlags ensures all export config flags that utilize
@@ -34,6 +62,7 @@ func ValidateExportConfigFlags() error {
 	acceptedFormatTypes := []string{
 		string(control.DefaultFormat),
 		string(control.JSONFormat),
+		string(control.MaildirFormat),
 	}
 
 	if !filters.Equal(acceptedFormatTypes).Compare(FormatFV) {
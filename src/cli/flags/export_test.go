@@ -36,6 +36,11 @@ func (suite *ExportUnitSuite) TestValidateExportConfigFlags() {
 	err = ValidateExportConfigFlags()
 	assert.NoError(t, err, clues.ToCore(err))
 
+	FormatFV = "maildir"
+
+	err = ValidateExportConfigFlags()
+	assert.NoError(t, err, clues.ToCore(err))
+
 	FormatFV = "fnerds"
 
 	err = ValidateExportConfigFlags()
@@ -0,0 +1,20 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+)
+
+const HealthAddrFN = "health-addr"
+
+var HealthAddrFV string
+
+// AddHealthAddrFlag adds the flag that serves the health and status
+// endpoints for the operations run by the command.
+func AddHealthAddrFlag(cmd *cobra.Command) {
+	fs := cmd.Flags()
+	fs.StringVar(
+		&HealthAddrFV,
+		HealthAddrFN,
+		"",
+		"Serve liveness and status checks at /healthz and /status on this address while the command runs (ex: \":8080\")")
+}
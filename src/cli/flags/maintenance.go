@@ -58,3 +58,16 @@ func AddMaintenanceHostnameFlag(cmd *cobra.Command) {
 		"",
 		"Attempt to run maintenance with the specified hostname for the repo owner hostname")
 }
+
+const DryRunFN = "dry-run"
+
+var DryRunFV bool
+
+func AddDryRunFlag(cmd *cobra.Command) {
+	fs := cmd.Flags()
+	fs.BoolVar(
+		&DryRunFV,
+		DryRunFN,
+		false,
+		"List the items that would be removed without deleting them")
+}
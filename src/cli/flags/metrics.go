@@ -0,0 +1,20 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+)
+
+const MetricsAddrFN = "metrics-addr"
+
+var MetricsAddrFV string
+
+// AddMetricsAddrFlag adds the flag that serves prometheus metrics for the
+// operations run by the command.
+func AddMetricsAddrFlag(cmd *cobra.Command) {
+	fs := cmd.Flags()
+	fs.StringVar(
+		&MetricsAddrFV,
+		MetricsAddrFN,
+		"",
+		"Serve prometheus metrics at /metrics on this address while the command runs (ex: \":9090\")")
+}
@@ -13,6 +13,10 @@ const (
 	// Corso Flags
 	CorsoPassphraseFN = "passphrase"
 	SucceedIfExistsFN = "succeed-if-exists"
+	ForceDeleteFN     = "force"
+	SampleItemsFN     = "sample-items"
+	LatestBackupFN    = "latest"
+	LegacySizesFN     = "legacy-sizes"
 )
 
 var (
@@ -22,6 +26,10 @@ var (
 	AWSSessionTokenFV    string
 	CorsoPassphraseFV    string
 	SucceedIfExistsFV    bool
+	ForceDeleteFV        bool
+	SampleItemsFV        int
+	LatestBackupFV       bool
+	LegacySizesFV        bool
 )
 
 // AddBackupIDFlag adds the --backup flag.
@@ -33,6 +41,38 @@ func AddBackupIDFlag(cmd *cobra.Command, require bool) {
 	}
 }
 
+// AddForceDeleteFlag adds the --force flag.
+func AddForceDeleteFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&ForceDeleteFV, ForceDeleteFN, false, "Delete the backup even if it is pinned.")
+}
+
+// AddLatestBackupFlag adds the --latest flag.
+func AddLatestBackupFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(
+		&LatestBackupFV,
+		LatestBackupFN,
+		false,
+		"Only list the most recent backup of each protected resource.")
+}
+
+// AddLegacySizesFlag adds the --legacy-sizes flag.
+func AddLegacySizesFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(
+		&LegacySizesFV,
+		LegacySizesFN,
+		false,
+		"Compute the size of backups made before sizes were recorded, by reading their details.")
+}
+
+// AddSampleItemsFlag adds the --sample-items flag.
+func AddSampleItemsFlag(cmd *cobra.Command) {
+	cmd.Flags().IntVar(
+		&SampleItemsFV,
+		SampleItemsFN,
+		100,
+		"Number of pieces of repository data to compress in the benchmark.")
+}
+
 func AddAWSCredsFlags(cmd *cobra.Command) {
 	fs := cmd.Flags()
 	fs.StringVar(&AWSAccessKeyFV, AWSAccessKeyFN, "", "S3 access key")
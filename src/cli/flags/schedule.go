@@ -0,0 +1,19 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+)
+
+const CronFN = "cron"
+
+var CronFV string
+
+// AddCronFlag adds the flag that runs backups on a cron schedule.
+func AddCronFlag(cmd *cobra.Command) {
+	fs := cmd.Flags()
+	fs.StringVar(
+		&CronFV,
+		CronFN,
+		"",
+		"Keep running, and create backups whenever the cron expression fires (ex: \"0 2 * * *\")")
+}
@@ -1,6 +1,7 @@
 package repo
 
 import (
+	"strconv"
 	"strings"
 
 	"github.com/alcionai/clues"
@@ -10,14 +11,19 @@ import (
 	"github.com/alcionai/corso/src/cli/flags"
 	"github.com/alcionai/corso/src/cli/print"
 	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/internal/common/ptr"
 	"github.com/alcionai/corso/src/pkg/control/repository"
 	"github.com/alcionai/corso/src/pkg/path"
 )
 
 const (
-	initCommand        = "init"
-	connectCommand     = "connect"
-	maintenanceCommand = "maintenance"
+	initCommand         = "init"
+	connectCommand      = "connect"
+	maintenanceCommand  = "maintenance"
+	purgeOrphansCommand = "purge-orphans"
+	encryptionCommand   = "encryption"
+	retentionCommand    = "retention"
+	benchmarkCommand    = "benchmark-compression"
 )
 
 var repoCommands = []func(cmd *cobra.Command) *cobra.Command{
@@ -34,18 +40,30 @@ func AddCommands(cmd *cobra.Command) {
 		initCmd        = initCmd()
 		connectCmd     = connectCmd()
 		maintenanceCmd = maintenanceCmd()
+		purgeCmd       = purgeOrphansCmd()
+		encryptionCmd  = encryptionCmd()
+		retentionCmd   = retentionCmd()
+		benchmarkCmd   = benchmarkCompressionCmd()
 	)
 
 	cmd.AddCommand(repoCmd)
 	repoCmd.AddCommand(initCmd)
 	repoCmd.AddCommand(connectCmd)
 	repoCmd.AddCommand(maintenanceCmd)
+	repoCmd.AddCommand(purgeCmd)
+	repoCmd.AddCommand(encryptionCmd)
+	repoCmd.AddCommand(retentionCmd)
+	repoCmd.AddCommand(benchmarkCmd)
 
 	flags.AddMaintenanceModeFlag(maintenanceCmd)
 	flags.AddForceMaintenanceFlag(maintenanceCmd)
 	flags.AddMaintenanceUserFlag(maintenanceCmd)
 	flags.AddMaintenanceHostnameFlag(maintenanceCmd)
 
+	flags.AddDryRunFlag(purgeCmd)
+
+	flags.AddSampleItemsFlag(benchmarkCmd)
+
 	for _, addRepoTo := range repoCommands {
 		addRepoTo(initCmd)
 		addRepoTo(connectCmd)
@@ -153,6 +171,209 @@ func handleMaintenanceCmd(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func purgeOrphansCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   purgeOrphansCommand,
+		Short: "Remove orphaned backup details from an existing repository",
+		Long: `Remove backup details and errors that no backup refers to, such as those left
+behind by interrupted backups.  Details written within the last day are kept.`,
+		RunE: handlePurgeOrphansCmd,
+		Args: cobra.NoArgs,
+	}
+}
+
+func handlePurgeOrphansCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	r, _, err := utils.AccountConnectAndWriteRepoConfig(
+		ctx,
+		cmd,
+		// Need to give it a valid service so it won't error out on us even though
+		// we don't need the graph client.
+		path.OneDriveService)
+	if err != nil {
+		return print.Only(ctx, err)
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	ids, err := r.GarbageCollectOrphans(ctx, flags.DryRunFV)
+	if err != nil {
+		return print.Only(ctx, clues.Wrap(err, "purging orphaned backup details"))
+	}
+
+	verb := "Removed"
+	if flags.DryRunFV {
+		verb = "Found"
+	}
+
+	print.Infof(ctx, "%s %d orphaned backup details", verb, len(ids))
+
+	for _, id := range ids {
+		print.Infof(ctx, "\t%s", id)
+	}
+
+	return nil
+}
+
+func encryptionCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   encryptionCommand,
+		Short: "Show how an existing repository is encrypted",
+		Long:  `Show the encryption algorithms and key derivation parameters that protect the repository's data at rest.`,
+		RunE:  handleEncryptionCmd,
+		Args:  cobra.NoArgs,
+	}
+}
+
+func handleEncryptionCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	r, _, err := utils.AccountConnectAndWriteRepoConfig(
+		ctx,
+		cmd,
+		// Need to give it a valid service so it won't error out on us even though
+		// we don't need the graph client.
+		path.OneDriveService)
+	if err != nil {
+		return print.Only(ctx, err)
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	ei, err := r.EncryptionInfo(ctx)
+	if err != nil {
+		return print.Only(ctx, err)
+	}
+
+	print.Item(ctx, ei)
+
+	return nil
+}
+
+func retentionCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   retentionCommand,
+		Short: "Show the retention settings of an existing repository",
+		Long:  `Show the retention mode and duration of immutable backups, and whether maintenance extends object locks.`,
+		RunE:  handleRetentionCmd,
+		Args:  cobra.NoArgs,
+	}
+}
+
+func handleRetentionCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	r, _, err := utils.AccountConnectAndWriteRepoConfig(
+		ctx,
+		cmd,
+		// Need to give it a valid service so it won't error out on us even though
+		// we don't need the graph client.
+		path.OneDriveService)
+	if err != nil {
+		return print.Only(ctx, err)
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	rs, err := r.RetentionStatus(ctx)
+	if err != nil {
+		return print.Only(ctx, err)
+	}
+
+	print.Item(ctx, retentionStatus{rs})
+
+	return nil
+}
+
+// retentionStatus prints the retention settings of a repository.
+type retentionStatus struct {
+	repository.Retention
+}
+
+func (rs retentionStatus) MinimumPrintable() any {
+	return struct {
+		Mode     string `json:"mode"`
+		Duration string `json:"duration"`
+		Extend   bool   `json:"extendObjectLocks"`
+	}{
+		Mode:     rs.mode(),
+		Duration: rs.duration(),
+		Extend:   ptr.Val(rs.Extend),
+	}
+}
+
+func (rs retentionStatus) Headers() []string {
+	return []string{"Mode", "Duration", "Extend Object Locks"}
+}
+
+func (rs retentionStatus) Values() []string {
+	var extend string
+	if rs.Extend != nil {
+		extend = strconv.FormatBool(*rs.Extend)
+	}
+
+	return []string{rs.mode(), rs.duration(), extend}
+}
+
+func (rs retentionStatus) mode() string {
+	if rs.Mode == nil {
+		return ""
+	}
+
+	return rs.Mode.String()
+}
+
+func (rs retentionStatus) duration() string {
+	if rs.Duration == nil {
+		return ""
+	}
+
+	return rs.Duration.String()
+}
+
+func benchmarkCompressionCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   benchmarkCommand,
+		Short: "Compare compressors on a sample of an existing repository's data",
+		Long: `Compress a sample of the repository's data with each supported compressor, and
+list the compressors from the smallest compression ratio to the largest.  Nothing is
+written to the repository.`,
+		RunE: handleBenchmarkCompressionCmd,
+		Args: cobra.NoArgs,
+	}
+}
+
+func handleBenchmarkCompressionCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	r, _, err := utils.AccountConnectAndWriteRepoConfig(
+		ctx,
+		cmd,
+		// Need to give it a valid service so it won't error out on us even though
+		// we don't need the graph client.
+		path.OneDriveService)
+	if err != nil {
+		return print.Only(ctx, err)
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	results, err := r.BenchmarkCompression(ctx, flags.SampleItemsFV)
+	if err != nil {
+		return print.Only(ctx, clues.Wrap(err, "benchmarking compression"))
+	}
+
+	ps := make([]print.Printable, 0, len(results))
+	for _, res := range results {
+		ps = append(ps, res)
+	}
+
+	print.All(ctx, ps...)
+
+	return nil
+}
+
 func getMaintenanceType(t string) (repository.MaintenanceType, error) {
 	res, ok := repository.StringToMaintenanceType[t]
 	if !ok {
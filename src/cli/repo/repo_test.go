@@ -2,13 +2,17 @@ package repo
 
 import (
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/alcionai/corso/src/cli/flags"
+	"github.com/alcionai/corso/src/internal/common/ptr"
 	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control/repository"
 )
 
 type RepoUnitSuite struct {
@@ -25,17 +29,66 @@ func (suite *RepoUnitSuite) TestAddRepoCommands() {
 
 	AddCommands(cmd)
 
-	var found bool
+	var found, foundPurge, foundEncryption, foundRetention, foundBenchmark bool
 
 	// This is the repo command.
 	repoCmds := cmd.Commands()
 	require.Len(t, repoCmds, 1)
 
 	for _, c := range repoCmds[0].Commands() {
-		if c.Use == maintenanceCommand {
+		switch c.Use {
+		case maintenanceCommand:
 			found = true
+		case purgeOrphansCommand:
+			foundPurge = true
+
+			assert.NotNil(t, c.Flags().Lookup(flags.DryRunFN), "dry run flag")
+		case encryptionCommand:
+			foundEncryption = true
+		case retentionCommand:
+			foundRetention = true
+		case benchmarkCommand:
+			foundBenchmark = true
+
+			assert.NotNil(t, c.Flags().Lookup(flags.SampleItemsFN), "sample items flag")
 		}
 	}
 
 	assert.True(t, found, "looking for maintenance command")
+	assert.True(t, foundPurge, "looking for purge orphans command")
+	assert.True(t, foundEncryption, "looking for encryption command")
+	assert.True(t, foundRetention, "looking for retention command")
+	assert.True(t, foundBenchmark, "looking for benchmark compression command")
+}
+
+func (suite *RepoUnitSuite) TestRetentionStatus_HeadersValues() {
+	table := []struct {
+		name         string
+		rs           repository.Retention
+		expectValues []string
+	}{
+		{
+			name:         "unset",
+			expectValues: []string{"", "", ""},
+		},
+		{
+			name: "governance",
+			rs: repository.Retention{
+				Mode:     ptr.To(repository.GovernanceRetention),
+				Duration: ptr.To(48 * time.Hour),
+				Extend:   ptr.To(true),
+			},
+			expectValues: []string{"governance", "48h0m0s", "true"},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			rs := retentionStatus{test.rs}
+
+			assert.Len(t, rs.Values(), len(rs.Headers()))
+			assert.Equal(t, test.expectValues, rs.Values())
+		})
+	}
 }
@@ -71,6 +71,7 @@ func (suite *ExchangeUnitSuite) TestAddExchangeCommands() {
 				"--" + flags.EmailFolderFN, testdata.FlgInputs(testdata.EmailFldInput),
 				"--" + flags.EmailReceivedAfterFN, testdata.EmailReceivedAfterInput,
 				"--" + flags.EmailReceivedBeforeFN, testdata.EmailReceivedBeforeInput,
+				"--" + flags.EmailRecipientFN, testdata.EmailRecipientInput,
 				"--" + flags.EmailSenderFN, testdata.EmailSenderInput,
 				"--" + flags.EmailSubjectFN, testdata.EmailSubjectInput,
 
@@ -113,6 +114,7 @@ func (suite *ExchangeUnitSuite) TestAddExchangeCommands() {
 			assert.ElementsMatch(t, testdata.EmailFldInput, opts.EmailFolder)
 			assert.Equal(t, testdata.EmailReceivedAfterInput, opts.EmailReceivedAfter)
 			assert.Equal(t, testdata.EmailReceivedBeforeInput, opts.EmailReceivedBefore)
+			assert.Equal(t, testdata.EmailRecipientInput, opts.EmailRecipient)
 			assert.Equal(t, testdata.EmailSenderInput, opts.EmailSender)
 			assert.Equal(t, testdata.EmailSubjectInput, opts.EmailSubject)
 
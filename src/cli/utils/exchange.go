@@ -19,6 +19,7 @@ type ExchangeOpts struct {
 	EmailFolder         []string
 	EmailReceivedAfter  string
 	EmailReceivedBefore string
+	EmailRecipient      string
 	EmailSender         string
 	EmailSubject        string
 
@@ -31,6 +32,7 @@ type ExchangeOpts struct {
 	EventSubject      string
 
 	RestoreCfg RestoreCfgOpts
+	ExportCfg  ExportCfgOpts
 
 	Populated flags.PopulatedFlags
 }
@@ -48,6 +50,7 @@ func MakeExchangeOpts(cmd *cobra.Command) ExchangeOpts {
 		EmailFolder:         flags.EmailFolderFV,
 		EmailReceivedAfter:  flags.EmailReceivedAfterFV,
 		EmailReceivedBefore: flags.EmailReceivedBeforeFV,
+		EmailRecipient:      flags.EmailRecipientFV,
 		EmailSender:         flags.EmailSenderFV,
 		EmailSubject:        flags.EmailSubjectFV,
 
@@ -60,6 +63,7 @@ func MakeExchangeOpts(cmd *cobra.Command) ExchangeOpts {
 		EventSubject:      flags.EventSubjectFV,
 
 		RestoreCfg: makeRestoreCfgOpts(cmd),
+		ExportCfg:  makeExportCfgOpts(cmd),
 
 		// populated contains the list of flags that appear in the
 		// command, according to pflags.  Use this to differentiate
@@ -135,6 +139,14 @@ func ValidateExchangeRestoreFlags(backupID string, opts ExchangeOpts) error {
 		return clues.New("invalid time format for event-starts-before")
 	}
 
+	if !IsValidTimeRange(opts.EmailReceivedAfter, opts.EmailReceivedBefore) {
+		return clues.New(flags.EmailReceivedAfterFN + " must not be later than " + flags.EmailReceivedBeforeFN)
+	}
+
+	if !IsValidTimeRange(opts.EventStartsAfter, opts.EventStartsBefore) {
+		return clues.New(flags.EventStartsAfterFN + " must not be later than " + flags.EventStartsBeforeFN)
+	}
+
 	if _, ok := opts.Populated[flags.EventRecursFN]; ok && !IsValidBool(opts.EventRecurs) {
 		return clues.New("invalid format for event-recurs")
 	}
@@ -171,6 +183,29 @@ func IncludeExchangeRestoreDataSelectors(opts ExchangeOpts) *selectors.ExchangeR
 	return sel
 }
 
+// IncludeExchangeExportDataSelectors builds the common data-selector
+// inclusions for exchange exports.  Only email can be exported, so the
+// selector is limited to mail.
+func IncludeExchangeExportDataSelectors(opts ExchangeOpts) *selectors.ExchangeRestore {
+	users := opts.Users
+	if len(users) == 0 {
+		users = selectors.Any()
+	}
+
+	sel := selectors.NewExchangeRestore(users)
+
+	if len(opts.Email)+len(opts.EmailFolder) == 0 {
+		sel.Include(sel.MailFolders(selectors.Any()))
+		return sel
+	}
+
+	opts.EmailFolder = trimFolderSlash(opts.EmailFolder)
+
+	AddExchangeInclude(sel, opts.EmailFolder, opts.Email, sel.Mails)
+
+	return sel
+}
+
 // FilterExchangeRestoreInfoSelectors builds the common info-selector filters.
 func FilterExchangeRestoreInfoSelectors(
 	sel *selectors.ExchangeRestore,
@@ -179,6 +214,7 @@ func FilterExchangeRestoreInfoSelectors(
 	AddExchangeInfo(sel, opts.ContactName, sel.ContactName)
 	AddExchangeInfo(sel, opts.EmailReceivedAfter, sel.MailReceivedAfter)
 	AddExchangeInfo(sel, opts.EmailReceivedBefore, sel.MailReceivedBefore)
+	AddExchangeInfo(sel, opts.EmailRecipient, sel.MailRecipient)
 	AddExchangeInfo(sel, opts.EmailSender, sel.MailSender)
 	AddExchangeInfo(sel, opts.EmailSubject, sel.MailSubject)
 	AddExchangeInfo(sel, opts.EventOrganizer, sel.EventOrganizer)
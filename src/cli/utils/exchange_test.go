@@ -5,12 +5,14 @@ import (
 
 	"github.com/alcionai/clues"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/alcionai/corso/src/cli/flags"
 	"github.com/alcionai/corso/src/cli/utils"
 	"github.com/alcionai/corso/src/internal/common/dttm"
 	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/path"
 	"github.com/alcionai/corso/src/pkg/selectors"
 )
 
@@ -51,6 +53,50 @@ func (suite *ExchangeUtilsSuite) TestValidateRestoreFlags() {
 			opts:   utils.ExchangeOpts{EmailReceivedAfter: "fnords"},
 			expect: assert.Error,
 		},
+		{
+			name:     "valid email received range",
+			backupID: "bid",
+			opts: utils.ExchangeOpts{
+				EmailReceivedAfter:  "2023-01-01T00:00:00Z",
+				EmailReceivedBefore: "2023-02-01T00:00:00Z",
+			},
+			expect: assert.NoError,
+		},
+		{
+			name:     "equal email received range",
+			backupID: "bid",
+			opts: utils.ExchangeOpts{
+				EmailReceivedAfter:  "2023-01-01T00:00:00Z",
+				EmailReceivedBefore: "2023-01-01T00:00:00Z",
+			},
+			expect: assert.NoError,
+		},
+		{
+			name:     "inverted email received range",
+			backupID: "bid",
+			opts: utils.ExchangeOpts{
+				EmailReceivedAfter:  "2023-02-01T00:00:00Z",
+				EmailReceivedBefore: "2023-01-01T00:00:00Z",
+			},
+			expect: assert.Error,
+		},
+		{
+			name:     "inverted event starts range",
+			backupID: "bid",
+			opts: utils.ExchangeOpts{
+				EventStartsAfter:  "2023-02-01T00:00:00Z",
+				EventStartsBefore: "2023-01-01T00:00:00Z",
+			},
+			expect: assert.Error,
+		},
+		{
+			name:     "only after",
+			backupID: "bid",
+			opts: utils.ExchangeOpts{
+				EventStartsAfter: "2023-02-01T00:00:00Z",
+			},
+			expect: assert.NoError,
+		},
 	}
 	for _, test := range table {
 		suite.Run(test.name, func() {
@@ -311,6 +357,56 @@ func (suite *ExchangeUtilsSuite) TestIncludeExchangeRestoreDataSelectors() {
 	}
 }
 
+func (suite *ExchangeUtilsSuite) TestIncludeExchangeExportDataSelectors() {
+	stub := []string{"id-stub"}
+	a := []string{flags.Wildcard}
+
+	table := []struct {
+		name             string
+		opts             utils.ExchangeOpts
+		expectIncludeLen int
+	}{
+		{
+			name:             "no selectors",
+			expectIncludeLen: 1,
+		},
+		{
+			name: "single user",
+			opts: utils.ExchangeOpts{
+				Users: stub,
+			},
+			expectIncludeLen: 1,
+		},
+		{
+			name: "any email",
+			opts: utils.ExchangeOpts{
+				Email:       a,
+				EmailFolder: a,
+			},
+			expectIncludeLen: 1,
+		},
+		{
+			name: "email, no folder",
+			opts: utils.ExchangeOpts{
+				Email: stub,
+			},
+			expectIncludeLen: 1,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			sel := utils.IncludeExchangeExportDataSelectors(test.opts)
+			require.Len(t, sel.Includes, test.expectIncludeLen)
+
+			scopes := sel.Scopes()
+			require.Len(t, scopes, 1)
+			assert.Equal(t, path.EmailCategory, scopes[0].Category().PathType())
+		})
+	}
+}
+
 func (suite *ExchangeUtilsSuite) TestAddExchangeInclude() {
 	var (
 		empty             = []string{}
@@ -414,6 +510,13 @@ func (suite *ExchangeUtilsSuite) TestFilterExchangeRestoreInfoSelectors() {
 			},
 			expectFilterLen: 1,
 		},
+		{
+			name: "recipient",
+			opts: utils.ExchangeOpts{
+				EmailRecipient: stub,
+			},
+			expectFilterLen: 1,
+		},
 		{
 			name: "sender",
 			opts: utils.ExchangeOpts{
@@ -469,6 +572,7 @@ func (suite *ExchangeUtilsSuite) TestFilterExchangeRestoreInfoSelectors() {
 				ContactName:         stub,
 				EmailReceivedAfter:  stub,
 				EmailReceivedBefore: stub,
+				EmailRecipient:      stub,
 				EmailSender:         stub,
 				EmailSubject:        stub,
 				EventOrganizer:      stub,
@@ -477,7 +581,7 @@ func (suite *ExchangeUtilsSuite) TestFilterExchangeRestoreInfoSelectors() {
 				EventStartsBefore:   stub,
 				EventSubject:        stub,
 			},
-			expectFilterLen: 10,
+			expectFilterLen: 11,
 		},
 	}
 	for _, test := range table {
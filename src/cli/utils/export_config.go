@@ -10,16 +10,24 @@ import (
 )
 
 type ExportCfgOpts struct {
-	Archive bool
-	Format  string
+	Archive            bool
+	ExcludeExtensions  []string
+	Format             string
+	IncludeErrorReport bool
+	PackageByFolder    bool
+	SinceManifest      string
 
 	Populated flags.PopulatedFlags
 }
 
 func makeExportCfgOpts(cmd *cobra.Command) ExportCfgOpts {
 	return ExportCfgOpts{
-		Archive: flags.ArchiveFV,
-		Format:  flags.FormatFV,
+		Archive:            flags.ArchiveFV,
+		ExcludeExtensions:  flags.ExcludeExtensionsFV,
+		Format:             flags.FormatFV,
+		IncludeErrorReport: flags.IncludeErrorReportFV,
+		PackageByFolder:    flags.PackageByFolderFV,
+		SinceManifest:      flags.SinceManifestFV,
 
 		// populated contains the list of flags that appear in the
 		// command, according to pflags.  Use this to differentiate
@@ -36,6 +44,9 @@ func MakeExportConfig(
 
 	exportCfg.Archive = opts.Archive
 	exportCfg.Format = control.FormatType(opts.Format)
+	exportCfg.IncludeErrorReport = opts.IncludeErrorReport
+	exportCfg.PackageByFolder = opts.PackageByFolder
+	exportCfg.ExcludeExtensions = opts.ExcludeExtensions
 
 	return exportCfg
 }
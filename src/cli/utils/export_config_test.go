@@ -20,7 +20,12 @@ func TestExportCfgUnitSuite(t *testing.T) {
 }
 
 func (suite *ExportCfgUnitSuite) TestMakeExportConfig() {
-	rco := &ExportCfgOpts{Archive: true}
+	rco := &ExportCfgOpts{
+		Archive:            true,
+		ExcludeExtensions:  []string{"exe"},
+		IncludeErrorReport: true,
+		PackageByFolder:    true,
+	}
 
 	table := []struct {
 		name      string
@@ -30,10 +35,16 @@ func (suite *ExportCfgUnitSuite) TestMakeExportConfig() {
 		{
 			name: "archive populated",
 			populated: flags.PopulatedFlags{
-				flags.ArchiveFN: {},
+				flags.ArchiveFN:            {},
+				flags.ExcludeExtensionsFN:  {},
+				flags.IncludeErrorReportFN: {},
+				flags.PackageByFolderFN:    {},
 			},
 			expect: control.ExportConfig{
-				Archive: true,
+				Archive:            true,
+				ExcludeExtensions:  []string{"exe"},
+				IncludeErrorReport: true,
+				PackageByFolder:    true,
 			},
 		},
 	}
@@ -49,6 +60,9 @@ func (suite *ExportCfgUnitSuite) TestMakeExportConfig() {
 
 			result := MakeExportConfig(ctx, opts)
 			assert.Equal(t, test.expect.Archive, result.Archive)
+			assert.Equal(t, test.expect.IncludeErrorReport, result.IncludeErrorReport)
+			assert.Equal(t, test.expect.PackageByFolder, result.PackageByFolder)
+			assert.Equal(t, test.expect.ExcludeExtensions, result.ExcludeExtensions)
 		})
 	}
 }
@@ -15,6 +15,23 @@ func IsValidTimeFormat(in string) bool {
 	return err == nil
 }
 
+// IsValidTimeRange returns false if both inputs are valid times, and the
+// after time is later than the before time.  Empty or unrecognized inputs
+// aren't a range, and are left to the format checks.
+func IsValidTimeRange(after, before string) bool {
+	a, err := dttm.ParseTime(after)
+	if err != nil {
+		return true
+	}
+
+	b, err := dttm.ParseTime(before)
+	if err != nil {
+		return true
+	}
+
+	return !a.After(b)
+}
+
 // IsValidTimeFormat returns true if the input is recognized as a
 // boolean.
 func IsValidBool(in string) bool {
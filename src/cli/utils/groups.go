@@ -138,6 +138,22 @@ func ValidateGroupsRestoreFlags(backupID string, opts GroupsOpts) error {
 		return clues.New("invalid time format for " + flags.MessageLastReplyBeforeFN)
 	}
 
+	if !IsValidTimeRange(opts.FileCreatedAfter, opts.FileCreatedBefore) {
+		return clues.New(flags.FileCreatedAfterFN + " must not be later than " + flags.FileCreatedBeforeFN)
+	}
+
+	if !IsValidTimeRange(opts.FileModifiedAfter, opts.FileModifiedBefore) {
+		return clues.New(flags.FileModifiedAfterFN + " must not be later than " + flags.FileModifiedBeforeFN)
+	}
+
+	if !IsValidTimeRange(opts.MessageCreatedAfter, opts.MessageCreatedBefore) {
+		return clues.New(flags.MessageCreatedAfterFN + " must not be later than " + flags.MessageCreatedBeforeFN)
+	}
+
+	if !IsValidTimeRange(opts.MessageLastReplyAfter, opts.MessageLastReplyBefore) {
+		return clues.New(flags.MessageLastReplyAfterFN + " must not be later than " + flags.MessageLastReplyBeforeFN)
+	}
+
 	return validateRestoreConfigFlags(flags.CollisionsFV, opts.RestoreCfg)
 }
 
@@ -164,8 +180,7 @@ func IncludeGroupsRestoreDataSelectors(ctx context.Context, opts GroupsOpts) *se
 		llf, lli    = len(opts.ListFolder), len(opts.ListItem)
 		lpf, lpi    = len(opts.PageFolder), len(opts.Page)
 		lg, lch, lm = len(opts.Groups), len(opts.Channels), len(opts.Messages)
-		// TODO(meain): handle sites once we add non-root site backup
-		// ls := len(opts.SiteID)
+		ls          = len(opts.SiteID)
 	)
 
 	if lg == 0 {
@@ -174,13 +189,19 @@ func IncludeGroupsRestoreDataSelectors(ctx context.Context, opts GroupsOpts) *se
 
 	sel := selectors.NewGroupsRestore(groups)
 
-	if lfp+lfn+llf+lli+lpf+lpi+lch+lm == 0 {
+	if lfp+lfn+llf+lli+lpf+lpi+lch+lm+ls == 0 {
 		sel.Include(sel.AllData())
 		return sel
 	}
 
 	// sharepoint site selectors
 
+	// sites are narrowed by the info filters.  Without any other
+	// library selectors, select every library item in the sites.
+	if ls > 0 && lfp+lfn+llf+lli+lpf+lpi == 0 {
+		sel.Include(sel.LibraryItems(selectors.Any(), selectors.Any()))
+	}
+
 	if lfp+lfn+llf+lli+lpf+lpi > 0 {
 		if lfp+lfn > 0 {
 			if lfn == 0 {
@@ -260,6 +281,11 @@ func FilterGroupsRestoreInfoSelectors(
 	opts GroupsOpts,
 ) {
 	AddGroupsFilter(sel, opts.Library, sel.Library)
+
+	if len(opts.SiteID) > 0 {
+		sel.Filter(sel.Sites(opts.SiteID))
+	}
+
 	AddGroupsFilter(sel, opts.FileCreatedAfter, sel.CreatedAfter)
 	AddGroupsFilter(sel, opts.FileCreatedBefore, sel.CreatedBefore)
 	AddGroupsFilter(sel, opts.FileModifiedAfter, sel.ModifiedAfter)
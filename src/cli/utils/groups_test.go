@@ -118,12 +118,27 @@ func (suite *GroupsUtilsSuite) TestIncludeGroupsRestoreDataSelectors() {
 			},
 			expectIncludeLen: 2,
 		},
+		{
+			name: "site id",
+			opts: utils.GroupsOpts{
+				SiteID: single,
+			},
+			expectIncludeLen: 1,
+		},
+		{
+			name: "site id and library folder",
+			opts: utils.GroupsOpts{
+				SiteID:     multi,
+				FolderPath: single,
+			},
+			expectIncludeLen: 1,
+		},
 		{
 			name: "library folder suffixes",
 			opts: utils.GroupsOpts{
 				FileName:   empty,
 				FolderPath: empty,
-				// SiteID:     empty,  // TODO(meain): Update once we support multiple sites
+				SiteID:     empty,
 			},
 			expectIncludeLen: 2,
 		},
@@ -132,7 +147,7 @@ func (suite *GroupsUtilsSuite) TestIncludeGroupsRestoreDataSelectors() {
 			opts: utils.GroupsOpts{
 				FileName:   empty,
 				FolderPath: empty,
-				// SiteID:     empty, // TODO(meain): update once we support multiple sites
+				SiteID:     empty,
 			},
 			expectIncludeLen: 2,
 		},
@@ -268,6 +283,69 @@ func (suite *GroupsUtilsSuite) TestValidateGroupsRestoreFlags() {
 			},
 			expect: assert.NoError,
 		},
+		{
+			name:     "valid ranges",
+			backupID: "id",
+			opts: utils.GroupsOpts{
+				FileCreatedAfter:       "2023-01-01T00:00:00Z",
+				FileCreatedBefore:      "2023-02-01T00:00:00Z",
+				MessageLastReplyAfter:  "2023-01-01T00:00:00Z",
+				MessageLastReplyBefore: "2023-01-01T00:00:00Z",
+			},
+			expect: assert.NoError,
+		},
+		{
+			name:     "inverted file created range",
+			backupID: "id",
+			opts: utils.GroupsOpts{
+				FileCreatedAfter:  "2023-02-01T00:00:00Z",
+				FileCreatedBefore: "2023-01-01T00:00:00Z",
+				Populated: flags.PopulatedFlags{
+					flags.FileCreatedAfterFN:  struct{}{},
+					flags.FileCreatedBeforeFN: struct{}{},
+				},
+			},
+			expect: assert.Error,
+		},
+		{
+			name:     "inverted file modified range",
+			backupID: "id",
+			opts: utils.GroupsOpts{
+				FileModifiedAfter:  "2023-02-01T00:00:00Z",
+				FileModifiedBefore: "2023-01-01T00:00:00Z",
+				Populated: flags.PopulatedFlags{
+					flags.FileModifiedAfterFN:  struct{}{},
+					flags.FileModifiedBeforeFN: struct{}{},
+				},
+			},
+			expect: assert.Error,
+		},
+		{
+			name:     "inverted message created range",
+			backupID: "id",
+			opts: utils.GroupsOpts{
+				MessageCreatedAfter:  "2023-02-01T00:00:00Z",
+				MessageCreatedBefore: "2023-01-01T00:00:00Z",
+				Populated: flags.PopulatedFlags{
+					flags.MessageCreatedAfterFN:  struct{}{},
+					flags.MessageCreatedBeforeFN: struct{}{},
+				},
+			},
+			expect: assert.Error,
+		},
+		{
+			name:     "inverted message last reply range",
+			backupID: "id",
+			opts: utils.GroupsOpts{
+				MessageLastReplyAfter:  "2023-02-01T00:00:00Z",
+				MessageLastReplyBefore: "2023-01-01T00:00:00Z",
+				Populated: flags.PopulatedFlags{
+					flags.MessageLastReplyAfterFN:  struct{}{},
+					flags.MessageLastReplyBeforeFN: struct{}{},
+				},
+			},
+			expect: assert.Error,
+		},
 		// sharepoint
 		{
 			name:     "invalid file created after",
@@ -19,6 +19,7 @@ var (
 	EmailFldInput            = []string{"mailFld1", "mailFld2"}
 	EmailReceivedAfterInput  = "mailReceivedAfter"
 	EmailReceivedBeforeInput = "mailReceivedBefore"
+	EmailRecipientInput      = "mailRecipient"
 	EmailSenderInput         = "mailSender"
 	EmailSubjectInput        = "mailSubject"
 
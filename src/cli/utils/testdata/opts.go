@@ -1008,6 +1008,28 @@ func (MockBackupGetter) BackupsByTag(
 	return nil, clues.New("unexpected call to mock")
 }
 
+func (MockBackupGetter) BackupsForResource(
+	context.Context,
+	string,
+) ([]*backup.Backup, error) {
+	return nil, clues.New("unexpected call to mock")
+}
+
+func (MockBackupGetter) LatestBackup(
+	context.Context,
+	string,
+	path.ServiceType,
+) (*backup.Backup, error) {
+	return nil, clues.New("unexpected call to mock")
+}
+
+func (MockBackupGetter) BackupLineage(
+	context.Context,
+	string,
+) ([]backup.LineageEntry, error) {
+	return nil, clues.New("unexpected call to mock")
+}
+
 func (bg *MockBackupGetter) GetBackupDetails(
 	ctx context.Context,
 	backupID string,
@@ -11,7 +11,6 @@ import (
 	"github.com/spf13/pflag"
 
 	"github.com/alcionai/corso/src/cli/config"
-	"github.com/alcionai/corso/src/cli/flags"
 	"github.com/alcionai/corso/src/internal/events"
 	"github.com/alcionai/corso/src/pkg/account"
 	"github.com/alcionai/corso/src/pkg/control"
@@ -32,7 +31,7 @@ func GetAccountAndConnectWithOverrides(
 	cmd *cobra.Command,
 	pst path.ServiceType,
 ) (repository.Repository, *storage.Storage, *account.Account, *control.Options, error) {
-	provider, overrides, err := GetStorageProviderAndOverrides(ctx, cmd)
+	provider, overrides, err := config.GetStorageProviderAndOverrides(ctx, cmd)
 	if err != nil {
 		return nil, nil, nil, nil, clues.Stack(err)
 	}
@@ -247,27 +246,6 @@ func SendStartCorsoEvent(
 	bus.Event(ctx, events.CorsoStart, data)
 }
 
-// GetStorageProviderAndOverrides returns the storage provider type and
-// any flags specified on the command line which are storage provider specific.
-func GetStorageProviderAndOverrides(
-	ctx context.Context,
-	cmd *cobra.Command,
-) (storage.ProviderType, map[string]string, error) {
-	provider, err := config.GetStorageProviderFromConfigFile(ctx)
-	if err != nil {
-		return provider, nil, clues.Stack(err)
-	}
-
-	switch provider {
-	case storage.ProviderS3:
-		return provider, flags.S3FlagOverrides(cmd), nil
-	case storage.ProviderFilesystem:
-		return provider, flags.FilesystemFlagOverrides(cmd), nil
-	}
-
-	return provider, nil, clues.New("unknown storage provider: " + provider.String())
-}
-
 // MakeAbsoluteFilePath does directory path expansions & conversions, namely:
 // 1. Expands "~" prefix to the user's home directory, and converts to absolute path.
 // 2. Relative paths are converted to absolute paths.
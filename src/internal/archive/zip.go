@@ -5,6 +5,7 @@ import (
 	"context"
 	"io"
 	"path"
+	"strings"
 
 	"github.com/alcionai/clues"
 
@@ -31,13 +32,17 @@ func (z zipCollection) Items(ctx context.Context) <-chan export.Item {
 	defer close(rc)
 
 	rc <- export.Item{
-		Name: "Corso_Export_" + dttm.FormatNow(dttm.HumanReadable) + ".zip",
+		Name: defaultZipName(),
 		Body: z.reader,
 	}
 
 	return rc
 }
 
+func defaultZipName() string {
+	return "Corso_Export_" + dttm.FormatNow(dttm.HumanReadable) + ".zip"
+}
+
 // ZipExportCollection takes a list of export collections and zips
 // them into a single collection.
 func ZipExportCollection(
@@ -48,6 +53,103 @@ func ZipExportCollection(
 		return nil, clues.New("no export collections provided")
 	}
 
+	return zipCollection{zipCollections(ctx, expCollections)}, nil
+}
+
+// folderArchive holds the export collections that share a top-level folder.
+type folderArchive struct {
+	folder string
+	colls  []export.Collectioner
+}
+
+type zipFolderCollection struct {
+	archives []folderArchive
+}
+
+func (z zipFolderCollection) BasePath() string {
+	return ""
+}
+
+// Items produces one zip per top-level folder.  Each zip is created as
+// its item is sent, so that the archives aren't all written at once.
+func (z zipFolderCollection) Items(ctx context.Context) <-chan export.Item {
+	rc := make(chan export.Item)
+
+	go func() {
+		defer close(rc)
+
+		for _, fa := range z.archives {
+			name := defaultZipName()
+			if len(fa.folder) > 0 {
+				name = fa.folder + ".zip"
+			}
+
+			item := export.Item{
+				ID:   fa.folder,
+				Name: name,
+				Body: zipCollections(ctx, fa.colls),
+			}
+
+			select {
+			case <-ctx.Done():
+				// stops the zip from being written.
+				item.Body.Close()
+				return
+			case rc <- item:
+			}
+		}
+	}()
+
+	return rc
+}
+
+// ZipExportCollectionsByFolder takes a list of export collections and
+// zips them into one archive per top-level folder.  Each archive is
+// named after its folder, and retains the folder within its entry paths.
+// Items at the root of the export are zipped into a separate archive.
+func ZipExportCollectionsByFolder(
+	ctx context.Context,
+	expCollections []export.Collectioner,
+) (export.Collectioner, error) {
+	if len(expCollections) == 0 {
+		return nil, clues.New("no export collections provided")
+	}
+
+	var (
+		archives []folderArchive
+		idx      = map[string]int{}
+	)
+
+	for _, ec := range expCollections {
+		folder := topLevelFolder(ec.BasePath())
+
+		i, ok := idx[folder]
+		if !ok {
+			i = len(archives)
+			idx[folder] = i
+
+			archives = append(archives, folderArchive{folder: folder})
+		}
+
+		archives[i].colls = append(archives[i].colls, ec)
+	}
+
+	return zipFolderCollection{archives}, nil
+}
+
+// topLevelFolder returns the first element of the base path, or an
+// empty string if the base path is the export root.
+func topLevelFolder(basePath string) string {
+	folder, _, _ := strings.Cut(strings.Trim(basePath, "/"), "/")
+	return folder
+}
+
+// zipCollections streams the items of every collection into a zip.  Any
+// error is returned when reading from the zip.
+func zipCollections(
+	ctx context.Context,
+	expCollections []export.Collectioner,
+) io.ReadCloser {
 	reader, writer := io.Pipe()
 	wr := zip.NewWriter(writer)
 
@@ -59,6 +161,16 @@ func ZipExportCollection(
 
 		for _, ec := range expCollections {
 			folder := ec.BasePath()
+
+			// zips hold empty folders as entries with a trailing slash.
+			for _, dir := range export.ExtraDirs(ec) {
+				//nolint:forbidigo
+				if _, err := wr.Create(path.Clean("/" + dir)[1:] + "/"); err != nil {
+					writer.CloseWithError(clues.Wrap(err, "creating zip folder entry"))
+					return
+				}
+			}
+
 			items := ec.Items(ctx)
 
 			for item := range items {
@@ -92,5 +204,5 @@ func ZipExportCollection(
 		}
 	}()
 
-	return zipCollection{reader}, nil
+	return reader
 }
@@ -93,6 +93,12 @@ type ItemModTime interface {
 	ModTime() time.Time
 }
 
+// ItemContentHash provides an identifier derived from the content of the
+// item.  Items with identical content produce identical hashes.
+type ItemContentHash interface {
+	ContentHash() string
+}
+
 type FetchItemByNamer interface {
 	// Fetch retrieves an item with the given name from the Collection if it
 	// exists. Items retrieved with Fetch may still appear in the channel returned
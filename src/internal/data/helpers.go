@@ -1,6 +1,10 @@
 package data
 
-import "sort"
+import (
+	"context"
+	"io"
+	"sort"
+)
 
 // SortRestoreCollections performs an in-place sort on the provided collection.
 func SortRestoreCollections(rcs []RestoreCollection) {
@@ -8,3 +12,25 @@ func SortRestoreCollections(rcs []RestoreCollection) {
 		return rcs[i].FullPath().String() < rcs[j].FullPath().String()
 	})
 }
+
+// StreamItemByName fetches the named item from the collection and returns
+// a reader over its data, along with the item's size, or -1 if the size is
+// unknown.  The reader streams from the backup store, so callers should
+// consume it incrementally instead of reading it into memory.
+func StreamItemByName(
+	ctx context.Context,
+	fibn FetchItemByNamer,
+	name string,
+) (io.ReadCloser, int64, error) {
+	item, err := fibn.FetchItemByName(ctx, name)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	size := int64(-1)
+	if is, ok := item.(ItemSize); ok {
+		size = is.Size()
+	}
+
+	return item.ToReader(), size, nil
+}
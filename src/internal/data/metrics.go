@@ -1,11 +1,16 @@
 package data
 
+import "github.com/alcionai/corso/src/internal/stats"
+
 type CollectionStats struct {
 	Folders,
 	Objects,
 	Successes int
 	Bytes   int64
 	Details string
+	// Concurrency holds the item fetch concurrency sampled while
+	// streaming each collection.
+	Concurrency []stats.ItemConcurrency
 }
 
 func (cs CollectionStats) IsZero() bool {
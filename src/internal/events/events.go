@@ -70,9 +70,15 @@ type Eventer interface {
 	Close() error
 }
 
+// Sink receives every event sent on a Bus.  The event data includes the
+// repo, tenant, and version properties of the bus.  The bus doesn't close
+// its sinks; flushing and closing is left to their owner.
+type Sink = control.EventSink
+
 // Bus handles all event communication into the events package.
 type Bus struct {
 	client analytics.Client
+	sinks  []Sink
 
 	repoID           string // one-way hash that uniquely identifies the repo.
 	tenant           string // one-way hash that uniquely identifies the tenant.
@@ -85,9 +91,28 @@ var (
 	RudderStackDataPlaneURL string
 )
 
-func NewBus(ctx context.Context, s storage.Storage, tenID string, co control.Options) (Bus, error) {
+// NewBus produces a bus that delivers events to the corso analytics backend,
+// and to each of the sinks in the options.  Disabling metrics only disables
+// the analytics backend; the sinks still receive every event.
+func NewBus(
+	ctx context.Context,
+	s storage.Storage,
+	tenID string,
+	co control.Options,
+) (Bus, error) {
+	sinks := co.EventSinks
+
 	if co.DisableMetrics {
-		return Bus{}, nil
+		if len(sinks) == 0 {
+			return Bus{}, nil
+		}
+
+		return Bus{
+			sinks:            sinks,
+			tenant:           sha256Truncated(tenID),
+			tenantDeprecated: tenantHash(tenID),
+			version:          version.Version,
+		}, nil
 	}
 
 	envWK := os.Getenv("RUDDERSTACK_CORSO_WRITE_KEY")
@@ -118,6 +143,7 @@ func NewBus(ctx context.Context, s storage.Storage, tenID string, co control.Opt
 
 	return Bus{
 		client:           client,
+		sinks:            sinks,
 		tenant:           sha256Truncated(tenID),
 		tenantDeprecated: tenantHash(tenID),
 		version:          version.Version,
@@ -133,6 +159,8 @@ func (b Bus) Close() error {
 }
 
 func (b Bus) Event(ctx context.Context, key string, data map[string]any) {
+	b.sinkEvent(ctx, key, data)
+
 	if b.client == nil {
 		return
 	}
@@ -174,6 +202,28 @@ func (b Bus) Event(ctx context.Context, key string, data map[string]any) {
 	}
 }
 
+// sinkEvent fans the event out to each of the bus's sinks.
+func (b Bus) sinkEvent(ctx context.Context, key string, data map[string]any) {
+	if len(b.sinks) == 0 {
+		return
+	}
+
+	props := map[string]any{
+		repoID:             b.repoID,
+		tenantID:           b.tenant,
+		tenantIDDeprecated: b.tenantDeprecated,
+		corsoVersion:       b.version,
+	}
+
+	for k, v := range data {
+		props[k] = v
+	}
+
+	for _, s := range b.sinks {
+		s.Event(ctx, key, props)
+	}
+}
+
 func (b *Bus) SetRepoID(hash string) {
 	b.repoID = hash
 }
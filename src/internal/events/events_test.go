@@ -1,9 +1,12 @@
 package events_test
 
 import (
+	"context"
+	"sync"
 	"testing"
 
 	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
@@ -64,3 +67,88 @@ func (suite *EventsIntegrationSuite) TestNewBus() {
 	err = b2.Close()
 	require.NoError(t, err, clues.ToCore(err))
 }
+
+type recordedEvent struct {
+	key  string
+	data map[string]any
+}
+
+type recordingSink struct {
+	mu     sync.Mutex
+	events []recordedEvent
+}
+
+func (s *recordingSink) Event(_ context.Context, key string, data map[string]any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events = append(s.events, recordedEvent{key, data})
+}
+
+type EventsUnitSuite struct {
+	tester.Suite
+}
+
+func TestEventsUnitSuite(t *testing.T) {
+	suite.Run(t, &EventsUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *EventsUnitSuite) TestBus_sinks() {
+	table := []struct {
+		name string
+		opts control.Options
+	}{
+		{
+			name: "metrics enabled",
+			opts: control.DefaultOptions(),
+		},
+		{
+			name: "metrics disabled",
+			opts: control.Options{DisableMetrics: true},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			// keep the analytics client from reaching out to a real backend.
+			t.Setenv("RUDDERSTACK_CORSO_WRITE_KEY", "")
+			t.Setenv("RUDDERSTACK_CORSO_DATA_PLANE_URL", "")
+
+			var (
+				s1 = &recordingSink{}
+				s2 = &recordingSink{}
+			)
+
+			opts := test.opts
+			opts.EventSinks = []control.EventSink{s1, s2}
+
+			b, err := events.NewBus(ctx, storage.Storage{}, "tid", opts)
+			require.NoError(t, err, clues.ToCore(err))
+
+			b.SetRepoID("rid")
+
+			b.Event(ctx, events.RepoInit, nil)
+			b.Event(ctx, events.BackupEnd, map[string]any{events.BackupID: "bid"})
+
+			for i, s := range []*recordingSink{s1, s2} {
+				require.Len(t, s.events, 2, "sink %d", i)
+
+				assert.Equal(t, events.RepoInit, s.events[0].key)
+				assert.Equal(t, "rid", s.events[0].data["repo_id"])
+				assert.NotEmpty(t, s.events[0].data["m365_tenant_hash"])
+				assert.NotEmpty(t, s.events[0].data["corso_version"])
+
+				assert.Equal(t, events.BackupEnd, s.events[1].key)
+				assert.Equal(t, "bid", s.events[1].data[events.BackupID])
+				assert.Equal(t, "rid", s.events[1].data["repo_id"])
+			}
+
+			err = b.Close()
+			require.NoError(t, err, clues.ToCore(err))
+		})
+	}
+}
@@ -254,6 +254,17 @@ func (b *baseFinder) findBasesInSet(
 			continue
 		}
 
+		// Partial backups were stopped early at the caller's request and don't
+		// contain all the data for the reason.  They can't act as either kind
+		// of base.
+		if bup.Tags[model.BackupTypeTag] == model.PartialBackup {
+			logger.Ctx(ictx).Debugw(
+				"skipping partial backup",
+				"search_backup_id", bup.ID)
+
+			continue
+		}
+
 		// If we've made it to this point then we're considering the backup
 		// complete as it has both an item data snapshot and a backup details
 		// snapshot.
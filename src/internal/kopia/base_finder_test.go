@@ -846,6 +846,41 @@ func (suite *BaseFinderUnitSuite) TestGetBases() {
 				newBackupModel(testBackup1, true, true, false, nil, nil),
 			},
 		},
+		{
+			name:  "Skips Partial Backup",
+			input: testUser1Mail,
+			manifestData: []manifestInfo{
+				newManifestInfo(
+					testID2,
+					testT2,
+					testCompleteMan,
+					testBackup2,
+					nil,
+					testMail,
+					testUser1),
+				newManifestInfo(
+					testID1,
+					testT1,
+					testCompleteMan,
+					testBackup1,
+					nil,
+					testMail,
+					testUser1),
+			},
+			expectedBaseReasons: map[int][]identity.Reasoner{
+				1: testUser1Mail,
+			},
+			backupData: []backupInfo{
+				newBackupModel(
+					testBackup2,
+					true,
+					true,
+					false,
+					map[string]string{model.BackupTypeTag: model.PartialBackup},
+					nil),
+				newBackupModel(testBackup1, true, true, false, nil, nil),
+			},
+		},
 		{
 			name:  "Newer merge base than assist base",
 			input: testUser1Mail,
@@ -25,6 +25,38 @@ const (
 	tenantTag           = "tenant"
 )
 
+// streamStoreManifests produces the snapshot manifests of every details and
+// errors streamstore in the repository.  Streamstore snapshots are the only
+// snapshots without the backup category tag.
+func streamStoreManifests(
+	ctx context.Context,
+	mf manifestFinder,
+) ([]*manifest.EntryMetadata, error) {
+	snaps, err := mf.FindManifests(
+		ctx,
+		map[string]string{
+			manifest.TypeLabelKey: snapshot.ManifestType,
+		})
+	if err != nil {
+		return nil, clues.Wrap(err, "getting snapshots")
+	}
+
+	var (
+		k, _ = makeTagKV(TagBackupCategory)
+		res  = []*manifest.EntryMetadata{}
+	)
+
+	for _, snap := range snaps {
+		if _, ok := snap.Labels[k]; ok {
+			continue
+		}
+
+		res = append(res, snap)
+	}
+
+	return res, nil
+}
+
 // cleanupOrphanedData uses bs and mf to lookup all models/snapshots for backups
 // and deletes items that are older than nowFunc() - gcBuffer (cutoff) that are
 // not "complete" backups with:
@@ -135,6 +135,33 @@ type backupRes struct {
 	err error
 }
 
+func (suite *BackupCleanupUnitSuite) TestStreamStoreManifests() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	backupTag, _ := makeTagKV(TagBackupCategory)
+
+	mmf := mockManifestFinder{
+		t: t,
+		manifests: []*manifest.EntryMetadata{
+			{ID: "data", Labels: map[string]string{backupTag: "0"}},
+			{ID: "details"},
+			{ID: "errors", Labels: map[string]string{"type": "snapshot"}},
+		},
+	}
+
+	snaps, err := streamStoreManifests(ctx, mmf)
+	assert.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, []*manifest.EntryMetadata{mmf.manifests[1], mmf.manifests[2]}, snaps)
+
+	mmf.err = assert.AnError
+
+	_, err = streamStoreManifests(ctx, mmf)
+	assert.Error(t, err, clues.ToCore(err))
+}
+
 func (suite *BackupCleanupUnitSuite) TestCleanupOrphanedData() {
 	backupTag, _ := makeTagKV(TagBackupCategory)
 
@@ -1,7 +1,9 @@
 package kopia
 
 import (
+	"bytes"
 	"context"
+	"crypto/subtle"
 	"path/filepath"
 	"sync"
 	"time"
@@ -12,6 +14,7 @@ import (
 	"github.com/kopia/kopia/repo/blob"
 	"github.com/kopia/kopia/repo/compression"
 	"github.com/kopia/kopia/repo/content"
+	"github.com/kopia/kopia/repo/content/index"
 	"github.com/kopia/kopia/repo/format"
 	"github.com/kopia/kopia/repo/maintenance"
 	"github.com/kopia/kopia/repo/manifest"
@@ -30,6 +33,10 @@ const (
 	defaultKopiaConfigDir  = "/tmp/"
 	defaultKopiaConfigFile = "repository.config"
 	defaultCompressor      = "zstd-better-compression"
+
+	defaultContentCacheSizeBytes  int64 = 5 << 30 // 5GiB
+	defaultMetadataCacheSizeBytes int64 = 1 << 30 // 1GiB
+
 	// Interval of 0 disables scheduling.
 	defaultSchedulingInterval = time.Second * 0
 )
@@ -37,6 +44,7 @@ const (
 var (
 	ErrSettingDefaultConfig = clues.New("setting default repo config values")
 	ErrorRepoAlreadyExists  = clues.New("repo already exists")
+	ErrorInvalidPassphrase  = clues.New("passphrase does not match the repo")
 )
 
 // Having all fields set to 0 causes it to keep max-int versions of snapshots.
@@ -173,6 +181,210 @@ func (w *conn) Connect(ctx context.Context, opts repository.Options) error {
 		defaultCompressor)
 }
 
+// SetReadOnly reconnects to the repo with the same options, but in
+// read-only mode.  The conn's existing handle is replaced, so anything
+// already built on the conn, such as a Wrapper or ModelStore, uses the
+// read-only connection afterwards.  Must not be called while operations
+// are using the conn.
+func (w *conn) SetReadOnly(ctx context.Context, opts repository.Options) error {
+	w.mu.Lock()
+	prev := w.Repository
+	w.mu.Unlock()
+
+	if prev == nil {
+		return clues.New("conn not established or already closed").WithClues(ctx)
+	}
+
+	opts.ReadOnly = true
+
+	// connecting opens a new handle, which also bumps the refCount.
+	if err := w.Connect(ctx, opts); err != nil {
+		return clues.Wrap(err, "reconnecting as read-only")
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.refCount--
+
+	if err := prev.Close(ctx); err != nil {
+		return clues.Wrap(err, "closing previous repository connection").WithClues(ctx)
+	}
+
+	return nil
+}
+
+// ChangePassphrase re-encrypts the repo's format blob with newPass, after
+// confirming that oldPass is the passphrase the repo is encrypted with.
+// The conn's storage config is updated to hold the new passphrase, so
+// that later connections use it.
+func (w *conn) ChangePassphrase(ctx context.Context, oldPass, newPass string) error {
+	if len(newPass) == 0 {
+		return clues.New("new passphrase is empty").WithClues(ctx)
+	}
+
+	dr, ok := w.Repository.(repo.DirectRepository)
+	if !ok {
+		return clues.New("getting handle to repo").WithClues(ctx)
+	}
+
+	fm := dr.FormatManager()
+
+	if !fm.SupportsPasswordChange() {
+		return clues.New("repo does not support passphrase changes").WithClues(ctx)
+	}
+
+	if err := verifyPassphrase(ctx, dr, oldPass); err != nil {
+		return clues.Stack(err).WithClues(ctx)
+	}
+
+	if err := fm.ChangePassword(ctx, newPass); err != nil {
+		return clues.Wrap(err, "changing repo passphrase").WithClues(ctx)
+	}
+
+	w.storage.SetCorsoPassphrase(newPass)
+
+	return nil
+}
+
+// DeriveKey derives a key of the given length, separated by purpose, from
+// the repo's master key.  The master key is stored in the repo's format
+// blob, and doesn't change when the passphrase changes.
+func (w *conn) DeriveKey(ctx context.Context, purpose []byte, length int) ([]byte, error) {
+	dr, ok := w.Repository.(repo.DirectRepository)
+	if !ok {
+		return nil, clues.New("getting handle to repo").WithClues(ctx)
+	}
+
+	return dr.DeriveKey(purpose, length), nil
+}
+
+// EncryptionInfo describes how the repo's data is encrypted at rest.
+type EncryptionInfo struct {
+	// ContentEncryption is the algorithm that encrypts all content in the
+	// repo, eg: AES256-GCM-HMAC-SHA256.
+	ContentEncryption string
+	// FormatEncryption is the algorithm that encrypts the repo's format
+	// blob, which holds the content encryption key.
+	FormatEncryption string
+	// KeyDerivation is the function, including its parameters, that derives
+	// the format encryption key from the passphrase, eg: scrypt-65536-8-1.
+	KeyDerivation string
+	// HashFunction is the keyed hash that identifies content.
+	HashFunction string
+}
+
+// EncryptionInfo produces the encryption algorithms and key derivation
+// parameters of the repo, as recorded in the repo's format blob.
+func (w *conn) EncryptionInfo(ctx context.Context) (EncryptionInfo, error) {
+	dr, ok := w.Repository.(repo.DirectRepository)
+	if !ok {
+		return EncryptionInfo{}, clues.New("getting handle to repo").WithClues(ctx)
+	}
+
+	var buf formatBuffer
+
+	err := dr.BlobReader().GetBlob(ctx, format.KopiaRepositoryBlobID, 0, -1, &buf)
+	if err != nil {
+		return EncryptionInfo{}, clues.Wrap(err, "reading repo format").WithClues(ctx)
+	}
+
+	f, err := format.ParseKopiaRepositoryJSON(buf.Bytes())
+	if err != nil {
+		return EncryptionInfo{}, clues.Wrap(err, "parsing repo format").WithClues(ctx)
+	}
+
+	fm := dr.FormatManager()
+
+	return EncryptionInfo{
+		ContentEncryption: fm.GetEncryptionAlgorithm(),
+		FormatEncryption:  f.EncryptionAlgorithm,
+		KeyDerivation:     f.KeyDerivationAlgorithm,
+		HashFunction:      fm.GetHashFunction(),
+	}, nil
+}
+
+// errSampleComplete stops content iteration once enough samples are read.
+var errSampleComplete = clues.New("sample complete")
+
+// SampleContents produces the plaintext of up to limit data contents in
+// the repo.  Contents holding repo metadata, such as manifests and
+// indexes, are left out.
+func (w *conn) SampleContents(ctx context.Context, limit int) ([][]byte, error) {
+	dr, ok := w.Repository.(repo.DirectRepository)
+	if !ok {
+		return nil, clues.New("getting handle to repo").WithClues(ctx)
+	}
+
+	var (
+		cr      = dr.ContentReader()
+		samples = [][]byte{}
+	)
+
+	if limit <= 0 {
+		return samples, nil
+	}
+
+	err := cr.IterateContents(
+		ctx,
+		content.IterateOptions{Range: index.AllNonPrefixedIDs},
+		func(ci content.Info) error {
+			bs, err := cr.GetContent(ctx, ci.GetContentID())
+			if err != nil {
+				return clues.Wrap(err, "reading content").With("content_id", ci.GetContentID())
+			}
+
+			samples = append(samples, bs)
+
+			if len(samples) >= limit {
+				return errSampleComplete
+			}
+
+			return nil
+		})
+	if err != nil && !errors.Is(err, errSampleComplete) {
+		return nil, clues.Wrap(err, "sampling repo contents").WithClues(ctx)
+	}
+
+	return samples, nil
+}
+
+// verifyPassphrase derives the format encryption key from pass, and
+// compares it to the key the repo was opened with.
+func verifyPassphrase(ctx context.Context, dr repo.DirectRepository, pass string) error {
+	var buf formatBuffer
+
+	err := dr.BlobReader().GetBlob(ctx, format.KopiaRepositoryBlobID, 0, -1, &buf)
+	if err != nil {
+		return clues.Wrap(err, "reading repo format")
+	}
+
+	f, err := format.ParseKopiaRepositoryJSON(buf.Bytes())
+	if err != nil {
+		return clues.Wrap(err, "parsing repo format")
+	}
+
+	key, err := f.DeriveFormatEncryptionKeyFromPassword(pass)
+	if err != nil {
+		return clues.Wrap(err, "deriving passphrase key")
+	}
+
+	if subtle.ConstantTimeCompare(key, dr.FormatManager().FormatEncryptionKey()) != 1 {
+		return clues.Stack(ErrorInvalidPassphrase)
+	}
+
+	return nil
+}
+
+// formatBuffer adapts a bytes.Buffer to kopia's blob.OutputBuffer.
+type formatBuffer struct {
+	bytes.Buffer
+}
+
+func (fb *formatBuffer) Length() int {
+	return fb.Len()
+}
+
 func (w *conn) commonConnect(
 	ctx context.Context,
 	opts repository.Options,
@@ -189,9 +401,7 @@ func (w *conn) commonConnect(
 	}
 
 	if len(configDir) > 0 {
-		kopiaOpts.CachingOptions = content.CachingOptions{
-			CacheDirectory: configDir,
-		}
+		kopiaOpts.CachingOptions = cachingOptions(configDir, opts)
 	} else {
 		configDir = defaultKopiaConfigDir
 	}
@@ -215,6 +425,26 @@ func (w *conn) commonConnect(
 	return nil
 }
 
+// cachingOptions builds the kopia cache settings for the given cache
+// directory, filling in default size limits where opts doesn't set one.
+func cachingOptions(cacheDir string, opts repository.Options) content.CachingOptions {
+	contentSize := opts.ContentCacheSizeBytes
+	if contentSize <= 0 {
+		contentSize = defaultContentCacheSizeBytes
+	}
+
+	metadataSize := opts.MetadataCacheSizeBytes
+	if metadataSize <= 0 {
+		metadataSize = defaultMetadataCacheSizeBytes
+	}
+
+	return content.CachingOptions{
+		CacheDirectory:            cacheDir,
+		MaxCacheSizeBytes:         contentSize,
+		MaxMetadataCacheSizeBytes: metadataSize,
+	}
+}
+
 func blobStoreByProvider(
 	ctx context.Context,
 	opts repository.Options,
@@ -486,6 +716,22 @@ func (w *conn) setRetentionParameters(
 	return clues.Stack(persistRetentionConfigs(ctx, dr, opts)).OrNil()
 }
 
+// retentionStatus produces the retention settings currently applied to
+// the repo.
+func (w *conn) retentionStatus(ctx context.Context) (repository.Retention, error) {
+	dr, ok := w.Repository.(repo.DirectRepository)
+	if !ok {
+		return repository.Retention{}, clues.New("getting handle to repo").WithClues(ctx)
+	}
+
+	blobCfg, params, err := getRetentionConfigs(ctx, dr)
+	if err != nil {
+		return repository.Retention{}, clues.Stack(err)
+	}
+
+	return retention.OptsFromConfigs(*blobCfg, *params).Retention(), nil
+}
+
 func getRetentionConfigs(
 	ctx context.Context,
 	dr repo.DirectRepository,
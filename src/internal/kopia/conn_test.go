@@ -9,6 +9,9 @@ import (
 	"github.com/alcionai/clues"
 	"github.com/kopia/kopia/repo"
 	"github.com/kopia/kopia/repo/blob"
+	"github.com/kopia/kopia/repo/encryption"
+	"github.com/kopia/kopia/repo/format"
+	"github.com/kopia/kopia/repo/hashing"
 	"github.com/kopia/kopia/snapshot"
 	"github.com/kopia/kopia/snapshot/policy"
 	"github.com/stretchr/testify/assert"
@@ -60,6 +63,49 @@ func (suite *WrapperUnitSuite) TestCloseWithoutOpenDoesNotCrash() {
 	})
 }
 
+func (suite *WrapperUnitSuite) TestCachingOptions() {
+	table := []struct {
+		name           string
+		opts           repository.Options
+		expectContent  int64
+		expectMetadata int64
+	}{
+		{
+			name:           "defaults",
+			expectContent:  defaultContentCacheSizeBytes,
+			expectMetadata: defaultMetadataCacheSizeBytes,
+		},
+		{
+			name: "configured",
+			opts: repository.Options{
+				ContentCacheSizeBytes:  100,
+				MetadataCacheSizeBytes: 10,
+			},
+			expectContent:  100,
+			expectMetadata: 10,
+		},
+		{
+			name: "negative values use defaults",
+			opts: repository.Options{
+				ContentCacheSizeBytes:  -1,
+				MetadataCacheSizeBytes: -1,
+			},
+			expectContent:  defaultContentCacheSizeBytes,
+			expectMetadata: defaultMetadataCacheSizeBytes,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			co := cachingOptions("/cache/dir", test.opts)
+			assert.Equal(t, "/cache/dir", co.CacheDirectory)
+			assert.Equal(t, test.expectContent, co.MaxCacheSizeBytes)
+			assert.Equal(t, test.expectMetadata, co.MaxMetadataCacheSizeBytes)
+		})
+	}
+}
+
 // ---------------
 // integration tests that use kopia
 // ---------------
@@ -397,6 +443,52 @@ func (suite *WrapperIntegrationSuite) TestInitAndConnWithTempDirectory() {
 	assert.NoError(t, err, clues.ToCore(err))
 }
 
+func (suite *WrapperIntegrationSuite) TestEncryptionInfo() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	k, err := openKopiaRepo(t, ctx)
+	require.NoError(t, err, clues.ToCore(err))
+
+	defer func() {
+		err := k.Close(ctx)
+		assert.NoError(t, err, clues.ToCore(err))
+	}()
+
+	ei, err := k.EncryptionInfo(ctx)
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, encryption.DefaultAlgorithm, ei.ContentEncryption)
+	assert.NotEmpty(t, ei.FormatEncryption)
+	assert.Equal(t, format.DefaultKeyDerivationAlgorithm, ei.KeyDerivation)
+	assert.Equal(t, hashing.DefaultAlgorithm, ei.HashFunction)
+}
+
+func (suite *WrapperIntegrationSuite) TestSampleContents() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	k, err := openKopiaRepo(t, ctx)
+	require.NoError(t, err, clues.ToCore(err))
+
+	defer func() {
+		err := k.Close(ctx)
+		assert.NoError(t, err, clues.ToCore(err))
+	}()
+
+	samples, err := k.SampleContents(ctx, 2)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.LessOrEqual(t, len(samples), 2)
+
+	samples, err = k.SampleContents(ctx, 0)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Empty(t, samples)
+}
+
 func (suite *WrapperIntegrationSuite) TestSetUserAndHost() {
 	t := suite.T()
 
@@ -518,4 +610,20 @@ func (suite *ConnRetentionIntegrationSuite) TestInitWithAndWithoutRetention() {
 
 	// Some checks to make sure retention was fully initialized as expected.
 	checkRetentionParams(t, ctx, k2, blob.Governance, time.Hour*48, assert.True)
+
+	rs1, err := k1.retentionStatus(ctx)
+	require.NoError(t, err, "getting retention status 1: %v", clues.ToCore(err))
+	assert.Equal(t, repository.NoRetention, ptr.Val(rs1.Mode), "retention status 1 mode")
+
+	rs2, err := k2.retentionStatus(ctx)
+	require.NoError(t, err, "getting retention status 2: %v", clues.ToCore(err))
+	assert.Equal(
+		t,
+		repository.Retention{
+			Mode:     ptr.To(repository.GovernanceRetention),
+			Duration: ptr.To(time.Hour * 48),
+			Extend:   ptr.To(true),
+		},
+		rs2,
+		"retention status 2")
 }
@@ -3,9 +3,11 @@ package kopia
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/alcionai/clues"
 	"github.com/kopia/kopia/fs"
+	"github.com/kopia/kopia/repo/object"
 
 	"github.com/alcionai/corso/src/internal/data"
 	"github.com/alcionai/corso/src/pkg/fault"
@@ -16,6 +18,8 @@ import (
 var (
 	_ data.RestoreCollection = &kopiaDataCollection{}
 	_ data.Item              = &kopiaDataStream{}
+	_ data.ItemModTime       = &kopiaDataStream{}
+	_ data.ItemContentHash   = &kopiaDataStream{}
 )
 
 type kopiaDataCollection struct {
@@ -118,20 +122,31 @@ func (kdc kopiaDataCollection) FetchItemByName(
 		return nil, clues.Wrap(err, "opening file").WithClues(ctx)
 	}
 
+	// kopia addresses file content by its hash, so the object ID
+	// doubles as a content hash.
+	var contentHash string
+	if oid, ok := f.(object.HasObjectID); ok {
+		contentHash = oid.ObjectID().String()
+	}
+
 	return &kopiaDataStream{
 		id: name,
 		reader: &restoreStreamReader{
 			ReadCloser:      r,
 			expectedVersion: kdc.expectedVersion,
 		},
-		size: size,
+		size:        size,
+		modTime:     f.ModTime(),
+		contentHash: contentHash,
 	}, nil
 }
 
 type kopiaDataStream struct {
-	reader io.ReadCloser
-	id     string
-	size   int64
+	reader      io.ReadCloser
+	id          string
+	size        int64
+	modTime     time.Time
+	contentHash string
 }
 
 func (kds kopiaDataStream) ToReader() io.ReadCloser {
@@ -149,3 +164,11 @@ func (kds kopiaDataStream) Deleted() bool {
 func (kds kopiaDataStream) Size() int64 {
 	return kds.size
 }
+
+func (kds kopiaDataStream) ModTime() time.Time {
+	return kds.modTime
+}
+
+func (kds kopiaDataStream) ContentHash() string {
+	return kds.contentHash
+}
@@ -0,0 +1,244 @@
+package kopia
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/kopia/kopia/fs"
+	"github.com/kopia/kopia/repo"
+	"github.com/kopia/kopia/repo/blob"
+	"github.com/kopia/kopia/repo/content"
+	"github.com/kopia/kopia/repo/manifest"
+	"github.com/kopia/kopia/repo/object"
+	"github.com/kopia/kopia/snapshot"
+	"github.com/kopia/kopia/snapshot/snapshotfs"
+
+	"github.com/alcionai/corso/src/pkg/control/repository"
+	"github.com/alcionai/corso/src/pkg/logger"
+)
+
+// blobGCParallelism matches kopia's default when deleting unreferenced
+// blobs.
+const blobGCParallelism = 16
+
+// MaintenanceCandidates describes the repo data that complete maintenance
+// would delete.
+type MaintenanceCandidates struct {
+	// Unreferenced contents aren't used by any snapshot, and are old enough
+	// to be deleted.
+	UnreferencedContents     int64
+	UnreferencedContentBytes int64
+	// Unreferenced blobs hold no contents known to the index, and are old
+	// enough to be deleted.
+	UnreferencedBlobs     int64
+	UnreferencedBlobBytes int64
+}
+
+// MaintenanceCandidates finds the contents and blobs that complete
+// maintenance would delete at the given safety level.  Nothing in the repo
+// gets deleted or modified.
+func (w Wrapper) MaintenanceCandidates(
+	ctx context.Context,
+	opts repository.Maintenance,
+) (MaintenanceCandidates, error) {
+	if w.c == nil {
+		return MaintenanceCandidates{}, clues.Stack(errNotConnected).WithClues(ctx)
+	}
+
+	if opts.Type != repository.CompleteMaintenance {
+		return MaintenanceCandidates{}, clues.New("only complete maintenance deletes data").
+			With("input_maintenance_type", opts.Type.String()).
+			WithClues(ctx)
+	}
+
+	kopiaSafety, err := translateSafety(opts.Safety)
+	if err != nil {
+		return MaintenanceCandidates{}, clues.Wrap(err, "identifying safety level").WithClues(ctx)
+	}
+
+	ctx = clues.Add(ctx, "kopia_safety", kopiaSafety)
+
+	dr, ok := w.c.Repository.(repo.DirectRepository)
+	if !ok {
+		return MaintenanceCandidates{}, clues.New("unable to get valid handle to repo").WithClues(ctx)
+	}
+
+	var (
+		mc  MaintenanceCandidates
+		now = dr.Time()
+	)
+
+	used, err := inUseContents(ctx, dr)
+	if err != nil {
+		return MaintenanceCandidates{}, clues.Wrap(err, "finding in-use contents").WithClues(ctx)
+	}
+
+	// follows kopia's snapshot garbage collection, minus the deletions.
+	err = dr.ContentReader().IterateContents(
+		ctx,
+		content.IterateOptions{},
+		func(ci content.Info) error {
+			if ci.GetContentID().Prefix() == manifest.ContentPrefix {
+				return nil
+			}
+
+			if _, ok := used[ci.GetContentID()]; ok {
+				return nil
+			}
+
+			if now.Sub(ci.Timestamp()) < kopiaSafety.MinContentAgeSubjectToGC {
+				return nil
+			}
+
+			mc.UnreferencedContents++
+			mc.UnreferencedContentBytes += int64(ci.GetPackedLength())
+
+			return nil
+		})
+	if err != nil {
+		return MaintenanceCandidates{}, clues.Wrap(err, "finding unreferenced contents").WithClues(ctx)
+	}
+
+	// listing unreferenced blobs requires a writer.  The writer is closed
+	// without flushing, so nothing gets written to the repo.
+	wctx, dw, err := dr.NewDirectWriter(ctx, repo.WriteSessionOptions{Purpose: "Corso maintenance preview"})
+	if err != nil {
+		return MaintenanceCandidates{}, clues.Wrap(err, "opening maintenance preview session").WithClues(ctx)
+	}
+
+	defer func() {
+		if err := dw.Close(wctx); err != nil {
+			logger.CtxErr(ctx, err).Info("closing maintenance preview session")
+		}
+	}()
+
+	blobs, blobBytes, err := unreferencedBlobs(
+		wctx,
+		dw,
+		now,
+		kopiaSafety.BlobDeleteMinAge,
+		kopiaSafety.SessionExpirationAge)
+	if err != nil {
+		return MaintenanceCandidates{}, clues.Wrap(err, "finding unreferenced blobs").WithClues(ctx)
+	}
+
+	mc.UnreferencedBlobs = blobs
+	mc.UnreferencedBlobBytes = blobBytes
+
+	return mc, nil
+}
+
+// inUseContents produces the ids of every content used by a snapshot in
+// the repo.
+func inUseContents(
+	ctx context.Context,
+	dr repo.DirectRepository,
+) (map[content.ID]struct{}, error) {
+	ids, err := snapshot.ListSnapshotManifests(ctx, dr, nil, nil)
+	if err != nil {
+		return nil, clues.Wrap(err, "listing snapshots")
+	}
+
+	mans, err := snapshot.LoadSnapshots(ctx, dr, ids)
+	if err != nil {
+		return nil, clues.Wrap(err, "loading snapshots")
+	}
+
+	var (
+		mu   sync.Mutex
+		used = map[content.ID]struct{}{}
+	)
+
+	// the walker calls back from many goroutines.
+	walker, err := snapshotfs.NewTreeWalker(ctx, snapshotfs.TreeWalkerOptions{
+		EntryCallback: func(ctx context.Context, _ fs.Entry, oid object.ID, _ string) error {
+			cids, err := dr.VerifyObject(ctx, oid)
+			if err != nil {
+				return clues.Wrap(err, "verifying object")
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			for _, cid := range cids {
+				used[cid] = struct{}{}
+			}
+
+			return nil
+		},
+	})
+	if err != nil {
+		return nil, clues.Wrap(err, "creating snapshot walker")
+	}
+
+	defer walker.Close(ctx)
+
+	for _, man := range mans {
+		root, err := snapshotfs.SnapshotRoot(dr, man)
+		if err != nil {
+			return nil, clues.Wrap(err, "getting snapshot root").With("snapshot_id", man.ID)
+		}
+
+		if err := walker.Process(ctx, root, ""); err != nil {
+			return nil, clues.Wrap(err, "walking snapshot").With("snapshot_id", man.ID)
+		}
+	}
+
+	return used, nil
+}
+
+// unreferencedBlobs counts the blobs, and their bytes, that kopia's blob
+// garbage collection would delete.
+func unreferencedBlobs(
+	ctx context.Context,
+	dw repo.DirectRepositoryWriter,
+	now time.Time,
+	minAge, sessionExpiration time.Duration,
+) (int64, int64, error) {
+	cm := dw.ContentManager()
+
+	sessions, err := cm.ListActiveSessions(ctx)
+	if err != nil {
+		return 0, 0, clues.Wrap(err, "listing active sessions")
+	}
+
+	var (
+		mu           sync.Mutex
+		count, bytes int64
+		prefixes     = []blob.ID{
+			content.PackBlobIDPrefixRegular,
+			content.PackBlobIDPrefixSpecial,
+			content.BlobIDPrefixSession,
+		}
+	)
+
+	err = cm.IterateUnreferencedBlobs(
+		ctx,
+		prefixes,
+		blobGCParallelism,
+		func(bm blob.Metadata) error {
+			if now.Sub(bm.Timestamp) < minAge {
+				return nil
+			}
+
+			sid := content.SessionIDFromBlobID(bm.BlobID)
+			if s, ok := sessions[sid]; ok && now.Sub(s.CheckpointTime) < sessionExpiration {
+				return nil
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			count++
+			bytes += bm.Length
+
+			return nil
+		})
+	if err != nil {
+		return 0, 0, clues.Stack(err)
+	}
+
+	return count, bytes, nil
+}
@@ -13,6 +13,9 @@ import (
 type DetailsMergeInfoer interface {
 	// ItemsToMerge returns the number of items that need to be merged.
 	ItemsToMerge() int
+	// OptionalItemsToMerge returns the number of items that are merged only
+	// if the base backup has details for them.
+	OptionalItemsToMerge() int
 	// GetNewPathRefs takes the old RepoRef and old LocationRef of an item and
 	// returns the new RepoRef and the new location of the item the item. If the
 	// item shouldn't be merged nils are returned.
@@ -32,6 +35,9 @@ type prevRef struct {
 type mergeDetails struct {
 	repoRefs  map[string]prevRef
 	locations *locationPrefixMatcher
+	// optional counts the entries in repoRefs that may not have details in
+	// the base backup.
+	optional int
 }
 
 func (m *mergeDetails) ItemsToMerge() int {
@@ -39,7 +45,15 @@ func (m *mergeDetails) ItemsToMerge() int {
 		return 0
 	}
 
-	return len(m.repoRefs)
+	return len(m.repoRefs) - m.optional
+}
+
+func (m *mergeDetails) OptionalItemsToMerge() int {
+	if m == nil {
+		return 0
+	}
+
+	return m.optional
 }
 
 // addRepoRef adds an entry in mergeDetails that can be looked up later. If
@@ -70,6 +84,24 @@ func (m *mergeDetails) addRepoRef(
 	return nil
 }
 
+// addOptionalRepoRef is like addRepoRef, but the entry isn't counted in
+// ItemsToMerge.  It's used for items that only some backups record in
+// details, such as the metadata of preserved empty folders.
+func (m *mergeDetails) addOptionalRepoRef(
+	oldRef *path.Builder,
+	modTime *time.Time,
+	newRef path.Path,
+	newLocRef *path.Builder,
+) error {
+	if err := m.addRepoRef(oldRef, modTime, newRef, newLocRef); err != nil {
+		return err
+	}
+
+	m.optional++
+
+	return nil
+}
+
 func (m *mergeDetails) GetNewPathRefs(
 	oldRef *path.Builder,
 	modTime time.Time,
@@ -55,6 +55,49 @@ func (suite *DetailsMergeInfoerUnitSuite) TestAddRepoRef_DuplicateFails() {
 	require.Error(t, err, clues.ToCore(err))
 }
 
+func (suite *DetailsMergeInfoerUnitSuite) TestAddOptionalRepoRef() {
+	t := suite.T()
+
+	oldRef1 := makePath(
+		t,
+		[]string{
+			testTenant,
+			service,
+			testUser,
+			category,
+			"folder1",
+		},
+		false)
+	oldRef2 := makePath(
+		t,
+		[]string{
+			testTenant,
+			service,
+			testUser,
+			category,
+			"folder2",
+		},
+		false)
+
+	dm := newMergeDetails()
+
+	err := dm.addRepoRef(oldRef1.ToBuilder(), nil, oldRef1, nil)
+	require.NoError(t, err, clues.ToCore(err))
+
+	err = dm.addOptionalRepoRef(oldRef2.ToBuilder(), nil, oldRef2, oldRef2.ToBuilder())
+	require.NoError(t, err, clues.ToCore(err))
+
+	err = dm.addOptionalRepoRef(oldRef2.ToBuilder(), nil, oldRef2, oldRef2.ToBuilder())
+	require.Error(t, err, clues.ToCore(err))
+
+	assert.Equal(t, 1, dm.ItemsToMerge(), "required items")
+	assert.Equal(t, 1, dm.OptionalItemsToMerge(), "optional items")
+
+	got, _, err := dm.GetNewPathRefs(oldRef2.ToBuilder(), time.Now(), nil)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, oldRef2, got, "optional item lookup")
+}
+
 // TestRepoRefs is a basic sanity test to ensure lookups are working properly
 // for stored RepoRefs.
 func (suite *DetailsMergeInfoerUnitSuite) TestGetNewPathRefs() {
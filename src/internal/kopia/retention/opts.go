@@ -9,6 +9,7 @@ import (
 	"github.com/kopia/kopia/repo/format"
 	"github.com/kopia/kopia/repo/maintenance"
 
+	"github.com/alcionai/corso/src/internal/common/ptr"
 	"github.com/alcionai/corso/src/pkg/control/repository"
 )
 
@@ -49,6 +50,27 @@ func (r *Opts) AsConfigs(
 	return r.blobCfg, r.params, nil
 }
 
+// Retention produces the retention settings held in the configs.  Unlike
+// the settings given to Set, every field is populated.
+func (r *Opts) Retention() repository.Retention {
+	mode := repository.UnknownRetention
+
+	switch r.blobCfg.RetentionMode {
+	case "":
+		mode = repository.NoRetention
+	case blob.Governance:
+		mode = repository.GovernanceRetention
+	case blob.Compliance:
+		mode = repository.ComplianceRetention
+	}
+
+	return repository.Retention{
+		Mode:     &mode,
+		Duration: ptr.To(r.blobCfg.RetentionPeriod),
+		Extend:   ptr.To(r.params.ExtendObjectLocks),
+	}
+}
+
 func (r *Opts) BlobChanged() bool {
 	return r.blobChanged
 }
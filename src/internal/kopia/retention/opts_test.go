@@ -55,6 +55,68 @@ func (suite *OptsUnitSuite) TestOptsFromConfigs() {
 	assert.Equal(t, paramsInput, params)
 }
 
+func (suite *OptsUnitSuite) TestRetention() {
+	table := []struct {
+		name     string
+		ctrlOpts repository.Retention
+		expect   repository.Retention
+	}{
+		{
+			name: "no retention",
+			expect: repository.Retention{
+				Mode:     ptr.To(repository.NoRetention),
+				Duration: ptr.To(time.Duration(0)),
+				Extend:   ptr.To(false),
+			},
+		},
+		{
+			name: "governance",
+			ctrlOpts: repository.Retention{
+				Mode:     ptr.To(repository.GovernanceRetention),
+				Duration: ptr.To(time.Hour * 48),
+				Extend:   ptr.To(true),
+			},
+			expect: repository.Retention{
+				Mode:     ptr.To(repository.GovernanceRetention),
+				Duration: ptr.To(time.Hour * 48),
+				Extend:   ptr.To(true),
+			},
+		},
+		{
+			name: "compliance",
+			ctrlOpts: repository.Retention{
+				Mode:     ptr.To(repository.ComplianceRetention),
+				Duration: ptr.To(time.Hour * 72),
+			},
+			expect: repository.Retention{
+				Mode:     ptr.To(repository.ComplianceRetention),
+				Duration: ptr.To(time.Hour * 72),
+				Extend:   ptr.To(false),
+			},
+		},
+	}
+
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			opts := retention.NewOpts()
+			err := opts.Set(test.ctrlOpts)
+			require.NoError(t, err, "setting params: %v", clues.ToCore(err))
+
+			// round trip through the configs, as they'd be persisted.
+			blobCfg, params, err := opts.AsConfigs(ctx)
+			require.NoError(t, err, "getting configs: %v", clues.ToCore(err))
+
+			result := retention.OptsFromConfigs(blobCfg, params).Retention()
+			assert.Equal(t, test.expect, result)
+		})
+	}
+}
+
 func (suite *OptsUnitSuite) TestSet() {
 	var (
 		kopiaMode = blob.Governance
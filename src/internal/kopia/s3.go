@@ -2,12 +2,15 @@ package kopia
 
 import (
 	"context"
+	"crypto/x509"
+	"os"
 
 	"github.com/alcionai/clues"
 	"github.com/kopia/kopia/repo/blob"
 	"github.com/kopia/kopia/repo/blob/s3"
 
 	"github.com/alcionai/corso/src/pkg/control/repository"
+	"github.com/alcionai/corso/src/pkg/logger"
 	"github.com/alcionai/corso/src/pkg/storage"
 )
 
@@ -20,11 +23,31 @@ func s3BlobStorage(
 	repoOpts repository.Options,
 	s storage.Storage,
 ) (blob.Storage, error) {
-	sc, err := s.StorageConfig()
+	opts, err := s3Options(ctx, repoOpts, s)
+	if err != nil {
+		return nil, err
+	}
+
+	store, err := s3.New(ctx, &opts, false)
 	if err != nil {
 		return nil, clues.Stack(err).WithClues(ctx)
 	}
 
+	return store, nil
+}
+
+// s3Options builds the kopia s3 options, which kopia uses to configure
+// the HTTP transport of the s3 client.
+func s3Options(
+	ctx context.Context,
+	repoOpts repository.Options,
+	s storage.Storage,
+) (s3.Options, error) {
+	sc, err := s.StorageConfig()
+	if err != nil {
+		return s3.Options{}, clues.Stack(err).WithClues(ctx)
+	}
+
 	cfg := sc.(*storage.S3Config)
 
 	endpoint := defaultS3Endpoint
@@ -37,7 +60,7 @@ func s3BlobStorage(
 		Endpoint:            endpoint,
 		Prefix:              cfg.Prefix,
 		DoNotUseTLS:         cfg.DoNotUseTLS,
-		DoNotVerifyTLS:      cfg.DoNotVerifyTLS,
+		DoNotVerifyTLS:      cfg.DoNotVerifyTLS || cfg.InsecureSkipVerify,
 		Tags:                s.SessionTags,
 		SessionName:         s.SessionName,
 		RoleARN:             s.Role,
@@ -49,10 +72,26 @@ func s3BlobStorage(
 		PointInTime:         repoOpts.ViewTimestamp,
 	}
 
-	store, err := s3.New(ctx, &opts, false)
-	if err != nil {
-		return nil, clues.Stack(err).WithClues(ctx)
+	if opts.DoNotVerifyTLS && !opts.DoNotUseTLS {
+		logger.Ctx(ctx).Warn("s3 endpoint tls certificate verification is disabled")
 	}
 
-	return store, nil
+	if len(cfg.CABundlePath) > 0 {
+		ctx = clues.Add(ctx, "ca_bundle_path", cfg.CABundlePath)
+
+		ca, err := os.ReadFile(cfg.CABundlePath)
+		if err != nil {
+			return s3.Options{}, clues.Wrap(err, "reading s3 ca bundle").WithClues(ctx)
+		}
+
+		// kopia only reports an unparsable bundle once it connects, so
+		// catch it here with a clearer error.
+		if !x509.NewCertPool().AppendCertsFromPEM(ca) {
+			return s3.Options{}, clues.New("s3 ca bundle contains no pem certificates").WithClues(ctx)
+		}
+
+		opts.RootCA = ca
+	}
+
+	return opts, nil
 }
@@ -0,0 +1,193 @@
+package kopia
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/kopia/kopia/repo/blob/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control/repository"
+	"github.com/alcionai/corso/src/pkg/credentials"
+	"github.com/alcionai/corso/src/pkg/storage"
+)
+
+type S3UnitSuite struct {
+	tester.Suite
+}
+
+func TestS3UnitSuite(t *testing.T) {
+	suite.Run(t, &S3UnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+// newTLSBucketServer produces a server that acts as an empty s3 bucket,
+// and writes its certificate to a pem file.
+func newTLSBucketServer(t *testing.T) (*httptest.Server, string) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.URL.Query()["location"]; ok {
+			w.Header().Set("Content-Type", "application/xml")
+			_, _ = w.Write([]byte(`<LocationConstraint>us-east-1</LocationConstraint>`))
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`<Error><Code>NoSuchKey</Code><Message>not found</Message></Error>`))
+	}))
+	t.Cleanup(srv.Close)
+
+	ca := pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: srv.Certificate().Raw,
+	})
+
+	fp := filepath.Join(t.TempDir(), "ca.pem")
+
+	err := os.WriteFile(fp, ca, 0o600)
+	require.NoError(t, err, clues.ToCore(err))
+
+	return srv, fp
+}
+
+func s3TestStorage(t *testing.T, cfg storage.S3Config) storage.Storage {
+	cfg.Bucket = "bucket"
+	cfg.AWS = credentials.AWS{AccessKey: "access", SecretKey: "secret"}
+
+	st, err := storage.NewStorage(storage.ProviderS3, &cfg)
+	require.NoError(t, err, clues.ToCore(err))
+
+	return st
+}
+
+func (suite *S3UnitSuite) TestS3Options_TLS() {
+	t := suite.T()
+	_, caPath := newTLSBucketServer(t)
+
+	ca, err := os.ReadFile(caPath)
+	require.NoError(t, err, clues.ToCore(err))
+
+	badPath := filepath.Join(t.TempDir(), "bad.pem")
+
+	err = os.WriteFile(badPath, []byte("not a certificate"), 0o600)
+	require.NoError(t, err, clues.ToCore(err))
+
+	table := []struct {
+		name         string
+		cfg          storage.S3Config
+		expectErr    assert.ErrorAssertionFunc
+		expectRootCA []byte
+		expectSkip   bool
+	}{
+		{
+			name:      "defaults",
+			expectErr: assert.NoError,
+		},
+		{
+			name:         "ca bundle",
+			cfg:          storage.S3Config{CABundlePath: caPath},
+			expectErr:    assert.NoError,
+			expectRootCA: ca,
+		},
+		{
+			name:       "insecure skip verify",
+			cfg:        storage.S3Config{InsecureSkipVerify: true},
+			expectErr:  assert.NoError,
+			expectSkip: true,
+		},
+		{
+			name:       "do not verify tls",
+			cfg:        storage.S3Config{DoNotVerifyTLS: true},
+			expectErr:  assert.NoError,
+			expectSkip: true,
+		},
+		{
+			name:      "missing ca bundle",
+			cfg:       storage.S3Config{CABundlePath: filepath.Join(t.TempDir(), "missing.pem")},
+			expectErr: assert.Error,
+		},
+		{
+			name:      "unparsable ca bundle",
+			cfg:       storage.S3Config{CABundlePath: badPath},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			opts, err := s3Options(ctx, repository.Options{}, s3TestStorage(t, test.cfg))
+			test.expectErr(t, err, clues.ToCore(err))
+
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, test.expectRootCA, opts.RootCA, "root ca")
+			assert.Equal(t, test.expectSkip, opts.DoNotVerifyTLS, "skip verification")
+		})
+	}
+}
+
+// TestS3BlobStorage_TLSTransport checks that kopia's s3 client connects to
+// an endpoint with a private certificate only when it's configured to.
+func (suite *S3UnitSuite) TestS3BlobStorage_TLSTransport() {
+	table := []struct {
+		name      string
+		cfg       func(caPath string) storage.S3Config
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "untrusted certificate",
+			cfg: func(string) storage.S3Config {
+				return storage.S3Config{}
+			},
+			expectErr: assert.Error,
+		},
+		{
+			name: "ca bundle",
+			cfg: func(caPath string) storage.S3Config {
+				return storage.S3Config{CABundlePath: caPath}
+			},
+			expectErr: assert.NoError,
+		},
+		{
+			name: "insecure skip verify",
+			cfg: func(string) storage.S3Config {
+				return storage.S3Config{InsecureSkipVerify: true}
+			},
+			expectErr: assert.NoError,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			srv, caPath := newTLSBucketServer(t)
+
+			cfg := test.cfg(caPath)
+			cfg.Endpoint = strings.TrimPrefix(srv.URL, "https://")
+
+			opts, err := s3Options(ctx, repository.Options{}, s3TestStorage(t, cfg))
+			require.NoError(t, err, clues.ToCore(err))
+
+			_, err = s3.New(ctx, &opts, false)
+			test.expectErr(t, err, clues.ToCore(err))
+		})
+	}
+}
@@ -216,7 +216,14 @@ func (cp *corsoProgress) FinishedFile(relativePath string, err error) {
 		cp.mu.Lock()
 		defer cp.mu.Unlock()
 
-		err := cp.toMerge.addRepoRef(
+		// Folder metadata only has details in backups that preserve empty
+		// folders.
+		addRef := cp.toMerge.addRepoRef
+		if metadata.IsFolderMetadataFile(d.repoPath) {
+			addRef = cp.toMerge.addOptionalRepoRef
+		}
+
+		err := addRef(
 			d.prevPath.ToBuilder(),
 			d.modTime,
 			d.repoPath,
@@ -562,12 +569,14 @@ func streamBaseEntries(
 			}
 
 			// Meta files aren't in backup details since it's the set of items the
-			// user sees.
+			// user sees.  The exception is folder metadata, which backups that
+			// preserve empty folders record in details, so it gets merged if the
+			// base backup has an entry for it.
 			//
 			// TODO(ashmrtn): We may eventually want to make this a function that is
 			// passed in so that we can more easily switch it between different
 			// external service provider implementations.
-			if !metadata.IsMetadataFile(itemPath) {
+			if !metadata.IsMetadataFile(itemPath) || metadata.IsFolderMetadataFile(itemPath) {
 				// All items have item info in the base backup. However, we need to make
 				// sure we have enough metadata to find those entries. To do that we add
 				// the item to progress and having progress aggregate everything for
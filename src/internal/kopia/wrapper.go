@@ -8,8 +8,10 @@ import (
 	"github.com/alcionai/clues"
 	"github.com/kopia/kopia/fs"
 	"github.com/kopia/kopia/repo"
+	"github.com/kopia/kopia/repo/content"
 	"github.com/kopia/kopia/repo/maintenance"
 	"github.com/kopia/kopia/repo/manifest"
+	"github.com/kopia/kopia/repo/object"
 	"github.com/kopia/kopia/snapshot"
 	"github.com/kopia/kopia/snapshot/policy"
 	"github.com/kopia/kopia/snapshot/snapshotfs"
@@ -57,6 +59,11 @@ type BackupStats struct {
 	TotalUploadedBytes        int64
 	TotalNonMetaUploadedBytes int64
 
+	// SnapshotBytes and SnapshotStoredBytes are the sizes of the data
+	// referenced by the snapshot before and after kopia compressed it.
+	SnapshotBytes       int64
+	SnapshotStoredBytes int64
+
 	TotalFileCount        int
 	TotalNonMetaFileCount int
 	CachedFileCount       int
@@ -130,6 +137,54 @@ func (w *Wrapper) Close(ctx context.Context) error {
 	return nil
 }
 
+// SetReadOnly reconnects the Wrapper's repo connection in read-only mode.
+// The connection is shared with any ModelStore built from the same conn.
+func (w *Wrapper) SetReadOnly(ctx context.Context, opts repository.Options) error {
+	if w.c == nil {
+		return clues.New("kopia wrapper closed").WithClues(ctx)
+	}
+
+	return clues.Stack(w.c.SetReadOnly(ctx, opts)).OrNil()
+}
+
+// ChangePassphrase re-encrypts the repo with newPass.  The connection is
+// shared with any ModelStore built from the same conn.
+func (w *Wrapper) ChangePassphrase(ctx context.Context, oldPass, newPass string) error {
+	if w.c == nil {
+		return clues.New("kopia wrapper closed").WithClues(ctx)
+	}
+
+	return clues.Stack(w.c.ChangePassphrase(ctx, oldPass, newPass)).OrNil()
+}
+
+// SampleContents produces the plaintext of up to limit data contents
+// in the repo.
+func (w *Wrapper) SampleContents(ctx context.Context, limit int) ([][]byte, error) {
+	if w.c == nil {
+		return nil, clues.New("kopia wrapper closed").WithClues(ctx)
+	}
+
+	return w.c.SampleContents(ctx, limit)
+}
+
+// DeriveKey derives a key of the given length, separated by purpose, from
+// the repo's master key.
+func (w *Wrapper) DeriveKey(ctx context.Context, purpose []byte, length int) ([]byte, error) {
+	if w.c == nil {
+		return nil, clues.New("kopia wrapper closed").WithClues(ctx)
+	}
+
+	return w.c.DeriveKey(ctx, purpose, length)
+}
+
+func (w *Wrapper) EncryptionInfo(ctx context.Context) (EncryptionInfo, error) {
+	if w.c == nil {
+		return EncryptionInfo{}, clues.New("kopia wrapper closed").WithClues(ctx)
+	}
+
+	return w.c.EncryptionInfo(ctx)
+}
+
 // ConsumeBackupCollections takes a set of collections and creates a kopia snapshot
 // with the data that they contain. previousSnapshots is used for incremental
 // backups and should represent the base snapshot from which metadata is sourced
@@ -226,8 +281,9 @@ func (w Wrapper) makeSnapshotWithRoot(
 	progress *corsoProgress,
 ) (*BackupStats, error) {
 	var (
-		man *snapshot.Manifest
-		bc  = &stats.ByteCounter{}
+		man             *snapshot.Manifest
+		bc              = &stats.ByteCounter{}
+		logical, stored int64
 	)
 
 	snapIDs := make([]manifest.ID, 0, len(prevSnapEntries)) // just for logging
@@ -319,6 +375,13 @@ func (w Wrapper) makeSnapshotWithRoot(
 				return err
 			}
 
+			// Sizes are only reported, so failing to read them doesn't
+			// fail the backup.
+			logical, stored, err = snapshotSizes(innerCtx, rw, man)
+			if err != nil {
+				logger.CtxErr(innerCtx, err).Info("reading kopia backup snapshot sizes")
+			}
+
 			return nil
 		})
 	// Telling kopia to always flush may hide other errors if it fails while
@@ -328,10 +391,70 @@ func (w Wrapper) makeSnapshotWithRoot(
 	}
 
 	res := manifestToStats(man, progress, bc)
+	res.SnapshotBytes = logical
+	res.SnapshotStoredBytes = stored
 
 	return &res, nil
 }
 
+// snapshotSizes sums the original and packed lengths of every content
+// referenced by the snapshot.  Contents shared by multiple objects in the
+// snapshot are only counted once.
+func snapshotSizes(
+	ctx context.Context,
+	rep repo.Repository,
+	man *snapshot.Manifest,
+) (int64, int64, error) {
+	root, err := snapshotfs.SnapshotRoot(rep, man)
+	if err != nil {
+		return 0, 0, clues.Wrap(err, "getting root directory").WithClues(ctx)
+	}
+
+	var (
+		seen            = map[content.ID]struct{}{}
+		logical, stored int64
+		walk            func(ctx context.Context, e fs.Entry) error
+	)
+
+	walk = func(ctx context.Context, e fs.Entry) error {
+		if hoid, ok := e.(object.HasObjectID); ok {
+			cids, err := rep.VerifyObject(ctx, hoid.ObjectID())
+			if err != nil {
+				return clues.Wrap(err, "getting object contents").With("entry_name", e.Name())
+			}
+
+			for _, cid := range cids {
+				if _, ok := seen[cid]; ok {
+					continue
+				}
+
+				seen[cid] = struct{}{}
+
+				ci, err := rep.ContentInfo(ctx, cid)
+				if err != nil {
+					return clues.Wrap(err, "getting content info").With("content_id", cid)
+				}
+
+				logical += int64(ci.GetOriginalLength())
+				stored += int64(ci.GetPackedLength())
+			}
+		}
+
+		dir, ok := e.(fs.Directory)
+		if !ok {
+			return nil
+		}
+
+		return dir.IterateEntries(ctx, walk)
+	}
+
+	if err := walk(ctx, root); err != nil {
+		return 0, 0, clues.Stack(err).WithClues(ctx)
+	}
+
+	return logical, stored, nil
+}
+
 func (w Wrapper) getSnapshotRoot(
 	ctx context.Context,
 	snapshotID string,
@@ -562,6 +685,17 @@ func (w Wrapper) NewBaseFinder(bg store.BackupGetter) (*baseFinder, error) {
 	return newBaseFinder(w.c, bg)
 }
 
+// StreamStoreManifests produces the snapshot manifests of every details
+// and errors streamstore in the repository, whether or not a backup model
+// still references them.
+func (w Wrapper) StreamStoreManifests(ctx context.Context) ([]*manifest.EntryMetadata, error) {
+	if w.c == nil {
+		return nil, clues.Stack(errNotConnected).WithClues(ctx)
+	}
+
+	return streamStoreManifests(ctx, w.c)
+}
+
 func isErrEntryNotFound(err error) bool {
 	// Calling Child on a directory may return this.
 	if errors.Is(err, fs.ErrEntryNotFound) {
@@ -707,3 +841,13 @@ func (w *Wrapper) SetRetentionParameters(
 ) error {
 	return clues.Stack(w.c.setRetentionParameters(ctx, retention)).OrNil()
 }
+
+// RetentionStatus produces the retention mode, duration, and object lock
+// extension currently configured on the storage bucket.
+func (w *Wrapper) RetentionStatus(ctx context.Context) (repository.Retention, error) {
+	if w.c == nil {
+		return repository.Retention{}, clues.New("kopia wrapper closed").WithClues(ctx)
+	}
+
+	return w.c.retentionStatus(ctx)
+}
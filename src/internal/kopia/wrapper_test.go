@@ -279,6 +279,33 @@ func (suite *BasicKopiaIntegrationSuite) TestMaintenance_WrongUser_Force_Succeed
 	require.NoError(t, err, clues.ToCore(err))
 }
 
+func (suite *BasicKopiaIntegrationSuite) TestMaintenanceCandidates() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	k, err := openKopiaRepo(t, ctx)
+	require.NoError(t, err, clues.ToCore(err))
+
+	w := &Wrapper{k}
+
+	mOpts := repository.Maintenance{
+		Safety: repository.FullMaintenanceSafety,
+		Type:   repository.MetadataMaintenance,
+	}
+
+	_, err = w.MaintenanceCandidates(ctx, mOpts)
+	assert.Error(t, err, "metadata maintenance", clues.ToCore(err))
+
+	mOpts.Type = repository.CompleteMaintenance
+
+	// a new repo holds nothing old enough to delete.
+	mc, err := w.MaintenanceCandidates(ctx, mOpts)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, MaintenanceCandidates{}, mc)
+}
+
 // Test that failing to put the storage blob will skip updating the maintenance
 // manifest too. It's still possible to end up halfway updating the repo config
 // blobs as there's several of them, but at least this gives us something.
@@ -905,6 +932,8 @@ func (suite *KopiaIntegrationSuite) TestBackupCollections() {
 				test.uploadedBytes[1],
 				stats.TotalUploadedBytes,
 				"high end of uploaded bytes")
+			assert.NotZero(t, stats.SnapshotStoredBytes, "snapshot stored bytes")
+			assert.Less(t, stats.SnapshotStoredBytes, stats.SnapshotBytes, "snapshot data is compressed")
 
 			if test.expectMerge {
 				assert.Empty(t, deets.Details().Entries, "details entries")
@@ -1094,6 +1123,7 @@ func (suite *KopiaIntegrationSuite) TestBackupCollections_NoDetailsForMeta() {
 		expectedCachedFiles   int
 		numDeetsEntries       int
 		hasMetaDeets          bool
+		expectedOptionalMerge int
 		cols                  func() []data.BackupCollection
 	}{
 		{
@@ -1141,6 +1171,8 @@ func (suite *KopiaIntegrationSuite) TestBackupCollections_NoDetailsForMeta() {
 			// Meta entries are filtered out.
 			numDeetsEntries: 1,
 			hasMetaDeets:    false,
+			// Folder metadata is merged if the base has details for it.
+			expectedOptionalMerge: 1,
 			cols: func() []data.BackupCollection {
 				info := baseOneDriveItemInfo
 				info.ItemName = testFileName
@@ -1208,6 +1240,7 @@ func (suite *KopiaIntegrationSuite) TestBackupCollections_NoDetailsForMeta() {
 			// Shouldn't have any items to merge because the cached files are metadata
 			// files.
 			assert.Equal(t, 0, prevShortRefs.ItemsToMerge(), "merge items")
+			assert.Equal(t, test.expectedOptionalMerge, prevShortRefs.OptionalItemsToMerge(), "optional merge items")
 
 			checkSnapshotTags(
 				t,
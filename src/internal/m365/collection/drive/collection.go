@@ -2,6 +2,7 @@
 package drive
 
 import (
+	"bytes"
 	"context"
 	"io"
 	"net/http"
@@ -19,6 +20,7 @@ import (
 	"github.com/alcionai/corso/src/internal/m365/graph"
 	"github.com/alcionai/corso/src/internal/m365/support"
 	"github.com/alcionai/corso/src/internal/observe"
+	"github.com/alcionai/corso/src/internal/stats"
 	"github.com/alcionai/corso/src/pkg/backup/details"
 	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/extensions"
@@ -288,6 +290,19 @@ func (oc *Collection) getDriveItemContent(
 			return nil, clues.Wrap(err, "deleted item").Label(graph.LabelsSkippable)
 		}
 
+		// Items in a package with a OneNote package type are the sections of a
+		// notebook, some of which graph won't serve through the drive apis.
+		// Graph rejects requests for features it doesn't support with a 501.
+		// https://learn.microsoft.com/en-us/graph/api/resources/package
+		// https://learn.microsoft.com/en-us/graph/errors#http-status-codes
+		if oc.scope == CollectionScopePackage &&
+			clues.HasLabel(err, graph.LabelStatus(http.StatusNotImplemented)) {
+			logger.CtxErr(ctx, err).With("skipped_reason", fault.SkipOneNote).Info("OneNote item not downloadable")
+			errs.AddSkip(ctx, fault.FileSkip(fault.SkipOneNote, driveID, itemID, itemName, graph.ItemInfo(item)))
+
+			return nil, clues.Wrap(err, "oneNote item").Label(graph.LabelsSkippable)
+		}
+
 		// Skip big OneNote files as they can't be downloaded
 		if clues.HasLabel(err, graph.LabelStatus(http.StatusServiceUnavailable)) &&
 			oc.scope == CollectionScopePackage && *item.GetSize() >= MaxOneNoteFileSize {
@@ -320,8 +335,8 @@ type itemAndAPIGetter interface {
 }
 
 // downloadContent attempts to fetch the item content.  If the content url
-// is expired (ie, returns a 401), it re-fetches the item to get a new download
-// url and tries again.
+// is expired (ie, returns a 401 or 403), it re-fetches the item to get a new
+// download url and tries again.
 func downloadContent(
 	ctx context.Context,
 	iaag itemAndAPIGetter,
@@ -335,15 +350,17 @@ func downloadContent(
 	content, err := downloadItem(ctx, iaag, item)
 	if err == nil {
 		return content, nil
-	} else if !graph.IsErrUnauthorized(err) {
+	} else if !isErrExpiredURL(err) {
 		return nil, err
 	}
 
 	// Assume unauthorized requests are a sign of an expired jwt
 	// token, and that we've overrun the available window to
 	// download the file.  Get a fresh url from the cache and attempt to
-	// download again.
-	content, err = readItemContents(ctx, iaag, uc, itemID)
+	// download again.  Forbidden responses mean the pre-signed url itself
+	// expired, and the cache may still hold that same url, so the cache
+	// gets refreshed before reading.
+	content, err = readItemContents(ctx, iaag, uc, itemID, isErrForbidden(err))
 	if err == nil {
 		logger.Ctx(ctx).Debug("found item in url cache")
 		return content, nil
@@ -374,12 +391,13 @@ func readItemContents(
 	iaag itemAndAPIGetter,
 	uc getItemPropertyer,
 	itemID string,
+	forceRefresh bool,
 ) (io.ReadCloser, error) {
 	if uc == nil {
 		return nil, clues.New("nil url cache")
 	}
 
-	props, err := uc.getItemProperties(ctx, itemID)
+	props, err := uc.getItemProperties(ctx, itemID, forceRefresh)
 	if err != nil {
 		return nil, err
 	}
@@ -391,7 +409,7 @@ func readItemContents(
 	}
 
 	rc, err := downloadFile(ctx, iaag, props.downloadURL)
-	if graph.IsErrUnauthorized(err) {
+	if isErrExpiredURL(err) {
 		logger.CtxErr(ctx, err).Info("stale item in cache")
 	}
 
@@ -402,6 +420,16 @@ func readItemContents(
 	return rc, nil
 }
 
+// isErrExpiredURL is true if the error suggests that the download url
+// has expired.
+func isErrExpiredURL(err error) bool {
+	return graph.IsErrUnauthorized(err) || isErrForbidden(err)
+}
+
+func isErrForbidden(err error) bool {
+	return clues.HasLabel(err, graph.LabelStatus(http.StatusForbidden))
+}
+
 type driveStats struct {
 	dirsRead   int64
 	dirsFound  int64
@@ -414,15 +442,17 @@ type driveStats struct {
 // and uses the collection `itemReader` to read the item
 func (oc *Collection) streamItems(ctx context.Context, errs *fault.Bus) {
 	var (
-		stats driveStats
-		wg    sync.WaitGroup
+		counts  driveStats
+		wg      sync.WaitGroup
+		limit   = graph.Parallelism(path.OneDriveService).Item()
+		sampler = stats.NewConcurrencySampler(limit)
 	)
 
 	// Retrieve the OneDrive folder path to set later in
 	// `details.OneDriveInfo`
 	parentPath, err := path.GetDriveFolderPath(oc.folderPath)
 	if err != nil {
-		oc.reportAsCompleted(ctx, 0, 0, 0)
+		oc.reportAsCompleted(ctx, 0, 0, 0, sampler.Summary(oc.folderPath.Folder(false)))
 		return
 	}
 
@@ -435,7 +465,7 @@ func (oc *Collection) streamItems(ctx context.Context, errs *fault.Bus) {
 		int64(len(oc.driveItems)))
 	defer close(folderProgress)
 
-	semaphoreCh := make(chan struct{}, graph.Parallelism(path.OneDriveService).Item())
+	semaphoreCh := make(chan struct{}, limit)
 	defer close(semaphoreCh)
 
 	for _, item := range oc.driveItems {
@@ -443,20 +473,23 @@ func (oc *Collection) streamItems(ctx context.Context, errs *fault.Bus) {
 			break
 		}
 
+		waitStart := time.Now()
 		semaphoreCh <- struct{}{}
 
+		sampler.Acquired(time.Since(waitStart))
 		wg.Add(1)
 
 		go func(item models.DriveItemable) {
 			defer wg.Done()
 			defer func() { <-semaphoreCh }()
+			defer sampler.Released()
 
 			// Read the item
 			oc.streamDriveItem(
 				ctx,
 				parentPath,
 				item,
-				&stats,
+				&counts,
 				oc.ctrl.ItemExtensionFactory,
 				errs)
 
@@ -466,7 +499,12 @@ func (oc *Collection) streamItems(ctx context.Context, errs *fault.Bus) {
 
 	wg.Wait()
 
-	oc.reportAsCompleted(ctx, int(stats.itemsFound), int(stats.itemsRead), stats.byteCount)
+	oc.reportAsCompleted(
+		ctx,
+		int(counts.itemsFound),
+		int(counts.itemsRead),
+		counts.byteCount,
+		sampler.Summary(oc.folderPath.Folder(false)))
 }
 
 func (oc *Collection) streamDriveItem(
@@ -499,6 +537,18 @@ func (oc *Collection) streamDriveItem(
 
 	isFile := item.GetFile() != nil
 
+	// Zero-byte files are often placeholders whose download fails, and
+	// there's no content to fetch anyway.  Files of unknown size still get
+	// downloaded.
+	isEmpty := isFile && item.GetSize() != nil && itemSize == 0
+
+	if isEmpty && oc.ctrl.SkipZeroByteFiles {
+		logger.Ctx(ctx).With("skipped_reason", fault.SkipZeroByteFile).Info("zero-byte file")
+		errs.AddSkip(ctx, fault.FileSkip(fault.SkipZeroByteFile, oc.driveID, itemID, itemName, graph.ItemInfo(item)))
+
+		return
+	}
+
 	if isFile {
 		atomic.AddInt64(&stats.itemsFound, 1)
 
@@ -511,8 +561,26 @@ func (oc *Collection) streamDriveItem(
 		metaSuffix = metadata.DirMetaFileSuffix
 	}
 
+	// An empty folder has nothing but its .dirmeta in the backup, which
+	// doesn't produce a details entry.  Preserved empty folders store the
+	// .dirmeta with the folder's info instead, so that restores can find
+	// and recreate the folder.
+	preserveFolder := !isFile && oc.ctrl.PreserveEmptyFolders && oc.isEmptyFolder(item)
+
+	// Folder metadata only holds permissions, so there's nothing
+	// to keep when permissions are skipped.
+	skipMeta := !isFile && oc.ctrl.SkipPermissionsMetadata && !preserveFolder
+
 	// Fetch metadata for the item
-	itemMeta, itemMetaSize, err = downloadItemMeta(ctx, oc.handler, oc.driveID, item)
+	if !skipMeta {
+		itemMeta, itemMetaSize, err = downloadItemMeta(
+			ctx,
+			oc.handler,
+			oc.driveID,
+			item,
+			oc.ctrl.SkipPermissionsMetadata)
+	}
+
 	if err != nil {
 		// Skip deleted items
 		if !clues.HasLabel(err, graph.LabelStatus(http.StatusNotFound)) && !graph.IsErrDeletedInFlight(err) {
@@ -534,9 +602,15 @@ func (oc *Collection) streamDriveItem(
 		// attempts to read bytes.  Assumption is that kopia will check things
 		// like file modtimes before attempting to read.
 		itemReader := lazy.NewLazyReadCloser(func() (io.ReadCloser, error) {
-			rc, err := oc.getDriveItemContent(ctx, oc.driveID, item, errs)
-			if err != nil {
-				return nil, err
+			rc := io.NopCloser(bytes.NewReader(nil))
+
+			if !isEmpty {
+				content, err := oc.getDriveItemContent(ctx, oc.driveID, item, errs)
+				if err != nil {
+					return nil, err
+				}
+
+				rc = content
 			}
 
 			extRc, extData, err := extensions.AddItemExtensions(
@@ -570,22 +644,35 @@ func (oc *Collection) streamDriveItem(
 		}
 	}
 
-	metaReader := lazy.NewLazyReadCloser(func() (io.ReadCloser, error) {
-		progReader, _ := observe.ItemProgress(
-			ctx,
-			itemMeta,
-			observe.ItemBackupMsg,
-			clues.Hide(itemName+metaSuffix),
-			int64(itemMetaSize))
-		return progReader, nil
-	})
-
-	oc.data <- &metadata.Item{
-		ItemID: metaFileName + metaSuffix,
-		Data:   metaReader,
-		// Metadata file should always use the latest time as
-		// permissions change does not update mod time.
-		Mod: time.Now(),
+	if !skipMeta {
+		metaReader := lazy.NewLazyReadCloser(func() (io.ReadCloser, error) {
+			progReader, _ := observe.ItemProgress(
+				ctx,
+				itemMeta,
+				observe.ItemBackupMsg,
+				clues.Hide(itemName+metaSuffix),
+				int64(itemMetaSize))
+			return progReader, nil
+		})
+
+		if preserveFolder {
+			// Details require the modTime to match the info, so the info
+			// carries the latest time as well.  Permissions changes don't
+			// update the folder's mod time.
+			oc.data <- &Item{
+				id:   metaFileName + metaSuffix,
+				data: metaReader,
+				info: withModified(itemInfo, time.Now()),
+			}
+		} else {
+			oc.data <- &metadata.Item{
+				ItemID: metaFileName + metaSuffix,
+				Data:   metaReader,
+				// Metadata file should always use the latest time as
+				// permissions change does not update mod time.
+				Mod: time.Now(),
+			}
+		}
 	}
 
 	// Item read successfully, add to collection
@@ -598,7 +685,61 @@ func (oc *Collection) streamDriveItem(
 	atomic.AddInt64(&stats.byteCount, itemSize)
 }
 
-func (oc *Collection) reportAsCompleted(ctx context.Context, itemsFound, itemsRead int, byteCount int64) {
+// withModified returns a copy of the drive item info using the given
+// modified time.
+func withModified(info details.ItemInfo, mod time.Time) details.ItemInfo {
+	switch {
+	case info.OneDrive != nil:
+		odi := *info.OneDrive
+		odi.Modified = mod
+		info.OneDrive = &odi
+
+	case info.SharePoint != nil:
+		spi := *info.SharePoint
+		spi.Modified = mod
+		info.SharePoint = &spi
+
+	case info.Groups != nil:
+		gi := *info.Groups
+		gi.Modified = mod
+		info.Groups = &gi
+	}
+
+	return info
+}
+
+// isEmptyFolder is true if the collection is a folder that holds no items.
+// Incremental collections only hold the changed items, so the folder's child
+// count is preferred over the collection's contents.  Without it, the
+// contents are only trusted if the folder was fully enumerated.
+func (oc *Collection) isEmptyFolder(folder models.DriveItemable) bool {
+	if oc.scope != CollectionScopeFolder {
+		return false
+	}
+
+	if f := folder.GetFolder(); f != nil && f.GetChildCount() != nil {
+		return ptr.Val(f.GetChildCount()) == 0
+	}
+
+	if oc.state != data.NewState && !oc.doNotMergeItems {
+		return false
+	}
+
+	for _, item := range oc.driveItems {
+		if item.GetFile() != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (oc *Collection) reportAsCompleted(
+	ctx context.Context,
+	itemsFound, itemsRead int,
+	byteCount int64,
+	concurrency stats.ItemConcurrency,
+) {
 	close(oc.data)
 
 	status := support.CreateStatus(ctx, support.Backup,
@@ -609,8 +750,12 @@ func (oc *Collection) reportAsCompleted(ctx context.Context, itemsFound, itemsRe
 			Bytes:     byteCount,
 		},
 		oc.folderPath.Folder(false))
+	status.Concurrency = []stats.ItemConcurrency{concurrency}
 
-	logger.Ctx(ctx).Debugw("done streaming items", "status", status.String())
+	logger.Ctx(ctx).Debugw(
+		"done streaming items",
+		"status", status.String(),
+		"item_concurrency", concurrency)
 
 	oc.statusUpdater(status)
 }
@@ -17,6 +17,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"golang.org/x/exp/maps"
 
 	"github.com/alcionai/corso/src/internal/common/ptr"
 	"github.com/alcionai/corso/src/internal/data"
@@ -487,7 +488,15 @@ func (suite *CollectionUnitSuite) TestCollectionPermissionBackupLatestModTime()
 		if strings.HasSuffix(i.ID(), metadata.MetaFileSuffix) {
 			content, err := io.ReadAll(i.ToReader())
 			require.NoError(t, err, clues.ToCore(err))
-			require.Equal(t, `{"filename":"Fake Item","permissionMode":1}`, string(content))
+
+			expect, err := json.Marshal(metadata.Metadata{
+				FileName:    stubItemName,
+				SharingMode: metadata.SharingModeInherited,
+				Created:     &mtime,
+				Modified:    &mtime,
+			})
+			require.NoError(t, err, clues.ToCore(err))
+			require.Equal(t, string(expect), string(content))
 
 			im, ok := i.(data.ItemModTime)
 			require.Equal(t, ok, true, "modtime interface")
@@ -496,6 +505,433 @@ func (suite *CollectionUnitSuite) TestCollectionPermissionBackupLatestModTime()
 	}
 }
 
+func (suite *CollectionUnitSuite) TestCollectionSkipPermissionsMetadata() {
+	var (
+		t          = suite.T()
+		fileID     = "fakeFileID"
+		fileName   = "Fake Item"
+		folderID   = "fakeFolderID"
+		now        = time.Now()
+		collStatus = support.ControllerOperationStatus{}
+		wg         = sync.WaitGroup{}
+	)
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	wg.Add(1)
+
+	pb := path.Builder{}.Append(path.Split("drive/driveID1/root:/folderPath")...)
+	folderPath, err := pb.ToDataLayerOneDrivePath("a-tenant", "a-user", false)
+	require.NoError(t, err, clues.ToCore(err))
+
+	mbh := mock.DefaultOneDriveBH("a-user")
+	mbh.ItemInfo = details.ItemInfo{OneDrive: &details.OneDriveInfo{ItemName: fileName, Modified: now}}
+	// permissions should never be fetched
+	mbh.GIP = mock.GetsItemPermission{Err: assert.AnError}
+	mbh.GetResps = []*http.Response{{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader("Fake Data!")),
+	}}
+	mbh.GetErrs = []error{nil}
+
+	opts := control.DefaultOptions()
+	opts.SkipPermissionsMetadata = true
+
+	coll, err := NewCollection(
+		mbh,
+		folderPath,
+		nil,
+		"drive-id",
+		suite.testStatusUpdater(&wg, &collStatus),
+		opts,
+		CollectionScopeFolder,
+		true,
+		nil)
+	require.NoError(t, err, clues.ToCore(err))
+
+	coll.Add(odTD.NewStubDriveItem(fileID, fileName, 10, now, now, true, true))
+	coll.Add(odTD.NewStubDriveItem(folderID, "folder", 0, now, now, false, true))
+
+	errs := fault.New(true)
+	readItems := map[string]data.Item{}
+
+	for item := range coll.Items(ctx, errs) {
+		readItems[item.ID()] = item
+	}
+
+	wg.Wait()
+
+	require.NoError(t, errs.Failure(), clues.ToCore(errs.Failure()))
+	assert.Empty(t, errs.Recovered(), "recovered errors")
+	assert.NotContains(t, readItems, metadata.DirMetaFileSuffix, "folder metadata")
+	require.Contains(t, readItems, fileID+metadata.DataFileSuffix, "file data")
+	require.Contains(t, readItems, fileID+metadata.MetaFileSuffix, "file metadata")
+	assert.Len(t, readItems, 2)
+
+	meta := metadata.Metadata{}
+
+	err = json.NewDecoder(readItems[fileID+metadata.MetaFileSuffix].ToReader()).Decode(&meta)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, "Fake Item", meta.FileName)
+	assert.Equal(t, metadata.SharingModeInherited, meta.SharingMode)
+	assert.Empty(t, meta.Permissions)
+	assert.Empty(t, meta.LinkShares)
+}
+
+func (suite *CollectionUnitSuite) TestCollectionZeroByteFiles() {
+	var (
+		fileID   = "fakeFileID"
+		fileName = "placeholder.docx"
+		now      = time.Now()
+	)
+
+	table := []struct {
+		name          string
+		skip          bool
+		expectItems   []string
+		expectSkipped int
+	}{
+		{
+			name:        "backed up as empty content",
+			expectItems: []string{fileID + metadata.DataFileSuffix, fileID + metadata.MetaFileSuffix},
+		},
+		{
+			name:          "skipped",
+			skip:          true,
+			expectItems:   []string{},
+			expectSkipped: 1,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			var (
+				t          = suite.T()
+				collStatus = support.ControllerOperationStatus{}
+				wg         = sync.WaitGroup{}
+			)
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			wg.Add(1)
+
+			pb := path.Builder{}.Append(path.Split("drive/driveID1/root:/folderPath")...)
+			folderPath, err := pb.ToDataLayerOneDrivePath("a-tenant", "a-user", false)
+			require.NoError(t, err, clues.ToCore(err))
+
+			// the default handler fails any download, which zero-byte files
+			// should never attempt.
+			mbh := mock.DefaultOneDriveBH("a-user")
+			mbh.ItemInfo = details.ItemInfo{
+				OneDrive:  &details.OneDriveInfo{ItemName: fileName, Modified: now},
+				Extension: &details.ExtensionData{},
+			}
+
+			opts := control.DefaultOptions()
+			opts.SkipZeroByteFiles = test.skip
+
+			coll, err := NewCollection(
+				mbh,
+				folderPath,
+				nil,
+				"drive-id",
+				suite.testStatusUpdater(&wg, &collStatus),
+				opts,
+				CollectionScopeFolder,
+				true,
+				nil)
+			require.NoError(t, err, clues.ToCore(err))
+
+			coll.Add(odTD.NewStubDriveItem(fileID, fileName, 0, now, now, true, false))
+
+			errs := fault.New(true)
+			readItems := map[string]data.Item{}
+
+			for item := range coll.Items(ctx, errs) {
+				readItems[item.ID()] = item
+			}
+
+			wg.Wait()
+
+			assert.ElementsMatch(t, test.expectItems, maps.Keys(readItems))
+
+			if item, ok := readItems[fileID+metadata.DataFileSuffix]; ok {
+				content, err := io.ReadAll(item.ToReader())
+				require.NoError(t, err, clues.ToCore(err))
+				assert.Empty(t, content)
+			}
+
+			require.NoError(t, errs.Failure(), clues.ToCore(errs.Failure()))
+			assert.Empty(t, errs.Recovered(), "recovered errors")
+
+			skipped := errs.Skipped()
+			require.Len(t, skipped, test.expectSkipped)
+
+			for _, s := range skipped {
+				assert.True(t, s.HasCause(fault.SkipZeroByteFile), "skip cause")
+			}
+		})
+	}
+}
+
+func (suite *CollectionUnitSuite) TestCollectionPreserveEmptyFolders() {
+	var (
+		fileID   = "fakeFileID"
+		folderID = "fakeFolderID"
+		now      = time.Now()
+	)
+
+	table := []struct {
+		name            string
+		preserve        bool
+		skipPerms       bool
+		withFile        bool
+		incremental     bool
+		childCount      *int32
+		expectPreserved bool
+	}{
+		{
+			name: "empty folder",
+		},
+		{
+			name:      "empty folder without permissions",
+			skipPerms: true,
+		},
+		{
+			name:            "preserved empty folder",
+			preserve:        true,
+			expectPreserved: true,
+		},
+		{
+			name:            "preserved empty folder without permissions",
+			preserve:        true,
+			skipPerms:       true,
+			expectPreserved: true,
+		},
+		{
+			name:     "folder with files",
+			preserve: true,
+			withFile: true,
+		},
+		{
+			name:            "preserved empty folder by child count",
+			preserve:        true,
+			childCount:      ptr.To[int32](0),
+			expectPreserved: true,
+		},
+		{
+			name:       "folder with children by child count",
+			preserve:   true,
+			childCount: ptr.To[int32](2),
+		},
+		{
+			name:        "incremental folder without changed files",
+			preserve:    true,
+			incremental: true,
+		},
+		{
+			name:            "incremental empty folder by child count",
+			preserve:        true,
+			incremental:     true,
+			childCount:      ptr.To[int32](0),
+			expectPreserved: true,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			var (
+				t          = suite.T()
+				collStatus = support.ControllerOperationStatus{}
+				wg         = sync.WaitGroup{}
+			)
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			wg.Add(1)
+
+			pb := path.Builder{}.Append(path.Split("drive/driveID1/root:/folderPath")...)
+			folderPath, err := pb.ToDataLayerOneDrivePath("a-tenant", "a-user", false)
+			require.NoError(t, err, clues.ToCore(err))
+
+			mbh := mock.DefaultOneDriveBH("a-user")
+			mbh.ItemInfo = details.ItemInfo{
+				OneDrive:  &details.OneDriveInfo{ItemName: "folder", Modified: now},
+				Extension: &details.ExtensionData{},
+			}
+			mbh.GIP = mock.GetsItemPermission{Perm: models.NewPermissionCollectionResponse()}
+			mbh.GetResps = []*http.Response{{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader("Fake Data!")),
+			}}
+			mbh.GetErrs = []error{nil}
+
+			opts := control.DefaultOptions()
+			opts.PreserveEmptyFolders = test.preserve
+			opts.SkipPermissionsMetadata = test.skipPerms
+
+			var prevPath path.Path
+			if test.incremental {
+				prevPath = folderPath
+			}
+
+			coll, err := NewCollection(
+				mbh,
+				folderPath,
+				prevPath,
+				"drive-id",
+				suite.testStatusUpdater(&wg, &collStatus),
+				opts,
+				CollectionScopeFolder,
+				!test.incremental,
+				nil)
+			require.NoError(t, err, clues.ToCore(err))
+
+			folder := odTD.NewStubDriveItem(folderID, "folder", 0, now, now, false, true)
+
+			if test.childCount != nil {
+				f := models.NewFolder()
+				f.SetChildCount(test.childCount)
+				folder.SetFolder(f)
+			}
+
+			coll.Add(folder)
+
+			if test.withFile {
+				coll.Add(odTD.NewStubDriveItem(fileID, "file", 10, now, now, true, true))
+			}
+
+			errs := fault.New(true)
+			readItems := map[string]data.Item{}
+
+			for item := range coll.Items(ctx, errs) {
+				readItems[item.ID()] = item
+			}
+
+			wg.Wait()
+
+			require.NoError(t, errs.Failure(), clues.ToCore(errs.Failure()))
+			assert.Empty(t, errs.Recovered(), "recovered errors")
+
+			if test.skipPerms && !test.expectPreserved {
+				assert.NotContains(t, readItems, metadata.DirMetaFileSuffix, "folder metadata")
+				return
+			}
+
+			require.Contains(t, readItems, metadata.DirMetaFileSuffix, "folder metadata")
+
+			// only items with info get added to the backup details, which
+			// restores use to find the folder.
+			infoer, ok := readItems[metadata.DirMetaFileSuffix].(data.ItemInfo)
+			assert.Equal(t, test.expectPreserved, ok, "folder metadata has item info")
+
+			if !ok {
+				return
+			}
+
+			info, err := infoer.Info()
+			require.NoError(t, err, clues.ToCore(err))
+			require.NotNil(t, info.OneDrive)
+			assert.Equal(t, "folder", info.OneDrive.ItemName)
+			assert.False(t, info.OneDrive.IsMeta, "entry is not excluded from details items")
+
+			// permissions changes don't update the folder's mod time, so the
+			// metadata always uses the latest time.
+			mt, ok := readItems[metadata.DirMetaFileSuffix].(data.ItemModTime)
+			require.True(t, ok, "folder metadata has mod time")
+			assert.True(t, mt.ModTime().After(now), "metadata uses the latest time")
+			assert.Equal(t, info.Modified(), mt.ModTime(), "details and metadata mod times")
+		})
+	}
+}
+
+// A preserved empty folder whose permissions changed between backups keeps
+// its mod time, so the folder metadata needs a newer mod time or incremental
+// backups reuse the previous metadata.
+func (suite *CollectionUnitSuite) TestCollectionPreserveEmptyFolders_permissionsChange() {
+	var (
+		t       = suite.T()
+		modTime = time.Now().Add(-time.Hour)
+		folder  = odTD.NewStubDriveItem("fakeFolderID", "folder", 0, modTime, modTime, false, true)
+	)
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	pb := path.Builder{}.Append(path.Split("drive/driveID1/root:/folderPath")...)
+	folderPath, err := pb.ToDataLayerOneDrivePath("a-tenant", "a-user", false)
+	require.NoError(t, err, clues.ToCore(err))
+
+	opts := control.DefaultOptions()
+	opts.PreserveEmptyFolders = true
+
+	backupFolder := func(
+		prevPath path.Path,
+		perms models.PermissionCollectionResponseable,
+	) (time.Time, []byte) {
+		var (
+			collStatus = support.ControllerOperationStatus{}
+			wg         = sync.WaitGroup{}
+		)
+
+		wg.Add(1)
+
+		mbh := mock.DefaultOneDriveBH("a-user")
+		mbh.ItemInfo = details.ItemInfo{
+			OneDrive:  &details.OneDriveInfo{ItemName: "folder", Modified: modTime},
+			Extension: &details.ExtensionData{},
+		}
+		mbh.GIP = mock.GetsItemPermission{Perm: perms}
+
+		coll, err := NewCollection(
+			mbh,
+			folderPath,
+			prevPath,
+			"drive-id",
+			suite.testStatusUpdater(&wg, &collStatus),
+			opts,
+			CollectionScopeFolder,
+			prevPath == nil,
+			nil)
+		require.NoError(t, err, clues.ToCore(err))
+
+		coll.Add(folder)
+
+		errs := fault.New(true)
+		readItems := map[string]data.Item{}
+
+		for item := range coll.Items(ctx, errs) {
+			readItems[item.ID()] = item
+		}
+
+		wg.Wait()
+
+		require.NoError(t, errs.Failure(), clues.ToCore(errs.Failure()))
+		require.Contains(t, readItems, metadata.DirMetaFileSuffix, "folder metadata")
+
+		item := readItems[metadata.DirMetaFileSuffix]
+
+		mt, ok := item.(data.ItemModTime)
+		require.True(t, ok, "folder metadata has mod time")
+
+		bs, err := io.ReadAll(item.ToReader())
+		require.NoError(t, err, clues.ToCore(err))
+
+		return mt.ModTime(), bs
+	}
+
+	firstMod, firstMeta := backupFolder(nil, models.NewPermissionCollectionResponse())
+
+	secondMod, secondMeta := backupFolder(
+		folderPath,
+		metaTD.NewStubPermissionResponse(metadata.GV2User, "permID", "email@provider.com", []string{"read"}))
+
+	assert.NotEqual(t, firstMeta, secondMeta, "folder metadata changed")
+	assert.True(t, firstMod.After(modTime), "first metadata uses the latest time")
+	assert.True(t, secondMod.After(firstMod), "second metadata uses the latest time")
+}
+
 type GetDriveItemUnitTestSuite struct {
 	tester.Suite
 }
@@ -513,6 +949,8 @@ func (suite *GetDriveItemUnitTestSuite) TestGetDriveItem_error() {
 		itemSize int64
 		labels   []string
 		err      error
+		// status of the download response, if the download doesn't error
+		status int
 	}{
 		{
 			name:     "Simple item fetch no error",
@@ -540,6 +978,21 @@ func (suite *GetDriveItemUnitTestSuite) TestGetDriveItem_error() {
 			err:      clues.New("not found error").Label(graph.LabelStatus(http.StatusNotFound)),
 			labels:   []string{graph.LabelStatus(http.StatusNotFound), graph.LabelsSkippable},
 		},
+		{
+			name:     "OneNote not downloadable response",
+			colScope: CollectionScopePackage,
+			itemSize: 10,
+			status:   http.StatusNotImplemented,
+			labels:   []string{graph.LabelStatus(http.StatusNotImplemented), graph.LabelsSkippable},
+		},
+		{
+			// only OneNote packages are expected to be unsupported
+			name:     "not implemented folder item response",
+			colScope: CollectionScopeFolder,
+			itemSize: 10,
+			status:   http.StatusNotImplemented,
+			labels:   []string{graph.LabelStatus(http.StatusNotImplemented)},
+		},
 		{
 			// This should create an error that stops the backup
 			name:     "small OneNote file",
@@ -589,18 +1042,27 @@ func (suite *GetDriveItemUnitTestSuite) TestGetDriveItem_error() {
 
 			mbh := mock.DefaultOneDriveBH("a-user")
 			mbh.GI = mock.GetsItem{Item: stubItem}
-			mbh.GetResps = []*http.Response{{StatusCode: http.StatusOK}}
+			status := http.StatusOK
+			if test.status != 0 {
+				status = test.status
+			}
+
+			mbh.GetResps = []*http.Response{{StatusCode: status, Status: http.StatusText(status)}}
 			mbh.GetErrs = []error{test.err}
 
 			col.handler = mbh
 
 			_, err := col.getDriveItemContent(ctx, "driveID", stubItem, errs)
-			if test.err == nil {
+			if test.err == nil && test.status == 0 {
 				assert.NoError(t, err, clues.ToCore(err))
 				return
 			}
 
-			assert.ErrorIs(t, err, test.err, clues.ToCore(err))
+			require.Error(t, err, clues.ToCore(err))
+
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err, clues.ToCore(err))
+			}
 
 			labelsMap := map[string]struct{}{}
 			for _, l := range test.labels {
@@ -608,6 +1070,12 @@ func (suite *GetDriveItemUnitTestSuite) TestGetDriveItem_error() {
 			}
 
 			assert.Equal(t, labelsMap, clues.Labels(err))
+
+			// skippable errors are tracked as skips, not failures
+			if _, ok := labelsMap[graph.LabelsSkippable]; ok {
+				assert.Len(t, errs.Skipped(), 1, "skipped items")
+				assert.Empty(t, errs.Recovered(), "recovered errors")
+			}
 		})
 	}
 }
@@ -615,14 +1083,15 @@ func (suite *GetDriveItemUnitTestSuite) TestGetDriveItem_error() {
 var _ getItemPropertyer = &mockURLCache{}
 
 type mockURLCache struct {
-	Get func(ctx context.Context, itemID string) (itemProps, error)
+	Get func(ctx context.Context, itemID string, forceRefresh bool) (itemProps, error)
 }
 
 func (muc *mockURLCache) getItemProperties(
 	ctx context.Context,
 	itemID string,
+	forceRefresh bool,
 ) (itemProps, error) {
-	return muc.Get(ctx, itemID)
+	return muc.Get(ctx, itemID, forceRefresh)
 }
 
 func (suite *GetDriveItemUnitTestSuite) TestDownloadContent() {
@@ -632,12 +1101,13 @@ func (suite *GetDriveItemUnitTestSuite) TestDownloadContent() {
 		item      = odTD.NewStubDriveItem("id", "n", 1, time.Now(), time.Now(), true, false)
 		itemWID   = odTD.NewStubDriveItem("id", "n", 1, time.Now(), time.Now(), true, false)
 		errUnauth = clues.Stack(assert.AnError).Label(graph.LabelStatus(http.StatusUnauthorized))
+		errForbid = clues.Stack(assert.AnError).Label(graph.LabelStatus(http.StatusForbidden))
 	)
 
 	itemWID.SetId(ptr.To("brainhooldy"))
 
 	m := &mockURLCache{
-		Get: func(ctx context.Context, itemID string) (itemProps, error) {
+		Get: func(ctx context.Context, itemID string, forceRefresh bool) (itemProps, error) {
 			return itemProps{}, clues.Stack(assert.AnError)
 		},
 	}
@@ -707,7 +1177,7 @@ func (suite *GetDriveItemUnitTestSuite) TestDownloadContent() {
 			expectErr: require.NoError,
 			expect:    require.NotNil,
 			muc: &mockURLCache{
-				Get: func(ctx context.Context, itemID string) (itemProps, error) {
+				Get: func(ctx context.Context, itemID string, forceRefresh bool) (itemProps, error) {
 					return itemProps{
 							downloadURL: "http://example.com",
 							isDeleted:   false,
@@ -725,7 +1195,7 @@ func (suite *GetDriveItemUnitTestSuite) TestDownloadContent() {
 			expectErr: require.Error,
 			expect:    require.Nil,
 			muc: &mockURLCache{
-				Get: func(ctx context.Context, itemID string) (itemProps, error) {
+				Get: func(ctx context.Context, itemID string, forceRefresh bool) (itemProps, error) {
 					return itemProps{
 							downloadURL: "http://example.com",
 							isDeleted:   true,
@@ -734,6 +1204,37 @@ func (suite *GetDriveItemUnitTestSuite) TestDownloadContent() {
 				},
 			},
 		},
+		{
+			name:      "forbidden url refreshes cache",
+			itemInfo:  details.ItemInfo{},
+			respBody:  []io.ReadCloser{nil, iorc},
+			getErr:    []error{errForbid, nil},
+			expectErr: require.NoError,
+			expect:    require.NotNil,
+			muc: &mockURLCache{
+				Get: func(ctx context.Context, itemID string, forceRefresh bool) (itemProps, error) {
+					if !forceRefresh {
+						return itemProps{}, clues.New("stale url")
+					}
+
+					return itemProps{downloadURL: "http://example.com/fresh"}, nil
+				},
+			},
+		},
+		{
+			name:      "forbidden url fails redownload",
+			mgi:       mock.GetsItem{Item: itemWID, Err: nil},
+			itemInfo:  details.ItemInfo{},
+			respBody:  []io.ReadCloser{nil, nil, nil},
+			getErr:    []error{errForbid, errForbid, errForbid},
+			expectErr: require.Error,
+			expect:    require.Nil,
+			muc: &mockURLCache{
+				Get: func(ctx context.Context, itemID string, forceRefresh bool) (itemProps, error) {
+					return itemProps{downloadURL: "http://example.com/fresh"}, nil
+				},
+			},
+		},
 		{
 			name:      "fallback to item fetch on any cache error",
 			mgi:       mock.GetsItem{Item: itemWID, Err: nil},
@@ -743,7 +1244,7 @@ func (suite *GetDriveItemUnitTestSuite) TestDownloadContent() {
 			expectErr: require.NoError,
 			expect:    require.NotNil,
 			muc: &mockURLCache{
-				Get: func(ctx context.Context, itemID string) (itemProps, error) {
+				Get: func(ctx context.Context, itemID string, forceRefresh bool) (itemProps, error) {
 					return itemProps{}, assert.AnError
 				},
 			},
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"path/filepath"
 	"strings"
 
 	"github.com/alcionai/clues"
@@ -19,6 +20,7 @@ import (
 	odConsts "github.com/alcionai/corso/src/internal/m365/service/onedrive/consts"
 	"github.com/alcionai/corso/src/internal/m365/support"
 	"github.com/alcionai/corso/src/internal/observe"
+	"github.com/alcionai/corso/src/pkg/backup/details"
 	bupMD "github.com/alcionai/corso/src/pkg/backup/metadata"
 	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/fault"
@@ -55,6 +57,11 @@ type Collections struct {
 
 	ctrl control.Options
 
+	// checksums of the drive files in the previous backup, used to find
+	// unchanged files when a drive's delta token gets rejected.
+	// driveID -> itemID -> checksum
+	checksums map[string]map[string]details.ItemChecksum
+
 	// collectionMap allows lookup of the data.BackupCollection
 	// for a OneDrive folder.
 	// driveID -> itemID -> collection
@@ -83,6 +90,13 @@ func NewCollections(
 	}
 }
 
+// SetChecksums hands the collections the checksums of the drive files in
+// the previous backup.  They're only used when the
+// DriveChecksumIncrementals toggle is enabled.
+func (c *Collections) SetChecksums(checksums map[string]map[string]details.ItemChecksum) {
+	c.checksums = checksums
+}
+
 func deserializeMetadata(
 	ctx context.Context,
 	cols []data.RestoreCollection,
@@ -289,12 +303,58 @@ func (c *Collections) Get(
 			"num_paths_entries", len(oldPaths),
 			"num_deltas_entries", numOldDelta)
 
+		collector := c.UpdateCollections
+
+		// without a delta token, only back up the files changed since
+		// the caller-provided time.
+		if len(prevDelta) == 0 && !c.ctrl.IncrementalSince.IsZero() {
+			collector = modifiedSinceCollector(c.ctrl.IncrementalSince, collector)
+		}
+
+		var filter *checksumFilter
+
+		// a rejected delta token would otherwise produce a full backup of the
+		// drive, so compare the enumerated files against the previous backup.
+		if c.ctrl.ToggleFeatures.DriveChecksumIncrementals &&
+			len(prevDelta) > 0 &&
+			len(c.checksums[driveID]) > 0 {
+			filter = newChecksumFilter(c.checksums[driveID])
+			collector = filter.collector(collector)
+		}
+
+		itemPager := c.handler.NewItemPager(driveID, "", api.DriveItemSelectDefault())
+
+		// A drive without changes since the previous backup only needs its
+		// prior state carried forward, which avoids enumerating its items.
+		if len(prevDelta) > 0 && len(oldPaths) > 0 {
+			var (
+				newDelta  string
+				unchanged bool
+			)
+
+			itemPager, newDelta, unchanged = peekUnchangedDelta(ictx, itemPager, prevDelta)
+
+			if unchanged {
+				logger.Ctx(ictx).Info("no changes in drive delta")
+
+				if err := c.addUnchangedDriveCollection(ictx, driveID, driveName, oldPaths); err != nil {
+					return nil, false, err
+				}
+
+				deltaURLs[driveID] = newDelta
+				folderPaths[driveID] = map[string]string{}
+				maps.Copy(folderPaths[driveID], oldPaths)
+
+				continue
+			}
+		}
+
 		delta, paths, excluded, err := collectItems(
 			ictx,
-			c.handler.NewItemPager(driveID, "", api.DriveItemSelectDefault()),
+			itemPager,
 			driveID,
 			driveName,
-			c.UpdateCollections,
+			collector,
 			oldPaths,
 			prevDelta,
 			errs)
@@ -345,20 +405,31 @@ func (c *Collections) Get(
 			}
 		}
 
-		// For both cases we don't need to do set difference on folder map if the
-		// delta token was valid because we should see all the changes.
-		if !delta.Reset {
-			if len(excluded) == 0 {
-				continue
-			}
+		mergeable := !delta.Reset
+
+		if filter != nil && filter.applied {
+			filter.excludeUnseen(excluded)
+
+			mergeable = true
 
+			logger.Ctx(ictx).Infow(
+				"compared drive items against previous backup checksums",
+				"num_checksums", len(filter.checksums),
+				"num_seen", len(filter.seen))
+		}
+
+		if mergeable && len(excluded) > 0 {
 			p, err := c.handler.CanonicalPath(odConsts.DriveFolderPrefixBuilder(driveID), c.tenantID)
 			if err != nil {
 				return nil, false, clues.Wrap(err, "making exclude prefix").WithClues(ictx)
 			}
 
 			ssmb.Add(p.String(), excluded)
+		}
 
+		// For both cases we don't need to do set difference on folder map if the
+		// delta token was valid because we should see all the changes.
+		if !delta.Reset {
 			continue
 		}
 
@@ -469,6 +540,49 @@ func (c *Collections) Get(
 	return collections, canUsePreviousBackup, nil
 }
 
+// addUnchangedDriveCollection adds a not-moved collection for the root of
+// a drive that had no changes since the previous backup, so that the
+// drive's prior contents get carried forward.
+func (c *Collections) addUnchangedDriveCollection(
+	ctx context.Context,
+	driveID, driveName string,
+	oldPaths map[string]string,
+) error {
+	rootPath, err := c.handler.CanonicalPath(odConsts.DriveFolderPrefixBuilder(driveID), c.tenantID)
+	if err != nil {
+		return clues.Wrap(err, "making drive root path").WithClues(ctx)
+	}
+
+	for folderID, p := range oldPaths {
+		if p != rootPath.String() {
+			continue
+		}
+
+		col, err := NewCollection(
+			c.handler,
+			rootPath,
+			rootPath,
+			driveID,
+			c.statusUpdater,
+			c.ctrl,
+			CollectionScopeFolder,
+			false,
+			nil)
+		if err != nil {
+			return clues.Wrap(err, "making collection").WithClues(ctx)
+		}
+
+		col.driveName = driveName
+
+		c.CollectionMap[driveID][folderID] = col
+		c.NumContainers++
+
+		break
+	}
+
+	return nil
+}
+
 // addURLCacheToDriveCollections adds an URL cache to all collections belonging to
 // a drive.
 func (c *Collections) addURLCacheToDriveCollections(
@@ -826,6 +940,19 @@ func (c *Collections) UpdateCollections(
 				return clues.New("file without parent ID").WithClues(ictx)
 			}
 
+			if isExcludedFile(itemName, c.ctrl.ExcludeFilePatterns) {
+				errs.AddSkip(ictx, fault.FileSkip(fault.SkipSystemFile, driveID, itemID, itemName, graph.ItemInfo(item)))
+				logger.Ctx(ictx).Debug("file matched an exclusion pattern")
+
+				// Drop any copy of the file retained from a prior backup.
+				if !invalidPrevDelta {
+					excluded[itemID+metadata.DataFileSuffix] = struct{}{}
+					excluded[itemID+metadata.MetaFileSuffix] = struct{}{}
+				}
+
+				continue
+			}
+
 			// Get the collection for this item.
 			parentID := ptr.Val(item.GetParentReference().GetId())
 			ictx = clues.Add(ictx, "parent_id", parentID)
@@ -884,6 +1011,20 @@ type dirScopeChecker interface {
 	IncludesDir(dir string) bool
 }
 
+// isExcludedFile returns true if the file name matches any of the glob
+// patterns.  Matching is case-insensitive.  Malformed patterns never match.
+func isExcludedFile(name string, patterns []string) bool {
+	name = strings.ToLower(name)
+
+	for _, p := range patterns {
+		if ok, err := filepath.Match(strings.ToLower(p), name); err == nil && ok {
+			return true
+		}
+	}
+
+	return false
+}
+
 func shouldSkip(ctx context.Context, drivePath path.Path, dsc dirScopeChecker, driveName string) bool {
 	return !includePath(ctx, dsc, drivePath) ||
 		(drivePath.Category() == path.LibrariesCategory && restrictedDirectory == driveName)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/alcionai/clues"
 	"github.com/google/uuid"
@@ -16,6 +17,7 @@ import (
 
 	"github.com/alcionai/corso/src/internal/common/prefixmatcher"
 	pmMock "github.com/alcionai/corso/src/internal/common/prefixmatcher/mock"
+	"github.com/alcionai/corso/src/internal/common/ptr"
 	"github.com/alcionai/corso/src/internal/data"
 	"github.com/alcionai/corso/src/internal/m365/collection/drive/metadata"
 	"github.com/alcionai/corso/src/internal/m365/graph"
@@ -23,6 +25,7 @@ import (
 	"github.com/alcionai/corso/src/internal/m365/service/onedrive/mock"
 	"github.com/alcionai/corso/src/internal/m365/support"
 	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/details"
 	bupMD "github.com/alcionai/corso/src/pkg/backup/metadata"
 	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/fault"
@@ -140,6 +143,7 @@ func (suite *OneDriveCollectionsUnitSuite) TestUpdateCollections() {
 		items                  []models.DriveItemable
 		inputFolderMap         map[string]string
 		scope                  selectors.OneDriveScope
+		excludePatterns        []string
 		expect                 assert.ErrorAssertionFunc
 		expectedCollectionIDs  map[string]statePath
 		expectedItemCount      int
@@ -723,6 +727,30 @@ func (suite *OneDriveCollectionsUnitSuite) TestUpdateCollections() {
 			},
 			expectedExcludes: getDelList("fileInRoot", "goodFile"),
 		},
+		{
+			testCase: "1 good file, 2 excluded system files",
+			items: []models.DriveItemable{
+				driveRootItem("root"),
+				driveItem("goodFile", "goodFile", testBaseDrivePath, "root", true, false, false),
+				driveItem("desktopIni", "desktop.ini", testBaseDrivePath, "root", true, false, false),
+				driveItem("thumbsDB", "Thumbs.db", testBaseDrivePath, "root", true, false, false),
+			},
+			inputFolderMap:  map[string]string{},
+			scope:           anyFolder,
+			excludePatterns: []string{"Desktop.ini", "thumbs.*"},
+			expect:          assert.NoError,
+			expectedCollectionIDs: map[string]statePath{
+				"root": expectedStatePath(data.NotMovedState, ""),
+			},
+			expectedItemCount:      1,
+			expectedFileCount:      1,
+			expectedContainerCount: 1,
+			expectedSkippedCount:   2,
+			expectedMetadataPaths: map[string]string{
+				"root": expectedPath(""),
+			},
+			expectedExcludes: getDelList("goodFile", "desktopIni", "thumbsDB"),
+		},
 	}
 
 	for _, tt := range tests {
@@ -748,7 +776,10 @@ func (suite *OneDriveCollectionsUnitSuite) TestUpdateCollections() {
 				tenant,
 				user,
 				nil,
-				control.Options{ToggleFeatures: control.Toggles{}})
+				control.Options{
+					ExcludeFilePatterns: tt.excludePatterns,
+					ToggleFeatures:      control.Toggles{},
+				})
 
 			c.CollectionMap[driveID] = map[string]*Collection{}
 
@@ -787,6 +818,35 @@ func (suite *OneDriveCollectionsUnitSuite) TestUpdateCollections() {
 	}
 }
 
+func (suite *OneDriveCollectionsUnitSuite) TestIsExcludedFile() {
+	patterns := []string{"desktop.ini", "Thumbs.db", "~$*", ".DS_Store", "*.tmp", "[bad"}
+
+	table := []struct {
+		name   string
+		expect assert.BoolAssertionFunc
+	}{
+		{name: "desktop.ini", expect: assert.True},
+		{name: "Desktop.ini", expect: assert.True},
+		{name: "thumbs.db", expect: assert.True},
+		{name: "~$report.docx", expect: assert.True},
+		{name: ".DS_Store", expect: assert.True},
+		{name: "scratch.TMP", expect: assert.True},
+		{name: "report.docx", expect: assert.False},
+		{name: "desktop.ini.bak", expect: assert.False},
+		{name: "mythumbs.db", expect: assert.False},
+		{name: "[bad", expect: assert.False},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			test.expect(suite.T(), isExcludedFile(test.name, patterns))
+		})
+	}
+
+	suite.Run("no patterns", func() {
+		assert.False(suite.T(), isExcludedFile("desktop.ini", nil))
+	})
+}
+
 func (suite *OneDriveCollectionsUnitSuite) TestDeserializeMetadata() {
 	tenant := "a-tenant"
 	user := "a-user"
@@ -2606,6 +2666,446 @@ func (suite *OneDriveCollectionsUnitSuite) TestCollectItems() {
 	}
 }
 
+func (suite *OneDriveCollectionsUnitSuite) TestCollectItems_modifiedSince() {
+	var (
+		t        = suite.T()
+		delta    = "delta"
+		since    = time.Now()
+		basePath = odConsts.DriveFolderPrefixBuilder("driveID1").String()
+		root     = driveRootItem("root")
+		fldr     = driveItem("folder", "folder", basePath, "root", false, true, false)
+		old      = driveItem("old", "old", basePath, "root", true, false, false)
+		fresh    = driveItem("fresh", "fresh", basePath, "root", true, false, false)
+		undated  = driveItem("undated", "undated", basePath, "root", true, false, false)
+		del      = delItem("deleted", basePath, "root", true, false, false)
+	)
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	fldr.SetLastModifiedDateTime(ptr.To(since.Add(-time.Hour)))
+	old.SetLastModifiedDateTime(ptr.To(since.Add(-time.Hour)))
+	fresh.SetLastModifiedDateTime(ptr.To(since.Add(time.Hour)))
+	del.SetLastModifiedDateTime(ptr.To(since.Add(-time.Hour)))
+
+	itemPager := &apiMock.DeltaPager[models.DriveItemable]{
+		ToReturn: []apiMock.PagerResult[models.DriveItemable]{
+			{
+				Values:    []models.DriveItemable{root, fldr, old, fresh, undated, del},
+				DeltaLink: &delta,
+			},
+		},
+	}
+
+	var collected []string
+
+	collectorFunc := func(
+		ctx context.Context,
+		driveID, driveName string,
+		driveItems []models.DriveItemable,
+		oldPaths map[string]string,
+		newPaths map[string]string,
+		excluded map[string]struct{},
+		itemCollection map[string]map[string]string,
+		doNotMergeItems bool,
+		errs *fault.Bus,
+	) error {
+		for _, item := range driveItems {
+			collected = append(collected, ptr.Val(item.GetId()))
+		}
+
+		return nil
+	}
+
+	_, _, _, err := collectItems(
+		ctx,
+		itemPager,
+		"",
+		"General",
+		modifiedSinceCollector(since, collectorFunc),
+		map[string]string{},
+		"",
+		fault.New(true))
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, []string{"root", "folder", "fresh", "undated", "deleted"}, collected)
+}
+
+func (suite *OneDriveCollectionsUnitSuite) TestCollectItems_checksums() {
+	var (
+		delta     = "delta"
+		prevDelta = "prev-delta"
+		basePath  = odConsts.DriveFolderPrefixBuilder("driveID1").String()
+		prevFiles = map[string]string{
+			"same":     "etag-same",
+			"edited":   "etag-edited",
+			"resized":  "etag-resized",
+			"removed":  "etag-removed",
+			"unsigned": "",
+		}
+	)
+
+	// the previous backup's details, built the way drive backups record them.
+	db := &details.Builder{}
+
+	for id, etag := range prevFiles {
+		rr, err := path.Build(
+			"tenant",
+			"user",
+			path.OneDriveService,
+			path.FilesCategory,
+			true,
+			odConsts.DrivesPathDir, "driveID1", odConsts.RootPathDir, id+metadata.DataFileSuffix)
+		require.NoError(suite.T(), err, clues.ToCore(err))
+
+		err = db.Add(
+			rr,
+			path.Builder{}.Append(odConsts.RootPathDir),
+			details.ItemInfo{
+				OneDrive: &details.OneDriveInfo{
+					ItemType:  details.OneDriveItem,
+					ItemName:  id,
+					DriveName: "General",
+					DriveID:   "driveID1",
+					ETag:      etag,
+					Size:      42,
+				},
+			})
+		require.NoError(suite.T(), err, clues.ToCore(err))
+	}
+
+	checksums := db.Details().DriveChecksums()["driveID1"]
+
+	fileWithChecksum := func(id, etag string, size int64) models.DriveItemable {
+		di := driveItem(id, id, basePath, "root", true, false, false)
+		di.SetETag(ptr.To(etag))
+		di.SetSize(ptr.To(size))
+
+		return di
+	}
+
+	items := func() []models.DriveItemable {
+		return []models.DriveItemable{
+			driveRootItem("root"),
+			driveItem("folder", "folder", basePath, "root", false, true, false),
+			fileWithChecksum("same", "etag-same", 42),
+			fileWithChecksum("edited", "etag-edited-2", 42),
+			fileWithChecksum("resized", "etag-resized", 43),
+			fileWithChecksum("unsigned", "", 42),
+			fileWithChecksum("added", "etag-added", 42),
+		}
+	}
+
+	table := []struct {
+		name            string
+		pages           []apiMock.PagerResult[models.DriveItemable]
+		expectCollected []string
+		expectApplied   bool
+		expectExcluded  map[string]struct{}
+	}{
+		{
+			name: "valid delta",
+			pages: []apiMock.PagerResult[models.DriveItemable]{
+				{Values: items(), DeltaLink: &delta},
+			},
+			expectCollected: []string{"root", "folder", "same", "edited", "resized", "unsigned", "added"},
+			expectExcluded:  map[string]struct{}{},
+		},
+		{
+			name: "rejected delta",
+			pages: []apiMock.PagerResult[models.DriveItemable]{
+				{Err: getDeltaError()},
+				{Values: items(), DeltaLink: &delta},
+			},
+			expectCollected: []string{"root", "folder", "edited", "resized", "unsigned", "added"},
+			expectApplied:   true,
+			expectExcluded: map[string]struct{}{
+				"removed" + metadata.DataFileSuffix: {},
+				"removed" + metadata.MetaFileSuffix: {},
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				filter    = newChecksumFilter(checksums)
+				collected []string
+				itemPager = &apiMock.DeltaPager[models.DriveItemable]{
+					ToReturn: test.pages,
+				}
+			)
+
+			collectorFunc := func(
+				ctx context.Context,
+				driveID, driveName string,
+				driveItems []models.DriveItemable,
+				oldPaths map[string]string,
+				newPaths map[string]string,
+				excluded map[string]struct{},
+				itemCollection map[string]map[string]string,
+				invalidPrevDelta bool,
+				errs *fault.Bus,
+			) error {
+				// collections must stay mergeable with the previous backup.
+				assert.False(t, invalidPrevDelta, "invalid prev delta")
+
+				for _, item := range driveItems {
+					collected = append(collected, ptr.Val(item.GetId()))
+				}
+
+				return nil
+			}
+
+			_, _, excluded, err := collectItems(
+				ctx,
+				itemPager,
+				"driveID1",
+				"General",
+				filter.collector(collectorFunc),
+				map[string]string{},
+				prevDelta,
+				fault.New(true))
+			require.NoError(t, err, clues.ToCore(err))
+
+			assert.Equal(t, test.expectCollected, collected)
+			assert.Equal(t, test.expectApplied, filter.applied)
+
+			if filter.applied {
+				filter.excludeUnseen(excluded)
+			}
+
+			assert.Equal(t, test.expectExcluded, excluded)
+		})
+	}
+}
+
+// countingDeltaPager tracks the pages requested from the wrapped pager.
+type countingDeltaPager struct {
+	api.DeltaPager[models.DriveItemable]
+	gets int
+}
+
+func (p *countingDeltaPager) GetPage(
+	ctx context.Context,
+) (api.DeltaLinkValuer[models.DriveItemable], error) {
+	p.gets++
+	return p.DeltaPager.GetPage(ctx)
+}
+
+func (suite *OneDriveCollectionsUnitSuite) TestPeekUnchangedDelta() {
+	var (
+		next     = "next"
+		delta    = "delta"
+		basePath = odConsts.DriveFolderPrefixBuilder("driveID1").String()
+		file     = driveItem("file", "file", basePath, "root", true, false, false)
+	)
+
+	table := []struct {
+		name            string
+		items           []apiMock.PagerResult[models.DriveItemable]
+		expectUnchanged bool
+		expectDelta     string
+	}{
+		{
+			name: "empty delta",
+			items: []apiMock.PagerResult[models.DriveItemable]{
+				{DeltaLink: &delta},
+			},
+			expectUnchanged: true,
+			expectDelta:     delta,
+		},
+		{
+			name: "changed items",
+			items: []apiMock.PagerResult[models.DriveItemable]{
+				{
+					Values:    []models.DriveItemable{driveRootItem("root"), file},
+					DeltaLink: &delta,
+				},
+			},
+		},
+		{
+			name: "more pages",
+			items: []apiMock.PagerResult[models.DriveItemable]{
+				{NextLink: &next},
+				{DeltaLink: &delta},
+			},
+		},
+		{
+			name: "no delta link",
+			items: []apiMock.PagerResult[models.DriveItemable]{
+				{},
+			},
+		},
+		{
+			name: "invalid delta",
+			items: []apiMock.PagerResult[models.DriveItemable]{
+				{Err: getDeltaError()},
+				{DeltaLink: &delta},
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			itemPager := &countingDeltaPager{
+				DeltaPager: &apiMock.DeltaPager[models.DriveItemable]{
+					ToReturn: test.items,
+				},
+			}
+
+			pager, newDelta, unchanged := peekUnchangedDelta(ctx, itemPager, "prev-delta")
+			assert.Equal(t, test.expectUnchanged, unchanged, "unchanged")
+			assert.Equal(t, test.expectDelta, newDelta, "delta url")
+			assert.Equal(t, 1, itemPager.gets, "peeked pages")
+
+			if unchanged {
+				return
+			}
+
+			// enumerating a changed drive starts with the peeked page.
+			_, _, _, err := collectItems(
+				ctx,
+				pager,
+				"driveID1",
+				"General",
+				func(
+					context.Context,
+					string, string,
+					[]models.DriveItemable,
+					map[string]string,
+					map[string]string,
+					map[string]struct{},
+					map[string]map[string]string,
+					bool,
+					*fault.Bus,
+				) error {
+					return nil
+				},
+				map[string]string{},
+				"prev-delta",
+				fault.New(true))
+			require.NoError(t, err, clues.ToCore(err))
+
+			assert.Equal(t, len(test.items), itemPager.gets, "fetched pages")
+		})
+	}
+}
+
+func (suite *OneDriveCollectionsUnitSuite) TestGet_unchangedDrive() {
+	var (
+		t          = suite.T()
+		tenant     = "a-tenant"
+		user       = "a-user"
+		driveID    = "drive-1-" + uuid.NewString()
+		delta      = "delta1"
+		prevDelta  = "prev-delta"
+		drive      = models.NewDrive()
+		bh         = itemBackupHandler{userID: user}
+		basePath   = odConsts.DriveFolderPrefixBuilder(driveID).String()
+		expected   = getExpectedPathGenerator(t, bh, tenant, basePath)
+		rootPath   = expected("")
+		folderPath = expected("/folder")
+	)
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	drive.SetId(&driveID)
+	drive.SetName(&driveID)
+
+	itemPager := &countingDeltaPager{
+		DeltaPager: &apiMock.DeltaPager[models.DriveItemable]{
+			ToReturn: []apiMock.PagerResult[models.DriveItemable]{
+				{DeltaLink: &delta},
+			},
+		},
+	}
+
+	mbh := mock.DefaultOneDriveBH(user)
+	mbh.DrivePagerV = &apiMock.Pager[models.Driveable]{
+		ToReturn: []apiMock.PagerResult[models.Driveable]{
+			{Values: []models.Driveable{drive}},
+		},
+	}
+	mbh.ItemPagerV = map[string]api.DeltaPager[models.DriveItemable]{
+		driveID: itemPager,
+	}
+
+	prevPaths := map[string]string{
+		"root":   rootPath,
+		"folder": folderPath,
+	}
+
+	pathPrefix, err := mbh.MetadataPathPrefix(tenant)
+	require.NoError(t, err, clues.ToCore(err))
+
+	mc, err := graph.MakeMetadataCollection(
+		pathPrefix,
+		[]graph.MetadataCollectionEntry{
+			graph.NewMetadataEntry(bupMD.DeltaURLsFileName, map[string]string{driveID: prevDelta}),
+			graph.NewMetadataEntry(bupMD.PreviousPathFileName, map[string]map[string]string{driveID: prevPaths}),
+		},
+		func(*support.ControllerOperationStatus) {})
+	require.NoError(t, err, clues.ToCore(err))
+
+	c := NewCollections(
+		mbh,
+		tenant,
+		user,
+		func(*support.ControllerOperationStatus) {},
+		control.Options{ToggleFeatures: control.Toggles{}})
+
+	cols, canUsePreviousBackup, err := c.Get(
+		ctx,
+		[]data.RestoreCollection{data.NoFetchRestoreCollection{Collection: mc}},
+		prefixmatcher.NewStringSetBuilder(),
+		fault.New(true))
+	require.NoError(t, err, clues.ToCore(err))
+	assert.True(t, canUsePreviousBackup, "can use previous backup")
+
+	// only the delta check is requested from graph
+	assert.Equal(t, 1, itemPager.gets, "item pages fetched")
+	assert.Zero(t, c.NumItems, "enumerated items")
+
+	var sawRoot bool
+
+	for _, baseCol := range cols {
+		if baseCol.FullPath().String() == pathPrefix.String() {
+			deltas, paths, _, err := deserializeMetadata(
+				ctx,
+				[]data.RestoreCollection{data.NoFetchRestoreCollection{Collection: baseCol}})
+			require.NoError(t, err, clues.ToCore(err))
+
+			assert.Equal(t, map[string]string{driveID: delta}, deltas, "delta urls")
+			assert.Equal(t, map[string]map[string]string{driveID: prevPaths}, paths, "folder paths")
+
+			continue
+		}
+
+		col, ok := baseCol.(*Collection)
+		require.True(t, ok, "getting drive collection")
+
+		assert.Equal(t, rootPath, col.FullPath().String(), "collection path")
+		assert.Equal(t, data.NotMovedState, col.State(), "collection state")
+		assert.False(t, col.DoNotMergeItems(), "do not merge items")
+		assert.Empty(t, col.driveItems, "collection items")
+		assert.Nil(t, col.urlCache, "url cache")
+
+		sawRoot = true
+	}
+
+	assert.True(t, sawRoot, "root collection")
+}
+
 func (suite *OneDriveCollectionsUnitSuite) TestAddURLCacheToDriveCollections() {
 	driveID := "test-drive"
 	collCount := 3
@@ -0,0 +1,244 @@
+package drive
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/common/idname"
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/m365/collection/drive/metadata"
+	"github.com/alcionai/corso/src/internal/operations/inject"
+	"github.com/alcionai/corso/src/internal/version"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+// EstimateCollisions counts the files in the collections whose names collide
+// with items that already exist in the restore destination.  Nothing gets
+// created in the destination: drives or folders that don't exist yet can't
+// hold any collisions.  Collections outside of drive categories are ignored.
+// Returns the count of colliding files, and the count of all files.
+func EstimateCollisions(
+	ctx context.Context,
+	rh RestoreHandler,
+	rcc inject.RestoreConsumerConfig,
+	backupDriveIDNames idname.Cacher,
+	dcs []data.RestoreCollection,
+	errs *fault.Bus,
+) (int, int, error) {
+	var (
+		existing, total int
+		caches          = NewRestoreCaches(backupDriveIDNames)
+		el              = errs.Local()
+	)
+
+	if err := caches.Populate(ctx, rh, rcc.ProtectedResource.ID()); err != nil {
+		return 0, 0, clues.Wrap(err, "initializing restore caches")
+	}
+
+	for _, dc := range dcs {
+		if el.Failure() != nil {
+			break
+		}
+
+		cat := dc.FullPath().Category()
+		if cat != path.FilesCategory && cat != path.LibrariesCategory {
+			continue
+		}
+
+		ictx := clues.Add(ctx, "full_path", dc.FullPath())
+
+		e, t, err := estimateCollectionCollisions(ictx, rh, rcc, dc, caches, errs)
+		if err != nil {
+			el.AddRecoverable(ctx, clues.Wrap(err, "estimating collection collisions"))
+			continue
+		}
+
+		existing += e
+		total += t
+	}
+
+	return existing, total, el.Failure()
+}
+
+// estimateCollectionCollisions looks up the collection's restore folder and,
+// if it exists, populates the caches with the collision keys of the folder's
+// items before counting the collection's collisions.
+func estimateCollectionCollisions(
+	ctx context.Context,
+	rh RestoreHandler,
+	rcc inject.RestoreConsumerConfig,
+	dc data.RestoreCollection,
+	caches *restoreCaches,
+	errs *fault.Bus,
+) (int, int, error) {
+	drivePath, err := path.ToDrivePath(dc.FullPath())
+	if err != nil {
+		return 0, 0, clues.Wrap(err, "creating drive path").WithClues(ctx)
+	}
+
+	caches.collisionKeyToItemID = map[string]api.DriveItemIDType{}
+
+	di, ok := lookupRestoreDrive(caches, drivePath, rcc.RestoreConfig.TargetDriveID)
+	if ok {
+		restoreDir := &path.Builder{}
+
+		if len(rcc.RestoreConfig.Location) > 0 {
+			restoreDir = restoreDir.Append(rcc.RestoreConfig.Location)
+		}
+
+		restoreDir = restoreDir.Append(drivePath.Folders...)
+
+		folderID, found, err := lookupRestoreFolder(ctx, rh, di, restoreDir)
+		if err != nil {
+			return 0, 0, clues.Stack(err)
+		}
+
+		if found {
+			ckii, err := rh.GetItemsInContainerByCollisionKey(ctx, di.id, folderID)
+			if err != nil {
+				return 0, 0, clues.Wrap(err, "generating map of item collision keys")
+			}
+
+			caches.collisionKeyToItemID = ckii
+		}
+	}
+
+	return countCollisions(ctx, rcc.BackupVersion, dc, caches, errs)
+}
+
+// lookupRestoreDrive finds the existing drive that a restore of drivePath
+// writes into, following the same preference as ensureDriveExists.
+func lookupRestoreDrive(
+	caches *restoreCaches,
+	drivePath *path.DrivePath,
+	targetDriveID string,
+) (driveInfo, bool) {
+	if len(targetDriveID) > 0 {
+		return caches.DriveIDToDriveInfo.Load(targetDriveID)
+	}
+
+	if di, ok := caches.DriveIDToDriveInfo.Load(drivePath.DriveID); ok {
+		return di, true
+	}
+
+	if name, ok := caches.BackupDriveIDName.NameOf(drivePath.DriveID); ok {
+		return caches.DriveNameToDriveInfo.Load(name)
+	}
+
+	return driveInfo{}, false
+}
+
+// lookupRestoreFolder walks the restoreDir hierarchy down from the root of
+// the drive.  Returns false if any of the folders doesn't exist.
+func lookupRestoreFolder(
+	ctx context.Context,
+	gfbn GetFolderByNamer,
+	di driveInfo,
+	restoreDir *path.Builder,
+) (string, bool, error) {
+	folderID := di.rootFolderID
+
+	for _, name := range restoreDir.Elements() {
+		folder, err := gfbn.GetFolderByName(ctx, di.id, folderID, name)
+		if errors.Is(err, api.ErrFolderNotFound) {
+			return "", false, nil
+		}
+
+		if err != nil {
+			return "", false, clues.Wrap(err, "getting restore folder")
+		}
+
+		folderID = ptr.Val(folder.GetId())
+	}
+
+	return folderID, true, nil
+}
+
+// countCollisions counts the files in the collection whose collision keys
+// are found in the caches.  Returns the count of colliding files, and the
+// count of all files.
+func countCollisions(
+	ctx context.Context,
+	backupVersion int,
+	dc data.RestoreCollection,
+	caches *restoreCaches,
+	errs *fault.Bus,
+) (int, int, error) {
+	var (
+		existing, total int
+		el              = errs.Local()
+	)
+
+	for itemData := range dc.Items(ctx, errs) {
+		if el.Failure() != nil {
+			break
+		}
+
+		ictx := clues.Add(ctx, "restore_item_id", itemData.ID())
+
+		name, isFile, err := restoreFileName(ictx, backupVersion, dc, itemData)
+		if err != nil {
+			el.AddRecoverable(ictx, clues.Wrap(err, "getting item name"))
+			continue
+		}
+
+		if !isFile {
+			continue
+		}
+
+		total++
+
+		key := api.DriveItemCollisionKey(newItem(name, false))
+		if _, ok := caches.collisionKeyToItemID[key]; ok {
+			logger.Ctx(ictx).With("collision_key", clues.Hide(key)).Debug("item collision")
+
+			existing++
+		}
+	}
+
+	return existing, total, el.Failure()
+}
+
+// restoreFileName produces the name that a restored item receives, according
+// to the backup version.  Returns false for items that don't get restored as
+// files, such as metadata.
+func restoreFileName(
+	ctx context.Context,
+	backupVersion int,
+	fibn data.FetchItemByNamer,
+	itemData data.Item,
+) (string, bool, error) {
+	itemUUID := itemData.ID()
+
+	if backupVersion < version.OneDrive1DataAndMetaFiles {
+		return itemUUID, true, nil
+	}
+
+	if !strings.HasSuffix(itemUUID, metadata.DataFileSuffix) {
+		return "", false, nil
+	}
+
+	trimmedName := strings.TrimSuffix(itemUUID, metadata.DataFileSuffix)
+
+	if backupVersion < version.OneDrive6NameInMeta {
+		return trimmedName, true, nil
+	}
+
+	meta, err := FetchAndReadMetadata(ctx, fibn, trimmedName+metadata.MetaFileSuffix)
+	if err != nil {
+		return "", false, clues.Stack(err)
+	}
+
+	if len(meta.FileName) == 0 {
+		return "", false, clues.New("item with empty name").WithClues(ctx)
+	}
+
+	return meta.FileName, true, nil
+}
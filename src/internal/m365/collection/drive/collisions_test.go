@@ -0,0 +1,321 @@
+package drive
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/idname"
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	dataMock "github.com/alcionai/corso/src/internal/data/mock"
+	"github.com/alcionai/corso/src/internal/m365/collection/drive/metadata"
+	odConsts "github.com/alcionai/corso/src/internal/m365/service/onedrive/consts"
+	odMock "github.com/alcionai/corso/src/internal/m365/service/onedrive/mock"
+	"github.com/alcionai/corso/src/internal/operations/inject"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/internal/version"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+type CollisionsUnitSuite struct {
+	tester.Suite
+}
+
+func TestCollisionsUnitSuite(t *testing.T) {
+	suite.Run(t, &CollisionsUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+// collisionCollection produces a restore collection in driveID holding a
+// data and meta file for each of the names, in the layout of the backup
+// version.
+func collisionCollection(
+	t *testing.T,
+	backupVersion int,
+	driveID string,
+	names ...string,
+) dataMock.Collection {
+	p, err := odConsts.DriveFolderPrefixBuilder(driveID).
+		Append("folder").
+		ToDataLayerOneDrivePath("t", "u", false)
+	require.NoError(t, err, clues.ToCore(err))
+
+	coll := dataMock.Collection{
+		Path:     p,
+		AuxItems: map[string]data.Item{},
+	}
+
+	for i, name := range names {
+		if backupVersion < version.OneDrive1DataAndMetaFiles {
+			coll.ItemData = append(coll.ItemData, &dataMock.Item{ItemID: name})
+			continue
+		}
+
+		id := name
+		if backupVersion >= version.OneDrive6NameInMeta {
+			id = "item-" + string(rune('a'+i))
+		}
+
+		meta := &dataMock.Item{
+			ItemID: id + metadata.MetaFileSuffix,
+			Reader: odMock.FileRespReadCloser(`{"fileName": "` + name + `"}`),
+		}
+
+		coll.ItemData = append(
+			coll.ItemData,
+			&dataMock.Item{ItemID: id + metadata.DataFileSuffix},
+			meta)
+		coll.AuxItems[meta.ItemID] = meta
+	}
+
+	return coll
+}
+
+func (suite *CollisionsUnitSuite) TestCountCollisions() {
+	table := []struct {
+		name           string
+		backupVersion  int
+		collisionKeys  map[string]api.DriveItemIDType
+		names          []string
+		expectExisting int
+		expectTotal    int
+	}{
+		{
+			name:           "no existing items",
+			backupVersion:  version.Backup,
+			collisionKeys:  map[string]api.DriveItemIDType{},
+			names:          []string{"a.txt", "b.txt"},
+			expectExisting: 0,
+			expectTotal:    2,
+		},
+		{
+			name:          "some existing items",
+			backupVersion: version.Backup,
+			collisionKeys: map[string]api.DriveItemIDType{
+				"a.txt":     {ItemID: "1"},
+				"other.txt": {ItemID: "2"},
+			},
+			names:          []string{"a.txt", "b.txt", "c.txt"},
+			expectExisting: 1,
+			expectTotal:    3,
+		},
+		{
+			name:          "folder with a file's name",
+			backupVersion: version.Backup,
+			collisionKeys: map[string]api.DriveItemIDType{
+				"a.txt": {ItemID: "1", IsFolder: true},
+			},
+			names:          []string{"a.txt"},
+			expectExisting: 1,
+			expectTotal:    1,
+		},
+		{
+			name:          "names in item ids",
+			backupVersion: version.OneDrive1DataAndMetaFiles,
+			collisionKeys: map[string]api.DriveItemIDType{
+				"a.txt": {ItemID: "1"},
+				"b.txt": {ItemID: "2"},
+			},
+			names:          []string{"a.txt", "b.txt"},
+			expectExisting: 2,
+			expectTotal:    2,
+		},
+		{
+			name:          "no meta files",
+			backupVersion: version.NoBackup,
+			collisionKeys: map[string]api.DriveItemIDType{
+				"b.txt": {ItemID: "2"},
+			},
+			names:          []string{"a.txt", "b.txt"},
+			expectExisting: 1,
+			expectTotal:    2,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			caches := NewRestoreCaches(nil)
+			caches.collisionKeyToItemID = test.collisionKeys
+
+			coll := collisionCollection(t, test.backupVersion, "driveID1", test.names...)
+
+			existing, total, err := countCollisions(ctx, test.backupVersion, coll, caches, fault.New(true))
+			require.NoError(t, err, clues.ToCore(err))
+
+			assert.Equal(t, test.expectExisting, existing, "existing items")
+			assert.Equal(t, test.expectTotal, total, "total items")
+		})
+	}
+}
+
+func (suite *CollisionsUnitSuite) TestCountCollisions_missingMeta() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	coll := collisionCollection(t, version.Backup, "driveID1", "a.txt", "b.txt")
+	delete(coll.AuxItems, "item-a"+metadata.MetaFileSuffix)
+
+	caches := NewRestoreCaches(nil)
+	caches.collisionKeyToItemID = map[string]api.DriveItemIDType{
+		"b.txt": {ItemID: "2"},
+	}
+
+	errs := fault.New(false)
+
+	existing, total, err := countCollisions(ctx, version.Backup, coll, caches, errs)
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, 1, existing, "existing items")
+	assert.Equal(t, 1, total, "total items")
+	assert.Len(t, errs.Recovered(), 1, "recovered errors")
+}
+
+type mockGFBN struct {
+	folders map[string]string
+}
+
+func (m *mockGFBN) GetFolderByName(
+	_ context.Context,
+	_, _, name string,
+) (models.DriveItemable, error) {
+	id, ok := m.folders[name]
+	if !ok {
+		return nil, clues.Stack(api.ErrFolderNotFound)
+	}
+
+	folder := models.NewDriveItem()
+	folder.SetId(ptr.To(id))
+	folder.SetFolder(models.NewFolder())
+
+	return folder, nil
+}
+
+func (suite *CollisionsUnitSuite) TestLookupRestoreFolder() {
+	di := driveInfo{id: "d", rootFolderID: "root"}
+
+	table := []struct {
+		name        string
+		folders     map[string]string
+		restoreDir  *path.Builder
+		expectID    string
+		expectFound assert.BoolAssertionFunc
+	}{
+		{
+			name:        "drive root",
+			restoreDir:  &path.Builder{},
+			expectID:    "root",
+			expectFound: assert.True,
+		},
+		{
+			name:        "existing folders",
+			folders:     map[string]string{"restore": "r-id", "folder": "f-id"},
+			restoreDir:  path.Builder{}.Append("restore", "folder"),
+			expectID:    "f-id",
+			expectFound: assert.True,
+		},
+		{
+			name:        "missing folder",
+			folders:     map[string]string{"restore": "r-id"},
+			restoreDir:  path.Builder{}.Append("restore", "folder"),
+			expectFound: assert.False,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			id, found, err := lookupRestoreFolder(ctx, &mockGFBN{folders: test.folders}, di, test.restoreDir)
+			require.NoError(t, err, clues.ToCore(err))
+
+			test.expectFound(t, found, "folder found")
+			assert.Equal(t, test.expectID, id)
+		})
+	}
+}
+
+func (suite *CollisionsUnitSuite) TestEstimateCollectionCollisions() {
+	table := []struct {
+		name           string
+		cacheDrive     bool
+		backupDriveIDs map[string]string
+		targetDriveID  string
+		expectExisting int
+	}{
+		{
+			name:           "drive found by id",
+			cacheDrive:     true,
+			expectExisting: 1,
+		},
+		{
+			name:           "drive found by backup name",
+			backupDriveIDs: map[string]string{"driveid1": "docs"},
+			expectExisting: 1,
+		},
+		{
+			name:           "target drive",
+			targetDriveID:  "restore-drive",
+			expectExisting: 1,
+		},
+		{
+			name:           "drive does not exist",
+			expectExisting: 0,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				caches = NewRestoreCaches(idname.NewCache(test.backupDriveIDs))
+				rh     = &odMock.RestoreHandler{
+					CollisionKeyMap: map[string]api.DriveItemIDType{
+						"a.txt": {ItemID: "1"},
+					},
+				}
+				rcc = inject.RestoreConsumerConfig{
+					BackupVersion: version.Backup,
+					RestoreConfig: control.RestoreConfig{
+						Location:      "restore",
+						TargetDriveID: test.targetDriveID,
+					},
+				}
+				restoreDrive = driveInfo{id: "restore-drive", name: "docs", rootFolderID: "root"}
+			)
+
+			caches.DriveIDToDriveInfo.Store(restoreDrive.id, restoreDrive)
+			caches.DriveNameToDriveInfo.Store(restoreDrive.name, restoreDrive)
+
+			if test.cacheDrive {
+				caches.DriveIDToDriveInfo.Store("driveid1", restoreDrive)
+			}
+
+			coll := collisionCollection(t, version.Backup, "driveid1", "a.txt", "b.txt")
+
+			existing, total, err := estimateCollectionCollisions(ctx, rh, rcc, coll, caches, fault.New(true))
+			require.NoError(t, err, clues.ToCore(err))
+
+			assert.Equal(t, test.expectExisting, existing, "existing items")
+			assert.Equal(t, 2, total, "total items")
+		})
+	}
+}
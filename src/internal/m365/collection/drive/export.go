@@ -18,11 +18,13 @@ func NewExportCollection(
 	baseDir string,
 	backingCollection []data.RestoreCollection,
 	backupVersion int,
+	cec control.ExportConfig,
 ) export.Collectioner {
 	return export.BaseCollection{
 		BaseDir:           baseDir,
 		BackingCollection: backingCollection,
 		BackupVersion:     backupVersion,
+		Cfg:               cec,
 		Stream:            streamItems,
 	}
 }
@@ -52,7 +54,7 @@ func streamItems(
 				ID:    itemUUID,
 				Name:  name,
 				Body:  item.ToReader(),
-				Error: err,
+				Error: export.ItemError(err, rc.FullPath(), itemUUID),
 			}
 		}
 
@@ -62,13 +64,13 @@ func streamItems(
 		for _, err := range items {
 			ch <- export.Item{
 				ID:    err.ID,
-				Error: &err,
+				Error: export.ItemError(&err, rc.FullPath(), err.ID),
 			}
 		}
 
 		for _, err := range recovered {
 			ch <- export.Item{
-				Error: err,
+				Error: export.ItemError(err, rc.FullPath(), ""),
 			}
 		}
 	}
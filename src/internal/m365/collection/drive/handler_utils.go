@@ -67,6 +67,7 @@ func augmentItemInfo(
 			Created:    ptr.Val(item.GetCreatedDateTime()),
 			DriveID:    driveID,
 			DriveName:  driveName,
+			ETag:       ptr.Val(item.GetETag()),
 			ItemName:   ptr.Val(item.GetName()),
 			ItemType:   details.OneDriveItem,
 			Modified:   ptr.Val(item.GetLastModifiedDateTime()),
@@ -79,6 +80,7 @@ func augmentItemInfo(
 			Created:    ptr.Val(item.GetCreatedDateTime()),
 			DriveID:    driveID,
 			DriveName:  driveName,
+			ETag:       ptr.Val(item.GetETag()),
 			ItemName:   ptr.Val(item.GetName()),
 			ItemType:   details.SharePointLibrary,
 			Modified:   ptr.Val(item.GetLastModifiedDateTime()),
@@ -94,6 +96,7 @@ func augmentItemInfo(
 			Created:    ptr.Val(item.GetCreatedDateTime()),
 			DriveID:    driveID,
 			DriveName:  driveName,
+			ETag:       ptr.Val(item.GetETag()),
 			ItemName:   ptr.Val(item.GetName()),
 			ItemType:   details.SharePointLibrary,
 			Modified:   ptr.Val(item.GetLastModifiedDateTime()),
@@ -84,7 +84,9 @@ type GetItemer interface {
 // ---------------------------------------------------------------------------
 
 type RestoreHandler interface {
+	CopyItemer
 	DeleteItemer
+	DeleteItemIfMatcher
 	DeleteItemPermissioner
 	GetFolderByNamer
 	GetItemsByCollisionKeyser
@@ -99,6 +101,15 @@ type RestoreHandler interface {
 	UpdateItemLinkSharer
 }
 
+type CopyItemer interface {
+	// CopyItem copies an existing item into the parent folder,
+	// under the given name, and returns the copied item.
+	CopyItem(
+		ctx context.Context,
+		driveID, itemID, parentFolderID, name string,
+	) (models.DriveItemable, error)
+}
+
 type DeleteItemer interface {
 	DeleteItem(
 		ctx context.Context,
@@ -106,6 +117,15 @@ type DeleteItemer interface {
 	) error
 }
 
+type DeleteItemIfMatcher interface {
+	// DeleteItemIfMatch deletes the item only if its current eTag matches
+	// the provided eTag.
+	DeleteItemIfMatch(
+		ctx context.Context,
+		driveID, itemID, eTag string,
+	) error
+}
+
 type DeleteItemPermissioner interface {
 	DeleteItemPermission(
 		ctx context.Context,
@@ -128,10 +148,12 @@ type GetItemsByCollisionKeyser interface {
 
 type NewItemContentUploader interface {
 	// NewItemContentUpload creates an upload session which is used as a writer
-	// for large item content.
+	// for large item content.  A non-nil fsi sets the item's file system
+	// timestamps.
 	NewItemContentUpload(
 		ctx context.Context,
 		driveID, itemID string,
+		fsi models.FileSystemInfoable,
 	) (models.UploadSessionable, error)
 }
 
@@ -84,6 +84,10 @@ func (dg *downloadWithRetries) Get(
 	// wouldn't work without it (get 416 responses instead of 206).
 	headers[acceptHeaderKey] = acceptHeaderValue
 
+	// file content can be large, and shouldn't be held to the
+	// timeout of metadata requests.
+	ctx = graph.BindRequestCategory(ctx, graph.DownloadRequests)
+
 	resp, err := dg.getter.Get(ctx, dg.url, headers)
 	if err != nil {
 		return nil, clues.Wrap(err, "getting file")
@@ -136,10 +140,29 @@ func downloadItemMeta(
 	gip GetItemPermissioner,
 	driveID string,
 	item models.DriveItemable,
+	skipPerms bool,
 ) (io.ReadCloser, int, error) {
-	meta := metadata.Metadata{FileName: ptr.Val(item.GetName())}
+	meta := metadata.Metadata{
+		FileName: ptr.Val(item.GetName()),
+		Created:  item.GetCreatedDateTime(),
+		Modified: item.GetLastModifiedDateTime(),
+	}
+
+	// prefer the client-side timestamps, which are what a restore
+	// gets to set.
+	if fsi := item.GetFileSystemInfo(); fsi != nil {
+		if fsi.GetCreatedDateTime() != nil {
+			meta.Created = fsi.GetCreatedDateTime()
+		}
+
+		if fsi.GetLastModifiedDateTime() != nil {
+			meta.Modified = fsi.GetLastModifiedDateTime()
+		}
+	}
 
-	if item.GetShared() == nil {
+	// when skipping permissions, the item is recorded as inheriting
+	// its permissions so that restores make no sharing changes.
+	if skipPerms || item.GetShared() == nil {
 		meta.SharingMode = metadata.SharingModeInherited
 	} else {
 		meta.SharingMode = metadata.SharingModeCustom
@@ -171,10 +194,11 @@ func driveItemWriter(
 	nicu NewItemContentUploader,
 	driveID, itemID string,
 	itemSize int64,
+	fsi models.FileSystemInfoable,
 ) (io.Writer, string, error) {
 	ctx = clues.Add(ctx, "upload_item_id", itemID)
 
-	icu, err := nicu.NewItemContentUpload(ctx, driveID, itemID)
+	icu, err := nicu.NewItemContentUpload(ctx, driveID, itemID, fsi)
 	if err != nil {
 		return nil, "", clues.Stack(err)
 	}
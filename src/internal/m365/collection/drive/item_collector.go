@@ -2,11 +2,15 @@ package drive
 
 import (
 	"context"
+	"time"
 
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
 	"golang.org/x/exp/maps"
 
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/m365/collection/drive/metadata"
 	"github.com/alcionai/corso/src/internal/m365/graph"
+	"github.com/alcionai/corso/src/pkg/backup/details"
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/logger"
 	"github.com/alcionai/corso/src/pkg/services/m365/api"
@@ -127,6 +131,215 @@ func collectItems(
 	return DeltaUpdate{URL: newDeltaURL, Reset: invalidPrevDelta}, newPaths, excluded, nil
 }
 
+// replayDeltaPager hands back a page that was already fetched from the
+// wrapped pager before deferring to it for any further pages.
+type replayDeltaPager struct {
+	api.DeltaPager[models.DriveItemable]
+	page   api.DeltaLinkValuer[models.DriveItemable]
+	err    error
+	replay bool
+}
+
+func (p *replayDeltaPager) GetPage(
+	ctx context.Context,
+) (api.DeltaLinkValuer[models.DriveItemable], error) {
+	if p.replay {
+		p.replay = false
+		return p.page, p.err
+	}
+
+	return p.DeltaPager.GetPage(ctx)
+}
+
+func (p *replayDeltaPager) Reset(ctx context.Context) {
+	p.replay = false
+	p.DeltaPager.Reset(ctx)
+}
+
+// peekUnchangedDelta fetches the first page of the drive's delta from
+// prevDelta to check whether anything changed since the previous backup.
+// The drive is unchanged when that page is the last one, holds no items,
+// and provides a new delta link, which gets returned.  The returned pager
+// replays the fetched page, so that enumerating a changed drive doesn't
+// repeat the call.
+func peekUnchangedDelta(
+	ctx context.Context,
+	pager api.DeltaPager[models.DriveItemable],
+	prevDelta string,
+) (api.DeltaPager[models.DriveItemable], string, bool) {
+	pager.SetNextLink(prevDelta)
+
+	page, err := pager.GetPage(graph.ConsumeNTokens(ctx, graph.SingleGetOrDeltaLC))
+	replay := &replayDeltaPager{
+		DeltaPager: pager,
+		page:       page,
+		err:        err,
+		replay:     true,
+	}
+
+	if err != nil {
+		return replay, "", false
+	}
+
+	nextLink, deltaLink := api.NextAndDeltaLink(page)
+
+	if len(page.GetValue()) > 0 || len(nextLink) > 0 || len(deltaLink) == 0 {
+		return replay, "", false
+	}
+
+	return replay, deltaLink, true
+}
+
+// modifiedSinceCollector wraps the collector so that it only receives the
+// files modified after since.  Folders, packages, and deletions are always
+// passed along, so that the drive's hierarchy is still built in full.
+func modifiedSinceCollector(since time.Time, collector itemCollector) itemCollector {
+	return func(
+		ctx context.Context,
+		driveID, driveName string,
+		driveItems []models.DriveItemable,
+		oldPaths map[string]string,
+		newPaths map[string]string,
+		excluded map[string]struct{},
+		itemCollections map[string]map[string]string,
+		validPrevDelta bool,
+		errs *fault.Bus,
+	) error {
+		items := make([]models.DriveItemable, 0, len(driveItems))
+
+		for _, item := range driveItems {
+			modTime := item.GetLastModifiedDateTime()
+
+			if item.GetFile() != nil &&
+				item.GetDeleted() == nil &&
+				modTime != nil &&
+				!modTime.After(since) {
+				continue
+			}
+
+			items = append(items, item)
+		}
+
+		return collector(
+			ctx,
+			driveID,
+			driveName,
+			items,
+			oldPaths,
+			newPaths,
+			excluded,
+			itemCollections,
+			validPrevDelta,
+			errs)
+	}
+}
+
+// checksumFilter drops the files that are unchanged since the previous
+// backup from the enumeration of a drive whose previous delta token got
+// rejected.  A file is unchanged when its etag and size match the checksum
+// recorded in the previous backup's details.
+type checksumFilter struct {
+	checksums map[string]details.ItemChecksum
+	// ids of the enumerated files, whether they changed or not.
+	seen map[string]struct{}
+	// true once the filter has been applied to an enumeration without
+	// a valid delta token.
+	applied bool
+}
+
+func newChecksumFilter(checksums map[string]details.ItemChecksum) *checksumFilter {
+	return &checksumFilter{
+		checksums: checksums,
+		seen:      map[string]struct{}{},
+	}
+}
+
+// unchanged returns true if the file matches its checksum from the
+// previous backup.
+func (cf *checksumFilter) unchanged(item models.DriveItemable) bool {
+	ic, ok := cf.checksums[ptr.Val(item.GetId())]
+
+	return ok &&
+		len(ic.ETag) > 0 &&
+		ic.ETag == ptr.Val(item.GetETag()) &&
+		ic.Size == ptr.Val(item.GetSize())
+}
+
+// collector wraps the collector with the filter.  Enumerations that use a
+// valid delta token only contain changes, and get passed along as-is.
+// Otherwise, unchanged files are dropped, and the collector is handed a
+// valid delta so that the resulting collections get merged with the
+// previous backup, which still holds the unchanged files.
+func (cf *checksumFilter) collector(collector itemCollector) itemCollector {
+	return func(
+		ctx context.Context,
+		driveID, driveName string,
+		driveItems []models.DriveItemable,
+		oldPaths map[string]string,
+		newPaths map[string]string,
+		excluded map[string]struct{},
+		itemCollections map[string]map[string]string,
+		invalidPrevDelta bool,
+		errs *fault.Bus,
+	) error {
+		if !invalidPrevDelta {
+			return collector(
+				ctx,
+				driveID,
+				driveName,
+				driveItems,
+				oldPaths,
+				newPaths,
+				excluded,
+				itemCollections,
+				invalidPrevDelta,
+				errs)
+		}
+
+		cf.applied = true
+
+		items := make([]models.DriveItemable, 0, len(driveItems))
+
+		for _, item := range driveItems {
+			if item.GetFile() != nil && item.GetDeleted() == nil {
+				cf.seen[ptr.Val(item.GetId())] = struct{}{}
+
+				if cf.unchanged(item) {
+					continue
+				}
+			}
+
+			items = append(items, item)
+		}
+
+		return collector(
+			ctx,
+			driveID,
+			driveName,
+			items,
+			oldPaths,
+			newPaths,
+			excluded,
+			itemCollections,
+			false,
+			errs)
+	}
+}
+
+// excludeUnseen adds the files from the previous backup that didn't turn
+// up in the enumeration to the excluded set.  Without a valid delta token
+// there are no deletion markers, so an absent file is a deleted file.
+func (cf *checksumFilter) excludeUnseen(excluded map[string]struct{}) {
+	for id := range cf.checksums {
+		if _, ok := cf.seen[id]; ok {
+			continue
+		}
+
+		excluded[id+metadata.DataFileSuffix] = struct{}{}
+		excluded[id+metadata.MetaFileSuffix] = struct{}{}
+	}
+}
+
 // newItem initializes a `models.DriveItemable` that can be used as input to `createItem`
 func newItem(name string, folder bool) *models.DriveItem {
 	itemToCreate := models.NewDriveItem()
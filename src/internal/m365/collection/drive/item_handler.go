@@ -187,6 +187,13 @@ func (h itemRestoreHandler) DeleteItem(
 	return h.ac.DeleteItem(ctx, driveID, itemID)
 }
 
+func (h itemRestoreHandler) DeleteItemIfMatch(
+	ctx context.Context,
+	driveID, itemID, eTag string,
+) error {
+	return h.ac.DeleteItemIfMatch(ctx, driveID, itemID, eTag)
+}
+
 func (h itemRestoreHandler) DeleteItemPermission(
 	ctx context.Context,
 	driveID, itemID, permissionID string,
@@ -209,8 +216,9 @@ func (h itemRestoreHandler) GetItemsInContainerByCollisionKey(
 func (h itemRestoreHandler) NewItemContentUpload(
 	ctx context.Context,
 	driveID, itemID string,
+	fsi models.FileSystemInfoable,
 ) (models.UploadSessionable, error) {
-	return h.ac.NewItemContentUpload(ctx, driveID, itemID)
+	return h.ac.NewItemContentUpload(ctx, driveID, itemID, fsi)
 }
 
 func (h itemRestoreHandler) PostItemPermissionUpdate(
@@ -229,6 +237,13 @@ func (h itemRestoreHandler) PostItemLinkShareUpdate(
 	return h.ac.PostItemLinkShareUpdate(ctx, driveID, itemID, body)
 }
 
+func (h itemRestoreHandler) CopyItem(
+	ctx context.Context,
+	driveID, itemID, parentFolderID, name string,
+) (models.DriveItemable, error) {
+	return h.ac.CopyItem(ctx, driveID, itemID, parentFolderID, name)
+}
+
 func (h itemRestoreHandler) PostItemInContainer(
 	ctx context.Context,
 	driveID, parentFolderID string,
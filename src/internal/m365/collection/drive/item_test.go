@@ -3,10 +3,12 @@ package drive
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"io"
 	"net/http"
 	"syscall"
 	"testing"
+	"time"
 
 	"github.com/alcionai/clues"
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
@@ -16,6 +18,8 @@ import (
 
 	"github.com/alcionai/corso/src/internal/common/dttm"
 	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/m365/collection/drive/metadata"
+	"github.com/alcionai/corso/src/internal/m365/graph"
 	"github.com/alcionai/corso/src/internal/tester"
 	"github.com/alcionai/corso/src/internal/tester/tconfig"
 	"github.com/alcionai/corso/src/pkg/control"
@@ -205,7 +209,8 @@ func (suite *ItemIntegrationSuite) TestItemWriter() {
 				rh,
 				test.driveID,
 				ptr.Val(newItem.GetId()),
-				writeSize)
+				writeSize,
+				nil)
 			require.NoError(t, err, clues.ToCore(err))
 
 			// Using a 32 KB buffer for the copy allows us to validate the
@@ -442,6 +447,33 @@ func (suite *ItemUnitTestSuite) TestDownloadItem() {
 	}
 }
 
+func (suite *ItemUnitTestSuite) TestDownloadItem_statusLabel() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	di := newItem("test", false)
+	di.SetAdditionalData(map[string]any{
+		"@microsoft.graph.downloadUrl": "https://example.com",
+	})
+
+	mg := mockGetter{
+		GetFunc: func(ctx context.Context, url string) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusNotImplemented,
+				Status:     http.StatusText(http.StatusNotImplemented),
+			}, nil
+		},
+	}
+
+	// callers identify unsupported downloads, such as OneNote sections,
+	// by the status label on the error.
+	_, err := downloadItem(ctx, mg, di)
+	require.Error(t, err, clues.ToCore(err))
+	assert.True(t, clues.HasLabel(err, graph.LabelStatus(http.StatusNotImplemented)), clues.ToCore(err))
+}
+
 type errReader struct{}
 
 func (r errReader) Read(p []byte) (int, error) {
@@ -502,3 +534,62 @@ func (suite *ItemUnitTestSuite) TestDownloadItem_ConnectionResetErrorOnFirstRead
 	require.NoError(t, err, clues.ToCore(err))
 	assert.Equal(t, testData, data)
 }
+
+func (suite *ItemUnitTestSuite) TestDownloadItemMeta_timestamps() {
+	var (
+		created   = time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+		modified  = created.Add(time.Hour)
+		fsCreated = created.Add(-24 * time.Hour)
+	)
+
+	table := []struct {
+		name           string
+		fsi            models.FileSystemInfoable
+		expectCreated  time.Time
+		expectModified time.Time
+	}{
+		{
+			name:           "item timestamps",
+			expectCreated:  created,
+			expectModified: modified,
+		},
+		{
+			name: "file system timestamps",
+			fsi: func() models.FileSystemInfoable {
+				fsi := models.NewFileSystemInfo()
+				fsi.SetCreatedDateTime(ptr.To(fsCreated))
+
+				return fsi
+			}(),
+			expectCreated:  fsCreated,
+			expectModified: modified,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			item := models.NewDriveItem()
+			item.SetName(ptr.To("file.txt"))
+			item.SetCreatedDateTime(ptr.To(created))
+			item.SetLastModifiedDateTime(ptr.To(modified))
+			item.SetFileSystemInfo(test.fsi)
+
+			rc, _, err := downloadItemMeta(ctx, nil, "drive-id", item, true)
+			require.NoError(t, err, clues.ToCore(err))
+
+			meta := metadata.Metadata{}
+
+			err = json.NewDecoder(rc).Decode(&meta)
+			require.NoError(t, err, clues.ToCore(err))
+
+			require.NotNil(t, meta.Created)
+			require.NotNil(t, meta.Modified)
+			assert.True(t, test.expectCreated.Equal(*meta.Created), "created")
+			assert.True(t, test.expectModified.Equal(*meta.Modified), "modified")
+		})
+	}
+}
@@ -222,6 +222,13 @@ func (h libraryRestoreHandler) DeleteItem(
 	return h.ac.Drives().DeleteItem(ctx, driveID, itemID)
 }
 
+func (h libraryRestoreHandler) DeleteItemIfMatch(
+	ctx context.Context,
+	driveID, itemID, eTag string,
+) error {
+	return h.ac.Drives().DeleteItemIfMatch(ctx, driveID, itemID, eTag)
+}
+
 func (h libraryRestoreHandler) DeleteItemPermission(
 	ctx context.Context,
 	driveID, itemID, permissionID string,
@@ -244,8 +251,9 @@ func (h libraryRestoreHandler) GetItemsInContainerByCollisionKey(
 func (h libraryRestoreHandler) NewItemContentUpload(
 	ctx context.Context,
 	driveID, itemID string,
+	fsi models.FileSystemInfoable,
 ) (models.UploadSessionable, error) {
-	return h.ac.Drives().NewItemContentUpload(ctx, driveID, itemID)
+	return h.ac.Drives().NewItemContentUpload(ctx, driveID, itemID, fsi)
 }
 
 func (h libraryRestoreHandler) PostItemPermissionUpdate(
@@ -264,6 +272,13 @@ func (h libraryRestoreHandler) PostItemLinkShareUpdate(
 	return h.ac.Drives().PostItemLinkShareUpdate(ctx, driveID, itemID, body)
 }
 
+func (h libraryRestoreHandler) CopyItem(
+	ctx context.Context,
+	driveID, itemID, parentFolderID, name string,
+) (models.DriveItemable, error) {
+	return h.ac.Drives().CopyItem(ctx, driveID, itemID, parentFolderID, name)
+}
+
 func (h libraryRestoreHandler) PostItemInContainer(
 	ctx context.Context,
 	driveID, parentFolderID string,
@@ -10,6 +10,15 @@ type Entity struct {
 	EntityType GV2Type `json:"entityType,omitempty"`
 }
 
+// Link share scopes, as reported by graph.  Anonymous and organization
+// links grant access to anyone holding the link, within those bounds.
+// Users links only grant access to the entities they were shared with.
+const (
+	LinkScopeAnonymous    = "anonymous"
+	LinkScopeOrganization = "organization"
+	LinkScopeUsers        = "users"
+)
+
 type LinkShareLink struct {
 	Scope            string `json:"scope,omitempty"`
 	Type             string `json:"type,omitempty"`
@@ -30,6 +39,18 @@ func (ls LinkShare) Equals(other LinkShare) bool {
 	return ls.Link.WebURL == other.Link.WebURL
 }
 
+// GrantsAccess is true if the link share gives anyone access to the item.
+// Anonymous and organization links grant access on their own, while other
+// links only grant access to their entities.
+func (ls LinkShare) GrantsAccess() bool {
+	switch ls.Link.Scope {
+	case LinkScopeAnonymous, LinkScopeOrganization:
+		return true
+	default:
+		return len(ls.Entities) > 0
+	}
+}
+
 // ItemMeta contains metadata about the Item. It gets stored in a
 // separate file in kopia
 type Metadata struct {
@@ -40,6 +61,10 @@ type Metadata struct {
 	SharingMode SharingMode  `json:"permissionMode,omitempty"`
 	Permissions []Permission `json:"permissions,omitempty"`
 	LinkShares  []LinkShare  `json:"linkShares,omitempty"`
+	// Created and Modified hold the file's timestamps at the time of
+	// backup.  Older backups don't record them.
+	Created  *time.Time `json:"created,omitempty"`
+	Modified *time.Time `json:"modified,omitempty"`
 }
 
 type Item struct {
@@ -66,20 +66,22 @@ func (p Permission) Equals(other Permission) bool {
 }
 
 // DiffLinkShares is just a wrapper on top of DiffPermissions but we
-// filter out link shares which do not have any associated users. This
-// is useful for two reason:
+// filter out link shares which don't grant anyone access.  Those are
+// user-scoped links without any associated users.  This is useful
+// for two reason:
 //   - When a user creates a link share on parent after creating a child
 //     link with `retainInheritedPermissisons`, all the previous link shares
 //     are inherited onto the child but without any users associated with
 //     the share. We have to drop the empty ones to make sure we reset.
-//   - We are restoring link shares so that we can restore permissions for
-//     the user, but restoring links without users is not useful.
+//   - Restoring user-scoped links without users is not useful.  Anonymous
+//     and organization links are kept, so that collaborators holding
+//     those links retain access.
 func DiffLinkShares(current, expected []LinkShare) ([]LinkShare, []LinkShare) {
 	filteredCurrent := []LinkShare{}
 	filteredExpected := []LinkShare{}
 
 	for _, ls := range current {
-		if len(ls.Entities) == 0 {
+		if !ls.GrantsAccess() {
 			continue
 		}
 
@@ -87,7 +89,7 @@ func DiffLinkShares(current, expected []LinkShare) ([]LinkShare, []LinkShare) {
 	}
 
 	for _, ls := range expected {
-		if len(ls.Entities) == 0 {
+		if !ls.GrantsAccess() {
 			continue
 		}
 
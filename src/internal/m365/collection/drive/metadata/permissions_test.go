@@ -1,10 +1,14 @@
 package metadata
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 
+	"github.com/alcionai/clues"
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/alcionai/corso/src/internal/tester"
@@ -162,6 +166,11 @@ func (suite *PermissionsUnitTestSuite) TestDiffLinkShares() {
 		Link: LinkShareLink{WebURL: "id2"},
 	}
 
+	lsanon := LinkShare{
+		ID:   "id3",
+		Link: LinkShareLink{WebURL: "id3", Scope: LinkScopeAnonymous, Type: "view"},
+	}
+
 	table := []struct {
 		name    string
 		before  []LinkShare
@@ -197,6 +206,20 @@ func (suite *PermissionsUnitTestSuite) TestDiffLinkShares() {
 			added:   []LinkShare{},
 			removed: []LinkShare{ls1},
 		},
+		{
+			name:    "anonymous link added",
+			before:  []LinkShare{ls1},
+			after:   []LinkShare{ls1, lsanon},
+			added:   []LinkShare{lsanon},
+			removed: []LinkShare{},
+		},
+		{
+			name:    "inherited anonymous link",
+			before:  []LinkShare{lsanon},
+			after:   []LinkShare{lsanon},
+			added:   []LinkShare{},
+			removed: []LinkShare{},
+		},
 	}
 
 	for _, test := range table {
@@ -214,6 +237,74 @@ func (suite *PermissionsUnitTestSuite) TestDiffLinkShares() {
 	}
 }
 
+func (suite *PermissionsUnitTestSuite) TestLinkShare_GrantsAccess() {
+	table := []struct {
+		name   string
+		ls     LinkShare
+		expect assert.BoolAssertionFunc
+	}{
+		{
+			name:   "anonymous",
+			ls:     LinkShare{Link: LinkShareLink{Scope: LinkScopeAnonymous}},
+			expect: assert.True,
+		},
+		{
+			name:   "organization",
+			ls:     LinkShare{Link: LinkShareLink{Scope: LinkScopeOrganization}},
+			expect: assert.True,
+		},
+		{
+			name:   "users with entities",
+			ls:     LinkShare{Link: LinkShareLink{Scope: LinkScopeUsers}, Entities: []Entity{{ID: "e1"}}},
+			expect: assert.True,
+		},
+		{
+			name:   "users without entities",
+			ls:     LinkShare{Link: LinkShareLink{Scope: LinkScopeUsers}},
+			expect: assert.False,
+		},
+		{
+			name:   "no scope",
+			ls:     LinkShare{},
+			expect: assert.False,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			test.expect(suite.T(), test.ls.GrantsAccess())
+		})
+	}
+}
+
+func (suite *PermissionsUnitTestSuite) TestLinkShare_serialization() {
+	t := suite.T()
+
+	expires := time.Now().UTC().Truncate(time.Second)
+	ls := LinkShare{
+		ID: "id",
+		Link: LinkShareLink{
+			Scope:            LinkScopeOrganization,
+			Type:             "edit",
+			WebURL:           "https://link",
+			PreventsDownload: true,
+		},
+		Roles:      []string{"write"},
+		Expiration: &expires,
+	}
+
+	bs, err := json.Marshal(Metadata{LinkShares: []LinkShare{ls}})
+	require.NoError(t, err, clues.ToCore(err))
+
+	var result Metadata
+
+	err = json.Unmarshal(bs, &result)
+	require.NoError(t, err, clues.ToCore(err))
+	require.Len(t, result.LinkShares, 1)
+
+	assert.Equal(t, ls, result.LinkShares[0])
+	assert.True(t, result.LinkShares[0].GrantsAccess(), "organization links grant access without entities")
+}
+
 func getPermsAndResourceOwnerPerms(
 	permID, resourceOwner string,
 	gv2t GV2Type,
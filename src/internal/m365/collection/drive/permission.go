@@ -2,7 +2,9 @@ package drive
 
 import (
 	"context"
+	"errors"
 	"strings"
+	"time"
 
 	"github.com/alcionai/clues"
 	"github.com/microsoftgraph/msgraph-sdk-go/drives"
@@ -79,6 +81,12 @@ func getCollectionMetadata(
 	}
 
 	meta, err := FetchAndReadMetadata(ctx, dc, metaName)
+	if errors.Is(err, data.ErrNotFound) {
+		// backups that skip permissions metadata have no .dirmeta files.
+		// The folder falls back to inheriting its parent's permissions.
+		return metadata.Metadata{SharingMode: metadata.SharingModeInherited}, nil
+	}
+
 	if err != nil {
 		return metadata.Metadata{}, clues.Wrap(err, "collection metadata")
 	}
@@ -313,18 +321,35 @@ func UpdateLinkShares(
 	alreadyDeleted := false
 	el := errs.Local()
 
+	now := time.Now()
+
 	for _, ls := range lsAdded {
 		if el.Failure() != nil {
 			break
 		}
 
-		ictx := clues.Add(ctx, "link_share_id", ls.ID)
+		ictx := clues.Add(
+			ctx,
+			"link_share_id", ls.ID,
+			"link_share_scope", ls.Link.Scope,
+			"link_share_type", ls.Link.Type)
+
+		lsr := planLinkShareRestore(ls, now)
+		if len(lsr.skipReason) > 0 {
+			logger.Ctx(ictx).
+				With("skipped_reason", fault.SkipLinkShare, "link_share_skip_reason", lsr.skipReason).
+				Info("link share not restorable")
+
+			el.AddSkip(ictx, fault.FileSkip(
+				fault.SkipLinkShare,
+				driveID,
+				ls.ID,
+				ls.Link.Scope+" "+ls.Link.Type+" link",
+				map[string]any{
+					"item_id":     itemID,
+					"skip_reason": lsr.skipReason,
+				}))
 
-		// Links with password are not shared with a specific user
-		// even when we select a particular user, plus we are not
-		// able to get the password or retain the original link and
-		// so restoring them makes no sense.
-		if ls.HasPassword {
 			continue
 		}
 
@@ -362,14 +387,20 @@ func UpdateLinkShares(
 		//   "retainInheritedPermissions": false
 		// }
 		lsbody := drives.NewItemItemsItemCreateLinkPostRequestBody()
-		lsbody.SetTypeEscaped(ptr.To(ls.Link.Type))
+		lsbody.SetTypeEscaped(ptr.To(lsr.linkType))
 		lsbody.SetScope(ptr.To(ls.Link.Scope))
 		lsbody.SetExpirationDateTime(ls.Expiration)
 
 		ad := map[string]any{
 			"sendNotification": false,
-			"recipients":       idens,
 		}
+
+		// anonymous and organization links aren't shared with anyone
+		// in particular.
+		if len(idens) > 0 {
+			ad["recipients"] = idens
+		}
+
 		lsbody.SetAdditionalData(ad)
 
 		if !alreadyDeleted {
@@ -430,6 +461,65 @@ func UpdateLinkShares(
 	return alreadyDeleted, nil
 }
 
+// restorableLinkScopes are the link share scopes that graph can create.
+var restorableLinkScopes = map[string]struct{}{
+	metadata.LinkScopeAnonymous:    {},
+	metadata.LinkScopeOrganization: {},
+	metadata.LinkScopeUsers:        {},
+}
+
+// restorableLinkTypes are the link share types that graph can create.
+var restorableLinkTypes = map[string]struct{}{
+	"view":           {},
+	"edit":           {},
+	"embed":          {},
+	"blocksDownload": {},
+	"createOnly":     {},
+}
+
+// linkShareRestore describes how a link share gets recreated during a
+// restore.
+type linkShareRestore struct {
+	// linkType is the type of link to create.
+	linkType string
+	// skipReason is populated if the link share can't be recreated.
+	skipReason string
+}
+
+// planLinkShareRestore decides whether the link share can be recreated,
+// and maps its type to the one graph creates.  View links that prevent
+// downloads were reported as view links by older graph versions, and
+// get recreated as blocksDownload links.
+func planLinkShareRestore(ls metadata.LinkShare, now time.Time) linkShareRestore {
+	lsr := linkShareRestore{linkType: ls.Link.Type}
+
+	if ls.Link.Type == "view" && ls.Link.PreventsDownload {
+		lsr.linkType = "blocksDownload"
+	}
+
+	switch {
+	// Links with password are not shared with a specific user
+	// even when we select a particular user, plus we are not
+	// able to get the password or retain the original link and
+	// so restoring them makes no sense.
+	case ls.HasPassword:
+		lsr.skipReason = "password protected"
+	case ls.Expiration != nil && !ls.Expiration.After(now):
+		lsr.skipReason = "expired"
+	case !hasKey(restorableLinkScopes, ls.Link.Scope):
+		lsr.skipReason = "unsupported scope"
+	case !hasKey(restorableLinkTypes, lsr.linkType):
+		lsr.skipReason = "unsupported type"
+	}
+
+	return lsr
+}
+
+func hasKey(m map[string]struct{}, k string) bool {
+	_, ok := m[k]
+	return ok
+}
+
 // RestorePermissions takes in the permissions of an item, computes
 // what permissions need to added and removed based on the parent
 // folder metas and uses that to add/remove the necessary permissions
@@ -450,13 +540,11 @@ func RestorePermissions(
 
 	ctx = clues.Add(ctx, "permission_item_id", itemID)
 
-	previousLinkShares, err := computePreviousLinkShares(ctx, itemPath, caches.ParentDirToMeta)
+	lsAdded, lsRemoved, err := linkShareChanges(ctx, itemPath, current, caches.ParentDirToMeta)
 	if err != nil {
-		return clues.Wrap(err, "previous link shares")
+		return clues.Stack(err)
 	}
 
-	lsAdded, lsRemoved := metadata.DiffLinkShares(previousLinkShares, current.LinkShares)
-
 	// Link shares have to be updated before permissions as we have to
 	// use the information about if we had to reset the inheritance to
 	// decide if we have to restore all the permissions.
@@ -473,21 +561,9 @@ func RestorePermissions(
 		return clues.Wrap(err, "updating link shares")
 	}
 
-	previous, err := computePreviousMetadata(ctx, itemPath, caches.ParentDirToMeta)
+	permAdded, permRemoved, err := permissionChanges(ctx, itemPath, current, caches.ParentDirToMeta, didReset)
 	if err != nil {
-		return clues.Wrap(err, "previous metadata")
-	}
-
-	permAdded, permRemoved := metadata.DiffPermissions(previous.Permissions, current.Permissions)
-
-	if didReset {
-		// In case we did a reset of permissions when restoring link
-		// shares, we have to make sure to restore all the permissions
-		// that an item has as they too will be removed.
-		logger.Ctx(ctx).Debug("link share creation reset all inherited permissions")
-
-		permRemoved = []metadata.Permission{}
-		permAdded = current.Permissions
+		return clues.Stack(err)
 	}
 
 	err = UpdatePermissions(
@@ -505,3 +581,52 @@ func RestorePermissions(
 
 	return nil
 }
+
+// linkShareChanges computes the link shares that restoring the item adds
+// and removes, relative to the link shares it inherits from its parents.
+func linkShareChanges(
+	ctx context.Context,
+	itemPath path.Path,
+	current metadata.Metadata,
+	parentMetas *xsync.MapOf[string, metadata.Metadata],
+) ([]metadata.LinkShare, []metadata.LinkShare, error) {
+	previousLinkShares, err := computePreviousLinkShares(ctx, itemPath, parentMetas)
+	if err != nil {
+		return nil, nil, clues.Wrap(err, "previous link shares")
+	}
+
+	added, removed := metadata.DiffLinkShares(previousLinkShares, current.LinkShares)
+
+	return added, removed, nil
+}
+
+// permissionChanges computes the permissions that restoring the item adds
+// and removes, relative to the permissions it inherits from its parents.
+// If restoring the link shares reset the item's inherited permissions,
+// all of the item's permissions get added.
+func permissionChanges(
+	ctx context.Context,
+	itemPath path.Path,
+	current metadata.Metadata,
+	parentMetas *xsync.MapOf[string, metadata.Metadata],
+	didReset bool,
+) ([]metadata.Permission, []metadata.Permission, error) {
+	previous, err := computePreviousMetadata(ctx, itemPath, parentMetas)
+	if err != nil {
+		return nil, nil, clues.Wrap(err, "previous metadata")
+	}
+
+	added, removed := metadata.DiffPermissions(previous.Permissions, current.Permissions)
+
+	if didReset {
+		// In case we did a reset of permissions when restoring link
+		// shares, we have to make sure to restore all the permissions
+		// that an item has as they too will be removed.
+		logger.Ctx(ctx).Debug("link share creation reset all inherited permissions")
+
+		removed = []metadata.Permission{}
+		added = current.Permissions
+	}
+
+	return added, removed, nil
+}
@@ -1,17 +1,29 @@
 package drive
 
 import (
+	"context"
+	"fmt"
+	"io"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/drives"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
 	"github.com/puzpuzpuz/xsync/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	dataMock "github.com/alcionai/corso/src/internal/data/mock"
 	"github.com/alcionai/corso/src/internal/m365/collection/drive/metadata"
 	odConsts "github.com/alcionai/corso/src/internal/m365/service/onedrive/consts"
 	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/internal/version"
+	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/path"
 )
 
@@ -31,6 +43,82 @@ func (suite *PermissionsUnitTestSuite) TestComputeParentPermissions_sharePoint()
 	runComputeParentPermissionsTest(suite, path.SharePointService, path.LibrariesCategory, "site")
 }
 
+func (suite *PermissionsUnitTestSuite) TestGetCollectionMetadata() {
+	folderPath := odConsts.DriveFolderPrefixBuilder("drive-id").String() + "/folder"
+
+	fullPath, err := path.Build(
+		"tenant",
+		"user",
+		path.OneDriveService,
+		path.FilesCategory,
+		false,
+		strings.Split(folderPath, "/")...)
+	require.NoError(suite.T(), err, "creating path")
+
+	drivePath, err := path.ToDrivePath(fullPath)
+	require.NoError(suite.T(), err, "creating drive path")
+
+	table := []struct {
+		name      string
+		auxItems  map[string]data.Item
+		expect    metadata.Metadata
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "dirmeta present",
+			auxItems: map[string]data.Item{
+				metadata.DirMetaFileSuffix: &dataMock.Item{
+					Reader: io.NopCloser(strings.NewReader(`{"permissionMode":0}`)),
+				},
+			},
+			expect:    metadata.Metadata{SharingMode: metadata.SharingModeCustom},
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "dirmeta missing",
+			expect:    metadata.Metadata{SharingMode: metadata.SharingModeInherited},
+			expectErr: assert.NoError,
+		},
+		{
+			name: "malformed dirmeta",
+			auxItems: map[string]data.Item{
+				metadata.DirMetaFileSuffix: &dataMock.Item{
+					Reader: io.NopCloser(strings.NewReader("not json")),
+				},
+			},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			dc := dataMock.Collection{
+				Path:     fullPath,
+				AuxItems: test.auxItems,
+			}
+
+			meta, err := getCollectionMetadata(
+				ctx,
+				drivePath,
+				dc,
+				NewRestoreCaches(nil),
+				version.Backup,
+				true)
+			test.expectErr(t, err, clues.ToCore(err))
+
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, test.expect, meta)
+		})
+	}
+}
+
 func runComputeParentPermissionsTest(
 	suite *PermissionsUnitTestSuite,
 	service path.ServiceType,
@@ -169,3 +257,159 @@ func runComputeParentPermissionsTest(
 		})
 	}
 }
+
+func (suite *PermissionsUnitTestSuite) TestPlanLinkShareRestore() {
+	var (
+		now    = time.Now()
+		past   = now.Add(-time.Hour)
+		future = now.Add(time.Hour)
+		link   = func(scope, typ string) metadata.LinkShareLink {
+			return metadata.LinkShareLink{Scope: scope, Type: typ}
+		}
+	)
+
+	table := []struct {
+		name       string
+		ls         metadata.LinkShare
+		expectType string
+		expectSkip string
+	}{
+		{
+			name:       "anonymous view",
+			ls:         metadata.LinkShare{Link: link(metadata.LinkScopeAnonymous, "view")},
+			expectType: "view",
+		},
+		{
+			name:       "organization edit",
+			ls:         metadata.LinkShare{Link: link(metadata.LinkScopeOrganization, "edit"), Expiration: &future},
+			expectType: "edit",
+		},
+		{
+			name: "view that prevents downloads",
+			ls: metadata.LinkShare{Link: metadata.LinkShareLink{
+				Scope:            metadata.LinkScopeUsers,
+				Type:             "view",
+				PreventsDownload: true,
+			}},
+			expectType: "blocksDownload",
+		},
+		{
+			name:       "expired",
+			ls:         metadata.LinkShare{Link: link(metadata.LinkScopeAnonymous, "view"), Expiration: &past},
+			expectType: "view",
+			expectSkip: "expired",
+		},
+		{
+			name:       "password protected",
+			ls:         metadata.LinkShare{Link: link(metadata.LinkScopeAnonymous, "view"), HasPassword: true},
+			expectType: "view",
+			expectSkip: "password protected",
+		},
+		{
+			name:       "unsupported scope",
+			ls:         metadata.LinkShare{Link: link("existingAccess", "view")},
+			expectType: "view",
+			expectSkip: "unsupported scope",
+		},
+		{
+			name:       "unsupported type",
+			ls:         metadata.LinkShare{Link: link(metadata.LinkScopeOrganization, "review")},
+			expectType: "review",
+			expectSkip: "unsupported type",
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			lsr := planLinkShareRestore(test.ls, now)
+			assert.Equal(t, test.expectType, lsr.linkType, "link type")
+			assert.Equal(t, test.expectSkip, lsr.skipReason, "skip reason")
+		})
+	}
+}
+
+// mockLinkSharer records the link shares it creates.
+type mockLinkSharer struct {
+	created []*drives.ItemItemsItemCreateLinkPostRequestBody
+}
+
+func (m *mockLinkSharer) DeleteItemPermission(context.Context, string, string, string) error {
+	return nil
+}
+
+func (m *mockLinkSharer) PostItemLinkShareUpdate(
+	_ context.Context,
+	_, _ string,
+	body *drives.ItemItemsItemCreateLinkPostRequestBody,
+) (models.Permissionable, error) {
+	m.created = append(m.created, body)
+
+	perm := models.NewPermission()
+	perm.SetId(ptr.To(fmt.Sprintf("new-%d", len(m.created))))
+
+	return perm, nil
+}
+
+func (suite *PermissionsUnitTestSuite) TestUpdateLinkShares_scopedLinks() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		past = time.Now().Add(-time.Hour)
+		anon = metadata.LinkShare{
+			ID:   "anon",
+			Link: metadata.LinkShareLink{Scope: metadata.LinkScopeAnonymous, Type: "view", WebURL: "https://anon"},
+		}
+		users = metadata.LinkShare{
+			ID:       "users",
+			Link:     metadata.LinkShareLink{Scope: metadata.LinkScopeUsers, Type: "edit", WebURL: "https://users"},
+			Entities: []metadata.Entity{{ID: "u1", EntityType: metadata.GV2User}},
+		}
+		expired = metadata.LinkShare{
+			ID:         "expired",
+			Link:       metadata.LinkShareLink{Scope: metadata.LinkScopeOrganization, Type: "view"},
+			Expiration: &past,
+		}
+		mls     = &mockLinkSharer{}
+		errs    = fault.New(false)
+		idToNew = xsync.NewMapOf[string]()
+	)
+
+	_, err := UpdateLinkShares(
+		ctx,
+		mls,
+		"drive",
+		"item",
+		[]metadata.LinkShare{anon, expired, users},
+		nil,
+		idToNew,
+		errs)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Empty(t, errs.Recovered(), "recovered errors")
+
+	require.Len(t, mls.created, 2, "created link shares")
+
+	assert.Equal(t, metadata.LinkScopeAnonymous, ptr.Val(mls.created[0].GetScope()))
+	assert.NotContains(t, mls.created[0].GetAdditionalData(), "recipients", "anonymous links have no recipients")
+	assert.Equal(t, metadata.LinkScopeUsers, ptr.Val(mls.created[1].GetScope()))
+	assert.Equal(
+		t,
+		[]map[string]string{{"objectId": "u1"}},
+		mls.created[1].GetAdditionalData()["recipients"])
+
+	newID, ok := idToNew.Load("anon")
+	assert.True(t, ok, "anonymous link recreated")
+	assert.Equal(t, "new-1", newID)
+
+	_, ok = idToNew.Load("expired")
+	assert.False(t, ok, "expired link not recreated")
+
+	skipped := errs.Skipped()
+	require.Len(t, skipped, 1, "skipped link shares")
+	assert.True(t, skipped[0].HasCause(fault.SkipLinkShare))
+	assert.Equal(t, "expired", skipped[0].Item.ID)
+	assert.Equal(t, "expired", skipped[0].Item.Additional["skip_reason"])
+}
@@ -0,0 +1,517 @@
+package drive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/common/idname"
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/m365/collection/drive/metadata"
+	"github.com/alcionai/corso/src/internal/operations/inject"
+	"github.com/alcionai/corso/src/internal/version"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+// PlanRestore produces the changes that restoring the collections makes in
+// the restore destination, without making any of them.  Drives, folders,
+// and collisions get resolved the same way the restore resolves them, and
+// the drives or folders that don't exist yet are recorded in the plan
+// instead of being created.  Collections outside of drive categories are
+// ignored.
+func PlanRestore(
+	ctx context.Context,
+	rh RestoreHandler,
+	rcc inject.RestoreConsumerConfig,
+	backupDriveIDNames idname.Cacher,
+	dcs []data.RestoreCollection,
+	fallbackDriveName string,
+	errs *fault.Bus,
+) (*control.RestorePlan, error) {
+	var (
+		caches = NewRestoreCaches(backupDriveIDNames)
+		el     = errs.Local()
+		rp     = newRestorePlanner(rh, rcc, fallbackDriveName)
+	)
+
+	if err := caches.Populate(ctx, rh, rcc.ProtectedResource.ID()); err != nil {
+		return nil, clues.Wrap(err, "initializing restore caches")
+	}
+
+	// parent folders get planned before their children, same as the
+	// restore, so that the children can compute inherited permissions.
+	data.SortRestoreCollections(dcs)
+
+	for _, dc := range dcs {
+		if el.Failure() != nil {
+			break
+		}
+
+		cat := dc.FullPath().Category()
+		if cat != path.FilesCategory && cat != path.LibrariesCategory {
+			continue
+		}
+
+		ictx := clues.Add(ctx, "full_path", dc.FullPath())
+
+		if err := rp.planCollection(ictx, dc, caches, errs); err != nil {
+			el.AddRecoverable(ctx, clues.Wrap(err, "planning collection restore"))
+		}
+	}
+
+	return rp.plan, el.Failure()
+}
+
+// plannedDrive is the drive that a collection restores into.
+type plannedDrive struct {
+	driveInfo
+	// exists is false if the restore creates the drive.
+	exists bool
+}
+
+// restorePlanner tracks the drives and folders that the plan creates, so
+// that collections sharing a drive or folder only plan its creation once.
+type restorePlanner struct {
+	rh                RestoreHandler
+	rcc               inject.RestoreConsumerConfig
+	fallbackDriveName string
+	plan              *control.RestorePlan
+	// drives planned for creation, keyed by the backup's drive id
+	newDrives map[string]plannedDrive
+	// ids of existing folders, keyed by location
+	folderIDs map[string]string
+	// locations of the folders planned for creation
+	newFolders map[string]struct{}
+}
+
+func newRestorePlanner(
+	rh RestoreHandler,
+	rcc inject.RestoreConsumerConfig,
+	fallbackDriveName string,
+) *restorePlanner {
+	return &restorePlanner{
+		rh:                rh,
+		rcc:               rcc,
+		fallbackDriveName: fallbackDriveName,
+		plan: &control.RestorePlan{
+			Drives:      []string{},
+			Folders:     []string{},
+			Items:       []control.RestorePlanItem{},
+			Permissions: []control.RestorePlanPermission{},
+		},
+		newDrives:  map[string]plannedDrive{},
+		folderIDs:  map[string]string{},
+		newFolders: map[string]struct{}{},
+	}
+}
+
+// planCollection plans the restore of the collection's folder and items.
+func (rp *restorePlanner) planCollection(
+	ctx context.Context,
+	dc data.RestoreCollection,
+	caches *restoreCaches,
+	errs *fault.Bus,
+) error {
+	drivePath, err := path.ToDrivePath(dc.FullPath())
+	if err != nil {
+		return clues.Wrap(err, "creating drive path").WithClues(ctx)
+	}
+
+	pd, err := rp.planDrive(ctx, caches, drivePath)
+	if err != nil {
+		return clues.Wrap(err, "planning drive")
+	}
+
+	restoreDir := &path.Builder{}
+
+	if len(rp.rcc.RestoreConfig.Location) > 0 {
+		restoreDir = restoreDir.Append(rp.rcc.RestoreConfig.Location)
+	}
+
+	restoreDir = restoreDir.Append(drivePath.Folders...)
+
+	folderID, found, err := rp.planFolders(ctx, pd, restoreDir)
+	if err != nil {
+		return clues.Wrap(err, "planning folders")
+	}
+
+	colMeta, err := getCollectionMetadata(
+		ctx,
+		drivePath,
+		dc,
+		caches,
+		rp.rcc.BackupVersion,
+		rp.rcc.RestoreConfig.IncludePermissions)
+	if err != nil {
+		return clues.Wrap(err, "getting permissions").WithClues(ctx)
+	}
+
+	folderLoc := driveLocation(pd).Append(restoreDir.Elements()...)
+
+	if rp.rcc.RestoreConfig.IncludePermissions && len(drivePath.Folders) > 0 {
+		err = rp.planPermissions(ctx, folderLoc, dc.FullPath(), colMeta, caches)
+		if err != nil {
+			return clues.Wrap(err, "planning folder permissions")
+		}
+	}
+
+	// folders that don't exist yet can't hold any collisions.
+	caches.collisionKeyToItemID = map[string]api.DriveItemIDType{}
+
+	if found {
+		ckii, err := rp.rh.GetItemsInContainerByCollisionKey(ctx, pd.id, folderID)
+		if err != nil {
+			return clues.Wrap(err, "generating map of item collision keys")
+		}
+
+		caches.collisionKeyToItemID = ckii
+	}
+
+	caches.ParentDirToMeta.Store(dc.FullPath().String(), colMeta)
+
+	return rp.planItems(ctx, dc, folderLoc, caches, errs)
+}
+
+// planDrive resolves the drive that the collection restores into, following
+// ensureDriveExists.  Drives that don't exist are planned for creation,
+// named the same way ensureDriveExists names them.
+func (rp *restorePlanner) planDrive(
+	ctx context.Context,
+	caches *restoreCaches,
+	drivePath *path.DrivePath,
+) (plannedDrive, error) {
+	if len(rp.rcc.RestoreConfig.TargetDriveID) > 0 {
+		// target drives are only looked up, never created.
+		di, err := ensureTargetDriveExists(ctx, rp.rh, caches, rp.rcc.RestoreConfig.TargetDriveID)
+		return plannedDrive{driveInfo: di, exists: true}, clues.Stack(err).OrNil()
+	}
+
+	if di, ok := lookupRestoreDrive(caches, drivePath, ""); ok {
+		return plannedDrive{driveInfo: di, exists: true}, nil
+	}
+
+	if pd, ok := rp.newDrives[drivePath.DriveID]; ok {
+		return pd, nil
+	}
+
+	newDriveName := rp.fallbackDriveName
+
+	if oldName, ok := caches.BackupDriveIDName.NameOf(drivePath.DriveID); ok {
+		newDriveName = oldName
+	}
+
+	nextDriveName := newDriveName
+
+	// drive names that collide get a number appended, until the name is
+	// unique.
+	for i := 1; rp.driveNameTaken(caches, nextDriveName); i++ {
+		nextDriveName = fmt.Sprintf("%s %d", newDriveName, i)
+	}
+
+	pd := plannedDrive{driveInfo: driveInfo{name: nextDriveName}}
+
+	rp.newDrives[drivePath.DriveID] = pd
+	rp.plan.Drives = append(rp.plan.Drives, nextDriveName)
+
+	return pd, nil
+}
+
+// driveNameTaken is true if an existing drive, or a drive planned for
+// creation, already has the name.
+func (rp *restorePlanner) driveNameTaken(caches *restoreCaches, name string) bool {
+	if _, ok := caches.DriveNameToDriveInfo.Load(name); ok {
+		return true
+	}
+
+	for _, pd := range rp.newDrives {
+		if pd.name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// planFolders walks the restoreDir hierarchy down from the root of the
+// drive, planning the creation of each folder that doesn't exist.  Returns
+// the id of the restore folder, and false if the restore creates it.
+func (rp *restorePlanner) planFolders(
+	ctx context.Context,
+	pd plannedDrive,
+	restoreDir *path.Builder,
+) (string, bool, error) {
+	var (
+		loc      = driveLocation(pd)
+		folderID = pd.rootFolderID
+		exists   = pd.exists
+	)
+
+	for _, name := range restoreDir.Elements() {
+		loc = loc.Append(name)
+		key := loc.PlainString()
+
+		if id, ok := rp.folderIDs[key]; ok {
+			folderID = id
+			continue
+		}
+
+		if exists {
+			folder, err := rp.rh.GetFolderByName(ctx, pd.id, folderID, name)
+			if err != nil && !errors.Is(err, api.ErrFolderNotFound) {
+				return "", false, clues.Wrap(err, "getting restore folder")
+			}
+
+			if err == nil {
+				folderID = ptr.Val(folder.GetId())
+				rp.folderIDs[key] = folderID
+
+				continue
+			}
+		}
+
+		// every folder below a created folder gets created, too.
+		exists = false
+
+		if _, ok := rp.newFolders[key]; !ok {
+			rp.newFolders[key] = struct{}{}
+			rp.plan.Folders = append(rp.plan.Folders, key)
+		}
+	}
+
+	return folderID, exists, nil
+}
+
+// planItems plans the action taken on each file in the collection,
+// following restoreItem.
+func (rp *restorePlanner) planItems(
+	ctx context.Context,
+	dc data.RestoreCollection,
+	folderLoc *path.Builder,
+	caches *restoreCaches,
+	errs *fault.Bus,
+) error {
+	var (
+		el            = errs.Local()
+		backupVersion = rp.rcc.BackupVersion
+		restoreCfg    = rp.rcc.RestoreConfig
+	)
+
+	for itemData := range dc.Items(ctx, errs) {
+		if el.Failure() != nil {
+			break
+		}
+
+		var (
+			itemUUID = itemData.ID()
+			ictx     = clues.Add(ctx, "restore_item_id", itemUUID)
+		)
+
+		itemPath, err := dc.FullPath().AppendItem(itemUUID)
+		if err != nil {
+			el.AddRecoverable(ctx, clues.Wrap(err, "appending item to full path").WithClues(ictx))
+			continue
+		}
+
+		if backupVersion >= version.OneDrive1DataAndMetaFiles &&
+			!strings.HasSuffix(itemUUID, metadata.DataFileSuffix) {
+			err := rp.planMetaItem(ictx, itemData, itemPath, caches)
+			if err != nil {
+				el.AddRecoverable(ctx, clues.Wrap(err, "planning metadata item"))
+			}
+
+			continue
+		}
+
+		name, meta, err := rp.restoredFileMeta(ictx, dc, itemData)
+		if err != nil {
+			el.AddRecoverable(ctx, clues.Wrap(err, "getting item metadata"))
+			continue
+		}
+
+		var (
+			item      = newItem(name, false)
+			key       = api.DriveItemCollisionKey(item)
+			entry     = collisionEntry(rp.rh, itemPath, itemData, item)
+			action, _ = collisionAction(restoreCfg, entry, caches.collisionKeyToItemID, key)
+			itemLoc   = folderLoc.Append(name)
+		)
+
+		rp.plan.Items = append(rp.plan.Items, control.RestorePlanItem{
+			Location: itemLoc.PlainString(),
+			Action:   action,
+		})
+
+		if action == control.RestoreSkip || !restoreCfg.IncludePermissions {
+			continue
+		}
+
+		if err := rp.planPermissions(ictx, itemLoc, itemPath, meta, caches); err != nil {
+			el.AddRecoverable(ctx, clues.Wrap(err, "planning item permissions"))
+		}
+	}
+
+	return el.Failure()
+}
+
+// planMetaItem handles the metadata files of v1+ backups.  They don't get
+// restored, but the permissions of older backups' folders are read from
+// them, same as in restoreItem.
+func (rp *restorePlanner) planMetaItem(
+	ctx context.Context,
+	itemData data.Item,
+	itemPath path.Path,
+	caches *restoreCaches,
+) error {
+	if !strings.HasSuffix(itemData.ID(), metadata.DirMetaFileSuffix) ||
+		!rp.rcc.RestoreConfig.IncludePermissions ||
+		rp.rcc.BackupVersion >= version.OneDrive4DirIncludesPermissions {
+		return nil
+	}
+
+	metaReader := itemData.ToReader()
+	defer metaReader.Close()
+
+	meta, err := getMetadata(metaReader)
+	if err != nil {
+		return clues.Wrap(err, "getting directory metadata").WithClues(ctx)
+	}
+
+	trimmedPath := strings.TrimSuffix(itemPath.String(), metadata.DirMetaFileSuffix)
+	caches.ParentDirToMeta.Store(trimmedPath, meta)
+
+	return nil
+}
+
+// restoredFileMeta produces the name and metadata that a restored file
+// receives, according to the backup version.  Metadata is only read from
+// older backups when it's needed to restore permissions.
+func (rp *restorePlanner) restoredFileMeta(
+	ctx context.Context,
+	fibn data.FetchItemByNamer,
+	itemData data.Item,
+) (string, metadata.Metadata, error) {
+	var (
+		backupVersion = rp.rcc.BackupVersion
+		trimmedName   = strings.TrimSuffix(itemData.ID(), metadata.DataFileSuffix)
+		metaName      = trimmedName + metadata.MetaFileSuffix
+	)
+
+	if backupVersion < version.OneDrive1DataAndMetaFiles {
+		return itemData.ID(), metadata.Metadata{}, nil
+	}
+
+	if backupVersion < version.OneDrive6NameInMeta {
+		if !rp.rcc.RestoreConfig.IncludePermissions {
+			return trimmedName, metadata.Metadata{}, nil
+		}
+
+		meta, err := FetchAndReadMetadata(ctx, fibn, metaName)
+		if errors.Is(err, data.ErrNotFound) {
+			// no permissions were backed up; the file inherits from its parent.
+			return trimmedName, metadata.Metadata{}, nil
+		}
+
+		return trimmedName, meta, clues.Stack(err).OrNil()
+	}
+
+	meta, err := FetchAndReadMetadata(ctx, fibn, metaName)
+	if err != nil {
+		return "", metadata.Metadata{}, clues.Stack(err)
+	}
+
+	if len(meta.FileName) == 0 {
+		return "", metadata.Metadata{}, clues.New("item with empty name").WithClues(ctx)
+	}
+
+	return meta.FileName, meta, nil
+}
+
+// planPermissions plans the sharing changes that RestorePermissions makes
+// to the restored folder or file.  Items without any changes are left out
+// of the plan.
+func (rp *restorePlanner) planPermissions(
+	ctx context.Context,
+	loc *path.Builder,
+	itemPath path.Path,
+	current metadata.Metadata,
+	caches *restoreCaches,
+) error {
+	if current.SharingMode == metadata.SharingModeInherited {
+		return nil
+	}
+
+	lsAdded, lsRemoved, err := linkShareChanges(ctx, itemPath, current, caches.ParentDirToMeta)
+	if err != nil {
+		return clues.Stack(err)
+	}
+
+	permAdded, permRemoved, err := permissionChanges(
+		ctx,
+		itemPath,
+		current,
+		caches.ParentDirToMeta,
+		linkSharesResetPermissions(lsAdded, lsRemoved))
+	if err != nil {
+		return clues.Stack(err)
+	}
+
+	rpp := control.RestorePlanPermission{
+		Location:          loc.PlainString(),
+		Added:             len(permAdded),
+		Removed:           len(permRemoved),
+		LinkSharesAdded:   countRestorableLinkShares(lsAdded),
+		LinkSharesRemoved: len(lsRemoved),
+	}
+
+	if rpp.Added+rpp.Removed+rpp.LinkSharesAdded+rpp.LinkSharesRemoved > 0 {
+		rp.plan.Permissions = append(rp.plan.Permissions, rpp)
+	}
+
+	return nil
+}
+
+// linkSharesResetPermissions is true if UpdateLinkShares resets the item's
+// inherited permissions: either by creating a link share, or by removing
+// the inherited link shares without adding any.
+func linkSharesResetPermissions(added, removed []metadata.LinkShare) bool {
+	if len(removed) > 0 && len(added) == 0 {
+		return true
+	}
+
+	return countRestorableLinkShares(added) > 0
+}
+
+// countRestorableLinkShares counts the link shares that UpdateLinkShares
+// creates.  Links that planLinkShareRestore skips aren't counted.
+func countRestorableLinkShares(lss []metadata.LinkShare) int {
+	var (
+		c   int
+		now = time.Now()
+	)
+
+	for _, ls := range lss {
+		if len(planLinkShareRestore(ls, now).skipReason) == 0 {
+			c++
+		}
+	}
+
+	return c
+}
+
+// driveLocation produces the root of the locations within the drive.  Drives
+// are located by name, when it's known.
+func driveLocation(pd plannedDrive) *path.Builder {
+	if len(pd.name) > 0 {
+		return path.Builder{}.Append(pd.name)
+	}
+
+	return path.Builder{}.Append(pd.id)
+}
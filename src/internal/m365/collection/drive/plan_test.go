@@ -0,0 +1,475 @@
+package drive
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/google/uuid"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/idname"
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	dataMock "github.com/alcionai/corso/src/internal/data/mock"
+	"github.com/alcionai/corso/src/internal/m365/collection/drive/metadata"
+	odConsts "github.com/alcionai/corso/src/internal/m365/service/onedrive/consts"
+	odMock "github.com/alcionai/corso/src/internal/m365/service/onedrive/mock"
+	odStub "github.com/alcionai/corso/src/internal/m365/service/onedrive/stub"
+	"github.com/alcionai/corso/src/internal/operations/inject"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/internal/version"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+type PlanUnitSuite struct {
+	tester.Suite
+}
+
+func TestPlanUnitSuite(t *testing.T) {
+	suite.Run(t, &PlanUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+// planRestoreHandler only finds the listed folders, by name.
+type planRestoreHandler struct {
+	*odMock.RestoreHandler
+	folders map[string]string
+}
+
+func (h planRestoreHandler) GetFolderByName(
+	_ context.Context,
+	_, _, name string,
+) (models.DriveItemable, error) {
+	id, ok := h.folders[name]
+	if !ok {
+		return nil, clues.Stack(api.ErrFolderNotFound)
+	}
+
+	folder := models.NewDriveItem()
+	folder.SetId(ptr.To(id))
+	folder.SetFolder(models.NewFolder())
+
+	return folder, nil
+}
+
+func (suite *PlanUnitSuite) TestPlanItems_matchesRestore() {
+	table := []struct {
+		name          string
+		collisionKeys map[string]api.DriveItemIDType
+		onCollision   control.CollisionPolicy
+		expectAction  control.RestoreAction
+	}{
+		{
+			name:          "no collision, copy",
+			collisionKeys: map[string]api.DriveItemIDType{},
+			onCollision:   control.Copy,
+			expectAction:  control.RestoreCreate,
+		},
+		{
+			name:          "no collision, replace",
+			collisionKeys: map[string]api.DriveItemIDType{},
+			onCollision:   control.Replace,
+			expectAction:  control.RestoreCreate,
+		},
+		{
+			name:          "no collision, skip",
+			collisionKeys: map[string]api.DriveItemIDType{},
+			onCollision:   control.Skip,
+			expectAction:  control.RestoreCreate,
+		},
+		{
+			name: "collision, copy",
+			collisionKeys: map[string]api.DriveItemIDType{
+				odMock.DriveItemFileName: {ItemID: "existing"},
+			},
+			onCollision:  control.Copy,
+			expectAction: control.RestoreCreate,
+		},
+		{
+			name: "collision, replace",
+			collisionKeys: map[string]api.DriveItemIDType{
+				odMock.DriveItemFileName: {ItemID: "existing"},
+			},
+			onCollision:  control.Replace,
+			expectAction: control.RestoreReplace,
+		},
+		{
+			name: "collision, skip",
+			collisionKeys: map[string]api.DriveItemIDType{
+				odMock.DriveItemFileName: {ItemID: "existing"},
+			},
+			onCollision:  control.Skip,
+			expectAction: control.RestoreSkip,
+		},
+		{
+			name: "file-folder collision, replace",
+			collisionKeys: map[string]api.DriveItemIDType{
+				odMock.DriveItemFileName: {ItemID: "existing", IsFolder: true},
+			},
+			onCollision:  control.Replace,
+			expectAction: control.RestoreCreate,
+		},
+		{
+			name: "file-folder collision, skip",
+			collisionKeys: map[string]api.DriveItemIDType{
+				odMock.DriveItemFileName: {ItemID: "existing", IsFolder: true},
+			},
+			onCollision:  control.Skip,
+			expectAction: control.RestoreSkip,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			rcc := inject.RestoreConsumerConfig{
+				BackupVersion: version.Backup,
+				Options:       control.DefaultOptions(),
+				RestoreConfig: control.RestoreConfig{OnCollision: test.onCollision},
+			}
+
+			// plan the restore
+			var (
+				planRH     = &odMock.RestoreHandler{}
+				planCaches = NewRestoreCaches(nil)
+				rp         = newRestorePlanner(planRH, rcc, "")
+				coll       = collisionCollection(t, version.Backup, "driveID1", odMock.DriveItemFileName)
+			)
+
+			planCaches.collisionKeyToItemID = test.collisionKeys
+
+			err := rp.planItems(ctx, coll, path.Builder{}.Append("docs"), planCaches, fault.New(true))
+			require.NoError(t, err, clues.ToCore(err))
+
+			require.Len(t, rp.plan.Items, 1)
+			assert.Equal(t, test.expectAction, rp.plan.Items[0].Action)
+			assert.Equal(t, "docs/"+odMock.DriveItemFileName, rp.plan.Items[0].Location)
+
+			assert.False(t, planRH.CalledPostItem, "plan posted an item")
+			assert.False(t, planRH.CalledDeleteItem, "plan deleted an item")
+			assert.False(t, planRH.CalledCopyItem, "plan copied an item")
+
+			// restore the same item
+			var (
+				restoreRH     = &odMock.RestoreHandler{PostItemResp: models.NewDriveItem()}
+				restoreCaches = NewRestoreCaches(nil)
+				ctr           = count.New()
+			)
+
+			restoreCaches.collisionKeyToItemID = test.collisionKeys
+
+			dpp, err := odConsts.DriveFolderPrefixBuilder("driveID1").ToDataLayerOneDrivePath("t", "u", false)
+			require.NoError(t, err, clues.ToCore(err))
+
+			dp, err := path.ToDrivePath(dpp)
+			require.NoError(t, err, clues.ToCore(err))
+
+			copyBuffer := restoreCaches.copyBuffers.get()
+			defer restoreCaches.copyBuffers.put(copyBuffer)
+
+			_, _, err = restoreItem(
+				ctx,
+				restoreRH,
+				rcc,
+				odMock.FetchItemByName{
+					Item: &dataMock.Item{
+						Reader:   odMock.FileRespReadCloser(odMock.DriveFileMetaData),
+						ItemInfo: odStub.DriveItemInfo(),
+					},
+				},
+				dp,
+				"",
+				*copyBuffer,
+				restoreCaches,
+				&dataMock.Item{
+					ItemID:   uuid.NewString() + metadata.DataFileSuffix,
+					Reader:   odMock.FileRespReadCloser(odMock.DriveFilePayloadData),
+					ItemInfo: odStub.DriveItemInfo(),
+				},
+				nil,
+				ctr,
+				fault.New(true))
+			require.NoError(t, err, clues.ToCore(err))
+
+			// the counts that the restore increments for each action.
+			restoreCounts := map[control.RestoreAction]int64{
+				control.RestoreCreate:  ctr.Get(count.NewItemCreated),
+				control.RestoreReplace: ctr.Get(count.CollisionReplace),
+				control.RestoreSkip:    ctr.Get(count.CollisionSkip),
+			}
+
+			for action, c := range restoreCounts {
+				expect := int64(0)
+				if action == test.expectAction {
+					expect = 1
+				}
+
+				assert.Equal(t, expect, c, "restore count of %s", action)
+			}
+		})
+	}
+}
+
+func (suite *PlanUnitSuite) TestRestorePlanner_planCollection() {
+	existingDrive := driveInfo{id: "existing-drive", name: "docs", rootFolderID: "root"}
+
+	table := []struct {
+		name              string
+		backupDriveIDs    map[string]string
+		cacheDrive        bool
+		targetDriveID     string
+		folders           map[string]string
+		expectDrives      []string
+		expectFolders     []string
+		expectItemActions []control.RestoreAction
+		expectItemLoc     string
+	}{
+		{
+			name:              "existing drive and folders",
+			cacheDrive:        true,
+			folders:           map[string]string{"restore": "r-id", "folder": "f-id"},
+			expectDrives:      []string{},
+			expectFolders:     []string{},
+			expectItemActions: []control.RestoreAction{control.RestoreReplace, control.RestoreCreate},
+			expectItemLoc:     "docs/restore/folder/a.txt",
+		},
+		{
+			name:              "existing drive, missing folder",
+			cacheDrive:        true,
+			folders:           map[string]string{"restore": "r-id"},
+			expectDrives:      []string{},
+			expectFolders:     []string{"docs/restore/folder"},
+			expectItemActions: []control.RestoreAction{control.RestoreCreate, control.RestoreCreate},
+			expectItemLoc:     "docs/restore/folder/a.txt",
+		},
+		{
+			name:              "drive found by backup name",
+			backupDriveIDs:    map[string]string{"driveid1": "docs"},
+			folders:           map[string]string{"restore": "r-id", "folder": "f-id"},
+			expectDrives:      []string{},
+			expectFolders:     []string{},
+			expectItemActions: []control.RestoreAction{control.RestoreReplace, control.RestoreCreate},
+			expectItemLoc:     "docs/restore/folder/a.txt",
+		},
+		{
+			name:              "target drive",
+			targetDriveID:     existingDrive.id,
+			folders:           map[string]string{"restore": "r-id", "folder": "f-id"},
+			expectDrives:      []string{},
+			expectFolders:     []string{},
+			expectItemActions: []control.RestoreAction{control.RestoreReplace, control.RestoreCreate},
+			expectItemLoc:     "docs/restore/folder/a.txt",
+		},
+		{
+			name:              "new drive from backup name",
+			backupDriveIDs:    map[string]string{"driveid1": "files"},
+			expectDrives:      []string{"files"},
+			expectFolders:     []string{"files/restore", "files/restore/folder"},
+			expectItemActions: []control.RestoreAction{control.RestoreCreate, control.RestoreCreate},
+			expectItemLoc:     "files/restore/folder/a.txt",
+		},
+		{
+			name:              "new drive with colliding name",
+			expectDrives:      []string{"docs 1"},
+			expectFolders:     []string{"docs 1/restore", "docs 1/restore/folder"},
+			expectItemActions: []control.RestoreAction{control.RestoreCreate, control.RestoreCreate},
+			expectItemLoc:     "docs 1/restore/folder/a.txt",
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				caches = NewRestoreCaches(idname.NewCache(test.backupDriveIDs))
+				mockRH = &odMock.RestoreHandler{
+					CollisionKeyMap: map[string]api.DriveItemIDType{
+						"a.txt": {ItemID: "1"},
+					},
+				}
+				rh  = planRestoreHandler{RestoreHandler: mockRH, folders: test.folders}
+				rcc = inject.RestoreConsumerConfig{
+					BackupVersion: version.Backup,
+					RestoreConfig: control.RestoreConfig{
+						Location:      "restore",
+						OnCollision:   control.Replace,
+						TargetDriveID: test.targetDriveID,
+					},
+				}
+				// the fallback name collides with the existing drive.
+				rp = newRestorePlanner(rh, rcc, "docs")
+			)
+
+			caches.DriveIDToDriveInfo.Store(existingDrive.id, existingDrive)
+			caches.DriveNameToDriveInfo.Store(existingDrive.name, existingDrive)
+
+			if test.cacheDrive {
+				caches.DriveIDToDriveInfo.Store("driveid1", existingDrive)
+			}
+
+			// two collections share the drive and folder, so that their
+			// creation only gets planned once.
+			for _, names := range [][]string{{"a.txt"}, {"b.txt"}} {
+				coll := collisionCollection(t, version.Backup, "driveid1", names...)
+
+				err := rp.planCollection(ctx, coll, caches, fault.New(true))
+				require.NoError(t, err, clues.ToCore(err))
+			}
+
+			assert.Equal(t, test.expectDrives, rp.plan.Drives, "drives")
+			assert.Equal(t, test.expectFolders, rp.plan.Folders, "folders")
+
+			actions := []control.RestoreAction{}
+
+			for _, item := range rp.plan.Items {
+				actions = append(actions, item.Action)
+			}
+
+			assert.Equal(t, test.expectItemActions, actions, "item actions")
+			assert.Equal(t, test.expectItemLoc, rp.plan.Items[0].Location, "item location")
+
+			assert.False(t, mockRH.CalledPostItem, "plan posted an item")
+			assert.False(t, mockRH.CalledDeleteItem, "plan deleted an item")
+		})
+	}
+}
+
+func (suite *PlanUnitSuite) TestRestorePlanner_planPermissions() {
+	var (
+		perm = metadata.Permission{ID: "p1", Roles: []string{"read"}, EntityID: "u1"}
+		ents = []metadata.Entity{{ID: "u1"}}
+		link = func(url string) metadata.LinkShareLink {
+			return metadata.LinkShareLink{WebURL: url, Scope: metadata.LinkScopeUsers, Type: "view"}
+		}
+		ls   = metadata.LinkShare{ID: "l1", Link: link("https://l1"), Entities: ents}
+		pwLS = metadata.LinkShare{
+			ID:          "l2",
+			Link:        link("https://l2"),
+			Entities:    ents,
+			HasPassword: true,
+		}
+	)
+
+	table := []struct {
+		name   string
+		meta   metadata.Metadata
+		expect []control.RestorePlanPermission
+	}{
+		{
+			name:   "inherited",
+			meta:   metadata.Metadata{SharingMode: metadata.SharingModeInherited},
+			expect: []control.RestorePlanPermission{},
+		},
+		{
+			name:   "custom without changes",
+			meta:   metadata.Metadata{SharingMode: metadata.SharingModeCustom},
+			expect: []control.RestorePlanPermission{},
+		},
+		{
+			name: "permissions",
+			meta: metadata.Metadata{
+				SharingMode: metadata.SharingModeCustom,
+				Permissions: []metadata.Permission{perm},
+			},
+			expect: []control.RestorePlanPermission{
+				{Location: "docs/a.txt", Added: 1},
+			},
+		},
+		{
+			name: "link shares",
+			meta: metadata.Metadata{
+				SharingMode: metadata.SharingModeCustom,
+				Permissions: []metadata.Permission{perm},
+				LinkShares:  []metadata.LinkShare{ls, pwLS},
+			},
+			expect: []control.RestorePlanPermission{
+				{Location: "docs/a.txt", Added: 1, LinkSharesAdded: 1},
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			itemPath, err := odConsts.DriveFolderPrefixBuilder("driveid1").
+				ToDataLayerOneDrivePath("t", "u", false)
+			require.NoError(t, err, clues.ToCore(err))
+
+			itemPath, err = itemPath.AppendItem("a.txt")
+			require.NoError(t, err, clues.ToCore(err))
+
+			rp := newRestorePlanner(&odMock.RestoreHandler{}, inject.RestoreConsumerConfig{}, "")
+
+			err = rp.planPermissions(
+				ctx,
+				path.Builder{}.Append("docs", "a.txt"),
+				itemPath,
+				test.meta,
+				NewRestoreCaches(nil))
+			require.NoError(t, err, clues.ToCore(err))
+
+			assert.Equal(t, test.expect, rp.plan.Permissions)
+		})
+	}
+}
+
+func (suite *PlanUnitSuite) TestLinkSharesResetPermissions() {
+	var (
+		link = metadata.LinkShareLink{Scope: metadata.LinkScopeUsers, Type: "view"}
+		ls   = metadata.LinkShare{ID: "l1", Link: link}
+		pwLS = metadata.LinkShare{ID: "l2", Link: link, HasPassword: true}
+	)
+
+	table := []struct {
+		name    string
+		added   []metadata.LinkShare
+		removed []metadata.LinkShare
+		expect  assert.BoolAssertionFunc
+	}{
+		{
+			name:   "no changes",
+			expect: assert.False,
+		},
+		{
+			name:   "added",
+			added:  []metadata.LinkShare{ls},
+			expect: assert.True,
+		},
+		{
+			name:   "only added with passwords",
+			added:  []metadata.LinkShare{pwLS},
+			expect: assert.False,
+		},
+		{
+			name:    "only removed",
+			removed: []metadata.LinkShare{ls},
+			expect:  assert.True,
+		},
+		{
+			name:    "removed with password added",
+			added:   []metadata.LinkShare{pwLS},
+			removed: []metadata.LinkShare{ls},
+			expect:  assert.False,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			test.expect(suite.T(), linkSharesResetPermissions(test.added, test.removed))
+		})
+	}
+}
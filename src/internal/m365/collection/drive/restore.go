@@ -13,6 +13,7 @@ import (
 	"github.com/alcionai/clues"
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
 	"github.com/pkg/errors"
+	"github.com/puzpuzpuz/xsync/v2"
 
 	"github.com/alcionai/corso/src/internal/common/ptr"
 	"github.com/alcionai/corso/src/internal/data"
@@ -35,8 +36,29 @@ import (
 const (
 	// Maximum number of retries for upload failures
 	maxUploadRetries = 3
+
+	// Number of drives created concurrently ahead of a restore, unless
+	// the options say otherwise.
+	defaultDriveCreationParallelism = 4
 )
 
+// driveCreationParallelism produces the number of drives created
+// concurrently ahead of a restore.
+func driveCreationParallelism(opts control.Options) int {
+	if opts.Parallelism.RestoreDriveCreate > 0 {
+		return opts.Parallelism.RestoreDriveCreate
+	}
+
+	return defaultDriveCreationParallelism
+}
+
+// restoreParallelism produces the number of items restored concurrently
+// within a collection.
+func restoreParallelism(ctx context.Context, opts control.Options) int {
+	return graph.Parallelism(path.OneDriveService).
+		ItemUploadOverride(ctx, opts.Parallelism.RestoreItemWrite)
+}
+
 // RestoreCollection handles restoration of an individual collection.
 // returns:
 // - the collection's item and byte count metrics
@@ -78,6 +100,7 @@ func RestoreCollection(
 		caches,
 		drivePath,
 		rcc.ProtectedResource.ID(),
+		rcc.RestoreConfig.TargetDriveID,
 		fallbackDriveName)
 	if err != nil {
 		return metrics, clues.Wrap(err, "ensuring drive exists")
@@ -145,7 +168,7 @@ func RestoreCollection(
 	caches.ParentDirToMeta.Store(dc.FullPath().String(), colMeta)
 	items := dc.Items(ctx, errs)
 
-	semaphoreCh := make(chan struct{}, graph.Parallelism(path.OneDriveService).ItemUpload())
+	semaphoreCh := make(chan struct{}, restoreParallelism(ctx, rcc.Options))
 	defer close(semaphoreCh)
 
 	deetsLock := sync.Mutex{}
@@ -189,8 +212,8 @@ func RestoreCollection(
 				defer wg.Done()
 				defer func() { <-semaphoreCh }()
 
-				copyBufferPtr := caches.pool.Get().(*[]byte)
-				defer caches.pool.Put(copyBufferPtr)
+				copyBufferPtr := caches.copyBuffers.get()
+				defer caches.copyBuffers.put(copyBufferPtr)
 
 				copyBuffer := *copyBufferPtr
 				ictx := clues.Add(ctx, "restore_item_id", itemData.ID())
@@ -268,7 +291,7 @@ func restoreItem(
 	ctx = clues.Add(ctx, "item_id", itemUUID)
 
 	if rcc.BackupVersion < version.OneDrive1DataAndMetaFiles {
-		itemInfo, err := restoreV0File(
+		itemInfo, action, err := restoreV0File(
 			ctx,
 			rh,
 			rcc.RestoreConfig,
@@ -277,17 +300,15 @@ func restoreItem(
 			restoreFolderID,
 			copyBuffer,
 			caches.collisionKeyToItemID,
+			caches.contentHashToItemID,
 			itemData,
+			itemPath,
 			ctr)
 		if err != nil {
-			if errors.Is(err, graph.ErrItemAlreadyExistsConflict) && rcc.RestoreConfig.OnCollision == control.Skip {
-				return details.ItemInfo{}, true, nil
-			}
-
 			return details.ItemInfo{}, false, clues.Wrap(err, "v0 restore")
 		}
 
-		return itemInfo, false, nil
+		return itemInfo, action == control.RestoreSkip, nil
 	}
 
 	// only v1+ backups from this point on
@@ -324,7 +345,7 @@ func restoreItem(
 	// only items with DataFileSuffix from this point on
 
 	if rcc.BackupVersion < version.OneDrive6NameInMeta {
-		itemInfo, err := restoreV1File(
+		itemInfo, action, err := restoreV1File(
 			ctx,
 			rh,
 			rcc,
@@ -338,19 +359,15 @@ func restoreItem(
 			ctr,
 			errs)
 		if err != nil {
-			if errors.Is(err, graph.ErrItemAlreadyExistsConflict) && rcc.RestoreConfig.OnCollision == control.Skip {
-				return details.ItemInfo{}, true, nil
-			}
-
 			return details.ItemInfo{}, false, clues.Wrap(err, "v1 restore")
 		}
 
-		return itemInfo, false, nil
+		return itemInfo, action == control.RestoreSkip, nil
 	}
 
 	// only v6+ backups from this point on
 
-	itemInfo, err := restoreV6File(
+	itemInfo, action, err := restoreV6File(
 		ctx,
 		rh,
 		rcc,
@@ -364,14 +381,10 @@ func restoreItem(
 		ctr,
 		errs)
 	if err != nil {
-		if errors.Is(err, graph.ErrItemAlreadyExistsConflict) && rcc.RestoreConfig.OnCollision == control.Skip {
-			return details.ItemInfo{}, true, nil
-		}
-
 		return details.ItemInfo{}, false, clues.Wrap(err, "v6 restore")
 	}
 
-	return itemInfo, false, nil
+	return itemInfo, action == control.RestoreSkip, nil
 }
 
 func restoreV0File(
@@ -383,26 +396,31 @@ func restoreV0File(
 	restoreFolderID string,
 	copyBuffer []byte,
 	collisionKeyToItemID map[string]api.DriveItemIDType,
+	contentHashToItemID *xsync.MapOf[string, string],
 	itemData data.Item,
+	itemPath path.Path,
 	ctr *count.Bus,
-) (details.ItemInfo, error) {
-	_, itemInfo, err := restoreFile(
+) (details.ItemInfo, control.RestoreAction, error) {
+	_, itemInfo, action, err := restoreFile(
 		ctx,
 		restoreCfg,
 		rh,
 		fibn,
 		itemData.ID(),
+		restoredFileSystemInfo(restoreCfg, metadata.Metadata{}, itemData),
 		itemData,
+		itemPath,
 		drivePath.DriveID,
 		restoreFolderID,
 		collisionKeyToItemID,
+		contentHashToItemID,
 		copyBuffer,
 		ctr)
 	if err != nil {
-		return itemInfo, clues.Wrap(err, "restoring file")
+		return itemInfo, action, clues.Wrap(err, "restoring file")
 	}
 
-	return itemInfo, nil
+	return itemInfo, action, nil
 }
 
 func restoreV1File(
@@ -418,37 +436,46 @@ func restoreV1File(
 	itemData data.Item,
 	ctr *count.Bus,
 	errs *fault.Bus,
-) (details.ItemInfo, error) {
+) (details.ItemInfo, control.RestoreAction, error) {
 	trimmedName := strings.TrimSuffix(itemData.ID(), metadata.DataFileSuffix)
 
-	itemID, itemInfo, err := restoreFile(
+	itemID, itemInfo, action, err := restoreFile(
 		ctx,
 		rcc.RestoreConfig,
 		rh,
 		fibn,
 		trimmedName,
+		restoredFileSystemInfo(rcc.RestoreConfig, metadata.Metadata{}, itemData),
 		itemData,
+		itemPath,
 		drivePath.DriveID,
 		restoreFolderID,
 		caches.collisionKeyToItemID,
+		caches.contentHashToItemID,
 		copyBuffer,
 		ctr)
 	if err != nil {
-		return details.ItemInfo{}, err
+		return details.ItemInfo{}, action, err
 	}
 
 	// Mark it as success without processing .meta
-	// file if we are not restoring permissions
-	if !rcc.RestoreConfig.IncludePermissions {
-		return itemInfo, nil
+	// file if we are not restoring permissions, or
+	// if the item was skipped.
+	if !rcc.RestoreConfig.IncludePermissions || action == control.RestoreSkip {
+		return itemInfo, action, nil
 	}
 
 	// Fetch item permissions from the collection and restore them.
 	metaName := trimmedName + metadata.MetaFileSuffix
 
 	meta, err := FetchAndReadMetadata(ctx, fibn, metaName)
+	if errors.Is(err, data.ErrNotFound) {
+		// no permissions were backed up; the file inherits from its parent.
+		return itemInfo, action, nil
+	}
+
 	if err != nil {
-		return details.ItemInfo{}, clues.Wrap(err, "restoring file")
+		return details.ItemInfo{}, action, clues.Wrap(err, "restoring file")
 	}
 
 	err = RestorePermissions(
@@ -461,10 +488,10 @@ func restoreV1File(
 		caches,
 		errs)
 	if err != nil {
-		return details.ItemInfo{}, clues.Wrap(err, "restoring item permissions")
+		return details.ItemInfo{}, action, clues.Wrap(err, "restoring item permissions")
 	}
 
-	return itemInfo, nil
+	return itemInfo, action, nil
 }
 
 func restoreV6File(
@@ -480,7 +507,7 @@ func restoreV6File(
 	itemData data.Item,
 	ctr *count.Bus,
 	errs *fault.Bus,
-) (details.ItemInfo, error) {
+) (details.ItemInfo, control.RestoreAction, error) {
 	trimmedName := strings.TrimSuffix(itemData.ID(), metadata.DataFileSuffix)
 
 	// Get metadata file so we can determine the file name.
@@ -488,7 +515,7 @@ func restoreV6File(
 
 	meta, err := FetchAndReadMetadata(ctx, fibn, metaName)
 	if err != nil {
-		return details.ItemInfo{}, clues.Wrap(err, "restoring file")
+		return details.ItemInfo{}, "", clues.Wrap(err, "restoring file")
 	}
 
 	ctx = clues.Add(
@@ -497,36 +524,40 @@ func restoreV6File(
 		"restore_item_name", clues.Hide(meta.FileName))
 
 	if err != nil {
-		return details.ItemInfo{}, clues.Wrap(err, "deserializing item metadata")
+		return details.ItemInfo{}, "", clues.Wrap(err, "deserializing item metadata")
 	}
 
 	// TODO(ashmrtn): Future versions could attempt to do the restore in a
 	// different location like "lost+found" and use the item ID if we want to do
 	// as much as possible to restore the data.
 	if len(meta.FileName) == 0 {
-		return details.ItemInfo{}, clues.New("item with empty name")
+		return details.ItemInfo{}, "", clues.New("item with empty name")
 	}
 
-	itemID, itemInfo, err := restoreFile(
+	itemID, itemInfo, action, err := restoreFile(
 		ctx,
 		rcc.RestoreConfig,
 		rh,
 		fibn,
 		meta.FileName,
+		restoredFileSystemInfo(rcc.RestoreConfig, meta, itemData),
 		itemData,
+		itemPath,
 		drivePath.DriveID,
 		restoreFolderID,
 		caches.collisionKeyToItemID,
+		caches.contentHashToItemID,
 		copyBuffer,
 		ctr)
 	if err != nil {
-		return details.ItemInfo{}, err
+		return details.ItemInfo{}, action, err
 	}
 
 	// Mark it as success without processing .meta
-	// file if we are not restoring permissions
-	if !rcc.RestoreConfig.IncludePermissions {
-		return itemInfo, nil
+	// file if we are not restoring permissions, or
+	// if the item was skipped.
+	if !rcc.RestoreConfig.IncludePermissions || action == control.RestoreSkip {
+		return itemInfo, action, nil
 	}
 
 	err = RestorePermissions(
@@ -539,10 +570,10 @@ func restoreV6File(
 		caches,
 		errs)
 	if err != nil {
-		return details.ItemInfo{}, clues.Wrap(err, "restoring item permissions")
+		return details.ItemInfo{}, action, clues.Wrap(err, "restoring item permissions")
 	}
 
-	return itemInfo, nil
+	return itemInfo, action, nil
 }
 
 // CreateRestoreFolders creates the restore folder hierarchy in
@@ -702,25 +733,32 @@ func createFolder(
 }
 
 type itemRestorer interface {
+	CopyItemer
 	DeleteItemer
+	DeleteItemIfMatcher
 	ItemInfoAugmenter
 	NewItemContentUploader
 	PostItemInContainerer
 }
 
-// restoreFile will create a new item in the specified `parentFolderID` and upload the data.Item
+// restoreFile will create a new item in the specified `parentFolderID` and upload the data.Item.
+// Returns the action taken on the item, which is resolved from the restore's collision
+// policy, or its collision callback, once per item.
 func restoreFile(
 	ctx context.Context,
 	restoreCfg control.RestoreConfig,
 	ir itemRestorer,
 	fibn data.FetchItemByNamer,
 	name string,
+	fsi models.FileSystemInfoable,
 	itemData data.Item,
+	itemPath path.Path,
 	driveID, parentFolderID string,
 	collisionKeyToItemID map[string]api.DriveItemIDType,
+	contentHashToItemID *xsync.MapOf[string, string],
 	copyBuffer []byte,
 	ctr *count.Bus,
-) (string, details.ItemInfo, error) {
+) (string, details.ItemInfo, control.RestoreAction, error) {
 	ctx, end := diagnostics.Span(ctx, "gc:oneDrive:restoreItem", diagnostics.Label("item_uuid", itemData.ID()))
 	defer end()
 
@@ -729,29 +767,31 @@ func restoreFile(
 	// Get the stream size (needed to create the upload session)
 	ss, ok := itemData.(data.ItemSize)
 	if !ok {
-		return "", details.ItemInfo{}, clues.New("item does not implement DataStreamInfo").WithClues(ctx)
+		return "", details.ItemInfo{}, "", clues.New("item does not implement DataStreamInfo").WithClues(ctx)
 	}
 
 	var (
 		item                 = newItem(name, false)
 		collisionKey         = api.DriveItemCollisionKey(item)
-		collision            api.DriveItemIDType
-		shouldDeleteOriginal bool
+		entry                = collisionEntry(ir, itemPath, itemData, item)
+		action, collision    = collisionAction(restoreCfg, entry, collisionKeyToItemID, collisionKey)
+		shouldDeleteOriginal = action == control.RestoreReplace
 	)
 
-	if dci, ok := collisionKeyToItemID[collisionKey]; ok {
+	if fsi != nil {
+		item.SetFileSystemInfo(fsi)
+	}
+
+	if len(collision.ItemID) > 0 {
 		log := logger.Ctx(ctx).With("collision_key", clues.Hide(collisionKey))
 		log.Debug("item collision")
 
-		if restoreCfg.OnCollision == control.Skip {
+		if action == control.RestoreSkip {
 			ctr.Inc(count.CollisionSkip)
 			log.Debug("skipping item with collision")
 
-			return "", details.ItemInfo{}, graph.ErrItemAlreadyExistsConflict
+			return "", details.ItemInfo{}, action, nil
 		}
-
-		collision = dci
-		shouldDeleteOriginal = restoreCfg.OnCollision == control.Replace && !dci.IsFolder
 	}
 
 	// drive items do not support PUT requests on the drive item data, so
@@ -762,11 +802,50 @@ func restoreFile(
 	// risk failures in the middle, or we post w/ copy, then delete, then patch
 	// the name, which could triple our graph calls in the worst case.
 	if shouldDeleteOriginal {
-		if err := ir.DeleteItem(ctx, driveID, collision.ItemID); err != nil && !graph.IsErrDeletedInFlight(err) {
-			return "", details.ItemInfo{}, clues.New("deleting colliding item")
+		var err error
+
+		// a conditional replace only deletes the original if it's unchanged
+		// since we looked up its collision key.
+		if restoreCfg.ReplaceIfUnchanged && len(collision.ETag) > 0 {
+			err = ir.DeleteItemIfMatch(ctx, driveID, collision.ItemID, collision.ETag)
+		} else {
+			err = ir.DeleteItem(ctx, driveID, collision.ItemID)
+		}
+
+		if graph.IsErrPreconditionFailed(err) {
+			return "", details.ItemInfo{}, action, clues.Stack(graph.ErrPreconditionFailed, err).
+				WithClues(ctx).
+				With("collision_key", clues.Hide(collisionKey))
+		}
+
+		if err != nil && !graph.IsErrDeletedInFlight(err) {
+			return "", details.ItemInfo{}, action, clues.New("deleting colliding item")
 		}
 	}
 
+	// Content that was already restored in this session gets copied from
+	// the earlier item instead of being uploaded a second time.  Copies
+	// carry the earlier item's timestamps, so items with timestamps of
+	// their own always get uploaded.
+	hashKey := contentHashKey(driveID, itemData)
+
+	if srcID, ok := contentHashToItemID.Load(hashKey); ok && len(hashKey) > 0 && fsi == nil {
+		copied, err := ir.CopyItem(ctx, driveID, srcID, parentFolderID, name)
+		if err == nil {
+			dii := ir.AugmentItemInfo(details.ItemInfo{}, copied, ss.Size(), nil)
+
+			if shouldDeleteOriginal {
+				ctr.Inc(count.CollisionReplace)
+			} else {
+				ctr.Inc(count.NewItemCreated)
+			}
+
+			return ptr.Val(copied.GetId()), dii, action, nil
+		}
+
+		logger.CtxErr(ctx, err).Info("copying previously restored content; uploading the item instead")
+	}
+
 	// Create Item
 	// the Copy collision policy is used since we've technically already handled
 	// the collision behavior above.  At this point, copy is most likely to succeed.
@@ -785,12 +864,12 @@ func restoreFile(
 		//    make no changes to the original file, and do not delete it.
 		control.Copy)
 	if err != nil {
-		return "", details.ItemInfo{}, err
+		return "", details.ItemInfo{}, action, err
 	}
 
-	w, uploadURL, err := driveItemWriter(ctx, ir, driveID, ptr.Val(newItem.GetId()), ss.Size())
+	w, uploadURL, err := driveItemWriter(ctx, ir, driveID, ptr.Val(newItem.GetId()), ss.Size(), fsi)
 	if err != nil {
-		return "", details.ItemInfo{}, clues.Wrap(err, "get item upload session")
+		return "", details.ItemInfo{}, action, clues.Wrap(err, "get item upload session")
 	}
 
 	var (
@@ -815,12 +894,19 @@ func restoreFile(
 			// If it is not the first try, we have to pull the file
 			// again from kopia. Ideally we could just seek the stream
 			// but we don't have a Seeker available here.
-			itemData, err := fibn.FetchItemByName(ctx, itemData.ID())
+			var size int64
+
+			iReader, size, err = data.StreamItemByName(ctx, fibn, itemData.ID())
 			if err != nil {
-				return "", details.ItemInfo{}, clues.Wrap(err, "get data file")
+				return "", details.ItemInfo{}, action, clues.Wrap(err, "get data file")
 			}
 
-			iReader = itemData.ToReader()
+			// the upload session was created for the original size, so the
+			// refetched data has to match it.
+			if size >= 0 && size != ss.Size() {
+				return "", details.ItemInfo{}, action, clues.New("refetched data file size changed").
+					With("expected_size", ss.Size(), "refetched_size", size)
+			}
 		}
 
 		progReader, closeProgressBar = observe.ItemProgress(
@@ -830,8 +916,15 @@ func restoreFile(
 			clues.Hide(pname),
 			ss.Size())
 
-		// Upload the stream data
-		written, err = io.CopyBuffer(w, progReader, copyBuffer)
+		// Upload the stream data in chunks the size of the copy buffer.
+		// Chunks of large files can be retried individually, so that a flaky
+		// connection doesn't force the entire file to be re-uploaded.
+		chunkRetries := 0
+		if ss.Size() > largeFileThreshold(restoreCfg) {
+			chunkRetries = maxChunkUploadRetries
+		}
+
+		written, err = uploadInChunks(ctx, w, progReader, copyBuffer, chunkRetries, graph.DefaultDelay)
 		if err == nil {
 			break
 		}
@@ -845,20 +938,127 @@ func restoreFile(
 	}
 
 	if err != nil {
-		return "", details.ItemInfo{}, clues.Wrap(err, "uploading file")
+		return "", details.ItemInfo{}, action, clues.Wrap(err, "uploading file")
 	}
 
 	defer closeProgressBar()
 
 	dii := ir.AugmentItemInfo(details.ItemInfo{}, newItem, written, nil)
 
+	if len(hashKey) > 0 {
+		contentHashToItemID.Store(hashKey, ptr.Val(newItem.GetId()))
+	}
+
 	if shouldDeleteOriginal {
 		ctr.Inc(count.CollisionReplace)
 	} else {
 		ctr.Inc(count.NewItemCreated)
 	}
 
-	return ptr.Val(newItem.GetId()), dii, nil
+	return ptr.Val(newItem.GetId()), dii, action, nil
+}
+
+// collisionAction decides what the restore does with a file whose
+// collision key is collisionKey, given the keys of the items already in
+// the restore folder.  The entry describes the file to the restore's
+// collision callback, if it has one.  Returns the action and the colliding item, which
+// is empty if nothing collides.
+func collisionAction(
+	restoreCfg control.RestoreConfig,
+	entry details.Entry,
+	collisionKeyToItemID map[string]api.DriveItemIDType,
+	collisionKey string,
+) (control.RestoreAction, api.DriveItemIDType) {
+	dci, ok := collisionKeyToItemID[collisionKey]
+	if !ok {
+		return control.RestoreCreate, api.DriveItemIDType{}
+	}
+
+	onCollision := restoreCfg.CollisionPolicyFor(
+		entry,
+		control.CollidingItem{ItemID: dci.ItemID, IsFolder: dci.IsFolder})
+
+	if onCollision == control.Skip {
+		return control.RestoreSkip, dci
+	}
+
+	// folders never get replaced by files.  Like copies, the file gets
+	// created alongside the colliding item.
+	if onCollision == control.Replace && !dci.IsFolder {
+		return control.RestoreReplace, dci
+	}
+
+	return control.RestoreCreate, dci
+}
+
+// collisionEntry describes a restored file to the restore's collision
+// callback.  The item path is optional.
+func collisionEntry(
+	iia ItemInfoAugmenter,
+	itemPath path.Path,
+	itemData data.Item,
+	item models.DriveItemable,
+) details.Entry {
+	var size int64
+
+	if ss, ok := itemData.(data.ItemSize); ok {
+		size = ss.Size()
+	}
+
+	entry := details.Entry{
+		ItemRef:  itemData.ID(),
+		ItemInfo: iia.AugmentItemInfo(details.ItemInfo{}, item, size, nil),
+	}
+
+	if itemPath != nil {
+		entry.RepoRef = itemPath.String()
+		entry.ShortRef = itemPath.ShortRef()
+		entry.ParentRef = itemPath.ToBuilder().Dir().ShortRef()
+	}
+
+	return entry
+}
+
+// restoredFileSystemInfo produces the file system timestamps given to a
+// restored file: the backed up created and modified times, falling back to
+// the item's modification time in the backup.  Returns nil, which leaves
+// the timestamps to the server, unless the restore preserves timestamps.
+func restoredFileSystemInfo(
+	restoreCfg control.RestoreConfig,
+	meta metadata.Metadata,
+	itemData data.Item,
+) models.FileSystemInfoable {
+	if !restoreCfg.PreserveTimestamps {
+		return nil
+	}
+
+	created, modified := meta.Created, meta.Modified
+
+	if mt, ok := itemData.(data.ItemModTime); ok && modified == nil && !mt.ModTime().IsZero() {
+		modified = ptr.To(mt.ModTime())
+	}
+
+	if created == nil && modified == nil {
+		return nil
+	}
+
+	fsi := models.NewFileSystemInfo()
+	fsi.SetCreatedDateTime(created)
+	fsi.SetLastModifiedDateTime(modified)
+
+	return fsi
+}
+
+// contentHashKey produces the restore cache key for the item's content.
+// Copies are only made within a drive, so the key is scoped by drive ID.
+// Returns an empty string if the item has no content hash.
+func contentHashKey(driveID string, itemData data.Item) string {
+	ch, ok := itemData.(data.ItemContentHash)
+	if !ok || len(ch.ContentHash()) == 0 {
+		return ""
+	}
+
+	return driveID + "/" + ch.ContentHash()
 }
 
 func FetchAndReadMetadata(
@@ -912,14 +1112,20 @@ type PostDriveAndGetRootFolderer interface {
 // that ID, a new drive is generated with the same name.  If the name collides
 // with an existing drive, a number is appended to the drive name.  Eg: foo ->
 // foo 1.  This will repeat as many times as is needed.
+// If a targetDriveID is provided, all name-based lookups are skipped, and the
+// target drive is used as long as it exists.
 // Returns the root folder of the drive
 func ensureDriveExists(
 	ctx context.Context,
 	pdagrf PostDriveAndGetRootFolderer,
 	caches *restoreCaches,
 	drivePath *path.DrivePath,
-	protectedResourceID, fallbackDriveName string,
+	protectedResourceID, targetDriveID, fallbackDriveName string,
 ) (driveInfo, error) {
+	if len(targetDriveID) > 0 {
+		return ensureTargetDriveExists(ctx, pdagrf, caches, targetDriveID)
+	}
+
 	driveID := drivePath.DriveID
 
 	// the drive might already be cached by ID.  it's okay
@@ -929,6 +1135,10 @@ func ensureDriveExists(
 		return di, nil
 	}
 
+	if di, ok := caches.BackupDriveIDToDriveInfo.Load(driveID); ok {
+		return di, nil
+	}
+
 	var (
 		newDriveName = fallbackDriveName
 		newDrive     models.Driveable
@@ -942,6 +1152,7 @@ func ensureDriveExists(
 	if ok {
 		// check for drives that currently have the same name
 		if di, ok := caches.DriveNameToDriveInfo.Load(oldName); ok {
+			caches.BackupDriveIDToDriveInfo.Store(driveID, di)
 			return di, nil
 		}
 
@@ -977,6 +1188,133 @@ func ensureDriveExists(
 	}
 
 	di, _ := caches.DriveIDToDriveInfo.Load(ptr.Val(newDrive.GetId()))
+	caches.BackupDriveIDToDriveInfo.Store(driveID, di)
+
+	return di, nil
+}
+
+// EnsureDrivesExist runs ensureDriveExists ahead of the restore for every
+// drive the collections restore into, creating several drives at once.
+// Drives that would be created with the same name are handled one at a
+// time, in collection order, so that name collisions resolve the same way
+// they would if each collection created its own drive.  Failures are logged
+// and left for the collection's restore to retry and report.
+func EnsureDrivesExist(
+	ctx context.Context,
+	pdagrf PostDriveAndGetRootFolderer,
+	rcc inject.RestoreConsumerConfig,
+	caches *restoreCaches,
+	dcs []data.RestoreCollection,
+	fallbackDriveName string,
+) {
+	// every collection restores into the target drive, which never
+	// gets created.
+	if len(rcc.RestoreConfig.TargetDriveID) > 0 {
+		return
+	}
+
+	var (
+		seen      = map[string]struct{}{}
+		byName    = map[string][]*path.DrivePath{}
+		nameOrder = []string{}
+	)
+
+	for _, dc := range dcs {
+		cat := dc.FullPath().Category()
+		if cat != path.FilesCategory && cat != path.LibrariesCategory {
+			continue
+		}
+
+		drivePath, err := path.ToDrivePath(dc.FullPath())
+		if err != nil {
+			continue
+		}
+
+		if _, ok := seen[drivePath.DriveID]; ok {
+			continue
+		}
+
+		seen[drivePath.DriveID] = struct{}{}
+
+		if _, ok := caches.DriveIDToDriveInfo.Load(drivePath.DriveID); ok {
+			continue
+		}
+
+		name := fallbackDriveName
+		if oldName, ok := caches.BackupDriveIDName.NameOf(drivePath.DriveID); ok {
+			name = oldName
+		}
+
+		if _, ok := byName[name]; !ok {
+			nameOrder = append(nameOrder, name)
+		}
+
+		byName[name] = append(byName[name], drivePath)
+	}
+
+	var (
+		wg          sync.WaitGroup
+		semaphoreCh = make(chan struct{}, driveCreationParallelism(rcc.Options))
+	)
+
+	defer close(semaphoreCh)
+
+	for _, name := range nameOrder {
+		wg.Add(1)
+		semaphoreCh <- struct{}{}
+
+		go func(drivePaths []*path.DrivePath) {
+			defer wg.Done()
+			defer func() { <-semaphoreCh }()
+
+			for _, dp := range drivePaths {
+				ictx := clues.Add(ctx, "backup_drive_id", dp.DriveID)
+
+				_, err := ensureDriveExists(
+					ictx,
+					pdagrf,
+					caches,
+					dp,
+					rcc.ProtectedResource.ID(),
+					rcc.RestoreConfig.TargetDriveID,
+					fallbackDriveName)
+				if err != nil {
+					logger.CtxErr(ictx, err).Info("creating drive ahead of restore")
+				}
+			}
+		}(byName[name])
+	}
+
+	wg.Wait()
+}
+
+// ensureTargetDriveExists validates that the drive with the given ID exists,
+// and caches its root folder.  Unlike ensureDriveExists, no drive gets created
+// if the lookup fails.
+func ensureTargetDriveExists(
+	ctx context.Context,
+	grf GetRootFolderer,
+	caches *restoreCaches,
+	targetDriveID string,
+) (driveInfo, error) {
+	if di, ok := caches.DriveIDToDriveInfo.Load(targetDriveID); ok {
+		return di, nil
+	}
+
+	ctx = clues.Add(ctx, "target_drive_id", targetDriveID)
+
+	root, err := grf.GetRootFolder(ctx, targetDriveID)
+	if err != nil {
+		return driveInfo{}, clues.Wrap(err, "getting target drive root folder")
+	}
+
+	di := driveInfo{
+		id:           targetDriveID,
+		rootFolderID: ptr.Val(root.GetId()),
+	}
+
+	// the drive name is unknown, so we only cache by ID.
+	caches.DriveIDToDriveInfo.Store(targetDriveID, di)
 
 	return di, nil
 }
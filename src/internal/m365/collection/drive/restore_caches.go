@@ -22,18 +22,51 @@ type driveInfo struct {
 }
 
 type restoreCaches struct {
-	BackupDriveIDName     idname.Cacher
-	collisionKeyToItemID  map[string]api.DriveItemIDType
-	DriveIDToDriveInfo    *xsync.MapOf[string, driveInfo]
-	DriveNameToDriveInfo  *xsync.MapOf[string, driveInfo]
-	Folders               *folderCache
-	OldLinkShareIDToNewID *xsync.MapOf[string, string]
-	OldPermIDToNewID      *xsync.MapOf[string, string]
-	ParentDirToMeta       *xsync.MapOf[string, metadata.Metadata]
+	BackupDriveIDName idname.Cacher
+	// BackupDriveIDToDriveInfo tracks the drive created, or reused, for
+	// each drive in the backup, so that later collections from the same
+	// backup drive don't create the drive a second time.
+	BackupDriveIDToDriveInfo *xsync.MapOf[string, driveInfo]
+	collisionKeyToItemID     map[string]api.DriveItemIDType
+	contentHashToItemID      *xsync.MapOf[string, string]
+	DriveIDToDriveInfo       *xsync.MapOf[string, driveInfo]
+	DriveNameToDriveInfo     *xsync.MapOf[string, driveInfo]
+	Folders                  *folderCache
+	OldLinkShareIDToNewID    *xsync.MapOf[string, string]
+	OldPermIDToNewID         *xsync.MapOf[string, string]
+	ParentDirToMeta          *xsync.MapOf[string, metadata.Metadata]
+
+	copyBuffers *copyBufferPool
+}
 
+// copyBufferPool hands out reusable buffers for copying item content
+// during uploads.  Restores process many items in parallel, and
+// allocating a fresh buffer per item churns the garbage collector.
+type copyBufferPool struct {
 	pool sync.Pool
 }
 
+func newCopyBufferPool(size int) *copyBufferPool {
+	return &copyBufferPool{
+		pool: sync.Pool{
+			New: func() any {
+				b := make([]byte, size)
+				return &b
+			},
+		},
+	}
+}
+
+// get returns a buffer from the pool.  Callers must hand the buffer
+// back with put once they're done with it.
+func (p *copyBufferPool) get() *[]byte {
+	return p.pool.Get().(*[]byte)
+}
+
+func (p *copyBufferPool) put(b *[]byte) {
+	p.pool.Put(b)
+}
+
 func (rc *restoreCaches) AddDrive(
 	ctx context.Context,
 	md models.Driveable,
@@ -96,20 +129,16 @@ func NewRestoreCaches(
 	}
 
 	return &restoreCaches{
-		BackupDriveIDName:     backupDriveIDNames,
-		collisionKeyToItemID:  map[string]api.DriveItemIDType{},
-		DriveIDToDriveInfo:    xsync.NewMapOf[driveInfo](),
-		DriveNameToDriveInfo:  xsync.NewMapOf[driveInfo](),
-		Folders:               NewFolderCache(),
-		OldLinkShareIDToNewID: xsync.NewMapOf[string](),
-		OldPermIDToNewID:      xsync.NewMapOf[string](),
-		ParentDirToMeta:       xsync.NewMapOf[metadata.Metadata](),
-		// Buffer pool for uploads
-		pool: sync.Pool{
-			New: func() any {
-				b := make([]byte, graph.CopyBufferSize)
-				return &b
-			},
-		},
+		BackupDriveIDName:        backupDriveIDNames,
+		BackupDriveIDToDriveInfo: xsync.NewMapOf[driveInfo](),
+		collisionKeyToItemID:     map[string]api.DriveItemIDType{},
+		contentHashToItemID:      xsync.NewMapOf[string](),
+		DriveIDToDriveInfo:       xsync.NewMapOf[driveInfo](),
+		DriveNameToDriveInfo:     xsync.NewMapOf[driveInfo](),
+		Folders:                  NewFolderCache(),
+		OldLinkShareIDToNewID:    xsync.NewMapOf[string](),
+		OldPermIDToNewID:         xsync.NewMapOf[string](),
+		ParentDirToMeta:          xsync.NewMapOf[metadata.Metadata](),
+		copyBuffers:              newCopyBufferPool(graph.CopyBufferSize),
 	}
 }
@@ -1,8 +1,12 @@
 package drive
 
 import (
+	"bytes"
 	"context"
+	"io"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/alcionai/clues"
 	"github.com/google/uuid"
@@ -13,7 +17,9 @@ import (
 
 	"github.com/alcionai/corso/src/internal/common/idname"
 	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
 	dataMock "github.com/alcionai/corso/src/internal/data/mock"
+	"github.com/alcionai/corso/src/internal/m365/collection/drive/metadata"
 	"github.com/alcionai/corso/src/internal/m365/graph"
 	odConsts "github.com/alcionai/corso/src/internal/m365/service/onedrive/consts"
 	odMock "github.com/alcionai/corso/src/internal/m365/service/onedrive/mock"
@@ -21,6 +27,7 @@ import (
 	"github.com/alcionai/corso/src/internal/operations/inject"
 	"github.com/alcionai/corso/src/internal/tester"
 	"github.com/alcionai/corso/src/internal/version"
+	"github.com/alcionai/corso/src/pkg/backup/details"
 	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/count"
 	"github.com/alcionai/corso/src/pkg/fault"
@@ -37,6 +44,212 @@ func TestRestoreUnitSuite(t *testing.T) {
 	suite.Run(t, &RestoreUnitSuite{Suite: tester.NewUnitSuite(t)})
 }
 
+func (suite *RestoreUnitSuite) TestRestoreParallelism() {
+	table := []struct {
+		name     string
+		override int
+		expect   int
+	}{
+		{"default", 0, graph.Parallelism(path.OneDriveService).ItemUpload()},
+		{"override", 2, 2},
+		{"override above backup fetch", 12, 12},
+		{"override above the upload limit", 100, graph.Parallelism(path.OneDriveService).ItemUpload()},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			opts := control.DefaultOptions()
+			opts.Parallelism.RestoreItemWrite = test.override
+
+			assert.Equal(t, test.expect, restoreParallelism(ctx, opts))
+		})
+	}
+}
+
+func (suite *RestoreUnitSuite) TestDriveCreationParallelism() {
+	table := []struct {
+		name     string
+		override int
+		expect   int
+	}{
+		{"default", 0, defaultDriveCreationParallelism},
+		{"override", 2, 2},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			opts := control.DefaultOptions()
+			opts.Parallelism.RestoreDriveCreate = test.override
+
+			assert.Equal(suite.T(), test.expect, driveCreationParallelism(opts))
+		})
+	}
+}
+
+func (suite *RestoreUnitSuite) TestRestoredFileSystemInfo() {
+	var (
+		created  = time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+		modified = created.Add(time.Hour)
+		modTime  = created.Add(2 * time.Hour)
+		preserve = control.RestoreConfig{PreserveTimestamps: true}
+	)
+
+	table := []struct {
+		name           string
+		restoreCfg     control.RestoreConfig
+		meta           metadata.Metadata
+		modTime        time.Time
+		expectNil      bool
+		expectCreated  *time.Time
+		expectModified *time.Time
+	}{
+		{
+			name:       "not preserving timestamps",
+			restoreCfg: control.RestoreConfig{},
+			meta:       metadata.Metadata{Created: &created, Modified: &modified},
+			modTime:    modTime,
+			expectNil:  true,
+		},
+		{
+			name:           "timestamps in metadata",
+			restoreCfg:     preserve,
+			meta:           metadata.Metadata{Created: &created, Modified: &modified},
+			modTime:        modTime,
+			expectCreated:  &created,
+			expectModified: &modified,
+		},
+		{
+			name:           "no timestamps in metadata",
+			restoreCfg:     preserve,
+			modTime:        modTime,
+			expectModified: &modTime,
+		},
+		{
+			name:       "no timestamps",
+			restoreCfg: preserve,
+			expectNil:  true,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			fsi := restoredFileSystemInfo(
+				test.restoreCfg,
+				test.meta,
+				&dataMock.Item{ModifiedTime: test.modTime})
+
+			if test.expectNil {
+				assert.Nil(t, fsi)
+				return
+			}
+
+			require.NotNil(t, fsi)
+			assert.Equal(t, test.expectCreated, fsi.GetCreatedDateTime(), "created")
+			assert.Equal(t, test.expectModified, fsi.GetLastModifiedDateTime(), "modified")
+		})
+	}
+}
+
+func (suite *RestoreUnitSuite) TestRestoreFile_preserveTimestamps() {
+	var (
+		created  = time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+		modified = created.Add(time.Hour)
+	)
+
+	table := []struct {
+		name      string
+		fsi       models.FileSystemInfoable
+		expectFSI assert.ValueAssertionFunc
+	}{
+		{
+			name: "timestamps",
+			fsi: func() models.FileSystemInfoable {
+				fsi := models.NewFileSystemInfo()
+				fsi.SetCreatedDateTime(&created)
+				fsi.SetLastModifiedDateTime(&modified)
+
+				return fsi
+			}(),
+			expectFSI: assert.NotNil,
+		},
+		{
+			name:      "no timestamps",
+			expectFSI: assert.Nil,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				caches   = NewRestoreCaches(nil)
+				uploaded = models.NewDriveItem()
+				rh       = &odMock.RestoreHandler{
+					PostItemResp: uploaded,
+					CopyItemResp: models.NewDriveItem(),
+				}
+				item = hashedItem{
+					Item: &dataMock.Item{
+						ItemID: uuid.NewString(),
+						Reader: io.NopCloser(bytes.NewReader(nil)),
+					},
+					hash: "hash",
+				}
+			)
+
+			uploaded.SetId(ptr.To("uploaded-id"))
+
+			// content restored earlier in the session gets copied, unless
+			// the item has timestamps of its own.
+			caches.contentHashToItemID.Store(contentHashKey("drive-id", item), "earlier-id")
+
+			copyBuffer := caches.copyBuffers.get()
+			defer caches.copyBuffers.put(copyBuffer)
+
+			_, _, _, err := restoreFile(
+				ctx,
+				control.RestoreConfig{OnCollision: control.Copy},
+				rh,
+				odMock.FetchItemByName{Item: item},
+				"file.txt",
+				test.fsi,
+				item,
+				nil,
+				"drive-id",
+				"parent-id",
+				caches.collisionKeyToItemID,
+				caches.contentHashToItemID,
+				*copyBuffer,
+				count.New())
+			require.NoError(t, err, clues.ToCore(err))
+
+			if test.fsi == nil {
+				assert.True(t, rh.CalledCopyItem, "copied earlier content")
+				return
+			}
+
+			assert.False(t, rh.CalledCopyItem, "copied earlier content")
+			require.True(t, rh.CalledPostItem, "posted item")
+
+			posted := rh.PostedItem.GetFileSystemInfo()
+			test.expectFSI(t, posted)
+			assert.Equal(t, &created, posted.GetCreatedDateTime(), "posted created")
+			assert.Equal(t, &modified, posted.GetLastModifiedDateTime(), "posted modified")
+
+			require.NotNil(t, rh.UploadSessionFSI, "upload session timestamps")
+			assert.Equal(t, &created, rh.UploadSessionFSI.GetCreatedDateTime(), "uploaded created")
+			assert.Equal(t, &modified, rh.UploadSessionFSI.GetLastModifiedDateTime(), "uploaded modified")
+		})
+	}
+}
+
 func (suite *RestoreUnitSuite) TestRestoreItem_collisionHandling() {
 	const mndiID = "mndi-id"
 
@@ -227,6 +440,9 @@ func (suite *RestoreUnitSuite) TestRestoreItem_collisionHandling() {
 				RestoreConfig: restoreCfg,
 			}
 
+			copyBuffer := caches.copyBuffers.get()
+			defer caches.copyBuffers.put(copyBuffer)
+
 			_, skip, err := restoreItem(
 				ctx,
 				rh,
@@ -239,7 +455,7 @@ func (suite *RestoreUnitSuite) TestRestoreItem_collisionHandling() {
 				},
 				dp,
 				"",
-				make([]byte, graph.CopyBufferSize),
+				*copyBuffer,
 				caches,
 				&dataMock.Item{
 					ItemID:   uuid.NewString(),
@@ -260,6 +476,457 @@ func (suite *RestoreUnitSuite) TestRestoreItem_collisionHandling() {
 	}
 }
 
+func (suite *RestoreUnitSuite) TestRestoreItem_replaceIfUnchanged() {
+	const mndiID = "mndi-id"
+
+	table := []struct {
+		name               string
+		replaceIfUnchanged bool
+		collisionETag      string
+		currentETag        string
+		expectErr          assert.ErrorAssertionFunc
+		expectConditional  bool
+		expectDeleted      bool
+		expectPosted       bool
+		expectReplaced     int64
+	}{
+		{
+			name:               "unchanged item",
+			replaceIfUnchanged: true,
+			collisionETag:      "etag-1",
+			currentETag:        "etag-1",
+			expectErr:          assert.NoError,
+			expectConditional:  true,
+			expectDeleted:      true,
+			expectPosted:       true,
+			expectReplaced:     1,
+		},
+		{
+			name:               "changed item",
+			replaceIfUnchanged: true,
+			collisionETag:      "etag-1",
+			currentETag:        "etag-2",
+			expectErr: func(t assert.TestingT, err error, msgAndArgs ...any) bool {
+				return assert.ErrorIs(t, err, graph.ErrPreconditionFailed, msgAndArgs...)
+			},
+			expectConditional: true,
+		},
+		{
+			name:               "no etag for the collision",
+			replaceIfUnchanged: true,
+			currentETag:        "etag-2",
+			expectErr:          assert.NoError,
+			expectDeleted:      true,
+			expectPosted:       true,
+			expectReplaced:     1,
+		},
+		{
+			name:           "unconditional replace",
+			collisionETag:  "etag-1",
+			currentETag:    "etag-2",
+			expectErr:      assert.NoError,
+			expectDeleted:  true,
+			expectPosted:   true,
+			expectReplaced: 1,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				caches = NewRestoreCaches(nil)
+				rh     = &odMock.RestoreHandler{
+					PostItemResp: models.NewDriveItem(),
+					CurrentETags: map[string]string{mndiID: test.currentETag},
+				}
+				restoreCfg = control.RestoreConfig{
+					OnCollision:        control.Replace,
+					ReplaceIfUnchanged: test.replaceIfUnchanged,
+				}
+				dpb = odConsts.DriveFolderPrefixBuilder("driveID1")
+				ctr = count.New()
+			)
+
+			caches.collisionKeyToItemID = map[string]api.DriveItemIDType{
+				odMock.DriveItemFileName: {
+					ItemID: mndiID,
+					ETag:   test.collisionETag,
+				},
+			}
+
+			dpp, err := dpb.ToDataLayerOneDrivePath("t", "u", false)
+			require.NoError(t, err)
+
+			dp, err := path.ToDrivePath(dpp)
+			require.NoError(t, err)
+
+			rcc := inject.RestoreConsumerConfig{
+				BackupVersion: version.Backup,
+				Options:       control.DefaultOptions(),
+				RestoreConfig: restoreCfg,
+			}
+
+			copyBuffer := caches.copyBuffers.get()
+			defer caches.copyBuffers.put(copyBuffer)
+
+			_, _, err = restoreItem(
+				ctx,
+				rh,
+				rcc,
+				odMock.FetchItemByName{
+					Item: &dataMock.Item{
+						Reader:   odMock.FileRespReadCloser(odMock.DriveFileMetaData),
+						ItemInfo: odStub.DriveItemInfo(),
+					},
+				},
+				dp,
+				"",
+				*copyBuffer,
+				caches,
+				&dataMock.Item{
+					ItemID:   uuid.NewString(),
+					Reader:   odMock.FileRespReadCloser(odMock.DriveFilePayloadData),
+					ItemInfo: odStub.DriveItemInfo(),
+				},
+				nil,
+				ctr,
+				fault.New(true))
+			test.expectErr(t, err, clues.ToCore(err))
+
+			assert.Equal(t, test.expectConditional, rh.CalledDeleteItemIfMatch, "conditional delete")
+			assert.Equal(t, test.expectDeleted, rh.CalledDeleteItem, "original item deleted")
+			assert.Equal(t, test.expectPosted, rh.CalledPostItem, "new item posted")
+			assert.Equal(t, test.expectReplaced, ctr.Get(count.CollisionReplace), "replaces")
+		})
+	}
+}
+
+func (suite *RestoreUnitSuite) TestRestoreItem_collisionFunc() {
+	const mndiID = "mndi-id"
+
+	var (
+		policies = map[string]control.CollisionPolicy{
+			"skip":    control.Skip,
+			"replace": control.Replace,
+			"copy":    control.Copy,
+			"unknown": control.Unknown,
+		}
+		existing = []string{}
+	)
+
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		caches = NewRestoreCaches(nil)
+		rh     = &odMock.RestoreHandler{PostItemResp: models.NewDriveItem()}
+		dpb    = odConsts.DriveFolderPrefixBuilder("driveID1")
+		ctr    = count.New()
+	)
+
+	caches.collisionKeyToItemID = map[string]api.DriveItemIDType{
+		odMock.DriveItemFileName: {ItemID: mndiID},
+	}
+
+	dpp, err := dpb.ToDataLayerOneDrivePath("t", "u", false)
+	require.NoError(t, err)
+
+	dp, err := path.ToDrivePath(dpp)
+	require.NoError(t, err)
+
+	rcc := inject.RestoreConsumerConfig{
+		BackupVersion: version.Backup,
+		Options:       control.DefaultOptions(),
+		RestoreConfig: control.RestoreConfig{
+			// the static policy applies wherever the callback's doesn't.
+			OnCollision: control.Skip,
+			OnCollisionFunc: func(
+				item details.Entry,
+				ci control.CollidingItem,
+			) control.CollisionPolicy {
+				existing = append(existing, ci.ItemID)
+				return policies[item.ItemRef]
+			},
+		},
+	}
+
+	copyBuffer := caches.copyBuffers.get()
+	defer caches.copyBuffers.put(copyBuffer)
+
+	skipped := map[string]bool{}
+
+	for id := range policies {
+		_, skip, err := restoreItem(
+			ctx,
+			rh,
+			rcc,
+			odMock.FetchItemByName{
+				Item: &dataMock.Item{
+					Reader:   odMock.FileRespReadCloser(odMock.DriveFileMetaData),
+					ItemInfo: odStub.DriveItemInfo(),
+				},
+			},
+			dp,
+			"",
+			*copyBuffer,
+			caches,
+			&dataMock.Item{
+				ItemID:   id,
+				Reader:   odMock.FileRespReadCloser(odMock.DriveFilePayloadData),
+				ItemInfo: odStub.DriveItemInfo(),
+			},
+			nil,
+			ctr,
+			fault.New(true))
+		require.NoError(t, err, clues.ToCore(err))
+
+		skipped[id] = skip
+	}
+
+	assert.Equal(
+		t,
+		map[string]bool{"skip": true, "replace": false, "copy": false, "unknown": true},
+		skipped,
+		"skipped items")
+	assert.Equal(t, []string{mndiID, mndiID, mndiID, mndiID}, existing, "colliding items")
+	assert.Equal(t, int64(2), ctr.Get(count.CollisionSkip), "skips")
+	assert.Equal(t, int64(1), ctr.Get(count.CollisionReplace), "replaces")
+	assert.Equal(t, int64(1), ctr.Get(count.NewItemCreated), "new items")
+	assert.Equal(t, mndiID, rh.CalledDeleteItemOn, "replaced the colliding item")
+}
+
+// conflicts reported by graph aren't skips unless the item's resolved
+// policy is to skip it.
+func (suite *RestoreUnitSuite) TestRestoreItem_collisionFuncGraphConflict() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		caches = NewRestoreCaches(nil)
+		rh     = &odMock.RestoreHandler{PostItemErr: graph.ErrItemAlreadyExistsConflict}
+		dpb    = odConsts.DriveFolderPrefixBuilder("driveID1")
+		ctr    = count.New()
+	)
+
+	caches.collisionKeyToItemID = map[string]api.DriveItemIDType{
+		odMock.DriveItemFileName: {ItemID: "mndi-id"},
+	}
+
+	dpp, err := dpb.ToDataLayerOneDrivePath("t", "u", false)
+	require.NoError(t, err)
+
+	dp, err := path.ToDrivePath(dpp)
+	require.NoError(t, err)
+
+	rcc := inject.RestoreConsumerConfig{
+		BackupVersion: version.Backup,
+		Options:       control.DefaultOptions(),
+		RestoreConfig: control.RestoreConfig{
+			OnCollision: control.Skip,
+			OnCollisionFunc: func(details.Entry, control.CollidingItem) control.CollisionPolicy {
+				return control.Copy
+			},
+		},
+	}
+
+	copyBuffer := caches.copyBuffers.get()
+	defer caches.copyBuffers.put(copyBuffer)
+
+	_, skip, err := restoreItem(
+		ctx,
+		rh,
+		rcc,
+		odMock.FetchItemByName{
+			Item: &dataMock.Item{
+				Reader:   odMock.FileRespReadCloser(odMock.DriveFileMetaData),
+				ItemInfo: odStub.DriveItemInfo(),
+			},
+		},
+		dp,
+		"",
+		*copyBuffer,
+		caches,
+		&dataMock.Item{
+			ItemID:   "copy",
+			Reader:   odMock.FileRespReadCloser(odMock.DriveFilePayloadData),
+			ItemInfo: odStub.DriveItemInfo(),
+		},
+		nil,
+		ctr,
+		fault.New(true))
+	assert.ErrorIs(t, err, graph.ErrItemAlreadyExistsConflict, clues.ToCore(err))
+	assert.False(t, skip, "item skipped")
+	assert.Zero(t, ctr.Get(count.CollisionSkip), "skips")
+}
+
+type hashedItem struct {
+	*dataMock.Item
+	hash string
+}
+
+func (hi hashedItem) ContentHash() string {
+	return hi.hash
+}
+
+func (suite *RestoreUnitSuite) TestRestoreFile_reusesRestoredContent() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		caches     = NewRestoreCaches(nil)
+		ctr        = count.New()
+		uploaded   = models.NewDriveItem()
+		copied     = models.NewDriveItem()
+		restoreCfg = control.RestoreConfig{OnCollision: control.Copy}
+		rh         = &odMock.RestoreHandler{
+			PostItemResp: uploaded,
+			CopyItemResp: copied,
+		}
+	)
+
+	uploaded.SetId(ptr.To("uploaded-id"))
+	copied.SetId(ptr.To("copied-id"))
+
+	copyBuffer := caches.copyBuffers.get()
+	defer caches.copyBuffers.put(copyBuffer)
+
+	restore := func(name, hash string) string {
+		item := hashedItem{
+			Item: &dataMock.Item{
+				ItemID: uuid.NewString(),
+				Reader: io.NopCloser(bytes.NewReader(nil)),
+			},
+			hash: hash,
+		}
+
+		id, _, _, err := restoreFile(
+			ctx,
+			restoreCfg,
+			rh,
+			odMock.FetchItemByName{Item: item},
+			name,
+			nil,
+			item,
+			nil,
+			"drive-id",
+			"parent-id",
+			caches.collisionKeyToItemID,
+			caches.contentHashToItemID,
+			*copyBuffer,
+			ctr)
+		require.NoError(t, err, clues.ToCore(err))
+
+		return id
+	}
+
+	assert.Equal(t, "uploaded-id", restore("first", "hash"))
+	assert.True(t, rh.CalledPostItem, "first item posted")
+	assert.False(t, rh.CalledCopyItem, "first item copied")
+
+	rh.CalledPostItem = false
+
+	assert.Equal(t, "copied-id", restore("second", "hash"))
+	assert.False(t, rh.CalledPostItem, "identical item posted")
+	assert.True(t, rh.CalledCopyItem, "identical item copied")
+	assert.Equal(t, "uploaded-id", rh.CalledCopyItemOn, "copied from the first item")
+
+	rh.CalledCopyItem = false
+
+	assert.Equal(t, "uploaded-id", restore("third", "other-hash"))
+	assert.True(t, rh.CalledPostItem, "different item posted")
+	assert.False(t, rh.CalledCopyItem, "different item copied")
+
+	rh.CalledPostItem = false
+	rh.CopyItemErr = assert.AnError
+
+	assert.Equal(t, "uploaded-id", restore("fourth", "hash"))
+	assert.True(t, rh.CalledCopyItem, "copy attempted")
+	assert.True(t, rh.CalledPostItem, "failed copy falls back to posting")
+
+	assert.Equal(t, int64(4), ctr.Get(count.NewItemCreated), "new items")
+}
+
+func (suite *RestoreUnitSuite) TestCopyBufferPool() {
+	var (
+		t    = suite.T()
+		pool = newCopyBufferPool(64)
+		// larger than the buffer, to ensure the copy spans multiple reads
+		src = bytes.Repeat([]byte("corso"), 100)
+	)
+
+	for i := 0; i < 3; i++ {
+		bufPtr := pool.get()
+		require.Len(t, *bufPtr, 64, "buffer size")
+
+		// a previous user of the buffer may have left data behind.  That
+		// must not leak into the copied content.
+		copy(*bufPtr, bytes.Repeat([]byte{'x'}, 64))
+
+		dst := &bytes.Buffer{}
+
+		// wrapped to hide ReadFrom and WriteTo, which would bypass the buffer.
+		n, err := io.CopyBuffer(
+			struct{ io.Writer }{dst},
+			struct{ io.Reader }{bytes.NewReader(src)},
+			*bufPtr)
+		require.NoError(t, err, clues.ToCore(err))
+		assert.Equal(t, int64(len(src)), n, "bytes copied")
+		assert.Equal(t, src, dst.Bytes(), "copied content")
+
+		pool.put(bufPtr)
+	}
+
+	caches := NewRestoreCaches(nil)
+	bufPtr := caches.copyBuffers.get()
+	assert.Len(t, *bufPtr, graph.CopyBufferSize, "restore cache buffer size")
+}
+
+// BenchmarkCopyBuffer compares allocating a copy buffer per item against
+// pulling buffers from the pool.  Run with -benchmem to see the difference
+// in allocations.
+func BenchmarkCopyBuffer(b *testing.B) {
+	src := bytes.Repeat([]byte("corso"), 1024)
+
+	b.Run("allocate", func(b *testing.B) {
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				buf := make([]byte, graph.CopyBufferSize)
+				_, _ = io.CopyBuffer(
+					struct{ io.Writer }{io.Discard},
+					struct{ io.Reader }{bytes.NewReader(src)},
+					buf)
+			}
+		})
+	})
+
+	b.Run("pool", func(b *testing.B) {
+		pool := newCopyBufferPool(graph.CopyBufferSize)
+
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				bufPtr := pool.get()
+				_, _ = io.CopyBuffer(
+					struct{ io.Writer }{io.Discard},
+					struct{ io.Reader }{bytes.NewReader(src)},
+					*bufPtr)
+				pool.put(bufPtr)
+			}
+		})
+	})
+}
+
 type mockPIIC struct {
 	i     int
 	errs  []error
@@ -606,6 +1273,7 @@ func (suite *RestoreUnitSuite) TestEnsureDriveExists() {
 		mock            *mockPDAGRF
 		rc              *restoreCaches
 		expectErr       require.ErrorAssertionFunc
+		targetDriveID   string
 		fallbackName    string
 		expectName      string
 		expectID        string
@@ -738,6 +1406,41 @@ func (suite *RestoreUnitSuite) TestEnsureDriveExists() {
 			expectName:   name,
 			expectID:     driveID,
 		},
+		{
+			name: "explicit target drive already in cache",
+			dp:   oldDP,
+			// no post responses: any attempt to create a drive will panic.
+			mock:          &mockPDAGRF{grf: grf},
+			rc:            populatedCache("target"),
+			expectErr:     require.NoError,
+			targetDriveID: "target",
+			fallbackName:  otherName,
+			expectName:    name,
+			expectID:      "target",
+		},
+		{
+			name:          "explicit target drive not cached",
+			dp:            dp,
+			mock:          &mockPDAGRF{grf: grf},
+			rc:            NewRestoreCaches(oldDriveIDNames),
+			expectErr:     require.NoError,
+			targetDriveID: "target",
+			fallbackName:  otherName,
+			expectName:    "",
+			expectID:      "target",
+		},
+		{
+			name: "explicit target drive does not exist",
+			dp:   dp,
+			mock: &mockPDAGRF{
+				grf: mockGRF{err: assert.AnError},
+			},
+			rc:              populatedCache(driveID),
+			expectErr:       require.Error,
+			targetDriveID:   "missing",
+			fallbackName:    name,
+			skipValueChecks: true,
+		},
 	}
 	for _, test := range table {
 		suite.Run(test.name, func() {
@@ -754,6 +1457,7 @@ func (suite *RestoreUnitSuite) TestEnsureDriveExists() {
 				rc,
 				test.dp,
 				"prID",
+				test.targetDriveID,
 				test.fallbackName)
 			test.expectErr(t, err, clues.ToCore(err))
 
@@ -763,7 +1467,150 @@ func (suite *RestoreUnitSuite) TestEnsureDriveExists() {
 
 				nameResult, _ := rc.DriveNameToDriveInfo.Load(test.expectName)
 				assert.Equal(t, test.expectName, nameResult.name, "found drive entry with expected name")
+
+				_, ok := rc.DriveIDToDriveInfo.Load(test.expectID)
+				assert.True(t, ok, "drive cached by id")
 			}
 		})
 	}
 }
+
+// namedPDAGRF creates drives concurrently, rejecting any name that
+// already exists.
+type namedPDAGRF struct {
+	mu       sync.Mutex
+	names    map[string]struct{}
+	inFlight int
+	maxIn    int
+
+	grf mockGRF
+}
+
+func (m *namedPDAGRF) PostDrive(
+	ctx context.Context,
+	protectedResourceID, driveName string,
+) (models.Driveable, error) {
+	m.mu.Lock()
+	m.inFlight++
+	m.maxIn = max(m.maxIn, m.inFlight)
+	m.mu.Unlock()
+
+	// give other creations a chance to overlap.
+	time.Sleep(10 * time.Millisecond)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.inFlight--
+
+	if _, ok := m.names[driveName]; ok {
+		return nil, clues.Stack(graph.ErrItemAlreadyExistsConflict)
+	}
+
+	m.names[driveName] = struct{}{}
+
+	md := models.NewDrive()
+	md.SetId(ptr.To("id-" + driveName))
+	md.SetName(ptr.To(driveName))
+
+	return md, nil
+}
+
+func (m *namedPDAGRF) GetRootFolder(
+	ctx context.Context,
+	driveID string,
+) (models.DriveItemable, error) {
+	return m.grf.rootFolder, m.grf.err
+}
+
+func (suite *RestoreUnitSuite) TestEnsureDrivesExist() {
+	const fallback = "fallback"
+
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	rf := models.NewDriveItem()
+	rf.SetId(ptr.To("root"))
+
+	var (
+		mock = &namedPDAGRF{
+			// a list on the site already uses this name.
+			names: map[string]struct{}{"docs": {}},
+			grf:   mockGRF{rootFolder: rf},
+		}
+		backupNames = idname.NewCache(map[string]string{
+			"b1": "docs",
+			"b2": "docs",
+			"b3": "archive",
+			"b4": "reports",
+			"b5": "finance",
+		})
+		caches = NewRestoreCaches(backupNames)
+		rcc    = inject.RestoreConsumerConfig{
+			Options:           control.DefaultOptions(),
+			ProtectedResource: idname.NewProvider("pr", "pr"),
+		}
+		dcs = []data.RestoreCollection{}
+	)
+
+	rcc.Options.Parallelism.RestoreDriveCreate = 3
+
+	existing := driveInfo{id: "existing", name: "existing", rootFolderID: "root"}
+	caches.DriveIDToDriveInfo.Store(existing.id, existing)
+	caches.DriveNameToDriveInfo.Store(existing.name, existing)
+
+	// b6 and b7 have no name in the backup; b1 has two collections.
+	for _, id := range []string{"b1", "b1", "b2", "b3", "b4", "b5", "b6", "b7", "existing"} {
+		p, err := odConsts.DriveFolderPrefixBuilder(id).
+			Append("folder").
+			ToDataLayerOneDrivePath("t", "u", false)
+		require.NoError(t, err, clues.ToCore(err))
+
+		dcs = append(dcs, dataMock.Collection{Path: p})
+	}
+
+	EnsureDrivesExist(ctx, mock, rcc, caches, dcs, fallback)
+
+	assert.LessOrEqual(t, mock.maxIn, 3, "concurrent drive creations")
+
+	expect := map[string]string{
+		// the list's name gets a suffix, in collection order.
+		"b1": "docs 1",
+		"b2": "docs 2",
+		"b3": "archive",
+		"b4": "reports",
+		"b5": "finance",
+		"b6": fallback,
+		"b7": fallback + " 1",
+	}
+
+	names := map[string]struct{}{}
+
+	for backupID, expectName := range expect {
+		di, ok := caches.BackupDriveIDToDriveInfo.Load(backupID)
+		require.True(t, ok, "backup drive %s has a restore drive", backupID)
+		assert.Equal(t, expectName, di.name, "backup drive %s", backupID)
+		assert.Equal(t, "root", di.rootFolderID, "backup drive %s", backupID)
+
+		_, ok = caches.DriveNameToDriveInfo.Load(di.name)
+		assert.True(t, ok, "drive %q cached by name", di.name)
+
+		names[di.name] = struct{}{}
+	}
+
+	assert.Len(t, names, 7, "unique drive names")
+	assert.Len(t, mock.names, 8, "drives created, plus the list")
+
+	// collections restored later reuse the drives instead of making more.
+	for backupID, expectName := range expect {
+		dp := &path.DrivePath{DriveID: backupID}
+
+		di, err := ensureDriveExists(ctx, mock, caches, dp, "pr", "", fallback)
+		require.NoError(t, err, clues.ToCore(err))
+		assert.Equal(t, expectName, di.name, "backup drive %s", backupID)
+	}
+
+	assert.Len(t, mock.names, 8, "no drives created by later collections")
+}
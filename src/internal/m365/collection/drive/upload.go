@@ -0,0 +1,124 @@
+package drive
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/alcionai/clues"
+	backoff "github.com/cenkalti/backoff/v4"
+
+	"github.com/alcionai/corso/src/internal/m365/graph"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/logger"
+)
+
+const (
+	// defaultLargeFileThreshold is the file size above which restores upload
+	// files in resumable chunks, used when the restore config doesn't
+	// provide a threshold of its own.
+	defaultLargeFileThreshold int64 = 100 * 1024 * 1024
+
+	// Maximum number of retries for a single chunk of a large file upload
+	maxChunkUploadRetries = 3
+)
+
+func largeFileThreshold(restoreCfg control.RestoreConfig) int64 {
+	if restoreCfg.LargeFileThreshold > 0 {
+		return restoreCfg.LargeFileThreshold
+	}
+
+	return defaultLargeFileThreshold
+}
+
+// uploadInChunks reads the reader in chunks the size of buf and writes each
+// chunk to the writer.  Graph requires every chunk other than the last to be
+// a multiple of 320 KiB, so chunks are always filled before writing.
+//
+// Failed chunks are retried on their own, without restarting the upload.
+// This relies on the writer only advancing its offset after a successful
+// write, as the graph upload session writer does.  Retries wait out the
+// graph retry backoff, starting from delay.
+func uploadInChunks(
+	ctx context.Context,
+	w io.Writer,
+	r io.Reader,
+	buf []byte,
+	maxRetries int,
+	delay time.Duration,
+) (int64, error) {
+	var written int64
+
+	for {
+		n, err := io.ReadFull(r, buf)
+		finalChunk := errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+
+		if err != nil && !finalChunk {
+			return written, clues.Wrap(err, "reading chunk").WithClues(ctx)
+		}
+
+		if n > 0 {
+			if err := uploadChunk(ctx, w, buf[:n], written, maxRetries, delay); err != nil {
+				return written, err
+			}
+
+			written += int64(n)
+		}
+
+		if finalChunk {
+			return written, nil
+		}
+	}
+}
+
+func uploadChunk(
+	ctx context.Context,
+	w io.Writer,
+	chunk []byte,
+	offset int64,
+	maxRetries int,
+	delay time.Duration,
+) error {
+	ctx = clues.Add(ctx, "chunk_offset", offset, "chunk_size", len(chunk))
+
+	var (
+		err error
+		bo  = graph.NewRetryBackOff(delay)
+	)
+
+	for i := 0; i <= maxRetries; i++ {
+		if i > 0 {
+			wait := bo.NextBackOff()
+			if wait == backoff.Stop {
+				break
+			}
+
+			logger.CtxErr(ctx, err).With("retry", i).Info("retrying chunk upload")
+
+			timer := time.NewTimer(wait)
+
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return clues.Stack(ctx.Err()).WithClues(ctx)
+			case <-timer.C:
+			}
+		}
+
+		var n int
+
+		n, err = w.Write(chunk)
+		if err != nil {
+			continue
+		}
+
+		if n != len(chunk) {
+			return clues.Stack(io.ErrShortWrite).WithClues(ctx)
+		}
+
+		return nil
+	}
+
+	return clues.Wrap(err, "uploading chunk").WithClues(ctx)
+}
@@ -0,0 +1,322 @@
+package drive
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control"
+)
+
+// mockChunkWriter records every chunk it accepts.  Writes fail whenever the
+// attempt count for the chunk at the current offset is found in failOn.
+type mockChunkWriter struct {
+	// chunk offset -> number of attempts that fail before succeeding
+	failOn   map[int64]int
+	attempts map[int64]int
+	offset   int64
+	chunks   [][]byte
+}
+
+func newMockChunkWriter(failOn map[int64]int) *mockChunkWriter {
+	return &mockChunkWriter{
+		failOn:   failOn,
+		attempts: map[int64]int{},
+	}
+}
+
+func (mcw *mockChunkWriter) Write(p []byte) (int, error) {
+	mcw.attempts[mcw.offset]++
+
+	if mcw.attempts[mcw.offset] <= mcw.failOn[mcw.offset] {
+		return 0, assert.AnError
+	}
+
+	mcw.chunks = append(mcw.chunks, bytes.Clone(p))
+	mcw.offset += int64(len(p))
+
+	return len(p), nil
+}
+
+type failingReader struct {
+	r     *bytes.Reader
+	after int
+	read  int
+}
+
+func (fr *failingReader) Read(p []byte) (int, error) {
+	if fr.read >= fr.after {
+		return 0, assert.AnError
+	}
+
+	if len(p) > fr.after-fr.read {
+		p = p[:fr.after-fr.read]
+	}
+
+	n, err := fr.r.Read(p)
+	fr.read += n
+
+	return n, err
+}
+
+// patternReader produces size bytes of generated data without holding
+// any of it in memory.
+type patternReader struct {
+	size int64
+	read int64
+}
+
+func (pr *patternReader) Read(p []byte) (int, error) {
+	if pr.read >= pr.size {
+		return 0, io.EOF
+	}
+
+	if int64(len(p)) > pr.size-pr.read {
+		p = p[:pr.size-pr.read]
+	}
+
+	for i := range p {
+		p[i] = byte(pr.read + int64(i))
+	}
+
+	pr.read += int64(len(p))
+
+	return len(p), nil
+}
+
+// sizeWriter discards everything written to it, recording the total and
+// largest write sizes.
+type sizeWriter struct {
+	written  int64
+	maxWrite int
+}
+
+func (sw *sizeWriter) Write(p []byte) (int, error) {
+	sw.written += int64(len(p))
+	sw.maxWrite = max(sw.maxWrite, len(p))
+
+	return len(p), nil
+}
+
+type UploadUnitSuite struct {
+	tester.Suite
+}
+
+func TestUploadUnitSuite(t *testing.T) {
+	suite.Run(t, &UploadUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *UploadUnitSuite) TestLargeFileThreshold() {
+	t := suite.T()
+
+	assert.Equal(t, defaultLargeFileThreshold, largeFileThreshold(control.RestoreConfig{}))
+	assert.Equal(t, defaultLargeFileThreshold, largeFileThreshold(control.RestoreConfig{LargeFileThreshold: -1}))
+	assert.Equal(t, int64(42), largeFileThreshold(control.RestoreConfig{LargeFileThreshold: 42}))
+}
+
+func (suite *UploadUnitSuite) TestUploadInChunks_splitting() {
+	table := []struct {
+		name         string
+		size         int
+		bufSize      int
+		expectChunks []int
+	}{
+		{
+			name:         "empty",
+			size:         0,
+			bufSize:      4,
+			expectChunks: []int{},
+		},
+		{
+			name:         "smaller than a chunk",
+			size:         3,
+			bufSize:      4,
+			expectChunks: []int{3},
+		},
+		{
+			name:         "exact multiple",
+			size:         8,
+			bufSize:      4,
+			expectChunks: []int{4, 4},
+		},
+		{
+			name:         "partial final chunk",
+			size:         10,
+			bufSize:      4,
+			expectChunks: []int{4, 4, 2},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				content = bytes.Repeat([]byte("a"), test.size)
+				mcw     = newMockChunkWriter(nil)
+			)
+
+			written, err := uploadInChunks(
+				ctx,
+				mcw,
+				bytes.NewReader(content),
+				make([]byte, test.bufSize),
+				maxChunkUploadRetries,
+				0)
+			require.NoError(t, err, clues.ToCore(err))
+			assert.Equal(t, int64(test.size), written)
+
+			sizes := []int{}
+			for _, c := range mcw.chunks {
+				sizes = append(sizes, len(c))
+			}
+
+			assert.Equal(t, test.expectChunks, sizes)
+			assert.Equal(t, content, bytes.Join(mcw.chunks, nil))
+		})
+	}
+}
+
+func (suite *UploadUnitSuite) TestUploadInChunks_resume() {
+	content := []byte("0123456789")
+
+	table := []struct {
+		name          string
+		failOn        map[int64]int
+		expectErr     assert.ErrorAssertionFunc
+		expectWritten int64
+		// offset -> number of write attempts
+		expectAttempts map[int64]int
+	}{
+		{
+			name:           "no failures",
+			expectErr:      assert.NoError,
+			expectWritten:  10,
+			expectAttempts: map[int64]int{0: 1, 4: 1, 8: 1},
+		},
+		{
+			name:           "resumes a failed middle chunk",
+			failOn:         map[int64]int{4: 2},
+			expectErr:      assert.NoError,
+			expectWritten:  10,
+			expectAttempts: map[int64]int{0: 1, 4: 3, 8: 1},
+		},
+		{
+			name:           "resumes several failed chunks",
+			failOn:         map[int64]int{0: 1, 8: maxChunkUploadRetries},
+			expectErr:      assert.NoError,
+			expectWritten:  10,
+			expectAttempts: map[int64]int{0: 2, 4: 1, 8: maxChunkUploadRetries + 1},
+		},
+		{
+			name:           "retries exhausted",
+			failOn:         map[int64]int{4: maxChunkUploadRetries + 1},
+			expectErr:      assert.Error,
+			expectWritten:  4,
+			expectAttempts: map[int64]int{0: 1, 4: maxChunkUploadRetries + 1},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			mcw := newMockChunkWriter(test.failOn)
+
+			written, err := uploadInChunks(
+				ctx,
+				mcw,
+				bytes.NewReader(content),
+				make([]byte, 4),
+				maxChunkUploadRetries,
+				0)
+			test.expectErr(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expectWritten, written, "bytes written")
+			assert.Equal(t, test.expectAttempts, mcw.attempts, "write attempts")
+			assert.Equal(t, content[:written], bytes.Join(mcw.chunks, nil), "uploaded content")
+		})
+	}
+}
+
+func (suite *UploadUnitSuite) TestUploadInChunks_backoffCanceled() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	mcw := newMockChunkWriter(map[int64]int{0: 1})
+
+	// the canceled context stops the upload while it waits to retry.
+	written, err := uploadInChunks(
+		ctx,
+		mcw,
+		bytes.NewReader([]byte("0123456789")),
+		make([]byte, 4),
+		maxChunkUploadRetries,
+		time.Minute)
+	assert.ErrorIs(t, err, context.Canceled, clues.ToCore(err))
+	assert.Zero(t, written)
+	assert.Equal(t, map[int64]int{0: 1}, mcw.attempts, "write attempts")
+}
+
+func (suite *UploadUnitSuite) TestUploadInChunks_readFailure() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	mcw := newMockChunkWriter(nil)
+
+	written, err := uploadInChunks(
+		ctx,
+		mcw,
+		&failingReader{r: bytes.NewReader([]byte("0123456789")), after: 6},
+		make([]byte, 4),
+		maxChunkUploadRetries,
+		0)
+	assert.ErrorIs(t, err, assert.AnError, clues.ToCore(err))
+	assert.Equal(t, int64(4), written)
+	assert.Len(t, mcw.chunks, 1)
+}
+
+func (suite *UploadUnitSuite) TestUploadInChunks_bufferSizedChunks() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	const (
+		itemSize = 10*16 + 3
+		bufSize  = 16
+	)
+
+	sw := &sizeWriter{}
+
+	written, err := uploadInChunks(
+		ctx,
+		sw,
+		&patternReader{size: itemSize},
+		make([]byte, bufSize),
+		maxChunkUploadRetries,
+		0)
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, int64(itemSize), written)
+	assert.Equal(t, int64(itemSize), sw.written)
+	assert.Equal(t, bufSize, sw.maxWrite, "chunks are never larger than the buffer")
+}
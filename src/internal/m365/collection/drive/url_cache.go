@@ -24,6 +24,7 @@ type getItemPropertyer interface {
 	getItemProperties(
 		ctx context.Context,
 		itemID string,
+		forceRefresh bool,
 	) (itemProps, error)
 }
 
@@ -100,10 +101,14 @@ func validateCacheParams(
 	return nil
 }
 
-// getItemProps returns the item properties for the specified drive item ID
+// getItemProps returns the item properties for the specified drive item ID.
+// If forceRefresh is true, the cache is refreshed before reading, even if
+// the refresh interval hasn't elapsed.  Callers should only force a refresh
+// when a url produced by the cache turned out to be expired.
 func (uc *urlCache) getItemProperties(
 	ctx context.Context,
 	itemID string,
+	forceRefresh bool,
 ) (itemProps, error) {
 	if len(itemID) == 0 {
 		return itemProps{}, clues.New("item id is empty")
@@ -111,8 +116,8 @@ func (uc *urlCache) getItemProperties(
 
 	ctx = clues.Add(ctx, "drive_id", uc.driveID)
 
-	if uc.needsRefresh() {
-		err := uc.refreshCache(ctx)
+	if forceRefresh || uc.needsRefresh() {
+		err := uc.refreshCache(ctx, forceRefresh)
 		if err != nil {
 			return itemProps{}, err
 		}
@@ -137,17 +142,26 @@ func (uc *urlCache) needsRefresh() bool {
 }
 
 // refreshCache refreshes the URL cache by performing a delta query.
+// A forced refresh skips the refresh interval check.
 func (uc *urlCache) refreshCache(
 	ctx context.Context,
+	force bool,
 ) error {
+	requestedAt := time.Now()
+
 	// Acquire mutex to prevent multiple threads from refreshing the
 	// cache at the same time
 	uc.refreshMu.Lock()
 	defer uc.refreshMu.Unlock()
 
 	// If the cache was refreshed by another thread while we were waiting
-	// to acquire mutex, return
-	if !uc.needsRefresh() {
+	// to acquire mutex, return.  This also collapses concurrent forced
+	// refreshes into a single delta query.
+	if !force && !uc.needsRefresh() {
+		return nil
+	}
+
+	if force && uc.refreshedSince(requestedAt) {
 		return nil
 	}
 
@@ -176,6 +190,14 @@ func (uc *urlCache) refreshCache(
 	return nil
 }
 
+// refreshedSince returns true if the cache was refreshed after t.
+func (uc *urlCache) refreshedSince(t time.Time) bool {
+	uc.cacheMu.RLock()
+	defer uc.cacheMu.RUnlock()
+
+	return uc.lastRefreshTime.After(t)
+}
+
 // deltaQuery performs a delta query on the drive and update the cache
 func (uc *urlCache) deltaQuery(
 	ctx context.Context,
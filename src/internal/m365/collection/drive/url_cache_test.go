@@ -12,6 +12,7 @@ import (
 
 	"github.com/alcionai/clues"
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
@@ -159,7 +160,8 @@ func (suite *URLCacheIntegrationSuite) TestURLCacheBasic() {
 			// Read item from URL cache
 			props, err := uc.getItemProperties(
 				ctx,
-				ptr.Val(items[i].GetId()))
+				ptr.Val(items[i].GetId()),
+				false)
 			require.NoError(t, err, clues.ToCore(err))
 
 			require.NotNil(t, props)
@@ -463,7 +465,7 @@ func (suite *URLCacheUnitSuite) TestGetItemProperties() {
 					for id, expected := range test.expectedItemProps {
 						time.Sleep(time.Duration(rand.Intn(100)) * time.Millisecond)
 
-						props, err := cache.getItemProperties(ctx, id)
+						props, err := cache.getItemProperties(ctx, id, false)
 
 						test.expectedErr(suite.T(), err, clues.ToCore(err))
 						require.Equal(suite.T(), expected, props)
@@ -478,6 +480,57 @@ func (suite *URLCacheUnitSuite) TestGetItemProperties() {
 	}
 }
 
+func (suite *URLCacheUnitSuite) TestGetItemProperties_forceRefresh() {
+	var (
+		t           = suite.T()
+		deltaString = "delta"
+		driveID     = "drive1"
+	)
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	itemPager := &apiMock.DeltaPager[models.DriveItemable]{
+		ToReturn: []apiMock.PagerResult[models.DriveItemable]{
+			{
+				Values: []models.DriveItemable{
+					fileItem("1", "file1", "root", "root", "https://expired.com", false),
+				},
+				DeltaLink: &deltaString,
+			},
+			{
+				Values: []models.DriveItemable{
+					fileItem("1", "file1", "root", "root", "https://fresh.com", false),
+				},
+				DeltaLink: &deltaString,
+			},
+		},
+	}
+
+	cache, err := newURLCache(
+		driveID,
+		"",
+		1*time.Hour,
+		itemPager,
+		fault.New(true))
+	require.NoError(t, err, clues.ToCore(err))
+
+	props, err := cache.getItemProperties(ctx, "1", false)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, "https://expired.com", props.downloadURL)
+
+	// the refresh interval hasn't elapsed, so the cached url is returned.
+	props, err = cache.getItemProperties(ctx, "1", false)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, "https://expired.com", props.downloadURL)
+	assert.Equal(t, 1, cache.deltaQueryCount)
+
+	props, err = cache.getItemProperties(ctx, "1", true)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, "https://fresh.com", props.downloadURL)
+	assert.Equal(t, 2, cache.deltaQueryCount)
+}
+
 // Test needsRefresh
 func (suite *URLCacheUnitSuite) TestNeedsRefresh() {
 	driveID := "drive1"
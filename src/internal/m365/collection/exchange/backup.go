@@ -160,7 +160,7 @@ func populateCollections(
 
 		ictx = clues.Add(ictx, "previous_path", prevPath)
 
-		added, _, removed, newDelta, err := bh.itemEnumerator().
+		added, validModTimes, removed, newDelta, err := bh.itemEnumerator().
 			GetAddedAndRemovedItemIDs(
 				ictx,
 				qp.ProtectedResource.ID(),
@@ -182,6 +182,16 @@ func populateCollections(
 			newDelta = api.DeltaUpdate{Reset: true}
 		}
 
+		// without a delta token, only back up the items changed since
+		// the caller-provided time.
+		if len(prevDelta) == 0 && !ctrlOpts.IncrementalSince.IsZero() {
+			if validModTimes {
+				added = api.AddedSince(added, ctrlOpts.IncrementalSince)
+			} else {
+				logger.Ctx(ictx).Info("item mod times unavailable, ignoring incremental since")
+			}
+		}
+
 		if len(newDelta.URL) > 0 {
 			deltaURLs[cID] = newDelta.URL
 		} else if !newDelta.Reset {
@@ -386,3 +396,94 @@ func includeContainer(
 
 	return dirPath, loc, ok
 }
+
+// LiveContainer is a container that currently exists in the user's
+// mailbox, and can be backed up.
+type LiveContainer struct {
+	ID string
+	// Location is the container's path, using display names.  Selector
+	// folder scopes match against it.
+	Location *path.Builder
+}
+
+// LiveContainers produces the containers that currently exist in the
+// user's mailbox for the category.
+func LiveContainers(
+	ctx context.Context,
+	handlers map[path.CategoryType]backupHandler,
+	userID string,
+	cat path.CategoryType,
+	errs *fault.Bus,
+) ([]LiveContainer, error) {
+	handler, ok := handlers[cat]
+	if !ok {
+		return nil, clues.New("unsupported backup category type").WithClues(ctx)
+	}
+
+	rootFolder, cc := handler.NewContainerCache(userID)
+
+	if err := cc.Populate(ctx, errs, rootFolder); err != nil {
+		return nil, clues.Wrap(err, "populating container cache")
+	}
+
+	lcs := []LiveContainer{}
+
+	for _, c := range cc.Items() {
+		// containers without a path (e.g. the root mail folder) are
+		// never backed up.
+		if c.Path() == nil || len(c.Path().Elements()) == 0 {
+			continue
+		}
+
+		loc := c.Location()
+
+		// matches the location handling in includeContainer.
+		if loc == nil {
+			loc = c.Path()
+		} else if cat == path.ContactsCategory && len(loc.Elements()) == 0 {
+			loc = loc.Append(ptr.Val(c.GetDisplayName()))
+		}
+
+		lcs = append(lcs, LiveContainer{
+			ID:       ptr.Val(c.GetId()),
+			Location: loc,
+		})
+	}
+
+	return lcs, nil
+}
+
+// LiveContainerItemIDs enumerates the IDs of every item that currently
+// exists in the container.
+func LiveContainerItemIDs(
+	ctx context.Context,
+	handlers map[path.CategoryType]backupHandler,
+	userID string,
+	cat path.CategoryType,
+	containerID string,
+	immutableIDs bool,
+) (map[string]struct{}, error) {
+	handler, ok := handlers[cat]
+	if !ok {
+		return nil, clues.New("unsupported backup category type").WithClues(ctx)
+	}
+
+	added, _, _, _, err := handler.itemEnumerator().GetAddedAndRemovedItemIDs(
+		clues.Add(ctx, "container_id", containerID),
+		userID,
+		containerID,
+		"",
+		immutableIDs,
+		false)
+	if err != nil {
+		return nil, clues.Wrap(err, "enumerating container items")
+	}
+
+	ids := make(map[string]struct{}, len(added))
+
+	for id := range added {
+		ids[id] = struct{}{}
+	}
+
+	return ids, nil
+}
@@ -12,11 +12,13 @@ import (
 	"time"
 
 	"github.com/alcionai/clues"
+	"github.com/microsoft/kiota-abstractions-go/serialization"
 
 	"github.com/alcionai/corso/src/internal/data"
 	"github.com/alcionai/corso/src/internal/m365/graph"
 	"github.com/alcionai/corso/src/internal/m365/support"
 	"github.com/alcionai/corso/src/internal/observe"
+	"github.com/alcionai/corso/src/internal/stats"
 	"github.com/alcionai/corso/src/pkg/backup/details"
 	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/fault"
@@ -36,6 +38,20 @@ const (
 	numberOfRetries             = 4
 )
 
+// removedItemSentinelModTime is the modTime of removed items when the
+// backup uses the control.RemovedModTimeSentinel policy.
+var removedItemSentinelModTime = time.Unix(0, 0).UTC()
+
+// removedItemModTime produces the modTime of an item removed since the
+// previous backup, which has no modTime entry of its own.
+func removedItemModTime(policy control.RemovedModTimePolicy) time.Time {
+	if policy == control.RemovedModTimeSentinel {
+		return removedItemSentinelModTime
+	}
+
+	return time.Now().UTC()
+}
+
 func NewBaseCollection(
 	curr, prev path.Path,
 	location *path.Builder,
@@ -110,6 +126,7 @@ func updateStatus(
 	success int,
 	totalBytes int64,
 	folderPath string,
+	concurrency stats.ItemConcurrency,
 	err error,
 ) {
 	status := support.CreateStatus(
@@ -122,8 +139,12 @@ func updateStatus(
 			Bytes:     totalBytes,
 		},
 		folderPath)
+	status.Concurrency = []stats.ItemConcurrency{concurrency}
 
-	logger.Ctx(ctx).Debugw("done streaming items", "status", status.String())
+	logger.Ctx(ctx).Debugw(
+		"done streaming items",
+		"status", status.String(),
+		"item_concurrency", concurrency)
 
 	statusUpdater(status)
 }
@@ -134,14 +155,26 @@ func getItemAndInfo(
 	userID string,
 	id string,
 	useImmutableIDs bool,
+	skipAttachments bool,
 	parentPath string,
 ) ([]byte, *details.ExchangeInfo, error) {
-	item, info, err := getter.GetItem(
-		ctx,
-		userID,
-		id,
-		useImmutableIDs,
-		fault.New(true)) // temporary way to force a failFast error
+	var (
+		item serialization.Parsable
+		info *details.ExchangeInfo
+		err  error
+	)
+
+	if alig, ok := getter.(attachmentlessItemGetter); ok && skipAttachments {
+		item, info, err = alig.GetItemWithoutAttachments(ctx, userID, id, useImmutableIDs)
+	} else {
+		item, info, err = getter.GetItem(
+			ctx,
+			userID,
+			id,
+			useImmutableIDs,
+			fault.New(true)) // temporary way to force a failFast error
+	}
+
 	if err != nil {
 		return nil, nil, clues.Wrap(err, "fetching item").
 			WithClues(ctx).
@@ -227,8 +260,9 @@ func (col *prefetchCollection) streamItems(
 		wg          sync.WaitGroup
 		colProgress chan<- struct{}
 
-		user = col.user
-		log  = logger.Ctx(ctx).With(
+		user    = col.user
+		sampler = stats.NewConcurrencySampler(col.ctrl.Parallelism.ItemFetch)
+		log     = logger.Ctx(ctx).With(
 			"service", path.ExchangeService.String(),
 			"category", col.FullPath().Category().String())
 	)
@@ -242,6 +276,7 @@ func (col *prefetchCollection) streamItems(
 			int(success),
 			totalBytes,
 			col.FullPath().Folder(false),
+			sampler.Summary(col.FullPath().Folder(false)),
 			errs.Failure())
 	}()
 
@@ -253,22 +288,30 @@ func (col *prefetchCollection) streamItems(
 		defer close(colProgress)
 	}
 
-	semaphoreCh := make(chan struct{}, col.ctrl.Parallelism.ItemFetch)
-	defer close(semaphoreCh)
+	// the limiter lowers item fetch concurrency while graph is throttling us.
+	limiter, stopLimiter := graph.ItemFetchLimiter(ctx, col.ctrl.Parallelism.ItemFetch)
+	defer stopLimiter()
 
 	// delete all removed items
 	for id := range col.removed {
-		semaphoreCh <- struct{}{}
+		waitStart := time.Now()
 
+		if err := limiter.Acquire(ctx); err != nil {
+			errs.AddRecoverable(ctx, clues.Stack(err).Label(fault.LabelForceNoBackupCreation))
+			break
+		}
+
+		sampler.Acquired(time.Since(waitStart))
 		wg.Add(1)
 
 		go func(id string) {
 			defer wg.Done()
-			defer func() { <-semaphoreCh }()
+			defer limiter.Release()
+			defer sampler.Released()
 
 			stream <- &Item{
 				id:      id,
-				modTime: time.Now().UTC(), // removed items have no modTime entry.
+				modTime: removedItemModTime(col.ctrl.RemovedItemModTime),
 				deleted: true,
 			}
 
@@ -288,13 +331,20 @@ func (col *prefetchCollection) streamItems(
 			break
 		}
 
-		semaphoreCh <- struct{}{}
+		waitStart := time.Now()
+
+		if err := limiter.Acquire(ctx); err != nil {
+			errs.AddRecoverable(ctx, clues.Stack(err).Label(fault.LabelForceNoBackupCreation))
+			break
+		}
 
+		sampler.Acquired(time.Since(waitStart))
 		wg.Add(1)
 
 		go func(id string) {
 			defer wg.Done()
-			defer func() { <-semaphoreCh }()
+			defer limiter.Release()
+			defer sampler.Released()
 
 			itemData, info, err := getItemAndInfo(
 				ctx,
@@ -302,6 +352,7 @@ func (col *prefetchCollection) streamItems(
 				user,
 				id,
 				col.ctrl.ToggleFeatures.ExchangeImmutableIDs,
+				col.ctrl.SkipMailAttachments,
 				parentPath)
 			if err != nil {
 				// Don't report errors for deleted items as there's no way for us to
@@ -345,8 +396,8 @@ type Item struct {
 	// some structured type in here (serialization to []byte can be done in `Read`)
 	message []byte
 	info    *details.ExchangeInfo // temporary change to bring populate function into directory
-	// TODO(ashmrtn): Can probably eventually be sourced from info as there's a
-	// request to provide modtime in ItemInfo structs.
+	// added items take their modTime from info.Modified.  Removed items have
+	// no info, and follow the backup's control.RemovedModTimePolicy.
 	modTime time.Time
 
 	// true if the item was marked by graph as deleted.
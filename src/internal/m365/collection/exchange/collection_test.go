@@ -2,22 +2,30 @@ package exchange
 
 import (
 	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/alcionai/clues"
+	"github.com/microsoft/kiota-abstractions-go/serialization"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/alcionai/corso/src/internal/common/ptr"
 	"github.com/alcionai/corso/src/internal/data"
 	"github.com/alcionai/corso/src/internal/m365/collection/exchange/mock"
 	"github.com/alcionai/corso/src/internal/m365/graph"
 	"github.com/alcionai/corso/src/internal/m365/support"
 	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/details"
 	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
 )
 
 type CollectionUnitSuite struct {
@@ -192,6 +200,133 @@ func (suite *CollectionUnitSuite) TestGetItemWithRetries() {
 	}
 }
 
+// mailGetter produces a message with a file attachment, unless the
+// attachments get skipped.
+type mailGetter struct {
+	body, attachment string
+
+	getCount            int
+	withoutAttachsCount int
+}
+
+func (mg *mailGetter) message() models.Messageable {
+	body := models.NewItemBody()
+	body.SetContent(ptr.To(mg.body))
+
+	msg := models.NewMessage()
+	msg.SetId(ptr.To("id"))
+	msg.SetBody(body)
+	msg.SetHasAttachments(ptr.To(true))
+
+	return msg
+}
+
+func (mg *mailGetter) GetItem(
+	context.Context,
+	string, string,
+	bool,
+	*fault.Bus,
+) (serialization.Parsable, *details.ExchangeInfo, error) {
+	mg.getCount++
+
+	att := models.NewFileAttachment()
+	att.SetName(ptr.To("file.txt"))
+	att.SetContentBytes([]byte(mg.attachment))
+	att.SetSize(ptr.To(int32(len(mg.attachment))))
+
+	msg := mg.message()
+	msg.SetAttachments([]models.Attachmentable{att})
+
+	return msg, api.MailInfo(msg, int64(len(mg.body)+len(mg.attachment))), nil
+}
+
+func (mg *mailGetter) GetItemWithoutAttachments(
+	context.Context,
+	string, string,
+	bool,
+) (serialization.Parsable, *details.ExchangeInfo, error) {
+	mg.withoutAttachsCount++
+
+	msg := mg.message()
+
+	return msg, api.MailInfo(msg, int64(len(mg.body))), nil
+}
+
+func (mg *mailGetter) Serialize(
+	ctx context.Context,
+	item serialization.Parsable,
+	user, itemID string,
+) ([]byte, error) {
+	return api.Mail{}.Serialize(ctx, item, user, itemID)
+}
+
+func (suite *CollectionUnitSuite) TestGetItemAndInfo_skipMailAttachments() {
+	const (
+		body       = "message body"
+		attachment = "attachment-content"
+	)
+
+	// attachment bytes get base64 encoded in the serialized message.
+	encodedAttachment := base64.StdEncoding.EncodeToString([]byte(attachment))
+
+	table := []struct {
+		name               string
+		skipAttachments    bool
+		expectGetCalls     int
+		expectWithoutCalls int
+		expectAttachment   assert.BoolAssertionFunc
+		expectSize         int64
+	}{
+		{
+			name:             "with attachments",
+			expectGetCalls:   1,
+			expectAttachment: assert.True,
+			expectSize:       int64(len(body) + len(attachment)),
+		},
+		{
+			name:               "skip attachments",
+			skipAttachments:    true,
+			expectWithoutCalls: 1,
+			expectAttachment:   assert.False,
+			expectSize:         int64(len(body)),
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			mg := &mailGetter{body: body, attachment: attachment}
+
+			bs, info, err := getItemAndInfo(ctx, mg, "user", "id", false, test.skipAttachments, "parent")
+			require.NoError(t, err, clues.ToCore(err))
+
+			assert.Equal(t, test.expectGetCalls, mg.getCount, "get item calls")
+			assert.Equal(t, test.expectWithoutCalls, mg.withoutAttachsCount, "get item without attachments calls")
+			test.expectAttachment(t, bytes.Contains(bs, []byte(encodedAttachment)), "serialized attachment")
+			assert.Contains(t, string(bs), body)
+			assert.Equal(t, test.expectSize, info.Size)
+			assert.Equal(t, "parent", info.ParentPath)
+		})
+	}
+}
+
+func (suite *CollectionUnitSuite) TestGetItemAndInfo_skipAttachmentsUnsupported() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	items := mock.DefaultItemGetSerialize()
+
+	_, _, err := getItemAndInfo(ctx, items, "user", "id", false, true, "parent")
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, 1, items.GetCount, "get item calls")
+}
+
 func (suite *CollectionUnitSuite) TestCollection_streamItems() {
 	var (
 		t             = suite.T()
@@ -296,3 +431,205 @@ func (suite *CollectionUnitSuite) TestCollection_streamItems() {
 		})
 	}
 }
+
+// modifiedItemGetter produces items last modified at a fixed time.
+type modifiedItemGetter struct {
+	mock.ItemGetSerialize
+	modified time.Time
+}
+
+func (mig *modifiedItemGetter) GetItem(
+	context.Context,
+	string, string,
+	bool,
+	*fault.Bus,
+) (serialization.Parsable, *details.ExchangeInfo, error) {
+	return nil, &details.ExchangeInfo{Modified: mig.modified}, nil
+}
+
+func (mig *modifiedItemGetter) Serialize(
+	context.Context,
+	serialization.Parsable,
+	string, string,
+) ([]byte, error) {
+	return nil, nil
+}
+
+func (suite *CollectionUnitSuite) TestCollection_streamItems_modTime() {
+	var (
+		t             = suite.T()
+		modified      = time.Now().Add(-time.Hour).UTC()
+		statusUpdater = func(*support.ControllerOperationStatus) {}
+	)
+
+	fullPath, err := path.Build("t", "pr", path.ExchangeService, path.EmailCategory, false, "fnords", "smarf")
+	require.NoError(t, err, clues.ToCore(err))
+
+	locPath, err := path.Build("t", "pr", path.ExchangeService, path.EmailCategory, false, "fnords", "smarf")
+	require.NoError(t, err, clues.ToCore(err))
+
+	table := []struct {
+		name          string
+		policy        control.RemovedModTimePolicy
+		expectRemoved func(t *testing.T, start, modTime time.Time)
+	}{
+		{
+			name:   "removed items use now",
+			policy: control.RemovedModTimeNow,
+			expectRemoved: func(t *testing.T, start, modTime time.Time) {
+				assert.False(t, modTime.Before(start), "removed item mod time")
+			},
+		},
+		{
+			name:   "removed items use sentinel",
+			policy: control.RemovedModTimeSentinel,
+			expectRemoved: func(t *testing.T, _, modTime time.Time) {
+				assert.Equal(t, removedItemSentinelModTime, modTime, "removed item mod time")
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			var (
+				t     = suite.T()
+				errs  = fault.New(true)
+				opts  = control.DefaultOptions()
+				start = time.Now().UTC()
+			)
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			opts.RemovedItemModTime = test.policy
+
+			col := NewCollection(
+				NewBaseCollection(
+					fullPath,
+					nil,
+					locPath.ToBuilder(),
+					opts,
+					false),
+				"",
+				&modifiedItemGetter{modified: modified},
+				statusUpdater)
+
+			col.added = map[string]struct{}{"fisher": {}, "flannigan": {}}
+			col.removed = map[string]struct{}{"princess": {}, "poppy": {}}
+
+			var itemCount int
+
+			for item := range col.Items(ctx, errs) {
+				itemCount++
+
+				dimt, ok := item.(data.ItemModTime)
+				require.True(t, ok, "item implements data.ItemModTime")
+
+				if item.Deleted() {
+					test.expectRemoved(t, start, dimt.ModTime())
+					continue
+				}
+
+				// added items always use the modified time of the item.
+				assert.Equal(t, modified, dimt.ModTime(), "added item mod time")
+			}
+
+			assert.NoError(t, errs.Failure())
+			assert.Equal(t, 4, itemCount, "should see all expected items")
+		})
+	}
+}
+
+// slowItemGetter holds each fetch open long enough for concurrent
+// fetches to overlap.
+type slowItemGetter struct {
+	mock.ItemGetSerialize
+	delay time.Duration
+}
+
+func (sig *slowItemGetter) GetItem(
+	context.Context,
+	string, string,
+	bool,
+	*fault.Bus,
+) (serialization.Parsable, *details.ExchangeInfo, error) {
+	time.Sleep(sig.delay)
+	return nil, &details.ExchangeInfo{}, nil
+}
+
+func (sig *slowItemGetter) Serialize(
+	context.Context,
+	serialization.Parsable,
+	string, string,
+) ([]byte, error) {
+	return nil, nil
+}
+
+func (suite *CollectionUnitSuite) TestCollection_streamItems_concurrency() {
+	fullPath, err := path.Build("t", "pr", path.ExchangeService, path.EmailCategory, false, "fnords", "smarf")
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	added := map[string]struct{}{}
+	for i := 0; i < 20; i++ {
+		added[fmt.Sprintf("item-%d", i)] = struct{}{}
+	}
+
+	table := []struct {
+		name  string
+		limit int
+	}{
+		{
+			name:  "serial",
+			limit: 1,
+		},
+		{
+			name:  "limited",
+			limit: 3,
+		},
+		{
+			name:  "limit above item count",
+			limit: 30,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			var (
+				t        = suite.T()
+				errs     = fault.New(true)
+				statusCh = make(chan *support.ControllerOperationStatus, 1)
+				opts     = control.DefaultOptions()
+			)
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			opts.Parallelism.ItemFetch = test.limit
+
+			col := NewCollection(
+				NewBaseCollection(
+					fullPath,
+					nil,
+					fullPath.ToBuilder(),
+					opts,
+					false),
+				"",
+				&slowItemGetter{delay: 5 * time.Millisecond},
+				func(s *support.ControllerOperationStatus) { statusCh <- s })
+
+			col.added = added
+
+			for range col.Items(ctx, errs) {
+			}
+
+			require.NoError(t, errs.Failure(), clues.ToCore(errs.Failure()))
+
+			status := <-statusCh
+			require.Len(t, status.Concurrency, 1)
+
+			ic := status.Concurrency[0]
+			assert.Equal(t, test.limit, ic.Limit)
+			assert.Equal(t, int64(len(added)), ic.Acquires)
+			assert.Positive(t, ic.MaxInFlight)
+			assert.LessOrEqual(t, ic.MaxInFlight, int64(test.limit), "max in-flight exceeds the item fetch limit")
+		})
+	}
+}
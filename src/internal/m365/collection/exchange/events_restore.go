@@ -3,10 +3,12 @@ package exchange
 import (
 	"bytes"
 	"context"
+	"time"
 
 	"github.com/alcionai/clues"
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
 
+	"github.com/alcionai/corso/src/internal/common/dttm"
 	"github.com/alcionai/corso/src/internal/common/ptr"
 	"github.com/alcionai/corso/src/internal/m365/graph"
 	"github.com/alcionai/corso/src/pkg/backup/details"
@@ -21,14 +23,17 @@ import (
 var _ itemRestorer = &eventRestoreHandler{}
 
 type eventRestoreHandler struct {
-	ac api.Events
+	ac        api.Events
+	dateRange eventDateRange
 }
 
 func newEventRestoreHandler(
 	ac api.Client,
+	dateRange eventDateRange,
 ) eventRestoreHandler {
 	return eventRestoreHandler{
-		ac: ac.Events(),
+		ac:        ac.Events(),
+		dateRange: dateRange,
 	}
 }
 
@@ -83,6 +88,7 @@ func (h eventRestoreHandler) restore(
 		ctx,
 		h.ac,
 		body,
+		h.dateRange,
 		userID, destinationID,
 		collisionKeyToItemID,
 		collisionPolicy,
@@ -99,6 +105,7 @@ func restoreEvent(
 	ctx context.Context,
 	er eventRestorer,
 	body []byte,
+	dateRange eventDateRange,
 	userID, destinationID string,
 	collisionKeyToItemID map[string]string,
 	collisionPolicy control.CollisionPolicy,
@@ -112,6 +119,11 @@ func restoreEvent(
 
 	ctx = clues.Add(ctx, "item_id", ptr.Val(event.GetId()))
 
+	if !dateRange.includes(event) {
+		ctr.Inc(count.EventOutsideRestoreRange)
+		return nil, clues.Stack(errEventOutsideRestoreRange).WithClues(ctx)
+	}
+
 	var (
 		collisionKey         = api.EventCollisionKey(event)
 		collisionID          string
@@ -204,6 +216,99 @@ func restoreEvent(
 	return info, nil
 }
 
+var errEventOutsideRestoreRange = clues.New("event starts outside the restore date range")
+
+// eventDateRange limits event restores to events starting within the
+// inclusive range.  A nil bound leaves that end of the range open.
+type eventDateRange struct {
+	after, before *time.Time
+}
+
+// includes returns true if the event should be restored.  Single events
+// are included when their start falls within the range.  Recurring series
+// are included when they overlap the range: the series starts on or before
+// the end of the range, and doesn't end before its start.  Events with an
+// unreadable start are always included.
+func (dr eventDateRange) includes(event models.Eventable) bool {
+	if dr.after == nil && dr.before == nil {
+		return true
+	}
+
+	start, ok := eventStart(event)
+	if !ok {
+		return true
+	}
+
+	if dr.before != nil && start.After(*dr.before) {
+		return false
+	}
+
+	if dr.after == nil {
+		return true
+	}
+
+	if event.GetRecurrence() == nil {
+		return !start.Before(*dr.after)
+	}
+
+	end, ok := seriesEnd(event.GetRecurrence())
+
+	return !ok || !end.Before(*dr.after)
+}
+
+// eventStart parses the event's start time in the event's time zone.  Time
+// zones that can't be loaded, such as windows time zone names, fall back to
+// UTC, which graph uses for event times unless asked otherwise.
+func eventStart(event models.Eventable) (time.Time, bool) {
+	start := event.GetStart()
+	if start == nil || len(ptr.Val(start.GetDateTime())) == 0 {
+		return time.Time{}, false
+	}
+
+	loc := time.UTC
+
+	if tz := ptr.Val(start.GetTimeZone()); len(tz) > 0 {
+		if l, err := time.LoadLocation(tz); err == nil {
+			loc = l
+		}
+	}
+
+	dt := ptr.Val(start.GetDateTime())
+
+	t, err := time.ParseInLocation(string(dttm.M365DateTimeTimeZone), dt, loc)
+	if err == nil {
+		return t.UTC(), true
+	}
+
+	// fall back to formats that carry their own offset.
+	t, err = dttm.ParseTime(dt)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return t, true
+}
+
+// seriesEnd returns the end of the last day of the recurring series.  Returns
+// false if the series has no end date, such as series that never end, or
+// that end after a number of occurrences.
+func seriesEnd(recurrence models.PatternedRecurrenceable) (time.Time, bool) {
+	rng := recurrence.GetRangeEscaped()
+	if rng == nil ||
+		rng.GetTypeEscaped() == nil ||
+		*rng.GetTypeEscaped() != models.ENDDATE_RECURRENCERANGETYPE ||
+		rng.GetEndDate() == nil {
+		return time.Time{}, false
+	}
+
+	end, err := time.Parse(string(dttm.DateOnly), rng.GetEndDate().String())
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return end.AddDate(0, 0, 1).Add(-time.Nanosecond), true
+}
+
 type attachmentGetDeletePoster interface {
 	attachmentPoster
 	GetAttachments(
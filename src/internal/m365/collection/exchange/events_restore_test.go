@@ -2,7 +2,9 @@ package exchange
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/alcionai/clues"
 	"github.com/google/uuid"
@@ -11,6 +13,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/alcionai/corso/src/internal/common/dttm"
+	"github.com/alcionai/corso/src/internal/common/ptr"
 	"github.com/alcionai/corso/src/internal/m365/graph"
 	"github.com/alcionai/corso/src/internal/m365/service/exchange/mock"
 	"github.com/alcionai/corso/src/internal/tester"
@@ -100,6 +104,274 @@ func (m *eventRestoreMock) PatchItem(
 // tests
 // ---------------------------------------------------------------------------
 
+type EventsRestoreUnitSuite struct {
+	tester.Suite
+}
+
+func TestEventsRestoreUnitSuite(t *testing.T) {
+	suite.Run(t, &EventsRestoreUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+// eventStartingAt produces a serialized event starting at the given time.
+// If recurrence is populated, the event is the master of a recurring series.
+func eventStartingAt(t *testing.T, start time.Time, recurrence string) models.Eventable {
+	if len(recurrence) == 0 {
+		recurrence = mock.NoRecurrence
+	}
+
+	body := mock.EventWith(
+		"organizer@foo.com", "subject",
+		"body", "preview",
+		mock.NoOriginalStartDate,
+		dttm.Format(start),
+		dttm.Format(start.Add(30*time.Minute)),
+		recurrence,
+		mock.NoAttendees,
+		mock.NoAttachments,
+		mock.NoCancelledOccurrences,
+		mock.NoExceptionOccurrences)
+
+	event, err := api.BytesToEventable(body)
+	require.NoError(t, err, clues.ToCore(err))
+
+	return event
+}
+
+// weeklyRecurrence produces a weekly series beginning on start.  A zero
+// end produces a series that never ends.
+func weeklyRecurrence(start, end time.Time) string {
+	rangeType := "noEnd"
+	endDate := "0001-01-01"
+
+	if !end.IsZero() {
+		rangeType = "endDate"
+		endDate = dttm.FormatTo(end, dttm.DateOnly)
+	}
+
+	return fmt.Sprintf(`{
+		"pattern": {
+			"type": "weekly",
+			"interval": 1,
+			"daysOfWeek": ["monday"],
+			"firstDayOfWeek": "sunday",
+			"index": "first"
+		},
+		"range": {
+			"type": "%s",
+			"startDate": "%s",
+			"endDate": "%s",
+			"numberOfOccurrences": 0,
+			"recurrenceTimeZone": "UTC"
+		}
+	}`, rangeType, dttm.FormatTo(start, dttm.DateOnly), endDate)
+}
+
+func (suite *EventsRestoreUnitSuite) TestEventDateRange_includes() {
+	var (
+		after  = time.Date(2023, 8, 7, 0, 0, 0, 0, time.UTC)
+		before = time.Date(2023, 8, 13, 23, 59, 59, 0, time.UTC)
+		week   = eventDateRange{after: &after, before: &before}
+
+		earlier = time.Date(2023, 7, 3, 9, 0, 0, 0, time.UTC)
+		within  = time.Date(2023, 8, 9, 9, 0, 0, 0, time.UTC)
+		later   = time.Date(2023, 9, 4, 9, 0, 0, 0, time.UTC)
+	)
+
+	table := []struct {
+		name       string
+		dateRange  eventDateRange
+		start      time.Time
+		recurrence string
+		expect     assert.BoolAssertionFunc
+	}{
+		{
+			name:      "no range",
+			dateRange: eventDateRange{},
+			start:     earlier,
+			expect:    assert.True,
+		},
+		{
+			name:      "starts before range",
+			dateRange: week,
+			start:     earlier,
+			expect:    assert.False,
+		},
+		{
+			name:      "starts within range",
+			dateRange: week,
+			start:     within,
+			expect:    assert.True,
+		},
+		{
+			name:      "starts at range start",
+			dateRange: week,
+			start:     after,
+			expect:    assert.True,
+		},
+		{
+			name:      "starts at range end",
+			dateRange: week,
+			start:     before,
+			expect:    assert.True,
+		},
+		{
+			name:      "starts after range",
+			dateRange: week,
+			start:     later,
+			expect:    assert.False,
+		},
+		{
+			name:      "open start",
+			dateRange: eventDateRange{before: &before},
+			start:     earlier,
+			expect:    assert.True,
+		},
+		{
+			name:      "open end",
+			dateRange: eventDateRange{after: &after},
+			start:     later,
+			expect:    assert.True,
+		},
+		{
+			name:       "series without end started before range",
+			dateRange:  week,
+			start:      earlier,
+			recurrence: weeklyRecurrence(earlier, time.Time{}),
+			expect:     assert.True,
+		},
+		{
+			name:       "series ending within range",
+			dateRange:  week,
+			start:      earlier,
+			recurrence: weeklyRecurrence(earlier, within),
+			expect:     assert.True,
+		},
+		{
+			name:       "series ending on range start",
+			dateRange:  week,
+			start:      earlier,
+			recurrence: weeklyRecurrence(earlier, after),
+			expect:     assert.True,
+		},
+		{
+			name:       "series ending before range",
+			dateRange:  week,
+			start:      earlier,
+			recurrence: weeklyRecurrence(earlier, after.AddDate(0, 0, -1)),
+			expect:     assert.False,
+		},
+		{
+			name:       "series starting after range",
+			dateRange:  week,
+			start:      later,
+			recurrence: weeklyRecurrence(later, time.Time{}),
+			expect:     assert.False,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			event := eventStartingAt(t, test.start, test.recurrence)
+			test.expect(t, test.dateRange.includes(event))
+		})
+	}
+}
+
+func (suite *EventsRestoreUnitSuite) TestEventStart() {
+	eventAt := func(dateTime, timeZone string) models.Eventable {
+		dttz := models.NewDateTimeTimeZone()
+		dttz.SetDateTime(ptr.To(dateTime))
+		dttz.SetTimeZone(ptr.To(timeZone))
+
+		event := models.NewEvent()
+		event.SetStart(dttz)
+
+		return event
+	}
+
+	table := []struct {
+		name     string
+		event    models.Eventable
+		expect   time.Time
+		expectOK assert.BoolAssertionFunc
+	}{
+		{
+			name:     "utc",
+			event:    eventAt("2023-08-09T09:00:00.0000000", "UTC"),
+			expect:   time.Date(2023, 8, 9, 9, 0, 0, 0, time.UTC),
+			expectOK: assert.True,
+		},
+		{
+			name:     "iana time zone",
+			event:    eventAt("2023-08-09T09:00:00.0000000", "America/New_York"),
+			expect:   time.Date(2023, 8, 9, 13, 0, 0, 0, time.UTC),
+			expectOK: assert.True,
+		},
+		{
+			name:     "unknown time zone falls back to utc",
+			event:    eventAt("2023-08-09T09:00:00.0000000", "Eastern Standard Time"),
+			expect:   time.Date(2023, 8, 9, 9, 0, 0, 0, time.UTC),
+			expectOK: assert.True,
+		},
+		{
+			name:     "offset in date time",
+			event:    eventAt("2023-08-09T09:00:00+02:00", ""),
+			expect:   time.Date(2023, 8, 9, 7, 0, 0, 0, time.UTC),
+			expectOK: assert.True,
+		},
+		{
+			name:     "no start",
+			event:    models.NewEvent(),
+			expectOK: assert.False,
+		},
+		{
+			name:     "unreadable start",
+			event:    eventAt("not a time", "UTC"),
+			expectOK: assert.False,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			start, ok := eventStart(test.event)
+			test.expectOK(t, ok)
+			assert.Equal(t, test.expect, start)
+		})
+	}
+}
+
+func (suite *EventsRestoreUnitSuite) TestRestoreEvent_outsideDateRange() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		after  = time.Date(2023, 8, 7, 0, 0, 0, 0, time.UTC)
+		before = time.Date(2023, 8, 13, 0, 0, 0, 0, time.UTC)
+		ctr    = count.New()
+		m      = &eventRestoreMock{}
+	)
+
+	_, err := restoreEvent(
+		ctx,
+		m,
+		mock.EventBytes("subject"),
+		eventDateRange{after: &after, before: &before},
+		"user",
+		"destination",
+		map[string]string{},
+		control.Copy,
+		fault.New(true),
+		ctr)
+	assert.ErrorIs(t, err, errEventOutsideRestoreRange, clues.ToCore(err))
+	assert.False(t, m.calledPost, "event posted")
+	assert.Equal(t, int64(1), ctr.Get(count.EventOutsideRestoreRange), "events outside range")
+	assert.Zero(t, ctr.Get(count.NewItemCreated), "new items")
+}
+
 type EventsRestoreIntgSuite struct {
 	tester.Suite
 	its intgTesterSetup
@@ -121,7 +393,7 @@ func (suite *EventsRestoreIntgSuite) SetupSuite() {
 func (suite *EventsRestoreIntgSuite) TestCreateContainerDestination() {
 	runCreateDestinationTest(
 		suite.T(),
-		newEventRestoreHandler(suite.its.ac),
+		newEventRestoreHandler(suite.its.ac, eventDateRange{}),
 		path.EventsCategory,
 		suite.its.creds.AzureTenantID,
 		suite.its.userID,
@@ -265,6 +537,7 @@ func (suite *EventsRestoreIntgSuite) TestRestoreEvent() {
 				ctx,
 				test.apiMock,
 				body,
+				eventDateRange{},
 				suite.its.userID,
 				"destination",
 				test.collisionMap,
@@ -0,0 +1,461 @@
+package exchange
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/export"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+const (
+	// maildirCurDir is the maildir subdirectory which holds messages that
+	// have been seen by a mail client.  Exported messages are always
+	// written here, since they aren't new mail to the client.
+	maildirCurDir = "cur"
+	// maildirNewDir and maildirTmpDir hold newly delivered messages, and
+	// messages in the middle of delivery.  Exports leave them empty, but
+	// mail clients only accept a maildir that has all three subdirectories.
+	maildirNewDir = "new"
+	maildirTmpDir = "tmp"
+
+	// maildirHost stands in for the hostname of the delivering machine
+	// within maildir filenames.
+	maildirHost = "corso"
+	emlExt      = ".eml"
+)
+
+// NewExportCollection produces an export collection for the email
+// collections.  Messages are exported as RFC 5322 (.eml) files, or, for
+// the maildir format, as maildir entries.  Maildir exports treat baseDir
+// as the root of the maildir, writing messages to its cur folder and
+// creating its empty new and tmp folders.  Maildir names contain a ':',
+// so the collection swaps the default windows sanitizer for the posix one
+// when exporting as maildir.
+func NewExportCollection(
+	baseDir string,
+	backingCollections []data.RestoreCollection,
+	backupVersion int,
+	cec control.ExportConfig,
+) export.Collectioner {
+	var dirs []string
+
+	if cec.Format == control.MaildirFormat {
+		if cec.NameSanitizer == control.DefaultNameSanitizer {
+			cec.NameSanitizer = control.POSIXNameSanitizer
+		}
+
+		dirs = []string{
+			path.Join(baseDir, maildirNewDir),
+			path.Join(baseDir, maildirTmpDir),
+		}
+		baseDir = path.Join(baseDir, maildirCurDir)
+	}
+
+	return export.BaseCollection{
+		BaseDir:           baseDir,
+		Dirs:              dirs,
+		BackingCollection: backingCollections,
+		BackupVersion:     backupVersion,
+		Cfg:               cec,
+		Stream:            streamItems,
+	}
+}
+
+// streamItems streams the items in the backingCollection into the export stream chan
+func streamItems(
+	ctx context.Context,
+	drc []data.RestoreCollection,
+	backupVersion int,
+	cec control.ExportConfig,
+	ch chan<- export.Item,
+) {
+	defer close(ch)
+
+	errs := fault.New(false)
+
+	for _, rc := range drc {
+		for item := range rc.Items(ctx, errs) {
+			name, body, err := formatMessage(cec, item.ID(), item.ToReader())
+			if err != nil {
+				ch <- export.Item{
+					ID:    item.ID(),
+					Error: export.ItemError(err, rc.FullPath(), item.ID()),
+				}
+			} else {
+				ch <- export.Item{
+					ID:   item.ID(),
+					Name: name,
+					Body: body,
+				}
+			}
+		}
+
+		items, recovered := errs.ItemsAndRecovered()
+
+		// Return all the items that we failed to source from the persistence layer
+		for _, item := range items {
+			ch <- export.Item{
+				ID:    item.ID,
+				Error: export.ItemError(&item, rc.FullPath(), item.ID),
+			}
+		}
+
+		for _, err := range recovered {
+			ch <- export.Item{
+				Error: export.ItemError(err, rc.FullPath(), ""),
+			}
+		}
+	}
+}
+
+// formatMessage produces the name and body of the exported message.
+func formatMessage(
+	cec control.ExportConfig,
+	itemID string,
+	rc io.ReadCloser,
+) (string, io.ReadCloser, error) {
+	if cec.Format == control.JSONFormat {
+		return itemID + ".json", rc, nil
+	}
+
+	defer rc.Close()
+
+	bs, err := io.ReadAll(rc)
+	if err != nil {
+		return "", nil, clues.Wrap(err, "reading item bytes")
+	}
+
+	cfb, err := api.CreateFromBytes(bs, models.CreateMessageFromDiscriminatorValue)
+	if err != nil {
+		return "", nil, clues.Wrap(err, "deserializing bytes to message")
+	}
+
+	msg, ok := cfb.(models.Messageable)
+	if !ok {
+		return "", nil, clues.New("expected deserialized item to implement models.Messageable")
+	}
+
+	eml, err := messageToEML(msg)
+	if err != nil {
+		return "", nil, clues.Stack(err)
+	}
+
+	name := itemID + emlExt
+	if cec.Format == control.MaildirFormat {
+		name = maildirName(itemID, msg)
+	}
+
+	return name, io.NopCloser(bytes.NewReader(eml)), nil
+}
+
+// maildirName produces a maildir filename for the message, in the form
+// "<time>.<unique>.<host>:2,<flags>".  The time is when the message was
+// received, the unique part is derived from the item ID so that repeated
+// exports produce the same names, and the flags record the message's
+// draft, flagged, and read states.
+func maildirName(itemID string, msg models.Messageable) string {
+	sum := sha256.Sum256([]byte(itemID))
+
+	// flags must be in ascii order.
+	var flags string
+
+	if ptr.Val(msg.GetIsDraft()) {
+		flags += "D"
+	}
+
+	if f := msg.GetFlag(); f != nil &&
+		ptr.Val(f.GetFlagStatus()) == models.FLAGGED_FOLLOWUPFLAGSTATUS {
+		flags += "F"
+	}
+
+	if ptr.Val(msg.GetIsRead()) {
+		flags += "S"
+	}
+
+	return fmt.Sprintf(
+		"%d.%s.%s:2,%s",
+		messageTime(msg).Unix(),
+		hex.EncodeToString(sum[:8]),
+		maildirHost,
+		flags)
+}
+
+// messageTime returns the time the message was received, falling back
+// to when it was sent or created.
+func messageTime(msg models.Messageable) time.Time {
+	return firstTime(
+		msg.GetReceivedDateTime(),
+		msg.GetSentDateTime(),
+		msg.GetCreatedDateTime())
+}
+
+// sentTime returns the time the message was sent, which rfc 5322 uses as
+// the origination date, falling back to when it was received or created.
+func sentTime(msg models.Messageable) time.Time {
+	return firstTime(
+		msg.GetSentDateTime(),
+		msg.GetReceivedDateTime(),
+		msg.GetCreatedDateTime())
+}
+
+// firstTime returns the first of the times that is set.
+func firstTime(ts ...*time.Time) time.Time {
+	for _, t := range ts {
+		if t != nil && !t.IsZero() {
+			return *t
+		}
+	}
+
+	return time.Time{}
+}
+
+// ---------------------------------------------------------------------------
+// RFC 5322
+// ---------------------------------------------------------------------------
+
+// messageToEML serializes the message into an RFC 5322 message.  File
+// attachments are included as base64 encoded parts of a multipart/mixed
+// body.  Other attachment types (items and references) are skipped.
+func messageToEML(msg models.Messageable) ([]byte, error) {
+	var (
+		buf  = &bytes.Buffer{}
+		hdrs = messageHeaders(msg)
+	)
+
+	bodyType, bodyContent := "text/plain", ""
+
+	if b := msg.GetBody(); b != nil {
+		bodyContent = ptr.Val(b.GetContent())
+
+		if ptr.Val(b.GetContentType()) == models.HTML_BODYTYPE {
+			bodyType = "text/html"
+		}
+	}
+
+	files := fileAttachments(msg)
+
+	if len(files) == 0 {
+		hdrs.Set("Content-Type", mime.FormatMediaType(bodyType, map[string]string{"charset": "utf-8"}))
+		hdrs.Set("Content-Transfer-Encoding", "quoted-printable")
+		writeHeaders(buf, hdrs)
+
+		if err := writeQuotedPrintable(buf, bodyContent); err != nil {
+			return nil, clues.Wrap(err, "writing message body")
+		}
+
+		return buf.Bytes(), nil
+	}
+
+	mw := multipart.NewWriter(buf)
+
+	hdrs.Set("Content-Type", mime.FormatMediaType("multipart/mixed", map[string]string{"boundary": mw.Boundary()}))
+	writeHeaders(buf, hdrs)
+
+	pw, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {mime.FormatMediaType(bodyType, map[string]string{"charset": "utf-8"})},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return nil, clues.Wrap(err, "creating body part")
+	}
+
+	if err := writeQuotedPrintable(pw, bodyContent); err != nil {
+		return nil, clues.Wrap(err, "writing message body")
+	}
+
+	for _, fa := range files {
+		if err := writeAttachment(mw, fa); err != nil {
+			return nil, clues.Wrap(err, "writing attachment").With("attachment_id", ptr.Val(fa.GetId()))
+		}
+	}
+
+	if err := mw.Close(); err != nil {
+		return nil, clues.Wrap(err, "closing multipart body")
+	}
+
+	return buf.Bytes(), nil
+}
+
+func messageHeaders(msg models.Messageable) textproto.MIMEHeader {
+	hdrs := textproto.MIMEHeader{}
+
+	if id := ptr.Val(msg.GetInternetMessageId()); len(id) > 0 {
+		hdrs.Set("Message-ID", id)
+	}
+
+	if t := sentTime(msg); !t.IsZero() {
+		hdrs.Set("Date", t.Format(time.RFC1123Z))
+	}
+
+	if from := formatRecipients(msg.GetFrom()); len(from) > 0 {
+		hdrs.Set("From", from)
+	}
+
+	if to := formatRecipients(msg.GetToRecipients()...); len(to) > 0 {
+		hdrs.Set("To", to)
+	}
+
+	if cc := formatRecipients(msg.GetCcRecipients()...); len(cc) > 0 {
+		hdrs.Set("Cc", cc)
+	}
+
+	if bcc := formatRecipients(msg.GetBccRecipients()...); len(bcc) > 0 {
+		hdrs.Set("Bcc", bcc)
+	}
+
+	if rt := formatRecipients(msg.GetReplyTo()...); len(rt) > 0 {
+		hdrs.Set("Reply-To", rt)
+	}
+
+	hdrs.Set("Subject", mime.QEncoding.Encode("utf-8", ptr.Val(msg.GetSubject())))
+	hdrs.Set("MIME-Version", "1.0")
+
+	return hdrs
+}
+
+// writeHeaders writes the headers in a stable order, followed by the
+// blank line which separates them from the body.
+func writeHeaders(w io.Writer, hdrs textproto.MIMEHeader) {
+	keys := make([]string, 0, len(hdrs))
+	for k := range hdrs {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		for _, v := range hdrs[k] {
+			fmt.Fprintf(w, "%s: %s\r\n", k, v)
+		}
+	}
+
+	fmt.Fprint(w, "\r\n")
+}
+
+func formatRecipients(rs ...models.Recipientable) string {
+	addrs := make([]string, 0, len(rs))
+
+	for _, r := range rs {
+		if r == nil || r.GetEmailAddress() == nil {
+			continue
+		}
+
+		ea := r.GetEmailAddress()
+
+		addr := mail.Address{
+			Name:    ptr.Val(ea.GetName()),
+			Address: ptr.Val(ea.GetAddress()),
+		}
+
+		if len(addr.Address) == 0 {
+			continue
+		}
+
+		addrs = append(addrs, addr.String())
+	}
+
+	return strings.Join(addrs, ", ")
+}
+
+func writeQuotedPrintable(w io.Writer, content string) error {
+	qp := quotedprintable.NewWriter(w)
+
+	if _, err := qp.Write([]byte(content)); err != nil {
+		return err
+	}
+
+	return qp.Close()
+}
+
+func fileAttachments(msg models.Messageable) []models.FileAttachmentable {
+	files := []models.FileAttachmentable{}
+
+	for _, a := range msg.GetAttachments() {
+		if fa, ok := a.(models.FileAttachmentable); ok {
+			files = append(files, fa)
+		}
+	}
+
+	return files
+}
+
+func writeAttachment(mw *multipart.Writer, fa models.FileAttachmentable) error {
+	var (
+		name        = ptr.Val(fa.GetName())
+		contentType = ptr.Val(fa.GetContentType())
+		disposition = "attachment"
+	)
+
+	if len(contentType) == 0 {
+		contentType = "application/octet-stream"
+	}
+
+	if ptr.Val(fa.GetIsInline()) {
+		disposition = "inline"
+	}
+
+	var typeParams, dispParams map[string]string
+
+	if len(name) > 0 {
+		typeParams = map[string]string{"name": name}
+		dispParams = map[string]string{"filename": name}
+	}
+
+	// FormatMediaType produces an empty string for malformed types.
+	ct := mime.FormatMediaType(contentType, typeParams)
+	if len(ct) == 0 {
+		ct = mime.FormatMediaType("application/octet-stream", typeParams)
+	}
+
+	hdrs := textproto.MIMEHeader{
+		"Content-Type":              {ct},
+		"Content-Disposition":       {mime.FormatMediaType(disposition, dispParams)},
+		"Content-Transfer-Encoding": {"base64"},
+	}
+
+	if cid := ptr.Val(fa.GetContentId()); len(cid) > 0 {
+		hdrs.Set("Content-ID", "<"+cid+">")
+	}
+
+	pw, err := mw.CreatePart(hdrs)
+	if err != nil {
+		return err
+	}
+
+	enc := base64.StdEncoding.EncodeToString(fa.GetContentBytes())
+
+	// rfc 2045 limits encoded lines to 76 characters.
+	for len(enc) > 76 {
+		if _, err := fmt.Fprintf(pw, "%s\r\n", enc[:76]); err != nil {
+			return err
+		}
+
+		enc = enc[76:]
+	}
+
+	_, err = fmt.Fprintf(pw, "%s\r\n", enc)
+
+	return err
+}
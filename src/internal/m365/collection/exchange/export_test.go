@@ -0,0 +1,359 @@
+package exchange
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	dataMock "github.com/alcionai/corso/src/internal/data/mock"
+	"github.com/alcionai/corso/src/internal/m365/service/exchange/mock"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/internal/version"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/export"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+// maildirNameRE matches "<time>.<unique>.<host>:2,<flags>", with the
+// flags in ascii order.
+var maildirNameRE = regexp.MustCompile(`^\d+\.[0-9a-f]+\.corso:2,D?F?S?$`)
+
+type ExportUnitSuite struct {
+	tester.Suite
+}
+
+func TestExportUnitSuite(t *testing.T) {
+	suite.Run(t, &ExportUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *ExportUnitSuite) TestStreamItems() {
+	makeBody := func() io.ReadCloser {
+		return io.NopCloser(bytes.NewReader(mock.MessageBytes("zim")))
+	}
+
+	table := []struct {
+		name        string
+		format      control.FormatType
+		backingColl dataMock.Collection
+		expectName  assert.ValueAssertionFunc
+		expectErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name: "default format",
+			backingColl: dataMock.Collection{
+				ItemData: []data.Item{
+					&dataMock.Item{
+						ItemID: "zim",
+						Reader: makeBody(),
+					},
+				},
+			},
+			expectName: func(t assert.TestingT, name any, _ ...any) bool {
+				return assert.Equal(t, "zim.eml", name)
+			},
+			expectErr: assert.NoError,
+		},
+		{
+			name:   "json format",
+			format: control.JSONFormat,
+			backingColl: dataMock.Collection{
+				ItemData: []data.Item{
+					&dataMock.Item{
+						ItemID: "zim",
+						Reader: makeBody(),
+					},
+				},
+			},
+			expectName: func(t assert.TestingT, name any, _ ...any) bool {
+				return assert.Equal(t, "zim.json", name)
+			},
+			expectErr: assert.NoError,
+		},
+		{
+			name:   "maildir format",
+			format: control.MaildirFormat,
+			backingColl: dataMock.Collection{
+				ItemData: []data.Item{
+					&dataMock.Item{
+						ItemID: "zim",
+						Reader: makeBody(),
+					},
+				},
+			},
+			expectName: func(t assert.TestingT, name any, _ ...any) bool {
+				return assert.Regexp(t, maildirNameRE, name)
+			},
+			expectErr: assert.NoError,
+		},
+		{
+			name: "malformed message",
+			backingColl: dataMock.Collection{
+				ItemData: []data.Item{
+					&dataMock.Item{
+						ItemID: "gir",
+						Reader: io.NopCloser(bytes.NewReader([]byte("not json"))),
+					},
+				},
+			},
+			expectName: assert.Empty,
+			expectErr:  assert.Error,
+		},
+		{
+			name: "only recoverable errors",
+			backingColl: dataMock.Collection{
+				ItemsRecoverableErrs: []error{
+					clues.New("The knowledge... it fills me! It is neat!"),
+				},
+			},
+			expectName: assert.Empty,
+			expectErr:  assert.Error,
+		},
+	}
+
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			ch := make(chan export.Item)
+
+			go streamItems(
+				ctx,
+				[]data.RestoreCollection{test.backingColl},
+				version.NoBackup,
+				control.ExportConfig{Format: test.format},
+				ch)
+
+			var (
+				itm export.Item
+				err error
+			)
+
+			for i := range ch {
+				if i.Error == nil {
+					itm = i
+				} else {
+					err = i.Error
+				}
+			}
+
+			test.expectErr(t, err, clues.ToCore(err))
+			test.expectName(t, itm.Name)
+
+			if itm.Body != nil {
+				itm.Body.Close()
+			}
+		})
+	}
+}
+
+func (suite *ExportUnitSuite) TestNewExportCollection_maildirSanitizer() {
+	table := []struct {
+		name      string
+		cfg       control.ExportConfig
+		expectSan control.NameSanitizer
+	}{
+		{
+			name:      "maildir swaps the default sanitizer",
+			cfg:       control.ExportConfig{Format: control.MaildirFormat},
+			expectSan: control.POSIXNameSanitizer,
+		},
+		{
+			name: "maildir keeps an explicit sanitizer",
+			cfg: control.ExportConfig{
+				Format:        control.MaildirFormat,
+				NameSanitizer: control.WindowsNameSanitizer,
+			},
+			expectSan: control.WindowsNameSanitizer,
+		},
+		{
+			name:      "eml keeps the default sanitizer",
+			cfg:       control.ExportConfig{},
+			expectSan: control.DefaultNameSanitizer,
+		},
+	}
+
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			coll := NewExportCollection("Inbox/cur", nil, version.Backup, test.cfg)
+
+			bc, ok := coll.(export.BaseCollection)
+			require.True(t, ok, "base collection")
+			assert.Equal(t, test.expectSan, bc.Cfg.NameSanitizer)
+		})
+	}
+}
+
+func (suite *ExportUnitSuite) TestMaildirName() {
+	table := []struct {
+		name        string
+		setup       func(msg models.Messageable)
+		expectFlags string
+	}{
+		{
+			name:        "unread",
+			setup:       func(msg models.Messageable) {},
+			expectFlags: "",
+		},
+		{
+			name: "read",
+			setup: func(msg models.Messageable) {
+				msg.SetIsRead(ptr.To(true))
+			},
+			expectFlags: "S",
+		},
+		{
+			name: "read, flagged draft",
+			setup: func(msg models.Messageable) {
+				flag := models.NewFollowupFlag()
+				flag.SetFlagStatus(ptr.To(models.FLAGGED_FOLLOWUPFLAGSTATUS))
+
+				msg.SetFlag(flag)
+				msg.SetIsDraft(ptr.To(true))
+				msg.SetIsRead(ptr.To(true))
+			},
+			expectFlags: "DFS",
+		},
+	}
+
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			received := time.Date(2022, 9, 29, 17, 39, 7, 0, time.UTC)
+
+			msg := models.NewMessage()
+			msg.SetReceivedDateTime(&received)
+			test.setup(msg)
+
+			name := maildirName("id", msg)
+			assert.Regexp(t, maildirNameRE, name)
+			assert.True(t, strings.HasPrefix(name, "1664473147."), "name starts with the received time")
+			assert.True(t, strings.HasSuffix(name, ":2,"+test.expectFlags), "name ends with the flags")
+
+			// names are stable between exports, and unique between items.
+			assert.Equal(t, name, maildirName("id", msg))
+			assert.NotEqual(t, name, maildirName("other-id", msg))
+		})
+	}
+}
+
+func (suite *ExportUnitSuite) TestMessageToEML() {
+	t := suite.T()
+
+	msg := deserializeMessage(t, mock.MessageBytes("Hello, wörld"))
+
+	sent := ptr.Val(msg.GetReceivedDateTime()).Add(-time.Hour)
+	msg.SetSentDateTime(&sent)
+
+	eml, err := messageToEML(msg)
+	require.NoError(t, err, clues.ToCore(err))
+
+	// a mail client must be able to parse the headers and body.
+	parsed, err := mail.ReadMessage(bytes.NewReader(eml))
+	require.NoError(t, err, clues.ToCore(err))
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, ptr.Val(msg.GetSubject()), subject)
+	assert.Contains(t, subject, "Hello, wörld")
+
+	from, err := parsed.Header.AddressList("From")
+	require.NoError(t, err, clues.ToCore(err))
+	require.Len(t, from, 1)
+	assert.Equal(t, ptr.Val(msg.GetFrom().GetEmailAddress().GetAddress()), from[0].Address)
+
+	to, err := parsed.Header.AddressList("To")
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Len(t, to, len(msg.GetToRecipients()))
+
+	date, err := parsed.Header.Date()
+	require.NoError(t, err, clues.ToCore(err))
+	assert.True(t, sent.Equal(date), "date is the sent time")
+
+	assert.Equal(t, ptr.Val(msg.GetInternetMessageId()), parsed.Header.Get("Message-ID"))
+
+	mt, _, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, "text/html", mt)
+
+	body, err := io.ReadAll(quotedprintable.NewReader(parsed.Body))
+	require.NoError(t, err, clues.ToCore(err))
+	assertSameBody(t, ptr.Val(msg.GetBody().GetContent()), string(body))
+}
+
+func (suite *ExportUnitSuite) TestMessageToEML_attachments() {
+	t := suite.T()
+
+	msg := deserializeMessage(t, mock.MessageWithDirectAttachment("attached"))
+	files := fileAttachments(msg)
+	require.Len(t, files, 1)
+
+	eml, err := messageToEML(msg)
+	require.NoError(t, err, clues.ToCore(err))
+
+	parsed, err := mail.ReadMessage(bytes.NewReader(eml))
+	require.NoError(t, err, clues.ToCore(err))
+
+	mt, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	require.NoError(t, err, clues.ToCore(err))
+	require.Equal(t, "multipart/mixed", mt)
+
+	mr := multipart.NewReader(parsed.Body, params["boundary"])
+
+	bodyPart, err := mr.NextPart()
+	require.NoError(t, err, clues.ToCore(err))
+
+	body, err := io.ReadAll(quotedprintable.NewReader(bodyPart))
+	require.NoError(t, err, clues.ToCore(err))
+	assertSameBody(t, ptr.Val(msg.GetBody().GetContent()), string(body))
+
+	attPart, err := mr.NextPart()
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, ptr.Val(files[0].GetName()), attPart.FileName())
+
+	att, err := io.ReadAll(base64.NewDecoder(base64.StdEncoding, attPart))
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, files[0].GetContentBytes(), att)
+
+	_, err = mr.NextPart()
+	assert.ErrorIs(t, err, io.EOF, "no more parts")
+}
+
+func deserializeMessage(t *testing.T, bs []byte) models.Messageable {
+	cfb, err := api.CreateFromBytes(bs, models.CreateMessageFromDiscriminatorValue)
+	require.NoError(t, err, clues.ToCore(err))
+
+	msg, ok := cfb.(models.Messageable)
+	require.True(t, ok, "deserialized message")
+
+	return msg
+}
+
+// assertSameBody compares message bodies, ignoring the line break style,
+// since quoted-printable canonicalizes line breaks to CRLF.
+func assertSameBody(t *testing.T, expect, got string) {
+	norm := strings.NewReplacer("\r\n", "\n").Replace
+
+	assert.Equal(t, norm(expect), norm(got))
+}
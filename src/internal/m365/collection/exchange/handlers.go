@@ -34,6 +34,16 @@ type addedAndRemovedItemGetter interface {
 	) (map[string]time.Time, bool, []string, api.DeltaUpdate, error)
 }
 
+// attachmentlessItemGetter is implemented by item getters that can
+// retrieve an item without downloading its attachments.
+type attachmentlessItemGetter interface {
+	GetItemWithoutAttachments(
+		ctx context.Context,
+		user, itemID string,
+		immutableIDs bool,
+	) (serialization.Parsable, *details.ExchangeInfo, error)
+}
+
 type itemGetterSerializer interface {
 	GetItem(
 		ctx context.Context,
@@ -107,14 +117,27 @@ type containerByNamer interface {
 	) (graph.Container, error)
 }
 
+// fixedContainerer is implemented by handlers that may restore every
+// item into a single, pre-existing container instead of the restore
+// location.  The bool is false if the handler uses the restore location.
+type fixedContainerer interface {
+	fixedRestoreContainer() (string, bool)
+}
+
 // primary interface controller for all per-cateogry restoration behavior.
 func RestoreHandlers(
 	ac api.Client,
+	restoreCfg control.RestoreConfig,
 ) map[path.CategoryType]restoreHandler {
+	eventRange := eventDateRange{
+		after:  restoreCfg.EventsAfter,
+		before: restoreCfg.EventsBefore,
+	}
+
 	return map[path.CategoryType]restoreHandler{
 		path.ContactsCategory: newContactRestoreHandler(ac),
-		path.EmailCategory:    newMailRestoreHandler(ac),
-		path.EventsCategory:   newEventRestoreHandler(ac),
+		path.EmailCategory:    newMailRestoreHandler(ac, restoreCfg.RestoreMailAsDraft, restoreCfg.MailRootFolder),
+		path.EventsCategory:   newEventRestoreHandler(ac, eventRange),
 	}
 }
 
@@ -18,17 +18,29 @@ import (
 	"github.com/alcionai/corso/src/pkg/services/m365/api"
 )
 
-var _ itemRestorer = &mailRestoreHandler{}
+var (
+	_ itemRestorer     = &mailRestoreHandler{}
+	_ fixedContainerer = &mailRestoreHandler{}
+)
 
 type mailRestoreHandler struct {
 	ac api.Mail
+	// restores messages into the drafts folder as unsent mail.
+	asDraft bool
+	// if populated, the top-level folder that contains the restored
+	// mail hierarchy.
+	rootFolder string
 }
 
 func newMailRestoreHandler(
 	ac api.Client,
+	asDraft bool,
+	rootFolder string,
 ) mailRestoreHandler {
 	return mailRestoreHandler{
-		ac: ac.Mail(),
+		ac:         ac.Mail(),
+		asDraft:    asDraft,
+		rootFolder: rootFolder,
 	}
 }
 
@@ -44,7 +56,9 @@ func (h mailRestoreHandler) FormatRestoreDestination(
 	destinationContainerName string,
 	collectionFullPath path.Path,
 ) *path.Builder {
-	return path.Builder{}.Append(destinationContainerName).Append(collectionFullPath.Folders()...)
+	return path.Builder{}.
+		Append(h.rootFolder, destinationContainerName).
+		Append(collectionFullPath.Folders()...)
 }
 
 func (h mailRestoreHandler) CreateContainer(
@@ -69,6 +83,16 @@ func (h mailRestoreHandler) DefaultRootContainer() string {
 	return api.MsgFolderRoot
 }
 
+// drafts are restored into the well-known drafts folder, which every
+// mailbox already has.
+func (h mailRestoreHandler) fixedRestoreContainer() (string, bool) {
+	if !h.asDraft {
+		return "", false
+	}
+
+	return api.MailDrafts, true
+}
+
 func (h mailRestoreHandler) restore(
 	ctx context.Context,
 	body []byte,
@@ -85,6 +109,7 @@ func (h mailRestoreHandler) restore(
 		userID, destinationID,
 		collisionKeyToItemID,
 		collisionPolicy,
+		h.asDraft,
 		errs,
 		ctr)
 }
@@ -102,6 +127,7 @@ func restoreMail(
 	userID, destinationID string,
 	collisionKeyToItemID map[string]string,
 	collisionPolicy control.CollisionPolicy,
+	asDraft bool,
 	errs *fault.Bus,
 	ctr *count.Bus,
 ) (*details.ExchangeInfo, error) {
@@ -133,12 +159,18 @@ func restoreMail(
 		shouldDeleteOriginal = collisionPolicy == control.Replace
 	}
 
-	msg = setMessageSVEPs(toMessage(msg))
+	msg = setMessageSVEPs(toMessage(msg), asDraft)
 
 	attachments := msg.GetAttachments()
 	// Item.Attachments --> HasAttachments doesn't always have a value populated when deserialized
 	msg.SetAttachments([]models.Attachmentable{})
 
+	// messages backed up without their attachments still claim to have
+	// them.  Don't carry the claim over to the restored message.
+	if len(attachments) == 0 {
+		msg.SetHasAttachments(nil)
+	}
+
 	item, err := mr.PostItem(ctx, userID, destinationID, msg)
 	if err != nil {
 		return nil, graph.Wrap(ctx, err, "restoring mail message")
@@ -183,15 +215,21 @@ func restoreMail(
 	return api.MailInfo(msg, size), nil
 }
 
-func setMessageSVEPs(msg models.Messageable) models.Messageable {
+// setMessageSVEPs sets the extended properties that restore the message's
+// sent and received dates.  Unless the message is restored as a draft, it
+// also gets marked as sent, so that graph doesn't treat it as unsent mail.
+func setMessageSVEPs(msg models.Messageable, asDraft bool) models.Messageable {
 	// Set Extended Properties:
 	svlep := make([]models.SingleValueLegacyExtendedPropertyable, 0)
 
-	// prevent "resending" of the mail in the graph api backstore
-	sv1 := models.NewSingleValueLegacyExtendedProperty()
-	sv1.SetId(ptr.To(MailRestorePropertyTag))
-	sv1.SetValue(ptr.To(RestoreCanonicalEnableValue))
-	svlep = append(svlep, sv1)
+	// prevent "resending" of the mail in the graph api backstore.
+	// messages created without this flag remain unsent drafts.
+	if !asDraft {
+		sv1 := models.NewSingleValueLegacyExtendedProperty()
+		sv1.SetId(ptr.To(MailRestorePropertyTag))
+		sv1.SetValue(ptr.To(RestoreCanonicalEnableValue))
+		svlep = append(svlep, sv1)
+	}
 
 	// establish the sent date
 	if msg.GetSentDateTime() != nil {
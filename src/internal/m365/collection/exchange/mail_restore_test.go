@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/alcionai/corso/src/internal/common/ptr"
 	"github.com/alcionai/corso/src/internal/m365/graph"
 	"github.com/alcionai/corso/src/internal/m365/service/exchange/mock"
 	"github.com/alcionai/corso/src/internal/tester"
@@ -28,17 +29,21 @@ var _ mailRestorer = &mailRestoreMock{}
 type mailRestoreMock struct {
 	postItemErr       error
 	calledPost        bool
+	postedMsg         models.Messageable
 	deleteItemErr     error
 	calledDelete      bool
 	postAttachmentErr error
+	postedAttachments int
 }
 
 func (m *mailRestoreMock) PostItem(
 	_ context.Context,
 	_, _ string,
-	_ models.Messageable,
+	msg models.Messageable,
 ) (models.Messageable, error) {
 	m.calledPost = true
+	m.postedMsg = msg
+
 	return models.NewMessage(), m.postItemErr
 }
 
@@ -55,6 +60,7 @@ func (m *mailRestoreMock) PostSmallAttachment(
 	_, _, _ string,
 	_ models.Attachmentable,
 ) error {
+	m.postedAttachments++
 	return m.postAttachmentErr
 }
 
@@ -63,13 +69,288 @@ func (m *mailRestoreMock) PostLargeAttachment(
 	_, _, _, _ string,
 	_ []byte,
 ) (string, error) {
+	m.postedAttachments++
 	return uuid.NewString(), m.postAttachmentErr
 }
 
+var _ containerAPI = &containerCreatorMock{}
+
+// containerCreatorMock records each created container as "parentID/name",
+// and gives it the ID "id-<name>".
+type containerCreatorMock struct {
+	created []string
+}
+
+func (m *containerCreatorMock) CreateContainer(
+	_ context.Context,
+	_, parentContainerID, containerName string,
+) (graph.Container, error) {
+	m.created = append(m.created, parentContainerID+"/"+containerName)
+
+	mf := models.NewMailFolder()
+	mf.SetId(ptr.To("id-" + containerName))
+	mf.SetDisplayName(ptr.To(containerName))
+	mf.SetParentFolderId(ptr.To(parentContainerID))
+
+	return mf, nil
+}
+
+func (m *containerCreatorMock) GetContainerByName(
+	_ context.Context,
+	_, _, _ string,
+) (graph.Container, error) {
+	return nil, clues.New("not found")
+}
+
+func (m *containerCreatorMock) DefaultRootContainer() string {
+	return api.MsgFolderRoot
+}
+
+// locationResolver is a container cache keyed by restore location.
+type locationResolver struct {
+	mockResolver
+	locations map[string]string
+}
+
+func (m locationResolver) LocationInCache(loc string) (string, bool) {
+	id, ok := m.locations[loc]
+	return id, ok
+}
+
+func (m locationResolver) AddToCache(_ context.Context, c graph.Container) error {
+	return nil
+}
+
 // ---------------------------------------------------------------------------
 // tests
 // ---------------------------------------------------------------------------
 
+type MailRestoreUnitSuite struct {
+	tester.Suite
+}
+
+func TestMailRestoreUnitSuite(t *testing.T) {
+	suite.Run(t, &MailRestoreUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *MailRestoreUnitSuite) TestRestoreDestination_asDraft() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	fullPath, err := path.Build("t", "pr", path.ExchangeService, path.EmailCategory, false, "Inbox", "fnords")
+	require.NoError(t, err, clues.ToCore(err))
+
+	h := newMailRestoreHandler(api.Client{}, true, "")
+
+	// the resolver is never consulted, since no containers get created.
+	containerID, _, err := RestoreDestination(
+		ctx,
+		h,
+		testdata.DefaultRestoreConfig("").Location,
+		fullPath,
+		"pr",
+		nil,
+		fault.New(true))
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, api.MailDrafts, containerID)
+
+	_, fixed := newMailRestoreHandler(api.Client{}, false, "").fixedRestoreContainer()
+	assert.False(t, fixed, "restore location used when not restoring drafts")
+}
+
+func (suite *MailRestoreUnitSuite) TestFormatRestoreDestination_rootFolder() {
+	fullPath, err := path.Build("t", "pr", path.ExchangeService, path.EmailCategory, false, "Inbox", "fnords")
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	table := []struct {
+		name       string
+		rootFolder string
+		location   string
+		expect     []string
+	}{
+		{
+			name:     "no root folder",
+			location: "Corso_Restore",
+			expect:   []string{"Corso_Restore", "Inbox", "fnords"},
+		},
+		{
+			name:       "root folder",
+			rootFolder: "Restored 2024",
+			location:   "Corso_Restore",
+			expect:     []string{"Restored 2024", "Corso_Restore", "Inbox", "fnords"},
+		},
+		{
+			name:       "root folder, restore in place",
+			rootFolder: "Restored 2024",
+			expect:     []string{"Restored 2024", "Inbox", "fnords"},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			h := newMailRestoreHandler(api.Client{}, false, test.rootFolder)
+
+			pb := h.FormatRestoreDestination(test.location, fullPath)
+			assert.Equal(suite.T(), path.Elements(test.expect), pb.Elements())
+		})
+	}
+}
+
+func (suite *MailRestoreUnitSuite) TestCreateDestination_rootFolder() {
+	fullPath, err := path.Build("t", "pr", path.ExchangeService, path.EmailCategory, false, "Inbox")
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	table := []struct {
+		name          string
+		cached        map[string]string
+		expectCreated []string
+	}{
+		{
+			name: "root folder absent",
+			expectCreated: []string{
+				"/Restored 2024",
+				"id-Restored 2024/Corso_Restore",
+				"id-Corso_Restore/Inbox",
+			},
+		},
+		{
+			name:   "root folder exists",
+			cached: map[string]string{"Restored 2024": "existing-root"},
+			expectCreated: []string{
+				"existing-root/Corso_Restore",
+				"id-Corso_Restore/Inbox",
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				h   = newMailRestoreHandler(api.Client{}, false, "Restored 2024")
+				ca  = &containerCreatorMock{}
+				gcr = locationResolver{locations: test.cached}
+			)
+
+			containerID, _, err := CreateDestination(
+				ctx,
+				ca,
+				h.FormatRestoreDestination("Corso_Restore", fullPath),
+				"pr",
+				gcr,
+				fault.New(true))
+			require.NoError(t, err, clues.ToCore(err))
+
+			assert.Equal(t, test.expectCreated, ca.created)
+			assert.Equal(t, "id-Inbox", containerID, "items restore into the nested folder")
+		})
+	}
+}
+
+func (suite *MailRestoreUnitSuite) TestRestoreMail_asDraft() {
+	table := []struct {
+		name        string
+		asDraft     bool
+		expectFlags assert.BoolAssertionFunc
+	}{
+		{
+			name:        "as draft",
+			asDraft:     true,
+			expectFlags: assert.False,
+		},
+		{
+			name:        "as sent",
+			asDraft:     false,
+			expectFlags: assert.True,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				body = mock.MessageBytes("subject")
+				m    = &mailRestoreMock{}
+			)
+
+			stub, err := api.BytesToMessageable(body)
+			require.NoError(t, err, clues.ToCore(err))
+
+			info, err := restoreMail(
+				ctx,
+				m,
+				body,
+				"pr",
+				api.MailDrafts,
+				map[string]string{},
+				control.Copy,
+				test.asDraft,
+				fault.New(true),
+				count.New())
+			require.NoError(t, err, clues.ToCore(err))
+			require.NotNil(t, m.postedMsg)
+
+			var hasFlags bool
+
+			for _, sv := range m.postedMsg.GetSingleValueExtendedProperties() {
+				if ptr.Val(sv.GetId()) == MailRestorePropertyTag {
+					hasFlags = true
+				}
+			}
+
+			test.expectFlags(t, hasFlags, "message flags mark the message as sent")
+
+			// details retain the original message metadata.
+			assert.Equal(t, ptr.Val(stub.GetSubject()), info.Subject)
+			assert.Equal(t, ptr.Val(stub.GetReceivedDateTime()), info.Received)
+		})
+	}
+}
+
+func (suite *MailRestoreUnitSuite) TestRestoreMail_withoutAttachments() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	stub, err := api.BytesToMessageable(mock.MessageBytes("subject"))
+	require.NoError(t, err, clues.ToCore(err))
+
+	// backups that skip attachments keep the message's attachment flag.
+	stub.SetHasAttachments(ptr.To(true))
+	stub.SetAttachments(nil)
+
+	body, err := api.Mail{}.Serialize(ctx, stub, "pr", "id")
+	require.NoError(t, err, clues.ToCore(err))
+
+	m := &mailRestoreMock{}
+
+	_, err = restoreMail(
+		ctx,
+		m,
+		body,
+		"pr",
+		"destination",
+		map[string]string{},
+		control.Copy,
+		false,
+		fault.New(true),
+		count.New())
+	require.NoError(t, err, clues.ToCore(err))
+	require.NotNil(t, m.postedMsg)
+
+	assert.Nil(t, m.postedMsg.GetHasAttachments(), "has attachments")
+	assert.Empty(t, m.postedMsg.GetAttachments(), "attachments")
+	assert.Zero(t, m.postedAttachments, "posted attachments")
+}
+
 type MailRestoreIntgSuite struct {
 	tester.Suite
 	its intgTesterSetup
@@ -90,7 +371,7 @@ func (suite *MailRestoreIntgSuite) SetupSuite() {
 func (suite *MailRestoreIntgSuite) TestCreateContainerDestination() {
 	runCreateDestinationTest(
 		suite.T(),
-		newMailRestoreHandler(suite.its.ac),
+		newMailRestoreHandler(suite.its.ac, false, ""),
 		path.EmailCategory,
 		suite.its.creds.AzureTenantID,
 		suite.its.userID,
@@ -238,6 +519,7 @@ func (suite *MailRestoreIntgSuite) TestRestoreMail() {
 				"destination",
 				test.collisionMap,
 				test.onCollision,
+				false,
 				fault.New(true),
 				ctr)
 
@@ -3,7 +3,9 @@ package exchange
 import (
 	"bytes"
 	"context"
+	"errors"
 	"runtime/trace"
+	"sync"
 
 	"github.com/alcionai/clues"
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
@@ -22,7 +24,15 @@ import (
 	"github.com/alcionai/corso/src/pkg/path"
 )
 
+// RestoreParallelism produces the number of items restored concurrently
+// within a collection.
+func RestoreParallelism(ctx context.Context, opts control.Options) int {
+	return graph.Parallelism(path.ExchangeService).
+		ItemUploadOverride(ctx, opts.Parallelism.RestoreItemWrite)
+}
+
 // RestoreCollection handles restoration of an individual collection.
+// Up to parallelism items are restored at the same time.
 func RestoreCollection(
 	ctx context.Context,
 	ir itemRestorer,
@@ -30,6 +40,7 @@ func RestoreCollection(
 	resourceID, destinationID string,
 	collisionKeyToItemID map[string]string,
 	collisionPolicy control.CollisionPolicy,
+	parallelism int,
 	deets *details.Builder,
 	errs *fault.Bus,
 	ctr *count.Bus,
@@ -38,88 +49,152 @@ func RestoreCollection(
 	defer end()
 
 	var (
-		el       = errs.Local()
-		metrics  support.CollectionMetrics
-		items    = dc.Items(ctx, errs)
-		fullPath = dc.FullPath()
-		category = fullPath.Category()
+		el        = errs.Local()
+		metrics   support.CollectionMetrics
+		metricsMu sync.Mutex
+		items     = dc.Items(ctx, errs)
+		fullPath  = dc.FullPath()
+		category  = fullPath.Category()
+		wg        sync.WaitGroup
 	)
 
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	semaphoreCh := make(chan struct{}, parallelism)
+	defer close(semaphoreCh)
+
 	colProgress := observe.CollectionProgress(
 		ctx,
 		category.HumanString(),
 		fullPath.Folder(false))
 	defer close(colProgress)
 
+	restoreItem := func(ictx context.Context, itemData data.Item) {
+		trace.Log(ictx, "m365:exchange:restoreCollection:item", itemData.ID())
+
+		metricsMu.Lock()
+		metrics.Objects++
+		metricsMu.Unlock()
+
+		buf := &bytes.Buffer{}
+
+		_, err := buf.ReadFrom(itemData.ToReader())
+		if err != nil {
+			el.AddRecoverable(ctx, clues.Wrap(err, "reading item bytes").WithClues(ictx))
+			return
+		}
+
+		body := buf.Bytes()
+
+		info, err := ir.restore(
+			ictx,
+			body,
+			resourceID,
+			destinationID,
+			collisionKeyToItemID,
+			collisionPolicy,
+			errs,
+			ctr)
+		if err != nil {
+			// events outside the restore date range aren't part of the restore.
+			if errors.Is(err, errEventOutsideRestoreRange) {
+				metricsMu.Lock()
+				metrics.Objects--
+				metricsMu.Unlock()
+
+				return
+			}
+
+			if !graph.IsErrItemAlreadyExistsConflict(err) {
+				el.AddRecoverable(ictx, err)
+			}
+
+			return
+		}
+
+		metricsMu.Lock()
+		metrics.Bytes += int64(len(body))
+		metrics.Successes++
+		metricsMu.Unlock()
+
+		// FIXME: this may be the incorrect path.  If we restored within a top-level
+		// destination folder, then the restore path no longer matches the fullPath.
+		itemPath, err := fullPath.AppendItem(itemData.ID())
+		if err != nil {
+			el.AddRecoverable(ctx, clues.Wrap(err, "adding item to collection path").WithClues(ctx))
+			return
+		}
+
+		locationRef := path.Builder{}.Append(itemPath.Folders()...)
+
+		err = deets.Add(
+			itemPath,
+			locationRef,
+			details.ItemInfo{
+				Exchange: info,
+			})
+		if err != nil {
+			// These deets additions are for cli display purposes only.
+			// no need to fail out on error.
+			logger.Ctx(ctx).Infow("accounting for restored item", "error", err)
+		}
+
+		colProgress <- struct{}{}
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
+			wg.Wait()
 			return metrics, clues.Wrap(ctx.Err(), "context cancelled").WithClues(ctx)
 
 		case itemData, ok := <-items:
 			if !ok || el.Failure() != nil {
+				wg.Wait()
 				return metrics, el.Failure()
 			}
 
-			ictx := clues.Add(ctx, "item_id", itemData.ID())
-			trace.Log(ictx, "m365:exchange:restoreCollection:item", itemData.ID())
-			metrics.Objects++
-
-			buf := &bytes.Buffer{}
-
-			_, err := buf.ReadFrom(itemData.ToReader())
-			if err != nil {
-				el.AddRecoverable(ctx, clues.Wrap(err, "reading item bytes").WithClues(ictx))
-				continue
-			}
-
-			body := buf.Bytes()
-
-			info, err := ir.restore(
-				ictx,
-				body,
-				resourceID,
-				destinationID,
-				collisionKeyToItemID,
-				collisionPolicy,
-				errs,
-				ctr)
-			if err != nil {
-				if !graph.IsErrItemAlreadyExistsConflict(err) {
-					el.AddRecoverable(ictx, err)
-				}
+			wg.Add(1)
+			semaphoreCh <- struct{}{}
 
-				continue
-			}
-
-			metrics.Bytes += int64(len(body))
-			metrics.Successes++
+			go func(itemData data.Item) {
+				defer wg.Done()
+				defer func() { <-semaphoreCh }()
 
-			// FIXME: this may be the incorrect path.  If we restored within a top-level
-			// destination folder, then the restore path no longer matches the fullPath.
-			itemPath, err := fullPath.AppendItem(itemData.ID())
-			if err != nil {
-				el.AddRecoverable(ctx, clues.Wrap(err, "adding item to collection path").WithClues(ctx))
-				continue
-			}
-
-			locationRef := path.Builder{}.Append(itemPath.Folders()...)
-
-			err = deets.Add(
-				itemPath,
-				locationRef,
-				details.ItemInfo{
-					Exchange: info,
-				})
-			if err != nil {
-				// These deets additions are for cli display purposes only.
-				// no need to fail out on error.
-				logger.Ctx(ctx).Infow("accounting for restored item", "error", err)
-			}
+				restoreItem(clues.Add(ctx, "item_id", itemData.ID()), itemData)
+			}(itemData)
+		}
+	}
+}
 
-			colProgress <- struct{}{}
+// RestoreDestination produces the ID of the container that receives the
+// collection's items.  Handlers that restore into a fixed container use
+// it as is.  Otherwise, the restore location and the collection's folders
+// get created as needed.
+func RestoreDestination(
+	ctx context.Context,
+	rh restoreHandler,
+	location string,
+	collectionFullPath path.Path,
+	resourceID string,
+	gcr graph.ContainerResolver,
+	errs *fault.Bus,
+) (string, graph.ContainerResolver, error) {
+	if fc, ok := rh.(fixedContainerer); ok {
+		if containerID, fixed := fc.fixedRestoreContainer(); fixed {
+			return containerID, gcr, nil
 		}
 	}
+
+	return CreateDestination(
+		ctx,
+		rh,
+		rh.FormatRestoreDestination(location, collectionFullPath),
+		resourceID,
+		gcr,
+		errs)
 }
 
 // CreateDestination creates folders in sequence
@@ -1,8 +1,13 @@
 package exchange
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"io"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/alcionai/clues"
 	"github.com/stretchr/testify/assert"
@@ -10,10 +15,13 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	dataMock "github.com/alcionai/corso/src/internal/data/mock"
 	exchMock "github.com/alcionai/corso/src/internal/m365/service/exchange/mock"
 	"github.com/alcionai/corso/src/internal/tester"
 	"github.com/alcionai/corso/src/internal/tester/tconfig"
 	"github.com/alcionai/corso/src/pkg/account"
+	"github.com/alcionai/corso/src/pkg/backup/details"
 	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/control/testdata"
 	"github.com/alcionai/corso/src/pkg/count"
@@ -22,6 +30,193 @@ import (
 	"github.com/alcionai/corso/src/pkg/services/m365/api"
 )
 
+type RestoreUnitSuite struct {
+	tester.Suite
+}
+
+func TestRestoreUnitSuite(t *testing.T) {
+	suite.Run(t, &RestoreUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *RestoreUnitSuite) TestRestoreParallelism() {
+	table := []struct {
+		name     string
+		override int
+		expect   int
+	}{
+		{"default", 0, 1},
+		{"override", 6, 6},
+		{"negative override", -1, 1},
+		{"override above the upload limit", 100, 1},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			opts := control.DefaultOptions()
+			opts.Parallelism.ItemFetch = 2
+			opts.Parallelism.RestoreItemWrite = test.override
+
+			assert.Equal(t, test.expect, RestoreParallelism(ctx, opts))
+		})
+	}
+}
+
+// blockingRestorer holds each restore open until released, tracking how
+// many restores are in flight at once.
+type blockingRestorer struct {
+	release  chan struct{}
+	inFlight atomic.Int64
+	maxSeen  atomic.Int64
+}
+
+func (br *blockingRestorer) restore(
+	_ context.Context,
+	_ []byte,
+	_, _ string,
+	_ map[string]string,
+	_ control.CollisionPolicy,
+	_ *fault.Bus,
+	_ *count.Bus,
+) (*details.ExchangeInfo, error) {
+	n := br.inFlight.Add(1)
+	defer br.inFlight.Add(-1)
+
+	for {
+		prev := br.maxSeen.Load()
+		if n <= prev || br.maxSeen.CompareAndSwap(prev, n) {
+			break
+		}
+	}
+
+	<-br.release
+
+	return &details.ExchangeInfo{ItemType: details.ExchangeMail}, nil
+}
+
+func (suite *RestoreUnitSuite) TestRestoreCollection_parallelism() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	const (
+		parallelism = 3
+		numItems    = 10
+	)
+
+	fp, err := path.Build("tid", "uid", path.ExchangeService, path.EmailCategory, false, "inbox")
+	require.NoError(t, err, clues.ToCore(err))
+
+	items := make([]data.Item, 0, numItems)
+	for i := 0; i < numItems; i++ {
+		items = append(items, &dataMock.Item{
+			ItemID: fmt.Sprintf("item-%d", i),
+			Reader: io.NopCloser(bytes.NewReader([]byte("body"))),
+		})
+	}
+
+	var (
+		br = &blockingRestorer{release: make(chan struct{})}
+		dc = dataMock.Collection{Path: fp, ItemData: items}
+	)
+
+	go func() {
+		// release the restores once the semaphore is saturated.
+		assert.Eventually(
+			t,
+			func() bool { return br.inFlight.Load() == parallelism },
+			5*time.Second,
+			10*time.Millisecond)
+		close(br.release)
+	}()
+
+	metrics, err := RestoreCollection(
+		ctx,
+		br,
+		dc,
+		"uid",
+		"destination",
+		nil,
+		control.Copy,
+		parallelism,
+		&details.Builder{},
+		fault.New(true),
+		count.New())
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, int64(parallelism), br.maxSeen.Load(), "max concurrent restores")
+	assert.Equal(t, numItems, metrics.Objects)
+	assert.Equal(t, numItems, metrics.Successes)
+}
+
+// rangeRestorer restores the items it includes, and leaves every other
+// item out of the restore date range.
+type rangeRestorer struct {
+	included map[string]struct{}
+}
+
+func (rr rangeRestorer) restore(
+	_ context.Context,
+	body []byte,
+	_, _ string,
+	_ map[string]string,
+	_ control.CollisionPolicy,
+	_ *fault.Bus,
+	_ *count.Bus,
+) (*details.ExchangeInfo, error) {
+	if _, ok := rr.included[string(body)]; !ok {
+		return nil, clues.Stack(errEventOutsideRestoreRange)
+	}
+
+	return &details.ExchangeInfo{ItemType: details.ExchangeEvent}, nil
+}
+
+func (suite *RestoreUnitSuite) TestRestoreCollection_outsideDateRange() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	fp, err := path.Build("tid", "uid", path.ExchangeService, path.EventsCategory, false, "calendar")
+	require.NoError(t, err, clues.ToCore(err))
+
+	items := []data.Item{}
+	for _, id := range []string{"before", "within", "after"} {
+		items = append(items, &dataMock.Item{
+			ItemID: id,
+			Reader: io.NopCloser(bytes.NewReader([]byte(id))),
+		})
+	}
+
+	var (
+		rr   = rangeRestorer{included: map[string]struct{}{"within": {}}}
+		dc   = dataMock.Collection{Path: fp, ItemData: items}
+		errs = fault.New(true)
+	)
+
+	metrics, err := RestoreCollection(
+		ctx,
+		rr,
+		dc,
+		"uid",
+		"destination",
+		nil,
+		control.Copy,
+		1,
+		&details.Builder{},
+		errs,
+		count.New())
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Empty(t, errs.Recovered(), "events outside the range aren't errors")
+	assert.Equal(t, 1, metrics.Objects, "events outside the range aren't counted")
+	assert.Equal(t, 1, metrics.Successes)
+}
+
 type RestoreIntgSuite struct {
 	tester.Suite
 	credentials account.M365Config
@@ -96,7 +291,7 @@ func (suite *RestoreIntgSuite) TestRestoreEvent() {
 	var (
 		userID  = tconfig.M365UserID(t)
 		subject = testdata.DefaultRestoreConfig("event").Location
-		handler = newEventRestoreHandler(suite.ac)
+		handler = newEventRestoreHandler(suite.ac, eventDateRange{})
 	)
 
 	calendar, err := handler.ac.CreateContainer(ctx, userID, "", subject)
@@ -166,7 +361,7 @@ func (suite *RestoreIntgSuite) TestRestoreEvent() {
 func (suite *RestoreIntgSuite) TestRestoreExchangeObject() {
 	t := suite.T()
 
-	handlers := RestoreHandlers(suite.ac)
+	handlers := RestoreHandlers(suite.ac, testdata.DefaultRestoreConfig(""))
 
 	userID := tconfig.M365UserID(suite.T())
 
@@ -399,7 +594,7 @@ func (suite *RestoreIntgSuite) TestRestoreAndBackupEvent_recurringInstancesWithA
 	var (
 		userID  = tconfig.M365UserID(t)
 		subject = testdata.DefaultRestoreConfig("event").Location
-		handler = newEventRestoreHandler(suite.ac)
+		handler = newEventRestoreHandler(suite.ac, eventDateRange{})
 	)
 
 	calendar, err := handler.ac.CreateContainer(ctx, userID, "", subject)
@@ -97,6 +97,20 @@ func (bh mockBackupHandler) GetChannelMessage(
 	return bh.messages[itemID], bh.info[itemID], bh.getMessageErr[itemID]
 }
 
+func (bh mockBackupHandler) GetChannelSettings(
+	_ context.Context,
+	_, _ string,
+) (models.Channelable, error) {
+	return models.NewChannel(), nil
+}
+
+func (bh mockBackupHandler) GetChannelTabs(
+	_ context.Context,
+	_, _ string,
+) ([]models.TeamsTabable, error) {
+	return nil, nil
+}
+
 // ---------------------------------------------------------------------------
 // Unit Suite
 // ---------------------------------------------------------------------------
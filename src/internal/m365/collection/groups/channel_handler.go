@@ -73,3 +73,17 @@ func (bh channelsBackupHandler) GetChannelMessage(
 ) (models.ChatMessageable, *details.GroupsInfo, error) {
 	return bh.ac.GetChannelMessage(ctx, teamID, channelID, itemID)
 }
+
+func (bh channelsBackupHandler) GetChannelSettings(
+	ctx context.Context,
+	teamID, channelID string,
+) (models.Channelable, error) {
+	return bh.ac.GetChannelSettings(ctx, teamID, channelID)
+}
+
+func (bh channelsBackupHandler) GetChannelTabs(
+	ctx context.Context,
+	teamID, channelID string,
+) ([]models.TeamsTabable, error) {
+	return bh.ac.GetChannelTabs(ctx, teamID, channelID)
+}
@@ -0,0 +1,164 @@
+package groups
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+)
+
+// ChannelMetaFileName is the name of the item that holds a channel's
+// settings and tabs.  The item is stored alongside the channel's
+// messages, but isn't added to backup details.
+const ChannelMetaFileName = ".channelmeta"
+
+var _ data.Item = &channelMetaItem{}
+
+// ChannelMeta holds the parts of a channel that aren't messages.
+type ChannelMeta struct {
+	// DisplayName is the name of the channel.  The channel's storage path
+	// is built from its ID, so restores look up the channel by this name.
+	DisplayName string          `json:"displayName,omitempty"`
+	Settings    ChannelSettings `json:"settings"`
+	Tabs        []ChannelTab    `json:"tabs,omitempty"`
+}
+
+// ChannelSettings are the channel properties that can be updated after
+// the channel is created.
+type ChannelSettings struct {
+	Description         string `json:"description,omitempty"`
+	IsFavoriteByDefault *bool  `json:"isFavoriteByDefault,omitempty"`
+}
+
+// ChannelTab is a tab pinned to the channel.  The configuration values
+// are owned by the tab's app, and frequently reference tenant specific
+// resources, such as a planner plan or a wiki page.
+type ChannelTab struct {
+	DisplayName string `json:"displayName"`
+	TeamsAppID  string `json:"teamsAppId"`
+	EntityID    string `json:"entityId,omitempty"`
+	ContentURL  string `json:"contentUrl,omitempty"`
+	WebsiteURL  string `json:"websiteUrl,omitempty"`
+	RemoveURL   string `json:"removeUrl,omitempty"`
+}
+
+func channelMetaFrom(
+	settings models.Channelable,
+	tabs []models.TeamsTabable,
+) ChannelMeta {
+	cm := ChannelMeta{}
+
+	if settings != nil {
+		cm.DisplayName = ptr.Val(settings.GetDisplayName())
+		cm.Settings = ChannelSettings{
+			Description:         ptr.Val(settings.GetDescription()),
+			IsFavoriteByDefault: settings.GetIsFavoriteByDefault(),
+		}
+	}
+
+	for _, tab := range tabs {
+		cm.Tabs = append(cm.Tabs, channelTabFrom(tab))
+	}
+
+	return cm
+}
+
+func channelTabFrom(tab models.TeamsTabable) ChannelTab {
+	ct := ChannelTab{
+		DisplayName: ptr.Val(tab.GetDisplayName()),
+	}
+
+	if tab.GetTeamsApp() != nil {
+		ct.TeamsAppID = ptr.Val(tab.GetTeamsApp().GetId())
+	}
+
+	if cfg := tab.GetConfiguration(); cfg != nil {
+		ct.EntityID = ptr.Val(cfg.GetEntityId())
+		ct.ContentURL = ptr.Val(cfg.GetContentUrl())
+		ct.WebsiteURL = ptr.Val(cfg.GetWebsiteUrl())
+		ct.RemoveURL = ptr.Val(cfg.GetRemoveUrl())
+	}
+
+	return ct
+}
+
+// ToTeamsTab produces the request body used to recreate the tab.
+func (ct ChannelTab) ToTeamsTab() models.TeamsTabable {
+	cfg := models.NewTeamsTabConfiguration()
+	cfg.SetEntityId(ptr.To(ct.EntityID))
+	cfg.SetContentUrl(ptr.To(ct.ContentURL))
+	cfg.SetWebsiteUrl(ptr.To(ct.WebsiteURL))
+	cfg.SetRemoveUrl(ptr.To(ct.RemoveURL))
+
+	tab := models.NewTeamsTab()
+	tab.SetDisplayName(ptr.To(ct.DisplayName))
+	tab.SetConfiguration(cfg)
+
+	return tab
+}
+
+// getChannelMeta fetches the channel's settings and tabs.
+func getChannelMeta(
+	ctx context.Context,
+	getter getChannelTabber,
+	teamID, channelID string,
+) (ChannelMeta, error) {
+	settings, err := getter.GetChannelSettings(ctx, teamID, channelID)
+	if err != nil {
+		return ChannelMeta{}, clues.Wrap(err, "getting channel settings")
+	}
+
+	tabs, err := getter.GetChannelTabs(ctx, teamID, channelID)
+	if err != nil {
+		return ChannelMeta{}, clues.Wrap(err, "getting channel tabs")
+	}
+
+	return channelMetaFrom(settings, tabs), nil
+}
+
+// channelMetaItem holds the serialized channel meta.  It doesn't implement
+// data.ItemInfo, which keeps it out of backup details.
+type channelMetaItem struct {
+	data []byte
+}
+
+func newChannelMetaItem(cm ChannelMeta) (*channelMetaItem, error) {
+	bs, err := json.Marshal(cm)
+	if err != nil {
+		return nil, clues.Wrap(err, "serializing channel meta")
+	}
+
+	return &channelMetaItem{data: bs}, nil
+}
+
+func (i channelMetaItem) ID() string {
+	return ChannelMetaFileName
+}
+
+func (i channelMetaItem) ToReader() io.ReadCloser {
+	return io.NopCloser(bytes.NewReader(i.data))
+}
+
+func (i channelMetaItem) Deleted() bool {
+	return false
+}
+
+// ReadChannelMeta deserializes the channel meta stored in the item.
+func ReadChannelMeta(item data.Item) (ChannelMeta, error) {
+	cm := ChannelMeta{}
+
+	rc := item.ToReader()
+	defer rc.Close()
+
+	if err := json.NewDecoder(rc).Decode(&cm); err != nil {
+		return ChannelMeta{}, clues.Wrap(err, "deserializing channel meta")
+	}
+
+	return cm, nil
+}
@@ -0,0 +1,193 @@
+package groups
+
+import (
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/m365/collection/groups/mock"
+	"github.com/alcionai/corso/src/internal/m365/support"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+type ChannelMetaUnitSuite struct {
+	tester.Suite
+}
+
+func TestChannelMetaUnitSuite(t *testing.T) {
+	suite.Run(t, &ChannelMetaUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func teamsTab(name, appID, entityID string) models.TeamsTabable {
+	tab := models.NewTeamsTab()
+	tab.SetDisplayName(ptr.To(name))
+
+	if len(appID) > 0 {
+		app := models.NewTeamsApp()
+		app.SetId(ptr.To(appID))
+		tab.SetTeamsApp(app)
+	}
+
+	cfg := models.NewTeamsTabConfiguration()
+	cfg.SetEntityId(ptr.To(entityID))
+	cfg.SetContentUrl(ptr.To("https://content/" + name))
+	tab.SetConfiguration(cfg)
+
+	return tab
+}
+
+func (suite *ChannelMetaUnitSuite) TestChannelMetaFrom() {
+	t := suite.T()
+
+	settings := models.NewChannel()
+	settings.SetDisplayName(ptr.To("General"))
+	settings.SetDescription(ptr.To("desc"))
+	settings.SetIsFavoriteByDefault(ptr.To(true))
+
+	cm := channelMetaFrom(
+		settings,
+		[]models.TeamsTabable{
+			teamsTab("plan", "com.microsoft.teamspace.tab.planner", "plan-id"),
+			teamsTab("no app", "", ""),
+		})
+
+	assert.Equal(
+		t,
+		ChannelMeta{
+			DisplayName: "General",
+			Settings: ChannelSettings{
+				Description:         "desc",
+				IsFavoriteByDefault: ptr.To(true),
+			},
+			Tabs: []ChannelTab{
+				{
+					DisplayName: "plan",
+					TeamsAppID:  "com.microsoft.teamspace.tab.planner",
+					EntityID:    "plan-id",
+					ContentURL:  "https://content/plan",
+				},
+				{
+					DisplayName: "no app",
+					ContentURL:  "https://content/no app",
+				},
+			},
+		},
+		cm)
+}
+
+func (suite *ChannelMetaUnitSuite) TestChannelMetaItem_roundTrip() {
+	t := suite.T()
+
+	cm := ChannelMeta{
+		Settings: ChannelSettings{Description: "desc"},
+		Tabs: []ChannelTab{
+			{DisplayName: "site", TeamsAppID: "com.microsoft.teamspace.tab.web", WebsiteURL: "https://site"},
+		},
+	}
+
+	item, err := newChannelMetaItem(cm)
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, ChannelMetaFileName, item.ID())
+	assert.False(t, item.Deleted())
+
+	_, ok := data.Item(item).(data.ItemInfo)
+	assert.False(t, ok, "channel meta must not be added to backup details")
+
+	result, err := ReadChannelMeta(item)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, cm, result)
+}
+
+func (suite *ChannelMetaUnitSuite) TestCollection_streamsChannelMeta() {
+	fullPath, err := path.Build("t", "pr", path.GroupsService, path.ChannelMessagesCategory, false, "chanID")
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	locPath, err := path.Build("t", "pr", path.GroupsService, path.ChannelMessagesCategory, false, "General")
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	table := []struct {
+		name       string
+		getter     mock.GetChannelMessage
+		expectMeta bool
+		expectTabs int
+	}{
+		{
+			name: "tabs",
+			getter: mock.GetChannelMessage{
+				Tabs: []models.TeamsTabable{
+					teamsTab("plan", "com.microsoft.teamspace.tab.planner", "plan-id"),
+					teamsTab("site", "com.microsoft.teamspace.tab.web", ""),
+				},
+			},
+			expectMeta: true,
+			expectTabs: 2,
+		},
+		{
+			name:       "no tabs",
+			getter:     mock.GetChannelMessage{},
+			expectMeta: true,
+		},
+		{
+			name: "tabs error",
+			getter: mock.GetChannelMessage{
+				TabsErr: assert.AnError,
+			},
+			expectMeta: false,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			var (
+				t    = suite.T()
+				errs = fault.New(true)
+				meta *ChannelMeta
+			)
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			col := &Collection{
+				added:         map[string]struct{}{"msg": {}},
+				ctrl:          control.DefaultOptions(),
+				getter:        test.getter,
+				stream:        make(chan data.Item),
+				fullPath:      fullPath,
+				locationPath:  locPath.ToBuilder(),
+				statusUpdater: func(*support.ControllerOperationStatus) {},
+			}
+
+			go col.streamItems(ctx, errs)
+
+			for item := range col.stream {
+				if item.ID() != ChannelMetaFileName {
+					continue
+				}
+
+				cm, err := ReadChannelMeta(item)
+				require.NoError(t, err, clues.ToCore(err))
+
+				meta = &cm
+			}
+
+			assert.NoError(t, errs.Failure(), clues.ToCore(errs.Failure()))
+
+			if !test.expectMeta {
+				assert.Nil(t, meta, "channel meta item")
+				return
+			}
+
+			require.NotNil(t, meta, "channel meta item")
+			assert.Len(t, meta.Tabs, test.expectTabs)
+		})
+	}
+}
@@ -13,8 +13,10 @@ import (
 
 	"github.com/alcionai/corso/src/internal/common/ptr"
 	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/m365/graph"
 	"github.com/alcionai/corso/src/internal/m365/support"
 	"github.com/alcionai/corso/src/internal/observe"
+	"github.com/alcionai/corso/src/internal/stats"
 	"github.com/alcionai/corso/src/pkg/backup/details"
 	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/fault"
@@ -43,7 +45,7 @@ type Collection struct {
 	// removed is a list of item IDs that were deleted from, or moved out, of a container
 	removed map[string]struct{}
 
-	getter getChannelMessager
+	getter channelItemGetter
 
 	category      path.CategoryType
 	statusUpdater support.StatusUpdater
@@ -74,7 +76,7 @@ type Collection struct {
 // If both are populated, then state is either moved (if they differ),
 // or notMoved (if they match).
 func NewCollection(
-	getter getChannelMessager,
+	getter channelItemGetter,
 	protectedResource string,
 	curr, prev path.Path,
 	location *path.Builder,
@@ -198,12 +200,18 @@ func (col *Collection) streamItems(ctx context.Context, errs *fault.Bus) {
 		wg            sync.WaitGroup
 		colProgress   chan<- struct{}
 		el            = errs.Local()
+		sampler       = stats.NewConcurrencySampler(col.ctrl.Parallelism.ItemFetch)
 	)
 
 	ctx = clues.Add(ctx, "category", col.category.String())
 
 	defer func() {
-		col.finishPopulation(ctx, streamedItems, totalBytes, errs.Failure())
+		col.finishPopulation(
+			ctx,
+			streamedItems,
+			totalBytes,
+			sampler.Summary(col.FullPath().Folder(false)),
+			errs.Failure())
 	}()
 
 	if len(col.added)+len(col.removed) > 0 {
@@ -214,18 +222,26 @@ func (col *Collection) streamItems(ctx context.Context, errs *fault.Bus) {
 		defer close(colProgress)
 	}
 
-	semaphoreCh := make(chan struct{}, col.ctrl.Parallelism.ItemFetch)
-	defer close(semaphoreCh)
+	// the limiter lowers item fetch concurrency while graph is throttling us.
+	limiter, stopLimiter := graph.ItemFetchLimiter(ctx, col.ctrl.Parallelism.ItemFetch)
+	defer stopLimiter()
 
 	// delete all removed items
 	for id := range col.removed {
-		semaphoreCh <- struct{}{}
+		waitStart := time.Now()
 
+		if err := limiter.Acquire(ctx); err != nil {
+			el.AddRecoverable(ctx, clues.Stack(err))
+			break
+		}
+
+		sampler.Acquired(time.Since(waitStart))
 		wg.Add(1)
 
 		go func(id string) {
 			defer wg.Done()
-			defer func() { <-semaphoreCh }()
+			defer limiter.Release()
+			defer sampler.Released()
 
 			col.stream <- &Item{
 				id:      id,
@@ -248,12 +264,20 @@ func (col *Collection) streamItems(ctx context.Context, errs *fault.Bus) {
 			break
 		}
 
+		waitStart := time.Now()
+
+		if err := limiter.Acquire(ctx); err != nil {
+			el.AddRecoverable(ctx, clues.Stack(err))
+			break
+		}
+
+		sampler.Acquired(time.Since(waitStart))
 		wg.Add(1)
-		semaphoreCh <- struct{}{}
 
 		go func(id string) {
 			defer wg.Done()
-			defer func() { <-semaphoreCh }()
+			defer limiter.Release()
+			defer sampler.Released()
 
 			writer := kjson.NewJsonSerializationWriter()
 			defer writer.Close()
@@ -301,6 +325,36 @@ func (col *Collection) streamItems(ctx context.Context, errs *fault.Bus) {
 	}
 
 	wg.Wait()
+
+	if el.Failure() == nil {
+		col.streamChannelMeta(ctx)
+	}
+}
+
+// streamChannelMeta adds the channel's settings and tabs to the stream.
+// Failures are logged instead of failing the collection, since the
+// messages are still backed up without them.
+func (col *Collection) streamChannelMeta(ctx context.Context) {
+	flds := col.fullPath.Folders()
+	channelID := flds[len(flds)-1]
+
+	cm, err := getChannelMeta(ctx, col.getter, col.protectedResource, channelID)
+	if err != nil {
+		logger.CtxErr(ctx, err).Info("getting channel settings and tabs")
+		return
+	}
+
+	item, err := newChannelMetaItem(cm)
+	if err != nil {
+		logger.CtxErr(ctx, err).Info("serializing channel settings and tabs")
+		return
+	}
+
+	select {
+	case <-ctx.Done():
+		logger.CtxErr(ctx, ctx.Err()).Info("streaming channel settings and tabs")
+	case col.stream <- item:
+	}
 }
 
 // finishPopulation is a utility function used to close a Collection's data channel
@@ -308,6 +362,7 @@ func (col *Collection) streamItems(ctx context.Context, errs *fault.Bus) {
 func (col *Collection) finishPopulation(
 	ctx context.Context,
 	streamedItems, totalBytes int64,
+	concurrency stats.ItemConcurrency,
 	err error,
 ) {
 	close(col.stream)
@@ -323,8 +378,12 @@ func (col *Collection) finishPopulation(
 			Bytes:     totalBytes,
 		},
 		col.FullPath().Folder(false))
+	status.Concurrency = []stats.ItemConcurrency{concurrency}
 
-	logger.Ctx(ctx).Debugw("done streaming items", "status", status.String())
+	logger.Ctx(ctx).Debugw(
+		"done streaming items",
+		"status", status.String(),
+		"item_concurrency", concurrency)
 
 	col.statusUpdater(status)
 }
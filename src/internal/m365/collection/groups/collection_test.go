@@ -2,6 +2,7 @@ package groups
 
 import (
 	"bytes"
+	"context"
 	"testing"
 	"time"
 
@@ -216,6 +217,10 @@ func (suite *CollectionUnitSuite) TestCollection_streamItems() {
 			go col.streamItems(ctx, errs)
 
 			for item := range col.stream {
+				if item.ID() == ChannelMetaFileName {
+					continue
+				}
+
 				itemCount++
 
 				_, aok := test.added[item.ID()]
@@ -243,3 +248,36 @@ func (suite *CollectionUnitSuite) TestCollection_streamItems() {
 		})
 	}
 }
+
+func (suite *CollectionUnitSuite) TestCollection_streamChannelMeta_canceled() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	fullPath, err := path.Build("t", "pr", path.GroupsService, path.ChannelMessagesCategory, false, "channel-id")
+	require.NoError(t, err, clues.ToCore(err))
+
+	col := &Collection{
+		getter:   mock.GetChannelMessage{},
+		stream:   make(chan data.Item),
+		fullPath: fullPath,
+	}
+
+	// nothing reads the stream, so the send only ends once ctx is done.
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		col.streamChannelMeta(ctx)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		assert.Fail(t, "streaming channel meta blocked after ctx was canceled")
+	}
+}
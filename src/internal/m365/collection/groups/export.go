@@ -51,7 +51,7 @@ func streamItems(
 			if err != nil {
 				ch <- export.Item{
 					ID:    item.ID(),
-					Error: err,
+					Error: export.ItemError(err, rc.FullPath(), item.ID()),
 				}
 			} else {
 				ch <- export.Item{
@@ -69,13 +69,13 @@ func streamItems(
 		for _, item := range items {
 			ch <- export.Item{
 				ID:    item.ID,
-				Error: &item,
+				Error: export.ItemError(&item, rc.FullPath(), item.ID),
 			}
 		}
 
 		for _, err := range recovered {
 			ch <- export.Item{
-				Error: err,
+				Error: export.ItemError(err, rc.FullPath(), ""),
 			}
 		}
 	}
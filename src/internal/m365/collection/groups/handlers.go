@@ -14,7 +14,7 @@ import (
 )
 
 type backupHandler interface {
-	getChannelMessager
+	channelItemGetter
 
 	// gets all channels for the group
 	getChannels(
@@ -51,3 +51,21 @@ type getChannelMessager interface {
 		teamID, channelID, itemID string,
 	) (models.ChatMessageable, *details.GroupsInfo, error)
 }
+
+type getChannelTabber interface {
+	GetChannelSettings(
+		ctx context.Context,
+		teamID, channelID string,
+	) (models.Channelable, error)
+
+	GetChannelTabs(
+		ctx context.Context,
+		teamID, channelID string,
+	) ([]models.TeamsTabable, error)
+}
+
+// channelItemGetter fetches everything a channel collection streams.
+type channelItemGetter interface {
+	getChannelMessager
+	getChannelTabber
+}
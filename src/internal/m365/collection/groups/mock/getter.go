@@ -11,6 +11,10 @@ import (
 
 type GetChannelMessage struct {
 	Err error
+
+	Settings models.Channelable
+	Tabs     []models.TeamsTabable
+	TabsErr  error
 }
 
 func (m GetChannelMessage) GetChannelMessage(
@@ -22,3 +26,21 @@ func (m GetChannelMessage) GetChannelMessage(
 
 	return msg, &details.GroupsInfo{}, m.Err
 }
+
+func (m GetChannelMessage) GetChannelSettings(
+	ctx context.Context,
+	teamID, channelID string,
+) (models.Channelable, error) {
+	if m.Settings == nil {
+		return models.NewChannel(), nil
+	}
+
+	return m.Settings, nil
+}
+
+func (m GetChannelMessage) GetChannelTabs(
+	ctx context.Context,
+	teamID, channelID string,
+) ([]models.TeamsTabable, error) {
+	return m.Tabs, m.TabsErr
+}
@@ -0,0 +1,98 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+)
+
+// ChannelRestorer records the channel changes made during a restore.
+type ChannelRestorer struct {
+	// ChannelID is the ID of the existing channel.  If empty, the
+	// channel isn't found, and gets created.
+	ChannelID      string
+	PostChannelErr error
+
+	PatchErr error
+
+	Tabs    []models.TeamsTabable
+	TabsErr error
+	// PostTabErrs is keyed by the tab's display name.
+	PostTabErrs map[string]error
+
+	CreatedChannels []string
+	PatchedSettings []models.Channelable
+	PostedTabs      []string
+}
+
+func (m *ChannelRestorer) GetChannelByName(
+	ctx context.Context,
+	teamID, containerName string,
+) (models.Channelable, error) {
+	if len(m.ChannelID) == 0 {
+		return nil, clues.New("channel not found")
+	}
+
+	ch := models.NewChannel()
+	ch.SetId(ptr.To(m.ChannelID))
+	ch.SetDisplayName(ptr.To(containerName))
+
+	return ch, nil
+}
+
+func (m *ChannelRestorer) PostChannel(
+	ctx context.Context,
+	teamID, containerName string,
+) (models.Channelable, error) {
+	if m.PostChannelErr != nil {
+		return nil, m.PostChannelErr
+	}
+
+	m.CreatedChannels = append(m.CreatedChannels, containerName)
+
+	ch := models.NewChannel()
+	ch.SetId(ptr.To("new-" + containerName))
+	ch.SetDisplayName(ptr.To(containerName))
+
+	return ch, nil
+}
+
+func (m *ChannelRestorer) PatchChannelSettings(
+	ctx context.Context,
+	teamID, channelID string,
+	body models.Channelable,
+) error {
+	if m.PatchErr != nil {
+		return m.PatchErr
+	}
+
+	m.PatchedSettings = append(m.PatchedSettings, body)
+
+	return nil
+}
+
+func (m *ChannelRestorer) GetChannelTabs(
+	ctx context.Context,
+	teamID, channelID string,
+) ([]models.TeamsTabable, error) {
+	return m.Tabs, m.TabsErr
+}
+
+func (m *ChannelRestorer) PostChannelTab(
+	ctx context.Context,
+	teamID, channelID, teamsAppID string,
+	body models.TeamsTabable,
+) (models.TeamsTabable, error) {
+	name := ptr.Val(body.GetDisplayName())
+
+	if err := m.PostTabErrs[name]; err != nil {
+		return nil, err
+	}
+
+	m.PostedTabs = append(m.PostedTabs, name)
+
+	return body, nil
+}
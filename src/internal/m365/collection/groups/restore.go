@@ -0,0 +1,216 @@
+package groups
+
+import (
+	"context"
+	"errors"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/m365/graph"
+	"github.com/alcionai/corso/src/internal/m365/support"
+	"github.com/alcionai/corso/src/internal/operations/inject"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+)
+
+// wikiTabAppID identifies the channel wiki.  Graph doesn't support
+// creating wiki tabs.
+const wikiTabAppID = "com.microsoft.teamspace.tab.wiki"
+
+type channelRestorer interface {
+	GetChannelByName(
+		ctx context.Context,
+		teamID, containerName string,
+	) (models.Channelable, error)
+
+	PostChannel(
+		ctx context.Context,
+		teamID, containerName string,
+	) (models.Channelable, error)
+
+	PatchChannelSettings(
+		ctx context.Context,
+		teamID, channelID string,
+		body models.Channelable,
+	) error
+
+	GetChannelTabs(
+		ctx context.Context,
+		teamID, channelID string,
+	) ([]models.TeamsTabable, error)
+
+	PostChannelTab(
+		ctx context.Context,
+		teamID, channelID, teamsAppID string,
+		body models.TeamsTabable,
+	) (models.TeamsTabable, error)
+}
+
+// RestoreChannelMeta recreates the settings and tabs of the channel in the
+// collection.  Graph doesn't support restoring channel messages, so the
+// collection's messages are left alone.  The meta is restored into the
+// channel with the backed up channel's name, which is created if it no
+// longer exists.  The name comes from the meta, since the collection's
+// storage path only holds the channel's ID.  Tabs are restored best-effort: tabs that already exist
+// in the channel, or that reference resources graph can't resolve, are
+// skipped.
+func RestoreChannelMeta(
+	ctx context.Context,
+	cr channelRestorer,
+	rcc inject.RestoreConsumerConfig,
+	dc data.RestoreCollection,
+	errs *fault.Bus,
+	ctr *count.Bus,
+) (support.CollectionMetrics, error) {
+	var (
+		metrics = support.CollectionMetrics{}
+		teamID  = rcc.ProtectedResource.ID()
+		el      = errs.Local()
+	)
+
+	ctx = clues.Add(ctx, "storage_path", dc.FullPath())
+
+	item, err := dc.FetchItemByName(ctx, ChannelMetaFileName)
+	if errors.Is(err, data.ErrNotFound) {
+		// backups made before channel meta was stored have nothing to restore.
+		logger.Ctx(ctx).Info("no channel meta in backup")
+		return metrics, nil
+	}
+
+	if err != nil {
+		return metrics, clues.Wrap(err, "fetching channel meta").WithClues(ctx)
+	}
+
+	cm, err := ReadChannelMeta(item)
+	if err != nil {
+		return metrics, clues.Stack(err).WithClues(ctx)
+	}
+
+	channelName := cm.DisplayName
+	if len(channelName) == 0 {
+		return metrics, clues.New("channel meta has no channel name").WithClues(ctx)
+	}
+
+	ctx = clues.Add(ctx, "channel_name", clues.Hide(channelName))
+
+	channelID, created, err := ensureChannelExists(ctx, cr, teamID, channelName)
+	if err != nil {
+		return metrics, clues.Stack(err).WithClues(ctx)
+	}
+
+	ctx = clues.Add(ctx, "channel_id", channelID)
+
+	// settings on an existing channel are only overwritten when the
+	// restore is allowed to replace existing data.
+	if created || rcc.RestoreConfig.OnCollision == control.Replace {
+		body := models.NewChannel()
+		body.SetDescription(ptr.To(cm.Settings.Description))
+		body.SetIsFavoriteByDefault(cm.Settings.IsFavoriteByDefault)
+
+		if err := cr.PatchChannelSettings(ctx, teamID, channelID, body); err != nil {
+			el.AddRecoverable(ctx, clues.Stack(err))
+		}
+	}
+
+	existing, err := cr.GetChannelTabs(ctx, teamID, channelID)
+	if err != nil {
+		return metrics, clues.Wrap(err, "getting existing channel tabs").WithClues(ctx)
+	}
+
+	existingKeys := map[string]struct{}{}
+
+	for _, tab := range existing {
+		existingKeys[channelTabFrom(tab).collisionKey()] = struct{}{}
+	}
+
+	for _, tab := range cm.Tabs {
+		if el.Failure() != nil {
+			break
+		}
+
+		ictx := clues.Add(
+			ctx,
+			"tab_name", clues.Hide(tab.DisplayName),
+			"teams_app_id", tab.TeamsAppID)
+
+		metrics.Objects++
+
+		if _, ok := existingKeys[tab.collisionKey()]; ok {
+			logger.Ctx(ictx).Debug("skipping existing channel tab")
+			ctr.Inc(count.CollisionSkip)
+
+			continue
+		}
+
+		if len(tab.TeamsAppID) == 0 || tab.TeamsAppID == wikiTabAppID {
+			logger.Ctx(ictx).Info("skipping unsupported channel tab")
+			ctr.Inc(count.ChannelTabUnresolvable)
+
+			continue
+		}
+
+		_, err := cr.PostChannelTab(ictx, teamID, channelID, tab.TeamsAppID, tab.ToTeamsTab())
+		if isUnresolvableTabErr(err) {
+			logger.CtxErr(ictx, err).Info("skipping channel tab with unresolvable configuration")
+			ctr.Inc(count.ChannelTabUnresolvable)
+
+			continue
+		}
+
+		if err != nil {
+			el.AddRecoverable(ictx, clues.Stack(err))
+			continue
+		}
+
+		metrics.Successes++
+
+		ctr.Inc(count.NewItemCreated)
+	}
+
+	return metrics, el.Failure()
+}
+
+// ensureChannelExists returns the ID of the channel with the given name,
+// creating the channel if none exists.  The bool is true if the channel
+// was created.
+func ensureChannelExists(
+	ctx context.Context,
+	cr channelRestorer,
+	teamID, channelName string,
+) (string, bool, error) {
+	ch, err := cr.GetChannelByName(ctx, teamID, channelName)
+	if err == nil {
+		return ptr.Val(ch.GetId()), false, nil
+	}
+
+	// channel names are unique within a team, so if the lookup failed for
+	// any reason besides a missing channel, creating it fails as well.
+	logger.CtxErr(ctx, err).Info("channel not found, creating it")
+
+	ch, err = cr.PostChannel(ctx, teamID, channelName)
+	if err != nil {
+		return "", false, clues.Wrap(err, "creating restore channel")
+	}
+
+	return ptr.Val(ch.GetId()), true, nil
+}
+
+// collisionKey identifies tabs that are considered the same tab.
+func (ct ChannelTab) collisionKey() string {
+	return ct.TeamsAppID + ct.DisplayName
+}
+
+// isUnresolvableTabErr is true if graph rejected the tab because its
+// app or configuration references something that doesn't exist, or
+// isn't available, in the team.
+func isUnresolvableTabErr(err error) bool {
+	return err != nil &&
+		(graph.IsErrBadRequest(err) ||
+			graph.IsErrItemNotFound(err) ||
+			graph.IsErrAccessDenied(err))
+}
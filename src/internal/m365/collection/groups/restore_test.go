@@ -0,0 +1,232 @@
+package groups
+
+import (
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/models/odataerrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/dttm"
+	"github.com/alcionai/corso/src/internal/common/idname"
+	"github.com/alcionai/corso/src/internal/data"
+	dataMock "github.com/alcionai/corso/src/internal/data/mock"
+	"github.com/alcionai/corso/src/internal/m365/collection/groups/mock"
+	"github.com/alcionai/corso/src/internal/operations/inject"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+type RestoreUnitSuite struct {
+	tester.Suite
+}
+
+func TestRestoreUnitSuite(t *testing.T) {
+	suite.Run(t, &RestoreUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func odErr(code string) *odataerrors.ODataError {
+	odErr := odataerrors.NewODataError()
+	merr := odataerrors.NewMainError()
+	merr.SetCode(&code)
+	odErr.SetErrorEscaped(merr)
+
+	return odErr
+}
+
+func (suite *RestoreUnitSuite) TestRestoreChannelMeta() {
+	var (
+		planner = ChannelTab{
+			DisplayName: "plan",
+			TeamsAppID:  "com.microsoft.teamspace.tab.planner",
+			EntityID:    "plan-id",
+		}
+		site = ChannelTab{
+			DisplayName: "site",
+			TeamsAppID:  "com.microsoft.teamspace.tab.web",
+			WebsiteURL:  "https://site",
+		}
+		wiki = ChannelTab{
+			DisplayName: "wiki",
+			TeamsAppID:  wikiTabAppID,
+		}
+		noApp = ChannelTab{
+			DisplayName: "no app",
+		}
+		cm = ChannelMeta{
+			DisplayName: "General",
+			Settings:    ChannelSettings{Description: "desc"},
+			Tabs:        []ChannelTab{planner, site, wiki, noApp},
+		}
+	)
+
+	table := []struct {
+		name            string
+		cr              *mock.ChannelRestorer
+		noMeta          bool
+		noName          bool
+		onCollision     control.CollisionPolicy
+		expectErr       assert.ErrorAssertionFunc
+		expectRecovered int
+		expectCreated   []string
+		expectPatched   int
+		expectPosted    []string
+		expectCounts    map[string]int64
+	}{
+		{
+			name:          "new channel",
+			cr:            &mock.ChannelRestorer{},
+			onCollision:   control.Skip,
+			expectErr:     assert.NoError,
+			expectCreated: []string{"General"},
+			expectPatched: 1,
+			expectPosted:  []string{"plan", "site"},
+			expectCounts: map[string]int64{
+				string(count.NewItemCreated):         2,
+				string(count.ChannelTabUnresolvable): 2,
+			},
+		},
+		{
+			name: "existing channel, existing tab",
+			cr: &mock.ChannelRestorer{
+				ChannelID: "chan",
+				Tabs:      []models.TeamsTabable{teamsTab("plan", planner.TeamsAppID, "plan-id")},
+			},
+			onCollision:  control.Skip,
+			expectErr:    assert.NoError,
+			expectPosted: []string{"site"},
+			expectCounts: map[string]int64{
+				string(count.NewItemCreated):         1,
+				string(count.CollisionSkip):          1,
+				string(count.ChannelTabUnresolvable): 2,
+			},
+		},
+		{
+			name: "existing channel, replace settings",
+			cr: &mock.ChannelRestorer{
+				ChannelID: "chan",
+				Tabs:      []models.TeamsTabable{teamsTab("site", site.TeamsAppID, "")},
+			},
+			onCollision:   control.Replace,
+			expectErr:     assert.NoError,
+			expectPatched: 1,
+			expectPosted:  []string{"plan"},
+			expectCounts: map[string]int64{
+				string(count.NewItemCreated):         1,
+				string(count.CollisionSkip):          1,
+				string(count.ChannelTabUnresolvable): 2,
+			},
+		},
+		{
+			name: "unresolvable tab configuration",
+			cr: &mock.ChannelRestorer{
+				ChannelID: "chan",
+				PostTabErrs: map[string]error{
+					"plan": odErr("BadRequest"),
+				},
+			},
+			onCollision:  control.Skip,
+			expectErr:    assert.NoError,
+			expectPosted: []string{"site"},
+			expectCounts: map[string]int64{
+				string(count.NewItemCreated):         1,
+				string(count.ChannelTabUnresolvable): 3,
+			},
+		},
+		{
+			name: "tab post failure",
+			cr: &mock.ChannelRestorer{
+				ChannelID: "chan",
+				PostTabErrs: map[string]error{
+					"plan": assert.AnError,
+				},
+			},
+			onCollision:     control.Skip,
+			expectErr:       assert.NoError,
+			expectRecovered: 1,
+			expectPosted:    []string{"site"},
+			expectCounts: map[string]int64{
+				string(count.NewItemCreated):         1,
+				string(count.ChannelTabUnresolvable): 2,
+			},
+		},
+		{
+			name: "channel creation failure",
+			cr: &mock.ChannelRestorer{
+				PostChannelErr: assert.AnError,
+			},
+			onCollision:  control.Skip,
+			expectErr:    assert.Error,
+			expectCounts: map[string]int64{},
+		},
+		{
+			name:         "no channel name in meta",
+			cr:           &mock.ChannelRestorer{},
+			noName:       true,
+			onCollision:  control.Skip,
+			expectErr:    assert.Error,
+			expectCounts: map[string]int64{},
+		},
+		{
+			name:         "no channel meta in backup",
+			cr:           &mock.ChannelRestorer{},
+			noMeta:       true,
+			onCollision:  control.Skip,
+			expectErr:    assert.NoError,
+			expectCounts: map[string]int64{},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			// channel collections are stored under the channel's ID.
+			fullPath, err := path.Build("t", "team", path.GroupsService, path.ChannelMessagesCategory, false, "channel-id")
+			require.NoError(t, err, clues.ToCore(err))
+
+			aux := map[string]data.Item{}
+
+			if !test.noMeta {
+				meta := cm
+				if test.noName {
+					meta.DisplayName = ""
+				}
+
+				item, err := newChannelMetaItem(meta)
+				require.NoError(t, err, clues.ToCore(err))
+
+				aux[ChannelMetaFileName] = item
+			}
+
+			rcc := inject.RestoreConsumerConfig{
+				ProtectedResource: idname.NewProvider("team", "team"),
+				RestoreConfig:     control.DefaultRestoreConfig(dttm.HumanReadable),
+			}
+			rcc.RestoreConfig.OnCollision = test.onCollision
+
+			var (
+				errs = fault.New(false)
+				ctr  = count.New()
+				dc   = dataMock.Collection{Path: fullPath, AuxItems: aux}
+			)
+
+			_, err = RestoreChannelMeta(ctx, test.cr, rcc, dc, errs, ctr)
+			test.expectErr(t, err, clues.ToCore(err))
+
+			assert.Len(t, errs.Recovered(), test.expectRecovered, "recovered errors")
+			assert.Equal(t, test.expectCreated, test.cr.CreatedChannels, "created channels")
+			assert.Len(t, test.cr.PatchedSettings, test.expectPatched, "patched settings")
+			assert.ElementsMatch(t, test.expectPosted, test.cr.PostedTabs, "posted tabs")
+			assert.Equal(t, test.expectCounts, ctr.Values(), "counts")
+		})
+	}
+}
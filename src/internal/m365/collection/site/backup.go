@@ -43,6 +43,8 @@ func CollectLibraries(
 			bpc.Options)
 	)
 
+	colls.SetChecksums(bpc.DriveChecksums)
+
 	odcs, canUsePreviousBackup, err := colls.Get(ctx, bpc.MetadataCollections, ssmb, errs)
 	if err != nil {
 		return nil, false, graph.Wrap(ctx, err, "getting library")
@@ -143,6 +145,10 @@ func CollectLists(
 			break
 		}
 
+		if !scope.Matches(selectors.SharePointList, tuple.Name) {
+			continue
+		}
+
 		dir, err := path.Build(
 			tenantID,
 			bpc.ProtectedResource.ID(),
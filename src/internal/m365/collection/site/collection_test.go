@@ -218,7 +218,7 @@ func (suite *SharePointCollectionSuite) TestListCollection_Restore() {
 
 	destName := testdata.DefaultRestoreConfig("").Location
 
-	deets, err := restoreListItem(ctx, service, listData, suite.siteID, destName)
+	deets, err := restoreListItem(ctx, service, listData, suite.siteID, destName, fault.New(true))
 	assert.NoError(t, err, clues.ToCore(err))
 	t.Logf("List created: %s\n", deets.SharePoint.ItemName)
 
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"runtime/trace"
+	"strings"
 
 	"github.com/alcionai/clues"
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
@@ -41,10 +42,11 @@ func ConsumeRestoreCollections(
 	ctr *count.Bus,
 ) (*support.ControllerOperationStatus, error) {
 	var (
-		lrh            = drive.NewLibraryRestoreHandler(ac, rcc.Selector.PathService())
-		restoreMetrics support.CollectionMetrics
-		caches         = drive.NewRestoreCaches(backupDriveIDNames)
-		el             = errs.Local()
+		lrh               = drive.NewLibraryRestoreHandler(ac, rcc.Selector.PathService())
+		restoreMetrics    support.CollectionMetrics
+		caches            = drive.NewRestoreCaches(backupDriveIDNames)
+		el                = errs.Local()
+		fallbackDriveName = control.DefaultRestoreContainerName(dttm.HumanReadableDriveItem)
 	)
 
 	err := caches.Populate(ctx, lrh, rcc.ProtectedResource.ID())
@@ -56,6 +58,8 @@ func ConsumeRestoreCollections(
 	// before the child directories; a requirement for permissions.
 	data.SortRestoreCollections(dcs)
 
+	drive.EnsureDrivesExist(ctx, lrh, rcc, caches, dcs, fallbackDriveName)
+
 	// Iterate through the data collections and restore the contents of each
 	for _, dc := range dcs {
 		if el.Failure() != nil {
@@ -82,7 +86,7 @@ func ConsumeRestoreCollections(
 				dc,
 				caches,
 				deets,
-				control.DefaultRestoreContainerName(dttm.HumanReadableDriveItem),
+				fallbackDriveName,
 				errs,
 				ctr)
 
@@ -138,6 +142,7 @@ func restoreListItem(
 	service graph.Servicer,
 	itemData data.Item,
 	siteID, destName string,
+	errs *fault.Bus,
 ) (details.ItemInfo, error) {
 	ctx, end := diagnostics.Span(ctx, "m365:sharepoint:restoreList", diagnostics.Label("item_uuid", itemData.ID()))
 	defer end()
@@ -185,6 +190,20 @@ func restoreListItem(
 	// Uploading of ListItems is conducted after the List is restored
 	// Reference: https://learn.microsoft.com/en-us/graph/api/listitem-create?view=graph-rest-1.0&tabs=http
 	if len(contents) > 0 {
+		columns, err := service.Client().
+			Sites().
+			BySiteIdString(siteID).
+			Lists().
+			ByListIdString(ptr.Val(restoredList.GetId())).
+			Columns().
+			Get(ctx, nil)
+		if err != nil {
+			return dii, graph.Wrap(ctx, err, "getting restored list columns").
+				With("restored_list_id", ptr.Val(restoredList.GetId()))
+		}
+
+		mapListItemFields(ctx, contents, columns.GetValue(), errs)
+
 		for _, lItem := range contents {
 			_, err := service.Client().
 				Sites().
@@ -246,7 +265,8 @@ func RestoreListCollection(
 				service,
 				itemData,
 				siteID,
-				restoreContainerName)
+				restoreContainerName,
+				errs)
 			if err != nil {
 				el.AddRecoverable(ctx, err)
 				continue
@@ -276,6 +296,76 @@ func RestoreListCollection(
 	return metrics, el.Failure()
 }
 
+// mapListItemFields maps the field values of each backed up list item onto
+// the columns currently defined in the destination list.  Fields are matched
+// by column name first, and then case-insensitively by name or display name,
+// in which case the field is renamed to the destination's column name.
+// Fields without a matching column are dropped from every item, and each
+// dropped column is reported once as a recoverable error, so that a schema
+// mismatch doesn't fail the restore of the whole list.
+func mapListItemFields(
+	ctx context.Context,
+	items []models.ListItemable,
+	columns []models.ColumnDefinitionable,
+	errs *fault.Bus,
+) {
+	var (
+		names   = map[string]struct{}{}
+		lowered = map[string]string{}
+		dropped = map[string]struct{}{}
+	)
+
+	for _, col := range columns {
+		name := ptr.Val(col.GetName())
+		if len(name) == 0 {
+			continue
+		}
+
+		names[name] = struct{}{}
+		lowered[strings.ToLower(name)] = name
+
+		if dn := strings.ToLower(ptr.Val(col.GetDisplayName())); len(dn) > 0 {
+			if _, ok := lowered[dn]; !ok {
+				lowered[dn] = name
+			}
+		}
+	}
+
+	for _, item := range items {
+		fields := item.GetFields()
+		if fields == nil {
+			continue
+		}
+
+		mapped := make(map[string]any, len(fields.GetAdditionalData()))
+
+		for key, value := range fields.GetAdditionalData() {
+			if _, ok := names[key]; ok {
+				mapped[key] = value
+				continue
+			}
+
+			if name, ok := lowered[strings.ToLower(key)]; ok {
+				if _, exists := mapped[name]; !exists {
+					mapped[name] = value
+				}
+
+				continue
+			}
+
+			dropped[key] = struct{}{}
+		}
+
+		fields.SetAdditionalData(mapped)
+	}
+
+	for key := range dropped {
+		errs.AddRecoverable(ctx, clues.New("list column not found in destination list").
+			WithClues(ctx).
+			With("list_column", clues.Hide(key)))
+	}
+}
+
 // RestorePageCollection handles restoration of an individual site page collection.
 // returns:
 // - the collection's item and byte count metrics
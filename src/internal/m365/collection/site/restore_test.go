@@ -0,0 +1,169 @@
+package site
+
+import (
+	"testing"
+
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/fault"
+)
+
+type RestoreUnitSuite struct {
+	tester.Suite
+}
+
+func TestRestoreUnitSuite(t *testing.T) {
+	suite.Run(t, &RestoreUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func column(name, displayName string) models.ColumnDefinitionable {
+	cd := models.NewColumnDefinition()
+	cd.SetName(ptr.To(name))
+	cd.SetDisplayName(ptr.To(displayName))
+
+	return cd
+}
+
+func listItem(fields map[string]any) models.ListItemable {
+	fvs := models.NewFieldValueSet()
+	fvs.SetAdditionalData(fields)
+
+	item := models.NewListItem()
+	item.SetFields(fvs)
+
+	return item
+}
+
+func (suite *RestoreUnitSuite) TestMapListItemFields() {
+	table := []struct {
+		name          string
+		columns       []models.ColumnDefinitionable
+		items         []map[string]any
+		expect        []map[string]any
+		expectDropped int
+	}{
+		{
+			name: "matching schema",
+			columns: []models.ColumnDefinitionable{
+				column("Title", "Title"),
+				column("Status", "Status"),
+			},
+			items: []map[string]any{
+				{"Title": "a", "Status": "open"},
+				{"Title": "b", "Status": "closed"},
+			},
+			expect: []map[string]any{
+				{"Title": "a", "Status": "open"},
+				{"Title": "b", "Status": "closed"},
+			},
+		},
+		{
+			name: "column missing in destination",
+			columns: []models.ColumnDefinitionable{
+				column("Title", "Title"),
+			},
+			items: []map[string]any{
+				{"Title": "a", "Status": "open", "Owner": "me"},
+				{"Title": "b", "Status": "closed"},
+			},
+			expect: []map[string]any{
+				{"Title": "a"},
+				{"Title": "b"},
+			},
+			expectDropped: 2,
+		},
+		{
+			name: "extra column in destination",
+			columns: []models.ColumnDefinitionable{
+				column("Title", "Title"),
+				column("Status", "Status"),
+				column("Priority", "Priority"),
+			},
+			items: []map[string]any{
+				{"Title": "a", "Status": "open"},
+			},
+			expect: []map[string]any{
+				{"Title": "a", "Status": "open"},
+			},
+		},
+		{
+			name: "renamed by case",
+			columns: []models.ColumnDefinitionable{
+				column("Title", "Title"),
+				column("status", "Status"),
+			},
+			items: []map[string]any{
+				{"Title": "a", "Status": "open"},
+			},
+			expect: []map[string]any{
+				{"Title": "a", "status": "open"},
+			},
+		},
+		{
+			name: "matched by display name",
+			columns: []models.ColumnDefinitionable{
+				column("Title", "Title"),
+				column("field_1", "Due Date"),
+			},
+			items: []map[string]any{
+				{"Title": "a", "due date": "2023-01-01"},
+			},
+			expect: []map[string]any{
+				{"Title": "a", "field_1": "2023-01-01"},
+			},
+		},
+		{
+			name: "exact name takes precedence",
+			columns: []models.ColumnDefinitionable{
+				column("Status", "Status"),
+			},
+			items: []map[string]any{
+				{"Status": "exact", "STATUS": "loose"},
+			},
+			expect: []map[string]any{
+				{"Status": "exact"},
+			},
+		},
+		{
+			name:    "no destination columns",
+			columns: []models.ColumnDefinitionable{},
+			items: []map[string]any{
+				{"Title": "a"},
+			},
+			expect: []map[string]any{
+				{},
+			},
+			expectDropped: 1,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				errs  = fault.New(false)
+				items = make([]models.ListItemable, 0, len(test.items))
+			)
+
+			for _, fields := range test.items {
+				items = append(items, listItem(fields))
+			}
+
+			mapListItemFields(ctx, items, test.columns, errs)
+
+			for i, item := range items {
+				assert.Equal(t, test.expect[i], item.GetFields().GetAdditionalData(), "item %d", i)
+			}
+
+			assert.NoError(t, errs.Failure())
+			assert.Len(t, errs.Recovered(), test.expectDropped, "dropped columns")
+		})
+	}
+}
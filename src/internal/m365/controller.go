@@ -126,11 +126,12 @@ func (ctrl *Controller) Wait() *data.CollectionStats {
 
 	// clean up and reset statefulness
 	dcs := data.CollectionStats{
-		Folders:   ctrl.status.Folders,
-		Objects:   ctrl.status.Metrics.Objects,
-		Successes: ctrl.status.Metrics.Successes,
-		Bytes:     ctrl.status.Metrics.Bytes,
-		Details:   ctrl.status.String(),
+		Folders:     ctrl.status.Folders,
+		Objects:     ctrl.status.Metrics.Objects,
+		Successes:   ctrl.status.Metrics.Successes,
+		Bytes:       ctrl.status.Metrics.Bytes,
+		Details:     ctrl.status.String(),
+		Concurrency: ctrl.status.Concurrency,
 	}
 
 	ctrl.wg = &sync.WaitGroup{}
@@ -8,6 +8,7 @@ import (
 	"github.com/alcionai/corso/src/internal/data"
 	"github.com/alcionai/corso/src/internal/diagnostics"
 	"github.com/alcionai/corso/src/internal/m365/graph"
+	"github.com/alcionai/corso/src/internal/m365/service/exchange"
 	"github.com/alcionai/corso/src/internal/m365/service/groups"
 	"github.com/alcionai/corso/src/internal/m365/service/onedrive"
 	"github.com/alcionai/corso/src/internal/m365/service/sharepoint"
@@ -43,6 +44,15 @@ func (ctrl *Controller) ProduceExportCollections(
 	)
 
 	switch sels.Service {
+	case selectors.ServiceExchange:
+		expCollections, err = exchange.ProduceExportCollections(
+			ctx,
+			backupVersion,
+			exportCfg,
+			opts,
+			dcs,
+			deets,
+			errs)
 	case selectors.ServiceOneDrive:
 		expCollections, err = onedrive.ProduceExportCollections(
 			ctx,
@@ -0,0 +1,212 @@
+package graph
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/logger"
+)
+
+// ---------------------------------------------------------------------------
+// Adaptive Concurrency
+// "how many items to fetch at one time, given recent throttling"
+// ---------------------------------------------------------------------------
+
+const (
+	// how often the adaptive limiter checks the throttle signal.
+	adaptiveAdjustInterval = 10 * time.Second
+	// the number of throttled responses within a single adjustment interval
+	// that counts as sustained throttling.
+	sustainedThrottleCount = 3
+)
+
+type throttleSignalKey string
+
+const throttleSignalCtxKey throttleSignalKey = "corsoGraphThrottleSignal"
+
+// BindThrottleSignal ensures the graph middleware tallies every throttled
+// response, and every retried request, made using this context in the
+// signal.  Adaptive limiters compare the throttle count between adjustments
+// to decide whether graph is pushing back.
+func BindThrottleSignal(ctx context.Context, signal *count.Bus) context.Context {
+	return context.WithValue(ctx, throttleSignalCtxKey, signal)
+}
+
+// ctxThrottleSignal returns the signal bound to the context, or nil if
+// the context has none.  Incrementing a nil signal is a no-op.
+func ctxThrottleSignal(ctx context.Context) *count.Bus {
+	signal, _ := ctx.Value(throttleSignalCtxKey).(*count.Bus)
+	return signal
+}
+
+type adaptiveLimiterKey string
+
+const adaptiveLimiterCtxKey adaptiveLimiterKey = "corsoGraphAdaptiveLimiter"
+
+// BindAdaptiveLimiter shares the limiter between every collection that
+// fetches items using this context.  The caller is responsible for starting
+// and stopping the limiter.
+func BindAdaptiveLimiter(ctx context.Context, al *AdaptiveLimiter) context.Context {
+	return context.WithValue(ctx, adaptiveLimiterCtxKey, al)
+}
+
+// ItemFetchLimiter returns the limiter bound to the context.  Contexts
+// without a limiter get a new one with maxConcurrency slots, which reads the
+// throttle signal bound to the context, and adjusts until the returned func
+// is called.
+func ItemFetchLimiter(ctx context.Context, maxConcurrency int) (*AdaptiveLimiter, func()) {
+	if al, ok := ctx.Value(adaptiveLimiterCtxKey).(*AdaptiveLimiter); ok && al != nil {
+		return al, func() {}
+	}
+
+	signal := ctxThrottleSignal(ctx)
+	if signal == nil {
+		signal = count.New()
+	}
+
+	al := NewAdaptiveLimiter(maxConcurrency, signal)
+
+	return al, al.Start(ctx)
+}
+
+// AdaptiveLimiter is a semaphore whose capacity shrinks while graph reports
+// sustained throttling, and grows back towards its maximum once the
+// throttling clears.  Capacity is halved on each throttled interval, and
+// raised by one on each quiet interval.
+type AdaptiveLimiter struct {
+	mu    sync.Mutex
+	max   int
+	limit int
+	inUse int
+	// closed, and replaced, whenever a slot might have become available.
+	wake chan struct{}
+
+	signal        *count.Bus
+	lastThrottles int64
+}
+
+func NewAdaptiveLimiter(maxConcurrency int, signal *count.Bus) *AdaptiveLimiter {
+	if maxConcurrency < 1 {
+		maxConcurrency = 1
+	}
+
+	return &AdaptiveLimiter{
+		max:           maxConcurrency,
+		limit:         maxConcurrency,
+		wake:          make(chan struct{}),
+		signal:        signal,
+		lastThrottles: signal.Get(count.ThrottledRequests),
+	}
+}
+
+// Acquire blocks until a slot is available under the current limit, or
+// until the context is cancelled.  Every successful Acquire must be paired
+// with a call to Release.
+func (al *AdaptiveLimiter) Acquire(ctx context.Context) error {
+	for {
+		al.mu.Lock()
+
+		if al.inUse < al.limit {
+			al.inUse++
+			al.mu.Unlock()
+
+			return nil
+		}
+
+		wake := al.wake
+		al.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return clues.Wrap(ctx.Err(), "waiting on adaptive limiter")
+		case <-wake:
+		}
+	}
+}
+
+// Release frees a slot obtained by Acquire.
+func (al *AdaptiveLimiter) Release() {
+	al.mu.Lock()
+	defer al.mu.Unlock()
+
+	al.inUse--
+	al.notify()
+}
+
+// Limit returns the current concurrency limit.
+func (al *AdaptiveLimiter) Limit() int {
+	al.mu.Lock()
+	defer al.mu.Unlock()
+
+	return al.limit
+}
+
+// Start adjusts the limit on an interval until the returned func is called.
+func (al *AdaptiveLimiter) Start(ctx context.Context) func() {
+	var (
+		ticker = time.NewTicker(adaptiveAdjustInterval)
+		done   = make(chan struct{})
+	)
+
+	go func() {
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-done:
+				return
+			case <-ticker.C:
+				al.adjust(ctx)
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
+
+// adjust compares the throttle signal against its value at the prior
+// adjustment.  Sustained throttling halves the limit; an interval without
+// any throttling raises it by one, up to the maximum.
+func (al *AdaptiveLimiter) adjust(ctx context.Context) {
+	al.mu.Lock()
+	defer al.mu.Unlock()
+
+	var (
+		total     = al.signal.Get(count.ThrottledRequests)
+		throttles = total - al.lastThrottles
+		prev      = al.limit
+	)
+
+	al.lastThrottles = total
+
+	switch {
+	case throttles >= sustainedThrottleCount:
+		al.limit = max(al.limit/2, 1)
+	case throttles == 0:
+		al.limit = min(al.limit+1, al.max)
+	}
+
+	if al.limit == prev {
+		return
+	}
+
+	logger.Ctx(ctx).Infow(
+		"adjusted adaptive concurrency limit",
+		"prev_limit", prev,
+		"limit", al.limit,
+		"throttled_responses", throttles)
+
+	al.notify()
+}
+
+// notify wakes all goroutines blocked in Acquire.  Callers must hold the lock.
+func (al *AdaptiveLimiter) notify() {
+	close(al.wake)
+	al.wake = make(chan struct{})
+}
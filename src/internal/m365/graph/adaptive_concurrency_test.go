@@ -0,0 +1,226 @@
+package graph
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/count"
+)
+
+type AdaptiveLimiterUnitSuite struct {
+	tester.Suite
+}
+
+func TestAdaptiveLimiterUnitSuite(t *testing.T) {
+	suite.Run(t, &AdaptiveLimiterUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *AdaptiveLimiterUnitSuite) TestAdjust_throttleBursts() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		signal = count.New()
+		al     = NewAdaptiveLimiter(8, signal)
+	)
+
+	// each step simulates one adjustment interval.  throttled is the number
+	// of throttled responses that occur within the interval.
+	steps := []struct {
+		throttled   int64
+		expectLimit int
+	}{
+		// quiet at the max
+		{throttled: 0, expectLimit: 8},
+		// a burst of sustained throttling halves the limit each interval
+		{throttled: 10, expectLimit: 4},
+		{throttled: sustainedThrottleCount, expectLimit: 2},
+		{throttled: 5, expectLimit: 1},
+		// the limit never drops below one
+		{throttled: 5, expectLimit: 1},
+		// sporadic throttling holds the limit steady
+		{throttled: sustainedThrottleCount - 1, expectLimit: 1},
+		// throttling clears, and the limit recovers one step per interval
+		{throttled: 0, expectLimit: 2},
+		{throttled: 0, expectLimit: 3},
+		// a second burst knocks it back down
+		{throttled: 4, expectLimit: 1},
+		{throttled: 0, expectLimit: 2},
+		{throttled: 0, expectLimit: 3},
+		{throttled: 0, expectLimit: 4},
+		{throttled: 0, expectLimit: 5},
+		{throttled: 0, expectLimit: 6},
+		{throttled: 0, expectLimit: 7},
+		{throttled: 0, expectLimit: 8},
+		// the limit never exceeds the max
+		{throttled: 0, expectLimit: 8},
+	}
+
+	for i, step := range steps {
+		signal.Add(count.ThrottledRequests, step.throttled)
+		al.adjust(ctx)
+		assert.Equalf(t, step.expectLimit, al.Limit(), "limit after step %d", i)
+	}
+}
+
+func (suite *AdaptiveLimiterUnitSuite) TestNewAdaptiveLimiter_ignoresPriorThrottles() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	signal := count.New()
+	signal.Add(count.ThrottledRequests, 100)
+
+	al := NewAdaptiveLimiter(4, signal)
+	al.adjust(ctx)
+
+	assert.Equal(t, 4, al.Limit())
+}
+
+func (suite *AdaptiveLimiterUnitSuite) TestNewAdaptiveLimiter_minimumOne() {
+	assert.Equal(suite.T(), 1, NewAdaptiveLimiter(0, count.New()).Limit())
+}
+
+func (suite *AdaptiveLimiterUnitSuite) TestAcquire_followsLimit() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		signal = count.New()
+		al     = NewAdaptiveLimiter(2, signal)
+	)
+
+	// drop the limit to 1.
+	signal.Add(count.ThrottledRequests, sustainedThrottleCount)
+	al.adjust(ctx)
+	require.Equal(t, 1, al.Limit())
+
+	err := al.Acquire(ctx)
+	require.NoError(t, err, clues.ToCore(err))
+
+	// a second caller is held back by the lowered limit.
+	tctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+
+	err = al.Acquire(tctx)
+	require.Error(t, err, clues.ToCore(err))
+
+	// once throttling clears, the raised limit lets the blocked caller through.
+	acquired := make(chan error)
+
+	go func() {
+		acquired <- al.Acquire(ctx)
+	}()
+
+	al.adjust(ctx)
+	require.Equal(t, 2, al.Limit())
+
+	select {
+	case err := <-acquired:
+		require.NoError(t, err, clues.ToCore(err))
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "acquire was not released after the limit increased")
+	}
+
+	al.Release()
+	al.Release()
+}
+
+func (suite *AdaptiveLimiterUnitSuite) TestRelease_wakesWaiters() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	al := NewAdaptiveLimiter(1, count.New())
+
+	err := al.Acquire(ctx)
+	require.NoError(t, err, clues.ToCore(err))
+
+	acquired := make(chan error)
+
+	go func() {
+		acquired <- al.Acquire(ctx)
+	}()
+
+	al.Release()
+
+	select {
+	case err := <-acquired:
+		require.NoError(t, err, clues.ToCore(err))
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "acquire was not released after a slot freed up")
+	}
+
+	al.Release()
+}
+
+func (suite *AdaptiveLimiterUnitSuite) TestItemFetchLimiter() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	// without a bound limiter, each call gets its own.
+	al1, stop1 := ItemFetchLimiter(ctx, 4)
+	defer stop1()
+
+	al2, stop2 := ItemFetchLimiter(ctx, 4)
+	defer stop2()
+
+	assert.NotSame(t, al1, al2, "unbound limiters")
+	assert.Equal(t, 4, al1.Limit())
+
+	// a bound limiter is shared by every call using the context.
+	var (
+		signal = count.New()
+		shared = NewAdaptiveLimiter(2, signal)
+		bctx   = BindAdaptiveLimiter(BindThrottleSignal(ctx, signal), shared)
+	)
+
+	al3, stop3 := ItemFetchLimiter(bctx, 4)
+	defer stop3()
+
+	al4, stop4 := ItemFetchLimiter(bctx, 8)
+	defer stop4()
+
+	assert.Same(t, shared, al3, "bound limiter")
+	assert.Same(t, shared, al4, "bound limiter")
+
+	// throttling seen by any request using the context lowers the shared limit.
+	signal.Add(count.ThrottledRequests, sustainedThrottleCount)
+	shared.adjust(ctx)
+
+	assert.Equal(t, 1, al3.Limit())
+}
+
+func (suite *AdaptiveLimiterUnitSuite) TestItemFetchLimiter_readsBoundSignal() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		signal  = count.New()
+		al, stp = ItemFetchLimiter(BindThrottleSignal(ctx, signal), 4)
+	)
+
+	defer stp()
+
+	signal.Add(count.ThrottledRequests, sustainedThrottleCount)
+	al.adjust(ctx)
+
+	assert.Equal(t, 2, al.Limit())
+}
@@ -11,6 +11,7 @@ import (
 	khttp "github.com/microsoft/kiota-http-go"
 	"golang.org/x/time/rate"
 
+	"github.com/alcionai/corso/src/pkg/count"
 	"github.com/alcionai/corso/src/pkg/logger"
 	"github.com/alcionai/corso/src/pkg/path"
 )
@@ -313,6 +314,10 @@ func (mw *throttlingMiddleware) Intercept(
 		return resp, err
 	}
 
+	if resp.StatusCode == http.StatusTooManyRequests {
+		ctxThrottleSignal(req.Context()).Inc(count.ThrottledRequests)
+	}
+
 	seconds := getRetryAfterHeader(resp)
 	if seconds < 1 {
 		return resp, nil
@@ -16,6 +16,7 @@ import (
 	"golang.org/x/net/context"
 
 	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/count"
 )
 
 type ConcurrencyMWUnitTestSuite struct {
@@ -230,9 +231,10 @@ func (suite *ConcurrencyMWUnitTestSuite) TestThrottlingMiddleware() {
 	}
 
 	table := []struct {
-		name          string
-		pipeline      mockPipeline
-		expectMinWait float64
+		name            string
+		pipeline        mockPipeline
+		expectMinWait   float64
+		expectThrottled int64
 	}{
 		{
 			name:          "2xx response",
@@ -257,7 +259,8 @@ func (suite *ConcurrencyMWUnitTestSuite) TestThrottlingMiddleware() {
 					Header:     http.Header{},
 				},
 			},
-			expectMinWait: 0,
+			expectMinWait:   0,
+			expectThrottled: 1,
 		},
 		{
 			name: "429 response w/ nan retry-after",
@@ -267,7 +270,8 @@ func (suite *ConcurrencyMWUnitTestSuite) TestThrottlingMiddleware() {
 					Header:     retryAfterNan,
 				},
 			},
-			expectMinWait: 0,
+			expectMinWait:   0,
+			expectThrottled: 1,
 		},
 		{
 			name: "429 response w/ negative retry-after",
@@ -277,7 +281,8 @@ func (suite *ConcurrencyMWUnitTestSuite) TestThrottlingMiddleware() {
 					Header:     retryAfterNeg1,
 				},
 			},
-			expectMinWait: 0,
+			expectMinWait:   0,
+			expectThrottled: 1,
 		},
 		{
 			name: "429 response w/ zero retry-after",
@@ -287,7 +292,8 @@ func (suite *ConcurrencyMWUnitTestSuite) TestThrottlingMiddleware() {
 					Header:     retryAfter0,
 				},
 			},
-			expectMinWait: 0,
+			expectMinWait:   0,
+			expectThrottled: 1,
 		},
 		{
 			name: "429 response w/ positive retry-after",
@@ -297,7 +303,8 @@ func (suite *ConcurrencyMWUnitTestSuite) TestThrottlingMiddleware() {
 					Header:     retryAfter5,
 				},
 			},
-			expectMinWait: 4,
+			expectMinWait:   4,
+			expectThrottled: 1,
 		},
 	}
 	for _, test := range table {
@@ -309,8 +316,10 @@ func (suite *ConcurrencyMWUnitTestSuite) TestThrottlingMiddleware() {
 
 			tm := throttlingMiddleware{newTimedFence()}
 
+			signal := count.New()
+
 			req := &http.Request{}
-			req = req.WithContext(ctx)
+			req = req.WithContext(BindThrottleSignal(ctx, signal))
 
 			start := time.Now()
 
@@ -321,6 +330,11 @@ func (suite *ConcurrencyMWUnitTestSuite) TestThrottlingMiddleware() {
 			require.NoError(t, err, clues.ToCore(err))
 
 			assert.Less(t, test.expectMinWait, time.Since(start).Seconds())
+			assert.Equal(
+				t,
+				test.expectThrottled,
+				signal.Get(count.ThrottledRequests),
+				"throttle signal")
 		})
 	}
 }
@@ -101,6 +101,21 @@ func (p parallelism) ItemUpload() int {
 	return p.itemUpload
 }
 
+// ItemUploadOverride produces the override when it is a valid number of
+// concurrent uploads, and the service's default otherwise.
+func (p parallelism) ItemUploadOverride(ctx context.Context, override int) int {
+	logger.Ctx(ctx).Infow(
+		"item upload parallelism",
+		"default_parallelism", p.itemUpload,
+		"requested_paralellism", override)
+
+	if !isWithin(1, maxConccurrentUploads, override) {
+		return p.ItemUpload()
+	}
+
+	return override
+}
+
 // returns low <= v <= high
 // if high < low, returns low <= v
 func isWithin(low, high, v int) bool {
@@ -38,3 +38,28 @@ func (suite *ConstsUnitSuite) TestIsWithin() {
 		})
 	}
 }
+
+func (suite *ConstsUnitSuite) TestItemUploadOverride() {
+	table := []struct {
+		name     string
+		p        parallelism
+		override int
+		expect   int
+	}{
+		{"no override uses default", parallelism{itemUpload: 7}, 0, 7},
+		{"override below default", parallelism{itemUpload: 7}, 3, 3},
+		{"override above default", parallelism{itemUpload: 7}, 12, 12},
+		{"override above limit", parallelism{itemUpload: 7}, maxConccurrentUploads + 1, 7},
+		{"no default", parallelism{}, 0, 1},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			assert.Equal(t, test.expect, test.p.ItemUploadOverride(ctx, test.override))
+		})
+	}
+}
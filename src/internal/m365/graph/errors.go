@@ -29,6 +29,12 @@ const (
 	// this auth error is a catch-all used by graph in a variety of cases:
 	// users without licenses, bad jwts, missing account permissions, etc.
 	AuthenticationError errorCode = "AuthenticationError"
+	// returned by directory apis (users, groups) when the application
+	// lacks the permissions required by the request.
+	authorizationRequestDenied errorCode = "Authorization_RequestDenied"
+	// returned by the teams apis when the request body references values
+	// that graph can't resolve, such as a tab app that isn't installed.
+	badRequest errorCode = "BadRequest"
 	// cannotOpenFileAttachment happen when an attachment is
 	// inaccessible. The error message is usually "OLE conversion
 	// failed for an attachment."
@@ -48,8 +54,11 @@ const (
 	malwareDetected             errorCode = "malwareDetected"
 	// nameAlreadyExists occurs when a request with
 	// @microsoft.graph.conflictBehavior=fail finds a conflicting file.
-	nameAlreadyExists       errorCode = "nameAlreadyExists"
-	noResolvedUsers         errorCode = "noResolvedUsers"
+	nameAlreadyExists errorCode = "nameAlreadyExists"
+	noResolvedUsers   errorCode = "noResolvedUsers"
+	// preconditionFailed occurs when the If-Match header of a request
+	// doesn't match the current eTag of the item.
+	preconditionFailed      errorCode = "preconditionFailed"
 	QuotaExceeded           errorCode = "ErrorQuotaExceeded"
 	RequestResourceNotFound errorCode = "Request_ResourceNotFound"
 	// Returned when we try to get the inbox of a user that doesn't exist.
@@ -71,6 +80,19 @@ const (
 	usersCannotBeResolved           errorMessage = "One or more users could not be resolved"
 )
 
+// Azure AD error codes are produced while acquiring a token, before any
+// graph api call is made, and only appear within the error message.
+// https://learn.microsoft.com/en-us/azure/active-directory/develop/reference-error-codes
+type aadErrorCode string
+
+const (
+	aadConsentRequired     aadErrorCode = "AADSTS65001"
+	aadInvalidClientSecret aadErrorCode = "AADSTS7000215"
+	aadExpiredClientSecret aadErrorCode = "AADSTS7000222"
+	aadApplicationNotFound aadErrorCode = "AADSTS700016"
+	aadTenantNotFound      aadErrorCode = "AADSTS90002"
+)
+
 const (
 	LabelsMalware             = "malware_detected"
 	LabelsMysiteNotFound      = "mysite_not_found"
@@ -96,6 +118,10 @@ var (
 	// when filenames collide in a @microsoft.graph.conflictBehavior=fail request.
 	ErrItemAlreadyExistsConflict = clues.New("item already exists")
 
+	// ErrPreconditionFailed denotes that a conditional request was rejected
+	// because the item changed since its eTag was read.
+	ErrPreconditionFailed = clues.New("item changed since it was read")
+
 	// ErrMultipleResultsMatchIdentifier describes a situation where we're doing a lookup
 	// in some way other than by canonical url ID (ex: filtering, searching, etc).
 	// This error should only be returned if a unique result is an expected constraint
@@ -180,7 +206,26 @@ func IsErrCannotOpenFileAttachment(err error) bool {
 }
 
 func IsErrAccessDenied(err error) bool {
-	return hasErrorCode(err, ErrorAccessDenied) || clues.HasLabel(err, LabelStatus(http.StatusForbidden))
+	return hasErrorCode(err, ErrorAccessDenied, authorizationRequestDenied) ||
+		clues.HasLabel(err, LabelStatus(http.StatusForbidden))
+}
+
+// IsErrCredentialsExpired is true if the application's client secret
+// has expired.
+func IsErrCredentialsExpired(err error) bool {
+	return hasAADErrorCode(err, aadExpiredClientSecret)
+}
+
+// IsErrCredentialsInvalid is true if the tenant, application, or client
+// secret used to acquire a token were not recognized.
+func IsErrCredentialsInvalid(err error) bool {
+	return hasAADErrorCode(err, aadInvalidClientSecret, aadApplicationNotFound, aadTenantNotFound)
+}
+
+// IsErrConsentRequired is true if an administrator has not yet granted
+// consent to the application within the tenant.
+func IsErrConsentRequired(err error) bool {
+	return hasAADErrorCode(err, aadConsentRequired)
 }
 
 func IsErrTimeout(err error) bool {
@@ -212,11 +257,60 @@ func IsErrItemAlreadyExistsConflict(err error) bool {
 		errors.Is(err, ErrItemAlreadyExistsConflict)
 }
 
+// IsErrPreconditionFailed is true if a conditional request was rejected
+// because the item changed.  The error code varies by service (OneDrive
+// produces "resourceModified"), so the 412 status is checked as well.
+func IsErrPreconditionFailed(err error) bool {
+	return hasErrorCode(err, preconditionFailed) ||
+		hasStatusCode(err, http.StatusPreconditionFailed) ||
+		errors.Is(err, ErrPreconditionFailed)
+}
+
+// hasStatusCode is true if the error is an ODataError produced by a
+// response with the status code, or if it was labeled with the status.
+func hasStatusCode(err error, statusCode int) bool {
+	if err == nil {
+		return false
+	}
+
+	if clues.HasLabel(err, LabelStatus(statusCode)) {
+		return true
+	}
+
+	var oDataError *odataerrors.ODataError
+
+	return errors.As(err, &oDataError) && oDataError.ResponseStatusCode == statusCode
+}
+
 // LabelStatus transforms the provided statusCode into
 // a standard label that can be attached to a clues error
 // and later reviewed when checking error statuses.
 func LabelStatus(statusCode int) string {
-	return fmt.Sprintf("status_code_%d", statusCode)
+	return fmt.Sprintf(statusLabelFmt, statusCode)
+}
+
+const statusLabelFmt = "status_code_%d"
+
+// LabeledStatus returns the status code attached to the error by
+// LabelStatus.  Errors without exactly one status label produce 0.
+func LabeledStatus(err error) int {
+	var code int
+
+	for l := range clues.Labels(err) {
+		var sc int
+
+		if _, serr := fmt.Sscanf(l, statusLabelFmt, &sc); serr != nil {
+			continue
+		}
+
+		if code != 0 {
+			return 0
+		}
+
+		code = sc
+	}
+
+	return code
 }
 
 // IsMalware is true if the graphAPI returns a "malware detected" error code.
@@ -232,6 +326,11 @@ func IsMalwareResp(ctx context.Context, resp *http.Response) bool {
 		resp.Header.Get("X-Virus-Infected") == "true"
 }
 
+// IsErrBadRequest is true if graph rejected the request body as invalid.
+func IsErrBadRequest(err error) bool {
+	return hasErrorCode(err, badRequest)
+}
+
 func IsErrFolderExists(err error) bool {
 	return hasErrorCode(err, folderExists)
 }
@@ -291,6 +390,22 @@ func hasErrorMessage(err error, msgs ...errorMessage) bool {
 	return filters.Contains(cs).Compare(msg)
 }
 
+func hasAADErrorCode(err error, codes ...aadErrorCode) bool {
+	if err == nil {
+		return false
+	}
+
+	msg := err.Error()
+
+	for _, c := range codes {
+		if strings.Contains(msg, string(c)) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Wrap is a helper function that extracts ODataError metadata from
 // the error.  If the error is not an ODataError type, returns the error.
 func Wrap(ctx context.Context, e error, msg string) *clues.Err {
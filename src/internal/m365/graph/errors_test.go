@@ -172,6 +172,70 @@ func (suite *GraphErrorsUnitSuite) TestIsErrDeletedInFlight() {
 	}
 }
 
+func (suite *GraphErrorsUnitSuite) TestIsErrPreconditionFailed() {
+	table := []struct {
+		name   string
+		err    error
+		expect assert.BoolAssertionFunc
+	}{
+		{
+			name:   "nil",
+			err:    nil,
+			expect: assert.False,
+		},
+		{
+			name:   "non-matching",
+			err:    assert.AnError,
+			expect: assert.False,
+		},
+		{
+			name:   "as",
+			err:    ErrPreconditionFailed,
+			expect: assert.True,
+		},
+		{
+			name:   "non-matching oDataErr",
+			err:    odErr("fnords"),
+			expect: assert.False,
+		},
+		{
+			name:   "precondition failed oDataErr",
+			err:    odErr(string(preconditionFailed)),
+			expect: assert.True,
+		},
+		{
+			name: "resource modified oDataErr with 412 status",
+			err: func() error {
+				err := odErr("resourceModified")
+				err.ResponseStatusCode = http.StatusPreconditionFailed
+
+				return err
+			}(),
+			expect: assert.True,
+		},
+		{
+			name:   "412 status label",
+			err:    clues.New("conflict").Label(LabelStatus(http.StatusPreconditionFailed)),
+			expect: assert.True,
+		},
+		{
+			name: "resource modified oDataErr with other status",
+			err: func() error {
+				err := odErr("resourceModified")
+				err.ResponseStatusCode = http.StatusConflict
+
+				return err
+			}(),
+			expect: assert.False,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			test.expect(suite.T(), IsErrPreconditionFailed(test.err))
+		})
+	}
+}
+
 func (suite *GraphErrorsUnitSuite) TestIsErrInvalidDelta() {
 	table := []struct {
 		name   string
@@ -413,6 +477,126 @@ func (suite *GraphErrorsUnitSuite) TestIsErrUnauthorized() {
 	}
 }
 
+func (suite *GraphErrorsUnitSuite) TestIsErrAccessDenied() {
+	table := []struct {
+		name   string
+		err    error
+		expect assert.BoolAssertionFunc
+	}{
+		{
+			name:   "nil",
+			err:    nil,
+			expect: assert.False,
+		},
+		{
+			name:   "non-matching",
+			err:    assert.AnError,
+			expect: assert.False,
+		},
+		{
+			name:   "access denied oDataErr",
+			err:    odErr(string(ErrorAccessDenied)),
+			expect: assert.True,
+		},
+		{
+			name:   "request denied oDataErr",
+			err:    odErr(string(authorizationRequestDenied)),
+			expect: assert.True,
+		},
+		{
+			name: "forbidden label",
+			err: clues.Stack(assert.AnError).
+				Label(LabelStatus(http.StatusForbidden)),
+			expect: assert.True,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			test.expect(suite.T(), IsErrAccessDenied(test.err))
+		})
+	}
+}
+
+func (suite *GraphErrorsUnitSuite) TestAADErrors() {
+	aadErr := func(code aadErrorCode) error {
+		return clues.New("ClientSecretCredential authentication failed: " + string(code) + ": description")
+	}
+
+	table := []struct {
+		name          string
+		err           error
+		expectExpired assert.BoolAssertionFunc
+		expectInvalid assert.BoolAssertionFunc
+		expectConsent assert.BoolAssertionFunc
+	}{
+		{
+			name:          "nil",
+			err:           nil,
+			expectExpired: assert.False,
+			expectInvalid: assert.False,
+			expectConsent: assert.False,
+		},
+		{
+			name:          "non-matching",
+			err:           assert.AnError,
+			expectExpired: assert.False,
+			expectInvalid: assert.False,
+			expectConsent: assert.False,
+		},
+		{
+			name:          "expired secret",
+			err:           aadErr(aadExpiredClientSecret),
+			expectExpired: assert.True,
+			expectInvalid: assert.False,
+			expectConsent: assert.False,
+		},
+		{
+			name:          "invalid secret",
+			err:           aadErr(aadInvalidClientSecret),
+			expectExpired: assert.False,
+			expectInvalid: assert.True,
+			expectConsent: assert.False,
+		},
+		{
+			name:          "application not found",
+			err:           aadErr(aadApplicationNotFound),
+			expectExpired: assert.False,
+			expectInvalid: assert.True,
+			expectConsent: assert.False,
+		},
+		{
+			name:          "tenant not found",
+			err:           aadErr(aadTenantNotFound),
+			expectExpired: assert.False,
+			expectInvalid: assert.True,
+			expectConsent: assert.False,
+		},
+		{
+			name:          "consent required",
+			err:           aadErr(aadConsentRequired),
+			expectExpired: assert.False,
+			expectInvalid: assert.False,
+			expectConsent: assert.True,
+		},
+		{
+			name:          "wrapped",
+			err:           clues.Wrap(aadErr(aadExpiredClientSecret), "getting users"),
+			expectExpired: assert.True,
+			expectInvalid: assert.False,
+			expectConsent: assert.False,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			test.expectExpired(t, IsErrCredentialsExpired(test.err), "expired")
+			test.expectInvalid(t, IsErrCredentialsInvalid(test.err), "invalid")
+			test.expectConsent(t, IsErrConsentRequired(test.err), "consent")
+		})
+	}
+}
+
 func (suite *GraphErrorsUnitSuite) TestMalwareInfo() {
 	var (
 		i         = models.NewDriveItem()
@@ -500,6 +684,45 @@ func (suite *GraphErrorsUnitSuite) TestIsErrFolderExists() {
 	}
 }
 
+func (suite *GraphErrorsUnitSuite) TestIsErrBadRequest() {
+	table := []struct {
+		name   string
+		err    error
+		expect assert.BoolAssertionFunc
+	}{
+		{
+			name:   "nil",
+			err:    nil,
+			expect: assert.False,
+		},
+		{
+			name:   "non-matching",
+			err:    assert.AnError,
+			expect: assert.False,
+		},
+		{
+			name:   "non-matching oDataErr",
+			err:    odErr(string(itemNotFound)),
+			expect: assert.False,
+		},
+		{
+			name:   "matching oDataErr",
+			err:    odErr(string(badRequest)),
+			expect: assert.True,
+		},
+		{
+			name:   "oDataErr lowercase",
+			err:    odErr("badrequest"),
+			expect: assert.True,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			test.expect(suite.T(), IsErrBadRequest(test.err))
+		})
+	}
+}
+
 func (suite *GraphErrorsUnitSuite) TestIsErrUsersCannotBeResolved() {
 	table := []struct {
 		name   string
@@ -685,3 +908,53 @@ func (suite *GraphErrorsUnitSuite) TestIsErrItemNotFound() {
 		})
 	}
 }
+
+func (suite *GraphErrorsUnitSuite) TestLabeledStatus() {
+	table := []struct {
+		name   string
+		err    error
+		expect int
+	}{
+		{
+			name:   "nil",
+			err:    nil,
+			expect: 0,
+		},
+		{
+			name:   "unlabeled",
+			err:    assert.AnError,
+			expect: 0,
+		},
+		{
+			name:   "other labels",
+			err:    clues.Stack(assert.AnError).Label(LabelsMysiteNotFound),
+			expect: 0,
+		},
+		{
+			name: "status label",
+			err: clues.Stack(assert.AnError).
+				Label(LabelsMysiteNotFound).
+				Label(LabelStatus(http.StatusTooManyRequests)),
+			expect: http.StatusTooManyRequests,
+		},
+		{
+			name: "wrapped status label",
+			err: clues.Wrap(
+				clues.Stack(assert.AnError).Label(LabelStatus(http.StatusForbidden)),
+				"wrapped"),
+			expect: http.StatusForbidden,
+		},
+		{
+			name: "multiple status labels",
+			err: clues.Stack(assert.AnError).
+				Label(LabelStatus(http.StatusForbidden)).
+				Label(LabelStatus(http.StatusNotFound)),
+			expect: 0,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			assert.Equal(suite.T(), test.expect, LabeledStatus(test.err))
+		})
+	}
+}
@@ -175,10 +175,14 @@ func defaultTransport() http.RoundTripper {
 func internalMiddleware(cc *clientConfig) []khttp.Middleware {
 	mw := []khttp.Middleware{
 		&RetryMiddleware{
-			MaxRetries: cc.maxRetries,
-			Delay:      cc.minDelay,
+			MaxRetries:      cc.maxRetries,
+			Delay:           cc.minDelay,
+			MaxThrottleWait: cc.maxThrottleWait,
 		},
-		khttp.NewRetryHandler(),
+		newBudgetedRetryHandler(),
+		// after the retry handlers, so that the timeout bounds each attempt
+		// instead of spanning every retry and backoff.
+		newRequestTimeoutMiddleware(cc),
 		khttp.NewRedirectHandler(),
 		&LoggingMiddleware{},
 		&throttlingMiddleware{newTimedFence()},
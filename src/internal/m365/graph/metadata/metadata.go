@@ -1,6 +1,8 @@
 package metadata
 
 import (
+	"strings"
+
 	"github.com/alcionai/corso/src/internal/m365/collection/drive/metadata"
 	"github.com/alcionai/corso/src/pkg/path"
 )
@@ -17,3 +19,9 @@ func IsMetadataFile(p path.Path) bool {
 		return false
 	}
 }
+
+// IsFolderMetadataFile is true for the metadata files drive services
+// store for each folder.
+func IsFolderMetadataFile(p path.Path) bool {
+	return IsMetadataFile(p) && strings.HasSuffix(p.Item(), metadata.DirMetaFileSuffix)
+}
@@ -153,3 +153,29 @@ func (suite *MetadataUnitSuite) TestIsMetadataFile_Directories() {
 		}
 	}
 }
+
+func (suite *MetadataUnitSuite) TestIsFolderMetadataFile() {
+	for _, test := range cases {
+		for _, ext := range append(append([]string{}, notMetaSuffixes...), metaSuffixes...) {
+			suite.Run(fmt.Sprintf("%s %s %s", test.service, test.category, ext), func() {
+				t := suite.T()
+
+				p, err := path.Build(
+					tenant,
+					user,
+					test.service,
+					test.category,
+					true,
+					"file"+ext)
+				require.NoError(t, err, clues.ToCore(err))
+
+				expected := test.expected
+				if ext != odmetadata.DirMetaFileSuffix {
+					expected = assert.Falsef
+				}
+
+				expected(t, metadata.IsFolderMetadataFile(p), "extension %s", ext)
+			})
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/alcionai/clues"
@@ -17,6 +18,7 @@ import (
 
 	"github.com/alcionai/corso/src/internal/common/pii"
 	"github.com/alcionai/corso/src/internal/events"
+	"github.com/alcionai/corso/src/pkg/count"
 	"github.com/alcionai/corso/src/pkg/logger"
 )
 
@@ -197,6 +199,10 @@ type RetryMiddleware struct {
 	MaxRetries int
 	// The delay in seconds between retries
 	Delay time.Duration
+	// The maximum cumulative time that a request pauses, honoring the
+	// Retry-After header, to resume after it's still throttled once the
+	// retry handler gives up.  Zero fails throttled requests immediately.
+	MaxThrottleWait time.Duration
 }
 
 // Intercept implements the interface and evaluates whether to retry a failed request.
@@ -205,9 +211,15 @@ func (mw RetryMiddleware) Intercept(
 	middlewareIndex int,
 	req *http.Request,
 ) (*http.Response, error) {
-	ctx := req.Context()
+	// the inner retry handler's waits on throttled responses are tracked on
+	// the request's context, so that they count against MaxThrottleWait.
+	ctx := withThrottleWait(req.Context())
+	req = req.WithContext(ctx)
+
 	resp, err := pipeline.Next(req, middlewareIndex)
 
+	resp, err = mw.resumeThrottled(ctx, pipeline, middlewareIndex, req, resp, err)
+
 	retriable := IsErrTimeout(err) ||
 		IsErrConnectionReset(err) ||
 		mw.isRetriableRespCode(ctx, resp)
@@ -216,9 +228,7 @@ func (mw RetryMiddleware) Intercept(
 		return resp, stackReq(ctx, req, resp, err).OrNil()
 	}
 
-	exponentialBackOff := backoff.NewExponentialBackOff()
-	exponentialBackOff.InitialInterval = mw.Delay
-	exponentialBackOff.Reset()
+	exponentialBackOff := NewRetryBackOff(mw.Delay)
 
 	resp, err = mw.retryRequest(
 		ctx,
@@ -263,8 +273,15 @@ func (mw RetryMiddleware) retryRequest(
 		return resp, stackReq(ctx, req, resp, priorErr).OrNil()
 	}
 
+	if !spendRetry(ctx) {
+		logger.Ctx(ctx).Info("operation retry budget exhausted; not retrying request")
+		return resp, clues.Stack(ErrRetryBudgetExhausted, priorErr).WithClues(ctx)
+	}
+
 	executionCount++
 
+	ctxThrottleSignal(ctx).Inc(count.GraphRetries)
+
 	delay := mw.getRetryDelay(req, resp, exponentialBackoff)
 	cumulativeDelay += delay
 
@@ -281,17 +298,8 @@ func (mw RetryMiddleware) retryRequest(
 	case <-timer.C:
 	}
 
-	// we have to reset the original body reader for each retry, or else the graph
-	// compressor will produce a 0 length body following an error response such
-	// as a 500.
-	if req.Body != nil {
-		if s, ok := req.Body.(io.Seeker); ok {
-			if _, err := s.Seek(0, io.SeekStart); err != nil {
-				return resp, Wrap(ctx, err, "resetting request body reader")
-			}
-		} else {
-			logger.Ctx(ctx).Error("body is not an io.Seeker: unable to reset request body")
-		}
+	if err := resetBody(ctx, req); err != nil {
+		return resp, err
 	}
 
 	nextResp, err := pipeline.Next(req, middlewareIndex)
@@ -311,6 +319,114 @@ func (mw RetryMiddleware) retryRequest(
 		err)
 }
 
+// resumeThrottled re-sends requests that are still throttled after the
+// retry handler exhausted its retries.  Each attempt waits for the duration
+// of the response's Retry-After header, as long as the cumulative wait,
+// including the waits of the retry handler, stays within the MaxThrottleWait
+// budget.  Once the budget is spent, the throttled response is returned
+// as-is.
+func (mw RetryMiddleware) resumeThrottled(
+	ctx context.Context,
+	pipeline khttp.Pipeline,
+	middlewareIndex int,
+	req *http.Request,
+	resp *http.Response,
+	err error,
+) (*http.Response, error) {
+	for err == nil && resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		delay, ok := retryAfterDelay(resp)
+		waited := throttleWaited(ctx)
+
+		if !ok || waited+delay > mw.MaxThrottleWait || !spendRetry(ctx) {
+			break
+		}
+
+		waited = addThrottleWait(ctx, delay)
+
+		logger.Ctx(ctx).
+			With("retry_after", delay, "throttle_wait", waited).
+			Info("pausing throttled request before resuming")
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return resp, clues.Stack(ctx.Err()).WithClues(ctx)
+		case <-timer.C:
+		}
+
+		if err := resetBody(ctx, req); err != nil {
+			return resp, err
+		}
+
+		if resp.Body != nil {
+			resp.Body.Close()
+		}
+
+		resp, err = pipeline.Next(req, middlewareIndex)
+	}
+
+	return resp, err
+}
+
+type throttleWaitKey string
+
+const throttleWaitCtxKey throttleWaitKey = "corsoGraphThrottleWait"
+
+// withThrottleWait adds a tracker for the time that a request spends waiting
+// on throttled responses to the context, unless it already has one.
+func withThrottleWait(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(throttleWaitCtxKey).(*atomic.Int64); ok {
+		return ctx
+	}
+
+	return context.WithValue(ctx, throttleWaitCtxKey, &atomic.Int64{})
+}
+
+// addThrottleWait adds the delay to the throttle wait tracked on the
+// context, and returns the total.  Contexts without a tracker report the
+// delay alone.
+func addThrottleWait(ctx context.Context, delay time.Duration) time.Duration {
+	tw, ok := ctx.Value(throttleWaitCtxKey).(*atomic.Int64)
+	if !ok {
+		return delay
+	}
+
+	return time.Duration(tw.Add(int64(delay)))
+}
+
+// throttleWaited reports the throttle wait tracked on the context.
+func throttleWaited(ctx context.Context) time.Duration {
+	tw, ok := ctx.Value(throttleWaitCtxKey).(*atomic.Int64)
+	if !ok {
+		return 0
+	}
+
+	return time.Duration(tw.Load())
+}
+
+// resetBody rewinds the original body reader of the request.  We have to
+// reset it for each retry, or else the graph compressor will produce a 0
+// length body following an error response such as a 500.
+func resetBody(ctx context.Context, req *http.Request) error {
+	if req.Body == nil {
+		return nil
+	}
+
+	s, ok := req.Body.(io.Seeker)
+	if !ok {
+		logger.Ctx(ctx).Error("body is not an io.Seeker: unable to reset request body")
+		return nil
+	}
+
+	if _, err := s.Seek(0, io.SeekStart); err != nil {
+		return Wrap(ctx, err, "resetting request body reader")
+	}
+
+	return nil
+}
+
 var retryableRespCodes = []int{
 	http.StatusInternalServerError,
 	http.StatusBadGateway,
@@ -347,11 +463,32 @@ func (mw RetryMiddleware) isRetriableRequest(req *http.Request) bool {
 	return true
 }
 
+// NewRetryBackOff produces the exponential backoff waited out between
+// retries of graph requests, starting from the given delay.
+func NewRetryBackOff(delay time.Duration) *backoff.ExponentialBackOff {
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = delay
+	bo.Reset()
+
+	return bo
+}
+
 func (mw RetryMiddleware) getRetryDelay(
 	req *http.Request,
 	resp *http.Response,
 	exponentialBackoff *backoff.ExponentialBackOff,
 ) time.Duration {
+	if delay, ok := retryAfterDelay(resp); ok {
+		return delay
+	}
+
+	return exponentialBackoff.NextBackOff()
+}
+
+// retryAfterDelay produces the delay, in seconds, requested by the
+// response's Retry-After header.  Returns false if the response has
+// no parsable header.
+func retryAfterDelay(resp *http.Response) (time.Duration, bool) {
 	var retryAfter string
 	if resp != nil {
 		retryAfter = resp.Header.Get(retryAfterHeader)
@@ -360,11 +497,11 @@ func (mw RetryMiddleware) getRetryDelay(
 	if len(retryAfter) > 0 {
 		retryAfterDelay, err := strconv.ParseFloat(retryAfter, 64)
 		if err == nil {
-			return time.Duration(retryAfterDelay) * time.Second
+			return time.Duration(retryAfterDelay) * time.Second, true
 		}
 	} // TODO parse the header if it's a date
 
-	return exponentialBackoff.NextBackOff()
+	return 0, false
 }
 
 // ---------------------------------------------------------------------------
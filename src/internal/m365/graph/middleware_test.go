@@ -2,6 +2,7 @@ package graph
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -446,3 +447,536 @@ func (suite *MiddlewareUnitSuite) TestLimiterConsumption() {
 		})
 	}
 }
+
+// sequencePipeline returns its responses in order, one for each call to Next.
+type sequencePipeline struct {
+	resps []*http.Response
+	calls int
+}
+
+func (mp *sequencePipeline) Next(*http.Request, int) (*http.Response, error) {
+	if mp.calls >= len(mp.resps) {
+		panic(clues.New("pipeline had more calls than responses"))
+	}
+
+	resp := mp.resps[mp.calls]
+	mp.calls++
+
+	return resp, nil
+}
+
+func throttledResp(retryAfter string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{retryAfterHeader: []string{retryAfter}},
+		Body:       io.NopCloser(bytes.NewBuffer(nil)),
+	}
+}
+
+func (suite *MiddlewareUnitSuite) TestRetryMiddleware_resumeThrottled() {
+	okResp := &http.Response{StatusCode: http.StatusOK}
+
+	table := []struct {
+		name            string
+		maxThrottleWait time.Duration
+		// throttle waits already spent by the inner retry handler.
+		priorWait     time.Duration
+		resps         []*http.Response
+		expectStatus  int
+		expectCalls   int
+		expectMinWait time.Duration
+	}{
+		{
+			name:            "retry-after within budget resumes",
+			maxThrottleWait: 2 * time.Second,
+			resps:           []*http.Response{throttledResp("1"), okResp},
+			expectStatus:    http.StatusOK,
+			expectCalls:     2,
+			expectMinWait:   time.Second,
+		},
+		{
+			name:            "retry-after beyond budget fails",
+			maxThrottleWait: 2 * time.Second,
+			resps:           []*http.Response{throttledResp("60")},
+			expectStatus:    http.StatusTooManyRequests,
+			expectCalls:     1,
+		},
+		{
+			name:            "cumulative wait beyond budget fails",
+			maxThrottleWait: time.Second,
+			resps:           []*http.Response{throttledResp("1"), throttledResp("1")},
+			expectStatus:    http.StatusTooManyRequests,
+			expectCalls:     2,
+			expectMinWait:   time.Second,
+		},
+		{
+			name:            "retry handler waits count against the budget",
+			maxThrottleWait: 2 * time.Second,
+			priorWait:       1500 * time.Millisecond,
+			resps:           []*http.Response{throttledResp("1"), okResp},
+			expectStatus:    http.StatusTooManyRequests,
+			expectCalls:     1,
+		},
+		{
+			name:         "no budget fails",
+			resps:        []*http.Response{throttledResp("1")},
+			expectStatus: http.StatusTooManyRequests,
+			expectCalls:  1,
+		},
+		{
+			name:            "no retry-after fails",
+			maxThrottleWait: time.Minute,
+			resps: []*http.Response{{
+				StatusCode: http.StatusTooManyRequests,
+				Header:     http.Header{},
+			}},
+			expectStatus: http.StatusTooManyRequests,
+			expectCalls:  1,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			ctx = withThrottleWait(ctx)
+			addThrottleWait(ctx, test.priorWait)
+
+			var (
+				mw = RetryMiddleware{
+					MaxRetries:      0,
+					Delay:           10 * time.Millisecond,
+					MaxThrottleWait: test.maxThrottleWait,
+				}
+				mp    = &sequencePipeline{resps: test.resps}
+				start = time.Now()
+			)
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://graph.microsoft.com", nil)
+			require.NoError(t, err, clues.ToCore(err))
+
+			resp, err := mw.Intercept(mp, 0, req)
+			require.NotNil(t, resp)
+
+			assert.Equal(t, test.expectStatus, resp.StatusCode)
+			assert.Equal(t, test.expectCalls, mp.calls, "pipeline calls")
+			assert.GreaterOrEqual(t, time.Since(start), test.expectMinWait, "paused")
+
+			if test.expectStatus == http.StatusOK {
+				assert.NoError(t, err, clues.ToCore(err))
+			}
+		})
+	}
+}
+
+// statusPipeline responds to every call with the status code.
+type statusPipeline struct {
+	status int
+	header http.Header
+	calls  int
+}
+
+func (mp *statusPipeline) Next(*http.Request, int) (*http.Response, error) {
+	mp.calls++
+
+	return &http.Response{
+		StatusCode: mp.status,
+		Header:     mp.header,
+		Body:       io.NopCloser(bytes.NewBuffer(nil)),
+	}, nil
+}
+
+func (suite *MiddlewareUnitSuite) TestRetryMiddleware_retryBudget() {
+	table := []struct {
+		name            string
+		budget          *RetryBudget
+		expectCalls     []int
+		expectExhausted []bool
+		expectRemaining int
+	}{
+		{
+			name:            "no budget",
+			expectCalls:     []int{4, 4, 4},
+			expectExhausted: []bool{false, false, false},
+		},
+		{
+			name:            "budget outlasts requests",
+			budget:          NewRetryBudget(10),
+			expectCalls:     []int{4, 4, 4},
+			expectExhausted: []bool{false, false, false},
+			expectRemaining: 1,
+		},
+		{
+			name:            "budget spent across requests",
+			budget:          NewRetryBudget(5),
+			expectCalls:     []int{4, 3, 1},
+			expectExhausted: []bool{false, true, true},
+			expectRemaining: 0,
+		},
+		{
+			name:            "empty budget",
+			budget:          NewRetryBudget(0),
+			expectCalls:     []int{1, 1, 1},
+			expectExhausted: []bool{true, true, true},
+			expectRemaining: 0,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			if test.budget != nil {
+				ctx = BindRetryBudget(ctx, test.budget)
+			}
+
+			mw := RetryMiddleware{
+				MaxRetries: 3,
+				Delay:      time.Millisecond,
+			}
+
+			for i := range test.expectCalls {
+				mp := &statusPipeline{status: http.StatusInternalServerError}
+
+				req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://graph.microsoft.com", nil)
+				require.NoError(t, err, clues.ToCore(err))
+
+				resp, err := mw.Intercept(mp, 0, req)
+				require.NotNil(t, resp)
+
+				assert.Equal(t, http.StatusInternalServerError, resp.StatusCode, "request %d", i)
+				assert.Equal(t, test.expectCalls[i], mp.calls, "pipeline calls for request %d", i)
+				if test.expectExhausted[i] {
+					assert.ErrorIs(t, err, ErrRetryBudgetExhausted, "request %d", i)
+				} else {
+					assert.NotErrorIs(t, err, ErrRetryBudgetExhausted, "request %d", i)
+				}
+			}
+
+			if test.budget != nil {
+				assert.Equal(t, test.expectRemaining, test.budget.Remaining())
+			}
+		})
+	}
+}
+
+func (suite *MiddlewareUnitSuite) TestBudgetedRetryHandler() {
+	table := []struct {
+		name            string
+		budget          *RetryBudget
+		expectCalls     int
+		expectRemaining int
+	}{
+		{
+			name:        "no budget",
+			expectCalls: 4,
+		},
+		{
+			name:            "budget outlasts retries",
+			budget:          NewRetryBudget(5),
+			expectCalls:     4,
+			expectRemaining: 2,
+		},
+		{
+			name:            "budget spent",
+			budget:          NewRetryBudget(2),
+			expectCalls:     3,
+			expectRemaining: 0,
+		},
+		{
+			name:            "empty budget",
+			budget:          NewRetryBudget(0),
+			expectCalls:     1,
+			expectRemaining: 0,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			if test.budget != nil {
+				ctx = BindRetryBudget(ctx, test.budget)
+			}
+
+			var (
+				mw = newBudgetedRetryHandler()
+				mp = &statusPipeline{
+					status: http.StatusServiceUnavailable,
+					header: http.Header{"Retry-After": []string{"0"}},
+				}
+			)
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://graph.microsoft.com", nil)
+			require.NoError(t, err, clues.ToCore(err))
+
+			resp, err := mw.Intercept(mp, 0, req)
+			require.NoError(t, err, clues.ToCore(err))
+			require.NotNil(t, resp)
+
+			assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+			assert.Equal(t, test.expectCalls, mp.calls, "pipeline calls")
+
+			if test.budget != nil {
+				assert.Equal(t, test.expectRemaining, test.budget.Remaining())
+			}
+		})
+	}
+}
+
+func (suite *MiddlewareUnitSuite) TestKiotaRetryDelay() {
+	table := []struct {
+		name   string
+		resp   *http.Response
+		n      int
+		expect time.Duration
+	}{
+		{
+			name:   "retry-after",
+			resp:   throttledResp("2"),
+			n:      2,
+			expect: 2 * time.Second,
+		},
+		{
+			name:   "first exponential retry",
+			resp:   &http.Response{Header: http.Header{}},
+			n:      1,
+			expect: 3 * time.Second,
+		},
+		{
+			name:   "second exponential retry",
+			resp:   &http.Response{Header: http.Header{}},
+			n:      2,
+			expect: 9 * time.Second,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			assert.Equal(suite.T(), test.expect, kiotaRetryDelay(test.resp, test.n))
+		})
+	}
+}
+
+func (suite *MiddlewareUnitSuite) TestBudgetedRetryHandler_tracksThrottleWait() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	ctx = withThrottleWait(ctx)
+
+	var (
+		mw = newBudgetedRetryHandler()
+		mp = &sequencePipeline{resps: []*http.Response{
+			throttledResp("1"),
+			{StatusCode: http.StatusOK},
+		}}
+	)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://graph.microsoft.com", nil)
+	require.NoError(t, err, clues.ToCore(err))
+
+	resp, err := mw.Intercept(mp, 0, req)
+	require.NoError(t, err, clues.ToCore(err))
+	require.NotNil(t, resp)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 2, mp.calls, "pipeline calls")
+	assert.Equal(t, time.Second, throttleWaited(ctx), "retry handler's wait")
+}
+
+type deadlinePipeline struct {
+	ctx context.Context
+}
+
+func (mp *deadlinePipeline) Next(req *http.Request, _ int) (*http.Response, error) {
+	mp.ctx = req.Context()
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBuffer(nil)),
+	}, nil
+}
+
+func (suite *MiddlewareUnitSuite) TestRequestTimeoutMiddleware() {
+	timeouts := RequestTimeouts{
+		MetadataRequests: time.Minute,
+		DownloadRequests: 10 * time.Hour,
+	}
+
+	table := []struct {
+		name          string
+		opts          []Option
+		category      RequestCategory
+		expectTimeout time.Duration
+	}{
+		{
+			name: "no timeouts",
+		},
+		{
+			name:          "metadata client",
+			opts:          []Option{CategoryTimeouts(timeouts)},
+			expectTimeout: time.Minute,
+		},
+		{
+			name:          "download client",
+			opts:          []Option{CategoryTimeouts(timeouts), NoTimeout()},
+			expectTimeout: 10 * time.Hour,
+		},
+		{
+			name:          "download request on metadata client",
+			opts:          []Option{CategoryTimeouts(timeouts)},
+			category:      DownloadRequests,
+			expectTimeout: 10 * time.Hour,
+		},
+		{
+			name:          "metadata request on download client",
+			opts:          []Option{CategoryTimeouts(timeouts), NoTimeout()},
+			category:      MetadataRequests,
+			expectTimeout: time.Minute,
+		},
+		{
+			name: "category without a timeout",
+			opts: []Option{CategoryTimeouts(RequestTimeouts{
+				MetadataRequests: time.Minute,
+			})},
+			category: DownloadRequests,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			if len(test.category) > 0 {
+				ctx = BindRequestCategory(ctx, test.category)
+			}
+
+			var (
+				mw = newRequestTimeoutMiddleware(populateConfig(test.opts...))
+				mp = &deadlinePipeline{}
+			)
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://graph.microsoft.com", nil)
+			require.NoError(t, err, clues.ToCore(err))
+
+			start := time.Now()
+
+			resp, err := mw.Intercept(mp, 0, req)
+			require.NoError(t, err, clues.ToCore(err))
+			require.NotNil(t, resp)
+
+			deadline, ok := mp.ctx.Deadline()
+
+			if test.expectTimeout == 0 {
+				assert.False(t, ok, "request has no deadline")
+				return
+			}
+
+			require.True(t, ok, "request has a deadline")
+			assert.WithinDuration(t, start.Add(test.expectTimeout), deadline, time.Minute/2)
+
+			// the timeout is held until the body is closed.
+			assert.NoError(t, mp.ctx.Err(), "request context before closing the body")
+
+			err = resp.Body.Close()
+			require.NoError(t, err, clues.ToCore(err))
+
+			assert.ErrorIs(t, mp.ctx.Err(), context.Canceled, "request context after closing the body")
+		})
+	}
+}
+
+func (suite *MiddlewareUnitSuite) TestRequestTimeoutMiddleware_followsRetries() {
+	var (
+		clientOptions = msgraphsdkgo.GetDefaultClientOptions()
+		cc            = populateConfig()
+	)
+
+	table := []struct {
+		name string
+		mws  []khttp.Middleware
+	}{
+		{
+			name: "kiota middlewares",
+			mws:  kiotaMiddlewares(&clientOptions, cc),
+		},
+		{
+			name: "internal middlewares",
+			mws:  internalMiddleware(cc),
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			retry, kiotaRetry, timeout := -1, -1, -1
+
+			for i, mw := range test.mws {
+				switch mw.(type) {
+				case *RetryMiddleware:
+					retry = i
+				case *khttp.RetryHandler:
+					kiotaRetry = i
+				case *RequestTimeoutMiddleware:
+					timeout = i
+				}
+			}
+
+			require.NotEqual(t, -1, timeout, "request timeout middleware")
+			assert.Greater(t, timeout, retry, "timeout follows the retry middleware")
+			assert.Greater(t, timeout, kiotaRetry, "timeout follows the kiota retry handler")
+		})
+	}
+}
+
+type headerPipeline struct {
+	header http.Header
+}
+
+func (mp *headerPipeline) Next(*http.Request, int) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: http.StatusAccepted,
+		Header:     mp.header,
+		Body:       io.NopCloser(bytes.NewBuffer(nil)),
+	}, nil
+}
+
+func (suite *MiddlewareUnitSuite) TestResponseHeadersMiddleware() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		mw = &ResponseHeadersMiddleware{}
+		mp = &headerPipeline{header: http.Header{"Location": []string{"https://monitor"}}}
+		rh = http.Header{}
+	)
+
+	unbound, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://graph.microsoft.com", nil)
+	require.NoError(t, err, clues.ToCore(err))
+
+	_, err = mw.Intercept(mp, 0, unbound)
+	require.NoError(t, err, clues.ToCore(err))
+
+	bound, err := http.NewRequestWithContext(
+		BindResponseHeaders(ctx, rh),
+		http.MethodPost,
+		"https://graph.microsoft.com",
+		nil)
+	require.NoError(t, err, clues.ToCore(err))
+
+	_, err = mw.Intercept(mp, 0, bound)
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, "https://monitor", rh.Get("Location"))
+}
@@ -0,0 +1,144 @@
+package graph
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	khttp "github.com/microsoft/kiota-http-go"
+)
+
+// RequestCategory groups graph requests that share a timeout.
+type RequestCategory string
+
+const (
+	// MetadataRequests are the small, fast requests that enumerate and
+	// describe items and containers.  They're expected to fail fast.
+	MetadataRequests RequestCategory = "metadata"
+	// DownloadRequests stream item content, which can run for a long time
+	// when the items are large.
+	DownloadRequests RequestCategory = "download"
+)
+
+// RequestTimeouts maps each request category to the timeout applied to its
+// requests.  Categories without a timeout, or with a non-positive one, fall
+// back to the http client's timeout.
+type RequestTimeouts map[RequestCategory]time.Duration
+
+// timeoutFor produces the timeout configured for the category.  Returns
+// false if the category has no timeout.
+func (rt RequestTimeouts) timeoutFor(rc RequestCategory) (time.Duration, bool) {
+	to, ok := rt[rc]
+	return to, ok && to > 0
+}
+
+type requestCategoryKey string
+
+const requestCategoryCtxKey requestCategoryKey = "corsoGraphRequestCategory"
+
+// BindRequestCategory ensures any requests using this context are given the
+// timeout of the category, instead of the client's default category.
+func BindRequestCategory(ctx context.Context, rc RequestCategory) context.Context {
+	return context.WithValue(ctx, requestCategoryCtxKey, rc)
+}
+
+// requestCategory produces the category bound to the context, or the
+// fallback if the context has no category.
+func requestCategory(ctx context.Context, fallback RequestCategory) RequestCategory {
+	rc, ok := ctx.Value(requestCategoryCtxKey).(RequestCategory)
+	if !ok || len(rc) == 0 {
+		return fallback
+	}
+
+	return rc
+}
+
+// CategoryTimeouts sets the timeout for each category of request.
+func CategoryTimeouts(rt RequestTimeouts) Option {
+	return func(c *clientConfig) {
+		c.requestTimeouts = rt
+	}
+}
+
+// defaultRequestCategory is the category of requests made by the client
+// when the context doesn't provide one.  Clients without a timeout are only
+// used to download large items.
+func (c *clientConfig) defaultRequestCategory() RequestCategory {
+	if c.noTimeout {
+		return DownloadRequests
+	}
+
+	return MetadataRequests
+}
+
+// maxRequestTimeout produces the longest of the configured category timeouts.
+func (c *clientConfig) maxRequestTimeout() time.Duration {
+	var longest time.Duration
+
+	for _, to := range c.requestTimeouts {
+		longest = max(longest, to)
+	}
+
+	return longest
+}
+
+// ---------------------------------------------------------------------------
+// middleware
+// ---------------------------------------------------------------------------
+
+// RequestTimeoutMiddleware bounds each request by the timeout of its
+// request category.
+type RequestTimeoutMiddleware struct {
+	timeouts        RequestTimeouts
+	defaultCategory RequestCategory
+}
+
+func newRequestTimeoutMiddleware(cc *clientConfig) *RequestTimeoutMiddleware {
+	return &RequestTimeoutMiddleware{
+		timeouts:        cc.requestTimeouts,
+		defaultCategory: cc.defaultRequestCategory(),
+	}
+}
+
+// timeout produces the timeout for the request's category.  Returns false
+// if the category has no timeout.
+func (mw *RequestTimeoutMiddleware) timeout(req *http.Request) (time.Duration, bool) {
+	return mw.timeouts.timeoutFor(requestCategory(req.Context(), mw.defaultCategory))
+}
+
+func (mw *RequestTimeoutMiddleware) Intercept(
+	pipeline khttp.Pipeline,
+	middlewareIndex int,
+	req *http.Request,
+) (*http.Response, error) {
+	to, ok := mw.timeout(req)
+	if !ok {
+		return pipeline.Next(req, middlewareIndex)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), to)
+
+	resp, err := pipeline.Next(req.WithContext(ctx), middlewareIndex)
+	if err != nil || resp == nil || resp.Body == nil {
+		cancel()
+		return resp, err
+	}
+
+	// the body is read after the response returns, so the timeout can't
+	// be released until the body gets closed.
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+
+	return resp, nil
+}
+
+// cancelOnClose releases the request's timeout when the body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
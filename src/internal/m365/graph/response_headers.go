@@ -0,0 +1,46 @@
+package graph
+
+import (
+	"context"
+	"net/http"
+
+	khttp "github.com/microsoft/kiota-http-go"
+)
+
+type responseHeadersKey string
+
+const responseHeadersCtxKey responseHeadersKey = "corsoGraphResponseHeaders"
+
+// BindResponseHeaders ensures that the headers of the responses to requests
+// using this context get recorded in rh.  The graph sdk only hands back the
+// deserialized body, which is missing headers such as the monitor url of
+// asynchronous actions.
+func BindResponseHeaders(ctx context.Context, rh http.Header) context.Context {
+	return context.WithValue(ctx, responseHeadersCtxKey, rh)
+}
+
+// ResponseHeadersMiddleware records the headers of responses to requests
+// whose context was bound to a header set by BindResponseHeaders.
+type ResponseHeadersMiddleware struct{}
+
+func (mw *ResponseHeadersMiddleware) Intercept(
+	pipeline khttp.Pipeline,
+	middlewareIndex int,
+	req *http.Request,
+) (*http.Response, error) {
+	resp, err := pipeline.Next(req, middlewareIndex)
+	if resp == nil {
+		return resp, err
+	}
+
+	rh, ok := req.Context().Value(responseHeadersCtxKey).(http.Header)
+	if !ok || rh == nil {
+		return resp, err
+	}
+
+	for k, vs := range resp.Header {
+		rh[k] = vs
+	}
+
+	return resp, err
+}
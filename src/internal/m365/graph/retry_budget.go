@@ -0,0 +1,102 @@
+package graph
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/alcionai/clues"
+	khttp "github.com/microsoft/kiota-http-go"
+)
+
+// ErrRetryBudgetExhausted is returned by requests that would have been
+// retried, if the operation hadn't already spent its retry budget.
+var ErrRetryBudgetExhausted = clues.New("operation retry budget exhausted")
+
+// RetryBudget caps the total number of retries made by the retry middlewares
+// across every request that shares the budget, such as all the requests in
+// a single backup.  Per-request retry limits still apply; the budget only
+// stops a degraded tenant from retrying indefinitely across many requests.
+type RetryBudget struct {
+	remaining atomic.Int64
+}
+
+// NewRetryBudget produces a budget that allows up to max retries.
+func NewRetryBudget(max int) *RetryBudget {
+	rb := &RetryBudget{}
+	rb.remaining.Store(int64(max))
+
+	return rb
+}
+
+// Remaining reports the number of retries left in the budget.
+func (rb *RetryBudget) Remaining() int {
+	return int(max(rb.remaining.Load(), 0))
+}
+
+// spend consumes one retry from the budget.  Returns false if the budget
+// was already exhausted.
+func (rb *RetryBudget) spend() bool {
+	return rb.remaining.Add(-1) >= 0
+}
+
+type retryBudgetKey string
+
+const retryBudgetCtxKey retryBudgetKey = "corsoGraphRetryBudget"
+
+// BindRetryBudget ensures any requests using this context spend their
+// retries from the budget.
+func BindRetryBudget(ctx context.Context, rb *RetryBudget) context.Context {
+	return context.WithValue(ctx, retryBudgetCtxKey, rb)
+}
+
+// spendRetry consumes one retry from the budget bound to the context.
+// Returns false if the budget is exhausted.  Contexts without a budget
+// can always retry.
+func spendRetry(ctx context.Context) bool {
+	rb, ok := ctx.Value(retryBudgetCtxKey).(*RetryBudget)
+	if !ok || rb == nil {
+		return true
+	}
+
+	return rb.spend()
+}
+
+// kiotaRetryDelaySeconds is the base of the exponential delay that kiota's
+// retry handler uses for responses without a Retry-After header.
+const kiotaRetryDelaySeconds = 3
+
+// newBudgetedRetryHandler produces kiota's retry handler, which retries
+// throttled (429, 503, 504) responses, with each of its retries spent from
+// the budget bound to the request's context.  The delay before each retry
+// is added to the request's throttle wait, so that it counts against the
+// MaxThrottleWait of the RetryMiddleware.
+func newBudgetedRetryHandler() *khttp.RetryHandler {
+	return khttp.NewRetryHandlerWithOptions(khttp.RetryHandlerOptions{
+		DelaySeconds: kiotaRetryDelaySeconds,
+		ShouldRetry: func(_ time.Duration, executionCount int, req *http.Request, resp *http.Response) bool {
+			ctx := req.Context()
+
+			if !spendRetry(ctx) {
+				return false
+			}
+
+			addThrottleWait(ctx, kiotaRetryDelay(resp, executionCount+1))
+
+			return true
+		},
+	})
+}
+
+// kiotaRetryDelay mirrors the delay that kiota's retry handler waits before
+// the nth retry: the response's Retry-After header, or an exponential delay
+// if the response doesn't have one.
+func kiotaRetryDelay(resp *http.Response, n int) time.Duration {
+	if delay, ok := retryAfterDelay(resp); ok {
+		return delay
+	}
+
+	return time.Duration(math.Pow(kiotaRetryDelaySeconds, float64(n))) * time.Second
+}
@@ -27,7 +27,6 @@ const (
 	log2xxGraphRequestsEnvKey = "LOG_2XX_GRAPH_REQUESTS"
 	log2xxGraphResponseEnvKey = "LOG_2XX_GRAPH_RESPONSES"
 	defaultMaxRetries         = 3
-	defaultDelay              = 3 * time.Second
 	locationHeader            = "Location"
 	rateLimitHeader           = "RateLimit-Limit"
 	rateRemainingHeader       = "RateLimit-Remaining"
@@ -37,6 +36,9 @@ const (
 	defaultHTTPClientTimeout  = 1 * time.Hour
 )
 
+// DefaultDelay is the initial delay between retries of graph requests.
+const DefaultDelay = 3 * time.Second
+
 type QueryParams struct {
 	Category          path.CategoryType
 	ProtectedResource idname.Provider
@@ -180,6 +182,11 @@ type clientConfig struct {
 	maxRetries int
 	// The minimum delay in seconds between retries
 	minDelay time.Duration
+	// The maximum time a request pauses to resume after throttling
+	// outlasts its retries.
+	maxThrottleWait time.Duration
+	// The timeouts applied to each category of request.
+	requestTimeouts RequestTimeouts
 
 	appendMiddleware []khttp.Middleware
 }
@@ -191,7 +198,7 @@ func populateConfig(opts ...Option) *clientConfig {
 	cc := clientConfig{
 		maxConnectionRetries: defaultMaxRetries,
 		maxRetries:           defaultMaxRetries,
-		minDelay:             defaultDelay,
+		minDelay:             DefaultDelay,
 	}
 
 	for _, opt := range opts {
@@ -210,6 +217,18 @@ func (c *clientConfig) apply(hc *http.Client) {
 		// https://github.com/microsoft/kiota-http-go/pull/71
 		hc.Timeout = 48 * time.Hour
 	}
+
+	// the client timeout caps every request, so it can't be shorter
+	// than the timeout of any request category.
+	if longest := c.maxRequestTimeout(); longest > hc.Timeout {
+		hc.Timeout = longest
+	}
+
+	// throttled requests pause within the client timeout, so it gets
+	// extended by the longest pause.
+	if c.maxThrottleWait > 0 && hc.Timeout > 0 {
+		hc.Timeout += c.maxThrottleWait
+	}
 }
 
 // NoTimeout sets the httpClient.Timeout to 0 (unlimited).
@@ -246,6 +265,21 @@ func MinimumBackoff(min time.Duration) Option {
 	}
 }
 
+// MaxThrottleWait sets the cumulative time that a request can pause, honoring
+// the Retry-After header, when it's still throttled after its retries are
+// exhausted.  The waits between throttled retries count against the same
+// budget, and the client timeout is extended to fit it.  Zero, the default,
+// fails such requests immediately.
+func MaxThrottleWait(max time.Duration) Option {
+	return func(c *clientConfig) {
+		if max < 0 {
+			max = 0
+		}
+
+		c.maxThrottleWait = max
+	}
+}
+
 func appendMiddleware(mw ...khttp.Middleware) Option {
 	return func(c *clientConfig) {
 		if len(mw) > 0 {
@@ -278,14 +312,19 @@ func kiotaMiddlewares(
 	mw := []khttp.Middleware{
 		msgraphgocore.NewGraphTelemetryHandler(options),
 		&RetryMiddleware{
-			MaxRetries: cc.maxRetries,
-			Delay:      cc.minDelay,
+			MaxRetries:      cc.maxRetries,
+			Delay:           cc.minDelay,
+			MaxThrottleWait: cc.maxThrottleWait,
 		},
-		khttp.NewRetryHandler(),
+		newBudgetedRetryHandler(),
+		// after the retry handlers, so that the timeout bounds each attempt
+		// instead of spanning every retry and backoff.
+		newRequestTimeoutMiddleware(cc),
 		khttp.NewRedirectHandler(),
 		khttp.NewCompressionHandler(),
 		khttp.NewParametersNameDecodingHandler(),
 		khttp.NewUserAgentHandler(),
+		&ResponseHeadersMiddleware{},
 		&LoggingMiddleware{},
 	}
 
@@ -79,7 +79,7 @@ func (suite *GraphIntgSuite) TestHTTPClient() {
 				assert.Equal(t, defaultHTTPClientTimeout, c.Timeout, "default timeout")
 			},
 			checkConfig: func(t *testing.T, c *clientConfig) {
-				assert.Equal(t, defaultDelay, c.minDelay, "default delay")
+				assert.Equal(t, DefaultDelay, c.minDelay, "default delay")
 				assert.Equal(t, defaultMaxRetries, c.maxRetries, "max retries")
 				assert.Equal(t, defaultMaxRetries, c.maxConnectionRetries, "max connection retries")
 			},
@@ -138,6 +138,32 @@ func (suite *GraphIntgSuite) TestHTTPClient() {
 				assert.Equal(t, 5, c.maxConnectionRetries, "max connection retries")
 			},
 		},
+		{
+			name: "request timeouts outlast the client timeout",
+			opts: []Option{
+				CategoryTimeouts(RequestTimeouts{
+					MetadataRequests: time.Minute,
+					DownloadRequests: 2 * defaultHTTPClientTimeout,
+				}),
+			},
+			check: func(t *testing.T, c *http.Client) {
+				assert.Equal(t, 2*defaultHTTPClientTimeout, c.Timeout, "longest request timeout")
+			},
+			checkConfig: func(t *testing.T, c *clientConfig) {
+				assert.Equal(t, time.Minute, c.requestTimeouts[MetadataRequests], "metadata timeout")
+				assert.Equal(t, 2*defaultHTTPClientTimeout, c.requestTimeouts[DownloadRequests], "download timeout")
+			},
+		},
+		{
+			name: "throttle waits extend the client timeout",
+			opts: []Option{MaxThrottleWait(3 * time.Hour)},
+			check: func(t *testing.T, c *http.Client) {
+				assert.Equal(t, defaultHTTPClientTimeout+3*time.Hour, c.Timeout, "extended timeout")
+			},
+			checkConfig: func(t *testing.T, c *clientConfig) {
+				assert.Equal(t, 3*time.Hour, c.maxThrottleWait, "max throttle wait")
+			},
+		},
 	}
 	for _, test := range table {
 		suite.Run(test.name, func() {
@@ -32,6 +32,11 @@ type Controller struct {
 
 	Stats data.CollectionStats
 
+	CollisionsExisting int
+	CollisionsTotal    int
+
+	RestorePlan *control.RestorePlan
+
 	ProtectedResourceID   string
 	ProtectedResourceName string
 	ProtectedResourceErr  error
@@ -81,6 +86,24 @@ func (ctrl Controller) ConsumeRestoreCollections(
 	return ctrl.Deets, ctrl.Err
 }
 
+func (ctrl Controller) EstimateRestoreCollisions(
+	_ context.Context,
+	_ inject.RestoreConsumerConfig,
+	_ []data.RestoreCollection,
+	_ *fault.Bus,
+) (int, int, error) {
+	return ctrl.CollisionsExisting, ctrl.CollisionsTotal, ctrl.Err
+}
+
+func (ctrl Controller) PlanRestoreCollections(
+	_ context.Context,
+	_ inject.RestoreConsumerConfig,
+	_ []data.RestoreCollection,
+	_ *fault.Bus,
+) (*control.RestorePlan, error) {
+	return ctrl.RestorePlan, ctrl.Err
+}
+
 func (ctrl Controller) CacheItemInfo(dii details.ItemInfo) {}
 
 func (ctrl Controller) ProduceExportCollections(
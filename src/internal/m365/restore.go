@@ -5,6 +5,7 @@ import (
 
 	"github.com/alcionai/clues"
 
+	"github.com/alcionai/corso/src/internal/common/dttm"
 	"github.com/alcionai/corso/src/internal/data"
 	"github.com/alcionai/corso/src/internal/diagnostics"
 	"github.com/alcionai/corso/src/internal/m365/collection/drive"
@@ -16,6 +17,7 @@ import (
 	"github.com/alcionai/corso/src/internal/m365/support"
 	"github.com/alcionai/corso/src/internal/operations/inject"
 	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/count"
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/path"
@@ -97,3 +99,81 @@ func (ctrl *Controller) ConsumeRestoreCollections(
 
 	return deets.Details(), err
 }
+
+// EstimateRestoreCollisions counts the items in the collections that
+// collide with items which already exist in the restore destination,
+// without restoring anything.  Only drive-based data supports estimates.
+// Returns the count of colliding items, and the count of all items.
+func (ctrl *Controller) EstimateRestoreCollisions(
+	ctx context.Context,
+	rcc inject.RestoreConsumerConfig,
+	dcs []data.RestoreCollection,
+	errs *fault.Bus,
+) (int, int, error) {
+	ctx, end := diagnostics.Span(ctx, "m365:estimateRestoreCollisions")
+	defer end()
+
+	ctx = graph.BindRateLimiterConfig(ctx, graph.LimiterCfg{Service: rcc.Selector.PathService()})
+	ctx = clues.Add(ctx, "restore_config", rcc.RestoreConfig)
+
+	var rh drive.RestoreHandler
+
+	switch service := rcc.Selector.PathService(); service {
+	case path.OneDriveService:
+		rh = drive.NewRestoreHandler(ctrl.AC)
+	case path.SharePointService:
+		rh = drive.NewLibraryRestoreHandler(ctrl.AC, service)
+	default:
+		return 0, 0, clues.Wrap(clues.New(service.String()), "service not supported").WithClues(ctx)
+	}
+
+	return drive.EstimateCollisions(
+		ctx,
+		rh,
+		rcc,
+		ctrl.backupDriveIDNames,
+		dcs,
+		errs)
+}
+
+// PlanRestoreCollections produces the changes that restoring the collections
+// makes in the restore destination, without making any of them.  Only
+// drive-based data supports plans.
+func (ctrl *Controller) PlanRestoreCollections(
+	ctx context.Context,
+	rcc inject.RestoreConsumerConfig,
+	dcs []data.RestoreCollection,
+	errs *fault.Bus,
+) (*control.RestorePlan, error) {
+	ctx, end := diagnostics.Span(ctx, "m365:planRestore")
+	defer end()
+
+	ctx = graph.BindRateLimiterConfig(ctx, graph.LimiterCfg{Service: rcc.Selector.PathService()})
+	ctx = clues.Add(ctx, "restore_config", rcc.RestoreConfig)
+
+	var (
+		rh                drive.RestoreHandler
+		fallbackDriveName string
+	)
+
+	// the fallback drive names match the ones used by each service's restore.
+	switch service := rcc.Selector.PathService(); service {
+	case path.OneDriveService:
+		rh = drive.NewRestoreHandler(ctrl.AC)
+		fallbackDriveName = rcc.RestoreConfig.Location
+	case path.SharePointService:
+		rh = drive.NewLibraryRestoreHandler(ctrl.AC, service)
+		fallbackDriveName = control.DefaultRestoreContainerName(dttm.HumanReadableDriveItem)
+	default:
+		return nil, clues.Wrap(clues.New(service.String()), "service not supported").WithClues(ctx)
+	}
+
+	return drive.PlanRestore(
+		ctx,
+		rh,
+		rcc,
+		ctrl.backupDriveIDNames,
+		dcs,
+		fallbackDriveName,
+		errs)
+}
@@ -0,0 +1,71 @@
+package exchange
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/m365/collection/exchange"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/export"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+// ProduceExportCollections will create the export collections for the
+// given restore collections.  Only email is supported.
+func ProduceExportCollections(
+	ctx context.Context,
+	backupVersion int,
+	exportCfg control.ExportConfig,
+	opts control.Options,
+	dcs []data.RestoreCollection,
+	deets *details.Builder,
+	errs *fault.Bus,
+) ([]export.Collectioner, error) {
+	var (
+		el = errs.Local()
+		ec = make([]export.Collectioner, 0, len(dcs))
+	)
+
+	for _, restoreColl := range dcs {
+		var (
+			fp  = restoreColl.FullPath()
+			cat = fp.Category()
+		)
+
+		if cat != path.EmailCategory {
+			el.AddRecoverable(
+				ctx,
+				clues.New("unsupported category for export").With("category", cat))
+
+			continue
+		}
+
+		ec = append(ec, exchange.NewExportCollection(
+			exportDir(fp, exportCfg).String(),
+			[]data.RestoreCollection{restoreColl},
+			backupVersion,
+			exportCfg))
+	}
+
+	return ec, el.Failure()
+}
+
+// exportDir produces the export directory of the mail folder.  The
+// folders of an exchange restore collection are the display names of its
+// location, the same hierarchy recorded in the ParentPath of the
+// collection's ExchangeInfo details.  Maildir exports root each folder's
+// maildir at that hierarchy, while other formats nest it under the
+// category.
+func exportDir(fp path.Path, exportCfg control.ExportConfig) *path.Builder {
+	if exportCfg.Format == control.MaildirFormat {
+		return path.Builder{}.Append(fp.Folders()...)
+	}
+
+	return path.Builder{}.
+		Append(fp.Category().String()).
+		Append(fp.Folders()...)
+}
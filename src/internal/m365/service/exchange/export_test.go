@@ -0,0 +1,214 @@
+package exchange
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/data"
+	dataMock "github.com/alcionai/corso/src/internal/data/mock"
+	exchMock "github.com/alcionai/corso/src/internal/m365/service/exchange/mock"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/internal/version"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/export"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+type ExportUnitSuite struct {
+	tester.Suite
+}
+
+func TestExportUnitSuite(t *testing.T) {
+	suite.Run(t, &ExportUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func mailCollection(t *testing.T, cat path.CategoryType, folders ...string) dataMock.Collection {
+	fp, err := path.Build("t", "u", path.ExchangeService, cat, false, folders...)
+	require.NoError(t, err, clues.ToCore(err))
+
+	return dataMock.Collection{
+		Path: fp,
+		ItemData: []data.Item{
+			&dataMock.Item{
+				ItemID: "id1",
+				Reader: io.NopCloser(bytes.NewReader(exchMock.MessageBytes("one"))),
+			},
+			&dataMock.Item{
+				ItemID: "id2",
+				Reader: io.NopCloser(bytes.NewReader(exchMock.MessageWithDirectAttachment("two"))),
+			},
+		},
+	}
+}
+
+func (suite *ExportUnitSuite) TestProduceExportCollections_dirs() {
+	table := []struct {
+		name            string
+		format          control.FormatType
+		expectDirs      []string
+		expectExtraDirs []string
+	}{
+		{
+			name:       "eml",
+			expectDirs: []string{"email/Inbox", "email/Inbox/Sub Folder"},
+		},
+		{
+			name:       "maildir",
+			format:     control.MaildirFormat,
+			expectDirs: []string{"Inbox/cur", "Inbox/Sub Folder/cur"},
+			expectExtraDirs: []string{
+				"Inbox/new", "Inbox/tmp",
+				"Inbox/Sub Folder/new", "Inbox/Sub Folder/tmp",
+			},
+		},
+	}
+
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			dcs := []data.RestoreCollection{
+				mailCollection(t, path.EmailCategory, "Inbox"),
+				mailCollection(t, path.EmailCategory, "Inbox", "Sub Folder"),
+			}
+
+			ecs, err := ProduceExportCollections(
+				ctx,
+				version.Backup,
+				control.ExportConfig{Format: test.format},
+				control.DefaultOptions(),
+				dcs,
+				&details.Builder{},
+				fault.New(true))
+			require.NoError(t, err, clues.ToCore(err))
+
+			dirs, extraDirs := []string{}, []string{}
+			for _, ec := range ecs {
+				dirs = append(dirs, ec.BasePath())
+				extraDirs = append(extraDirs, export.ExtraDirs(ec)...)
+			}
+
+			assert.ElementsMatch(t, test.expectDirs, dirs)
+			assert.ElementsMatch(t, test.expectExtraDirs, extraDirs)
+		})
+	}
+}
+
+func (suite *ExportUnitSuite) TestProduceExportCollections_unsupportedCategory() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	errs := fault.New(false)
+
+	ecs, err := ProduceExportCollections(
+		ctx,
+		version.Backup,
+		control.ExportConfig{},
+		control.DefaultOptions(),
+		[]data.RestoreCollection{
+			mailCollection(t, path.EmailCategory, "Inbox"),
+			mailCollection(t, path.ContactsCategory, "Contacts"),
+		},
+		&details.Builder{},
+		errs)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Len(t, ecs, 1)
+	assert.Len(t, errs.Recovered(), 1)
+}
+
+// TestProduceExportCollections_maildirLayout writes a maildir export to
+// disk and checks that it can be read as a maildir: every maildir has
+// cur, new, and tmp folders, and every message is in the cur folder of
+// its mail folder's maildir, has a maildir name, and parses as an RFC 5322
+// message.
+func (suite *ExportUnitSuite) TestProduceExportCollections_maildirLayout() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		root   = t.TempDir()
+		nameRE = regexp.MustCompile(`^\d+\.[0-9a-f]+\.[^./:]+:2,[A-Z]*$`)
+		errs   = fault.New(true)
+	)
+
+	ecs, err := ProduceExportCollections(
+		ctx,
+		version.Backup,
+		control.ExportConfig{Format: control.MaildirFormat},
+		control.DefaultOptions(),
+		[]data.RestoreCollection{
+			mailCollection(t, path.EmailCategory, "Inbox"),
+			mailCollection(t, path.EmailCategory, "Inbox", "Sub Folder"),
+		},
+		&details.Builder{},
+		errs)
+	require.NoError(t, err, clues.ToCore(err))
+
+	err = export.ConsumeExportCollections(ctx, root, ecs, errs)
+	require.NoError(t, err, clues.ToCore(err))
+
+	for _, md := range []string{"Inbox", "Inbox/Sub Folder"} {
+		for _, sub := range []string{"cur", "new", "tmp"} {
+			assert.DirExists(t, filepath.Join(root, filepath.FromSlash(md), sub))
+		}
+	}
+
+	msgsByDir := map[string]int{}
+
+	err = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		require.NoError(t, err, clues.ToCore(err))
+
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, p)
+		require.NoError(t, err, clues.ToCore(err))
+
+		dir, name := filepath.Split(rel)
+		dir = filepath.ToSlash(filepath.Clean(dir))
+
+		assert.Equal(t, "cur", filepath.Base(dir), "message is in a cur folder")
+		assert.Regexp(t, nameRE, name)
+
+		f, err := os.Open(p)
+		require.NoError(t, err, clues.ToCore(err))
+
+		defer f.Close()
+
+		msg, err := mail.ReadMessage(f)
+		require.NoError(t, err, clues.ToCore(err))
+		assert.NotEmpty(t, msg.Header.Get("Subject"))
+		assert.NotEmpty(t, msg.Header.Get("From"))
+
+		msgsByDir[dir]++
+
+		return nil
+	})
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(
+		t,
+		map[string]int{"Inbox/cur": 2, "Inbox/Sub Folder/cur": 2},
+		msgsByDir)
+}
@@ -35,7 +35,7 @@ func ConsumeRestoreCollections(
 	var (
 		resourceID     = rcc.ProtectedResource.ID()
 		directoryCache = make(map[path.CategoryType]graph.ContainerResolver)
-		handlers       = exchange.RestoreHandlers(ac)
+		handlers       = exchange.RestoreHandlers(ac, rcc.RestoreConfig)
 		metrics        support.CollectionMetrics
 		el             = errs.Local()
 	)
@@ -68,10 +68,11 @@ func ConsumeRestoreCollections(
 			directoryCache[category] = gcr
 		}
 
-		containerID, gcc, err := exchange.CreateDestination(
+		containerID, gcc, err := exchange.RestoreDestination(
 			ictx,
 			handler,
-			handler.FormatRestoreDestination(rcc.RestoreConfig.Location, dc.FullPath()),
+			rcc.RestoreConfig.Location,
+			dc.FullPath(),
 			resourceID,
 			directoryCache[category],
 			errs)
@@ -97,6 +98,7 @@ func ConsumeRestoreCollections(
 			containerID,
 			collisionKeyToItemID,
 			rcc.RestoreConfig.OnCollision,
+			exchange.RestoreParallelism(ctx, rcc.Options),
 			deets,
 			errs,
 			ctr)
@@ -5,6 +5,7 @@ import (
 
 	"github.com/alcionai/clues"
 	"github.com/kopia/kopia/repo/manifest"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
 
 	"github.com/alcionai/corso/src/internal/common/idname"
 	"github.com/alcionai/corso/src/internal/common/prefixmatcher"
@@ -25,6 +26,7 @@ import (
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/logger"
 	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
 	"github.com/alcionai/corso/src/pkg/services/m365/api"
 )
 
@@ -76,10 +78,7 @@ func ProduceBackupCollections(
 
 		switch scope.Category().PathType() {
 		case path.LibrariesCategory:
-			// TODO(meain): Private channels get a separate SharePoint
-			// site. We should also back those up and not just the
-			// default one.
-			resp, err := ac.Groups().GetRootSite(ctx, bpc.ProtectedResource.ID())
+			sites, err := ac.Groups().GetAllSites(ctx, bpc.ProtectedResource.ID(), isTeam, errs)
 			if err != nil {
 				return nil, nil, false, err
 			}
@@ -92,41 +91,59 @@ func ProduceBackupCollections(
 				siteMetadataCollection[siteID] = append(siteMetadataCollection[siteID], c)
 			}
 
-			pr := idname.NewProvider(ptr.Val(resp.GetId()), ptr.Val(resp.GetName()))
-			sbpc := inject.BackupProducerConfig{
-				LastBackupVersion:   bpc.LastBackupVersion,
-				Options:             bpc.Options,
-				ProtectedResource:   pr,
-				Selector:            bpc.Selector,
-				MetadataCollections: siteMetadataCollection[ptr.Val(resp.GetId())],
-			}
+			allSitesCanUsePrev := true
 
-			bh := drive.NewGroupBackupHandler(
-				bpc.ProtectedResource.ID(),
-				ptr.Val(resp.GetId()),
-				ac.Drives(),
-				scope)
+			for _, s := range librarySites(b, sites) {
+				if el.Failure() != nil {
+					break
+				}
 
-			cp, err := bh.SitePathPrefix(creds.AzureTenantID)
-			if err != nil {
-				return nil, nil, false, clues.Wrap(err, "getting canonical path")
-			}
+				var (
+					siteID = ptr.Val(s.GetId())
+					ictx   = clues.Add(ctx, "site_id", siteID)
+				)
 
-			sitesPreviousPaths[ptr.Val(resp.GetId())] = cp.String()
+				pr := idname.NewProvider(siteID, ptr.Val(s.GetName()))
+				sbpc := inject.BackupProducerConfig{
+					LastBackupVersion:   bpc.LastBackupVersion,
+					Options:             bpc.Options,
+					ProtectedResource:   pr,
+					Selector:            bpc.Selector,
+					MetadataCollections: siteMetadataCollection[siteID],
+				}
 
-			dbcs, canUsePreviousBackup, err = site.CollectLibraries(
-				ctx,
-				sbpc,
-				bh,
-				creds.AzureTenantID,
-				ssmb,
-				su,
-				errs)
-			if err != nil {
-				el.AddRecoverable(ctx, err)
-				continue
+				bh := drive.NewGroupBackupHandler(
+					bpc.ProtectedResource.ID(),
+					siteID,
+					ac.Drives(),
+					scope)
+
+				cp, err := bh.SitePathPrefix(creds.AzureTenantID)
+				if err != nil {
+					return nil, nil, false, clues.Wrap(err, "getting canonical path")
+				}
+
+				sitesPreviousPaths[siteID] = cp.String()
+
+				cs, canUsePrev, err := site.CollectLibraries(
+					ictx,
+					sbpc,
+					bh,
+					creds.AzureTenantID,
+					ssmb,
+					su,
+					errs)
+				if err != nil {
+					el.AddRecoverable(ictx, err)
+					continue
+				}
+
+				dbcs = append(dbcs, cs...)
+				allSitesCanUsePrev = allSitesCanUsePrev && canUsePrev
 			}
 
+			canUsePreviousBackup = allSitesCanUsePrev
+
 		case path.ChannelMessagesCategory:
 			if !isTeam {
 				continue
@@ -183,6 +200,23 @@ func ProduceBackupCollections(
 	return collections, ssmb.ToReader(), canUsePreviousBackup, el.Failure()
 }
 
+// librarySites filters the group's sites down to the
+// sites that are included by the selector.
+func librarySites(
+	sel *selectors.GroupsBackup,
+	sites []models.Siteable,
+) []models.Siteable {
+	included := make([]models.Siteable, 0, len(sites))
+
+	for _, s := range sites {
+		if sel.IncludesSite(ptr.Val(s.GetId())) {
+			included = append(included, s)
+		}
+	}
+
+	return included
+}
+
 func getSitesMetadataCollection(
 	tenantID, groupID string,
 	sites map[string]string,
@@ -6,18 +6,24 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/alcionai/clues"
 	"github.com/kopia/kopia/repo/manifest"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/alcionai/corso/src/internal/common/ptr"
 	"github.com/alcionai/corso/src/internal/data"
 	"github.com/alcionai/corso/src/internal/kopia"
 	"github.com/alcionai/corso/src/internal/kopia/inject"
+	"github.com/alcionai/corso/src/internal/m365/collection/drive"
 	"github.com/alcionai/corso/src/internal/tester"
 	"github.com/alcionai/corso/src/pkg/backup/identity"
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
 )
 
 type GroupsBackupUnitSuite struct {
@@ -143,3 +149,70 @@ func (suite *GroupsBackupUnitSuite) TestMetadataFiles() {
 		})
 	}
 }
+
+func (suite *GroupsBackupUnitSuite) TestLibrarySites() {
+	var (
+		rootSite    = models.NewSite()
+		channelSite = models.NewSite()
+		sites       = []models.Siteable{rootSite, channelSite}
+	)
+
+	rootSite.SetId(ptr.To("root-site-id"))
+	channelSite.SetId(ptr.To("channel-site-id"))
+
+	table := []struct {
+		name       string
+		siteFilter []string
+		expect     []string
+	}{
+		{
+			name:   "all sites",
+			expect: []string{"root-site-id", "channel-site-id"},
+		},
+		{
+			name:       "filtered to one site",
+			siteFilter: []string{"channel-site-id"},
+			expect:     []string{"channel-site-id"},
+		},
+		{
+			name:       "filtered to no sites",
+			siteFilter: []string{"other-site-id"},
+			expect:     []string{},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			sel := selectors.NewGroupsBackup([]string{"group-id"})
+			sel.Include(sel.LibraryFolders(selectors.Any()))
+
+			if len(test.siteFilter) > 0 {
+				sel.Filter(sel.Sites(test.siteFilter))
+			}
+
+			var (
+				scope    = sel.Scopes()[0]
+				ids      = []string{}
+				prefixes = map[string]struct{}{}
+			)
+
+			for _, s := range librarySites(sel, sites) {
+				siteID := ptr.Val(s.GetId())
+				ids = append(ids, siteID)
+
+				bh := drive.NewGroupBackupHandler("group-id", siteID, api.Drives{}, scope)
+
+				p, err := bh.SitePathPrefix("tenant")
+				require.NoError(t, err, clues.ToCore(err))
+
+				assert.Contains(t, p.String(), siteID)
+
+				prefixes[p.String()] = struct{}{}
+			}
+
+			assert.ElementsMatch(t, test.expect, ids)
+			assert.Len(t, prefixes, len(test.expect), "each site has a distinct path prefix")
+		})
+	}
+}
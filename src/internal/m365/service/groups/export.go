@@ -36,6 +36,11 @@ func ProduceExportCollections(
 		ec = make([]export.Collectioner, 0, len(dcs))
 	)
 
+	libDirs, err := libraryExportDirs(ctx, dcs, backupDriveIDNames, backupSiteIDWebURL)
+	if err != nil {
+		return nil, err
+	}
+
 	for _, restoreColl := range dcs {
 		var (
 			fp      = restoreColl.FullPath()
@@ -54,44 +59,27 @@ func ProduceExportCollections(
 				backupVersion,
 				exportCfg)
 		case path.LibrariesCategory:
-			drivePath, err := path.ToDrivePath(restoreColl.FullPath())
+			drivePath, err := path.ToDrivePath(fp)
 			if err != nil {
 				return nil, clues.Wrap(err, "transforming path to drive path").WithClues(ctx)
 			}
 
-			driveName, ok := backupDriveIDNames.NameOf(drivePath.DriveID)
+			ld, ok := libDirs[fp.String()]
 			if !ok {
-				// This should not happen, but just in case
-				logger.Ctx(ctx).With("drive_id", drivePath.DriveID).Info("drive name not found, using drive id")
-				driveName = drivePath.DriveID
-			}
-
-			folders := restoreColl.FullPath().Folders()
-			siteName := folders[1] // use siteID by default
-
-			webURL, ok := backupSiteIDWebURL.NameOf(siteName)
-			if !ok {
-				// This should not happen, but just in case
-				logger.Ctx(ctx).With("site_id", folders[1]).Info("site weburl not found, using site id")
-			}
-
-			if len(webURL) != 0 {
-				// We can't use the actual name anyways as it might
-				// contain invalid characters. This should also avoid
-				// possibility of name collisions.
-				siteName = stdlibpath.Base(webURL)
+				return nil, clues.New("missing library export dir").WithClues(ctx)
 			}
 
 			baseDir := path.Builder{}.
 				Append("Libraries").
-				Append(siteName).
-				Append(driveName).
+				Append(ld.siteName).
+				Append(ld.driveName).
 				Append(drivePath.Folders...)
 
 			coll = drive.NewExportCollection(
 				baseDir.String(),
 				[]data.RestoreCollection{restoreColl},
-				backupVersion)
+				backupVersion,
+				exportCfg)
 		default:
 			el.AddRecoverable(
 				ctx,
@@ -105,3 +93,108 @@ func ProduceExportCollections(
 
 	return ec, el.Failure()
 }
+
+// libraryDir holds the site and drive directory names used as the
+// export prefix for a single library collection.
+type libraryDir struct {
+	siteID    string
+	siteName  string
+	driveID   string
+	driveName string
+}
+
+// libraryExportDirs produces the site and drive directory names for
+// every library collection, keyed by the collection's full path.
+// Sites are named by the base of their webURL and drives by their
+// name.  Since neither is guaranteed unique across a group (ex:
+// /sites/foo and /teams/foo), any site name shared by multiple site
+// IDs gets suffixed with its site ID, and any drive name shared by
+// multiple drive IDs within a site gets suffixed with its drive ID.
+// This keeps items from different drives from clobbering each other
+// in the export.
+func libraryExportDirs(
+	ctx context.Context,
+	dcs []data.RestoreCollection,
+	backupDriveIDNames idname.Cacher,
+	backupSiteIDWebURL idname.Cacher,
+) (map[string]libraryDir, error) {
+	var (
+		dirs       = map[string]libraryDir{}
+		siteOwners = map[string]map[string]struct{}{}
+		// siteID -> driveName -> driveIDs
+		driveOwners = map[string]map[string]map[string]struct{}{}
+	)
+
+	for _, restoreColl := range dcs {
+		fp := restoreColl.FullPath()
+		if fp.Category() != path.LibrariesCategory {
+			continue
+		}
+
+		drivePath, err := path.ToDrivePath(fp)
+		if err != nil {
+			return nil, clues.Wrap(err, "transforming path to drive path").WithClues(ctx)
+		}
+
+		driveName, ok := backupDriveIDNames.NameOf(drivePath.DriveID)
+		if !ok {
+			// This should not happen, but just in case
+			logger.Ctx(ctx).With("drive_id", drivePath.DriveID).Info("drive name not found, using drive id")
+			driveName = drivePath.DriveID
+		}
+
+		folders := fp.Folders()
+		siteID := folders[1]
+		siteName := siteID // use siteID by default
+
+		webURL, ok := backupSiteIDWebURL.NameOf(siteID)
+		if !ok {
+			// This should not happen, but just in case
+			logger.Ctx(ctx).With("site_id", siteID).Info("site weburl not found, using site id")
+		}
+
+		if len(webURL) != 0 {
+			// We can't use the actual name anyways as it might
+			// contain invalid characters. This should also avoid
+			// possibility of name collisions.
+			siteName = stdlibpath.Base(webURL)
+		}
+
+		dirs[fp.String()] = libraryDir{
+			siteID:    siteID,
+			siteName:  siteName,
+			driveID:   drivePath.DriveID,
+			driveName: driveName,
+		}
+
+		if _, ok := siteOwners[siteName]; !ok {
+			siteOwners[siteName] = map[string]struct{}{}
+		}
+
+		siteOwners[siteName][siteID] = struct{}{}
+
+		if _, ok := driveOwners[siteID]; !ok {
+			driveOwners[siteID] = map[string]map[string]struct{}{}
+		}
+
+		if _, ok := driveOwners[siteID][driveName]; !ok {
+			driveOwners[siteID][driveName] = map[string]struct{}{}
+		}
+
+		driveOwners[siteID][driveName][drivePath.DriveID] = struct{}{}
+	}
+
+	for k, ld := range dirs {
+		if len(siteOwners[ld.siteName]) > 1 && ld.siteName != ld.siteID {
+			ld.siteName = ld.siteName + "_" + ld.siteID
+		}
+
+		if len(driveOwners[ld.siteID][ld.driveName]) > 1 && ld.driveName != ld.driveID {
+			ld.driveName = ld.driveName + "_" + ld.driveID
+		}
+
+		dirs[k] = ld
+	}
+
+	return dirs, nil
+}
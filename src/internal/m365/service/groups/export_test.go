@@ -205,3 +205,187 @@ func (suite *ExportUnitSuite) TestExportRestoreCollections_libraries() {
 
 	assert.Equal(t, expectedItems, fitems, "items")
 }
+
+func (suite *ExportUnitSuite) TestExportRestoreCollections_librariesAcrossSites() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		siteID1   = "siteID1"
+		siteID2   = "siteID2"
+		driveID1  = "driveID1"
+		driveID2  = "driveID2"
+		driveName = "Documents"
+		exportCfg = control.ExportConfig{}
+		// both drives share a name, and both sites share a webURL base
+		driveNameCache = idname.NewCache(map[string]string{
+			strings.ToLower(driveID1): driveName,
+			strings.ToLower(driveID2): driveName,
+		})
+		siteWebURLCache = idname.NewCache(map[string]string{
+			strings.ToLower(siteID1): "https://tenant.sharepoint.com/sites/shared",
+			strings.ToLower(siteID2): "https://tenant.sharepoint.com/teams/shared",
+		})
+		dii = odStub.DriveItemInfo()
+	)
+
+	dii.OneDrive.ItemName = "name1"
+
+	makeColl := func(siteID, driveID string) data.RestoreCollection {
+		p, err := odConsts.DriveFolderPrefixBuilder(driveID).ToDataLayerPath(
+			"t",
+			"u",
+			path.GroupsService,
+			path.LibrariesCategory,
+			false,
+			odConsts.SitesPathDir,
+			siteID)
+		assert.NoError(t, err, "build path")
+
+		return data.FetchRestoreCollection{
+			Collection: dataMock.Collection{
+				Path: p,
+				ItemData: []data.Item{
+					&dataMock.Item{
+						ItemID:   "id1.data",
+						Reader:   io.NopCloser(bytes.NewBufferString("body1")),
+						ItemInfo: dii,
+					},
+				},
+			},
+			FetchItemByNamer: finD{id: "id1.meta", key: "filename", name: "name1"},
+		}
+	}
+
+	dcs := []data.RestoreCollection{
+		makeColl(siteID1, driveID1),
+		makeColl(siteID2, driveID2),
+	}
+
+	ecs, err := ProduceExportCollections(
+		ctx,
+		int(version.Backup),
+		exportCfg,
+		control.DefaultOptions(),
+		dcs,
+		driveNameCache,
+		siteWebURLCache,
+		nil,
+		fault.New(true))
+	assert.NoError(t, err, "export collections error")
+	assert.Len(t, ecs, 2, "num of collections")
+
+	expectPaths := []string{
+		"Libraries/shared_" + siteID1 + "/" + driveName,
+		"Libraries/shared_" + siteID2 + "/" + driveName,
+	}
+
+	seen := map[string]struct{}{}
+
+	for _, ec := range ecs {
+		for item := range ec.Items(ctx) {
+			assert.NoError(t, item.Error, "item error")
+
+			key := ec.BasePath() + "/" + item.Name
+			assert.NotContains(t, seen, key, "export paths collide")
+
+			seen[key] = struct{}{}
+		}
+	}
+
+	assert.ElementsMatch(
+		t,
+		expectPaths,
+		[]string{ecs[0].BasePath(), ecs[1].BasePath()},
+		"base dirs")
+}
+
+func (suite *ExportUnitSuite) TestLibraryExportDirs() {
+	var (
+		driveNameCache = idname.NewCache(map[string]string{
+			"d1": "Documents",
+			"d2": "Documents",
+			"d3": "Documents",
+			"d4": "Reports",
+		})
+		siteWebURLCache = idname.NewCache(map[string]string{
+			"s1": "https://tenant.sharepoint.com/sites/alpha",
+			"s2": "https://tenant.sharepoint.com/sites/beta",
+		})
+	)
+
+	makeColl := func(t *testing.T, siteID, driveID string) data.RestoreCollection {
+		p, err := odConsts.DriveFolderPrefixBuilder(driveID).ToDataLayerPath(
+			"t",
+			"u",
+			path.GroupsService,
+			path.LibrariesCategory,
+			false,
+			odConsts.SitesPathDir,
+			siteID)
+		assert.NoError(t, err, "build path")
+
+		return dataMock.Collection{Path: p}
+	}
+
+	table := []struct {
+		name   string
+		colls  [][2]string
+		expect map[string][2]string
+	}{
+		{
+			name:  "distinct sites, same drive name",
+			colls: [][2]string{{"s1", "d1"}, {"s2", "d2"}},
+			expect: map[string][2]string{
+				"d1": {"alpha", "Documents"},
+				"d2": {"beta", "Documents"},
+			},
+		},
+		{
+			name:  "same site, colliding drive names",
+			colls: [][2]string{{"s1", "d1"}, {"s1", "d3"}, {"s1", "d4"}},
+			expect: map[string][2]string{
+				"d1": {"alpha", "Documents_d1"},
+				"d3": {"alpha", "Documents_d3"},
+				"d4": {"alpha", "Reports"},
+			},
+		},
+		{
+			name:  "unknown site falls back to site id",
+			colls: [][2]string{{"s3", "d4"}},
+			expect: map[string][2]string{
+				"d4": {"s3", "Reports"},
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			dcs := []data.RestoreCollection{}
+
+			for _, c := range test.colls {
+				dcs = append(dcs, makeColl(t, c[0], c[1]))
+			}
+
+			dirs, err := libraryExportDirs(ctx, dcs, driveNameCache, siteWebURLCache)
+			assert.NoError(t, err, "library dirs")
+			assert.Len(t, dirs, len(test.expect))
+
+			for _, ld := range dirs {
+				expect, ok := test.expect[ld.driveID]
+				if !assert.True(t, ok, "unexpected drive "+ld.driveID) {
+					continue
+				}
+
+				assert.Equal(t, expect[0], ld.siteName, "site name")
+				assert.Equal(t, expect[1], ld.driveName, "drive name")
+			}
+		})
+	}
+}
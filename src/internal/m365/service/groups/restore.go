@@ -12,12 +12,16 @@ import (
 	"github.com/alcionai/corso/src/internal/common/ptr"
 	"github.com/alcionai/corso/src/internal/data"
 	"github.com/alcionai/corso/src/internal/m365/collection/drive"
+	"github.com/alcionai/corso/src/internal/m365/collection/groups"
+	"github.com/alcionai/corso/src/internal/m365/graph"
+	odConsts "github.com/alcionai/corso/src/internal/m365/service/onedrive/consts"
 	"github.com/alcionai/corso/src/internal/m365/support"
 	"github.com/alcionai/corso/src/internal/operations/inject"
 	"github.com/alcionai/corso/src/pkg/backup/details"
 	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/count"
 	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
 	"github.com/alcionai/corso/src/pkg/path"
 	"github.com/alcionai/corso/src/pkg/services/m365/api"
 )
@@ -35,80 +39,85 @@ func ConsumeRestoreCollections(
 ) (*support.ControllerOperationStatus, error) {
 	var (
 		restoreMetrics support.CollectionMetrics
-		caches         = drive.NewRestoreCaches(backupDriveIDNames)
-		lrh            = drive.NewLibraryRestoreHandler(ac, rcc.Selector.PathService())
 		el             = errs.Local()
+		// library collections, grouped by the site they were backed up from.
+		siteIDs      = []string{}
+		siteColls    = map[string][]data.RestoreCollection{}
+		channelColls = []data.RestoreCollection{}
 	)
 
-	// TODO: uncomment when a handler is available
-	// err := caches.Populate(ctx, lrh, rcc.ProtectedResource.ID())
-	// if err != nil {
-	// 	return nil, clues.Wrap(err, "initializing restore caches")
-	// }
-
 	// Reorder collections so that the parents directories are created
 	// before the child directories; a requirement for permissions.
 	data.SortRestoreCollections(dcs)
 
-	// Iterate through the data collections and restore the contents of each
 	for _, dc := range dcs {
-		if el.Failure() != nil {
-			break
-		}
-
-		var (
-			err      error
-			resp     models.Siteable
-			category = dc.FullPath().Category()
-			metrics  support.CollectionMetrics
-			ictx     = clues.Add(ctx,
-				"category", category,
-				"restore_location", clues.Hide(rcc.RestoreConfig.Location),
-				"protected_resource", clues.Hide(dc.FullPath().ProtectedResource()),
-				"full_path", dc.FullPath())
-		)
-
-		switch dc.FullPath().Category() {
+		switch category := dc.FullPath().Category(); category {
 		case path.LibrariesCategory:
-			// TODO(meain): As of now we only restore the root site
-			// and that too to whatever is currently the root site of the
-			// group and not the original one. Not sure if the
-			// original can be changed.
-			resp, err = ac.Groups().GetRootSite(ctx, rcc.ProtectedResource.ID())
+			siteID, err := librarySiteID(dc.FullPath())
 			if err != nil {
-				return nil, err
+				return nil, clues.Stack(err).WithClues(ctx)
 			}
 
-			pr := idname.NewProvider(ptr.Val(resp.GetId()), ptr.Val(resp.GetName()))
-			srcc := inject.RestoreConsumerConfig{
-				BackupVersion:     rcc.BackupVersion,
-				Options:           rcc.Options,
-				ProtectedResource: pr,
-				RestoreConfig:     rcc.RestoreConfig,
-				Selector:          rcc.Selector,
+			if _, ok := siteColls[siteID]; !ok {
+				siteIDs = append(siteIDs, siteID)
 			}
 
-			err = caches.Populate(ctx, lrh, srcc.ProtectedResource.ID())
-			if err != nil {
-				return nil, clues.Wrap(err, "initializing restore caches")
-			}
-
-			metrics, err = drive.RestoreCollection(
-				ictx,
-				lrh,
-				srcc,
-				dc,
-				caches,
-				deets,
-				control.DefaultRestoreContainerName(dttm.HumanReadableDriveItem),
-				errs,
-				ctr)
+			siteColls[siteID] = append(siteColls[siteID], dc)
+		case path.ChannelMessagesCategory:
+			channelColls = append(channelColls, dc)
 		default:
 			return nil, clues.New("data category not supported").
 				With("category", category).
-				WithClues(ictx)
+				WithClues(ctx)
+		}
+	}
+
+	for _, siteID := range siteIDs {
+		if el.Failure() != nil {
+			break
 		}
 
+		metrics, err := restoreSiteLibraries(
+			clues.Add(ctx, "site_id", siteID),
+			ac,
+			rcc,
+			siteID,
+			siteColls[siteID],
+			backupDriveIDNames,
+			deets,
+			errs,
+			ctr)
+
+		restoreMetrics = support.CombineMetrics(restoreMetrics, metrics)
+
+		if err != nil {
+			el.AddRecoverable(ctx, err)
+		}
+
+		if errors.Is(err, context.Canceled) {
+			break
+		}
+	}
+
+	for _, dc := range channelColls {
+		if el.Failure() != nil || ctx.Err() != nil {
+			break
+		}
+
+		ictx := clues.Add(ctx,
+			"category", dc.FullPath().Category(),
+			"restore_location", clues.Hide(rcc.RestoreConfig.Location),
+			"protected_resource", clues.Hide(dc.FullPath().ProtectedResource()),
+			"full_path", dc.FullPath())
+
+		metrics, err := groups.RestoreChannelMeta(
+			ictx,
+			ac.Channels(),
+			rcc,
+			dc,
+			errs,
+			ctr)
+
 		restoreMetrics = support.CombineMetrics(restoreMetrics, metrics)
 
 		if err != nil {
@@ -129,3 +138,128 @@ func ConsumeRestoreCollections(
 
 	return status, el.Failure()
 }
+
+// restoreSiteLibraries restores the library collections that were backed
+// up from the site into that same site.
+func restoreSiteLibraries(
+	ctx context.Context,
+	ac api.Client,
+	rcc inject.RestoreConsumerConfig,
+	siteID string,
+	dcs []data.RestoreCollection,
+	backupDriveIDNames idname.Cacher,
+	deets *details.Builder,
+	errs *fault.Bus,
+	ctr *count.Bus,
+) (support.CollectionMetrics, error) {
+	var (
+		restoreMetrics support.CollectionMetrics
+		// drive names are only unique within a site, so each site
+		// gets caches of its own.
+		caches = drive.NewRestoreCaches(backupDriveIDNames)
+		lrh    = drive.NewLibraryRestoreHandler(ac, rcc.Selector.PathService())
+		el     = errs.Local()
+	)
+
+	resp, err := restoreSite(ctx, ac.Sites(), ac.Groups(), rcc.ProtectedResource.ID(), siteID)
+	if err != nil {
+		return restoreMetrics, err
+	}
+
+	srcc := inject.RestoreConsumerConfig{
+		BackupVersion:     rcc.BackupVersion,
+		Options:           rcc.Options,
+		ProtectedResource: idname.NewProvider(ptr.Val(resp.GetId()), ptr.Val(resp.GetName())),
+		RestoreConfig:     rcc.RestoreConfig,
+		Selector:          rcc.Selector,
+	}
+
+	err = caches.Populate(ctx, lrh, srcc.ProtectedResource.ID())
+	if err != nil {
+		return restoreMetrics, clues.Wrap(err, "initializing restore caches")
+	}
+
+	for _, dc := range dcs {
+		if el.Failure() != nil {
+			break
+		}
+
+		ictx := clues.Add(ctx,
+			"category", dc.FullPath().Category(),
+			"restore_location", clues.Hide(rcc.RestoreConfig.Location),
+			"protected_resource", clues.Hide(dc.FullPath().ProtectedResource()),
+			"full_path", dc.FullPath())
+
+		metrics, err := drive.RestoreCollection(
+			ictx,
+			lrh,
+			srcc,
+			dc,
+			caches,
+			deets,
+			control.DefaultRestoreContainerName(dttm.HumanReadableDriveItem),
+			errs,
+			ctr)
+
+		restoreMetrics = support.CombineMetrics(restoreMetrics, metrics)
+
+		if err != nil {
+			el.AddRecoverable(ctx, err)
+		}
+
+		if errors.Is(err, context.Canceled) {
+			return restoreMetrics, err
+		}
+	}
+
+	return restoreMetrics, el.Failure()
+}
+
+// librarySiteID returns the id of the site that the library collection
+// was backed up from.  Group library paths are prefixed with
+// `sites/<siteID>`.
+func librarySiteID(p path.Path) (string, error) {
+	folders := p.Folders()
+
+	if len(folders) < 2 || folders[0] != odConsts.SitesPathDir || len(folders[1]) == 0 {
+		return "", clues.New("library path has no site").With("full_path", p)
+	}
+
+	return folders[1], nil
+}
+
+type siteGetter interface {
+	GetByID(ctx context.Context, identifier string) (models.Siteable, error)
+}
+
+type rootSiteGetter interface {
+	GetRootSite(ctx context.Context, identifier string) (models.Siteable, error)
+}
+
+// restoreSite looks up the site to restore a library into: the site that
+// the library was backed up from.  Sites that no longer exist, such as the
+// site of a deleted channel, fall back to the group's current root site.
+func restoreSite(
+	ctx context.Context,
+	sg siteGetter,
+	rsg rootSiteGetter,
+	groupID, siteID string,
+) (models.Siteable, error) {
+	site, err := sg.GetByID(ctx, siteID)
+	if err == nil {
+		return site, nil
+	}
+
+	if !errors.Is(err, graph.ErrResourceOwnerNotFound) {
+		return nil, clues.Wrap(err, "getting restore site")
+	}
+
+	logger.CtxErr(ctx, err).Info("backup site not found; restoring into the group's root site")
+
+	site, err = rsg.GetRootSite(ctx, groupID)
+	if err != nil {
+		return nil, clues.Wrap(err, "getting group root site")
+	}
+
+	return site, nil
+}
@@ -0,0 +1,153 @@
+package groups
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/m365/graph"
+	odConsts "github.com/alcionai/corso/src/internal/m365/service/onedrive/consts"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+type RestoreUnitSuite struct {
+	tester.Suite
+}
+
+func TestRestoreUnitSuite(t *testing.T) {
+	suite.Run(t, &RestoreUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *RestoreUnitSuite) TestLibrarySiteID() {
+	table := []struct {
+		name      string
+		folders   []string
+		expect    string
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "root site",
+			folders: []string{
+				odConsts.SitesPathDir, "root-site-id",
+				odConsts.DrivesPathDir, "drive-id", odConsts.RootPathDir,
+			},
+			expect:    "root-site-id",
+			expectErr: assert.NoError,
+		},
+		{
+			name: "channel site",
+			folders: []string{
+				odConsts.SitesPathDir, "channel-site-id",
+				odConsts.DrivesPathDir, "drive-id", odConsts.RootPathDir, "folder",
+			},
+			expect:    "channel-site-id",
+			expectErr: assert.NoError,
+		},
+		{
+			name: "no site prefix",
+			folders: []string{
+				odConsts.DrivesPathDir, "drive-id", odConsts.RootPathDir,
+			},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			p, err := path.Build("t", "g", path.GroupsService, path.LibrariesCategory, false, test.folders...)
+			require.NoError(t, err, clues.ToCore(err))
+
+			siteID, err := librarySiteID(p)
+			test.expectErr(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expect, siteID)
+		})
+	}
+}
+
+type mockSiteGetter struct {
+	sites map[string]models.Siteable
+	err   error
+}
+
+func (m mockSiteGetter) GetByID(_ context.Context, id string) (models.Siteable, error) {
+	if s, ok := m.sites[id]; ok {
+		return s, nil
+	}
+
+	return nil, m.err
+}
+
+func (m mockSiteGetter) GetRootSite(_ context.Context, _ string) (models.Siteable, error) {
+	return m.sites["root"], nil
+}
+
+func stubSite(id string) models.Siteable {
+	s := models.NewSite()
+	s.SetId(ptr.To(id))
+
+	return s
+}
+
+func (suite *RestoreUnitSuite) TestRestoreSite() {
+	sg := mockSiteGetter{
+		sites: map[string]models.Siteable{
+			"root":            stubSite("root-site-id"),
+			"channel-site-id": stubSite("channel-site-id"),
+		},
+	}
+
+	table := []struct {
+		name      string
+		siteID    string
+		err       error
+		expect    string
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "channel site",
+			siteID:    "channel-site-id",
+			expect:    "channel-site-id",
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "deleted site falls back to the root site",
+			siteID:    "deleted-site-id",
+			err:       clues.Stack(graph.ErrResourceOwnerNotFound, assert.AnError),
+			expect:    "root-site-id",
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "lookup error",
+			siteID:    "deleted-site-id",
+			err:       assert.AnError,
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			sg.err = test.err
+
+			site, err := restoreSite(ctx, sg, sg, "group-id", test.siteID)
+			test.expectErr(t, err, clues.ToCore(err))
+
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, test.expect, ptr.Val(site.GetId()))
+		})
+	}
+}
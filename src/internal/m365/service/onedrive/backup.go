@@ -54,6 +54,7 @@ func ProduceBackupCollections(
 			bpc.ProtectedResource.ID(),
 			su,
 			bpc.Options)
+		nc.SetChecksums(bpc.DriveChecksums)
 
 		odcs, canUsePreviousBackup, err = nc.Get(ctx, bpc.MetadataCollections, ssmb, errs)
 		if err != nil {
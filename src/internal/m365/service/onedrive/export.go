@@ -43,7 +43,8 @@ func ProduceExportCollections(
 			drive.NewExportCollection(
 				baseDir.String(),
 				[]data.RestoreCollection{dc},
-				backupVersion))
+				backupVersion,
+				exportCfg))
 	}
 
 	return ec, el.Failure()
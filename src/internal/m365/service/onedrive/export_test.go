@@ -248,7 +248,8 @@ func (suite *ExportUnitSuite) TestGetItems() {
 			ec := drive.NewExportCollection(
 				"",
 				[]data.RestoreCollection{test.backingCollection},
-				test.version)
+				test.version,
+				control.DefaultExportConfig())
 
 			items := ec.Items(ctx)
 
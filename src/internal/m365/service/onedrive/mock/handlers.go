@@ -8,6 +8,7 @@ import (
 	"github.com/microsoftgraph/msgraph-sdk-go/drives"
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
 
+	"github.com/alcionai/corso/src/internal/m365/graph"
 	odConsts "github.com/alcionai/corso/src/internal/m365/service/onedrive/consts"
 	"github.com/alcionai/corso/src/pkg/backup/details"
 	"github.com/alcionai/corso/src/pkg/control"
@@ -283,7 +284,18 @@ type RestoreHandler struct {
 	CalledDeleteItemOn string
 	DeleteItemErr      error
 
+	// CurrentETags holds the eTag of each item in the destination, by
+	// item ID.  Conditional deletes fail when the eTags don't match.
+	CurrentETags            map[string]string
+	CalledDeleteItemIfMatch bool
+
+	CalledCopyItem   bool
+	CalledCopyItemOn string
+	CopyItemResp     models.DriveItemable
+	CopyItemErr      error
+
 	CalledPostItem bool
+	PostedItem     models.DriveItemable
 	PostItemResp   models.DriveItemable
 	PostItemErr    error
 
@@ -292,6 +304,7 @@ type RestoreHandler struct {
 	PostDriveResp models.Driveable
 	PostDriveErr  error
 
+	UploadSessionFSI models.FileSystemInfoable
 	UploadSessionErr error
 }
 
@@ -332,6 +345,22 @@ func (h *RestoreHandler) DeleteItem(
 	return h.DeleteItemErr
 }
 
+func (h *RestoreHandler) DeleteItemIfMatch(
+	_ context.Context,
+	_, itemID, eTag string,
+) error {
+	h.CalledDeleteItemIfMatch = true
+
+	if current, ok := h.CurrentETags[itemID]; ok && current != eTag {
+		return clues.Stack(graph.ErrPreconditionFailed)
+	}
+
+	h.CalledDeleteItem = true
+	h.CalledDeleteItemOn = itemID
+
+	return h.DeleteItemErr
+}
+
 func (h *RestoreHandler) DeleteItemPermission(
 	context.Context,
 	string, string, string,
@@ -340,9 +369,11 @@ func (h *RestoreHandler) DeleteItemPermission(
 }
 
 func (h *RestoreHandler) NewItemContentUpload(
-	context.Context,
-	string, string,
+	_ context.Context,
+	_, _ string,
+	fsi models.FileSystemInfoable,
 ) (models.UploadSessionable, error) {
+	h.UploadSessionFSI = fsi
 	return models.NewUploadSession(), h.UploadSessionErr
 }
 
@@ -362,13 +393,24 @@ func (h *RestoreHandler) PostItemLinkShareUpdate(
 	return nil, clues.New("not implemented")
 }
 
+func (h *RestoreHandler) CopyItem(
+	_ context.Context,
+	_, itemID, _, _ string,
+) (models.DriveItemable, error) {
+	h.CalledCopyItem = true
+	h.CalledCopyItemOn = itemID
+
+	return h.CopyItemResp, h.CopyItemErr
+}
+
 func (h *RestoreHandler) PostItemInContainer(
-	context.Context,
-	string, string,
-	models.DriveItemable,
-	control.CollisionPolicy,
+	_ context.Context,
+	_, _ string,
+	newItem models.DriveItemable,
+	_ control.CollisionPolicy,
 ) (models.DriveItemable, error) {
 	h.CalledPostItem = true
+	h.PostedItem = newItem
 	return h.PostItemResp, h.PostItemErr
 }
 
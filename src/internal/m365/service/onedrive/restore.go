@@ -48,6 +48,8 @@ func ConsumeRestoreCollections(
 	// before the child directories; a requirement for permissions.
 	data.SortRestoreCollections(dcs)
 
+	drive.EnsureDrivesExist(ctx, rh, rcc, caches, dcs, fallbackDriveName)
+
 	// Iterate through the data collections and restore the contents of each
 	for _, dc := range dcs {
 		if el.Failure() != nil {
@@ -109,6 +111,15 @@ func AugmentRestorePaths(
 	// of the directory.
 	colPaths := map[string]path.RestorePaths{}
 
+	// Backups that preserve empty folders record the folder's .dirmeta as
+	// an item, so the selected paths may already hold some of the dirmeta
+	// files added below.
+	selected := map[string]struct{}{}
+
+	for _, p := range paths {
+		selected[p.StoragePath.String()] = struct{}{}
+	}
+
 	allowedCategories := map[path.CategoryType]struct{}{
 		path.LibrariesCategory: {},
 		path.FilesCategory:     {},
@@ -181,6 +192,10 @@ func AugmentRestorePaths(
 				return nil, err
 			}
 
+			if _, ok := selected[mPath.String()]; ok {
+				continue
+			}
+
 			paths = append(
 				paths,
 				path.RestorePaths{StoragePath: mPath, RestorePath: p.RestorePath})
@@ -161,6 +161,29 @@ func (suite *RestoreUnitSuite) TestAugmentRestorePaths() {
 				"folder/folder2/file.txt.data",
 			},
 		},
+		{
+			name:    "empty folder v6",
+			version: version.OneDrive6NameInMeta,
+			input: []string{
+				"folder/.dirmeta",
+			},
+			output: []string{
+				"folder/.dirmeta",
+			},
+		},
+		{
+			name:    "empty folder in non-empty folder v6",
+			version: version.OneDrive6NameInMeta,
+			input: []string{
+				"folder/file.txt.data",
+				"folder/empty/.dirmeta",
+			},
+			output: []string{
+				"folder/.dirmeta",
+				"folder/empty/.dirmeta",
+				"folder/file.txt.data",
+			},
+		},
 	}
 
 	for _, test := range table {
@@ -166,9 +166,59 @@ func retrieveSitePageOptions() *betasites.ItemPagesSitePageItemRequestBuilderGet
 	return options
 }
 
+// PagePoster creates and publishes site pages.
+type PagePoster interface {
+	PostPage(ctx context.Context, siteID string, page betamodels.SitePageable) (betamodels.SitePageable, error)
+	GetPage(ctx context.Context, siteID, pageID string) (betamodels.SitePageable, error)
+	PublishPage(ctx context.Context, siteID, pageID string) error
+}
+
+var _ PagePoster = &BetaService{}
+
+// PostPage creates the page on the site.
+// See: https://learn.microsoft.com/en-us/graph/api/sitepage-create?view=graph-rest-beta
+func (s BetaService) PostPage(
+	ctx context.Context,
+	siteID string,
+	page betamodels.SitePageable,
+) (betamodels.SitePageable, error) {
+	resp, err := s.Client().SitesById(siteID).Pages().Post(ctx, page, nil)
+	if err != nil {
+		return nil, graph.Wrap(ctx, err, "creating page")
+	}
+
+	return resp, nil
+}
+
+// GetPage retrieves the page from the site.
+func (s BetaService) GetPage(
+	ctx context.Context,
+	siteID, pageID string,
+) (betamodels.SitePageable, error) {
+	resp, err := s.Client().SitesById(siteID).PagesById(pageID).Get(ctx, retrieveSitePageOptions())
+	if err != nil {
+		return nil, graph.Wrap(ctx, err, "getting page")
+	}
+
+	return resp, nil
+}
+
+// PublishPage makes the page visible on the site.
+// See https://learn.microsoft.com/en-us/graph/api/sitepage-publish?view=graph-rest-beta
+func (s BetaService) PublishPage(ctx context.Context, siteID, pageID string) error {
+	err := s.Client().SitesById(siteID).PagesById(pageID).Publish().Post(ctx, nil)
+	if err != nil {
+		return graph.Wrap(ctx, err, "publishing page")
+	}
+
+	return nil
+}
+
+// RestoreSitePage recreates the page from its backed up content, with the
+// name changed to {destName}_{name}, and publishes it.
 func RestoreSitePage(
 	ctx context.Context,
-	service *BetaService,
+	pp PagePoster,
 	itemData data.Item,
 	siteID, destName string,
 ) (details.ItemInfo, error) {
@@ -205,28 +255,35 @@ func RestoreSitePage(
 	// Restore is a 2-Step Process in Graph API
 	// 1. Create the Page on the site
 	// 2. Publish the site
-	// See: https://learn.microsoft.com/en-us/graph/api/sitepage-create?view=graph-rest-beta
-	restoredPage, err := service.Client().SitesById(siteID).Pages().Post(ctx, page, nil)
+	restoredPage, err := pp.PostPage(ctx, siteID, page)
 	if err != nil {
-		return dii, graph.Wrap(ctx, err, "creating page")
+		return dii, clues.Stack(err)
 	}
 
 	pageID = ptr.Val(restoredPage.GetId())
+	if len(pageID) == 0 {
+		return dii, clues.New("page id not populated during page creation").WithClues(ctx)
+	}
+
 	ctx = clues.Add(ctx, "restored_page_id", pageID)
 
-	// Publish page to make visible
-	// See https://learn.microsoft.com/en-us/graph/api/sitepage-publish?view=graph-rest-beta
+	// The beta adapter can't wait on async requests, so the page may not be
+	// fully provisioned when the creation response arrives.  Look the page
+	// up again before giving up on it.
 	if restoredPage.GetWebUrl() == nil {
-		return dii, clues.New("webURL not populated during page creation").WithClues(ctx)
+		restoredPage, err = pp.GetPage(ctx, siteID, pageID)
+		if err != nil {
+			return dii, clues.Stack(err)
+		}
+
+		if restoredPage.GetWebUrl() == nil {
+			return dii, clues.New("webURL not populated during page creation").WithClues(ctx)
+		}
 	}
 
-	err = service.Client().
-		SitesById(siteID).
-		PagesById(pageID).
-		Publish().
-		Post(ctx, nil)
-	if err != nil {
-		return dii, graph.Wrap(ctx, err, "publishing page")
+	// Publish page to make visible
+	if err := pp.PublishPage(ctx, siteID, pageID); err != nil {
+		return dii, clues.Stack(err)
 	}
 
 	dii.SharePoint = PageInfo(restoredPage, int64(len(byteArray)))
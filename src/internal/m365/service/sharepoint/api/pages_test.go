@@ -2,6 +2,7 @@ package api_test
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"testing"
 
@@ -10,13 +11,16 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/alcionai/corso/src/internal/common/ptr"
 	"github.com/alcionai/corso/src/internal/m365/collection/site"
 	"github.com/alcionai/corso/src/internal/m365/graph"
+	betamodels "github.com/alcionai/corso/src/internal/m365/graph/betasdk/models"
 	"github.com/alcionai/corso/src/internal/m365/service/sharepoint/api"
 	spMock "github.com/alcionai/corso/src/internal/m365/service/sharepoint/mock"
 	"github.com/alcionai/corso/src/internal/tester"
 	"github.com/alcionai/corso/src/internal/tester/tconfig"
 	"github.com/alcionai/corso/src/pkg/account"
+	"github.com/alcionai/corso/src/pkg/backup/details"
 	"github.com/alcionai/corso/src/pkg/control/testdata"
 	"github.com/alcionai/corso/src/pkg/fault"
 )
@@ -128,3 +132,169 @@ func (suite *SharePointPageSuite) TestRestoreSinglePage() {
 	err = api.DeleteSitePage(ctx, suite.service, suite.siteID, pageID)
 	assert.NoError(t, err, clues.ToCore(err))
 }
+
+// ---------------------------------------------------------------------------
+// unit tests
+// ---------------------------------------------------------------------------
+
+type mockPagePoster struct {
+	postErr    error
+	postResp   betamodels.SitePageable
+	getResp    betamodels.SitePageable
+	publishErr error
+
+	posted    betamodels.SitePageable
+	got       []string
+	published []string
+}
+
+func (m *mockPagePoster) PostPage(
+	_ context.Context,
+	_ string,
+	page betamodels.SitePageable,
+) (betamodels.SitePageable, error) {
+	m.posted = page
+	return m.postResp, m.postErr
+}
+
+func (m *mockPagePoster) GetPage(
+	_ context.Context,
+	_, pageID string,
+) (betamodels.SitePageable, error) {
+	m.got = append(m.got, pageID)
+	return m.getResp, nil
+}
+
+func (m *mockPagePoster) PublishPage(_ context.Context, _, pageID string) error {
+	m.published = append(m.published, pageID)
+	return m.publishErr
+}
+
+func sitePage(id, title, webURL string) betamodels.SitePageable {
+	page := betamodels.NewSitePage()
+	page.SetId(ptr.To(id))
+	page.SetTitle(ptr.To(title))
+
+	if len(webURL) > 0 {
+		page.SetWebUrl(ptr.To(webURL))
+	}
+
+	return page
+}
+
+type SharePointPageUnitSuite struct {
+	tester.Suite
+}
+
+func TestSharePointPageUnitSuite(t *testing.T) {
+	suite.Run(t, &SharePointPageUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *SharePointPageUnitSuite) TestRestoreSitePage() {
+	const (
+		title    = "Byte Test"
+		destName = "Corso_Restore"
+	)
+
+	table := []struct {
+		name            string
+		content         []byte
+		poster          *mockPagePoster
+		expectErr       assert.ErrorAssertionFunc
+		expectGot       []string
+		expectPublished []string
+	}{
+		{
+			name:    "restored and published",
+			content: spMock.Page(title),
+			poster: &mockPagePoster{
+				postResp: sitePage("new-id", title, "https://site/page"),
+			},
+			expectErr:       assert.NoError,
+			expectPublished: []string{"new-id"},
+		},
+		{
+			name:    "web url populated after creation",
+			content: spMock.Page(title),
+			poster: &mockPagePoster{
+				postResp: sitePage("new-id", title, ""),
+				getResp:  sitePage("new-id", title, "https://site/page"),
+			},
+			expectErr:       assert.NoError,
+			expectGot:       []string{"new-id"},
+			expectPublished: []string{"new-id"},
+		},
+		{
+			name:    "web url never populated",
+			content: spMock.Page(title),
+			poster: &mockPagePoster{
+				postResp: sitePage("new-id", title, ""),
+				getResp:  sitePage("new-id", title, ""),
+			},
+			expectErr: assert.Error,
+			expectGot: []string{"new-id"},
+		},
+		{
+			name:    "no page id",
+			content: spMock.Page(title),
+			poster: &mockPagePoster{
+				postResp: sitePage("", title, "https://site/page"),
+			},
+			expectErr: assert.Error,
+		},
+		{
+			name:    "post fails",
+			content: spMock.Page(title),
+			poster: &mockPagePoster{
+				postErr: assert.AnError,
+			},
+			expectErr: assert.Error,
+		},
+		{
+			name:    "publish fails",
+			content: spMock.Page(title),
+			poster: &mockPagePoster{
+				postResp:   sitePage("new-id", title, "https://site/page"),
+				publishErr: assert.AnError,
+			},
+			expectErr:       assert.Error,
+			expectPublished: []string{"new-id"},
+		},
+		{
+			name:      "malformed content",
+			content:   []byte("{not a page"),
+			poster:    &mockPagePoster{},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			pageData := site.NewItem("page-id", io.NopCloser(bytes.NewReader(test.content)))
+
+			info, err := api.RestoreSitePage(ctx, test.poster, pageData, "site-id", destName)
+			test.expectErr(t, err, clues.ToCore(err))
+
+			assert.Equal(t, test.expectGot, test.poster.got, "pages looked up")
+			assert.Equal(t, test.expectPublished, test.poster.published, "pages published")
+
+			if err != nil {
+				return
+			}
+
+			require.NotNil(t, test.poster.posted)
+			assert.Equal(t, destName+"_"+title+".aspx", ptr.Val(test.poster.posted.GetName()))
+
+			require.NotNil(t, info.SharePoint)
+			assert.Equal(t, details.SharePointPage, info.SharePoint.ItemType)
+			assert.Equal(t, title, info.SharePoint.ItemName)
+			assert.Equal(t, "https://site/page", info.SharePoint.WebURL)
+			assert.Equal(t, "new-id", info.SharePoint.ParentPath)
+			assert.Equal(t, int64(len(test.content)), info.SharePoint.Size)
+		})
+	}
+}
@@ -33,10 +33,11 @@ func ConsumeRestoreCollections(
 	ctr *count.Bus,
 ) (*support.ControllerOperationStatus, error) {
 	var (
-		lrh            = drive.NewLibraryRestoreHandler(ac, rcc.Selector.PathService())
-		restoreMetrics support.CollectionMetrics
-		caches         = drive.NewRestoreCaches(backupDriveIDNames)
-		el             = errs.Local()
+		lrh               = drive.NewLibraryRestoreHandler(ac, rcc.Selector.PathService())
+		restoreMetrics    support.CollectionMetrics
+		caches            = drive.NewRestoreCaches(backupDriveIDNames)
+		el                = errs.Local()
+		fallbackDriveName = control.DefaultRestoreContainerName(dttm.HumanReadableDriveItem)
 	)
 
 	err := caches.Populate(ctx, lrh, rcc.ProtectedResource.ID())
@@ -48,6 +49,8 @@ func ConsumeRestoreCollections(
 	// before the child directories; a requirement for permissions.
 	data.SortRestoreCollections(dcs)
 
+	drive.EnsureDrivesExist(ctx, lrh, rcc, caches, dcs, fallbackDriveName)
+
 	// Iterate through the data collections and restore the contents of each
 	for _, dc := range dcs {
 		if el.Failure() != nil {
@@ -74,7 +77,7 @@ func ConsumeRestoreCollections(
 				dc,
 				caches,
 				deets,
-				control.DefaultRestoreContainerName(dttm.HumanReadableDriveItem),
+				fallbackDriveName,
 				errs,
 				ctr)
 
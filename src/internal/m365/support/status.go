@@ -5,6 +5,8 @@ import (
 	"fmt"
 
 	"github.com/dustin/go-humanize"
+
+	"github.com/alcionai/corso/src/internal/stats"
 )
 
 // ControllerOperationStatus is a data type used to describe the state of
@@ -18,6 +20,10 @@ type ControllerOperationStatus struct {
 	Metrics CollectionMetrics
 	details string
 	op      Operation
+
+	// Concurrency holds the item fetch concurrency sampled for each
+	// collection that contributed to the status.
+	Concurrency []stats.ItemConcurrency
 }
 
 type CollectionMetrics struct {
@@ -77,10 +83,11 @@ func MergeStatus(one, two ControllerOperationStatus) ControllerOperationStatus {
 	}
 
 	status := ControllerOperationStatus{
-		Folders: one.Folders + two.Folders,
-		Metrics: CombineMetrics(one.Metrics, two.Metrics),
-		details: one.details + ", " + two.details,
-		op:      one.op,
+		Folders:     one.Folders + two.Folders,
+		Metrics:     CombineMetrics(one.Metrics, two.Metrics),
+		Concurrency: append(append([]stats.ItemConcurrency{}, one.Concurrency...), two.Concurrency...),
+		details:     one.details + ", " + two.details,
+		op:          one.op,
 	}
 
 	return status
@@ -53,6 +53,20 @@ const (
 	// manifest search. It shouldn't be used to differentiate between backups once
 	// the manifest search completes.
 	MergeBackup = "merge-backup"
+	// PartialBackup denotes that this backup was stopped before it completed,
+	// at the request of the caller. It holds a consistent, but incomplete,
+	// snapshot of the data being backed up, and is never used as a base for
+	// later backups.
+	PartialBackup = "partial-backup"
+	// BackupVersionTag holds the format version of the backup, so that
+	// backups needing migration can be found by tag.  The backup's Version
+	// field remains the source of truth for the format.  Backups created
+	// before the tag was introduced don't carry it.
+	BackupVersionTag = "backup-version"
+	// BackupGroupTag holds the id shared by backups that were produced by
+	// the same operation, when the operation splits its backup into one
+	// backup per service category.
+	BackupGroupTag = "backup-group"
 )
 
 // Valid returns true if the ModelType value fits within the const range.
@@ -6,6 +6,7 @@ import (
 
 	"github.com/alcionai/clues"
 	"github.com/google/uuid"
+	"golang.org/x/exp/maps"
 
 	"github.com/alcionai/corso/src/internal/common/crash"
 	"github.com/alcionai/corso/src/internal/common/dttm"
@@ -17,6 +18,7 @@ import (
 	"github.com/alcionai/corso/src/internal/kopia"
 	kinject "github.com/alcionai/corso/src/internal/kopia/inject"
 	"github.com/alcionai/corso/src/internal/m365/graph"
+	"github.com/alcionai/corso/src/internal/m365/graph/metadata"
 	"github.com/alcionai/corso/src/internal/model"
 	"github.com/alcionai/corso/src/internal/observe"
 	"github.com/alcionai/corso/src/internal/operations/inject"
@@ -53,6 +55,10 @@ type BackupOperation struct {
 	// should be removed when we have a more controlled workaround.
 	BackupVersion int
 
+	// DetailsSigningKey signs the backup's details, so that later reads
+	// can detect tampering.  Details aren't signed if the key is empty.
+	DetailsSigningKey []byte
+
 	account account.Account
 	bp      inject.BackupProducer
 
@@ -61,13 +67,28 @@ type BackupOperation struct {
 	// When true, disables kopia-assisted incremental backups. This forces
 	// downloading and hashing all item data for items not in the merge base(s).
 	disableAssistBackup bool
+
+	// set by RequestStop.  Pointer so that copies of the operation
+	// share the same stop.
+	stop *stopper
 }
 
 // BackupResults aggregate the details of the result of the operation.
 type BackupResults struct {
 	stats.ReadWrites
 	stats.StartAndEndTime
+	stats.CountValues
 	BackupID model.StableID `json:"backupID"`
+	// ItemConcurrency reports the item fetch concurrency achieved while
+	// streaming each collection.
+	ItemConcurrency []stats.ItemConcurrency `json:"itemConcurrency,omitempty"`
+	// BackupGroupID is shared by the backups of an operation that produced
+	// one backup per service category.  In that case, BackupIDs holds the
+	// id of every backup, and BackupID holds the first of them.
+	BackupGroupID string           `json:"backupGroupID,omitempty"`
+	BackupIDs     []model.StableID `json:"backupIDs,omitempty"`
+	// OperationID identifies the run of the backup in its logs and errors.
+	OperationID string `json:"operationID,omitempty"`
 }
 
 // NewBackupOperation constructs and validates a backup operation.
@@ -92,8 +113,11 @@ func NewBackupOperation(
 		incremental:         useIncrementalBackup(selector, opts),
 		disableAssistBackup: opts.ToggleFeatures.ForceItemDataDownload,
 		bp:                  bp,
+		stop:                newStopper(),
 	}
 
+	op.Results.OperationID = op.ID
+
 	if err := op.validate(); err != nil {
 		return BackupOperation{}, err
 	}
@@ -126,6 +150,8 @@ type backupStats struct {
 	ctrl                *data.CollectionStats
 	resourceCount       int
 	hasNewDetailEntries bool
+	mergeBaseIDs        []model.StableID
+	assistBaseIDs       []model.StableID
 }
 
 // An assist backup must meet the following criteria:
@@ -158,6 +184,20 @@ func isAssistBackup(
 		len(err.Recovered()) > 0
 }
 
+// A backup limited by control.Options.IncrementalSince is persisted as an
+// assist backup as long as it produced a valid snapshot & details, since it
+// doesn't contain the items modified before the cutoff.
+func isSinceLimitedBackup(
+	since time.Time,
+	snapID, ssid string,
+	err *fault.Bus,
+) bool {
+	return !since.IsZero() &&
+		len(snapID) > 0 &&
+		len(ssid) > 0 &&
+		err.Failure() == nil
+}
+
 // A merge backup must meet the following criteria:
 // 1. valid details ssid & item snapshot ID
 // 2. zero recoverable errors
@@ -186,6 +226,17 @@ func isMergeBackup(
 // Primary Controller
 // ---------------------------------------------------------------------------
 
+// RequestStop asks a running backup to stop gracefully.  Collections stop
+// handing new items to the repository, everything processed so far gets
+// persisted, and the backup is finalized with a PartiallyCompleted status.
+// Partial backups are tagged as such, and are never used as the base for
+// later incremental backups.  Safe to call from any goroutine, and at any
+// point in the operation.  A stop that arrives after every collection handed
+// over its items leaves the backup complete.
+func (op *BackupOperation) RequestStop() {
+	op.stop.request()
+}
+
 // Run begins a synchronous backup operation.
 func (op *BackupOperation) Run(ctx context.Context) (err error) {
 	defer func() {
@@ -199,6 +250,13 @@ func (op *BackupOperation) Run(ctx context.Context) (err error) {
 		end()
 	}()
 
+	ctx = op.bindOperationID(ctx)
+	ctx = op.bindRetryBudget(ctx)
+	ctx = op.bindThrottleSignal(ctx)
+
+	ctx, stopLimiter := op.bindItemFetchLimiter(ctx)
+	defer stopLimiter()
+
 	ctx, flushMetrics := events.NewMetrics(ctx, logger.Writer{Ctx: ctx})
 	defer flushMetrics()
 
@@ -223,6 +281,25 @@ func (op *BackupOperation) Run(ctx context.Context) (err error) {
 		return err
 	}
 
+	if op.Options.SnapshotPerCategory {
+		sels, err := op.Selectors.SplitByPathCategory()
+		if err != nil {
+			err = clues.Wrap(err, "splitting selector by category")
+			op.Errors.Fail(err)
+
+			return err
+		}
+
+		if len(sels) > 1 {
+			return op.runPerCategory(ctx, sels)
+		}
+	}
+
+	return op.run(ctx)
+}
+
+// run backs up the data in the operation's selector.
+func (op *BackupOperation) run(ctx context.Context) (err error) {
 	// -----
 	// Setup
 	// -----
@@ -255,6 +332,8 @@ func (op *BackupOperation) Run(ctx context.Context) (err error) {
 		})
 
 	defer func() {
+		op.Results.Counts = op.countValues(ctx)
+
 		op.bus.Event(
 			ctx,
 			events.BackupEnd,
@@ -284,7 +363,7 @@ func (op *BackupOperation) Run(ctx context.Context) (err error) {
 	if err != nil {
 		// No return here!  We continue down to persistResults, even in case of failure.
 		logger.CtxErr(ctx, err).Error("running backup")
-		op.Errors.Fail(clues.Wrap(err, "running backup"))
+		op.Errors.Fail(clues.Wrap(err, "running backup").WithClues(ctx))
 	}
 
 	LogFaultErrors(ctx, op.Errors.Errors(), "running backup")
@@ -320,6 +399,129 @@ func (op *BackupOperation) Run(ctx context.Context) (err error) {
 	return op.Errors.Failure()
 }
 
+// runPerCategory backs up each selector as a separate backup, each with
+// its own snapshot and backup model, linked by a shared backup group id.
+// The operation's results and errors aggregate those of every backup.
+func (op *BackupOperation) runPerCategory(
+	ctx context.Context,
+	sels []selectors.Selector,
+) error {
+	op.Results.BackupGroupID = uuid.NewString()
+
+	ctx = clues.Add(
+		ctx,
+		"backup_group_id", op.Results.BackupGroupID,
+		"category_backups", len(sels))
+
+	for _, sel := range sels {
+		cop := op.categoryBackup(sel)
+
+		if err := cop.run(ctx); err != nil {
+			logger.CtxErr(ctx, err).Error("running category backup")
+		}
+
+		op.mergeCategoryBackup(ctx, cop)
+
+		if op.stop.isRequested() ||
+			(op.Errors.Failure() != nil && op.Errors.FailFast()) {
+			break
+		}
+	}
+
+	op.Results.Counts = op.countValues(ctx)
+
+	return op.Errors.Failure()
+}
+
+// categoryBackup produces a copy of the operation that backs up the
+// selector.  The copy keeps its own results, errors, and counts, so
+// that the backup model it creates only describes its own data.
+func (op *BackupOperation) categoryBackup(sel selectors.Selector) *BackupOperation {
+	cop := *op
+	cop.Selectors = sel
+	cop.Status = InProgress
+	cop.Errors = fault.New(op.Errors.FailFast()).SetOperationID(op.ID)
+	cop.Counter = op.Counter.Local()
+	cop.Results = BackupResults{
+		BackupGroupID: op.Results.BackupGroupID,
+		OperationID:   op.ID,
+	}
+	cop.incremental = useIncrementalBackup(sel, op.Options)
+
+	return &cop
+}
+
+// mergeCategoryBackup folds the results, errors, and status of a finished
+// category backup into the operation.
+func (op *BackupOperation) mergeCategoryBackup(ctx context.Context, cop *BackupOperation) {
+	cr := cop.Results
+
+	if len(cr.BackupID) > 0 {
+		op.Results.BackupIDs = append(op.Results.BackupIDs, cr.BackupID)
+
+		if len(op.Results.BackupID) == 0 {
+			op.Results.BackupID = cr.BackupID
+		}
+	}
+
+	op.Results.BytesRead += cr.BytesRead
+	op.Results.BytesUploaded += cr.BytesUploaded
+	op.Results.ItemsRead += cr.ItemsRead
+	op.Results.ItemsWritten += cr.ItemsWritten
+	op.Results.NonMetaBytesUploaded += cr.NonMetaBytesUploaded
+	op.Results.NonMetaItemsWritten += cr.NonMetaItemsWritten
+	op.Results.ResourceOwners = max(op.Results.ResourceOwners, cr.ResourceOwners)
+	op.Results.SnapshotBytes += cr.SnapshotBytes
+	op.Results.SnapshotStoredBytes += cr.SnapshotStoredBytes
+	op.Results.CompressionRatio = compressionRatio(op.Results.SnapshotBytes, op.Results.SnapshotStoredBytes)
+	op.Results.ItemConcurrency = append(op.Results.ItemConcurrency, cr.ItemConcurrency...)
+
+	if op.Results.StartedAt.IsZero() || cr.StartedAt.Before(op.Results.StartedAt) {
+		op.Results.StartedAt = cr.StartedAt
+	}
+
+	if cr.CompletedAt.After(op.Results.CompletedAt) {
+		op.Results.CompletedAt = cr.CompletedAt
+	}
+
+	if err := cop.Errors.Failure(); err != nil {
+		op.Errors.Fail(err)
+	}
+
+	for _, err := range cop.Errors.Recovered() {
+		op.Errors.AddRecoverable(ctx, err)
+	}
+
+	skipped := cop.Errors.Skipped()
+	for i := range skipped {
+		op.Errors.AddSkip(ctx, &skipped[i])
+	}
+
+	op.Status = mergeCategoryStatus(op.Status, cop.Status)
+}
+
+// mergeCategoryStatus produces the status of an operation from the
+// statuses of its category backups.  A failure in any backup fails the
+// operation, and the operation only has no data if no backup had data.
+func mergeCategoryStatus(current, next OpStatus) OpStatus {
+	if current == InProgress || current == Unknown {
+		return next
+	}
+
+	rank := map[OpStatus]int{
+		NoData:             0,
+		Completed:          1,
+		PartiallyCompleted: 2,
+		Failed:             3,
+	}
+
+	if rank[next] > rank[current] {
+		return next
+	}
+
+	return current
+}
+
 // do is purely the action of running a backup.  All pre/post behavior
 // is found in Run().
 func (op *BackupOperation) do(
@@ -366,6 +568,14 @@ func (op *BackupOperation) do(
 		return nil, clues.Wrap(err, "producing manifests and metadata")
 	}
 
+	for _, base := range mans.Backups() {
+		opStats.mergeBaseIDs = append(opStats.mergeBaseIDs, base.ID)
+	}
+
+	for _, base := range mans.UniqueAssistBackups() {
+		opStats.assistBaseIDs = append(opStats.assistBaseIDs, base.ID)
+	}
+
 	ctx = clues.Add(
 		ctx,
 		"can_use_metadata", canUseMetadata,
@@ -376,6 +586,15 @@ func (op *BackupOperation) do(
 		lastBackupVersion = mans.MinBackupVersion()
 	}
 
+	var driveChecksums map[string]map[string]details.ItemChecksum
+
+	if canUseMetadata && op.Options.ToggleFeatures.DriveChecksumIncrementals {
+		driveChecksums, err = driveChecksumsFromBases(ctx, mans, detailsStore, op.Errors)
+		if err != nil {
+			return nil, clues.Wrap(err, "producing drive checksums")
+		}
+	}
+
 	// TODO(ashmrtn): This should probably just return a collection that deletes
 	// the entire subtree instead of returning an additional bool. That way base
 	// selection is controlled completely by flags and merging is controlled
@@ -386,6 +605,7 @@ func (op *BackupOperation) do(
 		op.ResourceOwner,
 		op.Selectors,
 		mdColls,
+		driveChecksums,
 		lastBackupVersion,
 		op.Options,
 		op.Errors)
@@ -398,6 +618,8 @@ func (op *BackupOperation) do(
 		"can_use_previous_backup", canUsePreviousBackup,
 		"collection_count", len(cs))
 
+	cs = stoppableCollections(cs, op.stop)
+
 	writeStats, deets, toMerge, err := consumeBackupCollections(
 		ctx,
 		op.kopia,
@@ -464,6 +686,7 @@ func produceBackupDataCollections(
 	protectedResource idname.Provider,
 	sel selectors.Selector,
 	metadata []data.RestoreCollection,
+	driveChecksums map[string]map[string]details.ItemChecksum,
 	lastBackupVersion int,
 	ctrlOpts control.Options,
 	errs *fault.Bus,
@@ -472,6 +695,7 @@ func produceBackupDataCollections(
 	defer close(progressBar)
 
 	bpc := inject.BackupProducerConfig{
+		DriveChecksums:      driveChecksums,
 		LastBackupVersion:   lastBackupVersion,
 		MetadataCollections: metadata,
 		Options:             ctrlOpts,
@@ -482,6 +706,41 @@ func produceBackupDataCollections(
 	return bp.ProduceBackupCollections(ctx, bpc, errs)
 }
 
+// driveChecksumsFromBases collects the checksums of the drive files in the
+// details of each merge base backup.  Drives only compare against these
+// checksums when their previous delta token gets rejected.
+func driveChecksumsFromBases(
+	ctx context.Context,
+	bases kopia.BackupBases,
+	detailsStore streamstore.Reader,
+	errs *fault.Bus,
+) (map[string]map[string]details.ItemChecksum, error) {
+	checksums := map[string]map[string]details.ItemChecksum{}
+
+	if bases == nil {
+		return checksums, nil
+	}
+
+	for _, base := range bases.Backups() {
+		ictx := clues.Add(ctx, "base_backup_id", base.ID)
+
+		deets, err := getDetailsFromBackup(ictx, base.Backup, detailsStore, errs)
+		if err != nil {
+			return nil, clues.Wrap(err, "fetching base details for backup")
+		}
+
+		for driveID, ics := range deets.DriveChecksums() {
+			if _, ok := checksums[driveID]; !ok {
+				checksums[driveID] = map[string]details.ItemChecksum{}
+			}
+
+			maps.Copy(checksums[driveID], ics)
+		}
+	}
+
+	return checksums, nil
+}
+
 // ---------------------------------------------------------------------------
 // Consumer funcs
 // ---------------------------------------------------------------------------
@@ -685,8 +944,11 @@ func mergeItemsFromBase(
 		alreadySeenItems[rr.ShortRef()] = struct{}{}
 
 		// Track how many entries we added so that we know if we got them all when
-		// we're done.
-		manifestAddedEntries++
+		// we're done.  Folder metadata is only in the details of backups that
+		// preserve empty folders, so it isn't part of that count.
+		if !metadata.IsFolderMetadataFile(rr) {
+			manifestAddedEntries++
+		}
 	}
 
 	logger.Ctx(ctx).Infow(
@@ -715,7 +977,9 @@ func mergeDetails(
 	writeStats.TotalNonMetaUploadedBytes = detailsModel.SumNonMetaFileSizes()
 
 	// Don't bother loading any of the base details if there's nothing we need to merge.
-	if bases == nil || dataFromBackup == nil || dataFromBackup.ItemsToMerge() == 0 {
+	if bases == nil ||
+		dataFromBackup == nil ||
+		dataFromBackup.ItemsToMerge()+dataFromBackup.OptionalItemsToMerge() == 0 {
 		logger.Ctx(ctx).Info("no base details to merge")
 		return nil
 	}
@@ -821,6 +1085,11 @@ func (op *BackupOperation) persistResults(
 	op.Results.NonMetaBytesUploaded = opStats.k.TotalNonMetaUploadedBytes
 	op.Results.NonMetaItemsWritten = opStats.k.TotalNonMetaFileCount
 	op.Results.ResourceOwners = opStats.resourceCount
+	op.Results.SnapshotBytes = opStats.k.SnapshotBytes
+	op.Results.SnapshotStoredBytes = opStats.k.SnapshotStoredBytes
+	op.Results.CompressionRatio = compressionRatio(
+		opStats.k.SnapshotBytes,
+		opStats.k.SnapshotStoredBytes)
 
 	if opStats.ctrl == nil {
 		op.Status = Failed
@@ -831,12 +1100,27 @@ func (op *BackupOperation) persistResults(
 		op.Status = NoData
 	}
 
+	if op.Status != Failed && op.stop.isInterrupted() {
+		op.Status = PartiallyCompleted
+	}
+
 	op.Results.ItemsRead = opStats.ctrl.Successes
+	op.Results.ItemConcurrency = opStats.ctrl.Concurrency
 
 	// Only return non-recoverable errors at this point.
 	return op.Errors.Failure()
 }
 
+// compressionRatio compares the size of the snapshot's data before and
+// after kopia compressed it.
+func compressionRatio(logical, stored int64) float64 {
+	if logical <= 0 {
+		return 0
+	}
+
+	return float64(stored) / float64(logical)
+}
+
 // stores the operation details, results, and selectors in the backup manifest.
 func (op *BackupOperation) createBackupModels(
 	ctx context.Context,
@@ -862,7 +1146,12 @@ func (op *BackupOperation) createBackupModels(
 
 	ctx = clues.Add(ctx, "details_entry_count", len(deets.Entries))
 
-	err := sscw.Collect(ctx, streamstore.DetailsCollector(deets))
+	var deetsMarshaller streamstore.Marshaller = deets
+	if op.Options.CompressBackupDetails {
+		deetsMarshaller = streamstore.Compressed(deets)
+	}
+
+	err := sscw.Collect(ctx, streamstore.DetailsCollector(deetsMarshaller))
 	if err != nil {
 		return clues.Wrap(err, "collecting details for persistence").WithClues(ctx)
 	}
@@ -883,10 +1172,22 @@ func (op *BackupOperation) createBackupModels(
 		model.ServiceTag: op.Selectors.PathService().String(),
 	}
 
-	// Add tags to mark this backup as either assist or merge. This is used to:
+	// Add tags to mark this backup as either partial, assist, or merge. This
+	// is used to:
 	// 1. Filter assist backups by tag during base selection process
 	// 2. Differentiate assist backups from merge backups
-	if isMergeBackup(
+	// 3. Keep partial backups out of base selection entirely
+	if op.stop.isInterrupted() {
+		tags[model.BackupTypeTag] = model.PartialBackup
+	} else if isSinceLimitedBackup(
+		op.Options.IncrementalSince,
+		snapID,
+		ssid,
+		op.Errors) {
+		// items modified before the cutoff were never enumerated, so this
+		// backup can't be used as a merge base without losing them.
+		tags[model.BackupTypeTag] = model.AssistBackup
+	} else if isMergeBackup(
 		snapID,
 		ssid,
 		op.Options.FailureHandling,
@@ -903,6 +1204,10 @@ func (op *BackupOperation) createBackupModels(
 		return clues.New("backup is neither assist nor merge").WithClues(ctx)
 	}
 
+	if len(op.Results.BackupGroupID) > 0 {
+		tags[model.BackupGroupTag] = op.Results.BackupGroupID
+	}
+
 	ctx = clues.Add(ctx, model.BackupTypeTag, tags[model.BackupTypeTag])
 
 	b := backup.New(
@@ -918,6 +1223,20 @@ func (op *BackupOperation) createBackupModels(
 		op.Errors.Errors(),
 		tags)
 
+	b.TotalItemBytes = deets.SumNonMetaFileSizes()
+	b.BackupGroupID = op.Results.BackupGroupID
+	b.CreatedByUser = op.Options.Repo.User
+	b.CreatedByHost = op.Options.Repo.Host
+	b.MergeBaseIDs = opStats.mergeBaseIDs
+	b.AssistBaseIDs = opStats.assistBaseIDs
+
+	if len(op.DetailsSigningKey) > 0 {
+		b.DetailsSignature, err = backup.SignDetails(op.DetailsSigningKey, deets)
+		if err != nil {
+			return clues.Stack(err).WithClues(ctx)
+		}
+	}
+
 	logger.Ctx(ctx).Info("creating new backup")
 
 	if err = op.store.Put(ctx, model.BackupSchema, b); err != nil {
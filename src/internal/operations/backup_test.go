@@ -18,6 +18,7 @@ import (
 	dataMock "github.com/alcionai/corso/src/internal/data/mock"
 	evmock "github.com/alcionai/corso/src/internal/events/mock"
 	"github.com/alcionai/corso/src/internal/kopia"
+	odmetadata "github.com/alcionai/corso/src/internal/m365/collection/drive/metadata"
 	"github.com/alcionai/corso/src/internal/m365/graph"
 	"github.com/alcionai/corso/src/internal/m365/mock"
 	odConsts "github.com/alcionai/corso/src/internal/m365/service/onedrive/consts"
@@ -39,6 +40,7 @@ import (
 	"github.com/alcionai/corso/src/pkg/backup/metadata"
 	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/control/repository"
+	"github.com/alcionai/corso/src/pkg/count"
 	"github.com/alcionai/corso/src/pkg/extensions"
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/path"
@@ -150,6 +152,7 @@ type mockDetailsMergeInfoer struct {
 	repoRefs map[string]path.Path
 	locs     map[string]*path.Builder
 	modTimes map[string]time.Time
+	optional map[string]struct{}
 }
 
 func (m *mockDetailsMergeInfoer) add(oldRef, newRef path.Path, newLoc *path.Builder) {
@@ -161,6 +164,11 @@ func (m *mockDetailsMergeInfoer) add(oldRef, newRef path.Path, newLoc *path.Buil
 	m.locs[oldPB.ShortRef()] = newLoc
 }
 
+func (m *mockDetailsMergeInfoer) addOptional(oldRef, newRef path.Path, newLoc *path.Builder) {
+	m.add(oldRef, newRef, newLoc)
+	m.optional[oldRef.ToBuilder().ShortRef()] = struct{}{}
+}
+
 func (m *mockDetailsMergeInfoer) addWithModTime(
 	oldRef path.Path,
 	modTime time.Time,
@@ -194,7 +202,15 @@ func (m *mockDetailsMergeInfoer) ItemsToMerge() int {
 		return 0
 	}
 
-	return len(m.repoRefs)
+	return len(m.repoRefs) - len(m.optional)
+}
+
+func (m *mockDetailsMergeInfoer) OptionalItemsToMerge() int {
+	if m == nil {
+		return 0
+	}
+
+	return len(m.optional)
 }
 
 func newMockDetailsMergeInfoer() *mockDetailsMergeInfoer {
@@ -202,6 +218,7 @@ func newMockDetailsMergeInfoer() *mockDetailsMergeInfoer {
 		repoRefs: map[string]path.Path{},
 		locs:     map[string]*path.Builder{},
 		modTimes: map[string]time.Time{},
+		optional: map[string]struct{}{},
 	}
 }
 
@@ -376,6 +393,8 @@ func (suite *BackupOpUnitSuite) TestBackupOperation_PersistResults() {
 		expectErr    assert.ErrorAssertionFunc
 		stats        backupStats
 		fail         error
+		stop         bool
+		interrupted  bool
 	}{
 		{
 			expectStatus: Completed,
@@ -383,9 +402,11 @@ func (suite *BackupOpUnitSuite) TestBackupOperation_PersistResults() {
 			stats: backupStats{
 				resourceCount: 1,
 				k: &kopia.BackupStats{
-					TotalFileCount:     1,
-					TotalHashedBytes:   1,
-					TotalUploadedBytes: 1,
+					TotalFileCount:      1,
+					TotalHashedBytes:    1,
+					TotalUploadedBytes:  1,
+					SnapshotBytes:       4,
+					SnapshotStoredBytes: 1,
 				},
 				ctrl: &data.CollectionStats{Successes: 1},
 			},
@@ -407,6 +428,40 @@ func (suite *BackupOpUnitSuite) TestBackupOperation_PersistResults() {
 				ctrl: &data.CollectionStats{},
 			},
 		},
+		{
+			expectStatus: PartiallyCompleted,
+			expectErr:    assert.NoError,
+			stop:         true,
+			interrupted:  true,
+			stats: backupStats{
+				resourceCount: 1,
+				k: &kopia.BackupStats{
+					TotalFileCount:      1,
+					TotalHashedBytes:    1,
+					TotalUploadedBytes:  1,
+					SnapshotBytes:       4,
+					SnapshotStoredBytes: 1,
+				},
+				ctrl: &data.CollectionStats{Successes: 1},
+			},
+		},
+		{
+			// the stop arrived after all data was consumed.
+			expectStatus: Completed,
+			expectErr:    assert.NoError,
+			stop:         true,
+			stats: backupStats{
+				resourceCount: 1,
+				k: &kopia.BackupStats{
+					TotalFileCount:      1,
+					TotalHashedBytes:    1,
+					TotalUploadedBytes:  1,
+					SnapshotBytes:       4,
+					SnapshotStoredBytes: 1,
+				},
+				ctrl: &data.CollectionStats{Successes: 1},
+			},
+		},
 	}
 	for _, test := range table {
 		suite.Run(test.expectStatus.String(), func() {
@@ -432,6 +487,12 @@ func (suite *BackupOpUnitSuite) TestBackupOperation_PersistResults() {
 
 			op.Errors.Fail(test.fail)
 
+			if test.stop {
+				op.RequestStop()
+			}
+
+			op.stop.interrupted.Store(test.interrupted)
+
 			test.expectErr(t, op.persistResults(now, &test.stats))
 
 			assert.Equal(t, test.expectStatus.String(), op.Status.String(), "status")
@@ -440,12 +501,157 @@ func (suite *BackupOpUnitSuite) TestBackupOperation_PersistResults() {
 			assert.Equal(t, test.stats.k.TotalHashedBytes, op.Results.BytesRead, "bytes read")
 			assert.Equal(t, test.stats.k.TotalUploadedBytes, op.Results.BytesUploaded, "bytes written")
 			assert.Equal(t, test.stats.resourceCount, op.Results.ResourceOwners, "resource owners")
+			assert.Equal(t, test.stats.k.SnapshotBytes, op.Results.SnapshotBytes, "snapshot bytes")
+			assert.Equal(t, test.stats.k.SnapshotStoredBytes, op.Results.SnapshotStoredBytes, "snapshot stored bytes")
+			assert.Equal(
+				t,
+				compressionRatio(test.stats.k.SnapshotBytes, test.stats.k.SnapshotStoredBytes),
+				op.Results.CompressionRatio,
+				"compression ratio")
 			assert.Equal(t, now, op.Results.StartedAt, "started at")
 			assert.Less(t, now, op.Results.CompletedAt, "completed at")
 		})
 	}
 }
 
+func (suite *BackupOpUnitSuite) TestCompressionRatio() {
+	table := []struct {
+		name    string
+		logical int64
+		stored  int64
+		expect  float64
+	}{
+		{"unknown sizes", 0, 0, 0},
+		{"nothing stored", 100, 0, 0},
+		{"compressed", 100, 25, 0.25},
+		{"uncompressed", 100, 100, 1},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			assert.InDelta(suite.T(), test.expect, compressionRatio(test.logical, test.stored), 0.0001)
+		})
+	}
+}
+
+func (suite *BackupOpUnitSuite) TestMergeCategoryStatus() {
+	table := []struct {
+		name     string
+		statuses []OpStatus
+		expect   OpStatus
+	}{
+		{
+			name:     "single",
+			statuses: []OpStatus{Completed},
+			expect:   Completed,
+		},
+		{
+			name:     "all completed",
+			statuses: []OpStatus{Completed, Completed},
+			expect:   Completed,
+		},
+		{
+			name:     "some data",
+			statuses: []OpStatus{NoData, Completed, NoData},
+			expect:   Completed,
+		},
+		{
+			name:     "no data",
+			statuses: []OpStatus{NoData, NoData},
+			expect:   NoData,
+		},
+		{
+			name:     "partially completed",
+			statuses: []OpStatus{Completed, PartiallyCompleted},
+			expect:   PartiallyCompleted,
+		},
+		{
+			name:     "failed",
+			statuses: []OpStatus{Failed, Completed, PartiallyCompleted},
+			expect:   Failed,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			status := InProgress
+
+			for _, s := range test.statuses {
+				status = mergeCategoryStatus(status, s)
+			}
+
+			assert.Equal(suite.T(), test.expect, status)
+		})
+	}
+}
+
+func (suite *BackupOpUnitSuite) TestBackupOperation_MergeCategoryBackup() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		now  = time.Now()
+		esel = selectors.NewExchangeBackup([]string{"user"})
+		op   = &BackupOperation{
+			operation: newOperation(
+				control.DefaultOptions(),
+				evmock.NewBus(),
+				count.New(),
+				nil,
+				nil),
+			Selectors: esel.Selector,
+			stop:      newStopper(),
+		}
+	)
+
+	op.Results.BackupGroupID = "group"
+
+	mail := op.categoryBackup(esel.Selector)
+	mail.Results.BackupID = "mail"
+	mail.Results.ItemsRead = 2
+	mail.Results.BytesRead = 200
+	mail.Results.SnapshotBytes = 200
+	mail.Results.SnapshotStoredBytes = 20
+	mail.Results.StartedAt = now
+	mail.Results.CompletedAt = now.Add(time.Minute)
+	mail.Status = Completed
+	mail.Counter.Add(count.NewItemCreated, 2)
+	mail.Errors.AddRecoverable(ctx, assert.AnError)
+
+	contacts := op.categoryBackup(esel.Selector)
+	contacts.Results.BackupID = "contacts"
+	contacts.Results.ItemsRead = 3
+	contacts.Results.BytesRead = 300
+	contacts.Results.SnapshotBytes = 300
+	contacts.Results.SnapshotStoredBytes = 180
+	contacts.Results.StartedAt = now.Add(time.Minute)
+	contacts.Results.CompletedAt = now.Add(2 * time.Minute)
+	contacts.Status = NoData
+	contacts.Counter.Add(count.NewItemCreated, 3)
+	contacts.Errors.AddSkip(ctx, fault.FileSkip(fault.SkipMalware, "ns", "id", "name", nil))
+
+	assert.Equal(t, "group", mail.Results.BackupGroupID, "category backup group")
+	assert.Equal(t, op.ID, mail.Results.OperationID, "category backup operation id")
+	assert.Equal(t, op.ID, mail.Errors.OperationID(), "category backup errors operation id")
+	assert.NotSame(t, op.Errors, mail.Errors, "category backup errors")
+	assert.Empty(t, contacts.Errors.Recovered(), "category backups share no errors")
+
+	op.mergeCategoryBackup(ctx, mail)
+	op.mergeCategoryBackup(ctx, contacts)
+
+	assert.Equal(t, model.StableID("mail"), op.Results.BackupID)
+	assert.Equal(t, []model.StableID{"mail", "contacts"}, op.Results.BackupIDs)
+	assert.Equal(t, 5, op.Results.ItemsRead)
+	assert.Equal(t, int64(500), op.Results.BytesRead)
+	assert.InDelta(t, 0.4, op.Results.CompressionRatio, 0.0001, "compression ratio")
+	assert.Equal(t, now, op.Results.StartedAt)
+	assert.Equal(t, now.Add(2*time.Minute), op.Results.CompletedAt)
+	assert.Equal(t, Completed, op.Status)
+	assert.Len(t, op.Errors.Recovered(), 1, "recovered errors")
+	assert.Len(t, op.Errors.Skipped(), 1, "skipped items")
+	assert.Equal(t, int64(5), op.Counter.Get(count.NewItemCreated), "counts tally in the operation")
+}
+
 func (suite *BackupOpUnitSuite) TestBackupOperation_ConsumeBackupDataCollections_Paths() {
 	var (
 		t = suite.T()
@@ -547,8 +753,22 @@ func (suite *BackupOpUnitSuite) TestBackupOperation_MergeBackupDetails_AddsItems
 				"item1",
 			},
 			true)
-		locationPath1 = path.Builder{}.Append(odConsts.RootPathDir, "work-display-name")
-		itemPath2     = makePath(
+		locationPath1   = path.Builder{}.Append(odConsts.RootPathDir, "work-display-name")
+		folderMetaPath1 = makePath(
+			suite.T(),
+			[]string{
+				tenant,
+				path.OneDriveService.String(),
+				ro,
+				path.FilesCategory.String(),
+				odConsts.DrivesPathDir,
+				"drive-id",
+				odConsts.RootPathDir,
+				"work",
+				odmetadata.DirMetaFileSuffix,
+			},
+			true)
+		itemPath2 = makePath(
 			suite.T(),
 			[]string{
 				tenant,
@@ -785,6 +1005,76 @@ func (suite *BackupOpUnitSuite) TestBackupOperation_MergeBackupDetails_AddsItems
 			},
 			errCheck: assert.Error,
 		},
+		{
+			name: "PreservedFolderMerged",
+			mdm: func() *mockDetailsMergeInfoer {
+				res := newMockDetailsMergeInfoer()
+				res.add(itemPath1, itemPath1, locationPath1)
+				res.addOptional(folderMetaPath1, folderMetaPath1, locationPath1)
+
+				return res
+			}(),
+			inputBackups: []kopia.BackupEntry{
+				{
+					Backup: &backup1,
+					Reasons: []identity.Reasoner{
+						pathReason1,
+					},
+				},
+			},
+			populatedDetails: map[string]*details.Details{
+				backup1.DetailsID: {
+					DetailsModel: details.DetailsModel{
+						Entries: []details.Entry{
+							*makeDetailsEntry(suite.T(), itemPath1, locationPath1, 42, false),
+							*makeDetailsEntry(suite.T(), folderMetaPath1, locationPath1, 0, false),
+						},
+					},
+				},
+			},
+			errCheck: assert.NoError,
+			expectedEntries: []*details.Entry{
+				makeDetailsEntry(suite.T(), itemPath1, locationPath1, 42, false),
+				func() *details.Entry {
+					res := makeDetailsEntry(suite.T(), folderMetaPath1, locationPath1, 0, false)
+					// details drop the metadata suffix from item refs.
+					res.ItemRef = ""
+
+					return res
+				}(),
+			},
+		},
+		{
+			name: "FolderMetadataNotInBase",
+			mdm: func() *mockDetailsMergeInfoer {
+				res := newMockDetailsMergeInfoer()
+				res.add(itemPath1, itemPath1, locationPath1)
+				res.addOptional(folderMetaPath1, folderMetaPath1, locationPath1)
+
+				return res
+			}(),
+			inputBackups: []kopia.BackupEntry{
+				{
+					Backup: &backup1,
+					Reasons: []identity.Reasoner{
+						pathReason1,
+					},
+				},
+			},
+			populatedDetails: map[string]*details.Details{
+				backup1.DetailsID: {
+					DetailsModel: details.DetailsModel{
+						Entries: []details.Entry{
+							*makeDetailsEntry(suite.T(), itemPath1, locationPath1, 42, false),
+						},
+					},
+				},
+			},
+			errCheck: assert.NoError,
+			expectedEntries: []*details.Entry{
+				makeDetailsEntry(suite.T(), itemPath1, locationPath1, 42, false),
+			},
+		},
 		{
 			name: "ItemMerged",
 			mdm: func() *mockDetailsMergeInfoer {
@@ -1883,6 +2173,247 @@ func (suite *AssistBackupIntegrationSuite) TestBackupTypesForFailureModes() {
 	}
 }
 
+// categoryBackupProducer only produces the collections in the categories
+// of the selector it's handed.
+type categoryBackupProducer struct {
+	inject.BackupProducer
+	colls []data.BackupCollection
+}
+
+func (p categoryBackupProducer) ProduceBackupCollections(
+	_ context.Context,
+	bpc inject.BackupProducerConfig,
+	_ *fault.Bus,
+) ([]data.BackupCollection, prefixmatcher.StringSetReader, bool, error) {
+	pcs, err := bpc.Selector.PathCategories()
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	colls := []data.BackupCollection{}
+
+	for _, c := range p.colls {
+		for _, cat := range pcs.Includes {
+			if c.FullPath().Category() == cat {
+				colls = append(colls, c)
+			}
+		}
+	}
+
+	return colls, nil, true, nil
+}
+
+func (suite *AssistBackupIntegrationSuite) TestSnapshotPerCategory() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		tenantID = suite.acct.Config[account.AzureTenantIDKey]
+		opts     = control.DefaultOptions()
+		esel     = selectors.NewExchangeBackup([]string{userID})
+		colls    = []data.BackupCollection{}
+		now      = time.Now()
+	)
+
+	opts.SnapshotPerCategory = true
+
+	esel.Include(esel.MailFolders(selectors.Any()), esel.ContactFolders(selectors.Any()))
+
+	for _, cat := range []path.CategoryType{path.EmailCategory, path.ContactsCategory} {
+		p, err := path.Build(tenantID, userID, path.ExchangeService, cat, false, "folder")
+		require.NoError(t, err, clues.ToCore(err))
+
+		colls = append(colls, makeBackupCollection(
+			p,
+			path.Builder{}.Append(p.Folders()...),
+			[]dataMock.Item{makeMockItem(cat.String()+"-item", nil, now, false, nil)}))
+	}
+
+	mbp := opMock.NewMockBackupProducer(nil, data.CollectionStats{}, false)
+	bp := categoryBackupProducer{BackupProducer: &mbp, colls: colls}
+
+	bo, err := NewBackupOperation(
+		ctx,
+		opts,
+		suite.kw,
+		suite.sw,
+		bp,
+		suite.acct,
+		esel.Selector,
+		selectors.Selector{DiscreteOwner: userID},
+		evmock.NewBus())
+	require.NoError(t, err, clues.ToCore(err))
+
+	err = bo.Run(ctx)
+	require.NoError(t, err, clues.ToCore(err))
+
+	groupID := bo.Results.BackupGroupID
+	require.NotEmpty(t, groupID, "backup group id")
+	require.Len(t, bo.Results.BackupIDs, 2, "backup ids")
+	assert.Equal(t, bo.Results.BackupIDs[0], bo.Results.BackupID)
+
+	bups, err := suite.sw.GetBackups(ctx, store.BackupGroup(groupID))
+	require.NoError(t, err, clues.ToCore(err))
+	require.Len(t, bups, 2, "grouped backup models")
+
+	var (
+		snapIDs = map[string]struct{}{}
+		cats    = []path.CategoryType{}
+	)
+
+	for _, b := range bups {
+		assert.Equal(t, groupID, b.BackupGroupID)
+		assert.Contains(t, bo.Results.BackupIDs, b.ID)
+
+		snapIDs[b.SnapshotID] = struct{}{}
+
+		reasons, err := b.Selector.Reasons(tenantID, false)
+		require.NoError(t, err, clues.ToCore(err))
+		require.Len(t, reasons, 1, "backup reasons")
+
+		cats = append(cats, reasons[0].Category())
+	}
+
+	assert.Len(t, snapIDs, 2, "distinct snapshots")
+	assert.ElementsMatch(t, []path.CategoryType{path.EmailCategory, path.ContactsCategory}, cats)
+}
+
+func (suite *AssistBackupIntegrationSuite) TestBackupRecordsCreatedBy() {
+	table := []struct {
+		name       string
+		user, host string
+	}{
+		{
+			name: "user and host",
+			user: "operator",
+			host: "backup-host",
+		},
+		{
+			name: "unset",
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				tenantID = suite.acct.Config[account.AzureTenantIDKey]
+				opts     = control.DefaultOptions()
+				esel     = selectors.NewExchangeBackup([]string{userID})
+			)
+
+			opts.Repo.User = test.user
+			opts.Repo.Host = test.host
+
+			esel.Include(esel.MailFolders(selectors.Any()))
+
+			p, err := path.Build(tenantID, userID, path.ExchangeService, path.EmailCategory, false, "folder")
+			require.NoError(t, err, clues.ToCore(err))
+
+			bp := opMock.NewMockBackupProducer(
+				[]data.BackupCollection{
+					makeBackupCollection(
+						p,
+						path.Builder{}.Append(p.Folders()...),
+						[]dataMock.Item{makeMockItem("item", nil, time.Now(), false, nil)}),
+				},
+				data.CollectionStats{},
+				false)
+
+			bo, err := NewBackupOperation(
+				ctx,
+				opts,
+				suite.kw,
+				suite.sw,
+				&bp,
+				suite.acct,
+				esel.Selector,
+				selectors.Selector{DiscreteOwner: userID},
+				evmock.NewBus())
+			require.NoError(t, err, clues.ToCore(err))
+
+			err = bo.Run(ctx)
+			require.NoError(t, err, clues.ToCore(err))
+
+			bup := backup.Backup{}
+
+			err = suite.ms.Get(ctx, model.BackupSchema, bo.Results.BackupID, &bup)
+			require.NoError(t, err, clues.ToCore(err))
+
+			assert.Equal(t, test.user, bup.CreatedByUser)
+			assert.Equal(t, test.host, bup.CreatedByHost)
+		})
+	}
+}
+
+func (suite *AssistBackupIntegrationSuite) TestIncrementalSinceBackupIsNotMergeBase() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		tenantID = suite.acct.Config[account.AzureTenantIDKey]
+		ownerID  = "since-user-id"
+		esel     = selectors.NewExchangeBackup([]string{ownerID})
+	)
+
+	esel.Include(esel.MailFolders(selectors.Any()))
+
+	p, err := path.Build(tenantID, ownerID, path.ExchangeService, path.EmailCategory, false, "folder")
+	require.NoError(t, err, clues.ToCore(err))
+
+	runBackup := func(opts control.Options, itemID string) backup.Backup {
+		bp := opMock.NewMockBackupProducer(
+			[]data.BackupCollection{
+				makeBackupCollection(
+					p,
+					path.Builder{}.Append(p.Folders()...),
+					[]dataMock.Item{makeMockItem(itemID, nil, time.Now(), false, nil)}),
+			},
+			data.CollectionStats{},
+			false)
+
+		bo, err := NewBackupOperation(
+			ctx,
+			opts,
+			suite.kw,
+			suite.sw,
+			&bp,
+			suite.acct,
+			esel.Selector,
+			selectors.Selector{DiscreteOwner: ownerID},
+			evmock.NewBus())
+		require.NoError(t, err, clues.ToCore(err))
+
+		err = bo.Run(ctx)
+		require.NoError(t, err, clues.ToCore(err))
+
+		bup := backup.Backup{}
+
+		err = suite.ms.Get(ctx, model.BackupSchema, bo.Results.BackupID, &bup)
+		require.NoError(t, err, clues.ToCore(err))
+
+		return bup
+	}
+
+	sinceOpts := control.DefaultOptions()
+	sinceOpts.IncrementalSince = time.Now().Add(-time.Hour)
+
+	first := runBackup(sinceOpts, "recent-item")
+	assert.Equal(t, model.AssistBackup, first.Tags[model.BackupTypeTag])
+
+	second := runBackup(control.DefaultOptions(), "next-item")
+	assert.Equal(t, model.MergeBackup, second.Tags[model.BackupTypeTag])
+	assert.NotContains(t, second.MergeBaseIDs, first.ID, "merge bases")
+	assert.Contains(t, second.AssistBaseIDs, first.ID, "assist bases")
+}
+
 func selectFilesFromDeets(d details.Details) map[string]details.Entry {
 	files := make(map[string]details.Entry)
 
@@ -59,3 +59,26 @@ func getDetailsFromBackup(
 
 	return &deets, nil
 }
+
+func getErrorsFromBackup(
+	ctx context.Context,
+	bup *backup.Backup,
+	errorsStore streamstore.Reader,
+	errs *fault.Bus,
+) (*fault.Errors, error) {
+	var (
+		fe  fault.Errors
+		umt = streamstore.FaultErrorsReader(fault.UnmarshalErrorsTo(&fe))
+	)
+
+	// backups that predate the streamstore only persisted details.
+	if len(bup.StreamStoreID) == 0 {
+		return nil, clues.New("no errors in backup").WithClues(ctx)
+	}
+
+	if err := errorsStore.Read(ctx, bup.StreamStoreID, umt, errs); err != nil {
+		return nil, clues.Wrap(err, "reading backup errors from streamstore")
+	}
+
+	return &fe, nil
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/alcionai/corso/src/internal/stats"
 	"github.com/alcionai/corso/src/internal/streamstore"
 	"github.com/alcionai/corso/src/pkg/account"
+	"github.com/alcionai/corso/src/pkg/backup"
 	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/count"
 	"github.com/alcionai/corso/src/pkg/export"
@@ -47,6 +48,13 @@ type ExportOperation struct {
 	ExportCfg control.ExportConfig
 	Version   string
 
+	// PreviousManifest, when populated, restricts the export to only
+	// those items which changed since the export that produced it.
+	PreviousManifest *export.Manifest
+	// Manifest records every item in the export.  It is only complete
+	// once all of the export collections have been consumed.
+	Manifest *export.Manifest
+
 	acct account.Account
 	ec   inject.ExportConsumer
 }
@@ -71,8 +79,11 @@ func NewExportOperation(
 		ExportCfg: exportCfg,
 		Selectors: sel,
 		Version:   "v0",
+		Manifest:  export.NewManifest(),
 		ec:        ec,
 	}
+	op.Results.OperationID = op.ID
+
 	if err := op.validate(); err != nil {
 		return ExportOperation{}, err
 	}
@@ -131,6 +142,10 @@ func (op *ExportOperation) Run(ctx context.Context) (
 		end()
 	}()
 
+	ctx = op.bindOperationID(ctx)
+	ctx = op.bindRetryBudget(ctx)
+	ctx = op.bindThrottleSignal(ctx)
+
 	ctx, flushMetrics := events.NewMetrics(ctx, logger.Writer{Ctx: ctx})
 	defer flushMetrics()
 
@@ -141,6 +156,8 @@ func (op *ExportOperation) Run(ctx context.Context) (
 		"service", op.Selectors.Service)
 
 	defer func() {
+		op.Results.Counts = op.countValues(ctx)
+
 		op.bus.Event(
 			ctx,
 			events.ExportEnd,
@@ -172,7 +189,7 @@ func (op *ExportOperation) Run(ctx context.Context) (
 			op.Errors.Fail(clues.New("empty backup or unknown path provided"))
 		}
 
-		op.Errors.Fail(clues.Wrap(err, "running export"))
+		op.Errors.Fail(clues.Wrap(err, "running export").WithClues(ctx))
 	}
 
 	finalizeErrorHandling(ctx, op.Options, op.Errors, "running export")
@@ -257,6 +274,8 @@ func (op *ExportOperation) do(
 	opStats.resourceCount = 1
 	opStats.cs = dcs
 
+	dcs = export.IncrementalCollections(dcs, op.PreviousManifest, op.Manifest, op.Counter)
+
 	expCollections, err := exportRestoreCollections(
 		ctx,
 		op.ec,
@@ -274,6 +293,30 @@ func (op *ExportOperation) do(
 
 	logger.Ctx(ctx).Debug(opStats.ctrl)
 
+	expCollections = export.WithExcludedExtensions(expCollections, op.ExportCfg.ExcludeExtensions, op.Counter)
+
+	if op.ExportCfg.IncludeErrorReport {
+		erc, err := errorReportCollection(ctx, bup, detailsStore, op.Errors)
+		if err != nil {
+			return nil, clues.Stack(err)
+		}
+
+		if erc != nil {
+			expCollections = append(expCollections, erc)
+		}
+	}
+
+	expCollections = export.WithBackupID(expCollections, string(op.BackupID))
+
+	if op.ExportCfg.PackageByFolder {
+		zc, err := archive.ZipExportCollectionsByFolder(ctx, expCollections)
+		if err != nil {
+			return nil, clues.Wrap(err, "zipping export collections by folder")
+		}
+
+		return []export.Collectioner{zc}, nil
+	}
+
 	if op.ExportCfg.Archive {
 		zc, err := archive.ZipExportCollection(ctx, expCollections)
 		if err != nil {
@@ -354,3 +397,27 @@ func exportRestoreCollections(
 
 	return expCollections, nil
 }
+
+// errorReportCollection produces the collection holding the errors that
+// were recorded during the backup.  Backups that predate the streamstore
+// didn't persist their errors, so they produce a nil collection.
+func errorReportCollection(
+	ctx context.Context,
+	bup *backup.Backup,
+	errorsStore streamstore.Reader,
+	errs *fault.Bus,
+) (export.Collectioner, error) {
+	if len(bup.StreamStoreID) == 0 {
+		logger.Ctx(ctx).Info("backup has no error report to export")
+		return nil, nil
+	}
+
+	fe, err := getErrorsFromBackup(ctx, bup, errorsStore, errs)
+	if err != nil {
+		return nil, clues.Wrap(err, "getting backup errors")
+	}
+
+	erc, err := export.NewErrorReportCollection(fe)
+
+	return erc, clues.Stack(err).OrNil()
+}
@@ -21,10 +21,14 @@ import (
 	"github.com/alcionai/corso/src/internal/m365/mock"
 	exchMock "github.com/alcionai/corso/src/internal/m365/service/exchange/mock"
 	"github.com/alcionai/corso/src/internal/stats"
+	ssmock "github.com/alcionai/corso/src/internal/streamstore/mock"
 	"github.com/alcionai/corso/src/internal/tester"
 	"github.com/alcionai/corso/src/pkg/account"
+	"github.com/alcionai/corso/src/pkg/backup"
 	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/export"
+	"github.com/alcionai/corso/src/pkg/fault"
+	faultTD "github.com/alcionai/corso/src/pkg/fault/testdata"
 	"github.com/alcionai/corso/src/pkg/selectors"
 	"github.com/alcionai/corso/src/pkg/store"
 )
@@ -121,6 +125,9 @@ func (suite *ExportUnitSuite) TestExportOperation_PersistResults() {
 			assert.Equal(t, test.stats.resourceCount, op.Results.ResourceOwners, "resource owners")
 			assert.Equal(t, now, op.Results.StartedAt, "started at")
 			assert.Less(t, now, op.Results.CompletedAt, "completed at")
+			assert.NotEmpty(t, op.Results.OperationID, "operation id")
+			assert.Equal(t, op.ID, op.Results.OperationID, "operation id")
+			assert.Equal(t, op.ID, op.Errors.Errors().OperationID, "errors operation id")
 		})
 	}
 }
@@ -332,3 +339,219 @@ func (suite *ExportUnitSuite) TestZipExports() {
 		})
 	}
 }
+
+func (suite *ExportUnitSuite) TestZipExportsByFolder() {
+	item := func(id string) export.Item {
+		return export.Item{
+			ID:   id,
+			Name: id + ".txt",
+			Body: NewReadSeekCloser([]byte(id)),
+		}
+	}
+
+	table := []struct {
+		name          string
+		inputColls    []export.Collectioner
+		expectZipErr  assert.ErrorAssertionFunc
+		expectReadErr assert.ErrorAssertionFunc
+		// archive name suffix -> zipped file name -> contents
+		expect map[string]map[string]string
+	}{
+		{
+			name:          "nothing",
+			inputColls:    []export.Collectioner{},
+			expectZipErr:  assert.Error,
+			expectReadErr: assert.NoError,
+		},
+		{
+			name: "root and folders",
+			inputColls: []export.Collectioner{
+				expCol{base: "", items: []export.Item{item("root")}},
+				expCol{base: "a", items: []export.Item{item("a1")}},
+				expCol{base: "b/sub", items: []export.Item{item("b1")}},
+				expCol{base: "a/sub/deeper", items: []export.Item{item("a2"), item("a3")}},
+				expCol{base: "/b", items: []export.Item{item("b2")}},
+			},
+			expectZipErr:  assert.NoError,
+			expectReadErr: assert.NoError,
+			expect: map[string]map[string]string{
+				".zip": {
+					"root.txt": "root",
+				},
+				"a.zip": {
+					"a/a1.txt":            "a1",
+					"a/sub/deeper/a2.txt": "a2",
+					"a/sub/deeper/a3.txt": "a3",
+				},
+				"b.zip": {
+					"b/sub/b1.txt": "b1",
+					"/b/b2.txt":    "b2",
+				},
+			},
+		},
+		{
+			name: "item with err",
+			inputColls: []export.Collectioner{
+				expCol{
+					base:  "a",
+					items: []export.Item{{ID: "id3", Error: assert.AnError}},
+				},
+			},
+			expectZipErr:  assert.NoError,
+			expectReadErr: assert.Error,
+		},
+	}
+
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			zc, err := archive.ZipExportCollectionsByFolder(ctx, test.inputColls)
+			test.expectZipErr(t, err, clues.ToCore(err))
+
+			if err != nil {
+				return
+			}
+
+			assert.Empty(t, zc.BasePath(), "base path")
+
+			result := map[string]map[string]string{}
+
+			for item := range zc.Items(ctx) {
+				data, err := io.ReadAll(item.Body)
+				test.expectReadErr(t, err, clues.ToCore(err))
+
+				item.Body.Close()
+
+				if err != nil {
+					continue
+				}
+
+				key := item.Name
+				if strings.HasPrefix(item.Name, "Corso_Export_") {
+					key = ".zip"
+				}
+
+				_, ok := result[key]
+				require.False(t, ok, "duplicate archive", item.Name)
+
+				result[key] = map[string]string{}
+
+				reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+				require.NoError(t, err, clues.ToCore(err))
+
+				for _, f := range reader.File {
+					rc, err := f.Open()
+					require.NoError(t, err, clues.ToCore(err))
+
+					data, err := io.ReadAll(rc)
+					require.NoError(t, err, clues.ToCore(err))
+
+					rc.Close()
+
+					result[key][f.Name] = string(data)
+				}
+			}
+
+			if test.expect != nil {
+				assert.Equal(t, test.expect, result)
+			}
+		})
+	}
+}
+
+func (suite *ExportUnitSuite) TestGetErrorsFromBackup() {
+	fe := faultTD.MakeErrors(true, true, true)
+
+	ss := ssmock.Streamer{
+		Errors: map[string]*fault.Errors{"ssid": &fe},
+	}
+
+	table := []struct {
+		name      string
+		bup       *backup.Backup
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "streamstore errors",
+			bup:       &backup.Backup{StreamStoreID: "ssid"},
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "legacy backup without streamstore",
+			bup:       &backup.Backup{DetailsID: "did"},
+			expectErr: assert.Error,
+		},
+		{
+			name:      "missing errors",
+			bup:       &backup.Backup{StreamStoreID: "missing"},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			result, err := getErrorsFromBackup(ctx, test.bup, ss, fault.New(true))
+			test.expectErr(t, err, clues.ToCore(err))
+
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, fe, *result)
+		})
+	}
+}
+
+func (suite *ExportUnitSuite) TestErrorReportCollection() {
+	fe := faultTD.MakeErrors(true, true, true)
+
+	ss := ssmock.Streamer{
+		Errors: map[string]*fault.Errors{"ssid": &fe},
+	}
+
+	table := []struct {
+		name         string
+		bup          *backup.Backup
+		expectErr    assert.ErrorAssertionFunc
+		expectReport assert.ValueAssertionFunc
+	}{
+		{
+			name:         "streamstore errors",
+			bup:          &backup.Backup{StreamStoreID: "ssid"},
+			expectErr:    assert.NoError,
+			expectReport: assert.NotNil,
+		},
+		{
+			name:         "legacy backup without streamstore",
+			bup:          &backup.Backup{DetailsID: "did"},
+			expectErr:    assert.NoError,
+			expectReport: assert.Nil,
+		},
+		{
+			name:         "missing errors",
+			bup:          &backup.Backup{StreamStoreID: "missing"},
+			expectErr:    assert.Error,
+			expectReport: assert.Nil,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			result, err := errorReportCollection(ctx, test.bup, ss, fault.New(true))
+			test.expectErr(t, err, clues.ToCore(err))
+			test.expectReport(t, result)
+		})
+	}
+}
@@ -11,6 +11,11 @@ import (
 	"github.com/alcionai/corso/src/pkg/logger"
 )
 
+// ErrPartialSuccess identifies an operation that completed, but which
+// recovered from errors along the way.  It's only produced under the
+// FailAfterRecovery policy.
+var ErrPartialSuccess = clues.New("partial success")
+
 // finalizeErrorHandling ensures the operation follows the
 // failure policy requirements.
 func finalizeErrorHandling(
@@ -29,15 +34,15 @@ func finalizeErrorHandling(
 	}
 
 	if opts.FailureHandling == control.FailAfterRecovery {
-		msg := fmt.Sprintf("%s: partial success: %d errors occurred", prefix, len(rcvd))
-		logger.Ctx(ctx).Error(msg)
+		logger.Ctx(ctx).Errorf("%s: partial success: %d errors occurred", prefix, len(rcvd))
 
+		// keep the single error detectable alongside the sentinel.
 		if len(rcvd) == 1 {
-			errs.Fail(rcvd[0])
+			errs.Fail(clues.Stack(ErrPartialSuccess, rcvd[0]))
 			return
 		}
 
-		errs.Fail(clues.New(msg))
+		errs.Fail(clues.Wrap(ErrPartialSuccess, fmt.Sprintf("%s: %d errors occurred", prefix, len(rcvd))))
 	}
 }
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/alcionai/clues"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 
@@ -26,6 +27,7 @@ func (suite *HelpersUnitSuite) TestFinalizeErrorHandling() {
 		errs      func(context.Context) *fault.Bus
 		opts      control.Options
 		expectErr assert.ErrorAssertionFunc
+		expectIs  []error
 	}{
 		{
 			name: "no errors",
@@ -72,6 +74,7 @@ func (suite *HelpersUnitSuite) TestFinalizeErrorHandling() {
 				FailureHandling: control.FailAfterRecovery,
 			},
 			expectErr: assert.Error,
+			expectIs:  []error{ErrPartialSuccess, assert.AnError},
 		},
 		{
 			name: "multiple recoverable errors produce hard fail",
@@ -86,6 +89,20 @@ func (suite *HelpersUnitSuite) TestFinalizeErrorHandling() {
 				FailureHandling: control.FailAfterRecovery,
 			},
 			expectErr: assert.Error,
+			expectIs:  []error{ErrPartialSuccess},
+		},
+		{
+			name: "already failed isn't a partial success",
+			errs: func(ctx context.Context) *fault.Bus {
+				fn := fault.New(false)
+				fn.AddRecoverable(ctx, assert.AnError)
+				fn.Fail(clues.New("fail"))
+				return fn
+			},
+			opts: control.Options{
+				FailureHandling: control.FailAfterRecovery,
+			},
+			expectErr: assert.Error,
 		},
 	}
 	for _, test := range table {
@@ -98,7 +115,17 @@ func (suite *HelpersUnitSuite) TestFinalizeErrorHandling() {
 			errs := test.errs(ctx)
 
 			finalizeErrorHandling(ctx, test.opts, errs, "test")
-			test.expectErr(t, errs.Failure())
+
+			err := errs.Failure()
+			test.expectErr(t, err, clues.ToCore(err))
+
+			for _, is := range test.expectIs {
+				assert.ErrorIs(t, err, is, clues.ToCore(err))
+			}
+
+			if len(test.expectIs) == 0 {
+				assert.NotErrorIs(t, err, ErrPartialSuccess, clues.ToCore(err))
+			}
 		})
 	}
 }
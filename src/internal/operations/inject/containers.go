@@ -3,6 +3,7 @@ package inject
 import (
 	"github.com/alcionai/corso/src/internal/common/idname"
 	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/pkg/backup/details"
 	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/selectors"
 )
@@ -22,6 +23,10 @@ type RestoreConsumerConfig struct {
 // configurations from various packages, all of which are widely used by
 // backup producers independent of service or data category.
 type BackupProducerConfig struct {
+	// DriveChecksums holds the checksums of the drive files in the merge
+	// base, keyed by drive id and item id.  Only populated when
+	// DriveChecksumIncrementals is toggled on.
+	DriveChecksums      map[string]map[string]details.ItemChecksum
 	LastBackupVersion   int
 	MetadataCollections []data.RestoreCollection
 	Options             control.Options
@@ -53,6 +53,26 @@ type (
 			ctr *count.Bus,
 		) (*details.Details, error)
 
+		// EstimateRestoreCollisions counts the items in the collections
+		// that already exist in the restore destination.  Returns the
+		// count of colliding items, and the count of all items.
+		EstimateRestoreCollisions(
+			ctx context.Context,
+			rcc RestoreConsumerConfig,
+			dcs []data.RestoreCollection,
+			errs *fault.Bus,
+		) (int, int, error)
+
+		// PlanRestoreCollections produces the changes that restoring the
+		// collections makes in the restore destination, without making
+		// any of them.
+		PlanRestoreCollections(
+			ctx context.Context,
+			rcc RestoreConsumerConfig,
+			dcs []data.RestoreCollection,
+			errs *fault.Bus,
+		) (*control.RestorePlan, error)
+
 		IsServiceEnableder
 
 		Wait() *data.CollectionStats
@@ -26,6 +26,7 @@ type MaintenanceOperation struct {
 // MaintenanceResults aggregate the details of the results of the operation.
 type MaintenanceResults struct {
 	stats.StartAndEndTime
+	stats.CountValues
 }
 
 // NewMaintenanceOperation constructs and validates a maintenance operation.
@@ -63,6 +64,8 @@ func (op *MaintenanceOperation) Run(ctx context.Context) (err error) {
 		})
 
 	defer func() {
+		op.Results.Counts = op.countValues(ctx)
+
 		op.bus.Event(
 			ctx,
 			events.MaintenanceEnd,
@@ -92,6 +92,11 @@ func getManifestsAndMetadata(
 		return bb, nil, false, nil
 	}
 
+	if deltaReset(bb) {
+		logger.Ctx(ctx).Info("delta tokens were reset, dropping prior metadata")
+		return bb, nil, false, nil
+	}
+
 	for _, man := range bb.MergeBases() {
 		mctx := clues.Add(ctx, "manifest_id", man.ID)
 
@@ -139,3 +144,15 @@ func getManifestsAndMetadata(
 
 	return bb, collections, true, nil
 }
+
+// deltaReset is true if the delta tokens of any of the merge base backups
+// were reset.  Their metadata is no longer trusted.
+func deltaReset(bb kopia.BackupBases) bool {
+	for _, b := range bb.Backups() {
+		if b.Backup != nil && b.DeltaReset {
+			return true
+		}
+	}
+
+	return false
+}
@@ -350,6 +350,13 @@ func (suite *OperationsManifestsUnitSuite) TestProduceManifestsAndMetadata() {
 		}
 	}
 
+	resetBackup := func(snapID string, cats ...path.CategoryType) kopia.BackupEntry {
+		be := makeBackup(snapID, cats...)
+		be.DeltaReset = true
+
+		return be
+	}
+
 	table := []struct {
 		name        string
 		bf          *mockBackupFinder
@@ -395,6 +402,32 @@ func (suite *OperationsManifestsUnitSuite) TestProduceManifestsAndMetadata() {
 				WithBackups(makeBackup("id1", path.EmailCategory)).
 				MockDisableMergeBases(),
 		},
+		{
+			name: "delta reset backup",
+			bf: &mockBackupFinder{
+				data: map[string]kopia.BackupBases{
+					ro: kopia.NewMockBackupBases().
+						WithMergeBases(makeMan("id1", "", path.EmailCategory)).
+						WithBackups(resetBackup("id1", path.EmailCategory)),
+				},
+			},
+			rp: mockRestoreProducer{
+				collsByID: map[string][]data.RestoreCollection{
+					"id1": {data.NoFetchRestoreCollection{Collection: mockColl{id: "id1"}}},
+				},
+			},
+			reasons: []identity.Reasoner{
+				kopia.NewReason("", ro, path.ExchangeService, path.EmailCategory),
+			},
+			getMeta:   true,
+			assertErr: assert.NoError,
+			assertB:   assert.False,
+			expectDCS: nil,
+			expectMans: kopia.NewMockBackupBases().
+				WithMergeBases(makeMan("id1", "", path.EmailCategory)).
+				WithBackups(resetBackup("id1", path.EmailCategory)).
+				MockDisableMergeBases(),
+		},
 		{
 			name: "don't get metadata, incomplete manifest",
 			bf: &mockBackupFinder{
@@ -1,15 +1,19 @@
 package operations
 
 import (
+	"context"
 	"time"
 
 	"github.com/alcionai/clues"
+	"github.com/google/uuid"
 
 	"github.com/alcionai/corso/src/internal/events"
 	"github.com/alcionai/corso/src/internal/kopia"
+	"github.com/alcionai/corso/src/internal/m365/graph"
 	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/count"
 	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
 	"github.com/alcionai/corso/src/pkg/store"
 )
 
@@ -29,15 +33,20 @@ import (
 // For example, if a backup is requested for a specific user's
 // mail, but that account contains zero mail messages, the backup
 // contains No Data.
+//
+// PartiallyCompleted - the caller requested the operation stop
+// before it finished processing all items.  Everything processed
+// before the stop was finalized.
 type OpStatus int
 
 //go:generate stringer -type=OpStatus -linecomment
 const (
-	Unknown    OpStatus = 0 // Status Unknown
-	InProgress OpStatus = 1 // In Progress
-	Completed  OpStatus = 2 // Completed
-	Failed     OpStatus = 3 // Failed
-	NoData     OpStatus = 4 // No Data
+	Unknown            OpStatus = 0 // Status Unknown
+	InProgress         OpStatus = 1 // In Progress
+	Completed          OpStatus = 2 // Completed
+	Failed             OpStatus = 3 // Failed
+	NoData             OpStatus = 4 // No Data
+	PartiallyCompleted OpStatus = 5 // Partially Completed
 )
 
 // --------------------------------------------------------------------------------
@@ -49,6 +58,10 @@ const (
 // with process specific details.
 type operation struct {
 	CreatedAt time.Time `json:"createdAt"`
+	// ID uniquely identifies the run of the operation.  It's added to the
+	// logs and errors of the operation, so that those of concurrent
+	// operations can be told apart.
+	ID string `json:"id"`
 
 	Errors  *fault.Bus `json:"errors"`
 	Counter *count.Bus
@@ -67,9 +80,12 @@ func newOperation(
 	kw *kopia.Wrapper,
 	sw store.BackupStorer,
 ) operation {
+	id := uuid.NewString()
+
 	return operation{
 		CreatedAt: time.Now(),
-		Errors:    fault.New(opts.FailureHandling == control.FailFast),
+		ID:        id,
+		Errors:    fault.New(opts.FailureHandling == control.FailFast).SetOperationID(id),
 		Counter:   ctr,
 		Options:   opts,
 
@@ -81,6 +97,48 @@ func newOperation(
 	}
 }
 
+// countValues snapshots the values in the operation's counter, and
+// emits them in a single structured log line.  Expected to be called
+// once, at the end of the operation.
+func (op operation) countValues(ctx context.Context) map[string]int64 {
+	vs := op.Counter.Values()
+
+	logger.Ctx(ctx).Infow("operation counts", "counts", vs)
+
+	return vs
+}
+
+// bindOperationID adds the operation's id to the context, which includes
+// it in every log and error produced with the context.
+func (op operation) bindOperationID(ctx context.Context) context.Context {
+	return clues.Add(ctx, "operation_id", op.ID)
+}
+
+// bindRetryBudget binds a graph api retry budget to the context, if the
+// operation's options cap the number of retries.
+func (op operation) bindRetryBudget(ctx context.Context) context.Context {
+	if op.Options.MaxOperationRetries <= 0 {
+		return ctx
+	}
+
+	return graph.BindRetryBudget(ctx, graph.NewRetryBudget(op.Options.MaxOperationRetries))
+}
+
+// bindThrottleSignal tallies the graph api throttling seen by the
+// operation's requests in its counter.
+func (op operation) bindThrottleSignal(ctx context.Context) context.Context {
+	return graph.BindThrottleSignal(ctx, op.Counter)
+}
+
+// bindItemFetchLimiter shares one adaptive item fetch limiter between all
+// of the operation's collections, so that throttling seen by any of them
+// lowers the concurrency of all of them.  The returned func stops the
+// limiter's adjustments.
+func (op operation) bindItemFetchLimiter(ctx context.Context) (context.Context, func()) {
+	al := graph.NewAdaptiveLimiter(op.Options.Parallelism.ItemFetch, op.Counter)
+	return graph.BindAdaptiveLimiter(ctx, al), al.Start(ctx)
+}
+
 func (op operation) validate() error {
 	if op.kopia == nil {
 		return clues.New("missing kopia connection")
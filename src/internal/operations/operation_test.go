@@ -10,6 +10,7 @@ import (
 
 	"github.com/alcionai/corso/src/internal/events"
 	"github.com/alcionai/corso/src/internal/kopia"
+	"github.com/alcionai/corso/src/internal/m365/graph"
 	"github.com/alcionai/corso/src/internal/tester"
 	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/count"
@@ -28,6 +29,60 @@ func (suite *OperationSuite) TestNewOperation() {
 	t := suite.T()
 	op := newOperation(control.DefaultOptions(), events.Bus{}, &count.Bus{}, nil, nil)
 	assert.Greater(t, op.CreatedAt, time.Time{})
+	assert.NotEmpty(t, op.ID)
+	assert.Equal(t, op.ID, op.Errors.OperationID(), "errors operation id")
+
+	other := newOperation(control.DefaultOptions(), events.Bus{}, &count.Bus{}, nil, nil)
+	assert.NotEqual(t, op.ID, other.ID, "operations have unique ids")
+}
+
+func (suite *OperationSuite) TestOperation_BindOperationID() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	op := newOperation(control.DefaultOptions(), events.Bus{}, &count.Bus{}, nil, nil)
+	ctx = op.bindOperationID(ctx)
+
+	assert.Equal(t, op.ID, clues.In(ctx).Map()["operation_id"], "context operation id")
+
+	err := clues.Wrap(assert.AnError, "running operation").WithClues(ctx)
+	assert.Equal(t, op.ID, clues.InErr(err).Map()["operation_id"], "error operation id")
+}
+
+func (suite *OperationSuite) TestOperation_BindItemFetchLimiter() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	opts := control.DefaultOptions()
+	opts.Parallelism.ItemFetch = 3
+
+	op := newOperation(opts, events.Bus{}, count.New(), nil, nil)
+
+	ctx, stop := op.bindItemFetchLimiter(op.bindThrottleSignal(ctx))
+	defer stop()
+
+	al1, stop1 := graph.ItemFetchLimiter(ctx, 8)
+	defer stop1()
+
+	al2, stop2 := graph.ItemFetchLimiter(ctx, 8)
+	defer stop2()
+
+	assert.Same(t, al1, al2, "collections share the operation's limiter")
+	assert.Equal(t, 3, al1.Limit(), "limit follows the operation's options")
+
+	other := newOperation(opts, events.Bus{}, count.New(), nil, nil)
+
+	octx, ostop := other.bindItemFetchLimiter(other.bindThrottleSignal(ctx))
+	defer ostop()
+
+	al3, stop3 := graph.ItemFetchLimiter(octx, 8)
+	defer stop3()
+
+	assert.NotSame(t, al1, al3, "operations don't share limiters")
 }
 
 func (suite *OperationSuite) TestOperation_Validate() {
@@ -51,3 +106,31 @@ func (suite *OperationSuite) TestOperation_Validate() {
 		})
 	}
 }
+
+func (suite *OperationSuite) TestOperation_CountValues() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	ctr := count.New()
+	op := newOperation(control.DefaultOptions(), events.Bus{}, ctr, nil, nil)
+
+	ctr.Inc(count.NewItemCreated)
+	ctr.Add(count.CollisionSkip, 3)
+	ctr.Add(count.CollisionReplace, 2)
+
+	result := op.countValues(ctx)
+	assert.Equal(
+		t,
+		map[string]int64{
+			string(count.NewItemCreated):   1,
+			string(count.CollisionSkip):    3,
+			string(count.CollisionReplace): 2,
+		},
+		result)
+
+	// the snapshot should not change as the counter continues to increment.
+	ctr.Inc(count.NewItemCreated)
+	assert.Equal(t, int64(1), result[string(count.NewItemCreated)])
+}
@@ -13,11 +13,12 @@ func _() {
 	_ = x[Completed-2]
 	_ = x[Failed-3]
 	_ = x[NoData-4]
+	_ = x[PartiallyCompleted-5]
 }
 
-const _OpStatus_name = "Status UnknownIn ProgressCompletedFailedNo Data"
+const _OpStatus_name = "Status UnknownIn ProgressCompletedFailedNo DataPartially Completed"
 
-var _OpStatus_index = [...]uint8{0, 14, 25, 34, 40, 47}
+var _OpStatus_index = [...]uint8{0, 14, 25, 34, 40, 47, 66}
 
 func (i OpStatus) String() string {
 	if i < 0 || i >= OpStatus(len(_OpStatus_index)-1) {
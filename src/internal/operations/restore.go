@@ -53,6 +53,9 @@ type RestoreOperation struct {
 type RestoreResults struct {
 	stats.ReadWrites
 	stats.StartAndEndTime
+	stats.CountValues
+	// OperationID identifies the run of the operation in its logs and errors.
+	OperationID string `json:"operationID,omitempty"`
 }
 
 // NewRestoreOperation constructs and validates a restore operation.
@@ -78,6 +81,12 @@ func NewRestoreOperation(
 		Version:    "v0",
 		rc:         rc,
 	}
+	op.Results.OperationID = op.ID
+
+	// plans and runs of the operation share the collision callback's
+	// decision for each item.
+	op.RestoreCfg.OnCollisionFunc = op.RestoreCfg.OnCollisionFunc.OncePerItem()
+
 	if err := op.validate(); err != nil {
 		return RestoreOperation{}, err
 	}
@@ -133,6 +142,10 @@ func (op *RestoreOperation) Run(ctx context.Context) (restoreDetails *details.De
 		end()
 	}()
 
+	ctx = op.bindOperationID(ctx)
+	ctx = op.bindRetryBudget(ctx)
+	ctx = op.bindThrottleSignal(ctx)
+
 	ctx, flushMetrics := events.NewMetrics(ctx, logger.Writer{Ctx: ctx})
 	defer flushMetrics()
 
@@ -144,6 +157,8 @@ func (op *RestoreOperation) Run(ctx context.Context) (restoreDetails *details.De
 		"destination_container", clues.Hide(op.RestoreCfg.Location))
 
 	defer func() {
+		op.Results.Counts = op.countValues(ctx)
+
 		op.bus.Event(
 			ctx,
 			events.RestoreEnd,
@@ -175,12 +190,11 @@ func (op *RestoreOperation) Run(ctx context.Context) (restoreDetails *details.De
 			op.Errors.Fail(clues.Wrap(err, "empty backup or unknown path provided"))
 		}
 
-		op.Errors.Fail(clues.Wrap(err, "running restore"))
+		op.Errors.Fail(clues.Wrap(err, "running restore").WithClues(ctx))
 	}
 
 	finalizeErrorHandling(ctx, op.Options, op.Errors, "running restore")
 	LogFaultErrors(ctx, op.Errors.Errors(), "running restore")
-	logger.Ctx(ctx).With("total_counts", op.Counter.Values()).Info("restore stats")
 
 	// -----
 	// Persistence
@@ -317,6 +331,137 @@ func (op *RestoreOperation) do(
 	return deets, nil
 }
 
+// EstimateCollisions counts the backed up items selected for restore that
+// collide with items which already exist in the restore destination.  No
+// data gets restored, and the operation's results are left untouched, so
+// the operation can still be run afterward.  Only drive-based services
+// support estimates.
+func (op *RestoreOperation) EstimateCollisions(
+	ctx context.Context,
+) (existing, total int, err error) {
+	defer func() {
+		if crErr := crash.Recovery(ctx, recover(), "restore collision estimate"); crErr != nil {
+			err = crErr
+		}
+	}()
+
+	errs := fault.New(op.Options.FailureHandling == control.FailFast)
+
+	rcc, dcs, err := op.restoreConsumerInputs(ctx, errs)
+	if err != nil {
+		return 0, 0, clues.Stack(err)
+	}
+
+	existing, total, err = op.rc.EstimateRestoreCollisions(ctx, rcc, dcs, errs)
+	if err != nil {
+		return 0, 0, clues.Wrap(err, "estimating restore collisions")
+	}
+
+	logger.Ctx(ctx).Infow(
+		"estimated restore collisions",
+		"existing_items", existing,
+		"total_items", total)
+
+	return existing, total, errs.Failure()
+}
+
+// Plan produces the changes that running the restore makes in the restore
+// destination: the drives and folders it creates, the action taken on each
+// item, and the permissions it applies.  Nothing gets restored, and the
+// operation's results are left untouched, so the operation can still be run
+// afterward.  Only drive-based services support plans.
+func (op *RestoreOperation) Plan(
+	ctx context.Context,
+) (plan *control.RestorePlan, err error) {
+	defer func() {
+		if crErr := crash.Recovery(ctx, recover(), "restore plan"); crErr != nil {
+			err = crErr
+		}
+	}()
+
+	errs := fault.New(op.Options.FailureHandling == control.FailFast)
+
+	rcc, dcs, err := op.restoreConsumerInputs(ctx, errs)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	plan, err = op.rc.PlanRestoreCollections(ctx, rcc, dcs, errs)
+	if err != nil {
+		return nil, clues.Wrap(err, "planning restore")
+	}
+
+	logger.Ctx(ctx).Infow(
+		"planned restore",
+		"drives_created", len(plan.Drives),
+		"folders_created", len(plan.Folders),
+		"item_actions", plan.ActionCounts())
+
+	return plan, errs.Failure()
+}
+
+// restoreConsumerInputs produces the consumer config and the collections
+// that the restore consumes, without consuming them.
+func (op *RestoreOperation) restoreConsumerInputs(
+	ctx context.Context,
+	errs *fault.Bus,
+) (inject.RestoreConsumerConfig, []data.RestoreCollection, error) {
+	sstore := streamstore.NewStreamer(op.kopia, op.acct.ID(), op.Selectors.PathService())
+
+	ctx = clues.Add(
+		ctx,
+		"tenant_id", clues.Hide(op.acct.ID()),
+		"backup_id", op.BackupID,
+		"service", op.Selectors.Service,
+		"destination_container", clues.Hide(op.RestoreCfg.Location))
+
+	bup, deets, err := getBackupAndDetailsFromID(
+		ctx,
+		op.BackupID,
+		op.store,
+		sstore,
+		errs)
+	if err != nil {
+		return inject.RestoreConsumerConfig{}, nil, clues.Wrap(err, "getting backup and details")
+	}
+
+	restoreToProtectedResource, err := chooseRestoreResource(ctx, op.rc, op.RestoreCfg, bup.Selector)
+	if err != nil {
+		return inject.RestoreConsumerConfig{}, nil, clues.Wrap(err, "getting destination protected resource")
+	}
+
+	paths, err := formatDetailsForRestoration(
+		ctx,
+		bup.Version,
+		op.Selectors,
+		deets,
+		op.rc,
+		errs)
+	if err != nil {
+		return inject.RestoreConsumerConfig{}, nil, clues.Wrap(err, "formatting paths from details")
+	}
+
+	dcs, err := op.kopia.ProduceRestoreCollections(
+		ctx,
+		bup.SnapshotID,
+		paths,
+		&stats.ByteCounter{},
+		errs)
+	if err != nil {
+		return inject.RestoreConsumerConfig{}, nil, clues.Wrap(err, "producing collections to restore")
+	}
+
+	rcc := inject.RestoreConsumerConfig{
+		BackupVersion:     bup.Version,
+		Options:           op.Options,
+		ProtectedResource: restoreToProtectedResource,
+		RestoreConfig:     op.RestoreCfg,
+		Selector:          op.Selectors,
+	}
+
+	return rcc, dcs, nil
+}
+
 // persists details and statistics about the restore operation.
 func (op *RestoreOperation) persistResults(
 	ctx context.Context,
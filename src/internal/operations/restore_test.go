@@ -132,6 +132,9 @@ func (suite *RestoreOpUnitSuite) TestRestoreOperation_PersistResults() {
 			assert.Equal(t, test.stats.resourceCount, op.Results.ResourceOwners, "resource owners")
 			assert.Equal(t, now, op.Results.StartedAt, "started at")
 			assert.Less(t, now, op.Results.CompletedAt, "completed at")
+			assert.NotEmpty(t, op.Results.OperationID, "operation id")
+			assert.Equal(t, op.ID, op.Results.OperationID, "operation id")
+			assert.Equal(t, op.ID, op.Errors.Errors().OperationID, "errors operation id")
 		})
 	}
 }
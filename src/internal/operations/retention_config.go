@@ -25,6 +25,7 @@ type RetentionConfigOperation struct {
 // RetentionConfigResults aggregate the details of the results of the operation.
 type RetentionConfigResults struct {
 	stats.StartAndEndTime
+	stats.CountValues
 }
 
 // NewRetentionConfigOperation constructs and validates an operation to change
@@ -55,6 +56,10 @@ func (op *RetentionConfigOperation) Run(ctx context.Context) (err error) {
 
 	op.Results.StartedAt = time.Now()
 
+	defer func() {
+		op.Results.Counts = op.countValues(ctx)
+	}()
+
 	// TODO(ashmrtn): Send telemetry?
 
 	return op.do(ctx)
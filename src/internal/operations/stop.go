@@ -0,0 +1,205 @@
+package operations
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+// stopper coordinates a graceful stop between an operation and the
+// collections it's consuming.
+type stopper struct {
+	requested atomic.Bool
+	// set when the stop cut a collection short, as opposed to arriving
+	// after every collection already handed over all of its items.
+	interrupted atomic.Bool
+	stopCh      chan struct{}
+	once        sync.Once
+}
+
+func newStopper() *stopper {
+	return &stopper{stopCh: make(chan struct{})}
+}
+
+func (s *stopper) request() {
+	s.requested.Store(true)
+	s.once.Do(func() { close(s.stopCh) })
+}
+
+func (s *stopper) isRequested() bool {
+	return s.requested.Load()
+}
+
+// isInterrupted is true if the stop was requested while a collection was
+// still producing items.
+func (s *stopper) isInterrupted() bool {
+	return s.interrupted.Load()
+}
+
+// stoppableCollections wraps each collection so that it stops handing items
+// to the consumer once a stop is requested.  The wrappers retain the location
+// interfaces of the original collection, since kopia relies on them when
+// building details entries.
+func stoppableCollections(
+	cs []data.BackupCollection,
+	stop *stopper,
+) []data.BackupCollection {
+	result := make([]data.BackupCollection, 0, len(cs))
+
+	for _, c := range cs {
+		sc := stoppableCollection{
+			BackupCollection: c,
+			stop:             stop,
+		}
+
+		switch lp := c.(type) {
+		case data.PreviousLocationPather:
+			result = append(result, stoppablePrevLocCollection{sc, lp})
+		case data.LocationPather:
+			result = append(result, stoppableLocCollection{sc, lp})
+		default:
+			result = append(result, sc)
+		}
+	}
+
+	return result
+}
+
+var _ data.BackupCollection = stoppableCollection{}
+
+type stoppableCollection struct {
+	data.BackupCollection
+	stop *stopper
+}
+
+// Items forwards items from the wrapped collection until a stop is
+// requested.  The stop cancels the context of the wrapped collection so
+// that it quits fetching data, and any items it still produces are drained
+// and dropped so that it doesn't block on a full channel.
+//
+// The wrapped collection records its errors on a bus of its own, which
+// passes each recoverable error and skipped item along to errs as they
+// get added.  Errors caused by cancelling its context are dropped, since
+// they're the expected outcome of the stop.
+func (sc stoppableCollection) Items(
+	ctx context.Context,
+	errs *fault.Bus,
+) <-chan data.Item {
+	var (
+		res          = make(chan data.Item)
+		ictx, cancel = context.WithCancel(ctx)
+		cerrs        = sc.forwardingBus(ctx, errs)
+		items        = sc.BackupCollection.Items(ictx, cerrs)
+	)
+
+	go func() {
+		select {
+		case <-sc.stop.stopCh:
+			// the collection may have completed before the stop arrived.
+			if ictx.Err() == nil {
+				sc.stop.interrupted.Store(true)
+				cancel()
+			}
+		case <-ictx.Done():
+		}
+	}()
+
+	go func() {
+		defer close(res)
+		defer cancel()
+
+		for item := range items {
+			if sc.stop.isRequested() || ctx.Err() != nil {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+			case res <- item:
+			}
+		}
+
+		sc.forwardFailure(cerrs, errs)
+	}()
+
+	return res
+}
+
+// stoppedErr is true if the error was caused by the stop cancelling the
+// wrapped collection's context.
+func (sc stoppableCollection) stoppedErr(err error) bool {
+	return sc.stop.isRequested() && errors.Is(err, context.Canceled)
+}
+
+// forwardingBus produces a bus for the wrapped collection that adds its
+// recoverable errors and skipped items to the errs as they're added.
+func (sc stoppableCollection) forwardingBus(
+	ctx context.Context,
+	errs *fault.Bus,
+) *fault.Bus {
+	cerrs := fault.New(errs.FailFast())
+
+	cerrs.Subscribe(func(kind string, err error, skip *fault.Skipped) {
+		switch kind {
+		case fault.EventRecoverable:
+			if !sc.stoppedErr(err) {
+				errs.AddRecoverable(ctx, err)
+			}
+		case fault.EventSkip:
+			errs.AddSkip(ctx, skip)
+		}
+	})
+
+	return cerrs
+}
+
+// forwardFailure fails errs if the wrapped collection failed its bus
+// without adding the failure as a recoverable error.  FailFast buses also
+// track their failure as a recoverable error, which already got passed
+// along to errs.
+func (sc stoppableCollection) forwardFailure(from, to *fault.Bus) {
+	failure := from.Failure()
+	if failure == nil || sc.stoppedErr(failure) {
+		return
+	}
+
+	for _, err := range from.Recovered() {
+		if errors.Is(err, failure) {
+			return
+		}
+	}
+
+	to.Fail(failure)
+}
+
+var _ data.LocationPather = stoppableLocCollection{}
+
+type stoppableLocCollection struct {
+	stoppableCollection
+	lp data.LocationPather
+}
+
+func (c stoppableLocCollection) LocationPath() *path.Builder {
+	return c.lp.LocationPath()
+}
+
+var _ data.PreviousLocationPather = stoppablePrevLocCollection{}
+
+type stoppablePrevLocCollection struct {
+	stoppableCollection
+	plp data.PreviousLocationPather
+}
+
+func (c stoppablePrevLocCollection) LocationPath() *path.Builder {
+	return c.plp.LocationPath()
+}
+
+func (c stoppablePrevLocCollection) PreviousLocationPath() details.LocationIDer {
+	return c.plp.PreviousLocationPath()
+}
@@ -0,0 +1,383 @@
+package operations
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/data"
+	dataMock "github.com/alcionai/corso/src/internal/data/mock"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+type StopUnitSuite struct {
+	tester.Suite
+}
+
+func TestStopUnitSuite(t *testing.T) {
+	suite.Run(t, &StopUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+// stoppingCollection waits for its context to get cancelled instead of
+// producing the item at index stopAt, the same as a collection that's in
+// the middle of fetching data when the stop arrives.
+type stoppingCollection struct {
+	dataMock.Collection
+	stopAt    int
+	cancelled *bool
+}
+
+func (c stoppingCollection) Items(ctx context.Context, errs *fault.Bus) <-chan data.Item {
+	ch := make(chan data.Item)
+
+	go func() {
+		defer close(ch)
+
+		for i, item := range c.ItemData {
+			if i == c.stopAt {
+				select {
+				case <-ctx.Done():
+					*c.cancelled = true
+					errs.AddRecoverable(ctx, clues.Stack(ctx.Err()))
+				case <-time.After(time.Minute):
+				}
+
+				return
+			}
+
+			ch <- item
+		}
+	}()
+
+	return ch
+}
+
+func (suite *StopUnitSuite) TestStoppableCollections() {
+	t := suite.T()
+
+	p, err := path.Build("t", "pr", path.ExchangeService, path.EmailCategory, false, "inbox")
+	require.NoError(t, err, clues.ToCore(err))
+
+	items := []data.Item{}
+
+	for _, id := range []string{"a", "b", "c", "d", "e"} {
+		items = append(items, &dataMock.Item{ItemID: id})
+	}
+
+	table := []struct {
+		name string
+		// stopAt is the number of items to consume before requesting a stop.
+		// Negative values never request a stop.
+		stopAt            int
+		expectCount       int
+		expectInterrupted bool
+	}{
+		{
+			name:        "no stop",
+			stopAt:      -1,
+			expectCount: len(items),
+		},
+		{
+			name:              "stop before reading",
+			stopAt:            0,
+			expectCount:       0,
+			expectInterrupted: true,
+		},
+		{
+			name:              "stop mid-stream",
+			stopAt:            2,
+			expectCount:       2,
+			expectInterrupted: true,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				stop      = newStopper()
+				errs      = fault.New(true)
+				cancelled bool
+				found     int
+			)
+
+			cs := stoppableCollections(
+				[]data.BackupCollection{stoppingCollection{
+					Collection: dataMock.Collection{Path: p, ItemData: items},
+					stopAt:     test.stopAt,
+					cancelled:  &cancelled,
+				}},
+				stop)
+			require.Len(t, cs, 1)
+
+			if test.stopAt == 0 {
+				stop.request()
+			}
+
+			// the wrapper checks for a stop before handing over each item, and
+			// the unbuffered channel means that check happens before the
+			// consumer receives the item.
+			for range cs[0].Items(ctx, errs) {
+				found++
+
+				if found == test.stopAt {
+					stop.request()
+				}
+			}
+
+			assert.Equal(t, test.expectCount, found, "items consumed")
+			assert.Equal(t, test.expectInterrupted, cancelled, "producer context cancelled")
+			assert.Equal(t, test.expectInterrupted, stop.isInterrupted(), "interrupted")
+			assert.NoError(t, errs.Failure(), "cancellation errors are dropped")
+			assert.Empty(t, errs.Recovered(), "cancellation errors are dropped")
+		})
+	}
+}
+
+func (suite *StopUnitSuite) TestStoppableCollections_stopAfterCompletion() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	p, err := path.Build("t", "pr", path.ExchangeService, path.EmailCategory, false, "inbox")
+	require.NoError(t, err, clues.ToCore(err))
+
+	var (
+		stop  = newStopper()
+		errs  = fault.New(true)
+		found int
+	)
+
+	cs := stoppableCollections(
+		[]data.BackupCollection{dataMock.Collection{
+			Path:     p,
+			ItemData: []data.Item{&dataMock.Item{ItemID: "a"}},
+		}},
+		stop)
+	require.Len(t, cs, 1)
+
+	for range cs[0].Items(ctx, errs) {
+		found++
+	}
+
+	stop.request()
+
+	assert.Equal(t, 1, found, "items consumed")
+	assert.False(t, stop.isInterrupted(), "interrupted")
+}
+
+func (suite *StopUnitSuite) TestStoppableCollections_forwardsErrors() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	p, err := path.Build("t", "pr", path.ExchangeService, path.EmailCategory, false, "inbox")
+	require.NoError(t, err, clues.ToCore(err))
+
+	var (
+		errs  = fault.New(false)
+		found int
+	)
+
+	cs := stoppableCollections(
+		[]data.BackupCollection{dataMock.Collection{
+			Path: p,
+			ItemData: []data.Item{
+				&dataMock.Item{ItemID: "a"},
+				&dataMock.Item{ItemID: "b", ReadErr: assert.AnError},
+			},
+		}},
+		newStopper())
+	require.Len(t, cs, 1)
+
+	for range cs[0].Items(ctx, errs) {
+		found++
+	}
+
+	assert.Equal(t, 1, found, "items consumed")
+	assert.NoError(t, errs.Failure())
+	assert.Len(t, errs.Recovered(), 1)
+}
+
+// faultingCollection hands each item to produce in place of streaming its
+// item data, which lets tests record errors in between items.
+type faultingCollection struct {
+	dataMock.Collection
+	produce func(ctx context.Context, errs *fault.Bus, ch chan<- data.Item)
+}
+
+func (c faultingCollection) Items(ctx context.Context, errs *fault.Bus) <-chan data.Item {
+	ch := make(chan data.Item)
+
+	go func() {
+		defer close(ch)
+		c.produce(ctx, errs, ch)
+	}()
+
+	return ch
+}
+
+func (suite *StopUnitSuite) TestStoppableCollections_forwardsErrorsAsAdded() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	p, err := path.Build("t", "pr", path.ExchangeService, path.EmailCategory, false, "inbox")
+	require.NoError(t, err, clues.ToCore(err))
+
+	var (
+		errs = fault.New(false)
+		skip = fault.FileSkip(fault.SkipMalware, "ns", "id", "name", nil)
+	)
+
+	cs := stoppableCollections(
+		[]data.BackupCollection{faultingCollection{
+			Collection: dataMock.Collection{Path: p},
+			produce: func(ctx context.Context, errs *fault.Bus, ch chan<- data.Item) {
+				errs.AddRecoverable(ctx, assert.AnError)
+				errs.AddSkip(ctx, skip)
+				ch <- &dataMock.Item{ItemID: "a"}
+			},
+		}},
+		newStopper())
+	require.Len(t, cs, 1)
+
+	for range cs[0].Items(ctx, errs) {
+		// the collection is still running, since it hasn't closed the channel.
+		assert.Len(t, errs.Recovered(), 1, "recovered errors")
+		assert.Len(t, errs.Skipped(), 1, "skipped items")
+	}
+
+	assert.NoError(t, errs.Failure())
+	assert.Len(t, errs.Recovered(), 1, "recovered errors are added once")
+	assert.Len(t, errs.Skipped(), 1, "skipped items are added once")
+}
+
+func (suite *StopUnitSuite) TestStoppableCollections_forwardsFailure() {
+	table := []struct {
+		name     string
+		failFast bool
+		fault    func(ctx context.Context, errs *fault.Bus)
+	}{
+		{
+			name: "failed bus",
+			fault: func(ctx context.Context, errs *fault.Bus) {
+				errs.Fail(assert.AnError)
+			},
+		},
+		{
+			name:     "fail fast recoverable",
+			failFast: true,
+			fault: func(ctx context.Context, errs *fault.Bus) {
+				errs.AddRecoverable(ctx, assert.AnError)
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			p, err := path.Build("t", "pr", path.ExchangeService, path.EmailCategory, false, "inbox")
+			require.NoError(t, err, clues.ToCore(err))
+
+			errs := fault.New(test.failFast)
+
+			cs := stoppableCollections(
+				[]data.BackupCollection{faultingCollection{
+					Collection: dataMock.Collection{Path: p},
+					produce: func(ctx context.Context, errs *fault.Bus, ch chan<- data.Item) {
+						test.fault(ctx, errs)
+					},
+				}},
+				newStopper())
+			require.Len(t, cs, 1)
+
+			for range cs[0].Items(ctx, errs) {
+			}
+
+			assert.ErrorIs(t, errs.Failure(), assert.AnError, clues.ToCore(errs.Failure()))
+		})
+	}
+}
+
+func (suite *StopUnitSuite) TestStoppableCollections_forwardsErrorsAfterStop() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	p, err := path.Build("t", "pr", path.ExchangeService, path.EmailCategory, false, "inbox")
+	require.NoError(t, err, clues.ToCore(err))
+
+	var (
+		stop  = newStopper()
+		errs  = fault.New(false)
+		found int
+	)
+
+	cs := stoppableCollections(
+		[]data.BackupCollection{faultingCollection{
+			Collection: dataMock.Collection{Path: p},
+			produce: func(ctx context.Context, errs *fault.Bus, ch chan<- data.Item) {
+				ch <- &dataMock.Item{ItemID: "a"}
+
+				<-ctx.Done()
+
+				// items and errors produced while the collection winds down.
+				errs.AddRecoverable(ctx, clues.Stack(ctx.Err()))
+				errs.AddRecoverable(ctx, assert.AnError)
+				errs.AddSkip(ctx, fault.FileSkip(fault.SkipMalware, "ns", "id", "name", nil))
+				ch <- &dataMock.Item{ItemID: "b"}
+			},
+		}},
+		stop)
+	require.Len(t, cs, 1)
+
+	for range cs[0].Items(ctx, errs) {
+		found++
+
+		stop.request()
+	}
+
+	assert.Equal(t, 1, found, "items consumed")
+	assert.True(t, stop.isInterrupted(), "interrupted")
+	assert.NoError(t, errs.Failure())
+	require.Len(t, errs.Recovered(), 1, "cancellation errors are dropped")
+	assert.ErrorIs(t, errs.Recovered()[0], assert.AnError)
+	assert.Len(t, errs.Skipped(), 1, "skipped items")
+}
+
+func (suite *StopUnitSuite) TestStoppableCollections_retainsLocation() {
+	t := suite.T()
+
+	p, err := path.Build("t", "pr", path.ExchangeService, path.EmailCategory, false, "inbox")
+	require.NoError(t, err, clues.ToCore(err))
+
+	loc := path.Builder{}.Append("Inbox")
+
+	cs := stoppableCollections(
+		[]data.BackupCollection{dataMock.Collection{Path: p, Loc: loc}},
+		newStopper())
+	require.Len(t, cs, 1)
+
+	lp, ok := cs[0].(data.LocationPather)
+	require.True(t, ok, "collection retains location pather")
+	assert.Equal(t, loc, lp.LocationPath())
+	assert.Equal(t, p, cs[0].FullPath())
+}
@@ -221,6 +221,8 @@ func runAndCheckBackup(
 	assert.NotZero(t, bo.Results.ItemsRead, "count of items read")
 	assert.NotZero(t, bo.Results.BytesRead, "bytes read")
 	assert.NotZero(t, bo.Results.BytesUploaded, "bytes uploaded")
+	assert.Greater(t, bo.Results.CompressionRatio, 0.0, "compression ratio")
+	assert.LessOrEqual(t, bo.Results.CompressionRatio, 1.0, "compression ratio")
 	assert.Equal(t, 1, bo.Results.ResourceOwners, "count of resource owners")
 	assert.NoError(t, bo.Errors.Failure(), "incremental non-recoverable error", clues.ToCore(bo.Errors.Failure()))
 	assert.Empty(t, bo.Errors.Recovered(), "incremental recoverable/iteration errors")
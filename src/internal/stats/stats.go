@@ -15,6 +15,14 @@ type ReadWrites struct {
 	NonMetaItemsWritten  int   `json:"nonMetaItemsWritten,omitempty"`
 	ItemsWritten         int   `json:"itemsWritten,omitempty"`
 	ResourceOwners       int   `json:"resourceOwners,omitempty"`
+	// SnapshotBytes and SnapshotStoredBytes are the sizes of the data held
+	// by the backup's snapshot before and after compression.
+	SnapshotBytes       int64 `json:"snapshotBytes,omitempty"`
+	SnapshotStoredBytes int64 `json:"snapshotStoredBytes,omitempty"`
+	// CompressionRatio is the ratio of SnapshotStoredBytes to SnapshotBytes.
+	// Smaller values denote better compression.  Zero if the snapshot sizes
+	// are unknown.
+	CompressionRatio float64 `json:"compressionRatio,omitempty"`
 }
 
 // StartAndEndTime tracks a paired starting time and ending time.
@@ -23,6 +31,12 @@ type StartAndEndTime struct {
 	CompletedAt time.Time `json:"completedAt"`
 }
 
+// CountValues holds a snapshot of the values tallied in an
+// operation's count.Bus.
+type CountValues struct {
+	Counts map[string]int64 `json:"counts,omitempty"`
+}
+
 type ByteCounter struct {
 	NumBytes int64
 }
@@ -37,3 +51,69 @@ type SkippedCounts struct {
 	SkippedNotFound           int `json:"skippedNotFound"`
 	SkippedInvalidOneNoteFile int `json:"skippedInvalidOneNoteFile"`
 }
+
+// ItemConcurrency summarizes the item fetch concurrency achieved while
+// streaming a single collection.
+type ItemConcurrency struct {
+	Collection string `json:"collection"`
+	// Limit is the configured maximum number of concurrent item fetches.
+	Limit       int   `json:"limit"`
+	MaxInFlight int64 `json:"maxInFlight"`
+	Acquires    int64 `json:"acquires"`
+	// AvgAcquireWait is the average time spent waiting for a fetch slot.
+	AvgAcquireWait time.Duration `json:"avgAcquireWait"`
+}
+
+// ConcurrencySampler tracks the number of in-flight item fetches, and the
+// time spent waiting to start each one.  All methods are safe for
+// concurrent use.
+type ConcurrencySampler struct {
+	limit       int
+	inFlight    int64
+	maxInFlight int64
+	acquires    int64
+	waitNanos   int64
+}
+
+func NewConcurrencySampler(limit int) *ConcurrencySampler {
+	return &ConcurrencySampler{limit: limit}
+}
+
+// Acquired records the start of a fetch that waited for the given
+// duration to obtain its slot.
+func (cs *ConcurrencySampler) Acquired(waited time.Duration) {
+	n := atomic.AddInt64(&cs.inFlight, 1)
+
+	for {
+		prev := atomic.LoadInt64(&cs.maxInFlight)
+		if n <= prev || atomic.CompareAndSwapInt64(&cs.maxInFlight, prev, n) {
+			break
+		}
+	}
+
+	atomic.AddInt64(&cs.acquires, 1)
+	atomic.AddInt64(&cs.waitNanos, int64(waited))
+}
+
+// Released records the end of a fetch.  It must be called before the
+// fetch's slot is handed back, so that the in-flight count never runs
+// ahead of the limiter.
+func (cs *ConcurrencySampler) Released() {
+	atomic.AddInt64(&cs.inFlight, -1)
+}
+
+// Summary reports the samples recorded so far for the collection.
+func (cs *ConcurrencySampler) Summary(collection string) ItemConcurrency {
+	ic := ItemConcurrency{
+		Collection:  collection,
+		Limit:       cs.limit,
+		MaxInFlight: atomic.LoadInt64(&cs.maxInFlight),
+		Acquires:    atomic.LoadInt64(&cs.acquires),
+	}
+
+	if ic.Acquires > 0 {
+		ic.AvgAcquireWait = time.Duration(atomic.LoadInt64(&cs.waitNanos) / ic.Acquires)
+	}
+
+	return ic
+}
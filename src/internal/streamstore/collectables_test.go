@@ -1,7 +1,10 @@
 package streamstore
 
 import (
+	"bytes"
 	"context"
+	"io"
+	"strconv"
 	"testing"
 
 	"github.com/alcionai/clues"
@@ -10,6 +13,7 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"github.com/alcionai/corso/src/internal/data"
+	dataMock "github.com/alcionai/corso/src/internal/data/mock"
 	"github.com/alcionai/corso/src/internal/kopia"
 	"github.com/alcionai/corso/src/internal/tester"
 	"github.com/alcionai/corso/src/pkg/backup/details"
@@ -235,3 +239,180 @@ func (suite *StreamStoreIntgSuite) TestStreamer() {
 		})
 	}
 }
+
+type StreamStoreUnitSuite struct {
+	tester.Suite
+}
+
+func TestStreamStoreUnitSuite(t *testing.T) {
+	suite.Run(t, &StreamStoreUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *StreamStoreUnitSuite) TestCollect_streamedDetails() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	const entryCount = 100000
+
+	deetsBuilder := &details.Builder{}
+
+	for i := 0; i < entryCount; i++ {
+		p, err := path.Build(
+			"tenant-id",
+			"user-id",
+			path.ExchangeService,
+			path.EmailCategory,
+			true,
+			"Inbox",
+			"item-"+strconv.Itoa(i))
+		require.NoError(t, err, clues.ToCore(err))
+
+		err = deetsBuilder.Add(
+			p,
+			path.Builder{}.Append("Inbox"),
+			details.ItemInfo{
+				Exchange: &details.ExchangeInfo{
+					ItemType: details.ExchangeMail,
+					Subject:  "subject " + strconv.Itoa(i),
+				},
+			})
+		require.NoError(t, err, clues.ToCore(err))
+	}
+
+	deets := deetsBuilder.Details()
+
+	dc, err := collect(ctx, "tenant", path.ExchangeService, DetailsCollector(deets))
+	require.NoError(t, err, clues.ToCore(err))
+
+	var items []data.Item
+
+	for item := range dc.Items(ctx, fault.New(true)) {
+		items = append(items, item)
+	}
+
+	require.Len(t, items, 1)
+
+	var result details.Details
+
+	err = details.UnmarshalTo(&result)(items[0].ToReader())
+	require.NoError(t, err, clues.ToCore(err))
+
+	require.Len(t, result.Entries, len(deets.Entries))
+	assert.Equal(t, deets.Entries, result.Entries)
+}
+
+// mockRestoreProducer produces a single collection holding the items
+// persisted by the collections.
+type mockRestoreProducer struct {
+	dcs []data.BackupCollection
+}
+
+func (mrp mockRestoreProducer) ProduceRestoreCollections(
+	ctx context.Context,
+	_ string,
+	paths []path.RestorePaths,
+	_ kopia.ByteCounter,
+	errs *fault.Bus,
+) ([]data.RestoreCollection, error) {
+	coll := dataMock.Collection{Path: paths[0].RestorePath}
+
+	for _, dc := range mrp.dcs {
+		for item := range dc.Items(ctx, errs) {
+			bs, err := io.ReadAll(item.ToReader())
+			if err != nil {
+				return nil, err
+			}
+
+			coll.ItemData = append(coll.ItemData, &dataMock.Item{
+				ItemID: item.ID(),
+				Reader: io.NopCloser(bytes.NewReader(bs)),
+			})
+		}
+	}
+
+	return []data.RestoreCollection{coll}, nil
+}
+
+func (suite *StreamStoreUnitSuite) TestReadCompressedDetails() {
+	deetsPath, err := path.FromDataLayerPath("tenant-id/exchange/user-id/email/Inbox/folder1/foo", true)
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	table := []struct {
+		name         string
+		mr           func(*details.Details) Marshaller
+		expectHeader assert.BoolAssertionFunc
+	}{
+		{
+			name:         "legacy",
+			mr:           func(d *details.Details) Marshaller { return d },
+			expectHeader: assert.False,
+		},
+		{
+			name:         "compressed",
+			mr:           func(d *details.Details) Marshaller { return Compressed(d) },
+			expectHeader: assert.True,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			deetsBuilder := &details.Builder{}
+
+			for i := 0; i < 1000; i++ {
+				err := deetsBuilder.Add(
+					deetsPath,
+					path.Builder{}.Append(deetsPath.Folders()...),
+					details.ItemInfo{
+						Exchange: &details.ExchangeInfo{
+							ItemType: details.ExchangeMail,
+							Subject:  "subject " + strconv.Itoa(i),
+						},
+					})
+				require.NoError(t, err, clues.ToCore(err))
+			}
+
+			deets := deetsBuilder.Details()
+
+			dc, err := collect(ctx, "tenant", path.ExchangeService, DetailsCollector(test.mr(deets)))
+			require.NoError(t, err, clues.ToCore(err))
+
+			mrp := mockRestoreProducer{dcs: []data.BackupCollection{dc}}
+
+			// check what gets persisted.
+			colls, err := mrp.ProduceRestoreCollections(
+				ctx,
+				"",
+				[]path.RestorePaths{{RestorePath: deetsPath}},
+				nil,
+				fault.New(true))
+			require.NoError(t, err, clues.ToCore(err))
+
+			for item := range colls[0].Items(ctx, fault.New(true)) {
+				bs, err := io.ReadAll(item.ToReader())
+				require.NoError(t, err, clues.ToCore(err))
+
+				test.expectHeader(t, bytes.HasPrefix(bs, gzipHeader), "gzip header")
+			}
+
+			var result details.Details
+
+			err = read(
+				ctx,
+				"snapshot-id",
+				"tenant",
+				path.ExchangeService,
+				DetailsReader(details.UnmarshalTo(&result)),
+				mrp,
+				fault.New(true))
+			require.NoError(t, err, clues.ToCore(err))
+
+			assert.Equal(t, deets.Entries, result.Entries)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package streamstore
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+
+	"github.com/alcionai/clues"
+)
+
+// gzipHeader is the magic number that prefixes every gzip payload.  Payloads
+// written before compression was supported are json, and never start with it.
+var gzipHeader = []byte{0x1f, 0x8b}
+
+var _ StreamMarshaller = compressedMarshaller{}
+
+// compressedMarshaller gzips the bytes produced by the wrapped marshaller.
+type compressedMarshaller struct {
+	mr Marshaller
+}
+
+// Compressed wraps the marshaller so that its bytes get gzip compressed
+// before they're persisted.  Reads detect the compression and decompress
+// the bytes before handing them to the unmarshaller.
+func Compressed(mr Marshaller) Marshaller {
+	return compressedMarshaller{mr: mr}
+}
+
+func (cm compressedMarshaller) Marshal() ([]byte, error) {
+	buf := &bytes.Buffer{}
+
+	if err := cm.MarshalTo(buf); err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (cm compressedMarshaller) MarshalTo(w io.Writer) error {
+	gw := gzip.NewWriter(w)
+
+	if sm, ok := cm.mr.(StreamMarshaller); ok {
+		if err := sm.MarshalTo(gw); err != nil {
+			return clues.Wrap(err, "marshalling compressed body")
+		}
+	} else {
+		bs, err := cm.mr.Marshal()
+		if err != nil {
+			return clues.Wrap(err, "marshalling compressed body")
+		}
+
+		if _, err := gw.Write(bs); err != nil {
+			return clues.Wrap(err, "compressing body")
+		}
+	}
+
+	return clues.Wrap(gw.Close(), "compressing body").OrNil()
+}
+
+type readCloser struct {
+	io.Reader
+	close func() error
+}
+
+func (rc readCloser) Close() error {
+	return rc.close()
+}
+
+// decompressed produces a reader of the uncompressed bytes in rc.  Bytes
+// without the gzip header are read as-is.
+func decompressed(rc io.ReadCloser) (io.ReadCloser, error) {
+	br := bufio.NewReader(rc)
+
+	hdr, err := br.Peek(len(gzipHeader))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, clues.Wrap(err, "reading body header")
+	}
+
+	if !bytes.Equal(hdr, gzipHeader) {
+		return readCloser{Reader: br, close: rc.Close}, nil
+	}
+
+	gr, err := gzip.NewReader(br)
+	if err != nil {
+		return nil, clues.Wrap(err, "decompressing body")
+	}
+
+	closeBoth := func() error {
+		err := gr.Close()
+
+		if cerr := rc.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+
+		return clues.Stack(err).OrNil()
+	}
+
+	return readCloser{Reader: gr, close: closeBoth}, nil
+}
@@ -36,11 +36,17 @@ func (ms Streamer) Read(
 
 	var mr streamstore.Marshaller
 
+	// only assign non-nil values, since a nil pointer in the interface
+	// wouldn't compare as a nil marshaller.
 	switch col.Type {
 	case streamstore.DetailsType:
-		mr = ms.Deets[snapshotID]
+		if d := ms.Deets[snapshotID]; d != nil {
+			mr = d
+		}
 	case streamstore.FaultErrorsType:
-		mr = ms.Errors[snapshotID]
+		if fe := ms.Errors[snapshotID]; fe != nil {
+			mr = fe
+		}
 	default:
 		return clues.New("unknown type: " + col.Type).WithClues(ctx)
 	}
@@ -46,8 +46,9 @@ func NewStreamer(
 }
 
 // Collect eagerly searializes the marshalable bytes in the collectable into a
-// data.BackupCollection.  The collection is stored within the storeStreamer
-// for persistence when Write is called.
+// data.BackupCollection.  Stream marshallers are the exception: their bytes
+// get produced while Write persists the collection.  The collection is stored
+// within the storeStreamer for persistence when Write is called.
 func (ss *storeStreamer) Collect(ctx context.Context, col Collectable) error {
 	cs, err := collect(ctx, ss.tenant, ss.service, col)
 	if err != nil {
@@ -115,6 +116,12 @@ type Marshaller interface {
 	Marshal() ([]byte, error)
 }
 
+// StreamMarshallers write their bytes directly into the store, instead of
+// producing them all at once.  Preferred over Marshal when both are supported.
+type StreamMarshaller interface {
+	MarshalTo(io.Writer) error
+}
+
 // Unmarshallers are used to serialize the bytes in the store into the original struct.
 type Unmarshaller func(io.ReadCloser) error
 
@@ -164,6 +171,9 @@ func (dc *streamCollection) Items(context.Context, *fault.Bus) <-chan data.Item
 type streamItem struct {
 	name string
 	data []byte
+	// if populated, the item's bytes are streamed from marshalTo
+	// instead of data.
+	marshalTo func(io.Writer) error
 }
 
 func (di *streamItem) ID() string {
@@ -171,7 +181,17 @@ func (di *streamItem) ID() string {
 }
 
 func (di *streamItem) ToReader() io.ReadCloser {
-	return io.NopCloser(bytes.NewReader(di.data))
+	if di.marshalTo == nil {
+		return io.NopCloser(bytes.NewReader(di.data))
+	}
+
+	pr, pw := io.Pipe()
+
+	go func() {
+		pw.CloseWithError(di.marshalTo(pw))
+	}()
+
+	return pr
 }
 
 func (di *streamItem) Deleted() bool {
@@ -195,8 +215,18 @@ func collect(
 		return nil, clues.Stack(err).WithClues(ctx)
 	}
 
-	// TODO: We could use an io.Pipe here to avoid a double copy but that
-	// makes error handling a bit complicated
+	if sm, ok := col.mr.(StreamMarshaller); ok {
+		dc := streamCollection{
+			folderPath: p,
+			item: &streamItem{
+				name:      col.itemName,
+				marshalTo: sm.MarshalTo,
+			},
+		}
+
+		return &dc, nil
+	}
+
 	bs, err := col.mr.Marshal()
 	if err != nil {
 		return nil, clues.Wrap(err, "marshalling body").WithClues(ctx)
@@ -305,7 +335,12 @@ func read(
 				return nil
 			}
 
-			if err := col.Unmr(itemData.ToReader()); err != nil {
+			rc, err := decompressed(itemData.ToReader())
+			if err != nil {
+				return clues.Stack(err).WithClues(ctx)
+			}
+
+			if err := col.Unmr(rc); err != nil {
 				return clues.Wrap(err, "unmarshalling data").WithClues(ctx)
 			}
 
@@ -2,6 +2,7 @@ package account
 
 import (
 	"github.com/alcionai/clues"
+	"golang.org/x/exp/maps"
 
 	"github.com/alcionai/corso/src/pkg/credentials"
 )
@@ -57,6 +58,48 @@ func (a Account) M365Config() (M365Config, error) {
 	return c, c.validate()
 }
 
+// ResolveCredentials populates any missing credentials using the provider,
+// falling back to env vars.  A nil provider only checks the environment.
+func (c M365Config) ResolveCredentials(p credentials.Provider) (M365Config, error) {
+	m365, err := c.M365.Resolve(p)
+	if err != nil {
+		return c, clues.Wrap(err, "resolving m365 credentials")
+	}
+
+	c.M365 = m365
+
+	return c, nil
+}
+
+// ResolveCredentials returns a copy of the account with any credentials
+// missing from its config populated using the provider, falling back to
+// env vars.  A nil provider only checks the environment.
+func (a Account) ResolveCredentials(p credentials.Provider) (Account, error) {
+	if a.Provider != ProviderM365 {
+		return a, nil
+	}
+
+	cfg := maps.Clone(a.Config)
+	if cfg == nil {
+		cfg = map[string]string{}
+	}
+
+	c := M365Config{}
+	c.AzureClientID = cfg[keyAzureClientID]
+	c.AzureClientSecret = cfg[keyAzureClientSecret]
+
+	c, err := c.ResolveCredentials(p)
+	if err != nil {
+		return a, err
+	}
+
+	cfg[keyAzureClientID] = c.AzureClientID
+	cfg[keyAzureClientSecret] = c.AzureClientSecret
+	a.Config = cfg
+
+	return a, nil
+}
+
 func (c M365Config) validate() error {
 	check := map[string]string{
 		credentials.AzureClientID:     c.AzureClientID,
@@ -125,3 +125,50 @@ func (suite *M365CfgSuite) TestAccount_M365Config_InvalidCases() {
 		})
 	}
 }
+
+type secretProvider map[string]string
+
+func (sp secretProvider) Get(key string) (string, error) {
+	return sp[key], nil
+}
+
+func (suite *M365CfgSuite) TestM365Config_ResolveCredentials() {
+	t := suite.T()
+
+	t.Setenv(credentials.AzureClientID, "env-id")
+	t.Setenv(credentials.AzureClientSecret, "env-secret")
+
+	c, err := account.M365Config{AzureTenantID: "tid"}.ResolveCredentials(secretProvider{
+		credentials.AzureClientSecret: "provided-secret",
+	})
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, "env-id", c.AzureClientID)
+	assert.Equal(t, "provided-secret", c.AzureClientSecret)
+	assert.Equal(t, "tid", c.AzureTenantID)
+}
+
+func (suite *M365CfgSuite) TestAccount_ResolveCredentials() {
+	t := suite.T()
+
+	t.Setenv(credentials.AzureClientID, "env-id")
+	t.Setenv(credentials.AzureClientSecret, "env-secret")
+
+	acct := account.Account{
+		Provider: account.ProviderM365,
+		Config:   map[string]string{account.AzureTenantIDKey: "tid"},
+	}
+
+	resolved, err := acct.ResolveCredentials(secretProvider{
+		credentials.AzureClientSecret: "provided-secret",
+	})
+	require.NoError(t, err, clues.ToCore(err))
+
+	c, err := resolved.M365Config()
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, "env-id", c.AzureClientID)
+	assert.Equal(t, "provided-secret", c.AzureClientSecret)
+	assert.Equal(t, "tid", c.AzureTenantID)
+
+	_, err = acct.M365Config()
+	assert.Error(t, err, "original account is unchanged")
+}
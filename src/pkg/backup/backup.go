@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/dustin/go-humanize"
+	"golang.org/x/exp/maps"
 
 	"github.com/alcionai/corso/src/cli/print"
 	"github.com/alcionai/corso/src/internal/common/dttm"
@@ -52,6 +53,44 @@ type Backup struct {
 	// the non-recoverable failure message, only populated if one occurred.
 	Failure string `json:"failure"`
 
+	// TotalItemBytes is the sum of the sizes of all items in the backup
+	// details, excluding metadata files.  Backups created before this value
+	// was recorded will hold a zero, and need to compute it from their details.
+	TotalItemBytes int64 `json:"totalItemBytes,omitempty"`
+
+	// Pinned backups are protected from deletion unless the deletion is
+	// forced, such as to keep a known-good backup safe from cleanup.
+	Pinned bool `json:"pinned,omitempty"`
+
+	// DeltaReset backups don't supply their delta tokens, or any other
+	// metadata, to later backups.  The next backup of the resource runs as
+	// a full backup.
+	DeltaReset bool `json:"deltaReset,omitempty"`
+
+	// BackupGroupID is shared by the backups produced by an operation that
+	// split its backup into one backup per service category.  Empty for
+	// backups that weren't split.
+	BackupGroupID string `json:"backupGroupID,omitempty"`
+
+	// CreatedByUser and CreatedByHost identify the repository user and host
+	// that ran the backup.  Empty if the repository connection didn't
+	// specify them, and in backups created before they were recorded.
+	CreatedByUser string `json:"createdByUser,omitempty"`
+	CreatedByHost string `json:"createdByHost,omitempty"`
+
+	// DetailsSignature is the hex encoded HMAC of the serialized backup
+	// details, keyed by the repository passphrase.  Used to detect details
+	// that were altered after the backup completed.  Empty in backups
+	// created before details were signed.
+	DetailsSignature string `json:"detailsSignature,omitempty"`
+
+	// MergeBaseIDs and AssistBaseIDs identify the backups whose snapshots
+	// this backup was built from.  Unchanged items were carried over from the
+	// merge bases, while assist bases only supplied cached item data.  Empty
+	// for full backups, and in backups created before bases were recorded.
+	MergeBaseIDs  []model.StableID `json:"mergeBaseIDs,omitempty"`
+	AssistBaseIDs []model.StableID `json:"assistBaseIDs,omitempty"`
+
 	// stats are embedded so that the values appear as top-level properties
 	stats.ReadWrites
 	stats.StartAndEndTime
@@ -105,17 +144,24 @@ func New(
 			malware++
 		case s.HasCause(fault.SkipNotFound):
 			notFound++
-		case s.HasCause(fault.SkipBigOneNote):
+		case s.HasCause(fault.SkipBigOneNote), s.HasCause(fault.SkipOneNote):
 			invalidONFile++
 		default:
 			otherSkips++
 		}
 	}
 
+	bTags := maps.Clone(tags)
+	if bTags == nil {
+		bTags = map[string]string{}
+	}
+
+	bTags[model.BackupVersionTag] = strconv.Itoa(version)
+
 	return &Backup{
 		BaseModel: model.BaseModel{
 			ID:   id,
-			Tags: tags,
+			Tags: bTags,
 		},
 
 		ResourceOwnerID:   ownerID,
@@ -145,6 +191,56 @@ func New(
 	}
 }
 
+// LatestByResource reduces the backups to the most recently created backup
+// of each protected resource.  The remaining backups keep their relative
+// order.
+func LatestByResource(bs []*Backup) []*Backup {
+	latest := map[string]*Backup{}
+
+	for _, b := range bs {
+		owner := b.Selector.DiscreteOwner
+
+		if l, ok := latest[owner]; !ok || b.CreationTime.After(l.CreationTime) {
+			latest[owner] = b
+		}
+	}
+
+	res := make([]*Backup, 0, len(latest))
+
+	for _, b := range bs {
+		if latest[b.Selector.DiscreteOwner] == b {
+			res = append(res, b)
+		}
+	}
+
+	return res
+}
+
+// LineageRelation describes how a backup in a lineage relates to the
+// backup that was built from it.
+type LineageRelation string
+
+const (
+	// LineageRoot is the backup whose lineage was requested.
+	LineageRoot       LineageRelation = "root"
+	LineageMergeBase  LineageRelation = "merge base"
+	LineageAssistBase LineageRelation = "assist base"
+)
+
+// LineageEntry is a single backup in the chain of bases behind a backup.
+type LineageEntry struct {
+	ID model.StableID `json:"id"`
+	// Depth counts the bases between this backup and the root.  The root
+	// has a depth of 0, and its bases a depth of 1.
+	Depth    int             `json:"depth"`
+	Relation LineageRelation `json:"relation"`
+	// BaseOf is the id of the backup that was built from this one.  Empty
+	// for the root.
+	BaseOf model.StableID `json:"baseOf,omitempty"`
+	// Backup is nil if the base no longer exists in the repository.
+	Backup *Backup `json:"-"`
+}
+
 // --------------------------------------------------------------------------------
 // CLI Output
 // --------------------------------------------------------------------------------
@@ -171,6 +267,51 @@ func PrintAll(ctx context.Context, bs []*Backup) {
 	print.All(ctx, ps...)
 }
 
+// ----- print lineage
+
+// interface compliance checks
+var _ print.Printable = LineageEntry{}
+
+// PrintLineage writes the lineage entries to StdOut, in the format
+// requested by the caller.
+func PrintLineage(ctx context.Context, les []LineageEntry) {
+	ps := make([]print.Printable, 0, len(les))
+	for _, le := range les {
+		ps = append(ps, le)
+	}
+
+	print.All(ctx, ps...)
+}
+
+// MinimumPrintable reduces the LineageEntry to its minimally printable
+// details.
+func (le LineageEntry) MinimumPrintable() any {
+	return le
+}
+
+// Headers returns the human-readable names of properties in a LineageEntry
+// for printing out to a terminal in a columnar display.
+func (le LineageEntry) Headers() []string {
+	return []string{"ID", "Relation", "Base Of", "Started At", "Status"}
+}
+
+// Values returns the values matching the Headers list for printing
+// out to a terminal in a columnar display.
+func (le LineageEntry) Values() []string {
+	var (
+		id      = strings.Repeat("  ", le.Depth) + string(le.ID)
+		started string
+		status  = "deleted"
+	)
+
+	if le.Backup != nil {
+		started = dttm.FormatToTabularDisplay(le.Backup.StartedAt)
+		status = le.Backup.Status
+	}
+
+	return []string{id, string(le.Relation), string(le.BaseOf), started, status}
+}
+
 type Printable struct {
 	ID                    model.StableID `json:"id"`
 	Status                string         `json:"status"`
@@ -178,6 +319,8 @@ type Printable struct {
 	ProtectedResourceID   string         `json:"protectedResourceID,omitempty"`
 	ProtectedResourceName string         `json:"protectedResourceName,omitempty"`
 	Owner                 string         `json:"owner,omitempty"`
+	CreatedBy             string         `json:"createdBy,omitempty"`
+	TotalItemBytes        int64          `json:"totalItemBytes"`
 	Stats                 backupStats    `json:"stats"`
 }
 
@@ -190,10 +333,25 @@ func (b Backup) ToPrintable() Printable {
 		ProtectedResourceID:   b.Selector.DiscreteOwner,
 		ProtectedResourceName: b.Selector.DiscreteOwnerName,
 		Owner:                 b.Selector.DiscreteOwner,
+		CreatedBy:             b.CreatedBy(),
+		TotalItemBytes:        b.TotalItemBytes,
 		Stats:                 b.toStats(),
 	}
 }
 
+// CreatedBy describes the user and host that ran the backup as user@host,
+// or whichever of the two is known.  Empty if neither was recorded.
+func (b Backup) CreatedBy() string {
+	switch {
+	case len(b.CreatedByUser) > 0 && len(b.CreatedByHost) > 0:
+		return b.CreatedByUser + "@" + b.CreatedByHost
+	case len(b.CreatedByUser) > 0:
+		return b.CreatedByUser
+	default:
+		return b.CreatedByHost
+	}
+}
+
 // MinimumPrintable reduces the Backup to its minimally printable details.
 func (b Backup) MinimumPrintable() any {
 	return b.ToPrintable()
@@ -208,6 +366,8 @@ func (b Backup) Headers() []string {
 		"Duration",
 		"Status",
 		"Resource Owner",
+		"Size",
+		"Created By",
 	}
 }
 
@@ -274,6 +434,8 @@ func (b Backup) Values() []string {
 		bs.EndedAt.Sub(bs.StartedAt).String(),
 		status,
 		name,
+		humanize.Bytes(uint64(b.TotalItemBytes)),
+		b.CreatedBy(),
 	}
 }
 
@@ -284,6 +446,7 @@ func (b Backup) toStats() backupStats {
 		ID:            string(b.ID),
 		BytesRead:     b.BytesRead,
 		BytesUploaded: b.NonMetaBytesUploaded,
+		Compression:   b.CompressionRatio,
 		EndedAt:       b.CompletedAt,
 		ErrorCount:    b.ErrorCount,
 		ItemsRead:     b.ItemsRead,
@@ -300,6 +463,7 @@ type backupStats struct {
 	ID            string    `json:"id"`
 	BytesRead     int64     `json:"bytesRead"`
 	BytesUploaded int64     `json:"bytesUploaded"`
+	Compression   float64   `json:"compressionRatio"`
 	EndedAt       time.Time `json:"endedAt"`
 	ErrorCount    int       `json:"errorCount"`
 	ItemsRead     int       `json:"itemsRead"`
@@ -324,6 +488,7 @@ func (bs backupStats) Headers() []string {
 	return []string{
 		"ID",
 		"Bytes Uploaded",
+		"Compression Ratio",
 		"Items Uploaded",
 		"Items Skipped",
 		"Errors",
@@ -336,6 +501,7 @@ func (bs backupStats) Values() []string {
 	return []string{
 		bs.ID,
 		humanize.Bytes(uint64(bs.BytesUploaded)),
+		strconv.FormatFloat(bs.Compression, 'f', 2, 64),
 		strconv.Itoa(bs.ItemsWritten),
 		strconv.Itoa(bs.ItemsSkipped),
 		strconv.Itoa(bs.ErrorCount),
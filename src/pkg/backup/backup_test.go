@@ -48,6 +48,9 @@ func stubBackup(t time.Time, ownerID, ownerName string) backup.Backup {
 		Selector:              sel.Selector,
 		ErrorCount:            2,
 		Failure:               "read, write",
+		TotalItemBytes:        2048,
+		CreatedByUser:         "operator",
+		CreatedByHost:         "host",
 		ReadWrites: stats.ReadWrites{
 			BytesRead:            301,
 			BytesUploaded:        301,
@@ -55,6 +58,7 @@ func stubBackup(t time.Time, ownerID, ownerName string) backup.Backup {
 			ItemsRead:            1,
 			NonMetaItemsWritten:  1,
 			ItemsWritten:         1,
+			CompressionRatio:     0.25,
 		},
 		StartAndEndTime: stats.StartAndEndTime{
 			StartedAt:   t,
@@ -79,6 +83,8 @@ func (suite *BackupUnitSuite) TestBackup_HeadersValues() {
 			"Duration",
 			"Status",
 			"Resource Owner",
+			"Size",
+			"Created By",
 		}
 		nowFmt   = dttm.FormatToTabularDisplay(now)
 		expectVs = []string{
@@ -87,6 +93,8 @@ func (suite *BackupUnitSuite) TestBackup_HeadersValues() {
 			"1m0s",
 			"status (2 errors, 1 skipped: 1 malware)",
 			"name-pr",
+			"2.0 kB",
+			"operator@host",
 		}
 	)
 
@@ -112,6 +120,8 @@ func (suite *BackupUnitSuite) TestBackup_HeadersValues_onlyResourceOwners() {
 			"Duration",
 			"Status",
 			"Resource Owner",
+			"Size",
+			"Created By",
 		}
 		nowFmt   = dttm.FormatToTabularDisplay(now)
 		expectVs = []string{
@@ -120,6 +130,8 @@ func (suite *BackupUnitSuite) TestBackup_HeadersValues_onlyResourceOwners() {
 			"1m0s",
 			"status (2 errors, 1 skipped: 1 malware)",
 			"name-ro",
+			"2.0 kB",
+			"operator@host",
 		}
 	)
 
@@ -252,6 +264,90 @@ func (suite *BackupUnitSuite) TestBackup_MinimumPrintable() {
 	assert.Equal(t, b.BytesRead, result.Stats.BytesRead, "size")
 	assert.Equal(t, b.NonMetaBytesUploaded, result.Stats.BytesUploaded, "stored size")
 	assert.Equal(t, b.Selector.DiscreteOwner, result.Owner, "owner")
+	assert.Equal(t, b.TotalItemBytes, result.TotalItemBytes, "total item bytes")
+	assert.Equal(t, "operator@host", result.CreatedBy, "created by")
+}
+
+func (suite *BackupUnitSuite) TestBackup_CreatedBy() {
+	table := []struct {
+		name   string
+		user   string
+		host   string
+		expect string
+	}{
+		{
+			name:   "user and host",
+			user:   "operator",
+			host:   "host",
+			expect: "operator@host",
+		},
+		{
+			name:   "only user",
+			user:   "operator",
+			expect: "operator",
+		},
+		{
+			name:   "only host",
+			host:   "host",
+			expect: "host",
+		},
+		{
+			name:   "unset",
+			expect: "",
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			b := stubBackup(time.Now(), "id", "name")
+			b.CreatedByUser = test.user
+			b.CreatedByHost = test.host
+
+			assert.Equal(t, test.expect, b.CreatedBy())
+
+			vs := b.Values()
+			assert.Equal(t, test.expect, vs[len(vs)-1], "listed created by")
+		})
+	}
+}
+
+func (suite *BackupUnitSuite) TestNew_versionTag() {
+	t := suite.T()
+
+	var (
+		sel  = selectors.NewExchangeBackup([]string{"test"})
+		tags = map[string]string{model.ServiceTag: sel.PathService().String()}
+	)
+
+	b := backup.New(
+		"snapshot", "streamstore", "status",
+		7,
+		"id",
+		sel.Selector,
+		"owner", "ownername",
+		stats.ReadWrites{},
+		stats.StartAndEndTime{},
+		nil,
+		tags)
+
+	assert.Equal(t, 7, b.Version)
+	assert.Equal(t, "7", b.Tags[model.BackupVersionTag])
+	assert.Equal(t, sel.PathService().String(), b.Tags[model.ServiceTag])
+	assert.NotContains(t, tags, model.BackupVersionTag, "caller's tags are not mutated")
+
+	b = backup.New(
+		"snapshot", "streamstore", "status",
+		3,
+		"id",
+		sel.Selector,
+		"owner", "ownername",
+		stats.ReadWrites{},
+		stats.StartAndEndTime{},
+		nil,
+		nil)
+
+	assert.Equal(t, map[string]string{model.BackupVersionTag: "3"}, b.Tags)
 }
 
 func (suite *BackupUnitSuite) TestStats() {
@@ -264,6 +360,7 @@ func (suite *BackupUnitSuite) TestStats() {
 
 	assert.Equal(t, b.BytesRead, s.BytesRead, "bytes read")
 	assert.Equal(t, b.BytesUploaded, s.BytesUploaded, "bytes uploaded")
+	assert.Equal(t, b.CompressionRatio, s.Compression, "compression ratio")
 	assert.Equal(t, b.CompletedAt, s.EndedAt, "completion time")
 	assert.Equal(t, b.ErrorCount, s.ErrorCount, "error count")
 	assert.Equal(t, b.ItemsRead, s.ItemsRead, "items read")
@@ -283,6 +380,7 @@ func (suite *BackupUnitSuite) TestStats_headersValues() {
 	expectHeaders := []string{
 		"ID",
 		"Bytes Uploaded",
+		"Compression Ratio",
 		"Items Uploaded",
 		"Items Skipped",
 		"Errors",
@@ -293,6 +391,7 @@ func (suite *BackupUnitSuite) TestStats_headersValues() {
 	expectValues := []string{
 		"id",
 		humanize.Bytes(uint64(b.BytesUploaded)),
+		"0.25",
 		strconv.Itoa(b.ItemsWritten),
 		strconv.Itoa(b.TotalSkippedItems),
 		strconv.Itoa(b.ErrorCount),
@@ -300,3 +399,96 @@ func (suite *BackupUnitSuite) TestStats_headersValues() {
 
 	assert.Equal(t, expectValues, s.Values())
 }
+
+func (suite *BackupUnitSuite) TestLatestByResource() {
+	var (
+		now       = time.Now()
+		newBackup = func(id, owner string, created time.Time) *backup.Backup {
+			return &backup.Backup{
+				BaseModel:    model.BaseModel{ID: model.StableID(id)},
+				CreationTime: created,
+				Selector:     selectors.NewExchangeBackup([]string{owner}).Selector,
+			}
+		}
+		user1Old = newBackup("u1-old", "user1", now.Add(-2*time.Hour))
+		user1New = newBackup("u1-new", "user1", now)
+		user1Mid = newBackup("u1-mid", "user1", now.Add(-time.Hour))
+		user2Old = newBackup("u2-old", "user2", now.Add(-3*time.Hour))
+		user2New = newBackup("u2-new", "user2", now.Add(-time.Minute))
+	)
+
+	table := []struct {
+		name   string
+		input  []*backup.Backup
+		expect []*backup.Backup
+	}{
+		{
+			name:   "no backups",
+			input:  []*backup.Backup{},
+			expect: []*backup.Backup{},
+		},
+		{
+			name:   "single resource",
+			input:  []*backup.Backup{user1Old, user1New, user1Mid},
+			expect: []*backup.Backup{user1New},
+		},
+		{
+			name:   "multiple resources keep their order",
+			input:  []*backup.Backup{user2Old, user1Old, user2New, user1Mid},
+			expect: []*backup.Backup{user2New, user1Mid},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			assert.Equal(suite.T(), test.expect, backup.LatestByResource(test.input))
+		})
+	}
+}
+
+func (suite *BackupUnitSuite) TestLineageEntry_HeadersValues() {
+	var (
+		now = time.Now()
+		b   = stubBackup(now, "base", "name")
+	)
+
+	table := []struct {
+		name     string
+		entry    backup.LineageEntry
+		expectVs []string
+	}{
+		{
+			name:     "root",
+			entry:    backup.LineageEntry{ID: "id", Relation: backup.LineageRoot, Backup: &b},
+			expectVs: []string{"id", "root", "", dttm.FormatToTabularDisplay(now), "status"},
+		},
+		{
+			name: "nested base",
+			entry: backup.LineageEntry{
+				ID:       "base",
+				Depth:    2,
+				Relation: backup.LineageMergeBase,
+				BaseOf:   "incr",
+				Backup:   &b,
+			},
+			expectVs: []string{"    base", "merge base", "incr", dttm.FormatToTabularDisplay(now), "status"},
+		},
+		{
+			name: "deleted base",
+			entry: backup.LineageEntry{
+				ID:       "gone",
+				Depth:    1,
+				Relation: backup.LineageAssistBase,
+				BaseOf:   "id",
+			},
+			expectVs: []string{"  gone", "assist base", "id", "", "deleted"},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			assert.Equal(t, []string{"ID", "Relation", "Base Of", "Started At", "Status"}, test.entry.Headers())
+			assert.Equal(t, test.expectVs, test.entry.Values())
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package details
 
 import (
+	"bufio"
 	"encoding/json"
 	"io"
 	"strings"
@@ -61,6 +62,48 @@ func (d *Details) Marshal() ([]byte, error) {
 	return json.Marshal(d)
 }
 
+// MarshalTo complies with the stream marshaller interface in streamStore.
+// Entries are encoded and written one at a time, so that large details
+// never get held in memory as a single blob.  The output matches Marshal.
+func (d *Details) MarshalTo(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	if d.Entries == nil {
+		if _, err := bw.WriteString(`{"entries":null}`); err != nil {
+			return clues.Wrap(err, "writing details")
+		}
+
+		return clues.Wrap(bw.Flush(), "flushing details").OrNil()
+	}
+
+	if _, err := bw.WriteString(`{"entries":[`); err != nil {
+		return clues.Wrap(err, "writing details")
+	}
+
+	for i, entry := range d.Entries {
+		if i > 0 {
+			if err := bw.WriteByte(','); err != nil {
+				return clues.Wrap(err, "writing details")
+			}
+		}
+
+		bs, err := json.Marshal(entry)
+		if err != nil {
+			return clues.Wrap(err, "marshalling details entry").With("entry_index", i)
+		}
+
+		if _, err := bw.Write(bs); err != nil {
+			return clues.Wrap(err, "writing details entry").With("entry_index", i)
+		}
+	}
+
+	if _, err := bw.WriteString(`]}`); err != nil {
+		return clues.Wrap(err, "writing details")
+	}
+
+	return clues.Wrap(bw.Flush(), "flushing details").OrNil()
+}
+
 // UnmarshalTo produces a func that complies with the unmarshaller type in streamStore.
 func UnmarshalTo(d *Details) func(io.ReadCloser) error {
 	return func(rc io.ReadCloser) error {
@@ -68,6 +111,99 @@ func UnmarshalTo(d *Details) func(io.ReadCloser) error {
 	}
 }
 
+// Schema versions of the payloads produced by MarshalVersioned.  Any change
+// to the details model that older payloads can't be read into as-is gets a
+// new version, along with an upgrade step in upgradeDetailsSchema.
+const (
+	// DetailsSchemaUnversioned payloads are the plain json of the details
+	// model, as produced by Marshal.
+	DetailsSchemaUnversioned = 0
+	// DetailsSchemaSharePointLibraryType marks SharePoint library items with
+	// the SharePointLibrary ItemType instead of the OneDriveItem ItemType.
+	DetailsSchemaSharePointLibraryType = 1
+
+	DetailsSchemaVersion = DetailsSchemaSharePointLibraryType
+)
+
+type versionedDetails struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	Details       json.RawMessage `json:"details"`
+}
+
+// MarshalVersioned produces the json of the details, embedded alongside the
+// schema version of the details model.  Unlike Marshal, the output can be
+// read by UnmarshalVersioned in future releases, after fields get added to or
+// changed in the details model.
+func (d *Details) MarshalVersioned() ([]byte, error) {
+	bs, err := d.Marshal()
+	if err != nil {
+		return nil, clues.Wrap(err, "marshalling details")
+	}
+
+	bs, err = json.Marshal(versionedDetails{
+		SchemaVersion: DetailsSchemaVersion,
+		Details:       bs,
+	})
+
+	return bs, clues.Wrap(err, "marshalling versioned details").OrNil()
+}
+
+// UnmarshalVersioned reads the output of MarshalVersioned into the details,
+// upgrading payloads from older schema versions to the current version.
+// Unversioned payloads, such as the output of Marshal, are accepted as the
+// oldest schema version.  Payloads from newer schema versions produce an
+// error.
+func (d *Details) UnmarshalVersioned(bs []byte) error {
+	var probe struct {
+		SchemaVersion *int            `json:"schemaVersion"`
+		Details       json.RawMessage `json:"details"`
+	}
+
+	if err := json.Unmarshal(bs, &probe); err != nil {
+		return clues.Wrap(err, "unmarshalling versioned details")
+	}
+
+	var (
+		schemaVersion = DetailsSchemaUnversioned
+		payload       = bs
+	)
+
+	if probe.SchemaVersion != nil {
+		schemaVersion = *probe.SchemaVersion
+		payload = probe.Details
+	}
+
+	if schemaVersion > DetailsSchemaVersion {
+		return clues.New("unsupported details schema version").
+			With("schema_version", schemaVersion, "max_schema_version", DetailsSchemaVersion)
+	}
+
+	var dm DetailsModel
+
+	if err := json.Unmarshal(payload, &dm); err != nil {
+		return clues.Wrap(err, "unmarshalling details").With("schema_version", schemaVersion)
+	}
+
+	upgradeDetailsSchema(&dm, schemaVersion)
+
+	d.DetailsModel = dm
+
+	return nil
+}
+
+// upgradeDetailsSchema updates, in place, details from the schema version
+// to the current schema version.
+func upgradeDetailsSchema(dm *DetailsModel, schemaVersion int) {
+	if schemaVersion < DetailsSchemaSharePointLibraryType {
+		for i := range dm.Entries {
+			spi := dm.Entries[i].SharePoint
+			if spi != nil && spi.ItemType == OneDriveItem {
+				spi.ItemType = SharePointLibrary
+			}
+		}
+	}
+}
+
 // remove metadata file suffixes from the string.
 // assumes only one suffix is applied to any given id.
 func withoutMetadataSuffix(id string) string {
@@ -903,13 +903,20 @@ func (suite *DetailsUnitSuite) TestDetailsModel_FilterMetaFiles() {
 					OneDrive: &OneDriveInfo{IsMeta: true},
 				},
 			},
+			{
+				RepoRef: "folder/.dirmeta",
+				ItemInfo: ItemInfo{
+					OneDrive: &OneDriveInfo{ItemType: OneDriveItem},
+				},
+			},
 		},
 	}
 
 	d2 := d.FilterMetaFiles()
 
 	assert.Len(t, d2.Entries, 2)
-	assert.Len(t, d.Entries, 3)
+	assert.Len(t, d.Entries, 4)
+	assert.Len(t, d.Items(), 3, "folder metadata is kept for restores")
 }
 
 func (suite *DetailsUnitSuite) TestBuilder_Add_shortRefsUniqueFromFolder() {
@@ -1247,6 +1254,41 @@ func (suite *DetailsUnitSuite) TestDetails_Marshal() {
 	}
 }
 
+func (suite *DetailsUnitSuite) TestDetails_MarshalTo() {
+	type marshalTest struct {
+		name string
+		ents []Entry
+	}
+
+	table := []marshalTest{
+		{name: "nil entries"},
+		{name: "empty entries", ents: []Entry{}},
+	}
+
+	for _, test := range pathItemsTable {
+		table = append(table, marshalTest{name: test.name, ents: test.ents})
+	}
+
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			d := &Details{DetailsModel: DetailsModel{
+				Entries: test.ents,
+			}}
+
+			expect, err := d.Marshal()
+			require.NoError(t, err, clues.ToCore(err))
+
+			buf := &bytes.Buffer{}
+
+			err = d.MarshalTo(buf)
+			require.NoError(t, err, clues.ToCore(err))
+			assert.Equal(t, string(expect), buf.String())
+		})
+	}
+}
+
 func (suite *DetailsUnitSuite) TestUnarshalTo() {
 	for _, test := range pathItemsTable {
 		suite.Run(test.name, func() {
@@ -1270,6 +1312,121 @@ func (suite *DetailsUnitSuite) TestUnarshalTo() {
 	}
 }
 
+func (suite *DetailsUnitSuite) TestDetails_MarshalVersioned() {
+	for _, test := range pathItemsTable {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			orig := &Details{DetailsModel: DetailsModel{
+				Entries: test.ents,
+			}}
+
+			bs, err := orig.MarshalVersioned()
+			require.NoError(t, err, clues.ToCore(err))
+			assert.Contains(t, string(bs), fmt.Sprintf(`"schemaVersion":%d`, DetailsSchemaVersion))
+
+			var result Details
+
+			err = result.UnmarshalVersioned(bs)
+			require.NoError(t, err, clues.ToCore(err))
+			assert.Equal(t, orig.Entries, result.Entries)
+		})
+	}
+}
+
+func (suite *DetailsUnitSuite) TestDetails_UnmarshalVersioned() {
+	const (
+		spRef    = "tenant/sharepoint/site/libraries/drives/d/root:/item"
+		exRef    = "tenant/exchange/user/email/inbox/mail"
+		oldSPEnt = `{"repoRef":"` + spRef + `","sharePoint":{"itemType":205,"itemName":"item"}}`
+		// simulates a field that was added to ExchangeInfo after the payload
+		// was produced, and another that was since removed.
+		exEnt = `{"repoRef":"` + exRef + `","exchange":{"itemType":3,"subject":"hi","removedField":"x"}}`
+	)
+
+	expectEntries := []Entry{
+		{
+			RepoRef: spRef,
+			ItemInfo: ItemInfo{SharePoint: &SharePointInfo{
+				ItemType: SharePointLibrary,
+				ItemName: "item",
+			}},
+		},
+		{
+			RepoRef: exRef,
+			ItemInfo: ItemInfo{Exchange: &ExchangeInfo{
+				ItemType: ExchangeMail,
+				Subject:  "hi",
+			}},
+		},
+	}
+
+	table := []struct {
+		name          string
+		payload       string
+		expectEntries []Entry
+		expectErr     assert.ErrorAssertionFunc
+	}{
+		{
+			name:          "unversioned",
+			payload:       `{"entries":[` + oldSPEnt + `,` + exEnt + `]}`,
+			expectEntries: expectEntries,
+			expectErr:     assert.NoError,
+		},
+		{
+			name: "unversioned schema version",
+			payload: fmt.Sprintf(
+				`{"schemaVersion":%d,"details":{"entries":[%s,%s]}}`,
+				DetailsSchemaUnversioned, oldSPEnt, exEnt),
+			expectEntries: expectEntries,
+			expectErr:     assert.NoError,
+		},
+		{
+			name: "current schema version",
+			payload: fmt.Sprintf(
+				`{"schemaVersion":%d,"details":{"entries":[%s]}}`,
+				DetailsSchemaVersion,
+				`{"repoRef":"`+spRef+`","sharePoint":{"itemType":101,"itemName":"item"}}`),
+			expectEntries: expectEntries[:1],
+			expectErr:     assert.NoError,
+		},
+		{
+			name:          "unversioned nil entries",
+			payload:       `{"entries":null}`,
+			expectEntries: nil,
+			expectErr:     assert.NoError,
+		},
+		{
+			name: "newer schema version",
+			payload: fmt.Sprintf(
+				`{"schemaVersion":%d,"details":{"entries":[]}}`,
+				DetailsSchemaVersion+1),
+			expectErr: assert.Error,
+		},
+		{
+			name:      "malformed",
+			payload:   `{"schemaVersion":`,
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			var result Details
+
+			err := result.UnmarshalVersioned([]byte(test.payload))
+			test.expectErr(t, err, clues.ToCore(err))
+
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, test.expectEntries, result.Entries)
+		})
+	}
+}
+
 func (suite *DetailsUnitSuite) TestLocationIDer_FromEntry() {
 	const (
 		rrString = "tenant-id/%s/user-id/%s/drives/drive-id/root:/some/folder/stuff/item"
@@ -1503,3 +1660,345 @@ func (suite *DetailsUnitSuite) TestLocationIDer_FromEntry() {
 		})
 	}
 }
+
+func syntheticDetailsModel(count int) DetailsModel {
+	dm := DetailsModel{Entries: make([]Entry, 0, count)}
+
+	for i := 0; i < count; i++ {
+		folder := "alpha"
+		if i%2 == 1 {
+			folder = "beta"
+		}
+
+		dm.Entries = append(dm.Entries, Entry{
+			RepoRef:     fmt.Sprintf("tenant/onedrive/user/files/drives/d/root:/%s/item%d", folder, i),
+			ShortRef:    fmt.Sprintf("short%d", i),
+			LocationRef: fmt.Sprintf("root:/%s-loc", folder),
+			ItemRef:     fmt.Sprintf("item%d", i),
+		})
+	}
+
+	return dm
+}
+
+func (suite *DetailsUnitSuite) TestDetailsModel_Window() {
+	const count = 20000
+
+	dm := syntheticDetailsModel(count)
+
+	table := []struct {
+		name        string
+		offset      int
+		limit       int
+		expectLen   int
+		expectFirst string
+	}{
+		{
+			name:        "no window",
+			expectLen:   count,
+			expectFirst: "item0",
+		},
+		{
+			name:        "limit only",
+			limit:       50,
+			expectLen:   50,
+			expectFirst: "item0",
+		},
+		{
+			name:        "offset only",
+			offset:      19990,
+			expectLen:   10,
+			expectFirst: "item19990",
+		},
+		{
+			name:        "offset and limit",
+			offset:      1000,
+			limit:       250,
+			expectLen:   250,
+			expectFirst: "item1000",
+		},
+		{
+			name:        "limit past end",
+			offset:      19995,
+			limit:       100,
+			expectLen:   5,
+			expectFirst: "item19995",
+		},
+		{
+			name:      "offset past end",
+			offset:    count + 1,
+			limit:     10,
+			expectLen: 0,
+		},
+		{
+			name:        "negative offset",
+			offset:      -5,
+			limit:       3,
+			expectLen:   3,
+			expectFirst: "item0",
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			result := dm.Window(test.offset, test.limit)
+			require.Len(t, result.Entries, test.expectLen)
+
+			if test.expectLen > 0 {
+				assert.Equal(t, test.expectFirst, result.Entries[0].ItemRef)
+			}
+		})
+	}
+}
+
+func (suite *DetailsUnitSuite) TestDetailsModel_FilterPathPrefix() {
+	const count = 20000
+
+	dm := syntheticDetailsModel(count)
+
+	table := []struct {
+		name      string
+		prefix    string
+		expectLen int
+		expectIn  string
+	}{
+		{
+			name:      "empty prefix",
+			expectLen: count,
+		},
+		{
+			name:      "repo ref prefix",
+			prefix:    "tenant/onedrive/user/files/drives/d/root:/alpha",
+			expectLen: count / 2,
+			expectIn:  "alpha",
+		},
+		{
+			name:      "location ref prefix",
+			prefix:    "root:/beta",
+			expectLen: count / 2,
+			expectIn:  "beta",
+		},
+		{
+			name:      "shared prefix",
+			prefix:    "tenant/onedrive",
+			expectLen: count,
+		},
+		{
+			name:      "no match",
+			prefix:    "nope",
+			expectLen: 0,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			result := dm.FilterPathPrefix(test.prefix)
+			require.Len(t, result.Entries, test.expectLen)
+
+			if len(test.expectIn) == 0 {
+				return
+			}
+
+			for _, ent := range result.Entries {
+				assert.Contains(t, ent.RepoRef, test.expectIn)
+			}
+		})
+	}
+}
+
+func (suite *DetailsUnitSuite) TestDetailsModel_Search() {
+	dm := DetailsModel{
+		Entries: []Entry{
+			{
+				RepoRef:     "tenant/onedrive/user/files/drives/d/root:/Reports/q1-id",
+				LocationRef: "root:/Reports",
+				ItemInfo: ItemInfo{
+					OneDrive: &OneDriveInfo{ItemType: OneDriveItem, ItemName: "Q1 Budget.xlsx"},
+				},
+			},
+			{
+				RepoRef:     "tenant/onedrive/user/files/drives/d/root:/Photos/img-id",
+				LocationRef: "root:/Photos",
+				ItemInfo: ItemInfo{
+					OneDrive: &OneDriveInfo{ItemType: OneDriveItem, ItemName: "beach.JPG"},
+				},
+			},
+			{
+				RepoRef:     "tenant/exchange/user/email/inbox/mail-id",
+				LocationRef: "Inbox",
+				ItemInfo: ItemInfo{
+					Exchange: &ExchangeInfo{ItemType: ExchangeMail, Subject: "Budget review"},
+				},
+			},
+			{
+				RepoRef:     "tenant/exchange/user/contacts/contacts/contact-id",
+				LocationRef: "Contacts",
+				ItemInfo: ItemInfo{
+					Exchange: &ExchangeInfo{ItemType: ExchangeContact, ContactName: "Jordan Budgeteer"},
+				},
+			},
+			{
+				RepoRef:     "tenant/onedrive/user/files/drives/d/root:/Archive",
+				LocationRef: "root:/Archive",
+				ItemInfo: ItemInfo{
+					Folder: &FolderInfo{ItemType: FolderItem, DisplayName: "Archive"},
+				},
+			},
+		},
+	}
+
+	table := []struct {
+		name        string
+		query       string
+		expectRefs  []string
+		expectCount int
+	}{
+		{
+			name:        "empty query",
+			expectCount: len(dm.Entries),
+		},
+		{
+			name:  "name across services, ignoring case",
+			query: "budget",
+			expectRefs: []string{
+				dm.Entries[0].RepoRef,
+				dm.Entries[2].RepoRef,
+				dm.Entries[3].RepoRef,
+			},
+		},
+		{
+			name:       "file extension",
+			query:      ".jpg",
+			expectRefs: []string{dm.Entries[1].RepoRef},
+		},
+		{
+			name:       "location path",
+			query:      "root:/photos",
+			expectRefs: []string{dm.Entries[1].RepoRef},
+		},
+		{
+			name:       "folder name",
+			query:      "archive",
+			expectRefs: []string{dm.Entries[4].RepoRef},
+		},
+		{
+			name:  "no match",
+			query: "nope",
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			result := dm.Search(test.query)
+
+			if test.expectCount > 0 {
+				assert.Len(t, result.Entries, test.expectCount)
+				return
+			}
+
+			refs := []string{}
+			for _, ent := range result.Entries {
+				refs = append(refs, ent.RepoRef)
+			}
+
+			assert.ElementsMatch(t, test.expectRefs, refs)
+		})
+	}
+}
+
+func (suite *DetailsUnitSuite) TestDetailsModel_FilterThenWindow() {
+	t := suite.T()
+	dm := syntheticDetailsModel(1000)
+
+	result := dm.FilterPathPrefix("root:/beta").Window(10, 5)
+	require.Len(t, result.Entries, 5)
+
+	// odd-numbered items land in beta, so the 11th beta item is item21.
+	assert.Equal(t, "item21", result.Entries[0].ItemRef)
+	assert.Equal(t, "item29", result.Entries[4].ItemRef)
+}
+
+func (suite *DetailsUnitSuite) TestDetailsModel_DriveChecksums() {
+	var (
+		t   = suite.T()
+		b   = &Builder{}
+		loc = &path.Builder{}
+
+		drivePath = func(svc path.ServiceType, cat path.CategoryType, driveID, name string) path.Path {
+			return makeItemPath(t, svc, cat, "t", "u", []string{driveID, "r:", "f", name})
+		}
+
+		adds = []struct {
+			rr   path.Path
+			info ItemInfo
+		}{
+			{
+				rr: drivePath(path.OneDriveService, path.FilesCategory, "d1", "file1"+metadata.DataFileSuffix),
+				info: ItemInfo{
+					OneDrive: &OneDriveInfo{ItemType: OneDriveItem, DriveID: "d1", ETag: "etag1", Size: 1},
+				},
+			},
+			{
+				rr: drivePath(path.SharePointService, path.LibrariesCategory, "d2", "file2"+metadata.DataFileSuffix),
+				info: ItemInfo{
+					SharePoint: &SharePointInfo{ItemType: SharePointLibrary, DriveID: "d2", ETag: "etag2", Size: 2},
+				},
+			},
+			{
+				// backed up before etags were recorded
+				rr: drivePath(path.OneDriveService, path.FilesCategory, "d1", "noetag"+metadata.DataFileSuffix),
+				info: ItemInfo{
+					OneDrive: &OneDriveInfo{ItemType: OneDriveItem, DriveID: "d1", Size: 4},
+				},
+			},
+			{
+				rr: drivePath(path.OneDriveService, path.FilesCategory, "d1", "meta"+metadata.MetaFileSuffix),
+				info: ItemInfo{
+					OneDrive: &OneDriveInfo{ItemType: OneDriveItem, DriveID: "d1", ETag: "etag5", IsMeta: true},
+				},
+			},
+			{
+				rr: makeItemPath(t, path.ExchangeService, path.EmailCategory, "t", "u", []string{"inbox", "mail"}),
+				info: ItemInfo{
+					Exchange: &ExchangeInfo{ItemType: ExchangeMail, Subject: "hi"},
+				},
+			},
+		}
+	)
+
+	for _, a := range adds {
+		err := b.Add(a.rr, loc, a.info)
+		require.NoError(t, err, clues.ToCore(err))
+	}
+
+	dm := b.Details().DetailsModel
+
+	// details written by older versions kept the data suffix on drive
+	// ItemRefs.
+	legacy := drivePath(path.GroupsService, path.LibrariesCategory, "d2", "file3"+metadata.DataFileSuffix)
+	dm.Entries = append(dm.Entries, Entry{
+		RepoRef:     legacy.String(),
+		ShortRef:    legacy.ShortRef(),
+		ParentRef:   legacy.ToBuilder().Dir().ShortRef(),
+		LocationRef: loc.String(),
+		ItemRef:     legacy.Item(),
+		ItemInfo: ItemInfo{
+			Groups: &GroupsInfo{ItemType: SharePointLibrary, DriveID: "d2", ETag: "etag3", Size: 3},
+		},
+	})
+
+	expect := map[string]map[string]ItemChecksum{
+		"d1": {
+			"file1": {ETag: "etag1", Size: 1},
+		},
+		"d2": {
+			"file2": {ETag: "etag2", Size: 2},
+			"file3": {ETag: "etag3", Size: 3},
+		},
+	}
+
+	assert.Equal(t, expect, dm.DriveChecksums())
+}
@@ -2,10 +2,12 @@ package details
 
 import (
 	"context"
+	"strings"
 
 	"github.com/alcionai/clues"
 
 	"github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/internal/m365/collection/drive/metadata"
 	"github.com/alcionai/corso/src/internal/version"
 	"github.com/alcionai/corso/src/pkg/path"
 )
@@ -125,6 +127,13 @@ func (de Entry) isMetaFile() bool {
 	return de.ItemInfo.OneDrive != nil && de.ItemInfo.OneDrive.IsMeta
 }
 
+// Check if a file is the metadata of a folder.  Backups that preserve
+// empty folders add these so that restores can recreate the folder, but
+// they aren't files the user backed up.
+func (de Entry) isFolderMetaFile() bool {
+	return de.isDriveItem() && strings.HasSuffix(de.RepoRef, metadata.DirMetaFileSuffix)
+}
+
 // --------------------------------------------------------------------------------
 // CLI Output
 // --------------------------------------------------------------------------------
@@ -54,6 +54,7 @@ type GroupsInfo struct {
 	// SharePoint specific
 	DriveName string `json:"driveName,omitempty"`
 	DriveID   string `json:"driveID,omitempty"`
+	ETag      string `json:"etag,omitempty"`
 	SiteID    string `json:"siteID,omitempty"`
 	WebURL    string `json:"webURL,omitempty"`
 }
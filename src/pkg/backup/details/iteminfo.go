@@ -124,6 +124,34 @@ func (i ItemInfo) size() int64 {
 	return 0
 }
 
+// name returns the human-readable name of the item: the file or folder
+// name for drive items and folders, and the subject (or contact name) for
+// exchange items.
+func (i ItemInfo) name() string {
+	switch {
+	case i.Exchange != nil:
+		if i.Exchange.ItemType == ExchangeContact {
+			return i.Exchange.ContactName
+		}
+
+		return i.Exchange.Subject
+
+	case i.OneDrive != nil:
+		return i.OneDrive.ItemName
+
+	case i.SharePoint != nil:
+		return i.SharePoint.ItemName
+
+	case i.Groups != nil:
+		return i.Groups.ItemName
+
+	case i.Folder != nil:
+		return i.Folder.DisplayName
+	}
+
+	return ""
+}
+
 func (i ItemInfo) Modified() time.Time {
 	switch {
 	case i.Exchange != nil:
@@ -183,6 +211,22 @@ func (i ItemInfo) updateFolder(f *FolderInfo) error {
 	}
 }
 
+// driveChecksum returns the drive id and the checksum of a drive item.
+func (i ItemInfo) driveChecksum() (string, ItemChecksum) {
+	switch {
+	case i.OneDrive != nil:
+		return i.OneDrive.DriveID, ItemChecksum{ETag: i.OneDrive.ETag, Size: i.OneDrive.Size}
+
+	case i.SharePoint != nil:
+		return i.SharePoint.DriveID, ItemChecksum{ETag: i.SharePoint.ETag, Size: i.SharePoint.Size}
+
+	case i.Groups != nil:
+		return i.Groups.DriveID, ItemChecksum{ETag: i.Groups.ETag, Size: i.Groups.Size}
+	}
+
+	return "", ItemChecksum{}
+}
+
 // true if the info represents an item backed by the drive api.
 func (i ItemInfo) isDriveItem() bool {
 	iit := i.infoType()
@@ -2,8 +2,10 @@ package details
 
 import (
 	"context"
+	"strings"
 
 	"github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/internal/m365/collection/drive/metadata"
 )
 
 // DetailsModel describes what was stored in a Backup
@@ -96,14 +98,14 @@ func (dm DetailsModel) Items() entrySet {
 }
 
 // FilterMetaFiles returns a copy of the Details with all of the
-// .meta files removed from the entries.
+// .meta and .dirmeta files removed from the entries.
 func (dm DetailsModel) FilterMetaFiles() DetailsModel {
 	d2 := DetailsModel{
 		Entries: []Entry{},
 	}
 
 	for _, ent := range dm.Entries {
-		if !ent.isMetaFile() {
+		if !ent.isMetaFile() && !ent.isFolderMetaFile() {
 			d2.Entries = append(d2.Entries, ent)
 		}
 	}
@@ -111,6 +113,75 @@ func (dm DetailsModel) FilterMetaFiles() DetailsModel {
 	return d2
 }
 
+// FilterPathPrefix returns a copy of the DetailsModel containing only the
+// entries whose RepoRef or LocationRef begins with the given prefix.  An
+// empty prefix matches all entries.
+func (dm DetailsModel) FilterPathPrefix(prefix string) DetailsModel {
+	if len(prefix) == 0 {
+		return dm
+	}
+
+	d2 := DetailsModel{
+		Entries: []Entry{},
+	}
+
+	for _, ent := range dm.Entries {
+		if strings.HasPrefix(ent.RepoRef, prefix) ||
+			strings.HasPrefix(ent.LocationRef, prefix) {
+			d2.Entries = append(d2.Entries, ent)
+		}
+	}
+
+	return d2
+}
+
+// Search returns a copy of the DetailsModel containing only the entries
+// whose item name, RepoRef, or LocationRef contains the query.  Matching is
+// case-insensitive.  An empty query matches all entries.
+func (dm DetailsModel) Search(query string) DetailsModel {
+	if len(query) == 0 {
+		return dm
+	}
+
+	var (
+		q  = strings.ToLower(query)
+		d2 = DetailsModel{
+			Entries: []Entry{},
+		}
+	)
+
+	for _, ent := range dm.Entries {
+		if strings.Contains(strings.ToLower(ent.ItemInfo.name()), q) ||
+			strings.Contains(strings.ToLower(ent.RepoRef), q) ||
+			strings.Contains(strings.ToLower(ent.LocationRef), q) {
+			d2.Entries = append(d2.Entries, ent)
+		}
+	}
+
+	return d2
+}
+
+// Window returns a copy of the DetailsModel containing at most limit
+// entries, starting at the given offset.  A limit <= 0 includes every
+// entry after the offset.  Offsets beyond the end of the entries
+// produce an empty model.
+func (dm DetailsModel) Window(offset, limit int) DetailsModel {
+	if offset < 0 {
+		offset = 0
+	}
+
+	if offset >= len(dm.Entries) {
+		return DetailsModel{Entries: []Entry{}}
+	}
+
+	end := len(dm.Entries)
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+	}
+
+	return DetailsModel{Entries: dm.Entries[offset:end]}
+}
+
 // SumNonMetaFileSizes returns the total size of items excluding all the
 // .meta files from the items.
 func (dm DetailsModel) SumNonMetaFileSizes() int64 {
@@ -123,3 +194,38 @@ func (dm DetailsModel) SumNonMetaFileSizes() int64 {
 
 	return size
 }
+
+// ItemChecksum holds the values used to tell whether a drive item changed
+// since it was backed up, without downloading its content.
+type ItemChecksum struct {
+	ETag string
+	Size int64
+}
+
+// DriveChecksums returns the checksums of the drive files in the details,
+// keyed by drive id, and then by item id.  Files that were recorded without
+// an etag, such as those from older backups, are left out.  Older details
+// may also hold ItemRefs with the data file suffix, which gets trimmed so
+// that every key is the bare item id.
+func (dm DetailsModel) DriveChecksums() map[string]map[string]ItemChecksum {
+	checksums := map[string]map[string]ItemChecksum{}
+
+	for _, ent := range dm.Items() {
+		if !ent.isDriveItem() {
+			continue
+		}
+
+		driveID, ic := ent.driveChecksum()
+		if len(driveID) == 0 || len(ic.ETag) == 0 {
+			continue
+		}
+
+		if _, ok := checksums[driveID]; !ok {
+			checksums[driveID] = map[string]ItemChecksum{}
+		}
+
+		checksums[driveID][strings.TrimSuffix(ent.ItemRef, metadata.DataFileSuffix)] = ic
+	}
+
+	return checksums
+}
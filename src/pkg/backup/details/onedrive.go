@@ -31,6 +31,7 @@ type OneDriveInfo struct {
 	Created    time.Time `json:"created,omitempty"`
 	DriveID    string    `json:"driveID,omitempty"`
 	DriveName  string    `json:"driveName,omitempty"`
+	ETag       string    `json:"etag,omitempty"`
 	IsMeta     bool      `json:"isMeta,omitempty"`
 	ItemName   string    `json:"itemName,omitempty"`
 	ItemType   ItemType  `json:"itemType,omitempty"`
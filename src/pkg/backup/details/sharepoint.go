@@ -31,6 +31,7 @@ type SharePointInfo struct {
 	Created    time.Time `json:"created,omitempty"`
 	DriveName  string    `json:"driveName,omitempty"`
 	DriveID    string    `json:"driveID,omitempty"`
+	ETag       string    `json:"etag,omitempty"`
 	ItemName   string    `json:"itemName,omitempty"`
 	ItemType   ItemType  `json:"itemType,omitempty"`
 	Modified   time.Time `json:"modified,omitempty"`
@@ -0,0 +1,77 @@
+package backup
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
+
+	"github.com/alcionai/clues"
+)
+
+// ErrDetailsTampered is returned when a backup's details don't match the
+// signature recorded when the backup was created.
+var ErrDetailsTampered = clues.New("backup details don't match their signature")
+
+// detailsSigningPurpose separates the details signing key from any other
+// key derived from the repository's key material.
+const detailsSigningPurpose = "corso backup details signature"
+
+// detailsSigningKeyLength matches the output size of the signing MAC.
+const detailsSigningKeyLength = sha256.Size
+
+// KeyDeriver derives keys from the repository's key material.
+type KeyDeriver interface {
+	DeriveKey(ctx context.Context, purpose []byte, length int) ([]byte, error)
+}
+
+// DetailsSigningKey derives the key used to sign backup details from the
+// repository's master key.  The master key isn't derived from the
+// passphrase, so details signed before a passphrase change still verify
+// after it.
+func DetailsSigningKey(ctx context.Context, kd KeyDeriver) ([]byte, error) {
+	key, err := kd.DeriveKey(ctx, []byte(detailsSigningPurpose), detailsSigningKeyLength)
+	if err != nil {
+		return nil, clues.Wrap(err, "deriving details signing key")
+	}
+
+	return key, nil
+}
+
+// NewDetailsMAC produces the hash which signs the serialized details.
+func NewDetailsMAC(key []byte) hash.Hash {
+	return hmac.New(sha256.New, key)
+}
+
+// SignDetails produces the signature of the serialized details.
+func SignDetails(
+	key []byte,
+	deets interface{ MarshalTo(io.Writer) error },
+) (string, error) {
+	mac := NewDetailsMAC(key)
+
+	if err := deets.MarshalTo(mac); err != nil {
+		return "", clues.Wrap(err, "serializing details for signature")
+	}
+
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// VerifyDetailsSignature compares the sum of a details MAC, produced
+// from the serialized details, against the backup's signature.  Backups
+// created without a signature, and verification without a key, always
+// pass.  Returns ErrDetailsTampered if the sum doesn't match.
+func (b Backup) VerifyDetailsSignature(key, sum []byte) error {
+	if len(b.DetailsSignature) == 0 || len(key) == 0 {
+		return nil
+	}
+
+	sig, err := hex.DecodeString(b.DetailsSignature)
+	if err != nil || !hmac.Equal(sig, sum) {
+		return clues.Stack(ErrDetailsTampered).With("backup_id", b.ID)
+	}
+
+	return nil
+}
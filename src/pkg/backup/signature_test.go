@@ -0,0 +1,190 @@
+package backup_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+)
+
+type SignatureUnitSuite struct {
+	tester.Suite
+}
+
+func TestSignatureUnitSuite(t *testing.T) {
+	suite.Run(t, &SignatureUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+// mockKeyDeriver derives keys from a fixed master key.
+type mockKeyDeriver struct {
+	master string
+	err    error
+}
+
+func (m mockKeyDeriver) DeriveKey(_ context.Context, purpose []byte, length int) ([]byte, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	mac := hmac.New(sha256.New, []byte(m.master))
+	mac.Write(purpose)
+
+	return mac.Sum(nil)[:length], nil
+}
+
+func detailsSigningKey(t *testing.T, master string) []byte {
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	key, err := backup.DetailsSigningKey(ctx, mockKeyDeriver{master: master})
+	require.NoError(t, err, clues.ToCore(err))
+
+	return key
+}
+
+func (suite *SignatureUnitSuite) TestDetailsSigningKey() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	assert.Len(t, detailsSigningKey(t, "master"), sha256.Size, "key length")
+	assert.Equal(t, detailsSigningKey(t, "master"), detailsSigningKey(t, "master"), "stable")
+	assert.NotEqual(t, detailsSigningKey(t, "master"), detailsSigningKey(t, "other"), "per master key")
+
+	_, err := backup.DetailsSigningKey(ctx, mockKeyDeriver{err: assert.AnError})
+	assert.ErrorIs(t, err, assert.AnError, clues.ToCore(err))
+}
+
+func (suite *SignatureUnitSuite) TestVerifyDetailsSignature() {
+	var (
+		key   = detailsSigningKey(suite.T(), "pass")
+		deets = &details.Details{
+			DetailsModel: details.DetailsModel{
+				Entries: []details.Entry{{RepoRef: "tenant/exchange/user/email/inbox/item"}},
+			},
+		}
+		modified = &details.Details{
+			DetailsModel: details.DetailsModel{
+				Entries: []details.Entry{{RepoRef: "tenant/exchange/user/email/inbox/other"}},
+			},
+		}
+	)
+
+	sig, err := backup.SignDetails(key, deets)
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	// sum produces the MAC of the details as they'd be read back from
+	// the store.
+	sum := func(key []byte, d *details.Details) []byte {
+		bs, err := d.Marshal()
+		require.NoError(suite.T(), err, clues.ToCore(err))
+
+		mac := backup.NewDetailsMAC(key)
+		mac.Write(bs)
+
+		return mac.Sum(nil)
+	}
+
+	table := []struct {
+		name      string
+		signature string
+		key       []byte
+		sum       []byte
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "valid",
+			signature: sig,
+			key:       key,
+			sum:       sum(key, deets),
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "modified details",
+			signature: sig,
+			key:       key,
+			sum:       sum(key, modified),
+			expectErr: tamperErr,
+		},
+		{
+			name:      "different passphrase",
+			signature: sig,
+			key:       detailsSigningKey(suite.T(), "word"),
+			sum:       sum(detailsSigningKey(suite.T(), "word"), deets),
+			expectErr: tamperErr,
+		},
+		{
+			name:      "malformed signature",
+			signature: "not hex",
+			key:       key,
+			sum:       sum(key, deets),
+			expectErr: tamperErr,
+		},
+		{
+			name:      "unsigned backup",
+			key:       key,
+			sum:       sum(key, modified),
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "no key",
+			signature: sig,
+			sum:       sum(nil, modified),
+			expectErr: assert.NoError,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			b := backup.Backup{DetailsSignature: test.signature}
+
+			err := b.VerifyDetailsSignature(test.key, test.sum)
+			test.expectErr(t, err, clues.ToCore(err))
+		})
+	}
+}
+
+func (suite *SignatureUnitSuite) TestSignDetails_matchesMarshal() {
+	t := suite.T()
+
+	var (
+		key   = detailsSigningKey(suite.T(), "pass")
+		deets = &details.Details{
+			DetailsModel: details.DetailsModel{
+				Entries: []details.Entry{
+					{RepoRef: "a", ItemRef: "1"},
+					{RepoRef: "b", ItemRef: "2"},
+				},
+			},
+		}
+	)
+
+	sig, err := backup.SignDetails(key, deets)
+	require.NoError(t, err, clues.ToCore(err))
+
+	bs, err := deets.Marshal()
+	require.NoError(t, err, clues.ToCore(err))
+
+	mac := backup.NewDetailsMAC(key)
+	_, err = bytes.NewReader(bs).WriteTo(mac)
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), sig, "signature covers the serialized details")
+}
+
+func tamperErr(t assert.TestingT, err error, msgAndArgs ...any) bool {
+	return assert.ErrorIs(t, err, backup.ErrDetailsTampered, msgAndArgs...)
+}
@@ -16,6 +16,27 @@ type ExportConfig struct {
 	// ex: html vs pst vs other.
 	// Default format is decided on a per-service or per-data basis.
 	Format FormatType
+
+	// IncludeErrorReport adds a file to the export which records the
+	// errors, skipped items, and recovered failures of the backup that
+	// produced the exported data.
+	IncludeErrorReport bool
+
+	// PackageByFolder creates one archive for each top-level folder in
+	// the export, instead of a single archive or a flat tree of files.
+	// Items at the root of the export are archived together.  If set,
+	// Archive is ignored.
+	PackageByFolder bool
+
+	// NameSanitizer decides how the names of exported items get rewritten
+	// to be legal filenames on the target OS.  Defaults to the windows
+	// rules, which are the most restrictive.
+	NameSanitizer NameSanitizer
+
+	// ExcludeExtensions leaves items out of the export if their name ends
+	// in one of the extensions, ex: [".exe", "dll"].  The leading dot is
+	// optional, and matching is case-insensitive.
+	ExcludeExtensions []string
 }
 
 type FormatType string
@@ -25,6 +46,23 @@ var (
 	DefaultFormat FormatType
 	// export the data as raw, unmodified json
 	JSONFormat FormatType = "json"
+	// export exchange mail as a maildir tree, which mirrors the mail
+	// folders of the backup.  Messages are written to the cur/ folder
+	// of each maildir.
+	MaildirFormat FormatType = "maildir"
+)
+
+type NameSanitizer string
+
+var (
+	// Use the default sanitizer, which follows the windows rules.
+	DefaultNameSanitizer NameSanitizer
+	// replace characters and names that are reserved on windows.
+	WindowsNameSanitizer NameSanitizer = "windows"
+	// replace characters and names that are reserved on posix systems.
+	POSIXNameSanitizer NameSanitizer = "posix"
+	// leave item names unchanged.
+	NoNameSanitizer NameSanitizer = "none"
 )
 
 func DefaultExportConfig() ExportConfig {
@@ -1,22 +1,107 @@
 package control
 
 import (
+	"context"
+	"time"
+
 	"github.com/alcionai/corso/src/pkg/control/repository"
+	"github.com/alcionai/corso/src/pkg/credentials"
 	"github.com/alcionai/corso/src/pkg/extensions"
 )
 
 // Options holds the optional configurations for a process
 type Options struct {
+	// CompressBackupDetails gzips the backup details before they're stored
+	// in the repository.  Compressed and uncompressed details can both be
+	// read, regardless of this setting.
+	CompressBackupDetails bool `json:"compressBackupDetails,omitempty"`
+	// CredentialsProvider, when set, supplies any repository and M365
+	// credentials missing from the storage and account configs when the
+	// repository is initialized or connected.  Env vars are checked as a
+	// fallback.
+	CredentialsProvider credentials.Provider `json:"-"`
 	// DeltaPageSize controls the quantity of items fetched in each page
 	// during multi-page queries, such as graph api delta endpoints.
-	DeltaPageSize        int32                              `json:"deltaPageSize"`
-	DisableMetrics       bool                               `json:"disableMetrics"`
-	FailureHandling      FailurePolicy                      `json:"failureHandling"`
+	DeltaPageSize  int32 `json:"deltaPageSize"`
+	DisableMetrics bool  `json:"disableMetrics"`
+	// EventSinks receive every event emitted by the repository, such as repo
+	// init and connect, and the start and end of each operation.  Sinks run
+	// alongside the default analytics backend, and still receive events when
+	// metrics are disabled.  The repository doesn't close its sinks; the
+	// caller should flush them once it's done with the repository.
+	EventSinks []EventSink `json:"-"`
+	// ExcludeFilePatterns holds glob patterns (ex: "Thumbs.db", "~$*") that
+	// are compared, case-insensitively, against the name of each drive file.
+	// Matching files are skipped during backup.
+	ExcludeFilePatterns []string      `json:"excludeFilePatterns,omitempty"`
+	FailureHandling     FailurePolicy `json:"failureHandling"`
+	// IncrementalSince, when set, limits the enumeration of any exchange
+	// folder or drive without a delta token from a previous backup to the
+	// items modified after this time.  This produces a best-effort
+	// incremental backup, such as after migrating a repository, instead of
+	// a full scan.  Items last modified before this time are not included
+	// in the backup, and deletions are not detected.  Such backups are saved
+	// as assist backups, so later backups never merge items from them.
+	IncrementalSince     time.Time                          `json:"incrementalSince,omitempty"`
 	ItemExtensionFactory []extensions.CreateItemExtensioner `json:"-"`
-	Parallelism          Parallelism                        `json:"parallelism"`
-	Repo                 repository.Options                 `json:"repo"`
-	SkipReduce           bool                               `json:"skipReduce"`
-	ToggleFeatures       Toggles                            `json:"toggleFeatures"`
+	// MaxOperationRetries caps the total number of graph api retries made
+	// across a backup, restore, or export.  Once the cap is reached, failed
+	// requests are no longer retried.  Zero, the default, leaves the total
+	// uncapped.
+	MaxOperationRetries int `json:"maxOperationRetries,omitempty"`
+	// MaxThrottleWait is the cumulative time that a graph api request can
+	// pause, honoring the Retry-After header, when it's still throttled after
+	// its retries are exhausted.  The waits between the request's throttled
+	// retries count against the same budget.  The operation resumes once the
+	// pause ends, instead of failing.  Zero, the default, disables pausing.
+	MaxThrottleWait time.Duration `json:"maxThrottleWait,omitempty"`
+	Parallelism     Parallelism   `json:"parallelism"`
+	// PreserveEmptyFolders records empty drive folders in the backup, so
+	// that restores recreate them.  When unset, folders only get restored
+	// as the ancestors of restored files.
+	PreserveEmptyFolders bool `json:"preserveEmptyFolders,omitempty"`
+	// RemovedItemModTime decides the modification time recorded for
+	// exchange items removed since the previous backup.  Removed items have
+	// no modification time of their own.  Defaults to the time of the
+	// backup.
+	RemovedItemModTime RemovedModTimePolicy `json:"removedItemModTime,omitempty"`
+	Repo               repository.Options   `json:"repo"`
+	// RequestTimeouts sets the timeout of each category of graph api
+	// request, so that metadata calls fail fast without capping the
+	// download of large items.
+	RequestTimeouts RequestTimeouts `json:"requestTimeouts,omitempty"`
+	// SkipPermissionsMetadata omits drive permissions from the backup.  Folder
+	// .dirmeta files are not produced, and file .meta files only retain the
+	// item name, which is needed to restore the file.
+	SkipPermissionsMetadata bool `json:"skipPermissionsMetadata,omitempty"`
+	// SkipMailAttachments omits attachments from exchange mail backups.  Only
+	// the message itself gets downloaded and stored, and the size recorded in
+	// the backup details only counts the message body.
+	SkipMailAttachments bool `json:"skipMailAttachments,omitempty"`
+	SkipReduce          bool `json:"skipReduce"`
+	// SkipZeroByteFiles leaves zero-byte drive files out of the backup, and
+	// records them as skipped items.  When unset, they're backed up as empty
+	// files without attempting to download their content.
+	SkipZeroByteFiles bool `json:"skipZeroByteFiles,omitempty"`
+	// SnapshotPerCategory splits a backup into one snapshot, and one backup
+	// model, for each service category in the selector.  The backups share
+	// a backup group id, so that maintenance and restores can target a
+	// subset of the data.
+	SnapshotPerCategory bool    `json:"snapshotPerCategory,omitempty"`
+	ToggleFeatures      Toggles `json:"toggleFeatures"`
+	// VerifyAccess runs a minimal graph api call when connecting to m365,
+	// so that invalid or under-permissioned credentials fail fast instead
+	// of partway through an operation.
+	VerifyAccess bool `json:"verifyAccess,omitempty"`
+}
+
+// EventSink receives every event emitted by a repository, alongside the
+// default analytics backend.  The event data must be treated as read-only.
+// Sinks are called synchronously, and may be called concurrently, so any
+// delivery to a remote service (webhooks, kafka, etc) should be buffered by
+// the sink.
+type EventSink interface {
+	Event(ctx context.Context, key string, data map[string]any)
 }
 
 type Parallelism struct {
@@ -24,6 +109,27 @@ type Parallelism struct {
 	CollectionBuffer int
 	// sets the parallelism of item population within a collection.
 	ItemFetch int
+	// sets the parallelism of item restoration within a collection.
+	// Restores are write-heavy and get throttled differently than
+	// backups, so they don't share the ItemFetch setting.  The zero
+	// value uses each service's default.
+	RestoreItemWrite int
+	// sets the number of drives created concurrently when a restore
+	// needs new drives.  The zero value uses the default.
+	RestoreDriveCreate int
+	// sets the number of backups looked up concurrently ahead of a
+	// deletion.  The zero value uses the default.
+	DeleteBackupLookup int
+}
+
+// RequestTimeouts holds the timeout of each category of graph api request.
+// The zero value of a category uses the graph client's timeout.
+type RequestTimeouts struct {
+	// Metadata bounds the requests that enumerate and describe items and
+	// containers.
+	Metadata time.Duration `json:"metadata,omitempty"`
+	// Download bounds the requests that download item content.
+	Download time.Duration `json:"download,omitempty"`
 }
 
 type FailurePolicy string
@@ -37,6 +143,18 @@ const (
 	BestEffort FailurePolicy = "best-effort"
 )
 
+// RemovedModTimePolicy describes the modification time given to items
+// that were removed since the previous backup.
+type RemovedModTimePolicy string
+
+const (
+	// removed items get the time at which the backup found them removed.
+	RemovedModTimeNow RemovedModTimePolicy = ""
+	// removed items get a fixed sentinel time, so that every backup records
+	// the same modification time for a removal.
+	RemovedModTimeSentinel RemovedModTimePolicy = "sentinel"
+)
+
 // DefaultOptions provides an Options with the default values set.
 func DefaultOptions() Options {
 	return Options{
@@ -80,6 +198,13 @@ type Toggles struct {
 	// immutable Exchange IDs. This is only safe to set if the previous backup for
 	// incremental backups used immutable IDs or if a full backup is being done.
 	ExchangeImmutableIDs bool `json:"exchangeImmutableIDs,omitempty"`
+	// DriveChecksumIncrementals keeps drive backups incremental when the
+	// previous delta token gets rejected.  Instead of downloading every
+	// file, the etag and size of each enumerated file are compared against
+	// the previous backup's details, and only the files that changed are
+	// backed up again.  Unchanged files are carried over from the previous
+	// backup.
+	DriveChecksumIncrementals bool `json:"driveChecksumIncrementals,omitempty"`
 
 	RunMigrations bool `json:"runMigrations"`
 
@@ -12,6 +12,11 @@ type Options struct {
 	// immutable backups are being used. If nil then the current time is used.
 	ViewTimestamp *time.Time `json:"viewTimestamp"`
 	ReadOnly      bool       `json:"readonly,omitempty"`
+	// ContentCacheSizeBytes and MetadataCacheSizeBytes cap the size of the
+	// local kopia caches.  Zero values fall back to Corso's defaults of 5GiB
+	// for content and 1GiB for metadata.
+	ContentCacheSizeBytes  int64 `json:"contentCacheSizeBytes,omitempty"`
+	MetadataCacheSizeBytes int64 `json:"metadataCacheSizeBytes,omitempty"`
 }
 
 type Maintenance struct {
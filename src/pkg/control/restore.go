@@ -5,12 +5,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/alcionai/clues"
 	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
 
 	"github.com/alcionai/corso/src/internal/common/dttm"
+	"github.com/alcionai/corso/src/pkg/backup/details"
 	"github.com/alcionai/corso/src/pkg/logger"
 	"github.com/alcionai/corso/src/pkg/path"
 )
@@ -37,6 +40,62 @@ func ValidCollisionPolicies() map[CollisionPolicy]struct{} {
 	}
 }
 
+// CollidingItem describes an item, already in the restore destination,
+// that collides with an item being restored.
+type CollidingItem struct {
+	ItemID   string
+	IsFolder bool
+}
+
+// CollisionFunc decides the collision policy for a single restored item,
+// given the details of the item and the existing item it collides with.
+// Items are restored in parallel, so the func gets called concurrently, and
+// must be safe for concurrent use.
+type CollisionFunc func(item details.Entry, existing CollidingItem) CollisionPolicy
+
+// OncePerItem wraps the func so that it's called at most once for each
+// pair of restored and colliding items.  Later calls for the same pair
+// reuse the first decision, which keeps a restore's plan consistent with
+// the restore itself.  A nil func stays nil.
+func (cf CollisionFunc) OncePerItem() CollisionFunc {
+	if cf == nil {
+		return nil
+	}
+
+	type decision struct {
+		once   sync.Once
+		policy CollisionPolicy
+	}
+
+	var (
+		mu        sync.Mutex
+		decisions = map[string]*decision{}
+	)
+
+	return func(item details.Entry, existing CollidingItem) CollisionPolicy {
+		ref := item.RepoRef
+		if len(ref) == 0 {
+			ref = item.ItemRef
+		}
+
+		key := ref + "/" + existing.ItemID
+
+		mu.Lock()
+
+		d, ok := decisions[key]
+		if !ok {
+			d = &decision{}
+			decisions[key] = d
+		}
+
+		mu.Unlock()
+
+		d.once.Do(func() { d.policy = cf(item, existing) })
+
+		return d.policy
+	}
+}
+
 const RootLocation = "/"
 
 // RestoreConfig contains
@@ -45,6 +104,22 @@ type RestoreConfig struct {
 	// Defaults to Skip.
 	OnCollision CollisionPolicy `json:"onCollision"`
 
+	// OnCollisionFunc, when set, is consulted for each colliding item, and
+	// the policy it returns overrides OnCollision for that item.  Unknown
+	// policies fall back to OnCollision.  It's called concurrently, and at
+	// most once per item for each restore operation.  Only drive items
+	// support per-item collision handling.
+	// Defaults to nil.
+	OnCollisionFunc CollisionFunc `json:"-"`
+
+	// ReplaceIfUnchanged makes the Replace collision policy conditional:
+	// a colliding item only gets replaced if it hasn't changed since the
+	// restore looked up the destination's items.  Items that changed in
+	// the meantime are left in place and reported as recoverable errors.
+	// Only drive items support conditional replacement.
+	// Defaults to false.
+	ReplaceIfUnchanged bool `json:"replaceIfUnchanged,omitempty"`
+
 	// ProtectedResource specifies which resource the data will be restored to.
 	// If empty, restores to the same resource that was backed up.
 	// Defaults to empty.
@@ -62,9 +137,52 @@ type RestoreConfig struct {
 	// Defaults to empty.
 	Drive string `json:"drive"`
 
+	// TargetDriveID specifies the ID of an existing drive into which the
+	// data will be restored.  When set, it takes precedence over both the
+	// backed up drive and any drive lookup by name.  The drive must already
+	// exist within the protected resource.
+	// Defaults to empty.
+	TargetDriveID string `json:"targetDriveID,omitempty"`
+
 	// IncludePermissions toggles whether the restore will include the original
 	// folder- and item-level permissions.
 	IncludePermissions bool `json:"includePermissions"`
+
+	// LargeFileThreshold is the size, in bytes, above which drive files are
+	// uploaded in individually retried chunks, so that a failure partway
+	// through the upload doesn't restart the entire file.
+	// Defaults to 0, which uses the service's default threshold.
+	LargeFileThreshold int64 `json:"largeFileThreshold,omitempty"`
+
+	// EventsAfter and EventsBefore limit the restore of calendar events to
+	// those starting within the inclusive range.  Recurring events are
+	// restored if their series overlaps the range.  A nil value leaves that
+	// end of the range open.
+	// Defaults to nil, which restores all events.
+	EventsAfter  *time.Time `json:"eventsAfter,omitempty"`
+	EventsBefore *time.Time `json:"eventsBefore,omitempty"`
+
+	// RestoreMailAsDraft restores exchange mail into the mailbox's drafts
+	// folder as unsent messages, instead of recreating the backed up
+	// folders.  This keeps restored mail from appearing as newly sent or
+	// received.  The restore location is ignored for mail.
+	// Defaults to false.
+	RestoreMailAsDraft bool `json:"restoreMailAsDraft,omitempty"`
+
+	// MailRootFolder nests the restored exchange mail hierarchy, including
+	// the restore location, beneath a top-level mail folder with this name.
+	// The folder is created if it doesn't already exist.  This allows a
+	// clean restore into a fresh folder tree, instead of merging with the
+	// mailbox's existing folders.  Ignored when restoring mail as drafts.
+	// Defaults to empty.
+	MailRootFolder string `json:"mailRootFolder,omitempty"`
+
+	// PreserveTimestamps restores drive files with the created and modified
+	// times they had when backed up, instead of the time of the restore.
+	// Backups that didn't record a file's created time only preserve its
+	// modified time.
+	// Defaults to false.
+	PreserveTimestamps bool `json:"preserveTimestamps,omitempty"`
 }
 
 func DefaultRestoreConfig(timeFormat dttm.TimeFormat) RestoreConfig {
@@ -95,10 +213,30 @@ func EnsureRestoreConfigDefaults(
 	}
 
 	rc.Location = strings.TrimPrefix(strings.TrimSpace(rc.Location), "/")
+	rc.MailRootFolder = strings.Trim(strings.TrimSpace(rc.MailRootFolder), "/")
 
 	return rc
 }
 
+// CollisionPolicyFor returns the collision policy for the restored item,
+// which is the result of OnCollisionFunc if one is set, and OnCollision
+// otherwise.
+func (rc RestoreConfig) CollisionPolicyFor(
+	item details.Entry,
+	existing CollidingItem,
+) CollisionPolicy {
+	if rc.OnCollisionFunc == nil {
+		return rc.OnCollision
+	}
+
+	policy := rc.OnCollisionFunc(item, existing)
+	if _, ok := ValidCollisionPolicies()[policy]; !ok {
+		return rc.OnCollision
+	}
+
+	return policy
+}
+
 // ---------------------------------------------------------------------------
 // pii control
 // ---------------------------------------------------------------------------
@@ -121,10 +259,19 @@ func (rc RestoreConfig) marshal() string {
 func (rc RestoreConfig) concealed() RestoreConfig {
 	return RestoreConfig{
 		OnCollision:        rc.OnCollision,
+		OnCollisionFunc:    rc.OnCollisionFunc,
+		ReplaceIfUnchanged: rc.ReplaceIfUnchanged,
 		ProtectedResource:  clues.Conceal(rc.ProtectedResource),
 		Location:           path.LoggableDir(rc.Location),
 		Drive:              clues.Conceal(rc.Drive),
+		TargetDriveID:      clues.Conceal(rc.TargetDriveID),
 		IncludePermissions: rc.IncludePermissions,
+		LargeFileThreshold: rc.LargeFileThreshold,
+		EventsAfter:        rc.EventsAfter,
+		EventsBefore:       rc.EventsBefore,
+		RestoreMailAsDraft: rc.RestoreMailAsDraft,
+		MailRootFolder:     path.LoggableDir(rc.MailRootFolder),
+		PreserveTimestamps: rc.PreserveTimestamps,
 	}
 }
 
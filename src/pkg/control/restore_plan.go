@@ -0,0 +1,61 @@
+package control
+
+// RestoreAction describes what a restore does with a backed up item.
+type RestoreAction string
+
+const (
+	// RestoreCreate restores the item as a new item.  Items that collide
+	// with an existing item under the Copy policy are created alongside
+	// the existing item.
+	RestoreCreate RestoreAction = "create"
+	// RestoreReplace deletes the existing, colliding item before
+	// restoring the item in its place.
+	RestoreReplace RestoreAction = "replace"
+	// RestoreSkip leaves the existing, colliding item in place, and
+	// doesn't restore the item.
+	RestoreSkip RestoreAction = "skip"
+)
+
+// RestorePlan describes the changes that a restore makes in the restore
+// destination, without making any of them.
+type RestorePlan struct {
+	// Drives holds the names of the drives that the restore creates.
+	Drives []string `json:"drives"`
+	// Folders holds the locations of the folders that the restore creates.
+	Folders []string `json:"folders"`
+	// Items holds the action taken for each restored file.
+	Items []RestorePlanItem `json:"items"`
+	// Permissions holds the sharing changes applied to restored folders
+	// and files.
+	Permissions []RestorePlanPermission `json:"permissions"`
+}
+
+// RestorePlanItem is the action a restore takes on a single file.
+type RestorePlanItem struct {
+	// Location is the restore location of the file, as the drive name
+	// followed by the folders and file name.
+	Location string        `json:"location"`
+	Action   RestoreAction `json:"action"`
+}
+
+// RestorePlanPermission is the set of sharing changes applied to a single
+// restored folder or file.
+type RestorePlanPermission struct {
+	// Location is the restore location of the folder or file.
+	Location          string `json:"location"`
+	Added             int    `json:"added"`
+	Removed           int    `json:"removed"`
+	LinkSharesAdded   int    `json:"linkSharesAdded"`
+	LinkSharesRemoved int    `json:"linkSharesRemoved"`
+}
+
+// ActionCounts tallies the plan's items by action.
+func (rp RestorePlan) ActionCounts() map[RestoreAction]int {
+	counts := map[RestoreAction]int{}
+
+	for _, item := range rp.Items {
+		counts[item.Action]++
+	}
+
+	return counts
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/alcionai/corso/src/internal/common/dttm"
 	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/details"
 	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/path"
 )
@@ -89,6 +90,17 @@ func (suite *RestoreUnitSuite) TestEnsureRestoreConfigDefaults() {
 				Drive:             "",
 			},
 		},
+		{
+			name: "mail root folder with surrounding slashes",
+			input: control.RestoreConfig{
+				OnCollision:    control.Skip,
+				MailRootFolder: " /Restored 2024/ ",
+			},
+			expect: control.RestoreConfig{
+				OnCollision:    control.Skip,
+				MailRootFolder: "Restored 2024",
+			},
+		},
 	}
 	for _, test := range table {
 		suite.Run(test.name, func() {
@@ -103,6 +115,82 @@ func (suite *RestoreUnitSuite) TestEnsureRestoreConfigDefaults() {
 	}
 }
 
+func (suite *RestoreUnitSuite) TestRestoreConfig_CollisionPolicyFor() {
+	table := []struct {
+		name   string
+		fn     control.CollisionFunc
+		expect control.CollisionPolicy
+	}{
+		{
+			name:   "no func",
+			expect: control.Skip,
+		},
+		{
+			name: "func replaces",
+			fn: func(details.Entry, control.CollidingItem) control.CollisionPolicy {
+				return control.Replace
+			},
+			expect: control.Replace,
+		},
+		{
+			name: "func copies",
+			fn: func(details.Entry, control.CollidingItem) control.CollisionPolicy {
+				return control.Copy
+			},
+			expect: control.Copy,
+		},
+		{
+			name: "func returns unknown policy",
+			fn: func(details.Entry, control.CollidingItem) control.CollisionPolicy {
+				return control.CollisionPolicy("batman")
+			},
+			expect: control.Skip,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			rc := control.RestoreConfig{
+				OnCollision:     control.Skip,
+				OnCollisionFunc: test.fn,
+			}
+
+			result := rc.CollisionPolicyFor(details.Entry{}, control.CollidingItem{})
+			assert.Equal(suite.T(), test.expect, result)
+		})
+	}
+}
+
+func (suite *RestoreUnitSuite) TestCollisionFunc_OncePerItem() {
+	t := suite.T()
+
+	assert.Nil(t, control.CollisionFunc(nil).OncePerItem())
+
+	calls := map[string]int{}
+
+	fn := control.CollisionFunc(func(item details.Entry, ci control.CollidingItem) control.CollisionPolicy {
+		key := item.RepoRef + "/" + ci.ItemID
+		calls[key]++
+
+		// flip-flops between calls for the same item.
+		if calls[key]%2 == 0 {
+			return control.Copy
+		}
+
+		return control.Replace
+	}).OncePerItem()
+
+	var (
+		a = details.Entry{RepoRef: "a"}
+		b = details.Entry{RepoRef: "b"}
+	)
+
+	assert.Equal(t, control.Replace, fn(a, control.CollidingItem{ItemID: "1"}))
+	assert.Equal(t, control.Replace, fn(a, control.CollidingItem{ItemID: "1"}), "repeated item")
+	assert.Equal(t, control.Replace, fn(b, control.CollidingItem{ItemID: "1"}), "other item")
+	assert.Equal(t, control.Replace, fn(a, control.CollidingItem{ItemID: "2"}), "other collision")
+	assert.Equal(t, map[string]int{"a/1": 1, "b/1": 1, "a/2": 1}, calls)
+}
+
 func (suite *RestoreUnitSuite) TestRestoreConfig_piiHandling() {
 	p, err := path.Build("tid", "ro", path.ExchangeService, path.EmailCategory, true, "foo", "bar", "baz")
 	require.NoError(suite.T(), err, clues.ToCore(err))
@@ -134,12 +222,13 @@ func (suite *RestoreUnitSuite) TestRestoreConfig_piiHandling() {
 				ProtectedResource:  "snoob",
 				Location:           p.String(),
 				Drive:              "somedriveid",
+				TargetDriveID:      "targetid",
 				IncludePermissions: true,
 			},
 			expectSafe: `{"onCollision":"copy","protectedResource":"***","location":"***/exchange/***/email/***/***/***",` +
-				`"drive":"***","includePermissions":true}`,
+				`"drive":"***","targetDriveID":"***","includePermissions":true}`,
 			expectPlain: `{"onCollision":"copy","protectedResource":"snoob","location":"tid/exchange/ro/email/foo/bar/baz",` +
-				`"drive":"somedriveid","includePermissions":true}`,
+				`"drive":"somedriveid","targetDriveID":"targetid","includePermissions":true}`,
 		},
 	}
 	for _, test := range table {
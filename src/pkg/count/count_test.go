@@ -178,3 +178,32 @@ func (suite *CountUnitSuite) TestBus_Values() {
 		})
 	}
 }
+
+func (suite *CountUnitSuite) TestBus_ValuesMultipleKeys() {
+	var (
+		t      = suite.T()
+		b      = New()
+		keyA   = key("key-a")
+		keyB   = key("key-b")
+		keyC   = key("key-c")
+		expect = map[string]int64{
+			string(keyA): 3,
+			string(keyB): 10,
+			string(keyC): 1,
+		}
+	)
+
+	b.Inc(keyA)
+	b.Inc(keyA)
+	b.Inc(keyA)
+	b.Add(keyB, 10)
+	b.Inc(keyC)
+
+	snapshot := b.Values()
+	assert.Equal(t, expect, snapshot)
+
+	// later increments don't alter an earlier snapshot.
+	b.Add(keyB, 5)
+	assert.Equal(t, int64(10), snapshot[string(keyB)])
+	assert.Equal(t, int64(15), b.Values()[string(keyB)])
+}
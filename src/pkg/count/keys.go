@@ -9,4 +9,27 @@ const (
 	NewItemCreated   key = "new-item-created"
 	CollisionReplace key = "collision-replace"
 	CollisionSkip    key = "collision-skip"
+	// EventOutsideRestoreRange counts calendar events left out of a
+	// restore because they start outside the configured date range.
+	EventOutsideRestoreRange key = "event-outside-restore-range"
+	// ChannelTabUnresolvable counts channel tabs left out of a restore
+	// because graph can't recreate them, or can't resolve the resources
+	// they reference.
+	ChannelTabUnresolvable key = "channel-tab-unresolvable"
+)
+
+const (
+	// ExportItemUnchanged counts items that were left out of an
+	// export because they match the entry in a previous export manifest.
+	ExportItemUnchanged key = "export-item-unchanged"
+	// ExportItemExcluded counts items that were left out of an export
+	// because their extension was excluded.
+	ExportItemExcluded key = "export-item-excluded"
+)
+
+const (
+	// ThrottledRequests counts graph api responses with a 429 status.
+	ThrottledRequests key = "throttled-requests"
+	// GraphRetries counts graph api requests re-sent by the retry middleware.
+	GraphRetries key = "graph-retries"
 )
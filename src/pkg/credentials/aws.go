@@ -27,6 +27,26 @@ func GetAWS(override map[string]string) AWS {
 	}
 }
 
+// Resolve populates any empty credentials from the provider, falling
+// back to env vars.  Values already set on the receiver take priority.
+func (c AWS) Resolve(p Provider) (AWS, error) {
+	var err error
+
+	if c.AccessKey, err = resolve(p, AWSAccessKeyID, c.AccessKey); err != nil {
+		return c, err
+	}
+
+	if c.SecretKey, err = resolve(p, AWSSecretAccessKey, c.SecretKey); err != nil {
+		return c, err
+	}
+
+	if c.SessionToken, err = resolve(p, AWSSessionToken, c.SessionToken); err != nil {
+		return c, err
+	}
+
+	return c, nil
+}
+
 func (c AWS) Validate() error {
 	check := map[string]string{
 		AWSAccessKeyID:     c.AccessKey,
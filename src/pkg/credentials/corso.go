@@ -14,6 +14,19 @@ type Corso struct {
 	CorsoPassphrase string // required
 }
 
+// Resolve populates any empty credentials from the provider, falling
+// back to env vars.  Values already set on the receiver take priority.
+func (c Corso) Resolve(p Provider) (Corso, error) {
+	pass, err := resolve(p, CorsoPassphrase, c.CorsoPassphrase)
+	if err != nil {
+		return c, err
+	}
+
+	c.CorsoPassphrase = pass
+
+	return c, nil
+}
+
 func (c Corso) Validate() error {
 	check := map[string]string{
 		CorsoPassphrase: c.CorsoPassphrase,
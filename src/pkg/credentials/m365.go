@@ -31,6 +31,22 @@ func GetM365() M365 {
 	}
 }
 
+// Resolve populates any empty credentials from the provider, falling
+// back to env vars.  Values already set on the receiver take priority.
+func (c M365) Resolve(p Provider) (M365, error) {
+	var err error
+
+	if c.AzureClientID, err = resolve(p, AzureClientID, c.AzureClientID); err != nil {
+		return c, err
+	}
+
+	if c.AzureClientSecret, err = resolve(p, AzureClientSecret, c.AzureClientSecret); err != nil {
+		return c, err
+	}
+
+	return c, nil
+}
+
 func (c M365) Validate() error {
 	check := map[string]string{
 		AzureClientID:     c.AzureClientID,
@@ -0,0 +1,47 @@
+package credentials
+
+import (
+	"os"
+
+	"github.com/alcionai/clues"
+)
+
+// Provider supplies secret values by key.  Keys match the credential
+// env var names (ex: CorsoPassphrase, AzureClientSecret).  Implementations
+// can source secrets from external stores such as Vault or AWS Secrets
+// Manager.  Get should return an empty string, and no error, for any key
+// the provider doesn't hold.
+type Provider interface {
+	Get(key string) (string, error)
+}
+
+var _ Provider = EnvProvider{}
+
+// EnvProvider is the default Provider, which reads secrets from env vars.
+type EnvProvider struct{}
+
+func (EnvProvider) Get(key string) (string, error) {
+	return os.Getenv(key), nil
+}
+
+// resolve returns the first populated value from, in order: the explicit
+// value, the provider, and the env var named by the key.  A nil provider
+// falls back to the environment.
+func resolve(p Provider, key, explicit string) (string, error) {
+	if len(explicit) > 0 {
+		return explicit, nil
+	}
+
+	if p != nil {
+		v, err := p.Get(key)
+		if err != nil {
+			return "", clues.Wrap(err, "getting credential from provider").With("credential_key", key)
+		}
+
+		if len(v) > 0 {
+			return v, nil
+		}
+	}
+
+	return os.Getenv(key), nil
+}
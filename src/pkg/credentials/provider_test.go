@@ -0,0 +1,144 @@
+package credentials_test
+
+import (
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/credentials"
+)
+
+type mockProvider struct {
+	secrets map[string]string
+	err     error
+}
+
+func (mp mockProvider) Get(key string) (string, error) {
+	return mp.secrets[key], mp.err
+}
+
+type ProviderUnitSuite struct {
+	tester.Suite
+}
+
+func TestProviderUnitSuite(t *testing.T) {
+	suite.Run(t, &ProviderUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *ProviderUnitSuite) TestEnvProvider() {
+	t := suite.T()
+
+	t.Setenv(credentials.CorsoPassphrase, "env-pass")
+
+	v, err := credentials.EnvProvider{}.Get(credentials.CorsoPassphrase)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, "env-pass", v)
+}
+
+func (suite *ProviderUnitSuite) TestCorso_Resolve() {
+	table := []struct {
+		name      string
+		explicit  string
+		provider  credentials.Provider
+		env       string
+		expect    string
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "explicit over provider and env",
+			explicit:  "explicit",
+			provider:  mockProvider{secrets: map[string]string{credentials.CorsoPassphrase: "provided"}},
+			env:       "env",
+			expect:    "explicit",
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "provider over env",
+			provider:  mockProvider{secrets: map[string]string{credentials.CorsoPassphrase: "provided"}},
+			env:       "env",
+			expect:    "provided",
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "env when provider lacks the key",
+			provider:  mockProvider{secrets: map[string]string{}},
+			env:       "env",
+			expect:    "env",
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "nil provider uses env",
+			env:       "env",
+			expect:    "env",
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "explicit skips a failing provider",
+			explicit:  "explicit",
+			provider:  mockProvider{err: assert.AnError},
+			expect:    "explicit",
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "provider error",
+			provider:  mockProvider{err: assert.AnError},
+			env:       "env",
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			t.Setenv(credentials.CorsoPassphrase, test.env)
+
+			c, err := credentials.Corso{CorsoPassphrase: test.explicit}.Resolve(test.provider)
+			test.expectErr(t, err, clues.ToCore(err))
+
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, test.expect, c.CorsoPassphrase)
+		})
+	}
+}
+
+func (suite *ProviderUnitSuite) TestM365_Resolve() {
+	t := suite.T()
+
+	t.Setenv(credentials.AzureClientID, "env-id")
+	t.Setenv(credentials.AzureClientSecret, "env-secret")
+
+	mp := mockProvider{secrets: map[string]string{
+		credentials.AzureClientSecret: "provided-secret",
+	}}
+
+	c, err := credentials.M365{}.Resolve(mp)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, "env-id", c.AzureClientID)
+	assert.Equal(t, "provided-secret", c.AzureClientSecret)
+}
+
+func (suite *ProviderUnitSuite) TestAWS_Resolve() {
+	t := suite.T()
+
+	t.Setenv(credentials.AWSAccessKeyID, "env-key")
+	t.Setenv(credentials.AWSSecretAccessKey, "env-secret")
+	t.Setenv(credentials.AWSSessionToken, "env-token")
+
+	mp := mockProvider{secrets: map[string]string{
+		credentials.AWSSecretAccessKey: "provided-secret",
+		credentials.AWSSessionToken:    "provided-token",
+	}}
+
+	c, err := credentials.AWS{SessionToken: "explicit-token"}.Resolve(mp)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, "env-key", c.AccessKey)
+	assert.Equal(t, "provided-secret", c.SecretKey)
+	assert.Equal(t, "explicit-token", c.SessionToken)
+}
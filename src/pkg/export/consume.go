@@ -2,9 +2,7 @@ package export
 
 import (
 	"context"
-	"io"
-	"os"
-	"path/filepath"
+	"path"
 
 	"github.com/alcionai/clues"
 
@@ -12,11 +10,24 @@ import (
 	"github.com/alcionai/corso/src/pkg/fault"
 )
 
+// ConsumeExportCollections writes the items of each collection to disk,
+// within the exportLocation directory.
 func ConsumeExportCollections(
 	ctx context.Context,
 	exportLocation string,
 	expColl []Collectioner,
 	errs *fault.Bus,
+) error {
+	return ConsumeExportCollectionsToSink(ctx, NewFilesystemSink(exportLocation), expColl, errs)
+}
+
+// ConsumeExportCollectionsToSink writes the items of each collection to
+// the sink.  Each collection's base path is used as the folder of its items.
+func ConsumeExportCollectionsToSink(
+	ctx context.Context,
+	sink Sink,
+	expColl []Collectioner,
+	errs *fault.Bus,
 ) error {
 	el := errs.Local()
 
@@ -25,15 +36,23 @@ func ConsumeExportCollections(
 			break
 		}
 
-		folder := filepath.Join(exportLocation, col.BasePath())
+		folder := path.Clean("/" + col.BasePath())[1:]
 		ictx := clues.Add(ctx, "dir_name", folder)
 
+		if ds, ok := sink.(DirSink); ok {
+			for _, dir := range ExtraDirs(col) {
+				if err := ds.MakeDir(ictx, path.Clean("/" + dir)[1:]); err != nil {
+					el.AddRecoverable(ictx, clues.Wrap(err, "creating folder"))
+				}
+			}
+		}
+
 		for item := range col.Items(ctx) {
 			if item.Error != nil {
 				el.AddRecoverable(ictx, clues.Wrap(item.Error, "getting item").WithClues(ctx))
 			}
 
-			if err := writeItem(ictx, item, folder); err != nil {
+			if err := writeItem(ictx, sink, item, folder); err != nil {
 				el.AddRecoverable(
 					ictx,
 					clues.Wrap(err, "writing item").With("file_name", item.Name).WithClues(ctx))
@@ -44,36 +63,16 @@ func ConsumeExportCollections(
 	return el.Failure()
 }
 
-// writeItem writes an ExportItem to disk in the specified folder.
-func writeItem(ctx context.Context, item Item, folder string) error {
-	name := item.Name
-	fpath := filepath.Join(folder, name)
-
+// writeItem writes an ExportItem to the sink in the specified folder.
+func writeItem(ctx context.Context, sink Sink, item Item, folder string) error {
 	progReader, pclose := observe.ItemSpinner(
 		ctx,
 		item.Body,
 		observe.ItemExportMsg,
-		clues.Hide(name))
+		clues.Hide(item.Name))
 
 	defer item.Body.Close()
 	defer pclose()
 
-	err := os.MkdirAll(folder, os.ModePerm)
-	if err != nil {
-		return clues.Wrap(err, "creating directory")
-	}
-
-	// In case the user tries to restore to a non-clean
-	// directory, we might run into collisions an fail.
-	f, err := os.Create(fpath)
-	if err != nil {
-		return clues.Wrap(err, "creating file")
-	}
-
-	_, err = io.Copy(f, progReader)
-	if err != nil {
-		return clues.Wrap(err, "writing data")
-	}
-
-	return nil
+	return sink.Write(ctx, folder, item.Name, progReader)
 }
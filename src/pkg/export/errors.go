@@ -0,0 +1,56 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/pkg/fault"
+)
+
+// ErrorReportFileName is the name of the file, written into the root of the
+// export, that holds the errors recorded by the exported backup.
+const ErrorReportFileName = "corso-backup-errors.json"
+
+var _ Collectioner = errorReportCollection{}
+
+// errorReportCollection holds a single item: the json-serialized fault.Errors
+// of a backup.
+type errorReportCollection struct {
+	report []byte
+}
+
+// NewErrorReportCollection produces a collection containing the backup's
+// errors, serialized to json.  The report gets exported to the root of the
+// export location.
+func NewErrorReportCollection(fe *fault.Errors) (Collectioner, error) {
+	if fe == nil {
+		fe = &fault.Errors{}
+	}
+
+	bs, err := fe.Marshal()
+	if err != nil {
+		return nil, clues.Wrap(err, "marshalling backup errors")
+	}
+
+	return errorReportCollection{report: bs}, nil
+}
+
+func (erc errorReportCollection) BasePath() string {
+	return ""
+}
+
+func (erc errorReportCollection) Items(ctx context.Context) <-chan Item {
+	ch := make(chan Item, 1)
+	defer close(ch)
+
+	ch <- Item{
+		ID:   ErrorReportFileName,
+		Name: ErrorReportFileName,
+		Body: io.NopCloser(bytes.NewReader(erc.report)),
+	}
+
+	return ch
+}
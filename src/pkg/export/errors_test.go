@@ -0,0 +1,80 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/fault"
+	faultTD "github.com/alcionai/corso/src/pkg/fault/testdata"
+)
+
+type ErrorReportUnitSuite struct {
+	tester.Suite
+}
+
+func TestErrorReportUnitSuite(t *testing.T) {
+	suite.Run(t, &ErrorReportUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *ErrorReportUnitSuite) TestNewErrorReportCollection() {
+	table := []struct {
+		name   string
+		errs   *fault.Errors
+		expect fault.Errors
+	}{
+		{
+			name:   "nil errors",
+			expect: fault.Errors{},
+		},
+		{
+			name: "no errors",
+			errs: &fault.Errors{},
+		},
+		{
+			name: "failure, recovered, and skipped",
+			errs: func() *fault.Errors {
+				fe := faultTD.MakeErrors(true, true, true)
+				return &fe
+			}(),
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			expect := test.expect
+			if test.errs != nil {
+				expect = *test.errs
+			}
+
+			erc, err := NewErrorReportCollection(test.errs)
+			require.NoError(t, err, clues.ToCore(err))
+
+			// the report lands in the root of the export, alongside the data.
+			dir := t.TempDir()
+
+			err = ConsumeExportCollections(ctx, dir, []Collectioner{erc}, fault.New(true))
+			require.NoError(t, err, clues.ToCore(err))
+
+			f, err := os.Open(filepath.Join(dir, ErrorReportFileName))
+			require.NoError(t, err, "report file exists", clues.ToCore(err))
+
+			var result fault.Errors
+
+			err = fault.UnmarshalErrorsTo(&result)(f)
+			require.NoError(t, err, "report file is parseable", clues.ToCore(err))
+
+			assert.Equal(t, expect, result)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package export
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/logger"
+)
+
+var _ Collectioner = excludedExtCollection{}
+
+// excludedExtCollection drops the items of a collection whose names end
+// in an excluded extension.
+type excludedExtCollection struct {
+	Collectioner
+	// lower-cased extensions, including the leading dot.
+	exts map[string]struct{}
+	ctr  *count.Bus
+}
+
+// WithExcludedExtensions wraps the collections so that items whose name
+// ends in one of the extensions get left out of the export.  Matching is
+// case-insensitive, and the leading dot of each extension is optional.
+// The number of items left out is tallied in the counter under
+// count.ExportItemExcluded.  If no extensions are provided, the
+// collections are returned unchanged.
+func WithExcludedExtensions(
+	colls []Collectioner,
+	extensions []string,
+	ctr *count.Bus,
+) []Collectioner {
+	exts := normalizeExtensions(extensions)
+	if len(exts) == 0 {
+		return colls
+	}
+
+	wrapped := make([]Collectioner, 0, len(colls))
+
+	for _, c := range colls {
+		wrapped = append(wrapped, excludedExtCollection{
+			Collectioner: c,
+			exts:         exts,
+			ctr:          ctr,
+		})
+	}
+
+	return wrapped
+}
+
+func (ec excludedExtCollection) ExtraDirs() []string {
+	return ExtraDirs(ec.Collectioner)
+}
+
+func (ec excludedExtCollection) Items(ctx context.Context) <-chan Item {
+	ch := make(chan Item)
+
+	go func() {
+		defer close(ch)
+
+		excluded := map[string]int{}
+
+		for item := range ec.Collectioner.Items(ctx) {
+			ext := strings.ToLower(filepath.Ext(item.Name))
+
+			// failed items are always passed along, so that the error
+			// gets reported.
+			if _, ok := ec.exts[ext]; !ok || item.Error != nil {
+				ch <- item
+				continue
+			}
+
+			if item.Body != nil {
+				item.Body.Close()
+			}
+
+			excluded[ext]++
+			ec.ctr.Inc(count.ExportItemExcluded)
+		}
+
+		if len(excluded) > 0 {
+			logger.Ctx(ctx).
+				With(
+					"export_location", ec.BasePath(),
+					"excluded_by_extension", excluded).
+				Info("excluded items from export")
+		}
+	}()
+
+	return ch
+}
+
+// normalizeExtensions produces the set of lower-cased extensions, each
+// with a leading dot.  Blank extensions are ignored.
+func normalizeExtensions(extensions []string) map[string]struct{} {
+	exts := map[string]struct{}{}
+
+	for _, ext := range extensions {
+		ext = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(ext)), ".")
+		if len(ext) == 0 {
+			continue
+		}
+
+		exts["."+ext] = struct{}{}
+	}
+
+	return exts
+}
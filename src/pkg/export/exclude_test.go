@@ -0,0 +1,141 @@
+package export
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/count"
+)
+
+type ExcludeUnitSuite struct {
+	tester.Suite
+}
+
+func TestExcludeUnitSuite(t *testing.T) {
+	suite.Run(t, &ExcludeUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *ExcludeUnitSuite) TestWithExcludedExtensions() {
+	names := []string{
+		"report.docx",
+		"setup.exe",
+		"SETUP2.EXE",
+		"lib.Dll",
+		"script.ps1",
+		"noextension",
+		"archive.tar.gz",
+		"exe",
+	}
+
+	table := []struct {
+		name           string
+		extensions     []string
+		expectNames    []string
+		expectExcluded int64
+	}{
+		{
+			name:       "no extensions",
+			extensions: nil,
+			expectNames: []string{
+				"report.docx", "setup.exe", "SETUP2.EXE", "lib.Dll",
+				"script.ps1", "noextension", "archive.tar.gz", "exe",
+			},
+		},
+		{
+			name:       "blank extensions",
+			extensions: []string{"", " ", "."},
+			expectNames: []string{
+				"report.docx", "setup.exe", "SETUP2.EXE", "lib.Dll",
+				"script.ps1", "noextension", "archive.tar.gz", "exe",
+			},
+		},
+		{
+			name:           "executables, mixed case and dots",
+			extensions:     []string{".exe", "DLL", " ps1 "},
+			expectNames:    []string{"report.docx", "noextension", "archive.tar.gz", "exe"},
+			expectExcluded: 4,
+		},
+		{
+			name:       "only the last extension matches",
+			extensions: []string{"tar", "gz"},
+			expectNames: []string{
+				"report.docx", "setup.exe", "SETUP2.EXE", "lib.Dll",
+				"script.ps1", "noextension", "exe",
+			},
+			expectExcluded: 1,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				ctr   = count.New()
+				items = make([]Item, 0, len(names))
+			)
+
+			for _, n := range names {
+				items = append(items, Item{
+					ID:   n,
+					Name: n,
+					Body: io.NopCloser(bytes.NewBufferString(n)),
+				})
+			}
+
+			colls := WithExcludedExtensions(
+				[]Collectioner{mockExportCollection{path: "folder", items: items}},
+				test.extensions,
+				ctr)
+			require.Len(t, colls, 1)
+			assert.Equal(t, "folder", colls[0].BasePath())
+
+			result := []string{}
+
+			for item := range colls[0].Items(ctx) {
+				result = append(result, item.Name)
+			}
+
+			assert.Equal(t, test.expectNames, result)
+			assert.Equal(t, test.expectExcluded, ctr.Get(count.ExportItemExcluded))
+		})
+	}
+}
+
+func (suite *ExcludeUnitSuite) TestWithExcludedExtensions_failedItems() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	ctr := count.New()
+
+	colls := WithExcludedExtensions(
+		[]Collectioner{mockExportCollection{
+			path: "folder",
+			items: []Item{
+				{ID: "bad", Name: "bad.exe", Error: assert.AnError},
+				{ID: "ok", Name: "ok.exe"},
+			},
+		}},
+		[]string{"exe"},
+		ctr)
+
+	items := []Item{}
+
+	for item := range colls[0].Items(ctx) {
+		items = append(items, item)
+	}
+
+	require.Len(t, items, 1, "failed items are exported so their errors get reported")
+	assert.Equal(t, "bad", items[0].ID)
+	assert.Equal(t, int64(1), ctr.Get(count.ExportItemExcluded))
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"io"
 
+	"github.com/alcionai/clues"
+
 	"github.com/alcionai/corso/src/internal/data"
 	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/path"
 )
 
 // ---------------------------------------------------------------------------
@@ -23,6 +26,23 @@ type Collectioner interface {
 	Items(context.Context) <-chan Item
 }
 
+// ExtraDirser is implemented by collections which produce folders that
+// must exist even if they don't hold any items.
+type ExtraDirser interface {
+	// ExtraDirs gets the folders, relative to the root of the export, that
+	// get created alongside the collection's items.
+	ExtraDirs() []string
+}
+
+// ExtraDirs returns the extra folders of the collection, if it has any.
+func ExtraDirs(c Collectioner) []string {
+	if ed, ok := c.(ExtraDirser); ok {
+		return ed.ExtraDirs()
+	}
+
+	return nil
+}
+
 type itemStreamer func(
 	ctx context.Context,
 	backingColls []data.RestoreCollection,
@@ -44,6 +64,10 @@ type BaseCollection struct {
 
 	Cfg control.ExportConfig
 
+	// Dirs lists folders, relative to the root of the export, which get
+	// created even if they don't hold any items.
+	Dirs []string
+
 	Stream itemStreamer
 }
 
@@ -51,9 +75,58 @@ func (bc BaseCollection) BasePath() string {
 	return bc.BaseDir
 }
 
+func (bc BaseCollection) ExtraDirs() []string {
+	return bc.Dirs
+}
+
 func (bc BaseCollection) Items(ctx context.Context) <-chan Item {
+	var (
+		ch        = make(chan Item)
+		streamed  = make(chan Item)
+		sanitized = make(chan Item)
+	)
+
+	go bc.Stream(ctx, bc.BackingCollection, bc.BackupVersion, bc.Cfg, streamed)
+	go withSanitizedNames(streamed, sanitized, bc.Cfg.NameSanitizer)
+	go withErrorContext(sanitized, ch, ErrCtxLocation, clues.Hide(bc.BaseDir))
+
+	return ch
+}
+
+// ---------------------------------------------------------------------------
+// Backup context
+// ---------------------------------------------------------------------------
+
+var _ Collectioner = backupCollection{}
+
+// backupCollection adds the backup ID to the errors of a collection's items.
+type backupCollection struct {
+	Collectioner
+	backupID string
+}
+
+// WithBackupID wraps the collections so that failed items carry the ID
+// of the backup being exported in their errors.
+func WithBackupID(colls []Collectioner, backupID string) []Collectioner {
+	wrapped := make([]Collectioner, 0, len(colls))
+
+	for _, c := range colls {
+		wrapped = append(wrapped, backupCollection{
+			Collectioner: c,
+			backupID:     backupID,
+		})
+	}
+
+	return wrapped
+}
+
+func (bc backupCollection) ExtraDirs() []string {
+	return ExtraDirs(bc.Collectioner)
+}
+
+func (bc backupCollection) Items(ctx context.Context) <-chan Item {
 	ch := make(chan Item)
-	go bc.Stream(ctx, bc.BackingCollection, bc.BackupVersion, bc.Cfg, ch)
+	go withErrorContext(bc.Collectioner.Items(ctx), ch, ErrCtxBackupID, bc.backupID)
 
 	return ch
 }
@@ -80,6 +153,50 @@ type Item struct {
 	// Error will contain any error that happened while trying to get
 	// the item/items like when trying to resolve the name of the item.
 	// In case we have the error bound to a particular item, we will
-	// also return the id of the item.
+	// also return the id of the item.  The error carries context about
+	// the failed item, which can be retrieved using clues.InErr(err),
+	// keyed by the ErrCtx consts.
 	Error error
 }
+
+// Keys of the context added to the Error of a failed Item.  Paths are
+// user data, and are concealed when the error is logged.
+const (
+	// ErrCtxBackupID holds the ID of the backup being exported.
+	ErrCtxBackupID = "backup_id"
+	// ErrCtxLocation holds the export path of the item's collection.
+	ErrCtxLocation = "location"
+	// ErrCtxRepoRef holds the path of the item, or of its collection if the
+	// item is unknown, within the backup.
+	ErrCtxRepoRef = "repo_ref"
+)
+
+// ItemError adds the repoRef of the failed item to err.  The repoRef is
+// built from the collection's path and, if known, the item's ID.  Returns
+// nil if err is nil.
+func ItemError(err error, collPath path.Path, itemID string) error {
+	if err == nil || collPath == nil {
+		return err
+	}
+
+	pb := collPath.ToBuilder()
+	if len(itemID) > 0 {
+		pb = pb.Append(itemID)
+	}
+
+	return clues.Stack(err).With(ErrCtxRepoRef, clues.Hide(pb.String())).OrNil()
+}
+
+// withErrorContext forwards every item from in to out, adding the key and
+// value to the errors of failed items.  Closes out once in is closed.
+func withErrorContext(in <-chan Item, out chan<- Item, key string, value any) {
+	defer close(out)
+
+	for item := range in {
+		if item.Error != nil {
+			item.Error = clues.Stack(item.Error).With(key, value).OrNil()
+		}
+
+		out <- item
+	}
+}
@@ -0,0 +1,99 @@
+package export
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+type ExportUnitSuite struct {
+	tester.Suite
+}
+
+func TestExportUnitSuite(t *testing.T) {
+	suite.Run(t, &ExportUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *ExportUnitSuite) TestItemErrorContext() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	collPath, err := path.Build("t", "pr", path.OneDriveService, path.FilesCategory, false, "drive", "root:", "folder")
+	require.NoError(t, err, clues.ToCore(err))
+
+	bc := BaseCollection{
+		BaseDir: "folder",
+		Stream: func(
+			_ context.Context,
+			_ []data.RestoreCollection,
+			_ int,
+			_ control.ExportConfig,
+			ch chan<- Item,
+		) {
+			defer close(ch)
+
+			ch <- Item{ID: "ok", Name: "ok"}
+			ch <- Item{ID: "failed", Error: ItemError(assert.AnError, collPath, "failed")}
+			ch <- Item{Error: ItemError(assert.AnError, collPath, "")}
+		},
+	}
+
+	colls := WithBackupID([]Collectioner{bc}, "bid")
+	require.Len(t, colls, 1)
+	assert.Equal(t, "folder", colls[0].BasePath())
+
+	items := []Item{}
+	for item := range colls[0].Items(ctx) {
+		items = append(items, item)
+	}
+
+	require.Len(t, items, 3)
+	assert.NoError(t, items[0].Error, "successful item")
+
+	table := []struct {
+		name          string
+		item          Item
+		expectRepoRef string
+	}{
+		{
+			name:          "failed item",
+			item:          items[1],
+			expectRepoRef: collPath.ToBuilder().Append("failed").String(),
+		},
+		{
+			name:          "failed collection",
+			item:          items[2],
+			expectRepoRef: collPath.String(),
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			assert.ErrorIs(t, test.item.Error, assert.AnError, clues.ToCore(test.item.Error))
+
+			vs := clues.InErr(test.item.Error).Map()
+			assert.Equal(t, "bid", vs[ErrCtxBackupID], "backup id")
+			assert.Equal(t, "folder", vs[ErrCtxLocation], "location")
+			assert.Equal(t, test.expectRepoRef, vs[ErrCtxRepoRef], "repo ref")
+		})
+	}
+}
+
+func (suite *ExportUnitSuite) TestItemError_nil() {
+	collPath, err := path.Build("t", "pr", path.OneDriveService, path.FilesCategory, false, "drive")
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	assert.NoError(suite.T(), ItemError(nil, collPath, "id"))
+}
@@ -0,0 +1,196 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/fault"
+)
+
+// ManifestFileName is the name of the file, written into the root of the
+// export location, that holds the manifest of the export.
+const ManifestFileName = ".corso-export-manifest.json"
+
+// ---------------------------------------------------------------------------
+// Manifest
+// ---------------------------------------------------------------------------
+
+// ManifestEntry records the state of a single item at the time it
+// was exported.
+type ManifestEntry struct {
+	ID       string    `json:"id"`
+	Modified time.Time `json:"modified"`
+	Size     int64     `json:"size"`
+}
+
+// Manifest records every item produced by an export.  Handing the manifest
+// of one export to a later export allows the later export to skip any
+// items which haven't changed in the meantime.
+type Manifest struct {
+	// Entries are keyed by the item's collection path and ID.
+	Entries map[string]ManifestEntry `json:"entries"`
+
+	mu sync.Mutex
+}
+
+func NewManifest() *Manifest {
+	return &Manifest{
+		Entries: map[string]ManifestEntry{},
+	}
+}
+
+// ReadManifest decodes a manifest previously written by Manifest.Write.
+func ReadManifest(r io.Reader) (*Manifest, error) {
+	m := NewManifest()
+
+	if err := json.NewDecoder(r).Decode(m); err != nil {
+		return nil, clues.Wrap(err, "decoding export manifest")
+	}
+
+	if m.Entries == nil {
+		m.Entries = map[string]ManifestEntry{}
+	}
+
+	return m, nil
+}
+
+// Write encodes the manifest into the writer.
+func (m *Manifest) Write(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := json.NewEncoder(w).Encode(m); err != nil {
+		return clues.Wrap(err, "encoding export manifest")
+	}
+
+	return nil
+}
+
+func (m *Manifest) add(key string, ent ManifestEntry) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.Entries[key] = ent
+}
+
+// unchanged returns true if the manifest contains an entry for the key
+// with the same modified time and size.  Entries without a modified time
+// are always considered changed, since we can't tell them apart.
+func (m *Manifest) unchanged(key string, ent ManifestEntry) bool {
+	if m == nil || ent.Modified.IsZero() {
+		return false
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	prev, ok := m.Entries[key]
+
+	return ok &&
+		prev.Size == ent.Size &&
+		prev.Modified.Equal(ent.Modified)
+}
+
+func manifestKey(rc data.RestoreCollection, itemID string) string {
+	return rc.FullPath().String() + "/" + itemID
+}
+
+// ---------------------------------------------------------------------------
+// Incremental collections
+// ---------------------------------------------------------------------------
+
+// IncrementalCollections wraps each restore collection so that every item it
+// produces gets recorded in the next manifest, and any item that matches its
+// entry in the previous manifest is left out of the export.  The number of
+// items left out is tallied in the counter under count.ExportItemUnchanged.
+// A nil prev manifest produces a full export.
+func IncrementalCollections(
+	dcs []data.RestoreCollection,
+	prev, next *Manifest,
+	ctr *count.Bus,
+) []data.RestoreCollection {
+	result := make([]data.RestoreCollection, 0, len(dcs))
+
+	for _, dc := range dcs {
+		result = append(result, manifestCollection{
+			RestoreCollection: dc,
+			prev:              prev,
+			next:              next,
+			ctr:               ctr,
+		})
+	}
+
+	return result
+}
+
+var _ data.RestoreCollection = manifestCollection{}
+
+// manifestCollection filters out items from the wrapped collection which
+// are unchanged since the previous manifest.  Items fetched by name are
+// passed through without filtering, since they are usually looked up as
+// supporting data (ex: drive item metadata) for items that did change.
+type manifestCollection struct {
+	data.RestoreCollection
+
+	prev *Manifest
+	next *Manifest
+	ctr  *count.Bus
+}
+
+func (mc manifestCollection) Items(
+	ctx context.Context,
+	errs *fault.Bus,
+) <-chan data.Item {
+	res := make(chan data.Item)
+
+	go func() {
+		defer close(res)
+
+		for item := range mc.RestoreCollection.Items(ctx, errs) {
+			var (
+				key = manifestKey(mc.RestoreCollection, item.ID())
+				ent = ManifestEntry{ID: item.ID()}
+			)
+
+			if ims, ok := item.(data.ItemModTime); ok {
+				ent.Modified = ims.ModTime()
+			}
+
+			if is, ok := item.(data.ItemSize); ok {
+				ent.Size = is.Size()
+			}
+
+			mc.next.add(key, ent)
+
+			if mc.prev.unchanged(key, ent) {
+				mc.ctr.Inc(count.ExportItemUnchanged)
+
+				// release the unused reader
+				if rc := item.ToReader(); rc != nil {
+					rc.Close()
+				}
+
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case res <- item:
+			}
+		}
+	}()
+
+	return res
+}
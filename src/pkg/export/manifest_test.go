@@ -0,0 +1,169 @@
+package export
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/data"
+	dataMock "github.com/alcionai/corso/src/internal/data/mock"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+type ManifestUnitSuite struct {
+	tester.Suite
+}
+
+func TestManifestUnitSuite(t *testing.T) {
+	suite.Run(t, &ManifestUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *ManifestUnitSuite) TestManifest_ReadWrite() {
+	t := suite.T()
+
+	m := NewManifest()
+	m.add("a/b/id1", ManifestEntry{
+		ID:       "id1",
+		Modified: time.Now().UTC().Truncate(time.Second),
+		Size:     42,
+	})
+
+	buf := &bytes.Buffer{}
+
+	err := m.Write(buf)
+	require.NoError(t, err, clues.ToCore(err))
+
+	result, err := ReadManifest(buf)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, m.Entries, result.Entries)
+
+	_, err = ReadManifest(bytes.NewBufferString("not json"))
+	assert.Error(t, err, clues.ToCore(err))
+}
+
+func (suite *ManifestUnitSuite) TestIncrementalCollections() {
+	var (
+		t       = suite.T()
+		now     = time.Now().UTC().Truncate(time.Second)
+		earlier = now.Add(-time.Hour)
+	)
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	p, err := path.Build("t", "pr", path.OneDriveService, path.FilesCategory, false, "folder")
+	require.NoError(t, err, clues.ToCore(err))
+
+	makeItem := func(id string, mod time.Time, size int64) data.Item {
+		return &dataMock.Item{
+			ItemID:       id,
+			ModifiedTime: mod,
+			ItemSize:     size,
+			Reader:       io.NopCloser(bytes.NewBufferString(id)),
+		}
+	}
+
+	backing := dataMock.Collection{
+		Path: p,
+		ItemData: []data.Item{
+			// matches the previous manifest
+			makeItem("unchanged", earlier, 10),
+			// modified since the previous manifest
+			makeItem("modified", now, 10),
+			// resized since the previous manifest
+			makeItem("resized", earlier, 20),
+			// not in the previous manifest
+			makeItem("new", now, 5),
+			// no mod time, so can't be compared
+			makeItem("nomodtime", time.Time{}, 1),
+		},
+	}
+
+	prev := NewManifest()
+
+	for _, ent := range []ManifestEntry{
+		{ID: "unchanged", Modified: earlier, Size: 10},
+		{ID: "modified", Modified: earlier, Size: 10},
+		{ID: "resized", Modified: earlier, Size: 10},
+		{ID: "nomodtime", Size: 1},
+		{ID: "deleted", Modified: earlier, Size: 3},
+	} {
+		prev.add(p.String()+"/"+ent.ID, ent)
+	}
+
+	var (
+		next = NewManifest()
+		ctr  = count.New()
+		dcs  = IncrementalCollections(
+			[]data.RestoreCollection{data.FetchRestoreCollection{Collection: backing}},
+			prev,
+			next,
+			ctr)
+		ids = []string{}
+	)
+
+	require.Len(t, dcs, 1)
+	assert.Equal(t, p, dcs[0].FullPath())
+
+	for item := range dcs[0].Items(ctx, fault.New(true)) {
+		ids = append(ids, item.ID())
+	}
+
+	assert.ElementsMatch(t, []string{"modified", "resized", "new", "nomodtime"}, ids)
+	assert.Equal(t, int64(1), ctr.Get(count.ExportItemUnchanged))
+
+	// the next manifest contains every item in the backing collection,
+	// including the skipped ones, and nothing that no longer exists.
+	assert.Len(t, next.Entries, 5)
+	assert.NotContains(t, next.Entries, p.String()+"/deleted")
+	assert.Equal(
+		t,
+		ManifestEntry{ID: "modified", Modified: now, Size: 10},
+		next.Entries[p.String()+"/modified"])
+}
+
+func (suite *ManifestUnitSuite) TestIncrementalCollections_noPrevious() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	p, err := path.Build("t", "pr", path.OneDriveService, path.FilesCategory, false, "folder")
+	require.NoError(t, err, clues.ToCore(err))
+
+	backing := dataMock.Collection{
+		Path: p,
+		ItemData: []data.Item{
+			&dataMock.Item{ItemID: "a", ModifiedTime: time.Now()},
+			&dataMock.Item{ItemID: "b", ModifiedTime: time.Now()},
+		},
+	}
+
+	var (
+		next = NewManifest()
+		ctr  = count.New()
+		dcs  = IncrementalCollections(
+			[]data.RestoreCollection{data.FetchRestoreCollection{Collection: backing}},
+			nil,
+			next,
+			ctr)
+		found int
+	)
+
+	for range dcs[0].Items(ctx, fault.New(true)) {
+		found++
+	}
+
+	assert.Equal(t, 2, found)
+	assert.Len(t, next.Entries, 2)
+	assert.Zero(t, ctr.Get(count.ExportItemUnchanged))
+}
@@ -0,0 +1,170 @@
+package export
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/alcionai/clues"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	"github.com/alcionai/corso/src/pkg/storage"
+)
+
+const (
+	defaultS3Endpoint = "s3.amazonaws.com"
+
+	// s3PartSize bounds the memory used to buffer each item while it is
+	// uploaded.  Item sizes aren't known ahead of time, so every body is
+	// streamed as a multipart upload.
+	s3PartSize = 16 * 1024 * 1024
+)
+
+// S3Putter uploads objects to an s3 bucket.  It is satisfied by the
+// minio client.
+type S3Putter interface {
+	PutObject(
+		ctx context.Context,
+		bucket, key string,
+		body io.Reader,
+		size int64,
+		opts minio.PutObjectOptions,
+	) (minio.UploadInfo, error)
+}
+
+var _ Sink = &S3Sink{}
+
+// S3Sink streams exported items into an s3 bucket.  The folder hierarchy
+// of the export is preserved in the object keys.
+type S3Sink struct {
+	putter S3Putter
+	bucket string
+	prefix string
+}
+
+// NewS3Sink produces a sink that writes items into bucket, with keys
+// beneath prefix.  The endpoint, credentials, and tls settings are taken
+// from the s3 config of the storage, so exports can target any bucket
+// that is reachable with the repository's credentials.
+func NewS3Sink(
+	ctx context.Context,
+	s storage.Storage,
+	bucket, prefix string,
+) (*S3Sink, error) {
+	ctx = clues.Add(ctx, "export_bucket", bucket, "export_prefix", prefix)
+
+	if len(bucket) == 0 {
+		return nil, clues.New("missing export bucket").WithClues(ctx)
+	}
+
+	sc, err := s.StorageConfig()
+	if err != nil {
+		return nil, clues.Wrap(err, "getting storage config").WithClues(ctx)
+	}
+
+	cfg, ok := sc.(*storage.S3Config)
+	if !ok {
+		return nil, clues.New("exporting to s3 requires an s3 repository").WithClues(ctx)
+	}
+
+	client, err := newMinioClient(cfg)
+	if err != nil {
+		return nil, clues.Stack(err).WithClues(ctx)
+	}
+
+	return newS3Sink(client, bucket, prefix), nil
+}
+
+func newS3Sink(p S3Putter, bucket, prefix string) *S3Sink {
+	return &S3Sink{
+		putter: p,
+		bucket: bucket,
+		prefix: strings.Trim(prefix, "/"),
+	}
+}
+
+func newMinioClient(cfg *storage.S3Config) (*minio.Client, error) {
+	endpoint := defaultS3Endpoint
+	if len(cfg.Endpoint) > 0 {
+		endpoint = cfg.Endpoint
+	}
+
+	creds := credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, cfg.SessionToken)
+
+	// fall back to the same sources the aws sdk checks when no keys
+	// were provided.
+	if len(cfg.AccessKey) == 0 {
+		creds = credentials.NewChainCredentials([]credentials.Provider{
+			&credentials.EnvAWS{},
+			&credentials.FileAWSCredentials{},
+			&credentials.IAM{Client: &http.Client{Transport: http.DefaultTransport}},
+		})
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		//nolint:gosec
+		InsecureSkipVerify: cfg.DoNotVerifyTLS || cfg.InsecureSkipVerify,
+	}
+
+	if len(cfg.CABundlePath) > 0 {
+		ca, err := os.ReadFile(cfg.CABundlePath)
+		if err != nil {
+			return nil, clues.Wrap(err, "reading s3 ca bundle").With("ca_bundle_path", cfg.CABundlePath)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, clues.New("s3 ca bundle contains no pem certificates").
+				With("ca_bundle_path", cfg.CABundlePath)
+		}
+
+		transport.TLSClientConfig.RootCAs = pool
+	}
+
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds:     creds,
+		Secure:    !cfg.DoNotUseTLS,
+		Transport: transport,
+	})
+	if err != nil {
+		return nil, clues.Wrap(err, "creating s3 client").With("endpoint", endpoint)
+	}
+
+	return client, nil
+}
+
+// key produces the object key for the item named name within dir.
+func (s *S3Sink) key(dir, name string) string {
+	return path.Join(s.prefix, dir, name)
+}
+
+func (s *S3Sink) Write(
+	ctx context.Context,
+	dir, name string,
+	body io.Reader,
+) error {
+	key := s.key(dir, name)
+
+	_, err := s.putter.PutObject(
+		ctx,
+		s.bucket,
+		key,
+		body,
+		-1,
+		minio.PutObjectOptions{PartSize: s3PartSize})
+	if err != nil {
+		return clues.Wrap(err, "uploading item").
+			WithClues(ctx).
+			With("export_bucket", s.bucket, "object_key", clues.Hide(key))
+	}
+
+	return nil
+}
@@ -0,0 +1,268 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/credentials"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/storage"
+)
+
+type mockPutter struct {
+	mu      sync.Mutex
+	buckets []string
+	bodies  map[string]string
+	err     error
+}
+
+func (mp *mockPutter) PutObject(
+	_ context.Context,
+	bucket, key string,
+	body io.Reader,
+	_ int64,
+	_ minio.PutObjectOptions,
+) (minio.UploadInfo, error) {
+	if mp.err != nil {
+		return minio.UploadInfo{}, mp.err
+	}
+
+	bs, err := io.ReadAll(body)
+	if err != nil {
+		return minio.UploadInfo{}, err
+	}
+
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+
+	if mp.bodies == nil {
+		mp.bodies = map[string]string{}
+	}
+
+	mp.buckets = append(mp.buckets, bucket)
+	mp.bodies[key] = string(bs)
+
+	return minio.UploadInfo{Bucket: bucket, Key: key, Size: int64(len(bs))}, nil
+}
+
+type S3SinkUnitSuite struct {
+	tester.Suite
+}
+
+func TestS3SinkUnitSuite(t *testing.T) {
+	suite.Run(t, &S3SinkUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *S3SinkUnitSuite) TestConsumeExportCollectionsToSink() {
+	type ei struct {
+		name string
+		body string
+	}
+
+	type i struct {
+		path  string
+		items []ei
+	}
+
+	table := []struct {
+		name   string
+		prefix string
+		cols   []i
+		expect map[string]string
+	}{
+		{
+			name: "root collection",
+			cols: []i{
+				{
+					path:  "",
+					items: []ei{{name: "name1", body: "body1"}},
+				},
+			},
+			expect: map[string]string{
+				"name1": "body1",
+			},
+		},
+		{
+			name: "nested collections",
+			cols: []i{
+				{
+					path: "",
+					items: []ei{
+						{name: "name1", body: "body1"},
+						{name: "name2", body: "body2"},
+					},
+				},
+				{
+					path:  "folder",
+					items: []ei{{name: "name3", body: "body3"}},
+				},
+				{
+					path:  "folder/sub",
+					items: []ei{{name: "name4", body: "body4"}},
+				},
+			},
+			expect: map[string]string{
+				"name1":            "body1",
+				"name2":            "body2",
+				"folder/name3":     "body3",
+				"folder/sub/name4": "body4",
+			},
+		},
+		{
+			name:   "with prefix",
+			prefix: "/exports/run1/",
+			cols: []i{
+				{
+					path:  "",
+					items: []ei{{name: "name1", body: "body1"}},
+				},
+				{
+					path:  "folder",
+					items: []ei{{name: "name2", body: "body2"}},
+				},
+			},
+			expect: map[string]string{
+				"exports/run1/name1":        "body1",
+				"exports/run1/folder/name2": "body2",
+			},
+		},
+	}
+
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			ecs := []Collectioner{}
+
+			for _, col := range test.cols {
+				items := []Item{}
+				for _, item := range col.items {
+					items = append(items, Item{
+						Name: item.name,
+						Body: io.NopCloser(bytes.NewBufferString(item.body)),
+					})
+				}
+
+				ecs = append(ecs, mockExportCollection{
+					path:  col.path,
+					items: items,
+				})
+			}
+
+			mp := &mockPutter{}
+			sink := newS3Sink(mp, "bucket", test.prefix)
+
+			err := ConsumeExportCollectionsToSink(ctx, sink, ecs, fault.New(true))
+			require.NoError(t, err, clues.ToCore(err))
+
+			assert.Equal(t, test.expect, mp.bodies)
+
+			for _, b := range mp.buckets {
+				assert.Equal(t, "bucket", b)
+			}
+		})
+	}
+}
+
+func (suite *S3SinkUnitSuite) TestConsumeExportCollectionsToSink_putError() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	ecs := []Collectioner{
+		mockExportCollection{
+			path: "folder",
+			items: []Item{{
+				Name: "name1",
+				Body: io.NopCloser(bytes.NewBufferString("body1")),
+			}},
+		},
+	}
+
+	mp := &mockPutter{err: assert.AnError}
+	errs := fault.New(false)
+
+	err := ConsumeExportCollectionsToSink(ctx, newS3Sink(mp, "bucket", ""), ecs, errs)
+	require.NoError(t, err, clues.ToCore(err))
+
+	recovered := errs.Recovered()
+	require.Len(t, recovered, 1)
+	assert.ErrorIs(t, recovered[0], assert.AnError)
+}
+
+func (suite *S3SinkUnitSuite) TestNewS3Sink() {
+	s3Storage, err := storage.NewStorage(
+		storage.ProviderS3,
+		&storage.S3Config{
+			AWS: credentials.AWS{
+				AccessKey: "access",
+				SecretKey: "secret",
+			},
+			Bucket:   "repo-bucket",
+			Endpoint: "localhost:9000",
+		})
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	fsStorage, err := storage.NewStorage(
+		storage.ProviderFilesystem,
+		&storage.FilesystemConfig{Path: suite.T().TempDir()})
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	table := []struct {
+		name      string
+		storage   storage.Storage
+		bucket    string
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "s3 storage",
+			storage:   s3Storage,
+			bucket:    "export-bucket",
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "missing bucket",
+			storage:   s3Storage,
+			expectErr: assert.Error,
+		},
+		{
+			name:      "filesystem storage",
+			storage:   fsStorage,
+			bucket:    "export-bucket",
+			expectErr: assert.Error,
+		},
+	}
+
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			sink, err := NewS3Sink(ctx, test.storage, test.bucket, "prefix")
+			test.expectErr(t, err, clues.ToCore(err))
+
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, test.bucket, sink.bucket)
+			assert.Equal(t, "prefix/folder/name", sink.key("folder", "name"))
+		})
+	}
+}
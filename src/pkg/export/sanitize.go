@@ -0,0 +1,136 @@
+package export
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/alcionai/corso/src/pkg/control"
+)
+
+const nameReplacement = "_"
+
+// windowsReservedNames are device names that windows refuses as filenames,
+// with or without an extension.
+var windowsReservedNames = map[string]struct{}{
+	"CON": {}, "PRN": {}, "AUX": {}, "NUL": {},
+	"COM1": {}, "COM2": {}, "COM3": {}, "COM4": {}, "COM5": {},
+	"COM6": {}, "COM7": {}, "COM8": {}, "COM9": {},
+	"LPT1": {}, "LPT2": {}, "LPT3": {}, "LPT4": {}, "LPT5": {},
+	"LPT6": {}, "LPT7": {}, "LPT8": {}, "LPT9": {},
+}
+
+// sanitizeName rewrites the name into a legal filename according to the
+// rules of the sanitizer.
+func sanitizeName(ns control.NameSanitizer, name string) string {
+	switch ns {
+	case control.NoNameSanitizer:
+		return name
+	case control.POSIXNameSanitizer:
+		return sanitizePOSIXName(name)
+	default:
+		return sanitizeWindowsName(name)
+	}
+}
+
+// sanitizeWindowsName replaces reserved and control characters, trailing
+// dots and spaces, and reserved device names, eg: "CON.txt" => "CON_.txt".
+func sanitizeWindowsName(name string) string {
+	var sb strings.Builder
+
+	for _, r := range name {
+		if r < 0x20 || strings.ContainsRune(`<>:"/\|?*`, r) {
+			sb.WriteString(nameReplacement)
+			continue
+		}
+
+		sb.WriteRune(r)
+	}
+
+	name = sb.String()
+
+	// windows silently drops trailing dots and spaces.
+	trimmed := strings.TrimRight(name, ". ")
+	name = trimmed + strings.Repeat(nameReplacement, len(name)-len(trimmed))
+
+	if len(name) == 0 {
+		return nameReplacement
+	}
+
+	base, ext, _ := strings.Cut(name, ".")
+	if _, ok := windowsReservedNames[strings.ToUpper(base)]; ok {
+		name = base + nameReplacement
+		if len(ext) > 0 {
+			name += "." + ext
+		}
+	}
+
+	return name
+}
+
+// sanitizePOSIXName replaces path separators and null bytes, and the names
+// that refer to the current or parent directory.
+func sanitizePOSIXName(name string) string {
+	name = strings.NewReplacer("/", nameReplacement, "\x00", nameReplacement).Replace(name)
+
+	switch name {
+	case "":
+		return nameReplacement
+	case ".", "..":
+		return strings.Repeat(nameReplacement, len(name))
+	}
+
+	return name
+}
+
+// nameCollisionKey produces the key under which the name is compared with
+// other names in the same collection.  Windows filenames are case
+// insensitive.
+func nameCollisionKey(ns control.NameSanitizer, name string) string {
+	if ns == control.POSIXNameSanitizer {
+		return name
+	}
+
+	return strings.ToLower(name)
+}
+
+// withSanitizedNames forwards every item from in to out, sanitizing the
+// names of successful items.  Names that collide with one already produced
+// by the collection get a numbered suffix, eg: "a:b.txt" and "a_b.txt" =>
+// "a_b.txt" and "a_b (1).txt".  Closes out once in is closed.
+func withSanitizedNames(in <-chan Item, out chan<- Item, ns control.NameSanitizer) {
+	defer close(out)
+
+	seen := map[string]struct{}{}
+
+	for item := range in {
+		if item.Error != nil || ns == control.NoNameSanitizer {
+			out <- item
+			continue
+		}
+
+		var (
+			name = sanitizeName(ns, item.Name)
+			ext  = filepath.Ext(name)
+			base = strings.TrimSuffix(name, ext)
+		)
+
+		// dotfiles, eg: ".config", have no extension.
+		if len(base) == 0 {
+			base, ext = name, ""
+		}
+
+		for i := 1; ; i++ {
+			if _, ok := seen[nameCollisionKey(ns, name)]; !ok {
+				break
+			}
+
+			name = fmt.Sprintf("%s (%d)%s", base, i, ext)
+		}
+
+		seen[nameCollisionKey(ns, name)] = struct{}{}
+		item.Name = name
+
+		out <- item
+	}
+}
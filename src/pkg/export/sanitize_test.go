@@ -0,0 +1,178 @@
+package export
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control"
+)
+
+type SanitizeUnitSuite struct {
+	tester.Suite
+}
+
+func TestSanitizeUnitSuite(t *testing.T) {
+	suite.Run(t, &SanitizeUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *SanitizeUnitSuite) TestSanitizeName() {
+	table := []struct {
+		name          string
+		input         string
+		expectWindows string
+		expectPOSIX   string
+	}{
+		{
+			name:          "legal name",
+			input:         "file.txt",
+			expectWindows: "file.txt",
+			expectPOSIX:   "file.txt",
+		},
+		{
+			name:          "reserved characters",
+			input:         `a<b>c:d"e\f|g?h*i.txt`,
+			expectWindows: "a_b_c_d_e_f_g_h_i.txt",
+			expectPOSIX:   `a<b>c:d"e\f|g?h*i.txt`,
+		},
+		{
+			name:          "path separator",
+			input:         "a/b.txt",
+			expectWindows: "a_b.txt",
+			expectPOSIX:   "a_b.txt",
+		},
+		{
+			name:          "control characters",
+			input:         "a\x00b\tc.txt",
+			expectWindows: "a_b_c.txt",
+			expectPOSIX:   "a_b\tc.txt",
+		},
+		{
+			name:          "trailing dots and spaces",
+			input:         "file. .",
+			expectWindows: "file___",
+			expectPOSIX:   "file. .",
+		},
+		{
+			name:          "reserved device name",
+			input:         "CON",
+			expectWindows: "CON_",
+			expectPOSIX:   "CON",
+		},
+		{
+			name:          "reserved device name with extension",
+			input:         "con.tar.gz",
+			expectWindows: "con_.tar.gz",
+			expectPOSIX:   "con.tar.gz",
+		},
+		{
+			name:          "numbered device name",
+			input:         "LPT1.txt",
+			expectWindows: "LPT1_.txt",
+			expectPOSIX:   "LPT1.txt",
+		},
+		{
+			name:          "device name prefix",
+			input:         "CONTACTS.txt",
+			expectWindows: "CONTACTS.txt",
+			expectPOSIX:   "CONTACTS.txt",
+		},
+		{
+			name:          "relative directory",
+			input:         "..",
+			expectWindows: "__",
+			expectPOSIX:   "__",
+		},
+		{
+			name:          "empty",
+			input:         "",
+			expectWindows: "_",
+			expectPOSIX:   "_",
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			assert.Equal(t, test.expectWindows, sanitizeName(control.DefaultNameSanitizer, test.input), "default")
+			assert.Equal(t, test.expectWindows, sanitizeName(control.WindowsNameSanitizer, test.input), "windows")
+			assert.Equal(t, test.expectPOSIX, sanitizeName(control.POSIXNameSanitizer, test.input), "posix")
+			assert.Equal(t, test.input, sanitizeName(control.NoNameSanitizer, test.input), "none")
+		})
+	}
+}
+
+func (suite *SanitizeUnitSuite) TestCollectionItems_sanitizedNames() {
+	table := []struct {
+		name      string
+		sanitizer control.NameSanitizer
+		input     []string
+		expect    []string
+	}{
+		{
+			name:      "windows collisions",
+			sanitizer: control.WindowsNameSanitizer,
+			input:     []string{"a:b.txt", "a_b.txt", "A?b.txt", "CON", "con_", ".config", "_config"},
+			expect:    []string{"a_b.txt", "a_b (1).txt", "A_b (2).txt", "CON_", "con_ (1)", ".config", "_config"},
+		},
+		{
+			name:      "posix collisions",
+			sanitizer: control.POSIXNameSanitizer,
+			input:     []string{"a/b.txt", "a_b.txt", "A_b.txt", "a:b.txt"},
+			expect:    []string{"a_b.txt", "a_b (1).txt", "A_b.txt", "a:b.txt"},
+		},
+		{
+			name:      "no sanitizer",
+			sanitizer: control.NoNameSanitizer,
+			input:     []string{"a:b.txt", "a:b.txt"},
+			expect:    []string{"a:b.txt", "a:b.txt"},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			bc := BaseCollection{
+				Cfg: control.ExportConfig{NameSanitizer: test.sanitizer},
+				Stream: func(
+					_ context.Context,
+					_ []data.RestoreCollection,
+					_ int,
+					_ control.ExportConfig,
+					ch chan<- Item,
+				) {
+					defer close(ch)
+
+					// failed items pass through untouched
+					ch <- Item{ID: "failed", Name: "a:b.txt", Error: assert.AnError}
+
+					for _, name := range test.input {
+						ch <- Item{ID: name, Name: name}
+					}
+				},
+			}
+
+			names := []string{}
+
+			for item := range bc.Items(ctx) {
+				if item.Error != nil {
+					assert.Equal(t, "a:b.txt", item.Name, "failed item name")
+					continue
+				}
+
+				names = append(names, item.Name)
+			}
+
+			require.Len(t, names, len(test.expect))
+			assert.Equal(t, test.expect, names)
+		})
+	}
+}
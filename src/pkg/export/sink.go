@@ -0,0 +1,80 @@
+package export
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/alcionai/clues"
+)
+
+// Sink is the destination that exported items get written to.
+type Sink interface {
+	// Write stores the contents of body as the item named name within
+	// dir.  The dir is a slash-separated path relative to the root of
+	// the sink, and is empty for items at the root.
+	Write(ctx context.Context, dir, name string, body io.Reader) error
+}
+
+// DirSink is implemented by sinks which can hold empty folders.  Sinks
+// without folders of their own, such as object stores, skip them.
+type DirSink interface {
+	// MakeDir creates the folder, which is a slash-separated path relative
+	// to the root of the sink.
+	MakeDir(ctx context.Context, dir string) error
+}
+
+var (
+	_ Sink    = FilesystemSink{}
+	_ DirSink = FilesystemSink{}
+)
+
+// FilesystemSink writes exported items into a directory on local disk.
+type FilesystemSink struct {
+	// Root is the directory which contains the exported items.
+	Root string
+}
+
+// NewFilesystemSink produces a sink that writes items beneath root.
+func NewFilesystemSink(root string) FilesystemSink {
+	return FilesystemSink{Root: root}
+}
+
+func (fs FilesystemSink) MakeDir(ctx context.Context, dir string) error {
+	err := os.MkdirAll(filepath.Join(fs.Root, filepath.FromSlash(dir)), os.ModePerm)
+	if err != nil {
+		return clues.Wrap(err, "creating directory").WithClues(ctx)
+	}
+
+	return nil
+}
+
+func (fs FilesystemSink) Write(
+	ctx context.Context,
+	dir, name string,
+	body io.Reader,
+) error {
+	folder := filepath.Join(fs.Root, filepath.FromSlash(dir))
+
+	err := os.MkdirAll(folder, os.ModePerm)
+	if err != nil {
+		return clues.Wrap(err, "creating directory").WithClues(ctx)
+	}
+
+	// In case the user tries to restore to a non-clean
+	// directory, we might run into collisions an fail.
+	f, err := os.Create(filepath.Join(folder, name))
+	if err != nil {
+		return clues.Wrap(err, "creating file").WithClues(ctx)
+	}
+
+	defer f.Close()
+
+	_, err = io.Copy(f, body)
+	if err != nil {
+		return clues.Wrap(err, "writing data").WithClues(ctx)
+	}
+
+	return nil
+}
@@ -4,7 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/alcionai/clues"
@@ -31,6 +34,15 @@ type Bus struct {
 	// we'd expect to see 1 error added to this slice.
 	recoverable []error
 
+	// maxRecoverable caps the number of errors retained in the
+	// recoverable slice.  Errors added beyond the cap are only
+	// counted in recoverableOverflow.  Zero means unbounded.
+	maxRecoverable int
+
+	// recoverableOverflow counts the recoverable errors that were
+	// dropped after the recoverable slice reached maxRecoverable.
+	recoverableOverflow int
+
 	// skipped is the accumulation of skipped items.  Skipped items
 	// are not errors themselves, but instead represent some permanent
 	// inability to process an item, due to a well-known cause.
@@ -41,8 +53,29 @@ type Bus struct {
 	// non-recoverable processing state, causing any running
 	// processes to exit.
 	failFast bool
+
+	// subscribers get called as each recoverable error or
+	// skipped item is added to the bus.  subMu serializes their
+	// calls, since additions can arrive from many local buses.
+	subMu       sync.Mutex
+	subscribers []Subscriber
+
+	// operationID correlates the errors with the logs of the operation
+	// that produced them.
+	operationID string
 }
 
+// kinds of events passed to subscribers.
+const (
+	EventRecoverable = "recoverable"
+	EventSkip        = "skip"
+)
+
+// Subscriber receives each recoverable error or skipped item as it gets
+// added to the bus.  Kind is one of EventRecoverable or EventSkip.  Only
+// err is populated for recoverable errors, and only skip for skipped items.
+type Subscriber func(kind string, err error, skip *Skipped)
+
 // New constructs a new error with default values in place.
 func New(failFast bool) *Bus {
 	return &Bus{
@@ -52,6 +85,61 @@ func New(failFast bool) *Bus {
 	}
 }
 
+// NewBounded constructs a new bus that retains at most maxRecoverable
+// recoverable errors.  Once the cap is reached, further recoverable errors
+// are still logged and passed to subscribers, but are only tallied in the
+// overflow count instead of retained.  A maxRecoverable below 1 produces an
+// unbounded bus, same as New.
+func NewBounded(failFast bool, maxRecoverable int) *Bus {
+	e := New(failFast)
+
+	if maxRecoverable > 0 {
+		e.maxRecoverable = maxRecoverable
+	}
+
+	return e
+}
+
+// Subscribe registers fn to be called whenever a recoverable error or
+// skipped item is added to the bus, including additions made through
+// local buses.  Calls are made one at a time, after the bus records the
+// event and releases its lock, and block the caller of the addition
+// until they return.  Subscribers may read from or fail the bus, but
+// must not add errors or skips to it themselves.  A nil fn is ignored.
+func (e *Bus) Subscribe(fn Subscriber) {
+	if fn == nil {
+		return
+	}
+
+	e.subMu.Lock()
+	defer e.subMu.Unlock()
+
+	e.subscribers = append(e.subscribers, fn)
+}
+
+// notify passes the event to each subscriber.
+func (e *Bus) notify(kind string, err error, skip *Skipped) {
+	e.subMu.Lock()
+	defer e.subMu.Unlock()
+
+	for _, fn := range e.subscribers {
+		fn(kind, err, skip)
+	}
+}
+
+// SetOperationID records the id of the operation that owns the bus,
+// which gets included in the bus's Errors.
+func (e *Bus) SetOperationID(id string) *Bus {
+	e.operationID = id
+	return e
+}
+
+// OperationID returns the id of the operation that owns the bus, if
+// one was set.
+func (e *Bus) OperationID() string {
+	return e.operationID
+}
+
 // FailFast returns the failFast flag in the bus.
 func (e *Bus) FailFast() bool {
 	return e.failFast
@@ -71,6 +159,13 @@ func (e *Bus) Recovered() []error {
 	return slices.Clone(e.recoverable)
 }
 
+// RecoveredOverflow returns the count of recoverable errors that
+// were dropped, instead of retained, because the bus reached its
+// cap.  Always zero for unbounded buses.
+func (e *Bus) RecoveredOverflow() int {
+	return e.recoverableOverflow
+}
+
 // Skipped returns the slice of items that were permanently
 // skipped during processing.
 func (e *Bus) Skipped() []Skipped {
@@ -107,11 +202,23 @@ func (e *Bus) setFailure(err error) *Bus {
 	// technically not a recoverable error: we're using the
 	// recoverable slice as an overflow container here to
 	// ensure everything is tracked.
-	e.recoverable = append(e.recoverable, err)
+	e.appendRecoverable(err)
 
 	return e
 }
 
+// appendRecoverable adds the error to the recoverable slice, or tallies
+// it as overflow if the slice is at its cap.  Sync locking gets handled
+// upstream of this call.
+func (e *Bus) appendRecoverable(err error) {
+	if e.maxRecoverable > 0 && len(e.recoverable) >= e.maxRecoverable {
+		e.recoverableOverflow++
+		return
+	}
+
+	e.recoverable = append(e.recoverable, err)
+}
+
 // AddRecoverable appends the error to the slice of recoverable
 // errors (ie: bus.recoverable).  If failFast is true, the first
 // added error will get copied to bus.failure, causing the bus
@@ -125,9 +232,10 @@ func (e *Bus) AddRecoverable(ctx context.Context, err error) {
 	}
 
 	e.mu.Lock()
-	defer e.mu.Unlock()
-
 	e.logAndAddRecoverable(ctx, err, 1)
+	e.mu.Unlock()
+
+	e.notify(EventRecoverable, err, nil)
 }
 
 // logs the error and adds it to the bus.  If the error is a failure,
@@ -155,7 +263,7 @@ func (e *Bus) addRecoverableErr(err error) bool {
 		isFail = true
 	}
 
-	e.recoverable = append(e.recoverable, err)
+	e.appendRecoverable(err)
 
 	return isFail
 }
@@ -176,19 +284,50 @@ func (e *Bus) AddSkip(ctx context.Context, s *Skipped) {
 	}
 
 	e.mu.Lock()
-	defer e.mu.Unlock()
-
 	e.logAndAddSkip(ctx, s, 1)
+	e.mu.Unlock()
+
+	e.notify(EventSkip, nil, s)
 }
 
+// structured log fields describing a skipped item.  Alerting can
+// depend on these, so they shouldn't change without good reason.
+const (
+	LogSkippedCause     = "skipped_cause"
+	LogSkippedType      = "skipped_type"
+	LogSkippedNamespace = "skipped_namespace"
+	LogSkippedID        = "skipped_id"
+	LogSkippedService   = "skipped_service"
+)
+
 // logs the error and adds a skipped item.
 func (e *Bus) logAndAddSkip(ctx context.Context, s *Skipped, skip int) {
 	logger.CtxStack(ctx, skip+1).
-		With("skipped", s).
+		With(skippedLogFields(ctx, s)...).
 		Info("recoverable error")
 	e.addSkip(s)
 }
 
+// skippedLogFields produces the skipped item's details as individual
+// string fields.  The item's name is left out, since it may contain
+// pii.  The service comes from the ctx, and is empty if the ctx
+// doesn't name one.
+func skippedLogFields(ctx context.Context, s *Skipped) []any {
+	var service string
+
+	if v, ok := clues.In(ctx).Map()["service"]; ok && v != nil {
+		service = fmt.Sprintf("%v", v)
+	}
+
+	return []any{
+		LogSkippedCause, s.Item.Cause,
+		LogSkippedType, string(s.Item.Type),
+		LogSkippedNamespace, s.Item.Namespace,
+		LogSkippedID, s.Item.ID,
+		LogSkippedService, service,
+	}
+}
+
 func (e *Bus) addSkip(s *Skipped) *Bus {
 	e.skipped = append(e.skipped, *s)
 	return e
@@ -205,6 +344,9 @@ func (e *Bus) Errors() *Errors {
 		Items:     items,
 		Skipped:   slices.Clone(e.skipped),
 		FailFast:  e.failFast,
+
+		RecoveredOverflow: e.recoverableOverflow,
+		OperationID:       e.operationID,
 	}
 }
 
@@ -268,6 +410,15 @@ type Errors struct {
 	// If FailFast is true, then the first Recoverable error will
 	// promote to the Failure spot, causing processing to exit.
 	FailFast bool `json:"failFast"`
+
+	// RecoveredOverflow counts the recoverable errors that weren't
+	// retained, and are therefore missing from Recovered and Items,
+	// because the bus that produced the errors was bounded.
+	RecoveredOverflow int `json:"recoveredOverflow,omitempty"`
+
+	// OperationID identifies the operation that produced the errors.
+	// Search the logs for the same operation_id to find their context.
+	OperationID string `json:"operationID,omitempty"`
 }
 
 // itemsIn reduces all errors (both the failure and recovered values)
@@ -345,6 +496,95 @@ func (e *Errors) PrintItems(ctx context.Context, ignoreErrors, ignoreSkips, igno
 	print.All(ctx, sl...)
 }
 
+// GroupByCause clusters the items by their root cause: the message of the
+// innermost error that caused the item to fail.  Items whose causes wrap
+// the same underlying error share a group.  Groups are keyed by the root
+// cause, and sorted by the item namespace and ID.
+func (e *Errors) GroupByCause() map[string][]Item {
+	groups := map[string][]Item{}
+
+	for _, i := range e.Items {
+		rc := rootCause(i.Cause)
+		groups[rc] = append(groups[rc], i)
+	}
+
+	for _, is := range groups {
+		slices.SortFunc(is, func(a, b Item) int {
+			return strings.Compare(a.dedupeID(), b.dedupeID())
+		})
+	}
+
+	return groups
+}
+
+// rootCause trims the context added by wrapping errors from the message,
+// leaving only the message of the innermost error.
+func rootCause(cause string) string {
+	cause = strings.TrimSpace(cause)
+
+	if i := strings.LastIndex(cause, ": "); i >= 0 {
+		cause = strings.TrimSpace(cause[i+2:])
+	}
+
+	if len(cause) == 0 {
+		return unknownCause
+	}
+
+	return cause
+}
+
+const unknownCause = "unknown cause"
+
+// PrintItemsByCause writes a summary of the failed items, grouped by their
+// root cause, to StdOut.  The most common causes are printed first.
+func (e *Errors) PrintItemsByCause(ctx context.Context) {
+	groups := e.GroupByCause()
+	if len(groups) == 0 {
+		return
+	}
+
+	cgs := make([]causeGroup, 0, len(groups))
+
+	for cause, is := range groups {
+		cgs = append(cgs, causeGroup{Cause: cause, Items: is})
+	}
+
+	slices.SortFunc(cgs, func(a, b causeGroup) int {
+		if len(a.Items) != len(b.Items) {
+			return len(b.Items) - len(a.Items)
+		}
+
+		return strings.Compare(a.Cause, b.Cause)
+	})
+
+	ps := make([]print.Printable, 0, len(cgs))
+	for _, cg := range cgs {
+		ps = append(ps, print.Printable(cg))
+	}
+
+	print.All(ctx, ps...)
+}
+
+var _ print.Printable = causeGroup{}
+
+// causeGroup is the printable summary of the items sharing a root cause.
+type causeGroup struct {
+	Cause string `json:"cause"`
+	Items []Item `json:"items"`
+}
+
+func (cg causeGroup) MinimumPrintable() any {
+	return cg
+}
+
+func (cg causeGroup) Headers() []string {
+	return []string{"Cause", "Failed Items"}
+}
+
+func (cg causeGroup) Values() []string {
+	return []string{cg.Cause, strconv.Itoa(len(cg.Items))}
+}
+
 var _ print.Printable = &printableErrCore{}
 
 type printableErrCore struct {
@@ -403,13 +643,15 @@ func (e *localBus) AddRecoverable(ctx context.Context, err error) {
 	}
 
 	e.mu.Lock()
-	defer e.mu.Unlock()
 
 	if e.current == nil && e.bus.failFast {
 		e.current = err
 	}
 
 	e.bus.logAndAddRecoverable(ctx, err, 1)
+	e.mu.Unlock()
+
+	e.bus.notify(EventRecoverable, err, nil)
 }
 
 // AddSkip appends a record of a Skipped item to the local bus.
@@ -428,9 +670,10 @@ func (e *localBus) AddSkip(ctx context.Context, s *Skipped) {
 	}
 
 	e.mu.Lock()
-	defer e.mu.Unlock()
-
 	e.bus.logAndAddSkip(ctx, s, 1)
+	e.mu.Unlock()
+
+	e.bus.notify(EventSkip, nil, s)
 }
 
 // Failure returns the failure that happened within the local bus.
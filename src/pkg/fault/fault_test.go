@@ -3,15 +3,21 @@ package fault_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/alcionai/clues"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 
 	"github.com/alcionai/corso/src/internal/tester"
 	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
 )
 
 type FaultErrorsUnitSuite struct {
@@ -189,6 +195,98 @@ func (suite *FaultErrorsUnitSuite) TestAdd() {
 	assert.Len(t, n.Recovered(), 2)
 }
 
+func (suite *FaultErrorsUnitSuite) TestNewBounded() {
+	table := []struct {
+		name           string
+		maxRecoverable int
+		add            int
+		expectRetained int
+		expectOverflow int
+	}{
+		{
+			name:           "under the cap",
+			maxRecoverable: 3,
+			add:            2,
+			expectRetained: 2,
+		},
+		{
+			name:           "at the cap",
+			maxRecoverable: 3,
+			add:            3,
+			expectRetained: 3,
+		},
+		{
+			name:           "over the cap",
+			maxRecoverable: 3,
+			add:            10,
+			expectRetained: 3,
+			expectOverflow: 7,
+		},
+		{
+			name:           "unbounded",
+			maxRecoverable: 0,
+			add:            10,
+			expectRetained: 10,
+		},
+		{
+			name:           "negative cap is unbounded",
+			maxRecoverable: -1,
+			add:            10,
+			expectRetained: 10,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				n        = fault.NewBounded(false, test.maxRecoverable)
+				el       = n.Local()
+				notified int
+			)
+
+			n.Subscribe(func(string, error, *fault.Skipped) { notified++ })
+
+			for i := 0; i < test.add; i++ {
+				if i%2 == 0 {
+					n.AddRecoverable(ctx, clues.New("bus"))
+				} else {
+					el.AddRecoverable(ctx, clues.New("local"))
+				}
+			}
+
+			assert.NoError(t, n.Failure())
+			assert.Len(t, n.Recovered(), test.expectRetained, "retained errors")
+			assert.Equal(t, test.expectOverflow, n.RecoveredOverflow(), "overflow")
+			assert.Equal(t, test.add, notified, "subscriber notifications")
+
+			errs := n.Errors()
+			assert.Len(t, errs.Recovered, test.expectRetained, "retained errors")
+			assert.Equal(t, test.expectOverflow, errs.RecoveredOverflow, "overflow")
+		})
+	}
+}
+
+func (suite *FaultErrorsUnitSuite) TestNewBounded_failFast() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	n := fault.NewBounded(true, 1)
+
+	n.AddRecoverable(ctx, clues.New("first"))
+	n.AddRecoverable(ctx, clues.New("second"))
+	n.Fail(clues.New("third"))
+
+	assert.ErrorContains(t, n.Failure(), "first")
+	assert.Len(t, n.Recovered(), 1)
+	assert.Equal(t, 2, n.RecoveredOverflow())
+}
+
 func (suite *FaultErrorsUnitSuite) TestAddSkip() {
 	t := suite.T()
 
@@ -208,6 +306,174 @@ func (suite *FaultErrorsUnitSuite) TestAddSkip() {
 	assert.Len(t, n.Skipped(), 1)
 }
 
+func (suite *FaultErrorsUnitSuite) TestAddSkip_logFields() {
+	table := []struct {
+		name          string
+		ctxService    any
+		local         bool
+		expectService string
+	}{
+		{
+			name:          "bus",
+			ctxService:    "exchange",
+			expectService: "exchange",
+		},
+		{
+			name:          "local bus",
+			ctxService:    "onedrive",
+			local:         true,
+			expectService: "onedrive",
+		},
+		{
+			name:          "stringer service",
+			ctxService:    path.SharePointService,
+			expectService: path.SharePointService.String(),
+		},
+		{
+			name: "no service",
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			core, logs := observer.New(zapcore.InfoLevel)
+			ctx = logger.Set(ctx, zap.New(core).Sugar())
+
+			if test.ctxService != nil {
+				ctx = clues.Add(ctx, "service", test.ctxService)
+			}
+
+			n := fault.New(false)
+			skip := fault.FileSkip(fault.SkipMalware, "ns", "id", "name", nil)
+
+			if test.local {
+				n.Local().AddSkip(ctx, skip)
+			} else {
+				n.AddSkip(ctx, skip)
+			}
+
+			entries := logs.All()
+			require.Len(t, entries, 1)
+
+			fields := entries[0].ContextMap()
+			expect := map[string]string{
+				fault.LogSkippedCause:     string(fault.SkipMalware),
+				fault.LogSkippedType:      string(fault.FileType),
+				fault.LogSkippedNamespace: "ns",
+				fault.LogSkippedID:        "id",
+				fault.LogSkippedService:   test.expectService,
+			}
+
+			for k, v := range expect {
+				require.Contains(t, fields, k)
+				assert.IsType(t, "", fields[k], k)
+				assert.Equal(t, v, fields[k], k)
+			}
+
+			assert.NotContains(t, fields, "skipped", "unstructured skip")
+		})
+	}
+}
+
+type busEvent struct {
+	kind string
+	err  error
+	skip *fault.Skipped
+}
+
+func (suite *FaultErrorsUnitSuite) TestSubscribe() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		n      = fault.New(false)
+		first  []busEvent
+		second []busEvent
+		err1   = clues.New("err1")
+		err2   = clues.New("err2")
+		skip1  = fault.FileSkip(fault.SkipMalware, "ns", "id1", "name1", nil)
+		skip2  = fault.ContainerSkip(fault.SkipMalware, "ns", "id2", "name2", nil)
+	)
+
+	// events added before subscribing aren't replayed.
+	n.AddRecoverable(ctx, assert.AnError)
+
+	n.Subscribe(func(kind string, err error, skip *fault.Skipped) {
+		first = append(first, busEvent{kind, err, skip})
+	})
+	n.Subscribe(nil)
+	n.Subscribe(func(kind string, err error, skip *fault.Skipped) {
+		second = append(second, busEvent{kind, err, skip})
+	})
+
+	n.AddRecoverable(ctx, err1)
+	n.AddSkip(ctx, skip1)
+	n.Local().AddRecoverable(ctx, err2)
+	n.Local().AddSkip(ctx, skip2)
+
+	// nil additions are dropped before reaching subscribers.
+	n.AddRecoverable(ctx, nil)
+	n.AddSkip(ctx, nil)
+
+	expect := []busEvent{
+		{fault.EventRecoverable, err1, nil},
+		{fault.EventSkip, nil, skip1},
+		{fault.EventRecoverable, err2, nil},
+		{fault.EventSkip, nil, skip2},
+	}
+
+	assert.Equal(t, expect, first)
+	assert.Equal(t, expect, second)
+}
+
+func (suite *FaultErrorsUnitSuite) TestSubscribe_callsBackIntoBus() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	n := fault.New(false)
+
+	// subscribers are called without the bus locked, so they can fail it.
+	n.Subscribe(func(kind string, err error, skip *fault.Skipped) {
+		if kind == fault.EventRecoverable {
+			n.Fail(err)
+		}
+	})
+
+	n.AddRecoverable(ctx, assert.AnError)
+	n.AddSkip(ctx, fault.OwnerSkip(fault.SkipMalware, "ns", "id", "name", nil))
+
+	assert.ErrorIs(t, n.Failure(), assert.AnError)
+	assert.Len(t, n.Recovered(), 1)
+	assert.Len(t, n.Skipped(), 1)
+}
+
+func (suite *FaultErrorsUnitSuite) TestSubscribe_none() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	n := fault.New(true)
+	n.Subscribe(nil)
+
+	assert.NotPanics(t, func() {
+		n.AddRecoverable(ctx, assert.AnError)
+		n.AddSkip(ctx, fault.OwnerSkip(fault.SkipMalware, "ns", "id", "name", nil))
+	})
+
+	assert.Len(t, n.Recovered(), 1)
+	assert.Len(t, n.Skipped(), 1)
+	assert.ErrorIs(t, n.Failure(), assert.AnError)
+}
+
 func (suite *FaultErrorsUnitSuite) TestErrors() {
 	t := suite.T()
 
@@ -241,6 +507,29 @@ func (suite *FaultErrorsUnitSuite) TestErrors() {
 	assert.True(t, d.FailFast)
 }
 
+func (suite *FaultErrorsUnitSuite) TestErrors_operationID() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	n := fault.New(false)
+	assert.Empty(t, n.Errors().OperationID)
+
+	n.SetOperationID("op-id")
+	n.AddRecoverable(ctx, clues.New("1"))
+	assert.Equal(t, "op-id", n.OperationID())
+
+	bs, err := json.Marshal(n.Errors())
+	require.NoError(t, err, clues.ToCore(err))
+
+	d := fault.Errors{}
+
+	err = json.Unmarshal(bs, &d)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, "op-id", d.OperationID)
+}
+
 func (suite *FaultErrorsUnitSuite) TestErrors_Items() {
 	ae := clues.Stack(assert.AnError)
 	noncore := []*clues.ErrCore{ae.Core()}
@@ -393,6 +682,57 @@ func (suite *FaultErrorsUnitSuite) TestErrors_Items() {
 	}
 }
 
+func (suite *FaultErrorsUnitSuite) TestErrors_GroupByCause() {
+	var (
+		throttled = errors.New("too many requests")
+		notFound  = errors.New("item not found")
+
+		item = func(id string, cause error) fault.Item {
+			return *fault.FileErr(cause, "ns", id, "name-"+id, nil)
+		}
+
+		mailThrottled  = item("a", clues.Wrap(throttled, "getting mail"))
+		eventThrottled = item("b", clues.Wrap(clues.Wrap(throttled, "paging"), "getting event"))
+		fileThrottled  = item("c", throttled)
+		fileNotFound   = item("d", clues.Wrap(notFound, "downloading file"))
+		noCause        = fault.Item{Namespace: "ns", ID: "e", Type: fault.FileType}
+	)
+
+	table := []struct {
+		name   string
+		items  []fault.Item
+		expect map[string][]fault.Item
+	}{
+		{
+			name:   "no items",
+			items:  []fault.Item{},
+			expect: map[string][]fault.Item{},
+		},
+		{
+			name:  "shared cause",
+			items: []fault.Item{fileThrottled, eventThrottled, mailThrottled},
+			expect: map[string][]fault.Item{
+				"too many requests": {mailThrottled, eventThrottled, fileThrottled},
+			},
+		},
+		{
+			name:  "differing causes",
+			items: []fault.Item{fileNotFound, mailThrottled, noCause, fileThrottled},
+			expect: map[string][]fault.Item{
+				"too many requests": {mailThrottled, fileThrottled},
+				"item not found":    {fileNotFound},
+				"unknown cause":     {noCause},
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			errs := fault.Errors{Items: test.items}
+			assert.Equal(suite.T(), test.expect, errs.GroupByCause())
+		})
+	}
+}
+
 func (suite *FaultErrorsUnitSuite) TestMarshalUnmarshal() {
 	t := suite.T()
 
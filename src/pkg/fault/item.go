@@ -177,6 +177,28 @@ const (
 	//nolint:lll
 	// https://support.microsoft.com/en-us/office/restrictions-and-limitations-in-onedrive-and-sharepoint-64883a5d-228e-48f5-b3d2-eb39e07630fa#onenotenotebooks
 	SkipBigOneNote skipCause = "big_one_note_file"
+
+	// SkipOneNote identifies that a OneNote file was skipped because graph
+	// refused to serve its content.  This is a known limitation: OneNote
+	// sections and notebooks are stored in drives, but can't always be
+	// downloaded through the drive APIs.
+	SkipOneNote skipCause = "one_note_file"
+
+	// SkipSystemFile identifies that a file was skipped because its name
+	// matched one of the file exclusion patterns provided by the caller.
+	// Ex: Desktop.ini, Thumbs.db, or .DS_Store.
+	SkipSystemFile skipCause = "system_file"
+
+	// SkipZeroByteFile identifies that a file was skipped because it held
+	// no content, and the caller asked for empty files to be left out.
+	// Zero-byte files are often placeholders that graph fails to download.
+	SkipZeroByteFile skipCause = "zero_byte_file"
+
+	// SkipLinkShare identifies that a sharing link on a restored item
+	// wasn't recreated because graph can't produce an equivalent link.
+	// Ex: the link expired, or it was protected by a password that
+	// backups can't retrieve.
+	SkipLinkShare skipCause = "link_share_not_restorable"
 )
 
 var _ print.Printable = &Skipped{}
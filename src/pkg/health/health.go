@@ -0,0 +1,295 @@
+// Package health exposes liveness and status endpoints for processes that
+// run Corso as a long-lived service.  The endpoints are opt-in: nothing is
+// served unless the caller hands the Monitor's Handler to a server, or calls
+// Serve with a non-empty address.  The endpoints are unauthenticated, so the
+// status never includes error messages, only their category and status code.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/m365/graph"
+	"github.com/alcionai/corso/src/pkg/logger"
+)
+
+const (
+	HealthzPath = "/healthz"
+	StatusPath  = "/status"
+)
+
+// Operation identifies the kind of operation tracked by the Monitor.
+type Operation string
+
+const (
+	BackupOp      Operation = "backup"
+	RestoreOp     Operation = "restore"
+	ExportOp      Operation = "export"
+	MaintenanceOp Operation = "maintenance"
+)
+
+const (
+	StatusCompleted = "completed"
+	StatusFailed    = "failed"
+)
+
+// Error categories describe why an operation failed.
+const (
+	ErrCategoryAccessDenied      = "access_denied"
+	ErrCategoryAuthentication    = "authentication"
+	ErrCategoryCanceled          = "canceled"
+	ErrCategoryNetwork           = "network"
+	ErrCategoryQuotaExceeded     = "quota_exceeded"
+	ErrCategoryServiceNotEnabled = "service_not_enabled"
+	ErrCategoryThrottled         = "throttled"
+	ErrCategoryTimeout           = "timeout"
+	ErrCategoryUnknown           = "unknown"
+)
+
+// Status is the body returned by the status endpoint.
+type Status struct {
+	RepoID            string      `json:"repoID"`
+	OperationRunning  bool        `json:"operationRunning"`
+	RunningOperations []Operation `json:"runningOperations"`
+	// LastBackupAt is the completion time of the most recent successful
+	// backup.  Nil until a backup completes.
+	LastBackupAt *time.Time `json:"lastBackupAt,omitempty"`
+	// LastOperation is the outcome of the most recently finished operation.
+	// Nil until an operation finishes.
+	LastOperation *OperationResult `json:"lastOperation,omitempty"`
+}
+
+// OperationResult records the outcome of a finished operation.
+type OperationResult struct {
+	Operation   Operation `json:"operation"`
+	Status      string    `json:"status"`
+	CompletedAt time.Time `json:"completedAt"`
+	// ErrorCategory is one of the ErrCategory values when the operation
+	// failed.
+	ErrorCategory string `json:"errorCategory,omitempty"`
+	// StatusCode is the http status code of the failed graph api request
+	// that caused the failure, if any.
+	StatusCode int `json:"statusCode,omitempty"`
+}
+
+// repoIDer is the subset of the repository.Repository that the monitor
+// relies on.
+type repoIDer interface {
+	GetID() string
+}
+
+// Monitor tracks the operations run against a repository, and serves
+// their state over http.
+type Monitor struct {
+	mu      sync.Mutex
+	repo    repoIDer
+	running map[Operation]int
+	// ordered by start time, so that the status output is stable.
+	runOrder   []Operation
+	lastBackup *time.Time
+	lastOp     *OperationResult
+
+	// overridden in tests
+	now func() time.Time
+}
+
+func NewMonitor(r repoIDer) *Monitor {
+	return &Monitor{
+		repo:    r,
+		running: map[Operation]int{},
+		now:     time.Now,
+	}
+}
+
+// Track marks an operation as running.  The returned func must be called
+// when the operation ends, passing the operation's error, if any.  A nil
+// Monitor tracks nothing.
+func (m *Monitor) Track(op Operation) func(err error) {
+	if m == nil {
+		return func(error) {}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.running[op] == 0 {
+		m.runOrder = append(m.runOrder, op)
+	}
+
+	m.running[op]++
+
+	var once sync.Once
+
+	return func(err error) {
+		once.Do(func() { m.finish(op, err) })
+	}
+}
+
+func (m *Monitor) finish(op Operation, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.running[op]--
+
+	if m.running[op] <= 0 {
+		delete(m.running, op)
+
+		for i, o := range m.runOrder {
+			if o == op {
+				m.runOrder = append(m.runOrder[:i], m.runOrder[i+1:]...)
+				break
+			}
+		}
+	}
+
+	result := &OperationResult{
+		Operation:   op,
+		Status:      StatusCompleted,
+		CompletedAt: m.now().UTC(),
+	}
+
+	if err != nil {
+		result.Status = StatusFailed
+		result.ErrorCategory = errorCategory(err)
+		result.StatusCode = graph.LabeledStatus(err)
+	}
+
+	m.lastOp = result
+
+	if op == BackupOp && err == nil {
+		t := result.CompletedAt
+		m.lastBackup = &t
+	}
+}
+
+// errorCategory classifies the error without exposing its message, which
+// can hold tenant, user, or path details.
+func errorCategory(err error) string {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return ErrCategoryCanceled
+	case errors.Is(err, graph.ErrServiceNotEnabled):
+		return ErrCategoryServiceNotEnabled
+	case graph.IsErrAuthenticationError(err),
+		graph.IsErrUnauthorized(err),
+		graph.IsErrCredentialsExpired(err),
+		graph.IsErrCredentialsInvalid(err),
+		graph.IsErrConsentRequired(err):
+		return ErrCategoryAuthentication
+	case graph.IsErrAccessDenied(err):
+		return ErrCategoryAccessDenied
+	case graph.IsErrQuotaExceeded(err):
+		return ErrCategoryQuotaExceeded
+	case clues.HasLabel(err, graph.LabelStatus(http.StatusTooManyRequests)):
+		return ErrCategoryThrottled
+	case graph.IsErrTimeout(err):
+		return ErrCategoryTimeout
+	case graph.IsErrConnectionReset(err):
+		return ErrCategoryNetwork
+	}
+
+	return ErrCategoryUnknown
+}
+
+// Status returns a snapshot of the monitor's current state.
+func (m *Monitor) Status() Status {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s := Status{
+		RepoID:            m.repo.GetID(),
+		OperationRunning:  len(m.runOrder) > 0,
+		RunningOperations: append([]Operation{}, m.runOrder...),
+	}
+
+	if m.lastBackup != nil {
+		t := *m.lastBackup
+		s.LastBackupAt = &t
+	}
+
+	if m.lastOp != nil {
+		r := *m.lastOp
+		s.LastOperation = &r
+	}
+
+	return s
+}
+
+// Handler produces an http.Handler that serves the health endpoints:
+//   - /healthz: liveness probe; always responds 200 while the process is up.
+//   - /status: the Status of the monitor, as json.
+func (m *Monitor) Handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc(HealthzPath, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, r, map[string]string{"status": "ok"})
+	})
+
+	mux.HandleFunc(StatusPath, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, r, m.Status())
+	})
+
+	return mux
+}
+
+func writeJSON(w http.ResponseWriter, r *http.Request, body any) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		logger.CtxErr(r.Context(), err).Error("writing health response")
+	}
+}
+
+// Serve listens on the address and serves the monitor's endpoints until
+// the context is cancelled.  An empty address disables the endpoints, and
+// returns immediately.
+func (m *Monitor) Serve(ctx context.Context, addr string) error {
+	if len(addr) == 0 {
+		return nil
+	}
+
+	ctx = clues.Add(ctx, "health_addr", addr)
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return clues.Wrap(err, "listening for health checks").WithClues(ctx)
+	}
+
+	srv := &http.Server{
+		Handler:           m.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		sctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := srv.Shutdown(sctx); err != nil {
+			logger.CtxErr(ctx, err).Error("shutting down health server")
+		}
+	}()
+
+	logger.Ctx(ctx).Info("serving health checks")
+
+	if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return clues.Wrap(err, "serving health checks").WithClues(ctx)
+	}
+
+	return nil
+}
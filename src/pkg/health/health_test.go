@@ -0,0 +1,294 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/m365/graph"
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+type mockRepo string
+
+func (mr mockRepo) GetID() string {
+	return string(mr)
+}
+
+type HealthUnitSuite struct {
+	tester.Suite
+}
+
+func TestHealthUnitSuite(t *testing.T) {
+	suite.Run(t, &HealthUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func getJSON(t *testing.T, h http.Handler, path string, v any) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	err := json.Unmarshal(rec.Body.Bytes(), v)
+	require.NoError(t, err, clues.ToCore(err))
+
+	return rec
+}
+
+func (suite *HealthUnitSuite) TestHealthz() {
+	t := suite.T()
+
+	var body map[string]string
+
+	getJSON(t, NewMonitor(mockRepo("rid")).Handler(), HealthzPath, &body)
+	assert.Equal(t, map[string]string{"status": "ok"}, body)
+}
+
+func (suite *HealthUnitSuite) TestStatus_jsonShape() {
+	t := suite.T()
+
+	m := NewMonitor(mockRepo("rid"))
+	m.now = func() time.Time { return time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC) }
+
+	m.Track(BackupOp)(nil)
+	m.Track(ExportOp)
+
+	var body map[string]any
+
+	getJSON(t, m.Handler(), StatusPath, &body)
+
+	assert.Equal(
+		t,
+		map[string]any{
+			"repoID":            "rid",
+			"operationRunning":  true,
+			"runningOperations": []any{"export"},
+			"lastBackupAt":      "2023-10-01T12:00:00Z",
+			"lastOperation": map[string]any{
+				"operation":   "backup",
+				"status":      "completed",
+				"completedAt": "2023-10-01T12:00:00Z",
+			},
+		},
+		body)
+}
+
+func (suite *HealthUnitSuite) TestStatus_transitions() {
+	t := suite.T()
+
+	var (
+		m   = NewMonitor(mockRepo("rid"))
+		h   = m.Handler()
+		now = time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+		s   Status
+	)
+
+	m.now = func() time.Time { return now }
+
+	// idle, nothing run yet
+	getJSON(t, h, StatusPath, &s)
+	assert.Equal(t, "rid", s.RepoID)
+	assert.False(t, s.OperationRunning)
+	assert.Empty(t, s.RunningOperations)
+	assert.Nil(t, s.LastBackupAt)
+	assert.Nil(t, s.LastOperation)
+
+	// a backup starts
+	doneBackup := m.Track(BackupOp)
+
+	getJSON(t, h, StatusPath, &s)
+	assert.True(t, s.OperationRunning)
+	assert.Equal(t, []Operation{BackupOp}, s.RunningOperations)
+	assert.Nil(t, s.LastBackupAt)
+
+	// a restore starts alongside it
+	doneRestore := m.Track(RestoreOp)
+
+	getJSON(t, h, StatusPath, &s)
+	assert.Equal(t, []Operation{BackupOp, RestoreOp}, s.RunningOperations)
+
+	// the backup completes
+	doneBackup(nil)
+
+	getJSON(t, h, StatusPath, &s)
+	assert.True(t, s.OperationRunning)
+	assert.Equal(t, []Operation{RestoreOp}, s.RunningOperations)
+	require.NotNil(t, s.LastBackupAt)
+	assert.True(t, now.Equal(*s.LastBackupAt))
+	require.NotNil(t, s.LastOperation)
+	assert.Equal(t, BackupOp, s.LastOperation.Operation)
+	assert.Equal(t, StatusCompleted, s.LastOperation.Status)
+
+	// the restore fails, returning to idle
+	now = now.Add(time.Hour)
+	doneRestore(assert.AnError)
+	// repeat calls are ignored
+	doneRestore(nil)
+
+	getJSON(t, h, StatusPath, &s)
+	assert.False(t, s.OperationRunning)
+	assert.Empty(t, s.RunningOperations)
+	require.NotNil(t, s.LastOperation)
+	assert.Equal(t, RestoreOp, s.LastOperation.Operation)
+	assert.Equal(t, StatusFailed, s.LastOperation.Status)
+	assert.Equal(t, ErrCategoryUnknown, s.LastOperation.ErrorCategory)
+	assert.True(t, now.Equal(s.LastOperation.CompletedAt))
+
+	// a failed backup leaves the last backup time alone
+	m.Track(BackupOp)(assert.AnError)
+
+	getJSON(t, h, StatusPath, &s)
+	require.NotNil(t, s.LastBackupAt)
+	assert.True(t, now.Add(-time.Hour).Equal(*s.LastBackupAt))
+	assert.Equal(t, BackupOp, s.LastOperation.Operation)
+	assert.Equal(t, StatusFailed, s.LastOperation.Status)
+}
+
+func (suite *HealthUnitSuite) TestStatus_hidesErrorDetails() {
+	t := suite.T()
+
+	m := NewMonitor(mockRepo("rid"))
+
+	m.Track(BackupOp)(clues.Wrap(
+		clues.New("user@tenant.com/Documents/secret.docx").Label(graph.LabelStatus(http.StatusForbidden)),
+		"getting item"))
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, StatusPath, nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "secret.docx")
+	assert.NotContains(t, rec.Body.String(), "getting item")
+
+	var s Status
+
+	err := json.Unmarshal(rec.Body.Bytes(), &s)
+	require.NoError(t, err, clues.ToCore(err))
+	require.NotNil(t, s.LastOperation)
+	assert.Equal(t, StatusFailed, s.LastOperation.Status)
+	assert.Equal(t, http.StatusForbidden, s.LastOperation.StatusCode)
+}
+
+func (suite *HealthUnitSuite) TestErrorCategory() {
+	table := []struct {
+		name   string
+		err    error
+		expect string
+	}{
+		{
+			name:   "unknown",
+			err:    assert.AnError,
+			expect: ErrCategoryUnknown,
+		},
+		{
+			name:   "canceled",
+			err:    clues.Wrap(context.Canceled, "running backup"),
+			expect: ErrCategoryCanceled,
+		},
+		{
+			name:   "service not enabled",
+			err:    clues.Stack(graph.ErrServiceNotEnabled),
+			expect: ErrCategoryServiceNotEnabled,
+		},
+		{
+			name:   "unauthorized",
+			err:    clues.Stack(assert.AnError).Label(graph.LabelStatus(http.StatusUnauthorized)),
+			expect: ErrCategoryAuthentication,
+		},
+		{
+			name:   "throttled",
+			err:    clues.Stack(assert.AnError).Label(graph.LabelStatus(http.StatusTooManyRequests)),
+			expect: ErrCategoryThrottled,
+		},
+		{
+			name:   "timeout",
+			err:    clues.Wrap(context.DeadlineExceeded, "getting item"),
+			expect: ErrCategoryTimeout,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			assert.Equal(suite.T(), test.expect, errorCategory(test.err))
+		})
+	}
+}
+
+func (suite *HealthUnitSuite) TestMonitor_nil() {
+	var m *Monitor
+
+	assert.NotPanics(suite.T(), func() {
+		m.Track(BackupOp)(assert.AnError)
+	})
+}
+
+func (suite *HealthUnitSuite) TestHandler_methodNotAllowed() {
+	t := suite.T()
+
+	rec := httptest.NewRecorder()
+	NewMonitor(mockRepo("rid")).Handler().ServeHTTP(
+		rec,
+		httptest.NewRequest(http.MethodPost, StatusPath, nil))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func (suite *HealthUnitSuite) TestServe() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	m := NewMonitor(mockRepo("rid"))
+
+	// an empty address disables the server.
+	err := m.Serve(ctx, "")
+	require.NoError(t, err, clues.ToCore(err))
+
+	// find a free port
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err, clues.ToCore(err))
+
+	addr := ln.Addr().String()
+	ln.Close()
+
+	sctx, cancel := context.WithCancel(ctx)
+	served := make(chan error)
+
+	go func() {
+		served <- m.Serve(sctx, addr)
+	}()
+
+	require.Eventually(
+		t,
+		func() bool {
+			resp, err := http.Get("http://" + addr + HealthzPath)
+			if err != nil {
+				return false
+			}
+
+			resp.Body.Close()
+
+			return resp.StatusCode == http.StatusOK
+		},
+		5*time.Second,
+		50*time.Millisecond)
+
+	cancel()
+
+	select {
+	case err := <-served:
+		assert.NoError(t, err, clues.ToCore(err))
+	case <-time.After(10 * time.Second):
+		assert.Fail(t, "server did not shut down")
+	}
+}
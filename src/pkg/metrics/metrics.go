@@ -0,0 +1,300 @@
+// Package metrics exposes the results of Corso operations as prometheus
+// metrics.  Like the health endpoints, metrics are opt-in: nothing gets
+// served unless the caller hands the Registry's Handler to a server, or
+// calls Serve with a non-empty address.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/alcionai/corso/src/internal/operations"
+	"github.com/alcionai/corso/src/internal/stats"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+)
+
+const (
+	MetricsPath = "/metrics"
+
+	namespace = "corso"
+)
+
+// Operation identifies the kind of operation that produced a metric.
+type Operation string
+
+const (
+	BackupOp  Operation = "backup"
+	RestoreOp Operation = "restore"
+)
+
+// label names
+const (
+	OperationLabel = "operation"
+	ServiceLabel   = "service"
+	StatusLabel    = "status"
+	DirectionLabel = "direction"
+	KindLabel      = "kind"
+	KeyLabel       = "key"
+)
+
+// well-known label values
+const (
+	DirectionRead     = "read"
+	DirectionWritten  = "written"
+	DirectionUploaded = "uploaded"
+
+	KindFailure   = "failure"
+	KindRecovered = "recovered"
+	KindSkipped   = "skipped"
+)
+
+// Registry holds the prometheus collectors populated by Corso operations.
+type Registry struct {
+	reg *prometheus.Registry
+
+	duration *prometheus.HistogramVec
+	items    *prometheus.CounterVec
+	bytes    *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+	counts   *prometheus.CounterVec
+	retries  *prometheus.CounterVec
+	throttle *prometheus.CounterVec
+}
+
+// NewRegistry produces a Registry with every Corso collector registered.
+func NewRegistry() *Registry {
+	opLabels := []string{OperationLabel, ServiceLabel}
+
+	r := &Registry{
+		reg: prometheus.NewRegistry(),
+		duration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "operation_duration_seconds",
+				Help:      "Time taken to run an operation, from start to completion.",
+				Buckets:   prometheus.ExponentialBuckets(1, 4, 10),
+			},
+			append(opLabels, StatusLabel)),
+		items: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "operation_items_total",
+				Help:      "Items read or written by operations.",
+			},
+			append(opLabels, DirectionLabel)),
+		bytes: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "operation_bytes_total",
+				Help:      "Bytes read or uploaded by operations.",
+			},
+			append(opLabels, DirectionLabel)),
+		errors: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "operation_errors_total",
+				Help:      "Errors and skipped items produced by operations.",
+			},
+			append(opLabels, KindLabel)),
+		counts: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "operation_counts_total",
+				Help:      "Values tallied in the counter of operations.",
+			},
+			append(opLabels, KeyLabel)),
+		retries: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "graph_retries_total",
+				Help:      "Graph api requests re-sent by the retry middleware.",
+			},
+			opLabels),
+		throttle: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "graph_throttled_requests_total",
+				Help:      "Graph api responses with a 429 status.",
+			},
+			opLabels),
+	}
+
+	r.reg.MustRegister(
+		r.duration,
+		r.items,
+		r.bytes,
+		r.errors,
+		r.counts,
+		r.retries,
+		r.throttle)
+
+	return r
+}
+
+// Gatherer exposes the underlying prometheus registry.
+func (r *Registry) Gatherer() prometheus.Gatherer {
+	return r.reg
+}
+
+// RecordBackup adds the results of a finished backup to the registry.
+// A nil registry is a no-op.
+func (r *Registry) RecordBackup(bo *operations.BackupOperation) {
+	if r == nil || bo == nil {
+		return
+	}
+
+	r.record(
+		BackupOp,
+		bo.Selectors.PathService().String(),
+		bo.Status,
+		bo.Results.ReadWrites,
+		bo.Results.StartAndEndTime,
+		bo.Results.Counts,
+		bo.Errors)
+}
+
+// RecordRestore adds the results of a finished restore to the registry.
+// A nil registry is a no-op.
+func (r *Registry) RecordRestore(ro *operations.RestoreOperation) {
+	if r == nil || ro == nil {
+		return
+	}
+
+	r.record(
+		RestoreOp,
+		ro.Selectors.PathService().String(),
+		ro.Status,
+		ro.Results.ReadWrites,
+		ro.Results.StartAndEndTime,
+		ro.Results.Counts,
+		ro.Errors)
+}
+
+func (r *Registry) record(
+	op Operation,
+	service string,
+	status operations.OpStatus,
+	rw stats.ReadWrites,
+	se stats.StartAndEndTime,
+	counts map[string]int64,
+	errs *fault.Bus,
+) {
+	lbls := prometheus.Labels{
+		OperationLabel: string(op),
+		ServiceLabel:   service,
+	}
+
+	with := func(k, v string) prometheus.Labels {
+		l := prometheus.Labels{k: v}
+		for lk, lv := range lbls {
+			l[lk] = lv
+		}
+
+		return l
+	}
+
+	if !se.StartedAt.IsZero() && !se.CompletedAt.IsZero() {
+		r.duration.
+			With(with(StatusLabel, statusLabelValue(status))).
+			Observe(se.CompletedAt.Sub(se.StartedAt).Seconds())
+	}
+
+	r.items.With(with(DirectionLabel, DirectionRead)).Add(float64(rw.ItemsRead))
+	r.items.With(with(DirectionLabel, DirectionWritten)).Add(float64(rw.ItemsWritten))
+	r.bytes.With(with(DirectionLabel, DirectionRead)).Add(float64(rw.BytesRead))
+	r.bytes.With(with(DirectionLabel, DirectionUploaded)).Add(float64(rw.BytesUploaded))
+
+	var failures, recovered, skipped int
+
+	if errs != nil {
+		if errs.Failure() != nil {
+			failures = 1
+		}
+
+		recovered = len(errs.Recovered())
+		skipped = len(errs.Skipped())
+	}
+
+	r.errors.With(with(KindLabel, KindFailure)).Add(float64(failures))
+	r.errors.With(with(KindLabel, KindRecovered)).Add(float64(recovered))
+	r.errors.With(with(KindLabel, KindSkipped)).Add(float64(skipped))
+
+	// graph throttling is tallied in the counts of the operation that
+	// made the requests.
+	r.retries.With(lbls).Add(float64(max(counts[string(count.GraphRetries)], 0)))
+	r.throttle.With(lbls).Add(float64(max(counts[string(count.ThrottledRequests)], 0)))
+
+	for k, v := range counts {
+		// counters can't decrease; negative tallies aren't meaningful here.
+		if v < 0 {
+			continue
+		}
+
+		r.counts.With(with(KeyLabel, k)).Add(float64(v))
+	}
+}
+
+// statusLabelValue produces a label-friendly form of the status,
+// eg: "Partially Completed" => "partially_completed".
+func statusLabelValue(s operations.OpStatus) string {
+	return strings.ReplaceAll(strings.ToLower(s.String()), " ", "_")
+}
+
+// Handler produces an http.Handler that serves the registry's metrics
+// in the prometheus exposition format.
+func (r *Registry) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(MetricsPath, promhttp.HandlerFor(r.reg, promhttp.HandlerOpts{}))
+
+	return mux
+}
+
+// Serve listens on the address and serves the registry's metrics until
+// the context is cancelled.  An empty address disables the endpoint, and
+// returns immediately.
+func (r *Registry) Serve(ctx context.Context, addr string) error {
+	if len(addr) == 0 {
+		return nil
+	}
+
+	ctx = clues.Add(ctx, "metrics_addr", addr)
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return clues.Wrap(err, "listening for metrics scrapes").WithClues(ctx)
+	}
+
+	srv := &http.Server{
+		Handler:           r.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		sctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := srv.Shutdown(sctx); err != nil {
+			logger.CtxErr(ctx, err).Error("shutting down metrics server")
+		}
+	}()
+
+	logger.Ctx(ctx).Info("serving metrics")
+
+	if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return clues.Wrap(err, "serving metrics").WithClues(ctx)
+	}
+
+	return nil
+}
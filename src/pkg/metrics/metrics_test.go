@@ -0,0 +1,220 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/operations"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/selectors"
+)
+
+type MetricsUnitSuite struct {
+	tester.Suite
+}
+
+func TestMetricsUnitSuite(t *testing.T) {
+	suite.Run(t, &MetricsUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+// gathered produces the label sets of every metric in the registry,
+// keyed by metric name.
+func gathered(t *testing.T, r *Registry) map[string][]map[string]string {
+	mfs, err := r.Gatherer().Gather()
+	require.NoError(t, err, clues.ToCore(err))
+
+	result := map[string][]map[string]string{}
+
+	for _, mf := range mfs {
+		lss := []map[string]string{}
+
+		for _, m := range mf.GetMetric() {
+			ls := map[string]string{}
+
+			for _, lp := range m.GetLabel() {
+				ls[lp.GetName()] = lp.GetValue()
+			}
+
+			lss = append(lss, ls)
+		}
+
+		result[mf.GetName()] = lss
+	}
+
+	return result
+}
+
+func labelValues(lss []map[string]string, label string) []string {
+	vs := []string{}
+
+	for _, ls := range lss {
+		vs = append(vs, ls[label])
+	}
+
+	sort.Strings(vs)
+
+	return vs
+}
+
+func (suite *MetricsUnitSuite) TestNewRegistry() {
+	t := suite.T()
+
+	// vectors without any observations aren't gathered.
+	assert.Empty(t, gathered(t, NewRegistry()))
+}
+
+func (suite *MetricsUnitSuite) TestRecordBackup() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		r     = NewRegistry()
+		now   = time.Now()
+		errs  = fault.New(false)
+		bo    = &operations.BackupOperation{}
+		skipd = fault.FileSkip(fault.SkipMalware, "ns", "id", "name", nil)
+	)
+
+	errs.AddRecoverable(ctx, assert.AnError)
+	errs.AddSkip(ctx, skipd)
+
+	bo.Selectors = selectors.NewExchangeBackup([]string{"user"}).Selector
+	bo.Status = operations.PartiallyCompleted
+	bo.Errors = errs
+	bo.Results.StartedAt = now.Add(-time.Minute)
+	bo.Results.CompletedAt = now
+	bo.Results.ItemsRead = 3
+	bo.Results.ItemsWritten = 2
+	bo.Results.BytesRead = 300
+	bo.Results.BytesUploaded = 200
+	bo.Results.Counts = map[string]int64{
+		string(count.NewItemCreated): 2,
+		string(count.GraphRetries):   4,
+	}
+
+	r.RecordBackup(bo)
+
+	lbls := prometheus.Labels{OperationLabel: string(BackupOp), ServiceLabel: "exchange"}
+	assert.Equal(t, 4.0, testutil.ToFloat64(r.retries.With(lbls)), "graph retries")
+	assert.Zero(t, testutil.ToFloat64(r.throttle.With(lbls)), "graph throttled requests")
+
+	ms := gathered(t, r)
+
+	expectNames := []string{
+		"corso_graph_retries_total",
+		"corso_graph_throttled_requests_total",
+		"corso_operation_bytes_total",
+		"corso_operation_counts_total",
+		"corso_operation_duration_seconds",
+		"corso_operation_errors_total",
+		"corso_operation_items_total",
+	}
+
+	names := []string{}
+	for n := range ms {
+		names = append(names, n)
+	}
+
+	sort.Strings(names)
+	assert.Equal(t, expectNames, names)
+
+	table := []struct {
+		metric      string
+		label       string
+		expectValue []string
+	}{
+		{
+			metric:      "corso_operation_duration_seconds",
+			label:       StatusLabel,
+			expectValue: []string{"partially_completed"},
+		},
+		{
+			metric:      "corso_operation_items_total",
+			label:       DirectionLabel,
+			expectValue: []string{DirectionRead, DirectionWritten},
+		},
+		{
+			metric:      "corso_operation_bytes_total",
+			label:       DirectionLabel,
+			expectValue: []string{DirectionRead, DirectionUploaded},
+		},
+		{
+			metric:      "corso_operation_errors_total",
+			label:       KindLabel,
+			expectValue: []string{KindFailure, KindRecovered, KindSkipped},
+		},
+		{
+			metric:      "corso_operation_counts_total",
+			label:       KeyLabel,
+			expectValue: []string{string(count.GraphRetries), string(count.NewItemCreated)},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.metric, func() {
+			t := suite.T()
+
+			lss := ms[test.metric]
+			require.NotEmpty(t, lss)
+
+			assert.Equal(t, test.expectValue, labelValues(lss, test.label))
+
+			for _, ls := range lss {
+				assert.Len(t, ls, 3, "label set")
+				assert.Equal(t, string(BackupOp), ls[OperationLabel])
+				assert.Equal(t, "exchange", ls[ServiceLabel])
+			}
+		})
+	}
+}
+
+func (suite *MetricsUnitSuite) TestRecord_nilRegistry() {
+	var r *Registry
+
+	assert.NotPanics(suite.T(), func() {
+		r.RecordBackup(&operations.BackupOperation{})
+		r.RecordRestore(&operations.RestoreOperation{})
+	})
+}
+
+func (suite *MetricsUnitSuite) TestHandler() {
+	t := suite.T()
+
+	r := NewRegistry()
+	r.RecordRestore(&operations.RestoreOperation{
+		Selectors: selectors.NewOneDriveRestore([]string{"user"}).Selector,
+	})
+
+	srv := httptest.NewServer(r.Handler())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + MetricsPath)
+	require.NoError(t, err, clues.ToCore(err))
+
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Contains(t, string(body), "corso_graph_retries_total")
+	assert.Contains(
+		t,
+		string(body),
+		`corso_operation_items_total{direction="written",operation="restore",service="onedrive"} 0`)
+}
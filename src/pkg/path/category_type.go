@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/alcionai/clues"
+	"golang.org/x/exp/slices"
 )
 
 var ErrorUnknownCategory = clues.New("unknown category string")
@@ -50,6 +51,19 @@ func ToCategoryType(s string) CategoryType {
 	return UnknownCategory
 }
 
+// CategoryTypes returns every known category, in enum order.
+func CategoryTypes() []CategoryType {
+	cats := make([]CategoryType, 0, len(strToCat))
+
+	for _, cat := range strToCat {
+		cats = append(cats, cat)
+	}
+
+	slices.Sort(cats)
+
+	return cats
+}
+
 var catToHuman = map[CategoryType]string{
 	EmailCategory:           "Emails",
 	ContactsCategory:        "Contacts",
@@ -70,3 +70,20 @@ func (suite *CategoryTypeUnitSuite) TestHumanString() {
 		})
 	}
 }
+
+func (suite *CategoryTypeUnitSuite) TestCategoryTypes() {
+	assert.Equal(
+		suite.T(),
+		[]CategoryType{
+			EmailCategory,
+			ContactsCategory,
+			EventsCategory,
+			FilesCategory,
+			ListsCategory,
+			LibrariesCategory,
+			PagesCategory,
+			DetailsCategory,
+			ChannelMessagesCategory,
+		},
+		CategoryTypes())
+}
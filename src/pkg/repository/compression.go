@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/dustin/go-humanize"
+	"github.com/kopia/kopia/repo/compression"
+)
+
+// CompressionResult is the outcome of compressing the sampled repository
+// data with a single compressor.
+type CompressionResult struct {
+	Compressor string `json:"compressor"`
+	// Deprecated compressors can still read existing data, but shouldn't
+	// be selected for new repositories.
+	Deprecated      bool  `json:"deprecated,omitempty"`
+	OriginalBytes   int64 `json:"originalBytes"`
+	CompressedBytes int64 `json:"compressedBytes"`
+	// Ratio is the compressed size over the original size.  Smaller
+	// ratios save more storage.
+	Ratio float64 `json:"ratio"`
+	// BytesPerSecond is the rate at which the compressor consumed the
+	// original data.
+	BytesPerSecond float64 `json:"bytesPerSecond"`
+}
+
+// MinimumPrintable reduces the CompressionResult to its minimally
+// printable details.
+func (cr CompressionResult) MinimumPrintable() any {
+	return cr
+}
+
+// Headers returns the human-readable names of properties in a
+// CompressionResult for printing out to a terminal in a columnar display.
+func (cr CompressionResult) Headers() []string {
+	return []string{
+		"Compressor",
+		"Ratio",
+		"Compressed Size",
+		"Throughput",
+	}
+}
+
+// Values returns the values matching the Headers list for printing
+// out to a terminal in a columnar display.
+func (cr CompressionResult) Values() []string {
+	name := cr.Compressor
+	if cr.Deprecated {
+		name += " (deprecated)"
+	}
+
+	return []string{
+		name,
+		strconv.FormatFloat(cr.Ratio, 'f', 3, 64),
+		humanize.Bytes(uint64(cr.CompressedBytes)),
+		humanize.Bytes(uint64(cr.BytesPerSecond)) + "/s",
+	}
+}
+
+// BenchmarkCompression compresses a sample of up to sampleItems pieces
+// of the repository's data with each compressor that kopia supports.
+// Results are ranked from the smallest compression ratio to the largest,
+// with faster compressors ranked first among equal ratios.  Nothing is
+// written to the repository.
+func (r repository) BenchmarkCompression(
+	ctx context.Context,
+	sampleItems int,
+) ([]CompressionResult, error) {
+	if r.dataLayer == nil {
+		return nil, clues.New("repository is closed").WithClues(ctx)
+	}
+
+	if sampleItems <= 0 {
+		return nil, clues.New("sample size must be greater than zero").WithClues(ctx)
+	}
+
+	samples, err := r.dataLayer.SampleContents(ctx, sampleItems)
+	if err != nil {
+		return nil, clues.Wrap(err, "sampling repository data")
+	}
+
+	if len(samples) == 0 {
+		return nil, clues.New("repository has no data to sample").WithClues(ctx)
+	}
+
+	return benchmarkCompressors(ctx, samples)
+}
+
+// benchmarkCompressors compresses the samples with every compressor in
+// compression.ByName, and ranks the results.
+func benchmarkCompressors(
+	ctx context.Context,
+	samples [][]byte,
+) ([]CompressionResult, error) {
+	var (
+		results  = make([]CompressionResult, 0, len(compression.ByName))
+		original int64
+	)
+
+	for _, s := range samples {
+		original += int64(len(s))
+	}
+
+	for name, comp := range compression.ByName {
+		var (
+			compressed int64
+			buf        bytes.Buffer
+			start      = time.Now()
+		)
+
+		for _, s := range samples {
+			buf.Reset()
+
+			if err := comp.Compress(&buf, bytes.NewReader(s)); err != nil {
+				return nil, clues.Wrap(err, "compressing sample").
+					With("compressor", name).
+					WithClues(ctx)
+			}
+
+			compressed += int64(buf.Len())
+		}
+
+		elapsed := time.Since(start)
+
+		res := CompressionResult{
+			Compressor:      string(name),
+			Deprecated:      compression.IsDeprecated[name],
+			OriginalBytes:   original,
+			CompressedBytes: compressed,
+		}
+
+		if original > 0 {
+			res.Ratio = float64(compressed) / float64(original)
+		}
+
+		if elapsed > 0 {
+			res.BytesPerSecond = float64(original) / elapsed.Seconds()
+		}
+
+		results = append(results, res)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Ratio != results[j].Ratio {
+			return results[i].Ratio < results[j].Ratio
+		}
+
+		if results[i].BytesPerSecond != results[j].BytesPerSecond {
+			return results[i].BytesPerSecond > results[j].BytesPerSecond
+		}
+
+		return results[i].Compressor < results[j].Compressor
+	})
+
+	return results, nil
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"bytes"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/kopia/kopia/repo/compression"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+type RepositoryCompressionUnitSuite struct {
+	tester.Suite
+}
+
+func TestRepositoryCompressionUnitSuite(t *testing.T) {
+	suite.Run(t, &RepositoryCompressionUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *RepositoryCompressionUnitSuite) TestBenchmarkCompressors() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		//nolint:gosec
+		rnd     = rand.New(rand.NewSource(1))
+		random  = make([]byte, 16*1024)
+		samples = [][]byte{
+			bytes.Repeat([]byte("corso backup sample "), 1024),
+			random,
+		}
+		original = int64(len(samples[0]) + len(samples[1]))
+	)
+
+	_, err := rnd.Read(random)
+	require.NoError(t, err, clues.ToCore(err))
+
+	results, err := benchmarkCompressors(ctx, samples)
+	require.NoError(t, err, clues.ToCore(err))
+	require.Len(t, results, len(compression.ByName), "one result per compressor")
+
+	names := map[string]struct{}{}
+
+	for _, res := range results {
+		names[res.Compressor] = struct{}{}
+
+		assert.Equal(t, original, res.OriginalBytes, res.Compressor)
+		assert.Positive(t, res.CompressedBytes, res.Compressor)
+		assert.Positive(t, res.Ratio, res.Compressor)
+		assert.Less(t, res.Ratio, 1.0, "repetitive data should compress: %s", res.Compressor)
+		assert.Equal(t, compression.IsDeprecated[compression.Name(res.Compressor)], res.Deprecated, res.Compressor)
+	}
+
+	for name := range compression.ByName {
+		assert.Contains(t, names, string(name))
+	}
+
+	assert.True(
+		t,
+		sort.SliceIsSorted(results, func(i, j int) bool {
+			return results[i].Ratio < results[j].Ratio
+		}),
+		"results ranked by ratio")
+}
+
+func (suite *RepositoryCompressionUnitSuite) TestBenchmarkCompression_errors() {
+	table := []struct {
+		name        string
+		sampleItems int
+	}{
+		{
+			name:        "closed repository",
+			sampleItems: 10,
+		},
+		{
+			name:        "no samples",
+			sampleItems: 0,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			_, err := repository{}.BenchmarkCompression(ctx, test.sampleItems)
+			assert.Error(t, err, clues.ToCore(err))
+		})
+	}
+}
+
+func (suite *RepositoryCompressionUnitSuite) TestCompressionResult_Values() {
+	t := suite.T()
+
+	res := CompressionResult{
+		Compressor:      "zstd",
+		OriginalBytes:   4000,
+		CompressedBytes: 1000,
+		Ratio:           0.25,
+		BytesPerSecond:  2000000,
+	}
+
+	assert.Equal(t, []string{"zstd", "0.250", "1.0 kB", "2.0 MB/s"}, res.Values())
+	assert.Len(t, res.Headers(), len(res.Values()))
+
+	res.Deprecated = true
+
+	assert.Equal(t, "zstd (deprecated)", res.Values()[0])
+}
@@ -0,0 +1,242 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/alcionai/clues"
+	"github.com/pkg/errors"
+
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/kopia"
+	"github.com/alcionai/corso/src/internal/kopia/inject"
+	"github.com/alcionai/corso/src/pkg/backup/identity"
+	"github.com/alcionai/corso/src/pkg/backup/metadata"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/store"
+)
+
+// DeltaToken is a delta token stored in the metadata of a resource's
+// latest backup.  The next incremental backup of the resource enumerates
+// the container's changes starting from the token.
+type DeltaToken struct {
+	BackupID string `json:"backupID"`
+	Service  string `json:"service"`
+	Category string `json:"category"`
+	// ContainerID is the id of the folder, or drive, that the token
+	// enumerates.
+	ContainerID string `json:"containerID"`
+	Token       string `json:"token"`
+}
+
+// deltaTokenCategories are the service categories whose backups store
+// delta tokens in their metadata.
+var deltaTokenCategories = map[path.ServiceType][]path.CategoryType{
+	path.ExchangeService: {
+		path.ContactsCategory,
+		path.EmailCategory,
+		path.EventsCategory,
+	},
+	path.OneDriveService:   {path.FilesCategory},
+	path.SharePointService: {path.LibrariesCategory},
+}
+
+// deltaTokenTags match the same backup bases that backups use.
+var deltaTokenTags = map[string]string{kopia.TagBackupCategory: ""}
+
+// deltaTokenReasons produces a reason for every service category that
+// stores delta tokens.
+func deltaTokenReasons(tenantID, resourceID string) []identity.Reasoner {
+	reasons := []identity.Reasoner{}
+
+	for srv, cats := range deltaTokenCategories {
+		for _, cat := range cats {
+			reasons = append(reasons, kopia.NewReason(tenantID, resourceID, srv, cat))
+		}
+	}
+
+	return reasons
+}
+
+// ListDeltaTokens produces the delta tokens stored in the metadata of the
+// resource's latest backups.
+func (r repository) ListDeltaTokens(
+	ctx context.Context,
+	resourceID string,
+) ([]DeltaToken, error) {
+	bf, err := r.dataLayer.NewBaseFinder(store.NewWrapper(r.modelStore))
+	if err != nil {
+		return nil, clues.Wrap(err, "constructing base finder").WithClues(ctx)
+	}
+
+	return listDeltaTokens(ctx, bf, r.dataLayer, r.Account.ID(), resourceID)
+}
+
+// listDeltaTokens handles the processing for ListDeltaTokens.
+func listDeltaTokens(
+	ctx context.Context,
+	bf inject.BaseFinder,
+	rp inject.RestoreProducer,
+	tenantID, resourceID string,
+) ([]DeltaToken, error) {
+	ctx = clues.Add(ctx, "resource_id", resourceID)
+
+	var (
+		bb     = bf.FindBases(ctx, deltaTokenReasons(tenantID, resourceID), deltaTokenTags)
+		tokens = []DeltaToken{}
+		// backup ids keyed by snapshot id
+		backupIDs = map[string]string{}
+	)
+
+	for _, b := range bb.Backups() {
+		backupIDs[b.SnapshotID] = string(b.ID)
+	}
+
+	for _, man := range bb.MergeBases() {
+		for _, reason := range man.Reasons {
+			mctx := clues.Add(
+				ctx,
+				"manifest_id", man.ID,
+				"service", reason.Service(),
+				"category", reason.Category())
+
+			deltas, err := readDeltaURLs(mctx, rp, string(man.ID), tenantID, resourceID, reason)
+			if err != nil {
+				return nil, clues.Stack(err)
+			}
+
+			for id, token := range deltas {
+				tokens = append(tokens, DeltaToken{
+					BackupID:    backupIDs[string(man.ID)],
+					Service:     reason.Service().String(),
+					Category:    reason.Category().String(),
+					ContainerID: id,
+					Token:       token,
+				})
+			}
+		}
+	}
+
+	sort.Slice(tokens, func(i, j int) bool {
+		ti, tj := tokens[i], tokens[j]
+
+		if ti.Service != tj.Service {
+			return ti.Service < tj.Service
+		}
+
+		if ti.Category != tj.Category {
+			return ti.Category < tj.Category
+		}
+
+		return ti.ContainerID < tj.ContainerID
+	})
+
+	return tokens, nil
+}
+
+// readDeltaURLs produces the delta urls, keyed by container id, from the
+// reason's metadata in the snapshot.  Snapshots aren't guaranteed to hold
+// delta metadata; if it doesn't exist, no urls are returned.
+func readDeltaURLs(
+	ctx context.Context,
+	rp inject.RestoreProducer,
+	snapshotID, tenantID, resourceID string,
+	reason identity.Reasoner,
+) (map[string]string, error) {
+	p, err := path.BuildMetadata(
+		tenantID,
+		resourceID,
+		reason.Service(),
+		reason.Category(),
+		true,
+		metadata.DeltaURLsFileName)
+	if err != nil {
+		return nil, clues.Wrap(err, "building delta metadata path").WithClues(ctx)
+	}
+
+	dir, err := p.Dir()
+	if err != nil {
+		return nil, clues.Wrap(err, "getting delta metadata directory").WithClues(ctx)
+	}
+
+	errs := fault.New(true)
+
+	colls, err := rp.ProduceRestoreCollections(
+		ctx,
+		snapshotID,
+		[]path.RestorePaths{{StoragePath: p, RestorePath: dir}},
+		nil,
+		errs)
+	if errors.Is(err, data.ErrNotFound) {
+		logger.Ctx(ctx).Debug("no delta metadata in snapshot")
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, clues.Wrap(err, "reading delta metadata").WithClues(ctx)
+	}
+
+	deltas := map[string]string{}
+
+	for _, coll := range colls {
+		for item := range coll.Items(ctx, errs) {
+			m := map[string]string{}
+
+			err := json.NewDecoder(item.ToReader()).Decode(&m)
+			if err != nil {
+				return nil, clues.Wrap(err, "decoding delta metadata").WithClues(ctx)
+			}
+
+			for k, v := range m {
+				deltas[k] = v
+			}
+		}
+	}
+
+	return deltas, clues.Stack(errs.Failure()).WithClues(ctx).OrNil()
+}
+
+// ResetDeltaTokens marks the resource's latest backups so that the next
+// backup of the resource ignores their delta tokens, and runs as a full,
+// non-incremental backup.
+func (r repository) ResetDeltaTokens(ctx context.Context, resourceID string) error {
+	sw := store.NewWrapper(r.modelStore)
+
+	bf, err := r.dataLayer.NewBaseFinder(sw)
+	if err != nil {
+		return clues.Wrap(err, "constructing base finder").WithClues(ctx)
+	}
+
+	return resetDeltaTokens(ctx, bf, sw, r.Account.ID(), resourceID)
+}
+
+// resetDeltaTokens handles the processing for ResetDeltaTokens.
+func resetDeltaTokens(
+	ctx context.Context,
+	bf inject.BaseFinder,
+	bu store.BackupUpdater,
+	tenantID, resourceID string,
+) error {
+	ctx = clues.Add(ctx, "resource_id", resourceID)
+
+	bb := bf.FindBases(ctx, deltaTokenReasons(tenantID, resourceID), deltaTokenTags)
+
+	for _, b := range bb.Backups() {
+		if b.DeltaReset {
+			continue
+		}
+
+		b.DeltaReset = true
+
+		if err := bu.UpdateBackup(ctx, b.Backup); err != nil {
+			return clues.Wrap(err, "resetting backup delta tokens").
+				WithClues(ctx).
+				With("backup_id", b.ID)
+		}
+	}
+
+	return nil
+}
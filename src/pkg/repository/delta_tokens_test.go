@@ -0,0 +1,338 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/kopia/kopia/repo/manifest"
+	"github.com/kopia/kopia/snapshot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/data"
+	dataMock "github.com/alcionai/corso/src/internal/data/mock"
+	"github.com/alcionai/corso/src/internal/kopia"
+	"github.com/alcionai/corso/src/internal/model"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup"
+	"github.com/alcionai/corso/src/pkg/backup/identity"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+type mockDeltaBaseFinder struct {
+	bb kopia.BackupBases
+}
+
+func (m mockDeltaBaseFinder) FindBases(
+	context.Context,
+	[]identity.Reasoner,
+	map[string]string,
+) kopia.BackupBases {
+	return m.bb
+}
+
+type mockDeltaRestoreProducer struct {
+	// delta urls keyed by snapshot id, then by category
+	deltas map[string]map[path.CategoryType]map[string]string
+	err    error
+}
+
+func (m mockDeltaRestoreProducer) ProduceRestoreCollections(
+	_ context.Context,
+	snapshotID string,
+	paths []path.RestorePaths,
+	_ kopia.ByteCounter,
+	_ *fault.Bus,
+) ([]data.RestoreCollection, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	colls := []data.RestoreCollection{}
+
+	for _, rp := range paths {
+		deltas, ok := m.deltas[snapshotID][rp.StoragePath.Category()]
+		if !ok {
+			return nil, clues.Stack(data.ErrNotFound)
+		}
+
+		bs, err := json.Marshal(deltas)
+		if err != nil {
+			return nil, err
+		}
+
+		colls = append(colls, dataMock.Collection{
+			Path: rp.RestorePath,
+			ItemData: []data.Item{
+				&dataMock.Item{
+					ItemID: rp.StoragePath.Item(),
+					Reader: io.NopCloser(bytes.NewReader(bs)),
+				},
+			},
+		})
+	}
+
+	return colls, nil
+}
+
+type mockBackupUpdater struct {
+	updated []*backup.Backup
+	err     error
+}
+
+func (m *mockBackupUpdater) UpdateBackup(_ context.Context, b *backup.Backup) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	m.updated = append(m.updated, b)
+
+	return nil
+}
+
+type RepositoryDeltaTokensUnitSuite struct {
+	tester.Suite
+}
+
+func TestRepositoryDeltaTokensUnitSuite(t *testing.T) {
+	suite.Run(t, &RepositoryDeltaTokensUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+const (
+	deltaTenant   = "tid"
+	deltaResource = "rid"
+)
+
+func deltaBackupEntry(
+	snapID string,
+	deltaReset bool,
+	srv path.ServiceType,
+	cats ...path.CategoryType,
+) (kopia.BackupEntry, kopia.ManifestEntry) {
+	reasons := []identity.Reasoner{}
+
+	for _, cat := range cats {
+		reasons = append(reasons, kopia.NewReason(deltaTenant, deltaResource, srv, cat))
+	}
+
+	be := kopia.BackupEntry{
+		Backup: &backup.Backup{
+			BaseModel:  model.BaseModel{ID: model.StableID(snapID + "bup")},
+			SnapshotID: snapID,
+			DeltaReset: deltaReset,
+		},
+		Reasons: reasons,
+	}
+
+	me := kopia.ManifestEntry{
+		Manifest: &snapshot.Manifest{ID: manifest.ID(snapID)},
+		Reasons:  reasons,
+	}
+
+	return be, me
+}
+
+func (suite *RepositoryDeltaTokensUnitSuite) TestListDeltaTokens() {
+	var (
+		mailBup, mailMan   = deltaBackupEntry("mail", false, path.ExchangeService, path.EmailCategory, path.EventsCategory)
+		driveBup, driveMan = deltaBackupEntry("drive", false, path.OneDriveService, path.FilesCategory)
+	)
+
+	table := []struct {
+		name      string
+		bb        kopia.BackupBases
+		rp        mockDeltaRestoreProducer
+		expect    []DeltaToken
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "no backups",
+			bb:        kopia.NewMockBackupBases(),
+			expect:    []DeltaToken{},
+			expectErr: assert.NoError,
+		},
+		{
+			name: "mail and drive tokens",
+			bb: kopia.NewMockBackupBases().
+				WithBackups(mailBup, driveBup).
+				WithMergeBases(mailMan, driveMan),
+			rp: mockDeltaRestoreProducer{
+				deltas: map[string]map[path.CategoryType]map[string]string{
+					"mail": {
+						path.EmailCategory:  {"inbox": "d-inbox", "archive": "d-archive"},
+						path.EventsCategory: {"calendar": "d-calendar"},
+					},
+					"drive": {
+						path.FilesCategory: {"drive1": "d-drive1"},
+					},
+				},
+			},
+			expect: []DeltaToken{
+				{"mailbup", "exchange", "email", "archive", "d-archive"},
+				{"mailbup", "exchange", "email", "inbox", "d-inbox"},
+				{"mailbup", "exchange", "events", "calendar", "d-calendar"},
+				{"drivebup", "onedrive", "files", "drive1", "d-drive1"},
+			},
+			expectErr: assert.NoError,
+		},
+		{
+			name: "missing delta metadata",
+			bb: kopia.NewMockBackupBases().
+				WithBackups(mailBup).
+				WithMergeBases(mailMan),
+			rp: mockDeltaRestoreProducer{
+				deltas: map[string]map[path.CategoryType]map[string]string{
+					"mail": {
+						path.EmailCategory: {"inbox": "d-inbox"},
+					},
+				},
+			},
+			expect: []DeltaToken{
+				{"mailbup", "exchange", "email", "inbox", "d-inbox"},
+			},
+			expectErr: assert.NoError,
+		},
+		{
+			name: "read failure",
+			bb: kopia.NewMockBackupBases().
+				WithBackups(mailBup).
+				WithMergeBases(mailMan),
+			rp:        mockDeltaRestoreProducer{err: assert.AnError},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			result, err := listDeltaTokens(
+				ctx,
+				mockDeltaBaseFinder{bb: test.bb},
+				test.rp,
+				deltaTenant,
+				deltaResource)
+			test.expectErr(t, err, clues.ToCore(err))
+
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, test.expect, result)
+		})
+	}
+}
+
+func (suite *RepositoryDeltaTokensUnitSuite) TestResetDeltaTokens() {
+	var (
+		mailBup, mailMan   = deltaBackupEntry("mail", false, path.ExchangeService, path.EmailCategory)
+		driveBup, driveMan = deltaBackupEntry("drive", false, path.OneDriveService, path.FilesCategory)
+		resetBup, resetMan = deltaBackupEntry("reset", true, path.SharePointService, path.LibrariesCategory)
+	)
+
+	table := []struct {
+		name          string
+		bb            *kopia.MockBackupBases
+		updateErr     error
+		expectUpdated []string
+		expectErr     assert.ErrorAssertionFunc
+	}{
+		{
+			name:          "no backups",
+			bb:            kopia.NewMockBackupBases(),
+			expectUpdated: []string{},
+			expectErr:     assert.NoError,
+		},
+		{
+			name: "resets every backup",
+			bb: kopia.NewMockBackupBases().
+				WithBackups(mailBup, driveBup).
+				WithMergeBases(mailMan, driveMan),
+			expectUpdated: []string{"mailbup", "drivebup"},
+			expectErr:     assert.NoError,
+		},
+		{
+			name: "skips backups already reset",
+			bb: kopia.NewMockBackupBases().
+				WithBackups(mailBup, resetBup).
+				WithMergeBases(mailMan, resetMan),
+			expectUpdated: []string{"mailbup"},
+			expectErr:     assert.NoError,
+		},
+		{
+			name: "update failure",
+			bb: kopia.NewMockBackupBases().
+				WithBackups(mailBup).
+				WithMergeBases(mailMan),
+			updateErr: assert.AnError,
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			// copy the backups, so that cases don't share the reset state.
+			bups := []kopia.BackupEntry{}
+
+			for _, be := range test.bb.Backups() {
+				b := *be.Backup
+				be.Backup = &b
+				bups = append(bups, be)
+			}
+
+			bu := &mockBackupUpdater{err: test.updateErr}
+
+			err := resetDeltaTokens(
+				ctx,
+				mockDeltaBaseFinder{bb: kopia.NewMockBackupBases().WithBackups(bups...)},
+				bu,
+				deltaTenant,
+				deltaResource)
+			test.expectErr(t, err, clues.ToCore(err))
+
+			if err != nil {
+				return
+			}
+
+			ids := []string{}
+
+			for _, b := range bu.updated {
+				assert.True(t, b.DeltaReset, "delta reset")
+				ids = append(ids, string(b.ID))
+			}
+
+			assert.Equal(t, test.expectUpdated, ids)
+		})
+	}
+}
+
+func (suite *RepositoryDeltaTokensUnitSuite) TestDeltaTokenReasons() {
+	reasons := deltaTokenReasons(deltaTenant, deltaResource)
+
+	got := map[path.ServiceType][]path.CategoryType{}
+
+	for _, r := range reasons {
+		assert.Equal(suite.T(), deltaTenant, r.Tenant())
+		assert.Equal(suite.T(), deltaResource, r.ProtectedResource())
+
+		got[r.Service()] = append(got[r.Service()], r.Category())
+	}
+
+	require.Len(suite.T(), got, len(deltaTokenCategories))
+
+	for srv, cats := range deltaTokenCategories {
+		assert.ElementsMatch(suite.T(), cats, got[srv], srv.String())
+	}
+}
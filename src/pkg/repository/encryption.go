@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+)
+
+// EncryptionInfo describes how the repository's data is encrypted at rest.
+type EncryptionInfo struct {
+	// ContentEncryption is the algorithm that encrypts all backup data and
+	// metadata in the repository.
+	ContentEncryption string `json:"contentEncryption"`
+	// FormatEncryption is the algorithm that encrypts the repository's
+	// format, which holds the content encryption key.
+	FormatEncryption string `json:"formatEncryption"`
+	// KeyDerivation is the function, including its parameters, that derives
+	// the format encryption key from the passphrase.
+	KeyDerivation string `json:"keyDerivation"`
+	// HashFunction is the keyed hash that identifies stored content.
+	HashFunction string `json:"hashFunction"`
+}
+
+// MinimumPrintable reduces the EncryptionInfo to its minimally printable
+// details.
+func (ei EncryptionInfo) MinimumPrintable() any {
+	return ei
+}
+
+// Headers returns the human-readable names of properties in an
+// EncryptionInfo for printing out to a terminal in a columnar display.
+func (ei EncryptionInfo) Headers() []string {
+	return []string{
+		"Content Encryption",
+		"Format Encryption",
+		"Key Derivation",
+		"Hash Function",
+	}
+}
+
+// Values returns the values matching the Headers list for printing
+// out to a terminal in a columnar display.
+func (ei EncryptionInfo) Values() []string {
+	return []string{
+		ei.ContentEncryption,
+		ei.FormatEncryption,
+		ei.KeyDerivation,
+		ei.HashFunction,
+	}
+}
+
+// EncryptionInfo produces the encryption algorithms and key derivation
+// parameters that protect the repository's data at rest.
+func (r repository) EncryptionInfo(ctx context.Context) (EncryptionInfo, error) {
+	if r.dataLayer == nil {
+		return EncryptionInfo{}, clues.New("repository is closed").WithClues(ctx)
+	}
+
+	ei, err := r.dataLayer.EncryptionInfo(ctx)
+	if err != nil {
+		return EncryptionInfo{}, clues.Wrap(err, "getting repository encryption info")
+	}
+
+	return EncryptionInfo{
+		ContentEncryption: ei.ContentEncryption,
+		FormatEncryption:  ei.FormatEncryption,
+		KeyDerivation:     ei.KeyDerivation,
+		HashFunction:      ei.HashFunction,
+	}, nil
+}
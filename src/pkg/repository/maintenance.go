@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/dustin/go-humanize"
+
+	"github.com/alcionai/corso/src/internal/kopia"
+	ctrlRepo "github.com/alcionai/corso/src/pkg/control/repository"
+	"github.com/alcionai/corso/src/pkg/store"
+)
+
+// MaintenancePreview reports the repository data that complete
+// maintenance would delete, without deleting anything.
+type MaintenancePreview struct {
+	// UnreferencedContents are pieces of backup data that no snapshot uses
+	// anymore, and that are old enough to be deleted.
+	UnreferencedContents     int64 `json:"unreferencedContents"`
+	UnreferencedContentBytes int64 `json:"unreferencedContentBytes"`
+	// UnreferencedBlobs are storage objects that hold no data known to the
+	// repository, and that are old enough to be deleted.
+	UnreferencedBlobs     int64 `json:"unreferencedBlobs"`
+	UnreferencedBlobBytes int64 `json:"unreferencedBlobBytes"`
+	// OrphanedSnapshots are the details and errors streamstores that no
+	// backup references.  Maintenance doesn't delete them, but garbage
+	// collecting orphans does, after which their data becomes unreferenced.
+	OrphanedSnapshots []string `json:"orphanedSnapshots,omitempty"`
+	// ReclaimBytes estimates the storage freed by maintenance.  Unreferenced
+	// contents only free their storage once the blobs holding them get
+	// rewritten, which can take more than one maintenance run.
+	ReclaimBytes int64 `json:"reclaimBytes"`
+}
+
+// MinimumPrintable reduces the MaintenancePreview to its minimally
+// printable details.
+func (mp MaintenancePreview) MinimumPrintable() any {
+	return mp
+}
+
+// Headers returns the human-readable names of properties in a
+// MaintenancePreview for printing out to a terminal in a columnar display.
+func (mp MaintenancePreview) Headers() []string {
+	return []string{
+		"Unreferenced Contents",
+		"Unreferenced Blobs",
+		"Orphaned Snapshots",
+		"Estimated Reclaim",
+	}
+}
+
+// Values returns the values matching the Headers list for printing
+// out to a terminal in a columnar display.
+func (mp MaintenancePreview) Values() []string {
+	return []string{
+		strconv.FormatInt(mp.UnreferencedContents, 10),
+		strconv.FormatInt(mp.UnreferencedBlobs, 10),
+		strconv.Itoa(len(mp.OrphanedSnapshots)),
+		humanize.Bytes(uint64(mp.ReclaimBytes)),
+	}
+}
+
+type maintenanceCandidateFinder interface {
+	MaintenanceCandidates(
+		ctx context.Context,
+		opts ctrlRepo.Maintenance,
+	) (kopia.MaintenanceCandidates, error)
+}
+
+// MaintenancePreview reports the unreferenced data that complete
+// maintenance with mOpts would delete, and the size of the storage it
+// would reclaim.  Only complete maintenance deletes data.  Nothing in the
+// repository is modified.
+func (r repository) MaintenancePreview(
+	ctx context.Context,
+	mOpts ctrlRepo.Maintenance,
+) (*MaintenancePreview, error) {
+	if r.dataLayer == nil {
+		return nil, clues.New("repository is closed").WithClues(ctx)
+	}
+
+	return maintenancePreview(
+		ctx,
+		r.dataLayer,
+		r.dataLayer,
+		store.NewWrapper(r.modelStore),
+		time.Now().Add(-orphanGCBuffer),
+		mOpts)
+}
+
+// maintenancePreview handles the processing for MaintenancePreview.
+// Streamstores modified after the cutoff aren't considered orphaned.
+func maintenancePreview(
+	ctx context.Context,
+	mcf maintenanceCandidateFinder,
+	ssmf streamStoreManifestFinder,
+	sw backupListerModelDeleter,
+	cutoff time.Time,
+	mOpts ctrlRepo.Maintenance,
+) (*MaintenancePreview, error) {
+	mc, err := mcf.MaintenanceCandidates(ctx, mOpts)
+	if err != nil {
+		return nil, clues.Wrap(err, "finding maintenance candidates")
+	}
+
+	orphans, err := garbageCollectOrphans(ctx, ssmf, sw, cutoff, true)
+	if err != nil {
+		return nil, clues.Wrap(err, "finding orphaned snapshots")
+	}
+
+	mp := &MaintenancePreview{
+		UnreferencedContents:     mc.UnreferencedContents,
+		UnreferencedContentBytes: mc.UnreferencedContentBytes,
+		UnreferencedBlobs:        mc.UnreferencedBlobs,
+		UnreferencedBlobBytes:    mc.UnreferencedBlobBytes,
+		ReclaimBytes:             mc.UnreferencedContentBytes + mc.UnreferencedBlobBytes,
+	}
+
+	for _, id := range orphans {
+		mp.OrphanedSnapshots = append(mp.OrphanedSnapshots, string(id))
+	}
+
+	return mp, nil
+}
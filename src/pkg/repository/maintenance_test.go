@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/kopia/kopia/repo/manifest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/kopia"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup"
+	ctrlRepo "github.com/alcionai/corso/src/pkg/control/repository"
+)
+
+type mockMaintenanceCandidateFinder struct {
+	mc  kopia.MaintenanceCandidates
+	err error
+
+	opts ctrlRepo.Maintenance
+}
+
+func (m *mockMaintenanceCandidateFinder) MaintenanceCandidates(
+	_ context.Context,
+	opts ctrlRepo.Maintenance,
+) (kopia.MaintenanceCandidates, error) {
+	m.opts = opts
+	return m.mc, m.err
+}
+
+type RepositoryMaintenanceUnitSuite struct {
+	tester.Suite
+}
+
+func TestRepositoryMaintenanceUnitSuite(t *testing.T) {
+	suite.Run(t, &RepositoryMaintenanceUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *RepositoryMaintenanceUnitSuite) TestMaintenancePreview() {
+	var (
+		now    = time.Now()
+		old    = now.Add(-2 * orphanGCBuffer)
+		cutoff = now.Add(-orphanGCBuffer)
+
+		mOpts = ctrlRepo.Maintenance{
+			Type:   ctrlRepo.CompleteMaintenance,
+			Safety: ctrlRepo.FullMaintenanceSafety,
+		}
+
+		manifests = []*manifest.EntryMetadata{
+			{ID: "referenced", ModTime: old},
+			{ID: "orphan", ModTime: old},
+			{ID: "recent", ModTime: now},
+		}
+
+		backups = []*backup.Backup{{StreamStoreID: "referenced"}}
+
+		candidates = kopia.MaintenanceCandidates{
+			UnreferencedContents:     3,
+			UnreferencedContentBytes: 300,
+			UnreferencedBlobs:        2,
+			UnreferencedBlobBytes:    2000,
+		}
+	)
+
+	table := []struct {
+		name      string
+		mcf       *mockMaintenanceCandidateFinder
+		ssmf      mockStreamStoreManifestFinder
+		expect    *MaintenancePreview
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "eligible content",
+			mcf:  &mockMaintenanceCandidateFinder{mc: candidates},
+			ssmf: mockStreamStoreManifestFinder{manifests: manifests},
+			expect: &MaintenancePreview{
+				UnreferencedContents:     3,
+				UnreferencedContentBytes: 300,
+				UnreferencedBlobs:        2,
+				UnreferencedBlobBytes:    2000,
+				OrphanedSnapshots:        []string{"orphan"},
+				ReclaimBytes:             2300,
+			},
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "nothing eligible",
+			mcf:       &mockMaintenanceCandidateFinder{},
+			ssmf:      mockStreamStoreManifestFinder{manifests: manifests[:1]},
+			expect:    &MaintenancePreview{},
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "finding candidates fails",
+			mcf:       &mockMaintenanceCandidateFinder{err: assert.AnError},
+			ssmf:      mockStreamStoreManifestFinder{manifests: manifests},
+			expectErr: assert.Error,
+		},
+		{
+			name:      "finding orphans fails",
+			mcf:       &mockMaintenanceCandidateFinder{mc: candidates},
+			ssmf:      mockStreamStoreManifestFinder{err: assert.AnError},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			sw := &mockBackupListerModelDeleter{backups: backups}
+
+			result, err := maintenancePreview(ctx, test.mcf, test.ssmf, sw, cutoff, mOpts)
+			test.expectErr(t, err, clues.ToCore(err))
+
+			assert.Equal(t, test.expect, result)
+			assert.Equal(t, mOpts, test.mcf.opts, "maintenance options")
+			assert.Empty(t, sw.deleted, "previews delete nothing")
+		})
+	}
+}
+
+func (suite *RepositoryMaintenanceUnitSuite) TestMaintenancePreview_closedRepository() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	_, err := repository{}.MaintenancePreview(ctx, ctrlRepo.Maintenance{})
+	require.Error(t, err, clues.ToCore(err))
+}
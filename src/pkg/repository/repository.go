@@ -2,6 +2,11 @@ package repository
 
 import (
 	"context"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/alcionai/clues"
@@ -16,6 +21,8 @@ import (
 	"github.com/alcionai/corso/src/internal/kopia"
 	"github.com/alcionai/corso/src/internal/m365"
 	"github.com/alcionai/corso/src/internal/m365/collection/drive/metadata"
+	"github.com/alcionai/corso/src/internal/m365/collection/exchange"
+	"github.com/alcionai/corso/src/internal/m365/graph"
 	"github.com/alcionai/corso/src/internal/model"
 	"github.com/alcionai/corso/src/internal/observe"
 	"github.com/alcionai/corso/src/internal/operations"
@@ -27,17 +34,28 @@ import (
 	"github.com/alcionai/corso/src/pkg/control"
 	ctrlRepo "github.com/alcionai/corso/src/pkg/control/repository"
 	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/credentials"
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/logger"
 	"github.com/alcionai/corso/src/pkg/path"
 	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
 	"github.com/alcionai/corso/src/pkg/storage"
 	"github.com/alcionai/corso/src/pkg/store"
 )
 
 var (
-	ErrorRepoAlreadyExists = clues.New("a repository was already initialized with that configuration")
-	ErrorBackupNotFound    = clues.New("no backup exists with that id")
+	ErrorRepoAlreadyExists  = clues.New("a repository was already initialized with that configuration")
+	ErrorBackupNotFound     = clues.New("no backup exists with that id")
+	ErrorInvalidPassphrase  = clues.New("the passphrase does not match the repository")
+	ErrorBackupPinned       = clues.New("the backup is pinned")
+	ErrorRepoVersionTooNew  = clues.New("the repository was upgraded by a newer version of corso")
+	ErrorPreviewUnsupported = clues.New("previewing a backup is only supported for exchange")
+
+	ErrorCredentialsExpired      = clues.New("the m365 client secret has expired")
+	ErrorCredentialsInvalid      = clues.New("the m365 tenant, client id, or client secret is invalid")
+	ErrorConsentRequired         = clues.New("admin consent has not been granted to the m365 application")
+	ErrorInsufficientPermissions = clues.New("the m365 application is missing required permissions")
 )
 
 // BackupGetter deals with retrieving metadata about backups from the
@@ -46,6 +64,9 @@ type BackupGetter interface {
 	Backup(ctx context.Context, id string) (*backup.Backup, error)
 	Backups(ctx context.Context, ids []string) ([]*backup.Backup, *fault.Bus)
 	BackupsByTag(ctx context.Context, fs ...store.FilterOption) ([]*backup.Backup, error)
+	BackupsForResource(ctx context.Context, resourceID string) ([]*backup.Backup, error)
+	LatestBackup(ctx context.Context, resourceID string, service path.ServiceType) (*backup.Backup, error)
+	BackupLineage(ctx context.Context, id string) ([]backup.LineageEntry, error)
 	GetBackupDetails(
 		ctx context.Context,
 		backupID string,
@@ -59,6 +80,15 @@ type BackupGetter interface {
 type Repository interface {
 	GetID() string
 	Close(context.Context) error
+	// SetReadOnly switches the open repository connection to read-only.
+	SetReadOnly(ctx context.Context) error
+	// ChangePassphrase re-encrypts the repository with a new passphrase.
+	ChangePassphrase(ctx context.Context, oldPass, newPass string) error
+	// EncryptionInfo describes how the repository is encrypted at rest.
+	EncryptionInfo(ctx context.Context) (EncryptionInfo, error)
+	// BenchmarkCompression measures how well each compressor compresses a
+	// sample of the repository's data.
+	BenchmarkCompression(ctx context.Context, sampleItems int) ([]CompressionResult, error)
 	NewBackup(
 		ctx context.Context,
 		self selectors.Selector,
@@ -84,11 +114,45 @@ type Repository interface {
 		ctx context.Context,
 		mOpts ctrlRepo.Maintenance,
 	) (operations.MaintenanceOperation, error)
+	// MaintenancePreview reports the data that complete maintenance would
+	// delete, without running it.
+	MaintenancePreview(
+		ctx context.Context,
+		mOpts ctrlRepo.Maintenance,
+	) (*MaintenancePreview, error)
 	NewRetentionConfig(
 		ctx context.Context,
 		rcOpts ctrlRepo.Retention,
 	) (operations.RetentionConfigOperation, error)
+	// RetentionStatus produces the retention settings currently applied
+	// to the repository.
+	RetentionStatus(ctx context.Context) (ctrlRepo.Retention, error)
+	// DeleteBackups refuses to delete pinned backups.
 	DeleteBackups(ctx context.Context, failOnMissing bool, ids ...string) error
+	// ForceDeleteBackups deletes the backups even if they're pinned.
+	ForceDeleteBackups(ctx context.Context, failOnMissing bool, ids ...string) error
+	// SetBackupPinned pins or unpins the backup.  Pinned backups are
+	// protected from deletion.
+	SetBackupPinned(ctx context.Context, backupID string, pinned bool) error
+	// GarbageCollectOrphans deletes details and errors streamstores that no
+	// backup references.  In a dry run, nothing gets deleted.
+	GarbageCollectOrphans(ctx context.Context, dryRun bool) ([]manifest.ID, error)
+	SearchBackupItems(ctx context.Context, backupID, query string) ([]details.Entry, error)
+	// ListDeletedItems finds items that were removed from the resource's
+	// backups since the given time, but remain in an earlier backup.
+	ListDeletedItems(ctx context.Context, resourceID string, since time.Time) ([]details.Entry, error)
+	// AuditBackup reports the items that currently exist in m365 for the
+	// backed up resource but are missing from the backup.
+	AuditBackup(ctx context.Context, backupID string) (*AuditReport, error)
+	// PreviewBackup reports the containers that a backup of the selector
+	// would include, without backing anything up.
+	PreviewBackup(ctx context.Context, sel selectors.Selector) (*BackupPreview, error)
+	// ListDeltaTokens produces the delta tokens stored in the metadata of
+	// the resource's latest backups.
+	ListDeltaTokens(ctx context.Context, resourceID string) ([]DeltaToken, error)
+	// ResetDeltaTokens forces the next backup of the resource to ignore
+	// the stored delta tokens, and run as a full backup.
+	ResetDeltaTokens(ctx context.Context, resourceID string) error
 	BackupGetter
 	// ConnectToM365 establishes graph api connections
 	// and initializes api client configurations.
@@ -96,6 +160,9 @@ type Repository interface {
 		ctx context.Context,
 		pst path.ServiceType,
 	) (*m365.Controller, error)
+	// VerifyAccess makes a minimal graph api call to confirm that the
+	// m365 credentials are valid and authorized for the service.
+	VerifyAccess(ctx context.Context, pst path.ServiceType) error
 }
 
 // Repository contains storage provider information.
@@ -145,6 +212,11 @@ func Initialize(
 		}
 	}()
 
+	acct, s, err = resolveCredentials(acct, s, opts.CredentialsProvider)
+	if err != nil {
+		return nil, clues.Stack(err).WithClues(ctx)
+	}
+
 	kopiaRef := kopia.NewConn(s)
 	if err := kopiaRef.Initialize(ctx, opts.Repo, retentionOpts); err != nil {
 		// replace common internal errors so that sdk users can check results with errors.Is()
@@ -178,7 +250,7 @@ func Initialize(
 
 	r := &repository{
 		ID:         repoID,
-		Version:    "v1",
+		Version:    formatRepoVersion(currentRepoVersion),
 		Account:    acct,
 		Storage:    s,
 		Bus:        bus,
@@ -188,7 +260,7 @@ func Initialize(
 	}
 
 	if err := newRepoModel(ctx, ms, r.ID); err != nil {
-		return nil, clues.New("setting up repository").WithClues(ctx)
+		return nil, clues.Wrap(err, "setting up repository").WithClues(ctx)
 	}
 
 	r.Bus.Event(ctx, events.RepoInit, nil)
@@ -207,6 +279,32 @@ func Connect(
 	s storage.Storage,
 	repoid string,
 	opts control.Options,
+) (Repository, error) {
+	return connect(ctx, acct, s, repoid, opts, RetryConfig{})
+}
+
+// ConnectWithRetry behaves like Connect, except that connecting to the
+// provider storage gets retried, with exponential backoff, while the
+// failures look like transient network errors.  Auth and config errors
+// fail immediately.
+func ConnectWithRetry(
+	ctx context.Context,
+	acct account.Account,
+	s storage.Storage,
+	repoid string,
+	opts control.Options,
+	retry RetryConfig,
+) (Repository, error) {
+	return connect(ctx, acct, s, repoid, opts, retry)
+}
+
+func connect(
+	ctx context.Context,
+	acct account.Account,
+	s storage.Storage,
+	repoid string,
+	opts control.Options,
+	retry RetryConfig,
 ) (r Repository, err error) {
 	ctx = clues.Add(
 		ctx,
@@ -220,11 +318,16 @@ func Connect(
 		}
 	}()
 
+	acct, s, err = resolveCredentials(acct, s, opts.CredentialsProvider)
+	if err != nil {
+		return nil, clues.Stack(err).WithClues(ctx)
+	}
+
 	progressBar := observe.MessageWithCompletion(ctx, "Connecting to repository")
 	defer close(progressBar)
 
 	kopiaRef := kopia.NewConn(s)
-	if err := kopiaRef.Connect(ctx, opts.Repo); err != nil {
+	if err := connectWithRetry(ctx, kopiaRef, opts.Repo, retry); err != nil {
 		return nil, clues.Wrap(err, "connecting kopia client")
 	}
 	// kopiaRef comes with a count of 1 and NewWrapper/NewModelStore bumps it again so safe
@@ -246,6 +349,11 @@ func Connect(
 		return nil, clues.Wrap(err, "constructing event bus")
 	}
 
+	repoVersion, err := upgradeRepo(ctx, ms, repoMigrations, currentRepoVersion, opts.Repo.ReadOnly)
+	if err != nil {
+		return nil, clues.Wrap(err, "upgrading repository")
+	}
+
 	if repoid == events.RepoIDNotFound {
 		rm, err := getRepoModel(ctx, ms)
 		if err != nil {
@@ -255,15 +363,12 @@ func Connect(
 		repoid = string(rm.ID)
 	}
 
-	// Do not query repo ID if metrics are disabled
-	if !opts.DisableMetrics {
-		bus.SetRepoID(repoid)
-	}
+	bus.SetRepoID(repoid)
 
 	// todo: ID and CreatedAt should get retrieved from a stored kopia config.
 	return &repository{
 		ID:         repoid,
-		Version:    "v1",
+		Version:    formatRepoVersion(repoVersion),
 		Account:    acct,
 		Storage:    s,
 		Bus:        bus,
@@ -273,6 +378,26 @@ func Connect(
 	}, nil
 }
 
+// resolveCredentials fills in any credentials missing from the account and
+// storage configs using the provider, falling back to env vars.
+func resolveCredentials(
+	acct account.Account,
+	s storage.Storage,
+	p credentials.Provider,
+) (account.Account, storage.Storage, error) {
+	acct, err := acct.ResolveCredentials(p)
+	if err != nil {
+		return acct, s, clues.Wrap(err, "resolving account credentials")
+	}
+
+	s, err = s.ResolveCredentials(p)
+	if err != nil {
+		return acct, s, clues.Wrap(err, "resolving storage credentials")
+	}
+
+	return acct, s, nil
+}
+
 func ConnectAndSendConnectEvent(ctx context.Context,
 	acct account.Account,
 	s storage.Storage,
@@ -314,6 +439,54 @@ func (r *repository) Close(ctx context.Context) error {
 	return nil
 }
 
+// SetReadOnly reconnects to the repository in read-only mode, so that any
+// later operation that writes to the repository, such as a backup or
+// maintenance, fails with a read-only error.  The connection stays
+// read-only until the repository is closed.  Must not be called while
+// other operations are running.
+func (r *repository) SetReadOnly(ctx context.Context) error {
+	if r.Opts.Repo.ReadOnly {
+		return nil
+	}
+
+	if r.dataLayer == nil {
+		return clues.New("repository is closed").WithClues(ctx)
+	}
+
+	// the data layer and model store share a single kopia connection.
+	if err := r.dataLayer.SetReadOnly(ctx, r.Opts.Repo); err != nil {
+		return clues.Wrap(err, "switching repository to read-only")
+	}
+
+	r.Opts.Repo.ReadOnly = true
+
+	return nil
+}
+
+// ChangePassphrase re-encrypts the repository's format with newPass, after
+// confirming that oldPass is the current passphrase.  Data in the
+// repository is not rewritten.  The repository's storage config is
+// updated to hold the new passphrase, but any persisted copy of the old
+// passphrase, such as in a config file or env var, must be updated by the
+// caller.
+func (r *repository) ChangePassphrase(ctx context.Context, oldPass, newPass string) error {
+	if r.dataLayer == nil {
+		return clues.New("repository is closed").WithClues(ctx)
+	}
+
+	if err := r.dataLayer.ChangePassphrase(ctx, oldPass, newPass); err != nil {
+		if errors.Is(err, kopia.ErrorInvalidPassphrase) {
+			return clues.Stack(ErrorInvalidPassphrase, err).WithClues(ctx)
+		}
+
+		return clues.Wrap(err, "changing repository passphrase")
+	}
+
+	r.Storage.SetCorsoPassphrase(newPass)
+
+	return nil
+}
+
 // NewBackup generates a BackupOperation runner.
 func (r repository) NewBackup(
 	ctx context.Context,
@@ -343,7 +516,7 @@ func (r repository) NewBackupWithLookup(
 	// TODO: retrieve display name from gc
 	sel = sel.SetDiscreteOwnerIDName(ownerID, ownerName)
 
-	return operations.NewBackupOperation(
+	op, err := operations.NewBackupOperation(
 		ctx,
 		r.Opts,
 		r.dataLayer,
@@ -353,6 +526,31 @@ func (r repository) NewBackupWithLookup(
 		sel,
 		sel, // the selector acts as an IDNamer for its discrete resource owner.
 		r.Bus)
+	if err != nil {
+		return operations.BackupOperation{}, err
+	}
+
+	op.DetailsSigningKey = r.detailsSigningKey(ctx)
+
+	return op, nil
+}
+
+// detailsSigningKey derives the key that signs and verifies backup
+// details from the repository's master key.  Returns nil if the key
+// can't be derived, in which case details are neither signed nor
+// verified.
+func (r repository) detailsSigningKey(ctx context.Context) []byte {
+	if r.dataLayer == nil {
+		return nil
+	}
+
+	key, err := backup.DetailsSigningKey(ctx, r.dataLayer)
+	if err != nil {
+		logger.CtxErr(ctx, err).Info("deriving details signing key")
+		return nil
+	}
+
+	return key
 }
 
 // NewExport generates a exportOperation runner.
@@ -430,6 +628,21 @@ func (r repository) NewRetentionConfig(
 		r.Bus)
 }
 
+// RetentionStatus produces the retention mode, duration, and object lock
+// extension currently applied to the repository's storage.
+func (r repository) RetentionStatus(ctx context.Context) (ctrlRepo.Retention, error) {
+	if r.dataLayer == nil {
+		return ctrlRepo.Retention{}, clues.New("repository is closed").WithClues(ctx)
+	}
+
+	rc, err := r.dataLayer.RetentionStatus(ctx)
+	if err != nil {
+		return ctrlRepo.Retention{}, clues.Wrap(err, "getting repository retention status")
+	}
+
+	return rc, nil
+}
+
 // Backup retrieves a backup by id.
 func (r repository) Backup(ctx context.Context, id string) (*backup.Backup, error) {
 	return getBackup(ctx, id, store.NewWrapper(r.modelStore))
@@ -506,166 +719,928 @@ func backupsByTag(
 	return res, nil
 }
 
-// BackupDetails returns the specified backup.Details
-func (r repository) GetBackupDetails(
+// BackupsForResource lists all backups in a repository whose selector
+// targets the protected resource.
+func (r repository) BackupsForResource(ctx context.Context, resourceID string) ([]*backup.Backup, error) {
+	sw := store.NewWrapper(r.modelStore)
+	return backupsForResource(ctx, sw, resourceID)
+}
+
+// backupsForResource returns all backups whose selector's discrete owner
+// matches the resource ID.  Assist backups are excluded, same as in
+// backupsByTag.
+func backupsForResource(
 	ctx context.Context,
-	backupID string,
-) (*details.Details, *backup.Backup, *fault.Bus) {
-	errs := fault.New(false)
+	sw store.BackupWrapper,
+	resourceID string,
+) ([]*backup.Backup, error) {
+	if len(resourceID) == 0 {
+		return nil, clues.New("missing protected resource id").WithClues(ctx)
+	}
 
-	deets, bup, err := getBackupDetails(
-		ctx,
-		backupID,
-		r.Account.ID(),
-		r.dataLayer,
-		store.NewWrapper(r.modelStore),
-		errs)
+	bs, err := backupsByTag(ctx, sw, nil)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
 
-	return deets, bup, errs.Fail(err)
+	res := make([]*backup.Backup, 0, len(bs))
+
+	for _, b := range bs {
+		if b.Selector.DiscreteOwner == resourceID {
+			res = append(res, b)
+		}
+	}
+
+	return res, nil
 }
 
-// getBackupDetails handles the processing for GetBackupDetails.
-func getBackupDetails(
+// LatestBackup returns the most recently created backup of the protected
+// resource within the service.  Assist backups are never returned.
+func (r repository) LatestBackup(
 	ctx context.Context,
-	backupID, tenantID string,
-	kw *kopia.Wrapper,
-	sw store.BackupGetter,
-	errs *fault.Bus,
-) (*details.Details, *backup.Backup, error) {
-	b, err := sw.GetBackup(ctx, model.StableID(backupID))
+	resourceID string,
+	service path.ServiceType,
+) (*backup.Backup, error) {
+	sw := store.NewWrapper(r.modelStore)
+	return latestBackup(ctx, sw, resourceID, service)
+}
+
+// latestBackup returns the newest of the resource's backups whose selector
+// targets the service.  Returns data.ErrNotFound if no backup matches.
+func latestBackup(
+	ctx context.Context,
+	sw store.BackupWrapper,
+	resourceID string,
+	service path.ServiceType,
+) (*backup.Backup, error) {
+	ctx = clues.Add(ctx, "resource_id", clues.Hide(resourceID), "service", service)
+
+	bs, err := backupsForResource(ctx, sw, resourceID)
 	if err != nil {
-		return nil, nil, errWrapper(err)
+		return nil, clues.Stack(err)
 	}
 
-	ssid := b.StreamStoreID
-	if len(ssid) == 0 {
-		ssid = b.DetailsID
+	res := make([]*backup.Backup, 0, len(bs))
+
+	for _, b := range bs {
+		if b.Selector.PathService() == service {
+			res = append(res, b)
+		}
 	}
 
-	if len(ssid) == 0 {
-		return nil, b, clues.New("no streamstore id in backup").WithClues(ctx)
+	res = backup.LatestByResource(res)
+	if len(res) == 0 {
+		return nil, clues.Stack(data.ErrNotFound).WithClues(ctx)
 	}
 
-	var (
-		sstore = streamstore.NewStreamer(kw, tenantID, b.Selector.PathService())
-		deets  details.Details
-	)
+	return res[0], nil
+}
 
-	err = sstore.Read(
-		ctx,
-		ssid,
-		streamstore.DetailsReader(details.UnmarshalTo(&deets)),
-		errs)
+// BackupLineage returns the chain of merge and assist bases that the backup
+// was built from, starting with the backup itself.
+func (r repository) BackupLineage(ctx context.Context, id string) ([]backup.LineageEntry, error) {
+	return backupLineage(ctx, id, store.NewWrapper(r.modelStore))
+}
+
+// backupLineage walks the base references of the backup depth-first, so
+// that each backup is followed by its own bases.  A base that's shared by
+// several backups in the chain only appears the first time it's found.
+// Bases that no longer exist are included without their backup model.
+func backupLineage(
+	ctx context.Context,
+	id string,
+	sw store.BackupGetter,
+) ([]backup.LineageEntry, error) {
+	root, err := getBackup(ctx, id, sw)
 	if err != nil {
-		return nil, nil, err
+		return nil, clues.Stack(err)
 	}
 
-	// Retroactively fill in isMeta information for items in older
-	// backup versions without that info
-	// version.Restore2 introduces the IsMeta flag, so only v1 needs a check.
-	if b.Version >= version.OneDrive1DataAndMetaFiles && b.Version < version.OneDrive3IsMetaMarker {
-		for _, d := range deets.Entries {
-			if d.OneDrive != nil {
-				d.OneDrive.IsMeta = metadata.HasMetaSuffix(d.RepoRef)
+	var (
+		res  = []backup.LineageEntry{}
+		seen = map[model.StableID]struct{}{}
+		walk func(le backup.LineageEntry) error
+	)
+
+	walk = func(le backup.LineageEntry) error {
+		res = append(res, le)
+
+		if le.Backup == nil {
+			return nil
+		}
+
+		bases := make([]backup.LineageEntry, 0, len(le.Backup.MergeBaseIDs)+len(le.Backup.AssistBaseIDs))
+
+		for _, bid := range le.Backup.MergeBaseIDs {
+			bases = append(bases, backup.LineageEntry{ID: bid, Relation: backup.LineageMergeBase})
+		}
+
+		for _, bid := range le.Backup.AssistBaseIDs {
+			bases = append(bases, backup.LineageEntry{ID: bid, Relation: backup.LineageAssistBase})
+		}
+
+		for _, base := range bases {
+			if _, ok := seen[base.ID]; ok {
+				continue
+			}
+
+			seen[base.ID] = struct{}{}
+
+			ictx := clues.Add(ctx, "base_backup_id", base.ID)
+
+			b, err := sw.GetBackup(ictx, base.ID)
+			if err != nil && !errors.Is(err, data.ErrNotFound) {
+				return clues.Wrap(err, "getting base backup").WithClues(ictx)
+			}
+
+			base.Depth = le.Depth + 1
+			base.BaseOf = le.ID
+			base.Backup = b
+
+			if err := walk(base); err != nil {
+				return err
 			}
 		}
+
+		return nil
 	}
 
-	deets.DetailsModel = deets.FilterMetaFiles()
+	seen[root.ID] = struct{}{}
 
-	return &deets, b, nil
+	err = walk(backup.LineageEntry{
+		ID:       root.ID,
+		Relation: backup.LineageRoot,
+		Backup:   root,
+	})
+
+	return res, err
 }
 
-// BackupErrors returns the specified backup's fault.Errors
-func (r repository) GetBackupErrors(
+// BackupDetails returns the specified backup.Details
+func (r repository) GetBackupDetails(
 	ctx context.Context,
 	backupID string,
-) (*fault.Errors, *backup.Backup, *fault.Bus) {
+) (*details.Details, *backup.Backup, *fault.Bus) {
 	errs := fault.New(false)
 
-	fe, bup, err := getBackupErrors(
+	deets, bup, err := getBackupDetails(
 		ctx,
 		backupID,
 		r.Account.ID(),
+		r.detailsSigningKey(ctx),
 		r.dataLayer,
 		store.NewWrapper(r.modelStore),
 		errs)
 
-	return fe, bup, errs.Fail(err)
+	return deets, bup, errs.Fail(err)
 }
 
-// getBackupErrors handles the processing for GetBackupErrors.
-func getBackupErrors(
+// SearchBackupItems returns the details entries in the backup whose item
+// name or path contains the query, ignoring case.
+func (r repository) SearchBackupItems(
 	ctx context.Context,
-	backupID, tenantID string,
-	kw *kopia.Wrapper,
-	sw store.BackupGetter,
-	errs *fault.Bus,
-) (*fault.Errors, *backup.Backup, error) {
-	b, err := sw.GetBackup(ctx, model.StableID(backupID))
+	backupID, query string,
+) ([]details.Entry, error) {
+	deets, _, err := getBackupDetails(
+		ctx,
+		backupID,
+		r.Account.ID(),
+		r.detailsSigningKey(ctx),
+		r.dataLayer,
+		store.NewWrapper(r.modelStore),
+		fault.New(false))
 	if err != nil {
-		return nil, nil, errWrapper(err)
+		return nil, clues.Wrap(err, "getting backup details")
 	}
 
-	ssid := b.StreamStoreID
-	if len(ssid) == 0 {
-		return nil, b, clues.New("missing streamstore id in backup").WithClues(ctx)
-	}
+	return deets.Search(query).Entries, nil
+}
 
-	var (
-		sstore = streamstore.NewStreamer(kw, tenantID, b.Selector.PathService())
-		fe     fault.Errors
-	)
+// ListDeletedItems compares the details of consecutive backups of the
+// resource to find items that were present in one backup and missing from
+// the next.  Only removals found in backups created at or after since are
+// reported, and items that reappear in a later backup are ignored.  Each
+// backed up service is compared separately.  The returned entries come
+// from the most recent backup that still holds the item, which can be used
+// to restore it.
+func (r repository) ListDeletedItems(
+	ctx context.Context,
+	resourceID string,
+	since time.Time,
+) ([]details.Entry, error) {
+	sw := store.NewWrapper(r.modelStore)
 
-	err = sstore.Read(
-		ctx,
-		ssid,
-		streamstore.FaultErrorsReader(fault.UnmarshalErrorsTo(&fe)),
-		errs)
+	bups, err := backupsByTag(ctx, sw, nil)
 	if err != nil {
-		return nil, nil, err
+		return nil, clues.Wrap(err, "listing backups")
 	}
 
-	return &fe, b, nil
-}
+	getDeets := func(ctx context.Context, b *backup.Backup) (*details.Details, error) {
+		deets, _, err := getBackupDetails(
+			ctx,
+			string(b.ID),
+			r.Account.ID(),
+			r.detailsSigningKey(ctx),
+			r.dataLayer,
+			sw,
+			fault.New(false))
+
+		return deets, clues.Stack(err).OrNil()
+	}
 
-// DeleteBackups removes the backups from both the model store and the backup
-// storage.
-//
-// If failOnMissing is true then returns an error if a backup model can't be
-// found. Otherwise ignores missing backup models.
-//
-// Missing models or snapshots during the actual deletion do not cause errors.
-//
-// All backups are delete as an atomic unit so any failures will result in no
-// deletions.
-func (r repository) DeleteBackups(
-	ctx context.Context,
-	failOnMissing bool,
-	ids ...string,
-) error {
-	return deleteBackups(ctx, store.NewWrapper(r.modelStore), failOnMissing, ids...)
+	return listDeletedItems(ctx, bups, resourceID, since, getDeets)
 }
 
-// deleteBackup handles the processing for backup deletion.
-func deleteBackups(
+type detailsGetter func(ctx context.Context, b *backup.Backup) (*details.Details, error)
+
+// listDeletedItems handles the processing for ListDeletedItems.
+func listDeletedItems(
 	ctx context.Context,
-	sw store.BackupGetterModelDeleter,
-	failOnMissing bool,
-	ids ...string,
-) error {
-	// Although we haven't explicitly stated it, snapshots are technically
-	// manifests in kopia. This means we can use the same delete API to remove
-	// them and backup models. Deleting all of them together gives us both
-	// atomicity guarantees (around when data will be flushed) and helps reduce
-	// the number of manifest blobs that kopia will create.
-	var toDelete []manifest.ID
+	bups []*backup.Backup,
+	resourceID string,
+	since time.Time,
+	getDeets detailsGetter,
+) ([]details.Entry, error) {
+	byService := map[path.ServiceType][]*backup.Backup{}
+
+	for _, b := range bups {
+		if b.Selector.ID() != resourceID {
+			continue
+		}
 
-	for _, id := range ids {
-		b, err := sw.GetBackup(ctx, model.StableID(id))
-		if err != nil {
-			if !failOnMissing && errors.Is(err, data.ErrNotFound) {
-				continue
+		pst := b.Selector.PathService()
+		byService[pst] = append(byService[pst], b)
+	}
+
+	var result []details.Entry
+
+	for _, sbups := range byService {
+		sort.Slice(sbups, func(i, j int) bool {
+			return sbups[i].CreationTime.Before(sbups[j].CreationTime)
+		})
+
+		// the last backup created before since is the baseline for
+		// removals in the first backup after it.
+		first := sort.Search(len(sbups), func(i int) bool {
+			return !sbups[i].CreationTime.Before(since)
+		})
+
+		if first == len(sbups) {
+			continue
+		}
+
+		if first > 0 {
+			first--
+		}
+
+		deleted, err := deletedFromBackups(ctx, sbups[first:], getDeets)
+		if err != nil {
+			return nil, clues.Stack(err)
+		}
+
+		result = append(result, deleted...)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].RepoRef < result[j].RepoRef
+	})
+
+	return result, nil
+}
+
+// deletedFromBackups diffs each backup's items against those of the
+// backup before it, and returns the items missing from the last backup.
+func deletedFromBackups(
+	ctx context.Context,
+	bups []*backup.Backup,
+	getDeets detailsGetter,
+) ([]details.Entry, error) {
+	var (
+		prev    map[string]details.Entry
+		deleted = map[string]details.Entry{}
+	)
+
+	for _, b := range bups {
+		ictx := clues.Add(ctx, "backup_id", b.ID)
+
+		deets, err := getDeets(ictx, b)
+		if err != nil {
+			return nil, clues.Wrap(err, "getting backup details").WithClues(ictx)
+		}
+
+		cur := map[string]details.Entry{}
+
+		for _, ent := range deets.Items() {
+			cur[deletedItemKey(*ent)] = *ent
+		}
+
+		for k, ent := range prev {
+			if _, ok := cur[k]; !ok {
+				deleted[k] = ent
+			}
+		}
+
+		// items that come back are no longer deleted.
+		for k := range cur {
+			delete(deleted, k)
+		}
+
+		prev = cur
+	}
+
+	result := make([]details.Entry, 0, len(deleted))
+	for _, ent := range deleted {
+		result = append(result, ent)
+	}
+
+	return result, nil
+}
+
+// deletedItemKey identifies an item across backups.  The item's ID is
+// preferred over its path, so that moved items aren't seen as deleted.
+func deletedItemKey(ent details.Entry) string {
+	if len(ent.ItemRef) > 0 {
+		return ent.ItemRef
+	}
+
+	return ent.RepoRef
+}
+
+// AuditReport describes how completely a backup holds the items that
+// currently exist in m365 for its protected resource.
+type AuditReport struct {
+	BackupID   string
+	ResourceID string
+	// LiveItems is the count of items currently found in m365.
+	LiveItems int
+	// BackedUpItems is the count of live items that are in the backup.
+	BackedUpItems int
+	// Missing holds the IDs of the items that exist in m365 but are not
+	// in the backup, sorted by ID.
+	Missing []string
+}
+
+// AuditBackup compares the backup's details against the items that
+// currently exist in m365 for the backed up resource, and reports the
+// items present in m365 but missing from the backup.  Only the containers
+// included by the scopes of the backup's selector are compared.
+func (r repository) AuditBackup(ctx context.Context, backupID string) (*AuditReport, error) {
+	deets, bup, err := getBackupDetails(
+		ctx,
+		backupID,
+		r.Account.ID(),
+		r.detailsSigningKey(ctx),
+		r.dataLayer,
+		store.NewWrapper(r.modelStore),
+		fault.New(false))
+	if err != nil {
+		return nil, clues.Wrap(err, "getting backup details")
+	}
+
+	creds, err := r.Account.M365Config()
+	if err != nil {
+		return nil, clues.Wrap(err, "retrieving m365 account configuration").WithClues(ctx)
+	}
+
+	ac, err := api.NewClient(creds, r.Opts)
+	if err != nil {
+		return nil, clues.Wrap(err, "creating api client").WithClues(ctx)
+	}
+
+	enumerator, err := liveContainerEnumeratorFor(bup.Selector.PathService(), ac, r.Opts)
+	if err != nil {
+		return nil, clues.Stack(err).WithClues(ctx)
+	}
+
+	return auditBackup(ctx, bup, deets, enumerator)
+}
+
+// auditBackup handles the processing for AuditBackup.
+func auditBackup(
+	ctx context.Context,
+	bup *backup.Backup,
+	deets *details.Details,
+	enumerator liveContainerEnumerator,
+) (*AuditReport, error) {
+	resourceID := bup.Selector.DiscreteOwner
+
+	ctx = clues.Add(ctx, "backup_id", bup.ID, "resource_id", clues.Hide(resourceID))
+
+	es, err := bup.Selector.ToExchangeBackup()
+	if err != nil {
+		return nil, clues.Wrap(err, "getting backup selector").WithClues(ctx)
+	}
+
+	// only the containers included by the backup's scopes get compared,
+	// otherwise every item outside of them would be reported as missing.
+	scs, err := scopedLiveContainers(ctx, es, resourceID, enumerator)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	live := map[string]struct{}{}
+
+	for _, sc := range scs {
+		cctx := clues.Add(ctx, "category", sc.cat.String())
+
+		ids, err := enumerator.LiveContainerItemIDs(cctx, resourceID, sc.cat, sc.ID)
+		if err != nil {
+			return nil, clues.Wrap(err, "enumerating live items").WithClues(cctx)
+		}
+
+		for id := range ids {
+			live[id] = struct{}{}
+		}
+	}
+
+	backedUp := map[string]struct{}{}
+
+	for _, ent := range deets.Items() {
+		backedUp[deletedItemKey(*ent)] = struct{}{}
+	}
+
+	report := &AuditReport{
+		BackupID:   string(bup.ID),
+		ResourceID: resourceID,
+		LiveItems:  len(live),
+		Missing:    []string{},
+	}
+
+	for id := range live {
+		if _, ok := backedUp[id]; ok {
+			report.BackedUpItems++
+			continue
+		}
+
+		report.Missing = append(report.Missing, id)
+	}
+
+	sort.Strings(report.Missing)
+
+	return report, nil
+}
+
+// BackupPreview describes the data that a backup of a selector would
+// include, as it currently exists in m365.
+type BackupPreview struct {
+	ResourceID string             `json:"resourceID"`
+	Containers []ContainerPreview `json:"containers"`
+}
+
+// ContainerPreview is a container that a backup would include.
+type ContainerPreview struct {
+	Category string `json:"category"`
+	ID       string `json:"id"`
+	Location string `json:"location"`
+	Items    int    `json:"items"`
+}
+
+// MinimumPrintable reduces the ContainerPreview to its minimally printable
+// details.
+func (cp ContainerPreview) MinimumPrintable() any {
+	return cp
+}
+
+// Headers returns the human-readable names of properties in a
+// ContainerPreview for printing out to a terminal in a columnar display.
+func (cp ContainerPreview) Headers() []string {
+	return []string{"Category", "Location", "Items"}
+}
+
+// Values returns the values matching the Headers list for printing
+// out to a terminal in a columnar display.
+func (cp ContainerPreview) Values() []string {
+	return []string{cp.Category, cp.Location, strconv.Itoa(cp.Items)}
+}
+
+// PreviewBackup resolves the selector against the data that currently
+// exists in m365 for its discrete resource, and reports each container
+// a backup of the selector would include, along with the number of items
+// it holds.  Nothing gets backed up.  Only exchange selectors can be
+// previewed; other services produce ErrorPreviewUnsupported.
+func (r repository) PreviewBackup(
+	ctx context.Context,
+	sel selectors.Selector,
+) (*BackupPreview, error) {
+	if sel.Service != selectors.ServiceExchange {
+		return nil, clues.Stack(ErrorPreviewUnsupported).
+			WithClues(ctx).
+			With("service", sel.Service.String())
+	}
+
+	creds, err := r.Account.M365Config()
+	if err != nil {
+		return nil, clues.Wrap(err, "retrieving m365 account configuration").WithClues(ctx)
+	}
+
+	ac, err := api.NewClient(creds, r.Opts)
+	if err != nil {
+		return nil, clues.Wrap(err, "creating api client").WithClues(ctx)
+	}
+
+	enumerator, err := liveContainerEnumeratorFor(sel.PathService(), ac, r.Opts)
+	if err != nil {
+		return nil, clues.Stack(err).WithClues(ctx)
+	}
+
+	return previewBackup(ctx, sel, enumerator)
+}
+
+// liveContainerEnumerator produces the containers and items that
+// currently exist in m365 for the resource.
+type liveContainerEnumerator interface {
+	LiveContainers(
+		ctx context.Context,
+		resourceID string,
+		cat path.CategoryType,
+	) ([]exchange.LiveContainer, error)
+	LiveContainerItemIDs(
+		ctx context.Context,
+		resourceID string,
+		cat path.CategoryType,
+		containerID string,
+	) (map[string]struct{}, error)
+}
+
+func liveContainerEnumeratorFor(
+	pst path.ServiceType,
+	ac api.Client,
+	opts control.Options,
+) (liveContainerEnumerator, error) {
+	switch pst {
+	case path.ExchangeService:
+		return exchangeLiveItems{
+			ac:           ac,
+			immutableIDs: opts.ToggleFeatures.ExchangeImmutableIDs,
+		}, nil
+	default:
+		return nil, clues.New("enumerating live data is not supported for the service").With("service", pst.String())
+	}
+}
+
+type exchangeLiveItems struct {
+	ac           api.Client
+	immutableIDs bool
+}
+
+func (eli exchangeLiveItems) LiveContainers(
+	ctx context.Context,
+	resourceID string,
+	cat path.CategoryType,
+) ([]exchange.LiveContainer, error) {
+	return exchange.LiveContainers(
+		ctx,
+		exchange.BackupHandlers(eli.ac),
+		resourceID,
+		cat,
+		fault.New(true))
+}
+
+func (eli exchangeLiveItems) LiveContainerItemIDs(
+	ctx context.Context,
+	resourceID string,
+	cat path.CategoryType,
+	containerID string,
+) (map[string]struct{}, error) {
+	return exchange.LiveContainerItemIDs(
+		ctx,
+		exchange.BackupHandlers(eli.ac),
+		resourceID,
+		cat,
+		containerID,
+		eli.immutableIDs)
+}
+
+// previewBackup handles the processing for PreviewBackup.
+func previewBackup(
+	ctx context.Context,
+	sel selectors.Selector,
+	enumerator liveContainerEnumerator,
+) (*BackupPreview, error) {
+	resourceID := sel.DiscreteOwner
+	if len(resourceID) == 0 {
+		return nil, clues.New("previewing requires a selector for a single resource").WithClues(ctx)
+	}
+
+	ctx = clues.Add(ctx, "resource_id", clues.Hide(resourceID))
+
+	es, err := sel.ToExchangeBackup()
+	if err != nil {
+		return nil, clues.Wrap(err, "previewing selector").WithClues(ctx)
+	}
+
+	scs, err := scopedLiveContainers(ctx, es, resourceID, enumerator)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	preview := &BackupPreview{
+		ResourceID: resourceID,
+		Containers: make([]ContainerPreview, 0, len(scs)),
+	}
+
+	for _, sc := range scs {
+		cctx := clues.Add(ctx, "category", sc.cat.String())
+
+		ids, err := enumerator.LiveContainerItemIDs(cctx, resourceID, sc.cat, sc.ID)
+		if err != nil {
+			return nil, clues.Wrap(err, "enumerating items").WithClues(cctx)
+		}
+
+		preview.Containers = append(preview.Containers, ContainerPreview{
+			Category: sc.cat.String(),
+			ID:       sc.ID,
+			Location: sc.Location.PlainString(),
+			Items:    len(ids),
+		})
+	}
+
+	sort.Slice(preview.Containers, func(i, j int) bool {
+		ci, cj := preview.Containers[i], preview.Containers[j]
+		if ci.Category != cj.Category {
+			return ci.Category < cj.Category
+		}
+
+		return ci.Location < cj.Location
+	})
+
+	return preview, nil
+}
+
+// scopedLiveContainer is a live container included by a selector scope.
+type scopedLiveContainer struct {
+	exchange.LiveContainer
+	cat path.CategoryType
+}
+
+// scopedLiveContainers produces the live containers that are included by
+// the selector's scopes.  Containers get enumerated once per category, and
+// produced once even if several scopes include them.
+func scopedLiveContainers(
+	ctx context.Context,
+	es *selectors.ExchangeBackup,
+	resourceID string,
+	enumerator liveContainerEnumerator,
+) ([]scopedLiveContainer, error) {
+	var (
+		scs      = []scopedLiveContainer{}
+		byCat    = map[path.CategoryType][]exchange.LiveContainer{}
+		included = map[string]struct{}{}
+		err      error
+	)
+
+	for _, scope := range es.Scopes() {
+		cat := scope.Category().PathType()
+		cctx := clues.Add(ctx, "category", cat.String())
+
+		lcs, ok := byCat[cat]
+		if !ok {
+			lcs, err = enumerator.LiveContainers(cctx, resourceID, cat)
+			if err != nil {
+				return nil, clues.Wrap(err, "enumerating containers").WithClues(cctx)
+			}
+
+			byCat[cat] = lcs
+		}
+
+		for _, lc := range lcs {
+			key := cat.String() + "/" + lc.ID
+
+			if _, ok := included[key]; ok || !scopeIncludesContainer(scope, cat, lc.Location.PlainString()) {
+				continue
+			}
+
+			included[key] = struct{}{}
+
+			scs = append(scs, scopedLiveContainer{LiveContainer: lc, cat: cat})
+		}
+	}
+
+	return scs, nil
+}
+
+// scopeIncludesContainer mirrors the container selection made by exchange
+// backups.
+func scopeIncludesContainer(
+	scope selectors.ExchangeScope,
+	cat path.CategoryType,
+	loc string,
+) bool {
+	switch cat {
+	case path.EmailCategory:
+		return scope.Matches(selectors.ExchangeMailFolder, loc)
+	case path.ContactsCategory:
+		return scope.Matches(selectors.ExchangeContactFolder, loc)
+	case path.EventsCategory:
+		return scope.Matches(selectors.ExchangeEventCalendar, loc)
+	default:
+		return false
+	}
+}
+
+// getBackupDetails handles the processing for GetBackupDetails.
+func getBackupDetails(
+	ctx context.Context,
+	backupID, tenantID string,
+	signingKey []byte,
+	kw *kopia.Wrapper,
+	sw store.BackupGetter,
+	errs *fault.Bus,
+) (*details.Details, *backup.Backup, error) {
+	b, err := sw.GetBackup(ctx, model.StableID(backupID))
+	if err != nil {
+		return nil, nil, errWrapper(err)
+	}
+
+	ssid := b.StreamStoreID
+	if len(ssid) == 0 {
+		ssid = b.DetailsID
+	}
+
+	if len(ssid) == 0 {
+		return nil, b, clues.New("no streamstore id in backup").WithClues(ctx)
+	}
+
+	var (
+		sstore = streamstore.NewStreamer(kw, tenantID, b.Selector.PathService())
+		deets  details.Details
+		mac    = backup.NewDetailsMAC(signingKey)
+	)
+
+	// the serialized details are hashed as they're read, so that they can
+	// be compared against the backup's signature.
+	readSigned := func(rc io.ReadCloser) error {
+		tee := io.TeeReader(rc, mac)
+
+		if err := details.UnmarshalTo(&deets)(io.NopCloser(tee)); err != nil {
+			return err
+		}
+
+		_, err := io.Copy(io.Discard, tee)
+
+		return clues.Wrap(err, "reading details").OrNil()
+	}
+
+	err = sstore.Read(
+		ctx,
+		ssid,
+		streamstore.DetailsReader(readSigned),
+		errs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := b.VerifyDetailsSignature(signingKey, mac.Sum(nil)); err != nil {
+		return nil, b, clues.Stack(err).WithClues(ctx)
+	}
+
+	// Retroactively fill in isMeta information for items in older
+	// backup versions without that info
+	// version.Restore2 introduces the IsMeta flag, so only v1 needs a check.
+	if b.Version >= version.OneDrive1DataAndMetaFiles && b.Version < version.OneDrive3IsMetaMarker {
+		for _, d := range deets.Entries {
+			if d.OneDrive != nil {
+				d.OneDrive.IsMeta = metadata.HasMetaSuffix(d.RepoRef)
+			}
+		}
+	}
+
+	deets.DetailsModel = deets.FilterMetaFiles()
+
+	return &deets, b, nil
+}
+
+// BackupErrors returns the specified backup's fault.Errors
+func (r repository) GetBackupErrors(
+	ctx context.Context,
+	backupID string,
+) (*fault.Errors, *backup.Backup, *fault.Bus) {
+	errs := fault.New(false)
+
+	fe, bup, err := getBackupErrors(
+		ctx,
+		backupID,
+		r.Account.ID(),
+		r.dataLayer,
+		store.NewWrapper(r.modelStore),
+		errs)
+
+	return fe, bup, errs.Fail(err)
+}
+
+// getBackupErrors handles the processing for GetBackupErrors.
+func getBackupErrors(
+	ctx context.Context,
+	backupID, tenantID string,
+	kw *kopia.Wrapper,
+	sw store.BackupGetter,
+	errs *fault.Bus,
+) (*fault.Errors, *backup.Backup, error) {
+	b, err := sw.GetBackup(ctx, model.StableID(backupID))
+	if err != nil {
+		return nil, nil, errWrapper(err)
+	}
+
+	ssid := b.StreamStoreID
+	if len(ssid) == 0 {
+		return nil, b, clues.New("missing streamstore id in backup").WithClues(ctx)
+	}
+
+	var (
+		sstore = streamstore.NewStreamer(kw, tenantID, b.Selector.PathService())
+		fe     fault.Errors
+	)
+
+	err = sstore.Read(
+		ctx,
+		ssid,
+		streamstore.FaultErrorsReader(fault.UnmarshalErrorsTo(&fe)),
+		errs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &fe, b, nil
+}
+
+// DeleteBackups removes the backups from both the model store and the backup
+// storage.
+//
+// If failOnMissing is true then returns an error if a backup model can't be
+// found. Otherwise ignores missing backup models.
+//
+// Missing models or snapshots during the actual deletion do not cause errors.
+//
+// All backups are delete as an atomic unit so any failures will result in no
+// deletions.  If any of the backups are pinned, returns ErrorBackupPinned
+// without deleting anything.
+func (r repository) DeleteBackups(
+	ctx context.Context,
+	failOnMissing bool,
+	ids ...string,
+) error {
+	return deleteBackups(
+		ctx,
+		store.NewWrapper(r.modelStore),
+		deleteLookupParallelism(r.Opts),
+		failOnMissing, false,
+		ids...)
+}
+
+// ForceDeleteBackups behaves like DeleteBackups, except that pinned backups
+// get deleted as well.
+func (r repository) ForceDeleteBackups(
+	ctx context.Context,
+	failOnMissing bool,
+	ids ...string,
+) error {
+	return deleteBackups(
+		ctx,
+		store.NewWrapper(r.modelStore),
+		deleteLookupParallelism(r.Opts),
+		failOnMissing, true,
+		ids...)
+}
+
+const defaultDeleteLookupParallelism = 8
+
+// deleteLookupParallelism produces the number of backups looked up
+// concurrently ahead of a deletion.
+func deleteLookupParallelism(opts control.Options) int {
+	if opts.Parallelism.DeleteBackupLookup > 0 {
+		return opts.Parallelism.DeleteBackupLookup
+	}
+
+	return defaultDeleteLookupParallelism
+}
+
+// deleteBackup handles the processing for backup deletion.  If any of the
+// backups are pinned, and force is false, none of the backups get deleted.
+// Up to parallelism backups are looked up concurrently.
+func deleteBackups(
+	ctx context.Context,
+	sw store.BackupGetterModelDeleter,
+	parallelism int,
+	failOnMissing, force bool,
+	ids ...string,
+) error {
+	// Although we haven't explicitly stated it, snapshots are technically
+	// manifests in kopia. This means we can use the same delete API to remove
+	// them and backup models. Deleting all of them together gives us both
+	// atomicity guarantees (around when data will be flushed) and helps reduce
+	// the number of manifest blobs that kopia will create.
+	var (
+		toDelete []manifest.ID
+		pinned   []string
+	)
+
+	gets := getBackups(ctx, sw, parallelism, ids)
+
+	for i, id := range ids {
+		b, err := gets[i].bup, gets[i].err
+		if err != nil {
+			if !failOnMissing && errors.Is(err, data.ErrNotFound) {
+				continue
 			}
 
 			return clues.Stack(errWrapper(err)).
@@ -673,6 +1648,11 @@ func deleteBackups(
 				With("delete_backup_id", id)
 		}
 
+		if b.Pinned && !force {
+			pinned = append(pinned, string(b.ID))
+			continue
+		}
+
 		toDelete = append(toDelete, b.ModelStoreID)
 
 		if len(b.SnapshotID) > 0 {
@@ -689,9 +1669,177 @@ func deleteBackups(
 		}
 	}
 
+	if len(pinned) > 0 {
+		return clues.Wrap(ErrorBackupPinned, "unpin or force deletion of "+strings.Join(pinned, ", ")).
+			WithClues(ctx).
+			With("pinned_backup_ids", pinned)
+	}
+
 	return sw.DeleteWithModelStoreIDs(ctx, toDelete...)
 }
 
+type backupLookup struct {
+	bup *backup.Backup
+	err error
+}
+
+// getBackups looks up the backups, running up to parallelism lookups
+// concurrently.  Results are returned in the same order as the ids.
+func getBackups(
+	ctx context.Context,
+	bg store.BackupGetter,
+	parallelism int,
+	ids []string,
+) []backupLookup {
+	var (
+		results     = make([]backupLookup, len(ids))
+		wg          sync.WaitGroup
+		semaphoreCh = make(chan struct{}, max(parallelism, 1))
+	)
+
+	defer close(semaphoreCh)
+
+	for i, id := range ids {
+		wg.Add(1)
+		semaphoreCh <- struct{}{}
+
+		go func(i int, id string) {
+			defer wg.Done()
+			defer func() { <-semaphoreCh }()
+
+			b, err := bg.GetBackup(ctx, model.StableID(id))
+			results[i] = backupLookup{bup: b, err: err}
+		}(i, id)
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+func (r repository) SetBackupPinned(
+	ctx context.Context,
+	backupID string,
+	pinned bool,
+) error {
+	return setBackupPinned(ctx, store.NewWrapper(r.modelStore), backupID, pinned)
+}
+
+// setBackupPinned handles the processing for SetBackupPinned.
+func setBackupPinned(
+	ctx context.Context,
+	sw store.BackupGetterUpdater,
+	backupID string,
+	pinned bool,
+) error {
+	ctx = clues.Add(ctx, "backup_id", backupID, "pinned", pinned)
+
+	b, err := sw.GetBackup(ctx, model.StableID(backupID))
+	if err != nil {
+		return clues.Stack(errWrapper(err)).WithClues(ctx)
+	}
+
+	if b.Pinned == pinned {
+		return nil
+	}
+
+	b.Pinned = pinned
+
+	return clues.Stack(sw.UpdateBackup(ctx, b)).WithClues(ctx).OrNil()
+}
+
+// orphanGCBuffer excludes recently written streamstores from garbage
+// collection.  Backups persist their streamstore before the backup model,
+// so a streamstore younger than the buffer may belong to an in-progress
+// backup.
+const orphanGCBuffer = 24 * time.Hour
+
+type streamStoreManifestFinder interface {
+	StreamStoreManifests(ctx context.Context) ([]*manifest.EntryMetadata, error)
+}
+
+type backupListerModelDeleter interface {
+	GetBackups(ctx context.Context, filters ...store.FilterOption) ([]*backup.Backup, error)
+	store.ModelDeleter
+}
+
+// GarbageCollectOrphans finds the details and errors streamstores that
+// aren't referenced by any backup model, such as those left behind by
+// interrupted backups, and deletes them.  Streamstores written within the
+// last day are never considered orphaned.  If dryRun is true, the orphans
+// are only listed.  Returns the IDs of the orphaned streamstores.
+func (r repository) GarbageCollectOrphans(
+	ctx context.Context,
+	dryRun bool,
+) ([]manifest.ID, error) {
+	return garbageCollectOrphans(
+		ctx,
+		r.dataLayer,
+		store.NewWrapper(r.modelStore),
+		time.Now().Add(-orphanGCBuffer),
+		dryRun)
+}
+
+// garbageCollectOrphans handles the processing for GarbageCollectOrphans.
+// Streamstores modified after the cutoff are skipped.
+func garbageCollectOrphans(
+	ctx context.Context,
+	ssmf streamStoreManifestFinder,
+	sw backupListerModelDeleter,
+	cutoff time.Time,
+	dryRun bool,
+) ([]manifest.ID, error) {
+	ctx = clues.Add(ctx, "dry_run", dryRun, "gc_cutoff", cutoff)
+
+	snaps, err := ssmf.StreamStoreManifests(ctx)
+	if err != nil {
+		return nil, clues.Wrap(err, "listing streamstores").WithClues(ctx)
+	}
+
+	bups, err := sw.GetBackups(ctx)
+	if err != nil {
+		return nil, clues.Wrap(err, "listing backups").WithClues(ctx)
+	}
+
+	referenced := map[manifest.ID]struct{}{}
+
+	for _, b := range bups {
+		if len(b.StreamStoreID) > 0 {
+			referenced[manifest.ID(b.StreamStoreID)] = struct{}{}
+		}
+
+		if len(b.DetailsID) > 0 {
+			referenced[manifest.ID(b.DetailsID)] = struct{}{}
+		}
+	}
+
+	orphans := []manifest.ID{}
+
+	for _, snap := range snaps {
+		if _, ok := referenced[snap.ID]; ok {
+			continue
+		}
+
+		if !cutoff.After(snap.ModTime) {
+			continue
+		}
+
+		orphans = append(orphans, snap.ID)
+	}
+
+	logger.Ctx(ctx).Infow("found orphaned streamstores", "orphan_count", len(orphans))
+
+	if dryRun || len(orphans) == 0 {
+		return orphans, nil
+	}
+
+	if err := sw.DeleteWithModelStoreIDs(ctx, orphans...); err != nil {
+		return nil, clues.Wrap(err, "deleting orphaned streamstores").WithClues(ctx)
+	}
+
+	return orphans, nil
+}
+
 func (r repository) ConnectToM365(
 	ctx context.Context,
 	pst path.ServiceType,
@@ -704,6 +1852,25 @@ func (r repository) ConnectToM365(
 	return ctrl, nil
 }
 
+// VerifyAccess makes a minimal graph api call to confirm that the m365
+// credentials are valid and authorized for the service.  Common credential
+// failures are returned as one of ErrorCredentialsExpired,
+// ErrorCredentialsInvalid, ErrorConsentRequired, or
+// ErrorInsufficientPermissions.
+func (r repository) VerifyAccess(ctx context.Context, pst path.ServiceType) error {
+	creds, err := r.Account.M365Config()
+	if err != nil {
+		return clues.Wrap(err, "retrieving m365 account configuration").WithClues(ctx)
+	}
+
+	ac, err := api.NewClient(creds, r.Opts)
+	if err != nil {
+		return clues.Wrap(err, "creating api client").WithClues(ctx)
+	}
+
+	return verifyAccess(ctx, pst, ac)
+}
+
 // ---------------------------------------------------------------------------
 // Repository ID Model
 // ---------------------------------------------------------------------------
@@ -711,21 +1878,65 @@ func (r repository) ConnectToM365(
 // repositoryModel identifies the current repository
 type repositoryModel struct {
 	model.BaseModel
+
+	// Version is the repository version, which tracks the migrations
+	// applied to the repository.  Zero for repositories that predate it.
+	Version int `json:"version,omitempty"`
 }
 
-// should only be called on init.
-func newRepoModel(ctx context.Context, ms *kopia.ModelStore, repoID string) error {
+// should only be called on init.  Returns ErrorRepoAlreadyExists if the
+// model store already holds a repository model.
+//
+// The model store can't write conditionally, so initializations that race,
+// including ones in other processes, can each find the store empty.  After
+// writing, the models are read back, and every initialization that didn't
+// write the oldest model deletes its own and reports that the repository
+// already exists.
+func newRepoModel(ctx context.Context, ms store.Storer, repoID string) error {
+	existing, err := getRepoModel(ctx, ms)
+	if err != nil {
+		return clues.Wrap(err, "checking for an existing repository").WithClues(ctx)
+	}
+
+	if len(existing.ID) > 0 {
+		return clues.Stack(ErrorRepoAlreadyExists).
+			With("existing_repo_id", existing.ID).
+			WithClues(ctx)
+	}
+
 	rm := repositoryModel{
 		BaseModel: model.BaseModel{
 			ID: model.StableID(repoID),
 		},
+		Version: currentRepoVersion,
+	}
+
+	if err := ms.Put(ctx, model.RepositorySchema, &rm); err != nil {
+		return clues.Wrap(err, "storing the repository model").WithClues(ctx)
 	}
 
-	return ms.Put(ctx, model.RepositorySchema, &rm)
+	winner, err := getRepoModel(ctx, ms)
+	if err != nil {
+		return clues.Wrap(err, "verifying the repository model").WithClues(ctx)
+	}
+
+	if winner.ID == rm.ID {
+		return nil
+	}
+
+	if err := ms.Delete(ctx, model.RepositorySchema, rm.ID); err != nil {
+		return clues.Wrap(err, "removing a duplicate repository model").WithClues(ctx)
+	}
+
+	return clues.Stack(ErrorRepoAlreadyExists).
+		With("existing_repo_id", winner.ID).
+		WithClues(ctx)
 }
 
-// retrieves the repository info
-func getRepoModel(ctx context.Context, ms *kopia.ModelStore) (*repositoryModel, error) {
+// retrieves the repository info.  If concurrent initializations left more
+// than one model, the oldest, breaking ties by ID, identifies the
+// repository.
+func getRepoModel(ctx context.Context, ms store.Storer) (*repositoryModel, error) {
 	bms, err := ms.GetIDsForType(ctx, model.RepositorySchema, nil)
 	if err != nil {
 		return nil, err
@@ -736,7 +1947,16 @@ func getRepoModel(ctx context.Context, ms *kopia.ModelStore) (*repositoryModel,
 		return rm, nil
 	}
 
-	rm.BaseModel = *bms[0]
+	oldest := bms[0]
+
+	for _, bm := range bms[1:] {
+		if bm.ModTime.Before(oldest.ModTime) ||
+			(bm.ModTime.Equal(oldest.ModTime) && bm.ID < oldest.ID) {
+			oldest = bm
+		}
+	}
+
+	rm.BaseModel = *oldest
 
 	return rm, nil
 }
@@ -772,9 +1992,64 @@ func connectToM365(
 		return nil, err
 	}
 
+	if co.VerifyAccess {
+		if err := verifyAccess(ctx, pst, ctrl.AC); err != nil {
+			return nil, err
+		}
+	}
+
 	return ctrl, nil
 }
 
+type accessChecker interface {
+	CheckAccess(ctx context.Context) error
+}
+
+func accessCheckerFor(pst path.ServiceType, ac api.Client) (accessChecker, error) {
+	switch pst {
+	case path.ExchangeService, path.OneDriveService:
+		return ac.Users(), nil
+	case path.SharePointService:
+		return ac.Sites(), nil
+	case path.GroupsService:
+		return ac.Groups(), nil
+	default:
+		return nil, clues.New("unsupported service").With("service", pst.String())
+	}
+}
+
+func verifyAccess(ctx context.Context, pst path.ServiceType, ac api.Client) error {
+	ctx = clues.Add(ctx, "service", pst.String())
+
+	checker, err := accessCheckerFor(pst, ac)
+	if err != nil {
+		return clues.Stack(err).WithClues(ctx)
+	}
+
+	return clues.Stack(checkAccess(ctx, checker)).WithClues(ctx).OrNil()
+}
+
+// checkAccess runs the access check, translating common credential and
+// authorization failures into typed errors.
+func checkAccess(ctx context.Context, checker accessChecker) error {
+	err := checker.CheckAccess(ctx)
+
+	switch {
+	case err == nil:
+		return nil
+	case graph.IsErrCredentialsExpired(err):
+		return clues.Stack(ErrorCredentialsExpired, err)
+	case graph.IsErrConsentRequired(err):
+		return clues.Stack(ErrorConsentRequired, err)
+	case graph.IsErrCredentialsInvalid(err), graph.IsErrUnauthorized(err):
+		return clues.Stack(ErrorCredentialsInvalid, err)
+	case graph.IsErrAccessDenied(err):
+		return clues.Stack(ErrorInsufficientPermissions, err)
+	default:
+		return clues.Wrap(err, "verifying m365 access")
+	}
+}
+
 func errWrapper(err error) error {
 	if errors.Is(err, data.ErrNotFound) {
 		return clues.Stack(ErrorBackupNotFound, err)
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"golang.org/x/exp/maps"
 
 	"github.com/alcionai/corso/src/internal/tester"
 	"github.com/alcionai/corso/src/internal/tester/tconfig"
@@ -17,6 +18,7 @@ import (
 	"github.com/alcionai/corso/src/pkg/control"
 	ctrlRepo "github.com/alcionai/corso/src/pkg/control/repository"
 	"github.com/alcionai/corso/src/pkg/control/testdata"
+	"github.com/alcionai/corso/src/pkg/credentials"
 	"github.com/alcionai/corso/src/pkg/extensions"
 	"github.com/alcionai/corso/src/pkg/selectors"
 	"github.com/alcionai/corso/src/pkg/services/m365/api"
@@ -211,6 +213,46 @@ func (suite *RepositoryIntegrationSuite) TestConnect() {
 	assert.NoError(t, err, clues.ToCore(err))
 }
 
+type passphraseProvider string
+
+func (pp passphraseProvider) Get(key string) (string, error) {
+	if key == credentials.CorsoPassphrase {
+		return string(pp), nil
+	}
+
+	return "", nil
+}
+
+func (suite *RepositoryIntegrationSuite) TestConnect_credentialsProvider() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	st := storeTD.NewPrefixedS3Storage(t)
+
+	cfg, err := st.CommonConfig()
+	require.NoError(t, err, clues.ToCore(err))
+
+	// the passphrase is only available through the provider.
+	st.SetCorsoPassphrase("")
+
+	opts := control.DefaultOptions()
+	opts.CredentialsProvider = passphraseProvider(cfg.CorsoPassphrase)
+
+	repo, err := Initialize(ctx, account.Account{}, st, opts, ctrlRepo.Retention{})
+	require.NoError(t, err, clues.ToCore(err))
+
+	err = repo.Close(ctx)
+	require.NoError(t, err, clues.ToCore(err))
+
+	r, err := Connect(ctx, account.Account{}, st, repo.GetID(), opts)
+	require.NoError(t, err, clues.ToCore(err))
+
+	err = r.Close(ctx)
+	assert.NoError(t, err, clues.ToCore(err))
+}
+
 func (suite *RepositoryIntegrationSuite) TestConnect_sameID() {
 	t := suite.T()
 
@@ -424,6 +466,143 @@ func (suite *RepositoryIntegrationSuite) TestConnect_ReadOnly() {
 	assert.ErrorIs(t, err, readonly.ErrReadonly)
 }
 
+func (suite *RepositoryIntegrationSuite) TestSetReadOnly() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	// need to initialize the repository before we can test connecting to it.
+	st := storeTD.NewPrefixedS3Storage(t)
+
+	repo, err := Initialize(
+		ctx,
+		account.Account{},
+		st,
+		control.DefaultOptions(),
+		ctrlRepo.Retention{})
+	require.NoError(t, err)
+
+	r, err := Connect(ctx, account.Account{}, st, repo.GetID(), control.DefaultOptions())
+	require.NoError(t, err, clues.ToCore(err))
+
+	defer r.Close(ctx)
+
+	// writes succeed before the switch.
+	op, err := r.NewMaintenance(ctx, ctrlRepo.Maintenance{})
+	require.NoError(t, err, clues.ToCore(err))
+
+	err = op.Run(ctx)
+	require.NoError(t, err, clues.ToCore(err))
+
+	err = r.SetReadOnly(ctx)
+	require.NoError(t, err, clues.ToCore(err))
+
+	// repeated calls are a no-op.
+	err = r.SetReadOnly(ctx)
+	require.NoError(t, err, clues.ToCore(err))
+
+	// reads still succeed.
+	_, err = r.BackupsByTag(ctx)
+	require.NoError(t, err, clues.ToCore(err))
+
+	op, err = r.NewMaintenance(ctx, ctrlRepo.Maintenance{})
+	require.NoError(t, err, clues.ToCore(err))
+
+	err = op.Run(ctx)
+	assert.ErrorIs(t, err, readonly.ErrReadonly)
+}
+
+func (suite *RepositoryIntegrationSuite) TestEncryptionInfo() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	st := storeTD.NewPrefixedS3Storage(t)
+
+	repo, err := Initialize(
+		ctx,
+		account.Account{},
+		st,
+		control.DefaultOptions(),
+		ctrlRepo.Retention{})
+	require.NoError(t, err, clues.ToCore(err))
+
+	r, err := Connect(ctx, account.Account{}, st, repo.GetID(), control.DefaultOptions())
+	require.NoError(t, err, clues.ToCore(err))
+
+	defer func() {
+		err := r.Close(ctx)
+		assert.NoError(t, err, clues.ToCore(err))
+	}()
+
+	ei, err := r.EncryptionInfo(ctx)
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.NotEmpty(t, ei.ContentEncryption, "content encryption")
+	assert.NotEmpty(t, ei.FormatEncryption, "format encryption")
+	assert.NotEmpty(t, ei.KeyDerivation, "key derivation")
+	assert.NotEmpty(t, ei.HashFunction, "hash function")
+	assert.Len(t, ei.Values(), len(ei.Headers()), "printable values")
+}
+
+func (suite *RepositoryIntegrationSuite) TestChangePassphrase() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	st := storeTD.NewPrefixedS3Storage(t)
+
+	cfg, err := st.CommonConfig()
+	require.NoError(t, err, clues.ToCore(err))
+
+	var (
+		oldPass = cfg.CorsoPassphrase
+		newPass = oldPass + "-rotated"
+		// the storage config is updated in place by the passphrase change.
+		oldSt = st
+	)
+
+	oldSt.Config = maps.Clone(st.Config)
+
+	repo, err := Initialize(
+		ctx,
+		account.Account{},
+		st,
+		control.DefaultOptions(),
+		ctrlRepo.Retention{})
+	require.NoError(t, err, clues.ToCore(err))
+
+	r, err := Connect(ctx, account.Account{}, st, repo.GetID(), control.DefaultOptions())
+	require.NoError(t, err, clues.ToCore(err))
+
+	err = r.ChangePassphrase(ctx, "not-the-passphrase", newPass)
+	require.ErrorIs(t, err, ErrorInvalidPassphrase, clues.ToCore(err))
+
+	err = r.ChangePassphrase(ctx, oldPass, newPass)
+	require.NoError(t, err, clues.ToCore(err))
+
+	err = r.Close(ctx)
+	require.NoError(t, err, clues.ToCore(err))
+
+	cfg, err = st.CommonConfig()
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, newPass, cfg.CorsoPassphrase, "stored passphrase")
+
+	_, err = Connect(ctx, account.Account{}, oldSt, repo.GetID(), control.DefaultOptions())
+	assert.Error(t, err, "connecting with the old passphrase")
+
+	r, err = Connect(ctx, account.Account{}, st, repo.GetID(), control.DefaultOptions())
+	require.NoError(t, err, clues.ToCore(err))
+
+	defer r.Close(ctx)
+
+	_, err = r.BackupsByTag(ctx)
+	assert.NoError(t, err, clues.ToCore(err))
+}
+
 // Test_Options tests that the options are passed through to the repository
 // correctly
 func (suite *RepositoryIntegrationSuite) Test_Options() {
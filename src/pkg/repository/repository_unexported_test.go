@@ -2,17 +2,25 @@ package repository
 
 import (
 	"context"
+	"net/http"
+	"strconv"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/alcionai/clues"
 	"github.com/google/uuid"
 	"github.com/kopia/kopia/repo/manifest"
+	"github.com/microsoftgraph/msgraph-sdk-go/models/odataerrors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"golang.org/x/exp/slices"
 
 	"github.com/alcionai/corso/src/internal/data"
 	"github.com/alcionai/corso/src/internal/kopia"
+	"github.com/alcionai/corso/src/internal/m365/collection/exchange"
+	"github.com/alcionai/corso/src/internal/m365/graph"
 	"github.com/alcionai/corso/src/internal/model"
 	"github.com/alcionai/corso/src/internal/operations"
 	"github.com/alcionai/corso/src/internal/stats"
@@ -45,7 +53,13 @@ func (mbl mockBackupList) GetBackup(
 	ctx context.Context,
 	backupID model.StableID,
 ) (*backup.Backup, error) {
-	return nil, clues.New("not implemented")
+	for _, b := range mbl.backups {
+		if b.ID == backupID {
+			return b, nil
+		}
+	}
+
+	return nil, clues.Stack(data.ErrNotFound)
 }
 
 func (mbl mockBackupList) DeleteBackup(
@@ -329,6 +343,9 @@ type getRes struct {
 type mockBackupGetterModelDeleter struct {
 	t *testing.T
 
+	// guards getCount, since backups are looked up concurrently.
+	mu sync.Mutex
+
 	gets       []getRes
 	deleteErrs []error
 
@@ -343,13 +360,19 @@ func (m *mockBackupGetterModelDeleter) GetBackup(
 	_ context.Context,
 	id model.StableID,
 ) (*backup.Backup, error) {
-	defer func() {
-		m.getCount++
-	}()
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
-	assert.Equal(m.t, m.expectGets[m.getCount], id)
+	m.getCount++
+
+	// lookups run concurrently, so results are matched by id instead of
+	// by call order.
+	i := slices.Index(m.expectGets, id)
+	if !assert.NotEqual(m.t, -1, i, "unexpected backup lookup: %s", id) {
+		return nil, clues.New("unexpected lookup")
+	}
 
-	return m.gets[m.getCount].bup, clues.Stack(m.gets[m.getCount].err).OrNil()
+	return m.gets[i].bup, clues.Stack(m.gets[i].err).OrNil()
 }
 
 func (m *mockBackupGetterModelDeleter) DeleteWithModelStoreIDs(
@@ -370,6 +393,193 @@ func (m *mockBackupGetterModelDeleter) DeleteWithModelStoreIDs(
 	return clues.Stack(m.deleteErrs[m.delCount]).OrNil()
 }
 
+func (suite *RepositoryBackupsUnitSuite) TestBackupsForResource() {
+	newBackup := func(owner, backupType string) *backup.Backup {
+		b := &backup.Backup{
+			BaseModel: model.BaseModel{
+				ID:   model.StableID(uuid.NewString()),
+				Tags: map[string]string{},
+			},
+			Selector: selectors.NewExchangeBackup([]string{owner}).Selector,
+		}
+
+		if len(backupType) > 0 {
+			b.Tags[model.BackupTypeTag] = backupType
+		}
+
+		return b
+	}
+
+	var (
+		user1       = newBackup("user1", "")
+		user1Merge  = newBackup("user1", model.MergeBackup)
+		user1Assist = newBackup("user1", model.AssistBackup)
+		user2       = newBackup("user2", model.MergeBackup)
+		user3       = newBackup("user3", "")
+		all         = []*backup.Backup{user1, user1Merge, user1Assist, user2, user3}
+	)
+
+	table := []struct {
+		name       string
+		resourceID string
+		listErr    error
+		expectErr  assert.ErrorAssertionFunc
+		expect     []*backup.Backup
+	}{
+		{
+			name:       "multiple backups",
+			resourceID: "user1",
+			expectErr:  assert.NoError,
+			expect:     []*backup.Backup{user1, user1Merge},
+		},
+		{
+			name:       "single backup",
+			resourceID: "user2",
+			expectErr:  assert.NoError,
+			expect:     []*backup.Backup{user2},
+		},
+		{
+			name:       "no backups",
+			resourceID: "user4",
+			expectErr:  assert.NoError,
+			expect:     []*backup.Backup{},
+		},
+		{
+			name:      "missing resource id",
+			expectErr: assert.Error,
+		},
+		{
+			name:       "lookup error",
+			resourceID: "user1",
+			listErr:    assert.AnError,
+			expectErr:  assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			mbl := mockBackupList{
+				backups: all,
+				err:     test.listErr,
+			}
+
+			bs, err := backupsForResource(ctx, mbl, test.resourceID)
+			test.expectErr(t, err, clues.ToCore(err))
+
+			assert.ElementsMatch(t, test.expect, bs)
+		})
+	}
+}
+
+func (suite *RepositoryBackupsUnitSuite) TestLatestBackup() {
+	var (
+		now       = time.Now()
+		newBackup = func(sel selectors.Selector, created time.Time, backupType string) *backup.Backup {
+			b := &backup.Backup{
+				BaseModel: model.BaseModel{
+					ID:   model.StableID(uuid.NewString()),
+					Tags: map[string]string{},
+				},
+				CreationTime: created,
+				Selector:     sel,
+			}
+
+			if len(backupType) > 0 {
+				b.Tags[model.BackupTypeTag] = backupType
+			}
+
+			return b
+		}
+		exchange = func(owner string) selectors.Selector {
+			return selectors.NewExchangeBackup([]string{owner}).Selector
+		}
+
+		user1Old    = newBackup(exchange("user1"), now.Add(-3*time.Hour), model.MergeBackup)
+		user1Mid    = newBackup(exchange("user1"), now.Add(-2*time.Hour), "")
+		user1New    = newBackup(exchange("user1"), now.Add(-time.Hour), model.MergeBackup)
+		user1Assist = newBackup(exchange("user1"), now, model.AssistBackup)
+		user1Drive  = newBackup(
+			selectors.NewOneDriveBackup([]string{"user1"}).Selector,
+			now.Add(time.Hour),
+			model.MergeBackup)
+		user2 = newBackup(exchange("user2"), now.Add(time.Hour), model.MergeBackup)
+		all   = []*backup.Backup{user1Mid, user1New, user1Old, user1Assist, user1Drive, user2}
+	)
+
+	table := []struct {
+		name       string
+		resourceID string
+		service    path.ServiceType
+		listErr    error
+		expectErr  assert.ErrorAssertionFunc
+		expect     *backup.Backup
+	}{
+		{
+			name:       "newest of several backups",
+			resourceID: "user1",
+			service:    path.ExchangeService,
+			expectErr:  assert.NoError,
+			expect:     user1New,
+		},
+		{
+			name:       "other service",
+			resourceID: "user1",
+			service:    path.OneDriveService,
+			expectErr:  assert.NoError,
+			expect:     user1Drive,
+		},
+		{
+			name:       "no backups for the service",
+			resourceID: "user2",
+			service:    path.OneDriveService,
+			expectErr: func(t assert.TestingT, err error, msgAndArgs ...any) bool {
+				return assert.ErrorIs(t, err, data.ErrNotFound, msgAndArgs...)
+			},
+		},
+		{
+			name:       "no backups for the resource",
+			resourceID: "user3",
+			service:    path.ExchangeService,
+			expectErr: func(t assert.TestingT, err error, msgAndArgs ...any) bool {
+				return assert.ErrorIs(t, err, data.ErrNotFound, msgAndArgs...)
+			},
+		},
+		{
+			name:      "missing resource id",
+			service:   path.ExchangeService,
+			expectErr: assert.Error,
+		},
+		{
+			name:       "lookup error",
+			resourceID: "user1",
+			service:    path.ExchangeService,
+			listErr:    assert.AnError,
+			expectErr:  assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			mbl := mockBackupList{
+				backups: all,
+				err:     test.listErr,
+			}
+
+			b, err := latestBackup(ctx, mbl, test.resourceID, test.service)
+			test.expectErr(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expect, b)
+		})
+	}
+}
+
 func (suite *RepositoryBackupsUnitSuite) TestDeleteBackups() {
 	bup := &backup.Backup{
 		BaseModel: model.BaseModel{
@@ -405,6 +615,16 @@ func (suite *RepositoryBackupsUnitSuite) TestDeleteBackups() {
 		SnapshotID: "nssid-bup-dsid",
 	}
 
+	bupPinned := &backup.Backup{
+		BaseModel: model.BaseModel{
+			ID:           model.StableID("pinned-bup-id"),
+			ModelStoreID: manifest.ID("pinned-bup-msid"),
+		},
+		SnapshotID:    "pinned-bup-dsid",
+		StreamStoreID: "pinned-bup-ssid",
+		Pinned:        true,
+	}
+
 	table := []struct {
 		name          string
 		inputIDs      []model.StableID
@@ -413,6 +633,7 @@ func (suite *RepositoryBackupsUnitSuite) TestDeleteBackups() {
 		dels          []error
 		expectDels    [][]string
 		failOnMissing bool
+		force         bool
 		expectErr     func(t *testing.T, result error)
 	}{
 		{
@@ -648,6 +869,56 @@ func (suite *RepositoryBackupsUnitSuite) TestDeleteBackups() {
 				assert.NoError(t, result, clues.ToCore(result))
 			},
 		},
+		{
+			name: "PinnedBackup Refused",
+			inputIDs: []model.StableID{
+				bup.ID,
+				bupPinned.ID,
+			},
+			gets: []getRes{
+				{bup: bup},
+				{bup: bupPinned},
+			},
+			expectGets: []model.StableID{
+				bup.ID,
+				bupPinned.ID,
+			},
+			expectErr: func(t *testing.T, result error) {
+				assert.ErrorIs(t, result, ErrorBackupPinned, clues.ToCore(result))
+				assert.Contains(t, result.Error(), string(bupPinned.ID))
+				assert.NotContains(t, result.Error(), string(bup.ID))
+			},
+		},
+		{
+			name: "PinnedBackup Forced",
+			inputIDs: []model.StableID{
+				bup.ID,
+				bupPinned.ID,
+			},
+			gets: []getRes{
+				{bup: bup},
+				{bup: bupPinned},
+			},
+			expectGets: []model.StableID{
+				bup.ID,
+				bupPinned.ID,
+			},
+			dels: []error{nil},
+			expectDels: [][]string{
+				{
+					string(bup.ModelStoreID),
+					bup.SnapshotID,
+					bup.StreamStoreID,
+					string(bupPinned.ModelStoreID),
+					bupPinned.SnapshotID,
+					bupPinned.StreamStoreID,
+				},
+			},
+			force: true,
+			expectErr: func(t *testing.T, result error) {
+				assert.NoError(t, result, clues.ToCore(result))
+			},
+		},
 	}
 	for _, test := range table {
 		suite.Run(test.name, func() {
@@ -670,119 +941,1327 @@ func (suite *RepositoryBackupsUnitSuite) TestDeleteBackups() {
 				strIDs = append(strIDs, string(id))
 			}
 
-			err := deleteBackups(ctx, m, test.failOnMissing, strIDs...)
+			err := deleteBackups(ctx, m, 2, test.failOnMissing, test.force, strIDs...)
 			test.expectErr(t, err)
+			assert.Equal(t, len(test.expectGets), m.getCount, "backup lookups")
 		})
 	}
 }
 
-// ---------------------------------------------------------------------------
-// integration
-// ---------------------------------------------------------------------------
-
-type RepositoryModelIntgSuite struct {
-	tester.Suite
-	kw          *kopia.Wrapper
-	ms          *kopia.ModelStore
-	sw          store.BackupStorer
-	kopiaCloser func(ctx context.Context)
-}
+// mockConcurrentBackupStore serves backups by id, and tracks how many
+// lookups run at the same time.
+type mockConcurrentBackupStore struct {
+	backups map[model.StableID]*backup.Backup
 
-func TestRepositoryModelIntgSuite(t *testing.T) {
-	suite.Run(t, &RepositoryModelIntgSuite{
-		Suite: tester.NewIntegrationSuite(
-			t,
-			[][]string{storeTD.AWSStorageCredEnvs, tconfig.M365AcctCredEnvs}),
-	})
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
+	deleted     [][]manifest.ID
 }
 
-func (suite *RepositoryModelIntgSuite) SetupSuite() {
-	t := suite.T()
-
-	ctx, flush := tester.NewContext(t)
-	defer flush()
-
-	var (
-		s   = storeTD.NewPrefixedS3Storage(t)
-		k   = kopia.NewConn(s)
-		err error
-	)
+func (m *mockConcurrentBackupStore) GetBackup(
+	_ context.Context,
+	id model.StableID,
+) (*backup.Backup, error) {
+	m.mu.Lock()
+	m.inFlight++
+	m.maxInFlight = max(m.maxInFlight, m.inFlight)
+	m.mu.Unlock()
 
-	require.NotNil(t, k)
+	time.Sleep(time.Millisecond)
 
-	err = k.Initialize(ctx, rep.Options{}, rep.Retention{})
-	require.NoError(t, err, clues.ToCore(err))
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
-	err = k.Connect(ctx, rep.Options{})
-	require.NoError(t, err, clues.ToCore(err))
+	m.inFlight--
 
-	suite.kopiaCloser = func(ctx context.Context) {
-		k.Close(ctx)
+	b, ok := m.backups[id]
+	if !ok {
+		return nil, clues.Stack(data.ErrNotFound)
 	}
 
-	suite.kw, err = kopia.NewWrapper(k)
-	require.NoError(t, err, clues.ToCore(err))
-
-	suite.ms, err = kopia.NewModelStore(k)
-	require.NoError(t, err, clues.ToCore(err))
+	return b, nil
+}
 
-	suite.sw = store.NewWrapper(suite.ms)
+func (m *mockConcurrentBackupStore) DeleteWithModelStoreIDs(
+	_ context.Context,
+	ids ...manifest.ID,
+) error {
+	m.deleted = append(m.deleted, ids)
+	return nil
 }
 
-func (suite *RepositoryModelIntgSuite) TearDownSuite() {
-	t := suite.T()
+func (suite *RepositoryBackupsUnitSuite) TestDeleteBackups_concurrentLookups() {
+	const parallelism = 4
 
-	ctx, flush := tester.NewContext(t)
-	defer flush()
+	var (
+		backups   = map[model.StableID]*backup.Backup{}
+		ids       []string
+		manifests []manifest.ID
+	)
 
-	if suite.ms != nil {
-		err := suite.ms.Close(ctx)
-		assert.NoError(t, err, clues.ToCore(err))
-	}
+	for i := 0; i < 40; i++ {
+		id := "bup-" + strconv.Itoa(i)
 
-	if suite.kw != nil {
-		err := suite.kw.Close(ctx)
-		assert.NoError(t, err, clues.ToCore(err))
+		backups[model.StableID(id)] = &backup.Backup{
+			BaseModel: model.BaseModel{
+				ID:           model.StableID(id),
+				ModelStoreID: manifest.ID(id + "-msid"),
+			},
+			SnapshotID:    id + "-snapid",
+			StreamStoreID: id + "-ssid",
+		}
+
+		ids = append(ids, id)
+		manifests = append(
+			manifests,
+			manifest.ID(id+"-msid"),
+			manifest.ID(id+"-snapid"),
+			manifest.ID(id+"-ssid"))
 	}
 
-	if suite.kopiaCloser != nil {
-		suite.kopiaCloser(ctx)
+	table := []struct {
+		name          string
+		ids           []string
+		failOnMissing bool
+		expectErr     assert.ErrorAssertionFunc
+		expectDeleted [][]manifest.ID
+	}{
+		{
+			name:          "all found",
+			ids:           ids,
+			failOnMissing: true,
+			expectErr:     assert.NoError,
+			expectDeleted: [][]manifest.ID{manifests},
+		},
+		{
+			name:          "missing backup, fail on missing",
+			ids:           append(slices.Clone(ids), "missing"),
+			failOnMissing: true,
+			expectErr: func(t assert.TestingT, err error, msgAndArgs ...any) bool {
+				return assert.ErrorIs(t, err, ErrorBackupNotFound, msgAndArgs...)
+			},
+		},
+		{
+			name:          "missing backup, ignore missing",
+			ids:           append(slices.Clone(ids), "missing"),
+			expectErr:     assert.NoError,
+			expectDeleted: [][]manifest.ID{manifests},
+		},
 	}
-}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
 
-func (suite *RepositoryModelIntgSuite) TestGetRepositoryModel() {
-	t := suite.T()
+			ctx, flush := tester.NewContext(t)
+			defer flush()
 
-	ctx, flush := tester.NewContext(t)
-	defer flush()
+			m := &mockConcurrentBackupStore{backups: backups}
 
-	var (
-		s = storeTD.NewPrefixedS3Storage(t)
-		k = kopia.NewConn(s)
-	)
+			err := deleteBackups(ctx, m, parallelism, test.failOnMissing, false, test.ids...)
+			test.expectErr(t, err, clues.ToCore(err))
 
-	err := k.Initialize(ctx, rep.Options{}, rep.Retention{})
-	require.NoError(t, err, "initializing repo: %v", clues.ToCore(err))
+			require.Len(t, m.deleted, len(test.expectDeleted), "deletions are atomic")
 
-	err = k.Connect(ctx, rep.Options{})
-	require.NoError(t, err, "connecting to repo: %v", clues.ToCore(err))
+			// manifests are aggregated in the order of the ids, regardless of
+			// the order in which the lookups complete.
+			for i, expect := range test.expectDeleted {
+				assert.Equal(t, expect, m.deleted[i])
+			}
 
-	defer k.Close(ctx)
+			assert.LessOrEqual(t, m.maxInFlight, parallelism, "concurrent lookups")
+		})
+	}
+}
 
-	ms, err := kopia.NewModelStore(k)
-	require.NoError(t, err)
+type mockBackupGetterUpdater struct {
+	bup     *backup.Backup
+	getErr  error
+	updated []*backup.Backup
+}
 
-	defer ms.Close(ctx)
+func (m *mockBackupGetterUpdater) GetBackup(
+	context.Context,
+	model.StableID,
+) (*backup.Backup, error) {
+	if m.getErr != nil {
+		return nil, m.getErr
+	}
 
-	err = newRepoModel(ctx, ms, "fnords")
-	require.NoError(t, err, clues.ToCore(err))
+	b := *m.bup
 
-	got, err := getRepoModel(ctx, ms)
-	require.NoError(t, err, clues.ToCore(err))
-	assert.Equal(t, "fnords", string(got.ID))
+	return &b, nil
 }
 
-// helper func for writing backups
+func (m *mockBackupGetterUpdater) UpdateBackup(_ context.Context, b *backup.Backup) error {
+	m.updated = append(m.updated, b)
+	return nil
+}
+
+func (suite *RepositoryBackupsUnitSuite) TestSetBackupPinned() {
+	table := []struct {
+		name          string
+		pinned        bool
+		setPinned     bool
+		getErr        error
+		expectErr     assert.ErrorAssertionFunc
+		expectUpdated bool
+	}{
+		{
+			name:          "pin",
+			setPinned:     true,
+			expectErr:     assert.NoError,
+			expectUpdated: true,
+		},
+		{
+			name:          "unpin",
+			pinned:        true,
+			expectErr:     assert.NoError,
+			expectUpdated: true,
+		},
+		{
+			name:      "already pinned",
+			pinned:    true,
+			setPinned: true,
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "not found",
+			setPinned: true,
+			getErr:    data.ErrNotFound,
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			m := &mockBackupGetterUpdater{
+				bup: &backup.Backup{
+					BaseModel: model.BaseModel{ID: "bid"},
+					Pinned:    test.pinned,
+				},
+				getErr: test.getErr,
+			}
+
+			err := setBackupPinned(ctx, m, "bid", test.setPinned)
+			test.expectErr(t, err, clues.ToCore(err))
+
+			if test.getErr != nil {
+				assert.ErrorIs(t, err, ErrorBackupNotFound, clues.ToCore(err))
+			}
+
+			if !test.expectUpdated {
+				assert.Empty(t, m.updated, "updated backups")
+				return
+			}
+
+			require.Len(t, m.updated, 1, "updated backups")
+			assert.Equal(t, test.setPinned, m.updated[0].Pinned, "pinned")
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// integration
+// ---------------------------------------------------------------------------
+
+type mockStreamStoreManifestFinder struct {
+	manifests []*manifest.EntryMetadata
+	err       error
+}
+
+func (m mockStreamStoreManifestFinder) StreamStoreManifests(
+	context.Context,
+) ([]*manifest.EntryMetadata, error) {
+	return m.manifests, m.err
+}
+
+type mockBackupListerModelDeleter struct {
+	backups   []*backup.Backup
+	getErr    error
+	deleteErr error
+
+	deleted []manifest.ID
+}
+
+func (m *mockBackupListerModelDeleter) GetBackups(
+	context.Context,
+	...store.FilterOption,
+) ([]*backup.Backup, error) {
+	return m.backups, m.getErr
+}
+
+func (m *mockBackupListerModelDeleter) DeleteWithModelStoreIDs(
+	_ context.Context,
+	ids ...manifest.ID,
+) error {
+	m.deleted = append(m.deleted, ids...)
+	return m.deleteErr
+}
+
+func (suite *RepositoryBackupsUnitSuite) TestGarbageCollectOrphans() {
+	var (
+		now    = time.Now()
+		old    = now.Add(-2 * orphanGCBuffer)
+		cutoff = now.Add(-orphanGCBuffer)
+
+		ssManifest = func(id string, modTime time.Time) *manifest.EntryMetadata {
+			return &manifest.EntryMetadata{ID: manifest.ID(id), ModTime: modTime}
+		}
+
+		manifests = []*manifest.EntryMetadata{
+			ssManifest("referenced-ss", old),
+			ssManifest("referenced-details", old),
+			ssManifest("orphan1", old),
+			ssManifest("orphan2", old),
+			ssManifest("recent", now),
+		}
+
+		backups = []*backup.Backup{
+			{StreamStoreID: "referenced-ss"},
+			{DetailsID: "referenced-details"},
+			{StreamStoreID: "missing-ss"},
+		}
+	)
+
+	table := []struct {
+		name          string
+		ssmf          mockStreamStoreManifestFinder
+		sw            *mockBackupListerModelDeleter
+		dryRun        bool
+		expectOrphans []manifest.ID
+		expectDeleted []manifest.ID
+		expectErr     assert.ErrorAssertionFunc
+	}{
+		{
+			name:          "deletes orphans",
+			ssmf:          mockStreamStoreManifestFinder{manifests: manifests},
+			sw:            &mockBackupListerModelDeleter{backups: backups},
+			expectOrphans: []manifest.ID{"orphan1", "orphan2"},
+			expectDeleted: []manifest.ID{"orphan1", "orphan2"},
+			expectErr:     assert.NoError,
+		},
+		{
+			name:          "dry run",
+			ssmf:          mockStreamStoreManifestFinder{manifests: manifests},
+			sw:            &mockBackupListerModelDeleter{backups: backups},
+			dryRun:        true,
+			expectOrphans: []manifest.ID{"orphan1", "orphan2"},
+			expectErr:     assert.NoError,
+		},
+		{
+			name:          "no backups",
+			ssmf:          mockStreamStoreManifestFinder{manifests: manifests},
+			sw:            &mockBackupListerModelDeleter{},
+			expectOrphans: []manifest.ID{"referenced-ss", "referenced-details", "orphan1", "orphan2"},
+			expectDeleted: []manifest.ID{"referenced-ss", "referenced-details", "orphan1", "orphan2"},
+			expectErr:     assert.NoError,
+		},
+		{
+			name:          "no orphans",
+			ssmf:          mockStreamStoreManifestFinder{manifests: manifests[:2]},
+			sw:            &mockBackupListerModelDeleter{backups: backups},
+			expectOrphans: []manifest.ID{},
+			expectErr:     assert.NoError,
+		},
+		{
+			name:      "listing streamstores fails",
+			ssmf:      mockStreamStoreManifestFinder{err: assert.AnError},
+			sw:        &mockBackupListerModelDeleter{backups: backups},
+			expectErr: assert.Error,
+		},
+		{
+			name:      "listing backups fails",
+			ssmf:      mockStreamStoreManifestFinder{manifests: manifests},
+			sw:        &mockBackupListerModelDeleter{getErr: assert.AnError},
+			expectErr: assert.Error,
+		},
+		{
+			name: "deletion fails",
+			ssmf: mockStreamStoreManifestFinder{manifests: manifests},
+			sw: &mockBackupListerModelDeleter{
+				backups:   backups,
+				deleteErr: assert.AnError,
+			},
+			expectDeleted: []manifest.ID{"orphan1", "orphan2"},
+			expectErr:     assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			orphans, err := garbageCollectOrphans(ctx, test.ssmf, test.sw, cutoff, test.dryRun)
+			test.expectErr(t, err, clues.ToCore(err))
+
+			assert.Equal(t, test.expectOrphans, orphans, "orphans")
+			assert.Equal(t, test.expectDeleted, test.sw.deleted, "deleted")
+		})
+	}
+}
+
+type mockAccessChecker struct {
+	err error
+}
+
+func (m mockAccessChecker) CheckAccess(context.Context) error {
+	return m.err
+}
+
+// Copied from src/internal/m365/graph/errors_test.go
+func odErr(code string) *odataerrors.ODataError {
+	odErr := odataerrors.NewODataError()
+	merr := odataerrors.NewMainError()
+	merr.SetCode(&code)
+	odErr.SetErrorEscaped(merr)
+
+	return odErr
+}
+
+func (suite *RepositoryBackupsUnitSuite) TestCheckAccess() {
+	aadErr := func(code string) error {
+		return clues.New("ClientSecretCredential authentication failed: " + code + ": description")
+	}
+
+	table := []struct {
+		name      string
+		err       error
+		expectErr assert.ErrorAssertionFunc
+		expectIs  error
+	}{
+		{
+			name:      "no error",
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "expired secret",
+			err:       aadErr("AADSTS7000222"),
+			expectErr: assert.Error,
+			expectIs:  ErrorCredentialsExpired,
+		},
+		{
+			name:      "invalid secret",
+			err:       aadErr("AADSTS7000215"),
+			expectErr: assert.Error,
+			expectIs:  ErrorCredentialsInvalid,
+		},
+		{
+			name:      "unknown tenant",
+			err:       aadErr("AADSTS90002"),
+			expectErr: assert.Error,
+			expectIs:  ErrorCredentialsInvalid,
+		},
+		{
+			name: "unauthorized",
+			err: clues.Stack(assert.AnError).
+				Label(graph.LabelStatus(http.StatusUnauthorized)),
+			expectErr: assert.Error,
+			expectIs:  ErrorCredentialsInvalid,
+		},
+		{
+			name:      "consent required",
+			err:       aadErr("AADSTS65001"),
+			expectErr: assert.Error,
+			expectIs:  ErrorConsentRequired,
+		},
+		{
+			name:      "access denied",
+			err:       graph.Stack(context.Background(), odErr(string(graph.ErrorAccessDenied))),
+			expectErr: assert.Error,
+			expectIs:  ErrorInsufficientPermissions,
+		},
+		{
+			name:      "request denied",
+			err:       graph.Stack(context.Background(), odErr("Authorization_RequestDenied")),
+			expectErr: assert.Error,
+			expectIs:  ErrorInsufficientPermissions,
+		},
+		{
+			name:      "other error",
+			err:       assert.AnError,
+			expectErr: assert.Error,
+			expectIs:  assert.AnError,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			err := checkAccess(ctx, mockAccessChecker{err: test.err})
+			test.expectErr(t, err, clues.ToCore(err))
+
+			if test.expectIs != nil {
+				assert.ErrorIs(t, err, test.expectIs, clues.ToCore(err))
+			}
+		})
+	}
+}
+
+func (suite *RepositoryBackupsUnitSuite) TestListDeletedItems() {
+	var (
+		user  = "user"
+		other = "other"
+		start = time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	item := func(id string) details.Entry {
+		return details.Entry{
+			RepoRef: "tid/exchange/user/email/inbox/" + id,
+			ItemRef: id,
+			ItemInfo: details.ItemInfo{
+				Exchange: &details.ExchangeInfo{ItemType: details.ExchangeMail},
+			},
+		}
+	}
+
+	folder := details.Entry{
+		RepoRef: "tid/exchange/user/email/inbox",
+		ItemInfo: details.ItemInfo{
+			Folder: &details.FolderInfo{DisplayName: "inbox"},
+		},
+	}
+
+	bup := func(
+		sel selectors.Selector,
+		hoursAfterStart int,
+	) *backup.Backup {
+		return &backup.Backup{
+			BaseModel: model.BaseModel{
+				ID: model.StableID(uuid.NewString()),
+			},
+			CreationTime: start.Add(time.Duration(hoursAfterStart) * time.Hour),
+			Selector:     sel,
+		}
+	}
+
+	var (
+		exSel    = selectors.NewExchangeBackup([]string{user}).Selector
+		odSel    = selectors.NewOneDriveBackup([]string{user}).Selector
+		otherSel = selectors.NewExchangeBackup([]string{other}).Selector
+	)
+
+	type backupDeets struct {
+		bup     *backup.Backup
+		entries []details.Entry
+	}
+
+	table := []struct {
+		name       string
+		backups    []backupDeets
+		since      time.Time
+		getErr     error
+		expectErr  assert.ErrorAssertionFunc
+		expectRefs []string
+	}{
+		{
+			name:      "no backups",
+			since:     start,
+			expectErr: assert.NoError,
+		},
+		{
+			name: "single backup",
+			backups: []backupDeets{
+				{bup(exSel, 1), []details.Entry{folder, item("a")}},
+			},
+			since:     start,
+			expectErr: assert.NoError,
+		},
+		{
+			name: "deleted between backups",
+			backups: []backupDeets{
+				{bup(exSel, 1), []details.Entry{folder, item("a"), item("b"), item("c")}},
+				{bup(exSel, 2), []details.Entry{folder, item("b")}},
+				{bup(exSel, 3), []details.Entry{folder, item("b"), item("d")}},
+			},
+			since:      start,
+			expectErr:  assert.NoError,
+			expectRefs: []string{item("a").RepoRef, item("c").RepoRef},
+		},
+		{
+			name: "out of order backups",
+			backups: []backupDeets{
+				{bup(exSel, 3), []details.Entry{folder, item("b")}},
+				{bup(exSel, 1), []details.Entry{folder, item("a"), item("b")}},
+				{bup(exSel, 2), []details.Entry{folder, item("a"), item("b")}},
+			},
+			since:      start,
+			expectErr:  assert.NoError,
+			expectRefs: []string{item("a").RepoRef},
+		},
+		{
+			name: "deleted item restored later",
+			backups: []backupDeets{
+				{bup(exSel, 1), []details.Entry{folder, item("a"), item("b")}},
+				{bup(exSel, 2), []details.Entry{folder, item("b")}},
+				{bup(exSel, 3), []details.Entry{folder, item("a")}},
+			},
+			since:      start,
+			expectErr:  assert.NoError,
+			expectRefs: []string{item("b").RepoRef},
+		},
+		{
+			name: "deleted before since",
+			backups: []backupDeets{
+				{bup(exSel, 1), []details.Entry{folder, item("a"), item("b"), item("c")}},
+				{bup(exSel, 2), []details.Entry{folder, item("b"), item("c")}},
+				{bup(exSel, 4), []details.Entry{folder, item("b")}},
+			},
+			since:      start.Add(3 * time.Hour),
+			expectErr:  assert.NoError,
+			expectRefs: []string{item("c").RepoRef},
+		},
+		{
+			name: "no backups after since",
+			backups: []backupDeets{
+				{bup(exSel, 1), []details.Entry{folder, item("a")}},
+				{bup(exSel, 2), []details.Entry{folder}},
+			},
+			since:     start.Add(3 * time.Hour),
+			expectErr: assert.NoError,
+		},
+		{
+			name: "other resources and services ignored",
+			backups: []backupDeets{
+				{bup(exSel, 1), []details.Entry{folder, item("a")}},
+				{bup(odSel, 2), []details.Entry{}},
+				{bup(otherSel, 3), []details.Entry{}},
+				{bup(exSel, 4), []details.Entry{folder, item("a")}},
+			},
+			since:     start,
+			expectErr: assert.NoError,
+		},
+		{
+			name: "details error",
+			backups: []backupDeets{
+				{bup(exSel, 1), []details.Entry{folder, item("a")}},
+				{bup(exSel, 2), []details.Entry{folder}},
+			},
+			since:     start,
+			getErr:    assert.AnError,
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				bups  = make([]*backup.Backup, 0, len(test.backups))
+				deets = map[model.StableID][]details.Entry{}
+			)
+
+			for _, bd := range test.backups {
+				bups = append(bups, bd.bup)
+				deets[bd.bup.ID] = bd.entries
+			}
+
+			getDeets := func(_ context.Context, b *backup.Backup) (*details.Details, error) {
+				if test.getErr != nil {
+					return nil, test.getErr
+				}
+
+				d := &details.Details{}
+				d.Entries = deets[b.ID]
+
+				return d, nil
+			}
+
+			result, err := listDeletedItems(ctx, bups, user, test.since, getDeets)
+			test.expectErr(t, err, clues.ToCore(err))
+
+			refs := make([]string, 0, len(result))
+			for _, ent := range result {
+				refs = append(refs, ent.RepoRef)
+			}
+
+			assert.ElementsMatch(t, test.expectRefs, refs)
+		})
+	}
+}
+
+type mockLiveContainerEnumerator struct {
+	containers map[path.CategoryType][]exchange.LiveContainer
+	// container ID -> item count
+	items      map[string]int
+	err        error
+	enumerated []path.CategoryType
+}
+
+func (m *mockLiveContainerEnumerator) LiveContainers(
+	_ context.Context,
+	_ string,
+	cat path.CategoryType,
+) ([]exchange.LiveContainer, error) {
+	m.enumerated = append(m.enumerated, cat)
+	return m.containers[cat], m.err
+}
+
+func (m *mockLiveContainerEnumerator) LiveContainerItemIDs(
+	_ context.Context,
+	_ string,
+	_ path.CategoryType,
+	containerID string,
+) (map[string]struct{}, error) {
+	ids := map[string]struct{}{}
+
+	for i := 0; i < m.items[containerID]; i++ {
+		ids[containerID+strconv.Itoa(i)] = struct{}{}
+	}
+
+	return ids, nil
+}
+
+func (suite *RepositoryBackupsUnitSuite) TestAuditBackup() {
+	item := func(id string) details.Entry {
+		return details.Entry{
+			RepoRef: "tid/exchange/user/email/inbox/" + id,
+			ItemRef: id,
+			ItemInfo: details.ItemInfo{
+				Exchange: &details.ExchangeInfo{ItemType: details.ExchangeMail},
+			},
+		}
+	}
+
+	folder := details.Entry{
+		RepoRef: "tid/exchange/user/email/inbox",
+		ItemInfo: details.ItemInfo{
+			Folder: &details.FolderInfo{DisplayName: "inbox"},
+		},
+	}
+
+	lc := func(id string, elems ...string) exchange.LiveContainer {
+		return exchange.LiveContainer{ID: id, Location: path.Builder{}.Append(elems...)}
+	}
+
+	containers := map[path.CategoryType][]exchange.LiveContainer{
+		path.EmailCategory: {
+			lc("inbox", "Inbox"),
+			lc("archive", "Archive"),
+		},
+		path.ContactsCategory: {
+			lc("contacts", "Contacts"),
+		},
+	}
+
+	bup := func(scopes ...[]selectors.ExchangeScope) *backup.Backup {
+		sel := selectors.NewExchangeBackup([]string{"user"})
+		sel.Include(scopes...)
+
+		return &backup.Backup{
+			BaseModel: model.BaseModel{ID: "bid"},
+			Selector:  sel.Selector,
+		}
+	}
+
+	sel := selectors.NewExchangeBackup(nil)
+
+	table := []struct {
+		name           string
+		bup            *backup.Backup
+		entries        []details.Entry
+		items          map[string]int
+		liveErr        error
+		expectErr      assert.ErrorAssertionFunc
+		expectCats     []path.CategoryType
+		expectLive     int
+		expectBackedUp int
+		expectMissing  []string
+	}{
+		{
+			name:           "complete backup",
+			bup:            bup(sel.MailFolders([]string{"Inbox"})),
+			entries:        []details.Entry{folder, item("inbox0"), item("inbox1")},
+			items:          map[string]int{"inbox": 2},
+			expectErr:      assert.NoError,
+			expectCats:     []path.CategoryType{path.EmailCategory},
+			expectLive:     2,
+			expectBackedUp: 2,
+			expectMissing:  []string{},
+		},
+		{
+			name:           "items missing from backup",
+			bup:            bup(sel.MailFolders(selectors.Any()), sel.ContactFolders(selectors.Any())),
+			entries:        []details.Entry{folder, item("inbox0")},
+			items:          map[string]int{"inbox": 2, "archive": 1, "contacts": 1},
+			expectErr:      assert.NoError,
+			expectCats:     []path.CategoryType{path.EmailCategory, path.ContactsCategory},
+			expectLive:     4,
+			expectBackedUp: 1,
+			expectMissing:  []string{"archive0", "contacts0", "inbox1"},
+		},
+		{
+			name:           "items outside the backup scopes",
+			bup:            bup(sel.MailFolders([]string{"Inbox"})),
+			entries:        []details.Entry{folder, item("inbox0")},
+			items:          map[string]int{"inbox": 1, "archive": 3, "contacts": 2},
+			expectErr:      assert.NoError,
+			expectCats:     []path.CategoryType{path.EmailCategory},
+			expectLive:     1,
+			expectBackedUp: 1,
+			expectMissing:  []string{},
+		},
+		{
+			name:           "items deleted since backup",
+			bup:            bup(sel.MailFolders([]string{"Inbox"})),
+			entries:        []details.Entry{folder, item("inbox0"), item("inbox1")},
+			items:          map[string]int{"inbox": 1},
+			expectErr:      assert.NoError,
+			expectCats:     []path.CategoryType{path.EmailCategory},
+			expectLive:     1,
+			expectBackedUp: 1,
+			expectMissing:  []string{},
+		},
+		{
+			name:       "enumeration error",
+			bup:        bup(sel.MailFolders(selectors.Any())),
+			entries:    []details.Entry{folder, item("inbox0")},
+			liveErr:    assert.AnError,
+			expectErr:  assert.Error,
+			expectCats: []path.CategoryType{path.EmailCategory},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			deets := &details.Details{}
+			deets.Entries = test.entries
+
+			enumerator := &mockLiveContainerEnumerator{
+				containers: containers,
+				items:      test.items,
+				err:        test.liveErr,
+			}
+
+			report, err := auditBackup(ctx, test.bup, deets, enumerator)
+			test.expectErr(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expectCats, enumerator.enumerated, "enumerated categories")
+
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, "bid", report.BackupID)
+			assert.Equal(t, "user", report.ResourceID)
+			assert.Equal(t, test.expectLive, report.LiveItems)
+			assert.Equal(t, test.expectBackedUp, report.BackedUpItems)
+			assert.Equal(t, test.expectMissing, report.Missing)
+		})
+	}
+}
+
+func (suite *RepositoryBackupsUnitSuite) TestPreviewBackup() {
+	lc := func(id string, elems ...string) exchange.LiveContainer {
+		return exchange.LiveContainer{ID: id, Location: path.Builder{}.Append(elems...)}
+	}
+
+	enumerator := func() *mockLiveContainerEnumerator {
+		return &mockLiveContainerEnumerator{
+			containers: map[path.CategoryType][]exchange.LiveContainer{
+				path.EmailCategory: {
+					lc("inbox", "Inbox"),
+					lc("sub", "Inbox", "Sub"),
+					lc("archive", "Archive"),
+					lc("inboxes", "Inboxes"),
+				},
+				path.ContactsCategory: {
+					lc("contacts", "Contacts"),
+				},
+			},
+			items: map[string]int{
+				"inbox":    3,
+				"sub":      1,
+				"contacts": 2,
+			},
+		}
+	}
+
+	preview := func(cat path.CategoryType, id, loc string, items int) ContainerPreview {
+		return ContainerPreview{Category: cat.String(), ID: id, Location: loc, Items: items}
+	}
+
+	table := []struct {
+		name            string
+		sel             func() selectors.Selector
+		enumErr         error
+		expectErr       assert.ErrorAssertionFunc
+		expectEnumCats  []path.CategoryType
+		expectContainer []ContainerPreview
+	}{
+		{
+			name: "all mail folders",
+			sel: func() selectors.Selector {
+				sel := selectors.NewExchangeBackup([]string{"user"})
+				sel.Include(sel.MailFolders(selectors.Any()))
+
+				return sel.Selector
+			},
+			expectErr:      assert.NoError,
+			expectEnumCats: []path.CategoryType{path.EmailCategory},
+			expectContainer: []ContainerPreview{
+				preview(path.EmailCategory, "archive", "Archive", 0),
+				preview(path.EmailCategory, "inbox", "Inbox", 3),
+				preview(path.EmailCategory, "sub", "Inbox/Sub", 1),
+				preview(path.EmailCategory, "inboxes", "Inboxes", 0),
+			},
+		},
+		{
+			name: "prefix matched mail folder",
+			sel: func() selectors.Selector {
+				sel := selectors.NewExchangeBackup([]string{"user"})
+				sel.Include(sel.MailFolders([]string{"Inbox"}, selectors.PrefixMatch()))
+
+				return sel.Selector
+			},
+			expectErr:      assert.NoError,
+			expectEnumCats: []path.CategoryType{path.EmailCategory},
+			expectContainer: []ContainerPreview{
+				preview(path.EmailCategory, "inbox", "Inbox", 3),
+				preview(path.EmailCategory, "sub", "Inbox/Sub", 1),
+			},
+		},
+		{
+			// backup folder scopes also include the folder's subfolders.
+			name: "mail folder",
+			sel: func() selectors.Selector {
+				sel := selectors.NewExchangeBackup([]string{"user"})
+				sel.Include(sel.MailFolders([]string{"Inbox"}))
+
+				return sel.Selector
+			},
+			expectErr:      assert.NoError,
+			expectEnumCats: []path.CategoryType{path.EmailCategory},
+			expectContainer: []ContainerPreview{
+				preview(path.EmailCategory, "inbox", "Inbox", 3),
+				preview(path.EmailCategory, "sub", "Inbox/Sub", 1),
+			},
+		},
+		{
+			name: "overlapping scopes and categories",
+			sel: func() selectors.Selector {
+				sel := selectors.NewExchangeBackup([]string{"user"})
+				sel.Include(
+					sel.MailFolders([]string{"Inbox"}, selectors.PrefixMatch()),
+					sel.MailFolders([]string{"Inbox"}),
+					sel.ContactFolders(selectors.Any()))
+
+				return sel.Selector
+			},
+			expectErr:      assert.NoError,
+			expectEnumCats: []path.CategoryType{path.EmailCategory, path.ContactsCategory},
+			expectContainer: []ContainerPreview{
+				preview(path.ContactsCategory, "contacts", "Contacts", 2),
+				preview(path.EmailCategory, "inbox", "Inbox", 3),
+				preview(path.EmailCategory, "sub", "Inbox/Sub", 1),
+			},
+		},
+		{
+			name: "no matching folders",
+			sel: func() selectors.Selector {
+				sel := selectors.NewExchangeBackup([]string{"user"})
+				sel.Include(sel.MailFolders([]string{"Missing"}))
+
+				return sel.Selector
+			},
+			expectErr:       assert.NoError,
+			expectEnumCats:  []path.CategoryType{path.EmailCategory},
+			expectContainer: []ContainerPreview{},
+		},
+		{
+			name: "enumeration error",
+			sel: func() selectors.Selector {
+				sel := selectors.NewExchangeBackup([]string{"user"})
+				sel.Include(sel.MailFolders(selectors.Any()))
+
+				return sel.Selector
+			},
+			enumErr:        assert.AnError,
+			expectErr:      assert.Error,
+			expectEnumCats: []path.CategoryType{path.EmailCategory},
+		},
+		{
+			name: "multiple resources",
+			sel: func() selectors.Selector {
+				sel := selectors.NewExchangeBackup([]string{"user", "other"})
+				sel.Include(sel.MailFolders(selectors.Any()))
+
+				return sel.Selector
+			},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			enumerator := enumerator()
+			enumerator.err = test.enumErr
+
+			result, err := previewBackup(ctx, test.sel(), enumerator)
+			test.expectErr(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expectEnumCats, enumerator.enumerated, "enumerated categories")
+
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, "user", result.ResourceID)
+			assert.Equal(t, test.expectContainer, result.Containers)
+		})
+	}
+}
+
+func (suite *RepositoryBackupsUnitSuite) TestPreviewBackup_unsupportedServices() {
+	table := []struct {
+		name string
+		sel  selectors.Selector
+	}{
+		{
+			name: "onedrive",
+			sel:  selectors.NewOneDriveBackup([]string{"user"}).Selector,
+		},
+		{
+			name: "sharepoint",
+			sel:  selectors.NewSharePointBackup([]string{"site"}).Selector,
+		},
+		{
+			name: "groups",
+			sel:  selectors.NewGroupsBackup([]string{"group"}).Selector,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			result, err := repository{}.PreviewBackup(ctx, test.sel)
+			assert.ErrorIs(t, err, ErrorPreviewUnsupported, clues.ToCore(err))
+			assert.Nil(t, result)
+		})
+	}
+}
+
+var _ store.Storer = &mockRepoModelStore{}
+
+// mockRepoModelStore keeps repository models in memory.  Puts yield to
+// other goroutines before storing the model, to widen the window in which
+// concurrent initializations can race.
+type mockRepoModelStore struct {
+	store.Storer
+
+	mu     sync.Mutex
+	models []*model.BaseModel
+	getErr error
+	// racer, if set, gets stored by the first put, ahead of the put model,
+	// as if another initialization won a race to the store.
+	racer *model.BaseModel
+}
+
+func (ms *mockRepoModelStore) GetIDsForType(
+	context.Context,
+	model.Schema,
+	map[string]string,
+) ([]*model.BaseModel, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	return append([]*model.BaseModel{}, ms.models...), ms.getErr
+}
+
+func (ms *mockRepoModelStore) Put(_ context.Context, _ model.Schema, m model.Model) error {
+	time.Sleep(time.Millisecond)
+
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if ms.racer != nil {
+		ms.models = append(ms.models, ms.racer)
+		ms.racer = nil
+	}
+
+	bm := m.Base()
+	bm.ModTime = time.Now()
+	ms.models = append(ms.models, bm)
+
+	return nil
+}
+
+func (ms *mockRepoModelStore) Delete(_ context.Context, _ model.Schema, id model.StableID) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	ms.models = slices.DeleteFunc(ms.models, func(bm *model.BaseModel) bool {
+		return bm.ID == id
+	})
+
+	return nil
+}
+
+type RepositoryModelUnitSuite struct {
+	tester.Suite
+}
+
+func TestRepositoryModelUnitSuite(t *testing.T) {
+	suite.Run(t, &RepositoryModelUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *RepositoryModelUnitSuite) TestNewRepoModel() {
+	table := []struct {
+		name      string
+		ms        *mockRepoModelStore
+		expectErr assert.ErrorAssertionFunc
+		expectIDs []model.StableID
+	}{
+		{
+			name:      "empty store",
+			ms:        &mockRepoModelStore{},
+			expectErr: assert.NoError,
+			expectIDs: []model.StableID{"fnords"},
+		},
+		{
+			name: "model already exists",
+			ms: &mockRepoModelStore{
+				models: []*model.BaseModel{{ID: "smarf"}},
+			},
+			expectErr: func(t assert.TestingT, err error, msgAndArgs ...any) bool {
+				return assert.ErrorIs(t, err, ErrorRepoAlreadyExists, msgAndArgs...)
+			},
+			expectIDs: []model.StableID{"smarf"},
+		},
+		{
+			name: "loses a race",
+			ms: &mockRepoModelStore{
+				racer: &model.BaseModel{ID: "smarf", ModTime: time.Now().Add(-time.Second)},
+			},
+			expectErr: func(t assert.TestingT, err error, msgAndArgs ...any) bool {
+				return assert.ErrorIs(t, err, ErrorRepoAlreadyExists, msgAndArgs...)
+			},
+			expectIDs: []model.StableID{"smarf"},
+		},
+		{
+			name: "lookup failure",
+			ms: &mockRepoModelStore{
+				getErr: assert.AnError,
+			},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			err := newRepoModel(ctx, test.ms, "fnords")
+			test.expectErr(t, err, clues.ToCore(err))
+
+			ids := []model.StableID{}
+			for _, bm := range test.ms.models {
+				ids = append(ids, bm.ID)
+			}
+
+			if len(test.expectIDs) == 0 {
+				assert.Empty(t, ids)
+				return
+			}
+
+			assert.Equal(t, test.expectIDs, ids)
+		})
+	}
+}
+
+func (suite *RepositoryModelUnitSuite) TestGetRepoModel_oldest() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	now := time.Now()
+
+	ms := &mockRepoModelStore{
+		models: []*model.BaseModel{
+			{ID: "newer", ModTime: now},
+			{ID: "tied-b", ModTime: now.Add(-time.Minute)},
+			{ID: "tied-a", ModTime: now.Add(-time.Minute)},
+		},
+	}
+
+	rm, err := getRepoModel(ctx, ms)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, model.StableID("tied-a"), rm.ID)
+}
+
+func (suite *RepositoryModelUnitSuite) TestNewRepoModel_concurrent() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		ms      = &mockRepoModelStore{}
+		callers = 10
+		wg      sync.WaitGroup
+		errs    = make([]error, callers)
+	)
+
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = newRepoModel(ctx, ms, "repo-"+strconv.Itoa(i))
+		}(i)
+	}
+
+	wg.Wait()
+
+	var created int
+
+	for _, err := range errs {
+		if err == nil {
+			created++
+			continue
+		}
+
+		assert.ErrorIs(t, err, ErrorRepoAlreadyExists, clues.ToCore(err))
+	}
+
+	assert.Equal(t, 1, created, "exactly one caller creates the repository model")
+	assert.Len(t, ms.models, 1, "stored repository models")
+}
+
+type RepositoryModelIntgSuite struct {
+	tester.Suite
+	kw          *kopia.Wrapper
+	ms          *kopia.ModelStore
+	sw          store.BackupStorer
+	kopiaCloser func(ctx context.Context)
+}
+
+func TestRepositoryModelIntgSuite(t *testing.T) {
+	suite.Run(t, &RepositoryModelIntgSuite{
+		Suite: tester.NewIntegrationSuite(
+			t,
+			[][]string{storeTD.AWSStorageCredEnvs, tconfig.M365AcctCredEnvs}),
+	})
+}
+
+func (suite *RepositoryModelIntgSuite) SetupSuite() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		s   = storeTD.NewPrefixedS3Storage(t)
+		k   = kopia.NewConn(s)
+		err error
+	)
+
+	require.NotNil(t, k)
+
+	err = k.Initialize(ctx, rep.Options{}, rep.Retention{})
+	require.NoError(t, err, clues.ToCore(err))
+
+	err = k.Connect(ctx, rep.Options{})
+	require.NoError(t, err, clues.ToCore(err))
+
+	suite.kopiaCloser = func(ctx context.Context) {
+		k.Close(ctx)
+	}
+
+	suite.kw, err = kopia.NewWrapper(k)
+	require.NoError(t, err, clues.ToCore(err))
+
+	suite.ms, err = kopia.NewModelStore(k)
+	require.NoError(t, err, clues.ToCore(err))
+
+	suite.sw = store.NewWrapper(suite.ms)
+}
+
+func (suite *RepositoryModelIntgSuite) TearDownSuite() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	if suite.ms != nil {
+		err := suite.ms.Close(ctx)
+		assert.NoError(t, err, clues.ToCore(err))
+	}
+
+	if suite.kw != nil {
+		err := suite.kw.Close(ctx)
+		assert.NoError(t, err, clues.ToCore(err))
+	}
+
+	if suite.kopiaCloser != nil {
+		suite.kopiaCloser(ctx)
+	}
+}
+
+func (suite *RepositoryModelIntgSuite) TestGetRepositoryModel() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		s = storeTD.NewPrefixedS3Storage(t)
+		k = kopia.NewConn(s)
+	)
+
+	err := k.Initialize(ctx, rep.Options{}, rep.Retention{})
+	require.NoError(t, err, "initializing repo: %v", clues.ToCore(err))
+
+	err = k.Connect(ctx, rep.Options{})
+	require.NoError(t, err, "connecting to repo: %v", clues.ToCore(err))
+
+	defer k.Close(ctx)
+
+	ms, err := kopia.NewModelStore(k)
+	require.NoError(t, err)
+
+	defer ms.Close(ctx)
+
+	err = newRepoModel(ctx, ms, "fnords")
+	require.NoError(t, err, clues.ToCore(err))
+
+	got, err := getRepoModel(ctx, ms)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, "fnords", string(got.ID))
+
+	err = newRepoModel(ctx, ms, "smarfs")
+	assert.ErrorIs(t, err, ErrorRepoAlreadyExists, clues.ToCore(err))
+
+	got, err = getRepoModel(ctx, ms)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, "fnords", string(got.ID), "original model is kept")
+}
+
+// helper func for writing backups
 func writeBackup(
 	t *testing.T,
 	ctx context.Context, //revive:disable-line:context-as-argument
@@ -851,11 +2330,24 @@ func (suite *RepositoryModelIntgSuite) TestGetBackupDetails() {
 	builder := &details.Builder{}
 	require.NoError(suite.T(), builder.Add(repoPath, loc, info))
 
+	ctx, flush := tester.NewContext(suite.T())
+	defer flush()
+
+	key, err := backup.DetailsSigningKey(ctx, suite.kw)
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	altered := details.Details{}
+
+	// the tampered backup holds a signature made over different details.
+	altered.Entries = append(altered.Entries, builder.Details().Entries...)
+	altered.Entries[0].ItemRef = "altered"
+
 	table := []struct {
 		name       string
 		writeBupID string
 		readBupID  string
 		deets      *details.Details
+		signature  func(t *testing.T) string
 		expectErr  require.ErrorAssertionFunc
 	}{
 		{
@@ -872,6 +2364,24 @@ func (suite *RepositoryModelIntgSuite) TestGetBackupDetails() {
 			deets:      builder.Details(),
 			expectErr:  require.Error,
 		},
+		{
+			name:       "signed",
+			writeBupID: "badgers",
+			readBupID:  "badgers",
+			deets:      builder.Details(),
+			signature:  func(t *testing.T) string { return signDetails(t, key, builder.Details()) },
+			expectErr:  require.NoError,
+		},
+		{
+			name:       "tampered",
+			writeBupID: "stoats",
+			readBupID:  "stoats",
+			deets:      builder.Details(),
+			signature:  func(t *testing.T) string { return signDetails(t, key, &altered) },
+			expectErr: func(t require.TestingT, err error, msgAndArgs ...any) {
+				require.ErrorIs(t, err, backup.ErrDetailsTampered, msgAndArgs...)
+			},
+		},
 	}
 	for _, test := range table {
 		suite.Run(test.name, func() {
@@ -892,8 +2402,22 @@ func (suite *RepositoryModelIntgSuite) TestGetBackupDetails() {
 				&fault.Errors{},
 				fault.New(true))
 
-			rDeets, rBup, err := getBackupDetails(ctx, test.readBupID, tenantID, suite.kw, suite.sw, fault.New(true))
-			test.expectErr(t, err)
+			if test.signature != nil {
+				b.DetailsSignature = test.signature(t)
+
+				err := suite.sw.Update(ctx, model.BackupSchema, b)
+				require.NoError(t, err, clues.ToCore(err))
+			}
+
+			rDeets, rBup, err := getBackupDetails(
+				ctx,
+				test.readBupID,
+				tenantID,
+				key,
+				suite.kw,
+				suite.sw,
+				fault.New(true))
+			test.expectErr(t, err, clues.ToCore(err))
 
 			if err != nil {
 				return
@@ -905,6 +2429,115 @@ func (suite *RepositoryModelIntgSuite) TestGetBackupDetails() {
 	}
 }
 
+func (suite *RepositoryModelIntgSuite) TestGetBackupDetails_afterPassphraseChange() {
+	const (
+		brunhilda = "brunhilda"
+		tenantID  = "tenant"
+		backupID  = "ferrets"
+	)
+
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	st := storeTD.NewPrefixedS3Storage(t)
+
+	cfg, err := st.CommonConfig()
+	require.NoError(t, err, clues.ToCore(err))
+
+	oldPass := cfg.CorsoPassphrase
+
+	k := kopia.NewConn(st)
+
+	err = k.Initialize(ctx, rep.Options{}, rep.Retention{})
+	require.NoError(t, err, clues.ToCore(err))
+
+	err = k.Close(ctx)
+	require.NoError(t, err, clues.ToCore(err))
+
+	// connect produces a repository over a fresh connection to the storage.
+	connect := func(t *testing.T) (repository, func()) {
+		k := kopia.NewConn(st)
+
+		err := k.Connect(ctx, rep.Options{})
+		require.NoError(t, err, clues.ToCore(err))
+
+		kw, err := kopia.NewWrapper(k)
+		require.NoError(t, err, clues.ToCore(err))
+
+		ms, err := kopia.NewModelStore(k)
+		require.NoError(t, err, clues.ToCore(err))
+
+		closer := func() {
+			ms.Close(ctx)
+			kw.Close(ctx)
+			k.Close(ctx)
+		}
+
+		return repository{Storage: st, dataLayer: kw, modelStore: ms}, closer
+	}
+
+	repoPath, err := path.FromDataLayerPath(tenantID+"/exchange/user-id/email/test/foo", true)
+	require.NoError(t, err, clues.ToCore(err))
+
+	builder := &details.Builder{}
+	err = builder.Add(repoPath, path.Builder{}.Append(repoPath.Folders()...), details.ItemInfo{
+		Exchange: &details.ExchangeInfo{ItemType: details.ExchangeMail},
+	})
+	require.NoError(t, err, clues.ToCore(err))
+
+	r, closer := connect(t)
+	sw := store.NewWrapper(r.modelStore)
+
+	key := r.detailsSigningKey(ctx)
+	require.NotEmpty(t, key, "details signing key")
+
+	b := writeBackup(
+		t,
+		ctx,
+		r.dataLayer,
+		sw,
+		tenantID, "snapID", backupID,
+		selectors.NewExchangeBackup([]string{brunhilda}).Selector,
+		brunhilda, brunhilda,
+		builder.Details(),
+		&fault.Errors{},
+		fault.New(true))
+
+	b.DetailsSignature = signDetails(t, key, builder.Details())
+
+	err = sw.Update(ctx, model.BackupSchema, b)
+	require.NoError(t, err, clues.ToCore(err))
+
+	err = r.ChangePassphrase(ctx, oldPass, oldPass+"-rotated")
+	require.NoError(t, err, clues.ToCore(err))
+
+	closer()
+
+	r, closer = connect(t)
+	defer closer()
+
+	assert.Equal(t, key, r.detailsSigningKey(ctx), "signing key survives the passphrase change")
+
+	_, _, err = getBackupDetails(
+		ctx,
+		backupID,
+		tenantID,
+		r.detailsSigningKey(ctx),
+		r.dataLayer,
+		store.NewWrapper(r.modelStore),
+		fault.New(true))
+	assert.NoError(t, err, "reading details signed with the old passphrase", clues.ToCore(err))
+}
+
+func signDetails(t *testing.T, key []byte, deets *details.Details) string {
+	sig, err := backup.SignDetails(key, deets)
+	require.NoError(t, err, clues.ToCore(err))
+
+	return sig
+}
+
 func (suite *RepositoryModelIntgSuite) TestGetBackupErrors() {
 	const (
 		tenantID  = "tenant"
@@ -1011,3 +2644,82 @@ func (suite *RepositoryModelIntgSuite) TestGetBackupErrors() {
 		})
 	}
 }
+
+func (suite *RepositoryBackupsUnitSuite) TestBackupLineage() {
+	newBackup := func(id string, merge, assist []model.StableID) *backup.Backup {
+		return &backup.Backup{
+			BaseModel:     model.BaseModel{ID: model.StableID(id)},
+			Status:        "Completed",
+			MergeBaseIDs:  merge,
+			AssistBaseIDs: assist,
+		}
+	}
+
+	var (
+		full   = newBackup("full", nil, nil)
+		incr1  = newBackup("incr1", []model.StableID{"full"}, nil)
+		assist = newBackup("assist", []model.StableID{"incr1"}, nil)
+		// incr1 is reachable both directly and through the assist base.
+		incr2 = newBackup("incr2", []model.StableID{"incr1"}, []model.StableID{"assist"})
+		// expired was deleted after orphan was built from it.
+		orphan = newBackup("orphan", []model.StableID{"expired"}, nil)
+		mbl    = mockBackupList{
+			backups: []*backup.Backup{full, incr1, assist, incr2, orphan},
+		}
+	)
+
+	table := []struct {
+		name      string
+		id        string
+		expect    []backup.LineageEntry
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "full backup",
+			id:   "full",
+			expect: []backup.LineageEntry{
+				{ID: "full", Relation: backup.LineageRoot, Backup: full},
+			},
+			expectErr: assert.NoError,
+		},
+		{
+			name: "incremental chain",
+			id:   "incr2",
+			expect: []backup.LineageEntry{
+				{ID: "incr2", Relation: backup.LineageRoot, Backup: incr2},
+				{ID: "incr1", Depth: 1, Relation: backup.LineageMergeBase, BaseOf: "incr2", Backup: incr1},
+				{ID: "full", Depth: 2, Relation: backup.LineageMergeBase, BaseOf: "incr1", Backup: full},
+				{ID: "assist", Depth: 1, Relation: backup.LineageAssistBase, BaseOf: "incr2", Backup: assist},
+			},
+			expectErr: assert.NoError,
+		},
+		{
+			name: "deleted base",
+			id:   "orphan",
+			expect: []backup.LineageEntry{
+				{ID: "orphan", Relation: backup.LineageRoot, Backup: orphan},
+				{ID: "expired", Depth: 1, Relation: backup.LineageMergeBase, BaseOf: "orphan"},
+			},
+			expectErr: assert.NoError,
+		},
+		{
+			name: "missing backup",
+			id:   "nope",
+			expectErr: func(t assert.TestingT, err error, i ...any) bool {
+				return assert.ErrorIs(t, err, ErrorBackupNotFound, i...)
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			les, err := backupLineage(ctx, test.id, mbl)
+			test.expectErr(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expect, les)
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/alcionai/clues"
+	backoff "github.com/cenkalti/backoff/v4"
+	"github.com/minio/minio-go/v7"
+
+	"github.com/alcionai/corso/src/internal/kopia"
+	ctrlRepo "github.com/alcionai/corso/src/pkg/control/repository"
+	"github.com/alcionai/corso/src/pkg/logger"
+)
+
+// RetryConfig controls how ConnectWithRetry retries connecting to storage
+// that is briefly unreachable.
+type RetryConfig struct {
+	// MaxAttempts is the total number of connection attempts.  Values
+	// below 1 produce a single attempt.
+	MaxAttempts int
+	// InitialDelay is the wait before the first retry.  Each following
+	// retry waits exponentially longer, up to MaxDelay.
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+}
+
+// DefaultRetryConfig provides a RetryConfig that rides out storage outages
+// of up to about a minute.
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxAttempts:  5,
+		InitialDelay: 2 * time.Second,
+		MaxDelay:     30 * time.Second,
+	}
+}
+
+// kopiaConnector is the subset of the kopia conn used to open the
+// connection to the repository.
+type kopiaConnector interface {
+	Connect(ctx context.Context, opts ctrlRepo.Options) error
+}
+
+// connectWithRetry connects the kopia conn, retrying transient failures
+// according to the retry config.
+func connectWithRetry(
+	ctx context.Context,
+	kc kopiaConnector,
+	opts ctrlRepo.Options,
+	retry RetryConfig,
+) error {
+	attempts := retry.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	ebo := backoff.NewExponentialBackOff()
+	ebo.InitialInterval = retry.InitialDelay
+	ebo.MaxInterval = retry.MaxDelay
+	// attempts, not time, bound the retries.
+	ebo.MaxElapsedTime = 0
+
+	bo := backoff.WithContext(backoff.WithMaxRetries(ebo, uint64(attempts-1)), ctx)
+
+	connect := func() error {
+		err := kc.Connect(ctx, opts)
+		if err != nil && !isTransientConnectErr(err) {
+			return backoff.Permanent(err)
+		}
+
+		return err
+	}
+
+	notify := func(err error, delay time.Duration) {
+		logger.CtxErr(ctx, err).
+			With("retry_delay", delay).
+			Info("retrying repository connection")
+	}
+
+	return clues.Stack(backoff.RetryNotify(connect, bo, notify)).OrNil()
+}
+
+// isTransientConnectErr returns true if the error looks like the storage
+// backend was briefly unreachable, as opposed to a problem with the
+// credentials or configuration.
+func isTransientConnectErr(err error) bool {
+	if err == nil ||
+		errors.Is(err, context.Canceled) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, kopia.ErrorInvalidPassphrase) {
+		return false
+	}
+
+	if errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTimeout || dnsErr.IsTemporary
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	var s3Err minio.ErrorResponse
+	if errors.As(err, &s3Err) {
+		return s3Err.StatusCode >= http.StatusInternalServerError ||
+			s3Err.StatusCode == http.StatusTooManyRequests
+	}
+
+	return false
+}
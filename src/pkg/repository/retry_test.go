@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/kopia"
+	"github.com/alcionai/corso/src/internal/tester"
+	ctrlRepo "github.com/alcionai/corso/src/pkg/control/repository"
+)
+
+// mockConnector returns each of the errs, in order, from consecutive
+// calls to Connect.  Calls beyond the end of errs succeed.
+type mockConnector struct {
+	errs  []error
+	calls int
+}
+
+func (mc *mockConnector) Connect(context.Context, ctrlRepo.Options) error {
+	mc.calls++
+
+	if mc.calls > len(mc.errs) {
+		return nil
+	}
+
+	return mc.errs[mc.calls-1]
+}
+
+type RepositoryRetryUnitSuite struct {
+	tester.Suite
+}
+
+func TestRepositoryRetryUnitSuite(t *testing.T) {
+	suite.Run(t, &RepositoryRetryUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+var (
+	connRefused = &net.OpError{
+		Op:  "dial",
+		Net: "tcp",
+		Err: syscall.ECONNREFUSED,
+	}
+	authFailure = minio.ErrorResponse{
+		Code:       "InvalidAccessKeyId",
+		StatusCode: http.StatusForbidden,
+	}
+)
+
+func (suite *RepositoryRetryUnitSuite) TestIsTransientConnectErr() {
+	table := []struct {
+		name   string
+		err    error
+		expect assert.BoolAssertionFunc
+	}{
+		{
+			name:   "nil",
+			expect: assert.False,
+		},
+		{
+			name:   "connection refused",
+			err:    clues.Wrap(connRefused, "connecting"),
+			expect: assert.True,
+		},
+		{
+			name:   "connection reset",
+			err:    clues.Stack(syscall.ECONNRESET),
+			expect: assert.True,
+		},
+		{
+			name:   "unexpected eof",
+			err:    clues.Stack(io.ErrUnexpectedEOF),
+			expect: assert.True,
+		},
+		{
+			name:   "dns timeout",
+			err:    &net.DNSError{Err: "timeout", IsTimeout: true},
+			expect: assert.True,
+		},
+		{
+			name:   "dns not found",
+			err:    &net.DNSError{Err: "no such host", IsNotFound: true},
+			expect: assert.False,
+		},
+		{
+			name:   "s3 unavailable",
+			err:    clues.Stack(minio.ErrorResponse{Code: "ServiceUnavailable", StatusCode: http.StatusServiceUnavailable}),
+			expect: assert.True,
+		},
+		{
+			name:   "s3 slow down",
+			err:    minio.ErrorResponse{Code: "SlowDown", StatusCode: http.StatusTooManyRequests},
+			expect: assert.True,
+		},
+		{
+			name:   "s3 auth failure",
+			err:    clues.Stack(authFailure),
+			expect: assert.False,
+		},
+		{
+			name:   "invalid passphrase",
+			err:    clues.Stack(kopia.ErrorInvalidPassphrase),
+			expect: assert.False,
+		},
+		{
+			name:   "context canceled",
+			err:    clues.Stack(context.Canceled),
+			expect: assert.False,
+		},
+		{
+			name:   "config error",
+			err:    clues.New("missing bucket"),
+			expect: assert.False,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			test.expect(suite.T(), isTransientConnectErr(test.err))
+		})
+	}
+}
+
+func (suite *RepositoryRetryUnitSuite) TestConnectWithRetry() {
+	retry := RetryConfig{
+		MaxAttempts:  3,
+		InitialDelay: time.Millisecond,
+		MaxDelay:     time.Millisecond,
+	}
+
+	table := []struct {
+		name        string
+		errs        []error
+		retry       RetryConfig
+		expectErr   assert.ErrorAssertionFunc
+		expectCalls int
+	}{
+		{
+			name:        "connects first try",
+			retry:       retry,
+			expectErr:   assert.NoError,
+			expectCalls: 1,
+		},
+		{
+			name:        "recovers from transient errors",
+			errs:        []error{connRefused, connRefused},
+			retry:       retry,
+			expectErr:   assert.NoError,
+			expectCalls: 3,
+		},
+		{
+			name:        "gives up after max attempts",
+			errs:        []error{connRefused, connRefused, connRefused, connRefused},
+			retry:       retry,
+			expectErr:   assert.Error,
+			expectCalls: 3,
+		},
+		{
+			name:        "fatal error is not retried",
+			errs:        []error{authFailure},
+			retry:       retry,
+			expectErr:   assert.Error,
+			expectCalls: 1,
+		},
+		{
+			name:        "fatal error after transient error",
+			errs:        []error{connRefused, authFailure},
+			retry:       retry,
+			expectErr:   assert.Error,
+			expectCalls: 2,
+		},
+		{
+			name:        "no retry config",
+			errs:        []error{connRefused},
+			expectErr:   assert.Error,
+			expectCalls: 1,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			mc := &mockConnector{errs: test.errs}
+
+			err := connectWithRetry(ctx, mc, ctrlRepo.Options{}, test.retry)
+			test.expectErr(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expectCalls, mc.calls, "connect calls")
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/model"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/store"
+)
+
+// Repository versions identify the layout of the models and data stored
+// in a repository.  The current version gets bumped each time a
+// migration is registered.
+const (
+	// repositories created before versions were stored are at version 1.
+	repoVersion1 = 1
+
+	currentRepoVersion = repoVersion1
+)
+
+// repoMigration upgrades a repository by a single version.
+type repoMigration struct {
+	// from is the version being upgraded.  The repository is at version
+	// from+1 once the migration succeeds.
+	from int
+	// name describes the migration in logs and errors.
+	name string
+	// migrate performs the upgrade, such as backfilling fields in stored
+	// models.  Migrations must be idempotent: if the process exits after
+	// migrate succeeds, but before the new version is stored, the
+	// migration runs again on the next connection.
+	migrate func(ctx context.Context, ms store.Storer) error
+}
+
+// repoMigrations holds every registered migration.  To register one,
+// append it here and bump currentRepoVersion to its from+1.
+var repoMigrations = []repoMigration{}
+
+func formatRepoVersion(v int) string {
+	return fmt.Sprintf("v%d", v)
+}
+
+// upgradeRepo brings the repository up to the target version by running
+// each migration between the stored version and the target, in order.
+// The stored version is updated after each migration, so that an
+// interrupted upgrade resumes from the last completed step.  Returns the
+// version of the repository once the upgrade ends.
+func upgradeRepo(
+	ctx context.Context,
+	ms store.Storer,
+	migrations []repoMigration,
+	target int,
+	readOnly bool,
+) (int, error) {
+	rm, err := getRepoModel(ctx, ms)
+	if err != nil {
+		return 0, clues.Wrap(err, "retrieving repo info").WithClues(ctx)
+	}
+
+	// without a stored model, there's nowhere to record the version.
+	if len(rm.ID) == 0 {
+		logger.Ctx(ctx).Info("no repository model found, skipping upgrade")
+		return repoVersion1, nil
+	}
+
+	if err := ms.Get(ctx, model.RepositorySchema, rm.ID, rm); err != nil {
+		return 0, clues.Wrap(err, "retrieving repo version").WithClues(ctx)
+	}
+
+	version := rm.Version
+	if version == 0 {
+		version = repoVersion1
+	}
+
+	ctx = clues.Add(ctx, "repo_version", version, "target_repo_version", target)
+
+	switch {
+	case version > target:
+		return version, clues.Stack(ErrorRepoVersionTooNew).WithClues(ctx)
+	case version == target:
+		return version, nil
+	case readOnly:
+		return version, clues.New("upgrading the repository requires a writable connection").WithClues(ctx)
+	}
+
+	byFrom := make(map[int]repoMigration, len(migrations))
+	for _, m := range migrations {
+		byFrom[m.from] = m
+	}
+
+	for version < target {
+		m, ok := byFrom[version]
+		if !ok {
+			return version, clues.New("no migration from repository version").
+				With("repo_version", version).
+				WithClues(ctx)
+		}
+
+		ictx := clues.Add(ctx, "repo_migration", m.name, "repo_migration_from", version)
+		logger.Ctx(ictx).Info("upgrading repository")
+
+		if err := m.migrate(ictx, ms); err != nil {
+			return version, clues.Wrap(err, "migrating repository").WithClues(ictx)
+		}
+
+		rm.Version = version + 1
+
+		if err := ms.Update(ictx, model.RepositorySchema, rm); err != nil {
+			return version, clues.Wrap(err, "storing repository version").WithClues(ictx)
+		}
+
+		version++
+	}
+
+	return version, nil
+}
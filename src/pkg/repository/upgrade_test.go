@@ -0,0 +1,305 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/kopia/kopia/repo/manifest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/model"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup"
+	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/store"
+)
+
+// ---------------------------------------------------------------------------
+// Mocks
+// ---------------------------------------------------------------------------
+
+var _ store.Storer = &mockUpgradeStore{}
+
+// mockUpgradeStore holds the repository model and backups in memory.
+type mockUpgradeStore struct {
+	repo    *repositoryModel
+	backups map[model.StableID]*backup.Backup
+	updates []int
+}
+
+func (ms *mockUpgradeStore) Delete(context.Context, model.Schema, model.StableID) error {
+	return clues.New("not implemented")
+}
+
+func (ms *mockUpgradeStore) DeleteWithModelStoreIDs(context.Context, ...manifest.ID) error {
+	return clues.New("not implemented")
+}
+
+func (ms *mockUpgradeStore) Get(
+	_ context.Context,
+	s model.Schema,
+	id model.StableID,
+	m model.Model,
+) error {
+	switch s {
+	case model.RepositorySchema:
+		*m.(*repositoryModel) = *ms.repo
+	case model.BackupSchema:
+		*m.(*backup.Backup) = *ms.backups[id]
+	default:
+		return clues.New("unsupported schema")
+	}
+
+	return nil
+}
+
+func (ms *mockUpgradeStore) GetIDsForType(
+	_ context.Context,
+	s model.Schema,
+	_ map[string]string,
+) ([]*model.BaseModel, error) {
+	var bms []*model.BaseModel
+
+	switch s {
+	case model.RepositorySchema:
+		if ms.repo != nil {
+			bm := ms.repo.BaseModel
+			bms = append(bms, &bm)
+		}
+	case model.BackupSchema:
+		for _, b := range ms.backups {
+			bm := b.BaseModel
+			bms = append(bms, &bm)
+		}
+	}
+
+	return bms, nil
+}
+
+func (ms *mockUpgradeStore) GetWithModelStoreID(
+	context.Context,
+	model.Schema,
+	manifest.ID,
+	model.Model,
+) error {
+	return clues.New("not implemented")
+}
+
+func (ms *mockUpgradeStore) Put(context.Context, model.Schema, model.Model) error {
+	return clues.New("not implemented")
+}
+
+func (ms *mockUpgradeStore) Update(_ context.Context, s model.Schema, m model.Model) error {
+	switch s {
+	case model.RepositorySchema:
+		rm := *m.(*repositoryModel)
+		ms.repo = &rm
+		ms.updates = append(ms.updates, rm.Version)
+	case model.BackupSchema:
+		b := *m.(*backup.Backup)
+		ms.backups[b.ID] = &b
+	default:
+		return clues.New("unsupported schema")
+	}
+
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// Unit
+// ---------------------------------------------------------------------------
+
+type RepositoryUpgradeUnitSuite struct {
+	tester.Suite
+}
+
+func TestRepositoryUpgradeUnitSuite(t *testing.T) {
+	suite.Run(t, &RepositoryUpgradeUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+// backfillProtectedResourceID is a sample migration that fills in the
+// protected resource ID of backups that predate the field.
+func backfillProtectedResourceID(ctx context.Context, ms store.Storer) error {
+	bms, err := ms.GetIDsForType(ctx, model.BackupSchema, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, bm := range bms {
+		b := &backup.Backup{}
+
+		if err := ms.Get(ctx, model.BackupSchema, bm.ID, b); err != nil {
+			return err
+		}
+
+		if len(b.ProtectedResourceID) > 0 {
+			continue
+		}
+
+		b.ProtectedResourceID = b.Selector.DiscreteOwner
+
+		if err := ms.Update(ctx, model.BackupSchema, b); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (suite *RepositoryUpgradeUnitSuite) TestUpgradeRepo() {
+	var (
+		noop = repoMigration{
+			from:    1,
+			name:    "noop",
+			migrate: func(context.Context, store.Storer) error { return nil },
+		}
+		backfill = repoMigration{
+			from:    2,
+			name:    "backfill protected resource id",
+			migrate: backfillProtectedResourceID,
+		}
+		failing = repoMigration{
+			from:    2,
+			name:    "failing",
+			migrate: func(context.Context, store.Storer) error { return assert.AnError },
+		}
+	)
+
+	table := []struct {
+		name          string
+		noRepoModel   bool
+		storedVersion int
+		migrations    []repoMigration
+		target        int
+		readOnly      bool
+		expectErr     assert.ErrorAssertionFunc
+		expectVersion int
+		expectUpdates []int
+		expectBackup  string
+	}{
+		{
+			name:          "current version",
+			storedVersion: 1,
+			target:        1,
+			expectErr:     assert.NoError,
+			expectVersion: 1,
+		},
+		{
+			name:          "legacy repo without a stored version",
+			target:        1,
+			expectErr:     assert.NoError,
+			expectVersion: 1,
+		},
+		{
+			name:          "no repository model",
+			noRepoModel:   true,
+			migrations:    []repoMigration{noop},
+			target:        2,
+			expectErr:     assert.NoError,
+			expectVersion: 1,
+		},
+		{
+			name:          "noop migration",
+			storedVersion: 1,
+			migrations:    []repoMigration{noop},
+			target:        2,
+			expectErr:     assert.NoError,
+			expectVersion: 2,
+			expectUpdates: []int{2},
+		},
+		{
+			name:          "sample migration",
+			storedVersion: 1,
+			migrations:    []repoMigration{backfill, noop},
+			target:        3,
+			expectErr:     assert.NoError,
+			expectVersion: 3,
+			expectUpdates: []int{2, 3},
+			expectBackup:  "user",
+		},
+		{
+			name:          "resumes from the stored version",
+			storedVersion: 2,
+			migrations:    []repoMigration{noop, backfill},
+			target:        3,
+			expectErr:     assert.NoError,
+			expectVersion: 3,
+			expectUpdates: []int{3},
+			expectBackup:  "user",
+		},
+		{
+			name:          "failed migration keeps completed steps",
+			storedVersion: 1,
+			migrations:    []repoMigration{noop, failing},
+			target:        3,
+			expectErr:     assert.Error,
+			expectVersion: 2,
+			expectUpdates: []int{2},
+		},
+		{
+			name:          "missing migration",
+			storedVersion: 1,
+			migrations:    []repoMigration{backfill},
+			target:        3,
+			expectErr:     assert.Error,
+			expectVersion: 1,
+		},
+		{
+			name:          "read only",
+			storedVersion: 1,
+			migrations:    []repoMigration{noop},
+			target:        2,
+			readOnly:      true,
+			expectErr:     assert.Error,
+			expectVersion: 1,
+		},
+		{
+			name:          "newer repository",
+			storedVersion: 3,
+			target:        2,
+			expectErr: func(t assert.TestingT, err error, msgAndArgs ...any) bool {
+				return assert.ErrorIs(t, err, ErrorRepoVersionTooNew, msgAndArgs...)
+			},
+			expectVersion: 3,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			ms := &mockUpgradeStore{
+				backups: map[model.StableID]*backup.Backup{
+					"bid": {
+						BaseModel: model.BaseModel{ID: "bid"},
+						Selector:  selectors.NewExchangeBackup([]string{"user"}).Selector,
+					},
+				},
+			}
+
+			if !test.noRepoModel {
+				ms.repo = &repositoryModel{
+					BaseModel: model.BaseModel{ID: "rid"},
+					Version:   test.storedVersion,
+				}
+			}
+
+			version, err := upgradeRepo(ctx, ms, test.migrations, test.target, test.readOnly)
+			test.expectErr(t, err, clues.ToCore(err))
+
+			assert.Equal(t, test.expectVersion, version)
+			assert.Equal(t, test.expectUpdates, ms.updates)
+			assert.Equal(t, test.expectBackup, ms.backups["bid"].ProtectedResourceID)
+
+			if len(test.expectUpdates) > 0 {
+				require.NotNil(t, ms.repo)
+				assert.Equal(t, test.expectVersion, ms.repo.Version, "stored version")
+			}
+		})
+	}
+}
@@ -0,0 +1,330 @@
+// Package schedule parses cron expressions and runs jobs, such as backups,
+// whenever their schedules fire.
+package schedule
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alcionai/clues"
+)
+
+// maxSearchYears bounds the search for the next fire time.  Every valid
+// schedule fires at least once within this window, since leap days
+// recur at most eight years apart.
+const maxSearchYears = 9
+
+type bounds struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteBounds = bounds{name: "minute", min: 0, max: 59}
+	hourBounds   = bounds{name: "hour", min: 0, max: 23}
+	domBounds    = bounds{name: "day of month", min: 1, max: 31}
+	monthBounds  = bounds{
+		name: "month",
+		min:  1,
+		max:  12,
+		names: map[string]int{
+			"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+			"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+		},
+	}
+	// both 0 and 7 are sunday.
+	dowBounds = bounds{
+		name: "day of week",
+		min:  0,
+		max:  7,
+		names: map[string]int{
+			"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+		},
+	}
+)
+
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Schedule is a parsed cron expression.
+type Schedule struct {
+	expr string
+
+	minute, hour, dom, month, dow uint64
+
+	// a field that starts with '*' is unrestricted, which decides
+	// how the day of month and day of week fields combine.
+	hourStar, domStar, dowStar bool
+}
+
+// Parse reads a standard five-field cron expression: minute, hour, day of
+// month, month, and day of week.  Fields accept '*', values, ranges (1-5),
+// steps (*/15, 1-30/5, 10/20), and comma-separated lists of each.  Months
+// and days of week also accept three-letter names (jan, mon).  The macros
+// @yearly, @annually, @monthly, @weekly, @daily, @midnight, and @hourly
+// are also accepted.
+//
+// As in standard cron, if both the day of month and day of week are
+// restricted, the schedule fires on days matching either one.
+func Parse(expr string) (Schedule, error) {
+	s := Schedule{expr: expr}
+
+	fields := strings.Fields(strings.ToLower(expr))
+	if len(fields) == 1 && strings.HasPrefix(fields[0], "@") {
+		m, ok := macros[fields[0]]
+		if !ok {
+			return Schedule{}, clues.New("unknown schedule macro").With("macro", fields[0])
+		}
+
+		fields = strings.Fields(m)
+	}
+
+	if len(fields) != 5 {
+		return Schedule{}, clues.New("cron expressions require five fields").
+			With("expression", expr, "field_count", len(fields))
+	}
+
+	var err error
+
+	if s.minute, err = parseField(fields[0], minuteBounds); err != nil {
+		return Schedule{}, err
+	}
+
+	if s.hour, err = parseField(fields[1], hourBounds); err != nil {
+		return Schedule{}, err
+	}
+
+	if s.dom, err = parseField(fields[2], domBounds); err != nil {
+		return Schedule{}, err
+	}
+
+	if s.month, err = parseField(fields[3], monthBounds); err != nil {
+		return Schedule{}, err
+	}
+
+	if s.dow, err = parseField(fields[4], dowBounds); err != nil {
+		return Schedule{}, err
+	}
+
+	// fold sunday-as-7 into sunday-as-0.
+	if s.dow&(1<<7) != 0 {
+		s.dow = s.dow&^(1<<7) | 1
+	}
+
+	s.hourStar = strings.HasPrefix(fields[1], "*")
+	s.domStar = strings.HasPrefix(fields[2], "*")
+	s.dowStar = strings.HasPrefix(fields[4], "*")
+
+	if !s.canFire() {
+		return Schedule{}, clues.New("schedule never fires").With("expression", expr)
+	}
+
+	return s, nil
+}
+
+// String returns the expression the schedule was parsed from.
+func (s Schedule) String() string {
+	return s.expr
+}
+
+func parseField(field string, b bounds) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(field, ",") {
+		pbits, err := parsePart(part, b)
+		if err != nil {
+			return 0, clues.Stack(err).With("field", b.name, "value", field)
+		}
+
+		bits |= pbits
+	}
+
+	return bits, nil
+}
+
+// parsePart reads a single, comma-delimited element of a field.
+func parsePart(part string, b bounds) (uint64, error) {
+	rng, stepStr, hasStep := strings.Cut(part, "/")
+
+	step := 1
+
+	if hasStep {
+		n, err := strconv.Atoi(stepStr)
+		if err != nil || n < 1 {
+			return 0, clues.New("step must be a positive number")
+		}
+
+		step = n
+	}
+
+	var lo, hi int
+
+	switch {
+	case rng == "*":
+		lo, hi = b.min, b.max
+
+	case strings.Contains(rng, "-"):
+		loStr, hiStr, _ := strings.Cut(rng, "-")
+
+		var err error
+
+		if lo, err = parseValue(loStr, b); err != nil {
+			return 0, err
+		}
+
+		if hi, err = parseValue(hiStr, b); err != nil {
+			return 0, err
+		}
+
+		if lo > hi {
+			return 0, clues.New("range start is after range end")
+		}
+
+	default:
+		v, err := parseValue(rng, b)
+		if err != nil {
+			return 0, err
+		}
+
+		lo, hi = v, v
+
+		// "10/20" is shorthand for "10-max/20".
+		if hasStep {
+			hi = b.max
+		}
+	}
+
+	var bits uint64
+
+	for i := lo; i <= hi; i += step {
+		bits |= 1 << uint(i)
+	}
+
+	return bits, nil
+}
+
+func parseValue(v string, b bounds) (int, error) {
+	if n, ok := b.names[v]; ok {
+		return n, nil
+	}
+
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, clues.New("not a number").With("value", v)
+	}
+
+	if n < b.min || n > b.max {
+		return 0, clues.New("value out of range").With("value", n, "min", b.min, "max", b.max)
+	}
+
+	return n, nil
+}
+
+// daysInMonth holds the longest length of each month, including leap years.
+var daysInMonth = [13]int{0, 31, 29, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}
+
+// canFire is false if the schedule only selects days that don't exist,
+// such as february 30th.
+func (s Schedule) canFire() bool {
+	// any restriction on the day of week can always be met.
+	if !s.dowStar {
+		return true
+	}
+
+	for m := 1; m <= 12; m++ {
+		if !has(s.month, m) {
+			continue
+		}
+
+		for d := 1; d <= daysInMonth[m]; d++ {
+			if has(s.dom, d) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func has(bits uint64, i int) bool {
+	return bits&(1<<uint(i)) != 0
+}
+
+func (s Schedule) dayMatches(t time.Time) bool {
+	var (
+		domMatch = has(s.dom, t.Day())
+		dowMatch = has(s.dow, int(t.Weekday()))
+	)
+
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+
+	return domMatch || dowMatch
+}
+
+// Next returns the first time after t at which the schedule fires,
+// evaluated in t's location.
+//
+// Daylight saving transitions follow the wall clock.  Times skipped
+// when clocks move forward never fire.  When clocks move back, times in
+// the repeated hour fire once, unless the hour is unrestricted, in which
+// case the schedule fires in both the first and the repeated hour.
+func (s Schedule) Next(t time.Time) time.Time {
+	var (
+		loc   = t.Location()
+		limit = t.AddDate(maxSearchYears, 0, 0)
+	)
+
+	t = t.Truncate(time.Minute).Add(time.Minute)
+
+	for t.Before(limit) {
+		if !has(s.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		if !has(s.hour, t.Hour()) {
+			t = t.Add(time.Duration(60-t.Minute()) * time.Minute)
+			continue
+		}
+
+		if !has(s.minute, t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		if !s.hourStar && isRepeatedWallTime(t) {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+// isRepeatedWallTime is true if the wall clock showed the same time an
+// hour earlier, which happens when clocks move back.
+func isRepeatedWallTime(t time.Time) bool {
+	prev := t.Add(-time.Hour)
+
+	return prev.Day() == t.Day() &&
+		prev.Hour() == t.Hour() &&
+		prev.Minute() == t.Minute()
+}
@@ -0,0 +1,435 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+type CronUnitSuite struct {
+	tester.Suite
+}
+
+func TestCronUnitSuite(t *testing.T) {
+	suite.Run(t, &CronUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func bitsOf(is ...int) uint64 {
+	var bits uint64
+
+	for _, i := range is {
+		bits |= 1 << uint(i)
+	}
+
+	return bits
+}
+
+func bitRange(lo, hi, step int) uint64 {
+	var bits uint64
+
+	for i := lo; i <= hi; i += step {
+		bits |= 1 << uint(i)
+	}
+
+	return bits
+}
+
+func (suite *CronUnitSuite) TestParse() {
+	table := []struct {
+		name      string
+		expr      string
+		expectErr assert.ErrorAssertionFunc
+		expect    Schedule
+	}{
+		{
+			name:      "every minute",
+			expr:      "* * * * *",
+			expectErr: assert.NoError,
+			expect: Schedule{
+				minute:   bitRange(0, 59, 1),
+				hour:     bitRange(0, 23, 1),
+				dom:      bitRange(1, 31, 1),
+				month:    bitRange(1, 12, 1),
+				dow:      bitRange(0, 6, 1),
+				hourStar: true,
+				domStar:  true,
+				dowStar:  true,
+			},
+		},
+		{
+			name:      "fixed time",
+			expr:      "0 2 * * *",
+			expectErr: assert.NoError,
+			expect: Schedule{
+				minute:  bitsOf(0),
+				hour:    bitsOf(2),
+				dom:     bitRange(1, 31, 1),
+				month:   bitRange(1, 12, 1),
+				dow:     bitRange(0, 6, 1),
+				domStar: true,
+				dowStar: true,
+			},
+		},
+		{
+			name:      "ranges, steps, and lists",
+			expr:      "*/15 9-17/2 1,15,20-22 * 1-5",
+			expectErr: assert.NoError,
+			expect: Schedule{
+				minute: bitsOf(0, 15, 30, 45),
+				hour:   bitsOf(9, 11, 13, 15, 17),
+				dom:    bitsOf(1, 15, 20, 21, 22),
+				month:  bitRange(1, 12, 1),
+				dow:    bitRange(1, 5, 1),
+			},
+		},
+		{
+			name:      "step from a value",
+			expr:      "10/20 * * * *",
+			expectErr: assert.NoError,
+			expect: Schedule{
+				minute:   bitsOf(10, 30, 50),
+				hour:     bitRange(0, 23, 1),
+				dom:      bitRange(1, 31, 1),
+				month:    bitRange(1, 12, 1),
+				dow:      bitRange(0, 6, 1),
+				hourStar: true,
+				domStar:  true,
+				dowStar:  true,
+			},
+		},
+		{
+			name:      "names",
+			expr:      "0 0 * JAN-mar,Dec sun,FRI",
+			expectErr: assert.NoError,
+			expect: Schedule{
+				minute:  bitsOf(0),
+				hour:    bitsOf(0),
+				dom:     bitRange(1, 31, 1),
+				month:   bitsOf(1, 2, 3, 12),
+				dow:     bitsOf(0, 5),
+				domStar: true,
+			},
+		},
+		{
+			name:      "sunday as seven",
+			expr:      "0 0 * * 5-7",
+			expectErr: assert.NoError,
+			expect: Schedule{
+				minute:  bitsOf(0),
+				hour:    bitsOf(0),
+				dom:     bitRange(1, 31, 1),
+				month:   bitRange(1, 12, 1),
+				dow:     bitsOf(0, 5, 6),
+				domStar: true,
+			},
+		},
+		{
+			name:      "macro",
+			expr:      "@weekly",
+			expectErr: assert.NoError,
+			expect: Schedule{
+				minute:  bitsOf(0),
+				hour:    bitsOf(0),
+				dom:     bitRange(1, 31, 1),
+				month:   bitRange(1, 12, 1),
+				dow:     bitsOf(0),
+				domStar: true,
+			},
+		},
+		{
+			name:      "leap day",
+			expr:      "0 0 29 2 *",
+			expectErr: assert.NoError,
+			expect: Schedule{
+				minute:  bitsOf(0),
+				hour:    bitsOf(0),
+				dom:     bitsOf(29),
+				month:   bitsOf(2),
+				dow:     bitRange(0, 6, 1),
+				dowStar: true,
+			},
+		},
+		{
+			name:      "too few fields",
+			expr:      "0 2 * *",
+			expectErr: assert.Error,
+		},
+		{
+			name:      "too many fields",
+			expr:      "0 0 2 * * *",
+			expectErr: assert.Error,
+		},
+		{
+			name:      "empty",
+			expr:      "",
+			expectErr: assert.Error,
+		},
+		{
+			name:      "unknown macro",
+			expr:      "@fortnightly",
+			expectErr: assert.Error,
+		},
+		{
+			name:      "minute out of range",
+			expr:      "60 * * * *",
+			expectErr: assert.Error,
+		},
+		{
+			name:      "day of month zero",
+			expr:      "0 0 0 * *",
+			expectErr: assert.Error,
+		},
+		{
+			name:      "day of week out of range",
+			expr:      "0 0 * * 8",
+			expectErr: assert.Error,
+		},
+		{
+			name:      "reversed range",
+			expr:      "0 17-9 * * *",
+			expectErr: assert.Error,
+		},
+		{
+			name:      "zero step",
+			expr:      "*/0 * * * *",
+			expectErr: assert.Error,
+		},
+		{
+			name:      "negative step",
+			expr:      "*/-5 * * * *",
+			expectErr: assert.Error,
+		},
+		{
+			name:      "not a number",
+			expr:      "0 two * * *",
+			expectErr: assert.Error,
+		},
+		{
+			name:      "empty list element",
+			expr:      "0,,30 * * * *",
+			expectErr: assert.Error,
+		},
+		{
+			name:      "day that never exists",
+			expr:      "0 0 30 2 *",
+			expectErr: assert.Error,
+		},
+		{
+			name:      "day that never exists, with a day of week",
+			expr:      "0 0 30 2 mon",
+			expectErr: assert.NoError,
+			expect: Schedule{
+				minute: bitsOf(0),
+				hour:   bitsOf(0),
+				dom:    bitsOf(30),
+				month:  bitsOf(2),
+				dow:    bitsOf(1),
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			s, err := Parse(test.expr)
+			test.expectErr(t, err, clues.ToCore(err))
+
+			if err != nil {
+				return
+			}
+
+			test.expect.expr = test.expr
+			assert.Equal(t, test.expect, s)
+		})
+	}
+}
+
+func (suite *CronUnitSuite) TestNext() {
+	nyc, err := time.LoadLocation("America/New_York")
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	at := func(loc *time.Location, y int, mo time.Month, d, h, mi int) time.Time {
+		return time.Date(y, mo, d, h, mi, 0, 0, loc)
+	}
+
+	table := []struct {
+		name   string
+		expr   string
+		after  time.Time
+		expect []time.Time
+	}{
+		{
+			name:  "every minute",
+			expr:  "* * * * *",
+			after: at(time.UTC, 2023, 6, 1, 10, 0).Add(30 * time.Second),
+			expect: []time.Time{
+				at(time.UTC, 2023, 6, 1, 10, 1),
+				at(time.UTC, 2023, 6, 1, 10, 2),
+			},
+		},
+		{
+			name:  "fire time is exclusive",
+			expr:  "0 2 * * *",
+			after: at(time.UTC, 2023, 6, 1, 2, 0),
+			expect: []time.Time{
+				at(time.UTC, 2023, 6, 2, 2, 0),
+				at(time.UTC, 2023, 6, 3, 2, 0),
+			},
+		},
+		{
+			name:  "steps roll over the hour",
+			expr:  "*/20 * * * *",
+			after: at(time.UTC, 2023, 6, 1, 23, 41),
+			expect: []time.Time{
+				at(time.UTC, 2023, 6, 2, 0, 0),
+				at(time.UTC, 2023, 6, 2, 0, 20),
+			},
+		},
+		{
+			name:  "end of year",
+			expr:  "30 23 31 12 *",
+			after: at(time.UTC, 2023, 12, 31, 23, 30),
+			expect: []time.Time{
+				at(time.UTC, 2024, 12, 31, 23, 30),
+			},
+		},
+		{
+			name:  "short months are skipped",
+			expr:  "0 0 31 * *",
+			after: at(time.UTC, 2023, 1, 31, 0, 0),
+			expect: []time.Time{
+				at(time.UTC, 2023, 3, 31, 0, 0),
+				at(time.UTC, 2023, 5, 31, 0, 0),
+			},
+		},
+		{
+			name:  "leap day",
+			expr:  "0 0 29 2 *",
+			after: at(time.UTC, 2023, 3, 1, 0, 0),
+			expect: []time.Time{
+				at(time.UTC, 2024, 2, 29, 0, 0),
+				at(time.UTC, 2028, 2, 29, 0, 0),
+			},
+		},
+		{
+			name:  "leap day across a skipped century",
+			expr:  "0 0 29 2 *",
+			after: at(time.UTC, 2096, 3, 1, 0, 0),
+			expect: []time.Time{
+				at(time.UTC, 2104, 2, 29, 0, 0),
+			},
+		},
+		{
+			name: "weekdays",
+			expr: "0 9 * * mon-fri",
+			// a friday
+			after: at(time.UTC, 2023, 6, 2, 9, 0),
+			expect: []time.Time{
+				at(time.UTC, 2023, 6, 5, 9, 0),
+				at(time.UTC, 2023, 6, 6, 9, 0),
+			},
+		},
+		{
+			name: "day of month or day of week",
+			// the 13th, or any friday
+			expr:  "0 0 13 * 5",
+			after: at(time.UTC, 2023, 6, 10, 0, 0),
+			expect: []time.Time{
+				at(time.UTC, 2023, 6, 13, 0, 0),
+				at(time.UTC, 2023, 6, 16, 0, 0),
+				at(time.UTC, 2023, 6, 23, 0, 0),
+			},
+		},
+		{
+			name: "day of month and stepped day of week",
+			// a starred field combines the days with "and"
+			expr:  "0 0 1-7 * */7",
+			after: at(time.UTC, 2023, 6, 1, 0, 0),
+			expect: []time.Time{
+				// the first sunday of the month
+				at(time.UTC, 2023, 6, 4, 0, 0),
+				at(time.UTC, 2023, 7, 2, 0, 0),
+			},
+		},
+		{
+			name:  "local time",
+			expr:  "0 2 * * *",
+			after: at(nyc, 2023, 6, 1, 12, 0),
+			expect: []time.Time{
+				at(nyc, 2023, 6, 2, 2, 0),
+			},
+		},
+		{
+			name: "dst starts: skipped time never fires",
+			expr: "30 2 * * *",
+			// clocks move from 2:00 to 3:00 on 2023-03-12
+			after: at(nyc, 2023, 3, 11, 3, 0),
+			expect: []time.Time{
+				at(nyc, 2023, 3, 13, 2, 30),
+			},
+		},
+		{
+			name:  "dst starts: later times are unaffected",
+			expr:  "0 3 * * *",
+			after: at(nyc, 2023, 3, 11, 3, 0),
+			expect: []time.Time{
+				at(nyc, 2023, 3, 12, 3, 0),
+				at(nyc, 2023, 3, 13, 3, 0),
+			},
+		},
+		{
+			name:  "dst starts: hourly",
+			expr:  "30 * * * *",
+			after: at(nyc, 2023, 3, 12, 1, 0),
+			expect: []time.Time{
+				at(nyc, 2023, 3, 12, 1, 30),
+				at(nyc, 2023, 3, 12, 3, 30),
+			},
+		},
+		{
+			name: "dst ends: repeated time fires once",
+			expr: "30 1 * * *",
+			// clocks move from 2:00 back to 1:00 on 2023-11-05
+			after: at(nyc, 2023, 11, 5, 0, 0),
+			expect: []time.Time{
+				time.Date(2023, 11, 5, 5, 30, 0, 0, time.UTC).In(nyc),
+				at(nyc, 2023, 11, 6, 1, 30),
+			},
+		},
+		{
+			name:  "dst ends: hourly fires in both hours",
+			expr:  "30 * * * *",
+			after: at(nyc, 2023, 11, 5, 0, 45),
+			expect: []time.Time{
+				time.Date(2023, 11, 5, 5, 30, 0, 0, time.UTC).In(nyc),
+				time.Date(2023, 11, 5, 6, 30, 0, 0, time.UTC).In(nyc),
+				time.Date(2023, 11, 5, 7, 30, 0, 0, time.UTC).In(nyc),
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			s, err := Parse(test.expr)
+			require.NoError(t, err, clues.ToCore(err))
+
+			next := test.after
+
+			for i, expect := range test.expect {
+				next = s.Next(next)
+				assert.True(
+					t,
+					expect.Equal(next),
+					"fire %d: expected %s, got %s", i, expect, next)
+				assert.Equal(t, test.after.Location(), next.Location(), "location")
+			}
+		})
+	}
+}
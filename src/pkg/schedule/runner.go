@@ -0,0 +1,164 @@
+package schedule
+
+import (
+	"context"
+	"time"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/pkg/logger"
+)
+
+// Job is a task the Runner triggers whenever its schedule fires.
+type Job struct {
+	// Name identifies the job in the persisted state.  It must be unique
+	// within the runner and stable across runs.
+	Name     string
+	Schedule Schedule
+	Run      func(ctx context.Context) error
+}
+
+// Runner triggers jobs at the times their schedules fire.
+type Runner struct {
+	jobs  []Job
+	state StateStore
+
+	// replaceable for testing.
+	now   func() time.Time
+	after func(time.Duration) <-chan time.Time
+}
+
+// NewRunner produces a Runner for the jobs, persisting their last run
+// times to the state store.
+func NewRunner(state StateStore, jobs ...Job) (*Runner, error) {
+	if state == nil {
+		return nil, clues.New("missing schedule state store")
+	}
+
+	if len(jobs) == 0 {
+		return nil, clues.New("no scheduled jobs provided")
+	}
+
+	names := map[string]struct{}{}
+
+	for _, j := range jobs {
+		if len(j.Name) == 0 {
+			return nil, clues.New("scheduled job is missing a name")
+		}
+
+		if len(j.Schedule.String()) == 0 {
+			return nil, clues.New("scheduled job is missing a schedule").With("job", j.Name)
+		}
+
+		if j.Run == nil {
+			return nil, clues.New("scheduled job is missing a run func").With("job", j.Name)
+		}
+
+		if _, ok := names[j.Name]; ok {
+			return nil, clues.New("duplicate scheduled job name").With("job", j.Name)
+		}
+
+		names[j.Name] = struct{}{}
+	}
+
+	return &Runner{
+		jobs:  jobs,
+		state: state,
+		now:   time.Now,
+		after: time.After,
+	}, nil
+}
+
+// Run blocks until the ctx is done, triggering each job when its schedule
+// fires.  Jobs that missed a fire time while no runner was active, going
+// by the persisted state, run once as soon as the runner starts.  A job's
+// failure is logged, and does not stop the runner.
+func (r *Runner) Run(ctx context.Context) error {
+	st, err := r.state.Load(ctx)
+	if err != nil {
+		return clues.Stack(err)
+	}
+
+	var (
+		now  = r.now()
+		next = make([]time.Time, len(r.jobs))
+	)
+
+	for i, j := range r.jobs {
+		next[i] = firstFire(j, st, now)
+
+		logger.Ctx(ctx).Infow(
+			"scheduled job",
+			"job", j.Name,
+			"schedule", j.Schedule.String(),
+			"next_run", next[i])
+	}
+
+	for ctx.Err() == nil {
+		soonest := next[0]
+
+		for _, n := range next[1:] {
+			if n.Before(soonest) {
+				soonest = n
+			}
+		}
+
+		if wait := soonest.Sub(r.now()); wait > 0 {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-r.after(wait):
+			}
+		}
+
+		for i, j := range r.jobs {
+			if ctx.Err() != nil {
+				break
+			}
+
+			started := r.now()
+
+			if next[i].After(started) {
+				continue
+			}
+
+			r.runJob(ctx, j)
+
+			st.LastRuns[j.Name] = started
+			next[i] = j.Schedule.Next(started)
+
+			// a failed save only risks re-running the job after a restart.
+			if err := r.state.Save(ctx, st); err != nil {
+				logger.CtxErr(ctx, err).Error("saving schedule state")
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *Runner) runJob(ctx context.Context, j Job) {
+	ctx = clues.Add(ctx, "scheduled_job", j.Name)
+
+	logger.Ctx(ctx).Info("running scheduled job")
+
+	if err := j.Run(ctx); err != nil {
+		logger.CtxErr(ctx, err).Error("running scheduled job")
+	}
+}
+
+// firstFire returns the first time the job should run.  If the job
+// missed a fire time since it last ran, it runs immediately.
+func firstFire(j Job, st State, now time.Time) time.Time {
+	last, ok := st.LastRuns[j.Name]
+	if !ok {
+		return j.Schedule.Next(now)
+	}
+
+	missed := j.Schedule.Next(last.In(now.Location()))
+	if !missed.After(now) {
+		return now
+	}
+
+	return missed
+}
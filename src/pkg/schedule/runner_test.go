@@ -0,0 +1,320 @@
+package schedule
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+// ---------------------------------------------------------------------------
+// mocks
+// ---------------------------------------------------------------------------
+
+type mockState struct {
+	st    State
+	saves int
+}
+
+func (ms *mockState) Load(context.Context) (State, error) {
+	st := State{LastRuns: map[string]time.Time{}}
+
+	for k, v := range ms.st.LastRuns {
+		st.LastRuns[k] = v
+	}
+
+	return st, nil
+}
+
+func (ms *mockState) Save(_ context.Context, st State) error {
+	ms.st = st
+	ms.saves++
+
+	return nil
+}
+
+// fakeClock moves forward only when the runner waits.
+type fakeClock struct {
+	now time.Time
+}
+
+func (fc *fakeClock) Now() time.Time {
+	return fc.now
+}
+
+func (fc *fakeClock) After(d time.Duration) <-chan time.Time {
+	fc.now = fc.now.Add(d)
+
+	ch := make(chan time.Time, 1)
+	ch <- fc.now
+
+	return ch
+}
+
+func mustParse(t *testing.T, expr string) Schedule {
+	s, err := Parse(expr)
+	require.NoError(t, err, clues.ToCore(err))
+
+	return s
+}
+
+func newTestRunner(
+	t *testing.T,
+	clock *fakeClock,
+	state StateStore,
+	jobs ...Job,
+) *Runner {
+	r, err := NewRunner(state, jobs...)
+	require.NoError(t, err, clues.ToCore(err))
+
+	r.now = clock.Now
+	r.after = clock.After
+
+	return r
+}
+
+// ---------------------------------------------------------------------------
+// tests
+// ---------------------------------------------------------------------------
+
+type RunnerUnitSuite struct {
+	tester.Suite
+}
+
+func TestRunnerUnitSuite(t *testing.T) {
+	suite.Run(t, &RunnerUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *RunnerUnitSuite) TestNewRunner() {
+	var (
+		s   = mustParse(suite.T(), "0 2 * * *")
+		run = func(context.Context) error { return nil }
+	)
+
+	table := []struct {
+		name      string
+		state     StateStore
+		jobs      []Job
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "valid",
+			state:     &mockState{},
+			jobs:      []Job{{Name: "a", Schedule: s, Run: run}, {Name: "b", Schedule: s, Run: run}},
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "no state",
+			jobs:      []Job{{Name: "a", Schedule: s, Run: run}},
+			expectErr: assert.Error,
+		},
+		{
+			name:      "no jobs",
+			state:     &mockState{},
+			expectErr: assert.Error,
+		},
+		{
+			name:      "missing name",
+			state:     &mockState{},
+			jobs:      []Job{{Schedule: s, Run: run}},
+			expectErr: assert.Error,
+		},
+		{
+			name:      "missing schedule",
+			state:     &mockState{},
+			jobs:      []Job{{Name: "a", Run: run}},
+			expectErr: assert.Error,
+		},
+		{
+			name:      "missing run func",
+			state:     &mockState{},
+			jobs:      []Job{{Name: "a", Schedule: s}},
+			expectErr: assert.Error,
+		},
+		{
+			name:      "duplicate names",
+			state:     &mockState{},
+			jobs:      []Job{{Name: "a", Schedule: s, Run: run}, {Name: "a", Schedule: s, Run: run}},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			_, err := NewRunner(test.state, test.jobs...)
+			test.expectErr(t, err, clues.ToCore(err))
+		})
+	}
+}
+
+func (suite *RunnerUnitSuite) TestRun() {
+	start := time.Date(2023, 6, 1, 10, 30, 0, 0, time.UTC)
+
+	table := []struct {
+		name      string
+		lastRuns  map[string]time.Time
+		expectRun []time.Time
+	}{
+		{
+			name: "never run",
+			expectRun: []time.Time{
+				time.Date(2023, 6, 1, 11, 0, 0, 0, time.UTC),
+				time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC),
+				time.Date(2023, 6, 1, 13, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "ran this hour",
+			lastRuns: map[string]time.Time{
+				"job": time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC),
+			},
+			expectRun: []time.Time{
+				time.Date(2023, 6, 1, 11, 0, 0, 0, time.UTC),
+				time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC),
+				time.Date(2023, 6, 1, 13, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "missed a run",
+			lastRuns: map[string]time.Time{
+				"job": time.Date(2023, 5, 30, 10, 0, 0, 0, time.UTC),
+			},
+			expectRun: []time.Time{
+				start,
+				time.Date(2023, 6, 1, 11, 0, 0, 0, time.UTC),
+				time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+
+			var (
+				clock = &fakeClock{now: start}
+				state = &mockState{st: State{LastRuns: test.lastRuns}}
+				ran   []time.Time
+			)
+
+			job := Job{
+				Name:     "job",
+				Schedule: mustParse(t, "0 * * * *"),
+				Run: func(context.Context) error {
+					ran = append(ran, clock.Now())
+
+					if len(ran) == len(test.expectRun) {
+						cancel()
+					}
+
+					return nil
+				},
+			}
+
+			r := newTestRunner(t, clock, state, job)
+
+			err := r.Run(ctx)
+			require.NoError(t, err, clues.ToCore(err))
+
+			assert.Equal(t, test.expectRun, ran, "run times")
+			assert.Equal(t, len(test.expectRun), state.saves, "state saves")
+			assert.Equal(t, test.expectRun[len(test.expectRun)-1], state.st.LastRuns["job"], "persisted last run")
+		})
+	}
+}
+
+func (suite *RunnerUnitSuite) TestRun_failingJobKeepsRunning() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		clock    = &fakeClock{now: time.Date(2023, 6, 1, 10, 30, 0, 0, time.UTC)}
+		state    = &mockState{}
+		failures int
+		runs     int
+	)
+
+	failing := Job{
+		Name:     "failing",
+		Schedule: mustParse(t, "0 * * * *"),
+		Run: func(context.Context) error {
+			failures++
+			return assert.AnError
+		},
+	}
+
+	working := Job{
+		Name:     "working",
+		Schedule: mustParse(t, "0 */2 * * *"),
+		Run: func(context.Context) error {
+			runs++
+
+			if runs == 2 {
+				cancel()
+			}
+
+			return nil
+		},
+	}
+
+	r := newTestRunner(t, clock, state, failing, working)
+
+	err := r.Run(ctx)
+	require.NoError(t, err, clues.ToCore(err))
+
+	// the working job runs at 12:00 and 14:00, while the failing
+	// job runs at 11:00, 12:00, 13:00, and 14:00.
+	assert.Equal(t, 4, failures, "failing job runs")
+	assert.Equal(t, 2, runs, "working job runs")
+	assert.Equal(t, clock.Now(), state.st.LastRuns["failing"], "failing job last run")
+	assert.Equal(t, clock.Now(), state.st.LastRuns["working"], "working job last run")
+}
+
+func (suite *RunnerUnitSuite) TestFileState() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	fp := filepath.Join(t.TempDir(), "nested", "schedule.json")
+	fst := NewFileState(fp)
+
+	st, err := fst.Load(ctx)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Empty(t, st.LastRuns, "state from missing file")
+
+	last := time.Date(2023, 6, 1, 2, 0, 0, 0, time.UTC)
+	st.LastRuns["job"] = last
+
+	err = fst.Save(ctx, st)
+	require.NoError(t, err, clues.ToCore(err))
+
+	st, err = NewFileState(fp).Load(ctx)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.True(t, last.Equal(st.LastRuns["job"]), "persisted last run")
+
+	err = os.WriteFile(fp, []byte("not json"), 0o600)
+	require.NoError(t, err, clues.ToCore(err))
+
+	_, err = fst.Load(ctx)
+	assert.Error(t, err, clues.ToCore(err))
+}
@@ -0,0 +1,85 @@
+package schedule
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/alcionai/clues"
+)
+
+// State records when each job last ran, so that a restarted runner
+// picks up where the previous one left off.
+type State struct {
+	LastRuns map[string]time.Time `json:"lastRuns"`
+}
+
+// StateStore persists the runner's State.
+type StateStore interface {
+	Load(ctx context.Context) (State, error)
+	Save(ctx context.Context, st State) error
+}
+
+var _ StateStore = &fileState{}
+
+type fileState struct {
+	path string
+}
+
+// NewFileState produces a StateStore that keeps the state as json in the
+// file at the given path.  A missing file holds an empty state.
+func NewFileState(path string) StateStore {
+	return &fileState{path: path}
+}
+
+func (fst fileState) Load(ctx context.Context) (State, error) {
+	st := State{LastRuns: map[string]time.Time{}}
+
+	bs, err := os.ReadFile(fst.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return st, nil
+	}
+
+	if err != nil {
+		return State{}, clues.Wrap(err, "reading schedule state").WithClues(ctx)
+	}
+
+	if err := json.Unmarshal(bs, &st); err != nil {
+		return State{}, clues.Wrap(err, "parsing schedule state").WithClues(ctx)
+	}
+
+	if st.LastRuns == nil {
+		st.LastRuns = map[string]time.Time{}
+	}
+
+	return st, nil
+}
+
+// Save writes the state to a temporary file before moving it into place,
+// so that an interrupted save doesn't corrupt the previous state.
+func (fst fileState) Save(ctx context.Context, st State) error {
+	bs, err := json.Marshal(st)
+	if err != nil {
+		return clues.Wrap(err, "serializing schedule state").WithClues(ctx)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(fst.path), 0o700); err != nil {
+		return clues.Wrap(err, "creating schedule state directory").WithClues(ctx)
+	}
+
+	tmp := fst.path + ".tmp"
+
+	if err := os.WriteFile(tmp, bs, 0o600); err != nil {
+		return clues.Wrap(err, "writing schedule state").WithClues(ctx)
+	}
+
+	if err := os.Rename(tmp, fst.path); err != nil {
+		return clues.Wrap(err, "replacing schedule state").WithClues(ctx)
+	}
+
+	return nil
+}
@@ -42,9 +42,10 @@ type (
 )
 
 var (
-	_ Reducer        = &ExchangeRestore{}
-	_ pathCategorier = &ExchangeRestore{}
-	_ reasoner       = &ExchangeRestore{}
+	_ Reducer              = &ExchangeRestore{}
+	_ pathCategorier       = &ExchangeRestore{}
+	_ pathCategorySplitter = &ExchangeRestore{}
+	_ reasoner             = &ExchangeRestore{}
 )
 
 // NewExchange produces a new Selector with the service set to ServiceExchange.
@@ -124,6 +125,12 @@ func (s exchange) PathCategories() selectorPathCategories {
 	}
 }
 
+// SplitByPathCategory produces one selector for each path category in the
+// selector's inclusions and filters.
+func (s exchange) SplitByPathCategory() []Selector {
+	return splitByPathCategory[ExchangeScope, exchangeCategory](s.Selector)
+}
+
 // Reasons returns a deduplicated set of the backup reasons produced
 // using the selector's discrete owner and each scopes' service and
 // category types.
@@ -453,6 +460,21 @@ func (sr *ExchangeRestore) MailReceivedBefore(timeStrings string) []ExchangeScop
 	}
 }
 
+// MailRecipient produces one or more exchange mail recipient info scopes.
+// Matches any mail where one of the recipients contains one of the provided strings.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+func (sr *ExchangeRestore) MailRecipient(recipient string) []ExchangeScope {
+	return []ExchangeScope{
+		makeInfoScope[ExchangeScope](
+			ExchangeMail,
+			ExchangeInfoMailRecipient,
+			[]string{recipient},
+			filters.In),
+	}
+}
+
 // MailSender produces one or more exchange mail sender info scopes.
 // Matches any mail whose sender contains one of the provided strings.
 // If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
@@ -508,6 +530,7 @@ const (
 
 	// data contained within details.ItemInfo
 	ExchangeInfoMailSender         exchangeCategory = "ExchangeInfoMailSender"
+	ExchangeInfoMailRecipient      exchangeCategory = "ExchangeInfoMailRecipient"
 	ExchangeInfoMailSubject        exchangeCategory = "ExchangeInfoMailSubject"
 	ExchangeInfoMailReceivedAfter  exchangeCategory = "ExchangeInfoMailReceivedAfter"
 	ExchangeInfoMailReceivedBefore exchangeCategory = "ExchangeInfoMailReceivedBefore"
@@ -561,7 +584,8 @@ func (ec exchangeCategory) leafCat() categorizer {
 		return ExchangeEvent
 
 	case ExchangeMail, ExchangeMailFolder, ExchangeInfoMailReceivedAfter,
-		ExchangeInfoMailReceivedBefore, ExchangeInfoMailSender, ExchangeInfoMailSubject:
+		ExchangeInfoMailReceivedBefore, ExchangeInfoMailRecipient, ExchangeInfoMailSender,
+		ExchangeInfoMailSubject:
 		return ExchangeMail
 	}
 
@@ -790,6 +814,8 @@ func (s ExchangeScope) matchesInfo(dii details.ItemInfo) bool {
 		i = dttm.Format(info.EventStart)
 	case ExchangeInfoEventSubject:
 		i = info.Subject
+	case ExchangeInfoMailRecipient:
+		return matchesAny(s, infoCat, info.Recipient)
 	case ExchangeInfoMailSender:
 		i = info.Sender
 	case ExchangeInfoMailSubject:
@@ -585,6 +585,7 @@ func (suite *ExchangeSelectorSuite) TestExchangeScope_MatchesInfo() {
 		name      = "smarf mcfnords"
 		organizer = "cooks@2many.smarf"
 		sender    = "smarf@2many.cooks"
+		recipient = "fnords@2many.cooks"
 		subject   = "I have seen the fnords!"
 	)
 
@@ -603,6 +604,7 @@ func (suite *ExchangeSelectorSuite) TestExchangeScope_MatchesInfo() {
 				EventStart:  now,
 				Organizer:   organizer,
 				Sender:      sender,
+				Recipient:   []string{"mcfnords@2many.cooks", recipient},
 				Subject:     subject,
 				Received:    now,
 			},
@@ -619,6 +621,12 @@ func (suite *ExchangeSelectorSuite) TestExchangeScope_MatchesInfo() {
 		{"no mail, regardless of sender", details.ExchangeMail, es.MailSender(NoneTgt), assert.False},
 		{"mail from a different sender", details.ExchangeMail, es.MailSender("magoo@ma.goo"), assert.False},
 		{"mail from the matching sender", details.ExchangeMail, es.MailSender(sender), assert.True},
+		{"any mail with a recipient", details.ExchangeMail, es.MailRecipient(AnyTgt), assert.True},
+		{"no mail, regardless of recipient", details.ExchangeMail, es.MailRecipient(NoneTgt), assert.False},
+		{"mail to a different recipient", details.ExchangeMail, es.MailRecipient("magoo@ma.goo"), assert.False},
+		{"mail to the matching recipient", details.ExchangeMail, es.MailRecipient(recipient), assert.True},
+		{"mail to the sender", details.ExchangeMail, es.MailRecipient(sender), assert.False},
+		{"event with the matching recipient", details.ExchangeEvent, es.MailRecipient(recipient), assert.False},
 		{"mail with any subject", details.ExchangeMail, es.MailSubject(AnyTgt), assert.True},
 		{"mail with none subject", details.ExchangeMail, es.MailSubject(NoneTgt), assert.False},
 		{"mail with a different subject", details.ExchangeMail, es.MailSubject("fancy"), assert.False},
@@ -1264,6 +1272,92 @@ func (suite *ExchangeSelectorSuite) TestExchangeRestore_Reduce_locationRef() {
 	}
 }
 
+func (suite *ExchangeSelectorSuite) TestExchangeRestore_Reduce_senderRecipient() {
+	var (
+		fromBob   = stubRepoRef(path.ExchangeService, path.EmailCategory, "uid", "inbx", "m1")
+		toBob     = stubRepoRef(path.ExchangeService, path.EmailCategory, "uid", "inbx", "m2")
+		ccBobs    = stubRepoRef(path.ExchangeService, path.EmailCategory, "uid", "inbx", "m3")
+		unrelated = stubRepoRef(path.ExchangeService, path.EmailCategory, "uid", "inbx", "m4")
+		event     = stubRepoRef(path.ExchangeService, path.EventsCategory, "uid", "cal", "e1")
+	)
+
+	entry := func(rr string, itype details.ItemType, sender string, recipients ...string) details.Entry {
+		return details.Entry{
+			RepoRef:     rr,
+			LocationRef: "inbx",
+			ItemInfo: details.ItemInfo{
+				Exchange: &details.ExchangeInfo{
+					ItemType:  itype,
+					Sender:    sender,
+					Recipient: recipients,
+				},
+			},
+		}
+	}
+
+	deets := &details.Details{
+		DetailsModel: details.DetailsModel{
+			Entries: []details.Entry{
+				entry(fromBob, details.ExchangeMail, "bob@corp.com", "alice@corp.com"),
+				entry(toBob, details.ExchangeMail, "alice@corp.com", "bob@corp.com"),
+				entry(ccBobs, details.ExchangeMail, "carol@corp.com", "alice@corp.com", "bob@corp.com"),
+				entry(unrelated, details.ExchangeMail, "carol@corp.com", "dave@corp.com"),
+				entry(event, details.ExchangeEvent, "", "bob@corp.com"),
+			},
+		},
+	}
+
+	table := []struct {
+		name    string
+		filters func(er *ExchangeRestore) []ExchangeScope
+		expect  []string
+	}{
+		{
+			name: "sender",
+			filters: func(er *ExchangeRestore) []ExchangeScope {
+				return er.MailSender("bob@corp.com")
+			},
+			expect: []string{fromBob},
+		},
+		{
+			name: "recipient",
+			filters: func(er *ExchangeRestore) []ExchangeScope {
+				return er.MailRecipient("bob@corp.com")
+			},
+			expect: []string{toBob, ccBobs},
+		},
+		{
+			name: "sender and recipient",
+			filters: func(er *ExchangeRestore) []ExchangeScope {
+				return append(er.MailSender("carol@corp.com"), er.MailRecipient("bob@corp.com")...)
+			},
+			expect: []string{ccBobs},
+		},
+		{
+			name: "no matching recipient",
+			filters: func(er *ExchangeRestore) []ExchangeScope {
+				return er.MailRecipient("erin@corp.com")
+			},
+			expect: []string{},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			er := NewExchangeRestore(Any())
+			er.Include(er.AllData())
+			er.Filter(test.filters(er))
+
+			results := er.Reduce(ctx, deets, fault.New(true))
+			assert.ElementsMatch(t, test.expect, results.Paths())
+		})
+	}
+}
+
 func (suite *ExchangeSelectorSuite) TestScopesByCategory() {
 	var (
 		es       = NewExchangeRestore(Any())
@@ -1622,6 +1716,7 @@ func (suite *ExchangeSelectorSuite) TestCategory_PathType() {
 		{ExchangeMailFolder, path.EmailCategory},
 		{ExchangeUser, path.UnknownCategory},
 		{ExchangeInfoMailSender, path.EmailCategory},
+		{ExchangeInfoMailRecipient, path.EmailCategory},
 		{ExchangeInfoMailSubject, path.EmailCategory},
 		{ExchangeInfoMailReceivedAfter, path.EmailCategory},
 		{ExchangeInfoMailReceivedBefore, path.EmailCategory},
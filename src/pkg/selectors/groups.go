@@ -42,9 +42,10 @@ type (
 )
 
 var (
-	_ Reducer        = &GroupsRestore{}
-	_ pathCategorier = &GroupsRestore{}
-	_ reasoner       = &GroupsRestore{}
+	_ Reducer              = &GroupsRestore{}
+	_ pathCategorier       = &GroupsRestore{}
+	_ pathCategorySplitter = &GroupsRestore{}
+	_ reasoner             = &GroupsRestore{}
 )
 
 // NewGroupsBackup produces a new Selector with the service set to ServiceGroups.
@@ -70,6 +71,21 @@ func (s Selector) ToGroupsBackup() (*GroupsBackup, error) {
 	return &src, nil
 }
 
+// IncludesSite returns true if the selector's site filters, if any, allow
+// library data from the given site.  Selectors without any site filters
+// include every site.
+func (s GroupsBackup) IncludesSite(siteID string) bool {
+	for _, f := range s.Filters {
+		sc := GroupsScope(f)
+
+		if sc.InfoCategory() == GroupsInfoSite && !sc.Matches(GroupsInfoSite, siteID) {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (s GroupsBackup) SplitByResourceOwner(resources []string) []GroupsBackup {
 	sels := splitByProtectedResource[GroupsScope](s.Selector, resources, GroupsGroup)
 
@@ -124,6 +140,12 @@ func (s groups) PathCategories() selectorPathCategories {
 	}
 }
 
+// SplitByPathCategory produces one selector for each path category in the
+// selector's inclusions and filters.
+func (s groups) SplitByPathCategory() []Selector {
+	return splitByPathCategory[GroupsScope, groupsCategory](s.Selector)
+}
+
 // Reasons returns a deduplicated set of the backup reasons produced
 // using the selector's discrete owner and each scopes' service and
 // category types.
@@ -278,6 +300,23 @@ func (s *groups) Library(library string) []GroupsScope {
 	}
 }
 
+// Sites produces one or more Group site scopes, where the site matches
+// upon any of the given site IDs.  In order to ensure site selection this
+// should always be embedded within the Filter() set; include(Sites())
+// will select all library items in the sites without further filtering.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+func (s *groups) Sites(sites []string) []GroupsScope {
+	return []GroupsScope{
+		makeInfoScope[GroupsScope](
+			GroupsLibraryItem,
+			GroupsInfoSite,
+			sites,
+			filters.Equal),
+	}
+}
+
 // LibraryFolders produces one or more SharePoint libraryFolder scopes.
 // If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
 // If any slice contains selectors.None, that slice is reduced to [selectors.None]
@@ -519,6 +558,7 @@ const (
 	GroupsInfoLibraryItemModifiedBefore groupsCategory = "GroupsInfoLibraryItemModifiedBefore"
 
 	// channel and drive selection
+	GroupsInfoSite             groupsCategory = "GroupsInfoSite"
 	GroupsInfoSiteLibraryDrive groupsCategory = "GroupsInfoSiteLibraryDrive"
 
 	// data contained within details.ItemInfo
@@ -562,7 +602,7 @@ func (c groupsCategory) leafCat() categorizer {
 		GroupsInfoChannelMessageCreatedAfter, GroupsInfoChannelMessageCreatedBefore, GroupsInfoChannelMessageCreator,
 		GroupsInfoChannelMessageLastReplyAfter, GroupsInfoChannelMessageLastReplyBefore:
 		return GroupsChannelMessage
-	case GroupsLibraryFolder, GroupsLibraryItem, GroupsInfoSiteLibraryDrive,
+	case GroupsLibraryFolder, GroupsLibraryItem, GroupsInfoSite, GroupsInfoSiteLibraryDrive,
 		GroupsInfoLibraryItemCreatedAfter, GroupsInfoLibraryItemCreatedBefore,
 		GroupsInfoLibraryItemModifiedAfter, GroupsInfoLibraryItemModifiedBefore:
 		return GroupsLibraryItem
@@ -781,6 +821,8 @@ func (s GroupsScope) matchesInfo(dii details.ItemInfo) bool {
 	}
 
 	switch infoCat {
+	case GroupsInfoSite:
+		i = info.SiteID
 	case GroupsInfoSiteLibraryDrive:
 		ds := []string{}
 
@@ -44,6 +44,46 @@ func (suite *GroupsSelectorSuite) TestToGroupsBackup() {
 	assert.NotZero(t, ob.Scopes())
 }
 
+func (suite *GroupsSelectorSuite) TestGroupsBackup_IncludesSite() {
+	table := []struct {
+		name   string
+		sites  []string
+		expect assert.BoolAssertionFunc
+	}{
+		{
+			name:   "no site filter",
+			expect: assert.True,
+		},
+		{
+			name:   "matching site filter",
+			sites:  []string{"site-id"},
+			expect: assert.True,
+		},
+		{
+			name:   "one of many site filters",
+			sites:  []string{"other-site-id", "site-id"},
+			expect: assert.True,
+		},
+		{
+			name:   "other site filter",
+			sites:  []string{"other-site-id"},
+			expect: assert.False,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			sel := NewGroupsBackup(Any())
+			sel.Include(sel.AllData())
+
+			if len(test.sites) > 0 {
+				sel.Filter(sel.Sites(test.sites))
+			}
+
+			test.expect(suite.T(), sel.IncludesSite("site-id"))
+		})
+	}
+}
+
 func (suite *GroupsSelectorSuite) TestNewGroupsRestore() {
 	t := suite.T()
 	or := NewGroupsRestore(nil)
@@ -396,6 +436,9 @@ func (suite *GroupsSelectorSuite) TestGroupsScope_MatchesInfo() {
 		{"not in library", dspl, user, sel.Library("not-included-library"), assert.False},
 		{"library id", dspl, user, sel.Library("1234"), assert.True},
 		{"not library id", dspl, user, sel.Library("abcd"), assert.False},
+		{"in site", dspl, user, sel.Sites([]string{"site-id"}), assert.True},
+		{"not in site", dspl, user, sel.Sites([]string{"other-site-id"}), assert.False},
+		{"in site wrong type", dgcm, user, sel.Sites([]string{"site-id"}), assert.False},
 
 		{"channel message created by", dgcm, user, sel.MessageCreator(user), assert.True},
 		{"channel message not created by", dgcm, user, sel.MessageCreator(host), assert.False},
@@ -430,6 +473,7 @@ func (suite *GroupsSelectorSuite) TestGroupsScope_MatchesInfo() {
 					LastReplyAt:    mod,
 					DriveName:      "included-library",
 					DriveID:        "1234",
+					SiteID:         "site-id",
 				},
 			}
 
@@ -456,6 +500,7 @@ func (suite *GroupsSelectorSuite) TestCategory_PathType() {
 		{GroupsInfoChannelMessageLastReplyBefore, path.ChannelMessagesCategory},
 		{GroupsLibraryFolder, path.LibrariesCategory},
 		{GroupsLibraryItem, path.LibrariesCategory},
+		{GroupsInfoSite, path.LibrariesCategory},
 		{GroupsInfoSiteLibraryDrive, path.LibrariesCategory},
 	}
 	for _, test := range table {
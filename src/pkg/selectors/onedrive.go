@@ -41,9 +41,10 @@ type (
 )
 
 var (
-	_ Reducer        = &OneDriveRestore{}
-	_ pathCategorier = &OneDriveRestore{}
-	_ reasoner       = &OneDriveRestore{}
+	_ Reducer              = &OneDriveRestore{}
+	_ pathCategorier       = &OneDriveRestore{}
+	_ pathCategorySplitter = &OneDriveRestore{}
+	_ reasoner             = &OneDriveRestore{}
 )
 
 // NewOneDriveBackup produces a new Selector with the service set to ServiceOneDrive.
@@ -123,6 +124,12 @@ func (s oneDrive) PathCategories() selectorPathCategories {
 	}
 }
 
+// SplitByPathCategory produces one selector for each path category in the
+// selector's inclusions and filters.
+func (s oneDrive) SplitByPathCategory() []Selector {
+	return splitByPathCategory[OneDriveScope, oneDriveCategory](s.Selector)
+}
+
 // Reasons returns a deduplicated set of the backup reasons produced
 // using the selector's discrete owner and each scopes' service and
 // category types.
@@ -7,6 +7,7 @@ import (
 
 	"github.com/alcionai/clues"
 	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 
 	"github.com/alcionai/corso/src/internal/common/idname"
 	"github.com/alcionai/corso/src/pkg/backup/details"
@@ -35,6 +36,27 @@ var serviceToPathType = map[service]path.ServiceType{
 	ServiceGroups:     path.GroupsService,
 }
 
+// SupportedServiceCategories lists the data categories that can be
+// selected within each service.  Every pairing passes
+// path.ValidateServiceAndCategory.  Categories are listed in enum order.
+func SupportedServiceCategories() map[path.ServiceType][]path.CategoryType {
+	ssc := map[path.ServiceType][]path.CategoryType{}
+
+	for _, pst := range serviceToPathType {
+		if pst == path.UnknownService {
+			continue
+		}
+
+		for _, cat := range path.CategoryTypes() {
+			if err := path.ValidateServiceAndCategory(pst, cat); err == nil {
+				ssc[pst] = append(ssc[pst], cat)
+			}
+		}
+	}
+
+	return ssc
+}
+
 var (
 	ErrorBadSelectorCast     = clues.New("wrong selector service type")
 	ErrorNoMatchingItems     = clues.New("no items match the provided selectors")
@@ -97,6 +119,10 @@ type reasoner interface {
 	Reasons(tenantID string, useOwnerNameForID bool) []identity.Reasoner
 }
 
+type pathCategorySplitter interface {
+	SplitByPathCategory() []Selector
+}
+
 // ---------------------------------------------------------------------------
 // Selector
 // ---------------------------------------------------------------------------
@@ -305,6 +331,18 @@ func (s Selector) Reasons(tenantID string, useOwnerNameForID bool) ([]identity.R
 	return ro.Reasons(tenantID, useOwnerNameForID), nil
 }
 
+// SplitByPathCategory produces one selector for each path category in the
+// selector's inclusions and filters.  Each selector holds only the scopes
+// of its own category.  Selectors are ordered by category.
+func (s Selector) SplitByPathCategory() ([]Selector, error) {
+	ps, err := selectorAsIface[pathCategorySplitter](s)
+	if err != nil {
+		return nil, err
+	}
+
+	return ps.SplitByPathCategory(), nil
+}
+
 // transformer for arbitrary selector interfaces
 func selectorAsIface[T any](s Selector) (T, error) {
 	var (
@@ -432,3 +470,46 @@ func pathCategoriesIn[T scopeT, C categoryT](ss []scope) []path.CategoryType {
 
 	return maps.Keys(m)
 }
+
+// produces the scopes in the slice whose leaf category matches the path
+// category.
+func scopesInPathCategory[T scopeT, C categoryT](ss []scope, cat path.CategoryType) []scope {
+	result := []scope{}
+
+	for _, s := range ss {
+		if T(s).categorizer().leafCat().PathType() == cat {
+			result = append(result, s)
+		}
+	}
+
+	return result
+}
+
+// splits the selector into one selector per path category in its
+// inclusions and filters.
+func splitByPathCategory[T scopeT, C categoryT](s Selector) []Selector {
+	var (
+		m    = map[path.CategoryType]struct{}{}
+		sels = []Selector{}
+	)
+
+	for _, ss := range [][]scope{s.Includes, s.Filters} {
+		for _, cat := range pathCategoriesIn[T, C](ss) {
+			m[cat] = struct{}{}
+		}
+	}
+
+	cats := maps.Keys(m)
+	slices.Sort(cats)
+
+	for _, cat := range cats {
+		sel := s
+		sel.Excludes = scopesInPathCategory[T, C](s.Excludes, cat)
+		sel.Filters = scopesInPathCategory[T, C](s.Filters, cat)
+		sel.Includes = scopesInPathCategory[T, C](s.Includes, cat)
+
+		sels = append(sels, sel)
+	}
+
+	return sels
+}
@@ -6,7 +6,9 @@ import (
 
 	"github.com/alcionai/clues"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"golang.org/x/exp/slices"
 
 	"github.com/alcionai/corso/src/internal/tester"
 	"github.com/alcionai/corso/src/pkg/filters"
@@ -29,6 +31,36 @@ func (suite *SelectorSuite) TestNewSelector() {
 	assert.NotNil(t, s.Includes)
 }
 
+func (suite *SelectorSuite) TestSupportedServiceCategories() {
+	t := suite.T()
+	ssc := SupportedServiceCategories()
+
+	assert.Equal(
+		t,
+		map[path.ServiceType][]path.CategoryType{
+			path.ExchangeService:   {path.EmailCategory, path.ContactsCategory, path.EventsCategory},
+			path.OneDriveService:   {path.FilesCategory},
+			path.SharePointService: {path.ListsCategory, path.LibrariesCategory, path.PagesCategory},
+			path.GroupsService:     {path.LibrariesCategory, path.ChannelMessagesCategory},
+		},
+		ssc)
+
+	for _, pst := range serviceToPathType {
+		for _, cat := range path.CategoryTypes() {
+			err := path.ValidateServiceAndCategory(pst, cat)
+
+			if slices.Contains(ssc[pst], cat) {
+				assert.NoError(t, err, "advertised %s/%s must validate: %v", pst, cat, clues.ToCore(err))
+			} else {
+				assert.Error(t, err, "unadvertised %s/%s must not validate", pst, cat)
+			}
+		}
+	}
+
+	_, ok := ssc[path.UnknownService]
+	assert.False(t, ok, "unknown service")
+}
+
 // set the clues hashing to mask for the span of this suite
 func (suite *SelectorSuite) SetupSuite() {
 	clues.SetHasher(clues.HashCfg{HashAlg: clues.Flatmask})
@@ -367,6 +399,86 @@ func (suite *SelectorSuite) TestPathCategories_includes() {
 	}
 }
 
+func (suite *SelectorSuite) TestSplitByPathCategory() {
+	users := []string{"someuser@onmicrosoft.com"}
+
+	table := []struct {
+		name        string
+		getSelector func() Selector
+		expect      []path.CategoryType
+		// the number of includes, filters, and excludes in each split selector
+		expectScopes [][3]int
+		isErr        assert.ErrorAssertionFunc
+	}{
+		{
+			name:        "empty",
+			getSelector: func() Selector { return Selector{} },
+			isErr:       assert.Error,
+		},
+		{
+			name: "exchange",
+			getSelector: func() Selector {
+				sel := NewExchangeRestore(users)
+				sel.Include(sel.AllData())
+				sel.Exclude(sel.Mails(Any(), []string{"mail"}))
+				sel.Filter(sel.MailRecipient("recipient"), sel.EventSubject("subject"))
+
+				return sel.Selector
+			},
+			expect: []path.CategoryType{
+				path.EmailCategory,
+				path.ContactsCategory,
+				path.EventsCategory,
+			},
+			expectScopes: [][3]int{{1, 1, 1}, {1, 0, 0}, {1, 1, 0}},
+			isErr:        assert.NoError,
+		},
+		{
+			name: "single category",
+			getSelector: func() Selector {
+				sel := NewOneDriveBackup(users)
+				sel.Include(sel.Folders(Any()))
+
+				return sel.Selector
+			},
+			expect:       []path.CategoryType{path.FilesCategory},
+			expectScopes: [][3]int{{1, 0, 0}},
+			isErr:        assert.NoError,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			sel := test.getSelector()
+
+			sels, err := sel.SplitByPathCategory()
+			test.isErr(t, err, clues.ToCore(err))
+
+			if err != nil {
+				return
+			}
+
+			require.Len(t, sels, len(test.expect))
+
+			for i, s := range sels {
+				assert.Equal(t, sel.Service, s.Service, "service")
+				assert.Equal(t, sel.DiscreteOwner, s.DiscreteOwner, "discrete owner")
+
+				pcs, err := s.PathCategories()
+				require.NoError(t, err, clues.ToCore(err))
+
+				assert.Equal(t, []path.CategoryType{test.expect[i]}, pcs.Includes, "included categories")
+				assert.Equal(
+					t,
+					test.expectScopes[i],
+					[3]int{len(s.Includes), len(s.Filters), len(s.Excludes)},
+					"includes, filters, excludes")
+			}
+		})
+	}
+}
+
 func (suite *SelectorSuite) TestSelector_pii() {
 	table := []struct {
 		name        string
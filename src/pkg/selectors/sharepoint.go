@@ -41,9 +41,10 @@ type (
 )
 
 var (
-	_ Reducer        = &SharePointRestore{}
-	_ pathCategorier = &SharePointRestore{}
-	_ reasoner       = &SharePointRestore{}
+	_ Reducer              = &SharePointRestore{}
+	_ pathCategorier       = &SharePointRestore{}
+	_ pathCategorySplitter = &SharePointRestore{}
+	_ reasoner             = &SharePointRestore{}
 )
 
 // NewSharePointBackup produces a new Selector with the service set to ServiceSharePoint.
@@ -123,6 +124,12 @@ func (s sharePoint) PathCategories() selectorPathCategories {
 	}
 }
 
+// SplitByPathCategory produces one selector for each path category in the
+// selector's inclusions and filters.
+func (s sharePoint) SplitByPathCategory() []Selector {
+	return splitByPathCategory[SharePointScope, sharePointCategory](s.Selector)
+}
+
 // Reasons returns a deduplicated set of the backup reasons produced
 // using the selector's discrete owner and each scopes' service and
 // category types.
@@ -258,7 +265,8 @@ func (s *sharePoint) AllData() []SharePointScope {
 	return scopes
 }
 
-// Lists produces one or more SharePoint list scopes.
+// Lists produces one or more SharePoint list scopes, matching lists by
+// their display name.
 // If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
 // If any slice contains selectors.None, that slice is reduced to [selectors.None]
 // Any empty slice defaults to [selectors.None]
@@ -541,6 +549,12 @@ func (c sharePointCategory) pathValues(
 		folderCat, itemCat = SharePointList, SharePointListItem
 		rFld = ent.LocationRef
 
+		// list backups don't record a location, but each list is stored
+		// as a single item named after the list's display name.
+		if len(rFld) == 0 && ent.SharePoint != nil {
+			rFld = ent.SharePoint.ItemName
+		}
+
 	case SharePointPage, SharePointPageFolder:
 		folderCat, itemCat = SharePointPageFolder, SharePointPage
 		rFld = ent.LocationRef
@@ -408,6 +408,16 @@ func (suite *SharePointSelectorSuite) TestSharePointCategory_PathValues() {
 			},
 			cfg: Config{},
 		},
+		{
+			name:      "SharePoint Lists w/o location",
+			sc:        SharePointListItem,
+			pathElems: elems,
+			expected: map[categorizer][]string{
+				SharePointList:     {itemName},
+				SharePointListItem: {itemID, shortRef},
+			},
+			cfg: Config{},
+		},
 	}
 
 	for _, test := range table {
@@ -443,6 +453,89 @@ func (suite *SharePointSelectorSuite) TestSharePointCategory_PathValues() {
 	}
 }
 
+func (suite *SharePointSelectorSuite) TestSharePointScope_Lists_matchDisplayName() {
+	sel := NewSharePointBackup(Any())
+
+	table := []struct {
+		name   string
+		scope  []SharePointScope
+		target string
+		expect assert.BoolAssertionFunc
+	}{
+		{"any", sel.Lists(Any()), "Team Tasks", assert.True},
+		{"none", sel.Lists(None()), "Team Tasks", assert.False},
+		{"exact name", sel.Lists([]string{"Team Tasks"}), "Team Tasks", assert.True},
+		{"case insensitive", sel.Lists([]string{"team tasks"}), "Team Tasks", assert.True},
+		{"one of many", sel.Lists([]string{"Issues", "Team Tasks"}), "Team Tasks", assert.True},
+		{"different name", sel.Lists([]string{"Issues"}), "Team Tasks", assert.False},
+		// list names are compared as whole path elements, like folders
+		{"substring", sel.Lists([]string{"Tasks"}), "Team Tasks", assert.False},
+		{"name prefix", sel.Lists([]string{"Team"}, PrefixMatch()), "Team Tasks", assert.False},
+		{"exact match", sel.Lists([]string{"Team Tasks"}, ExactMatch()), "Team Tasks", assert.True},
+		{"list items scope", sel.ListItems([]string{"Team Tasks"}, Any()), "Team Tasks", assert.True},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			require.Len(t, test.scope, 1)
+			test.expect(t, test.scope[0].Matches(SharePointList, test.target))
+		})
+	}
+}
+
+func (suite *SharePointSelectorSuite) TestSharePointRestore_Reduce_listsByName() {
+	var (
+		tasks   = stubRepoRef(path.SharePointService, path.ListsCategory, "sid", "Team Tasks", "list1")
+		issues  = stubRepoRef(path.SharePointService, path.ListsCategory, "sid", "Issues", "list2")
+		listEnt = func(rr, itemRef, name string) details.Entry {
+			return details.Entry{
+				RepoRef: rr,
+				ItemRef: itemRef,
+				ItemInfo: details.ItemInfo{
+					SharePoint: &details.SharePointInfo{
+						ItemType: details.SharePointList,
+						ItemName: name,
+					},
+				},
+			}
+		}
+		deets = &details.Details{
+			DetailsModel: details.DetailsModel{
+				Entries: []details.Entry{
+					listEnt(tasks, "list1", "Team Tasks"),
+					listEnt(issues, "list2", "Issues"),
+				},
+			},
+		}
+	)
+
+	table := []struct {
+		name   string
+		lists  []string
+		expect []string
+	}{
+		{"all lists", Any(), []string{tasks, issues}},
+		{"single list", []string{"Team Tasks"}, []string{tasks}},
+		{"multiple lists", []string{"Team Tasks", "Issues"}, []string{tasks, issues}},
+		{"no such list", []string{"Calendar"}, []string{}},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			sel := NewSharePointRestore(Any())
+			sel.Include(sel.Lists(test.lists))
+
+			results := sel.Reduce(ctx, deets, fault.New(true))
+			assert.ElementsMatch(t, test.expect, results.Paths())
+		})
+	}
+}
+
 func (suite *SharePointSelectorSuite) TestSharePointScope_MatchesInfo() {
 	var (
 		sel          = NewSharePointRestore(Any())
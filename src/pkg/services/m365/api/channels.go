@@ -100,6 +100,132 @@ func (c Channels) GetChannelByName(
 	return cal, nil
 }
 
+// PostChannel creates a standard channel with the provided name.
+func (c Channels) PostChannel(
+	ctx context.Context,
+	teamID, containerName string,
+) (models.Channelable, error) {
+	body := models.NewChannel()
+	body.SetDisplayName(ptr.To(containerName))
+	body.SetMembershipType(ptr.To(models.STANDARD_CHANNELMEMBERSHIPTYPE))
+
+	resp, err := c.Stable.
+		Client().
+		Teams().
+		ByTeamIdString(teamID).
+		Channels().
+		Post(ctx, body, nil)
+	if err != nil {
+		return nil, graph.Wrap(ctx, err, "creating channel")
+	}
+
+	return resp, nil
+}
+
+// ---------------------------------------------------------------------------
+// settings and tabs
+// ---------------------------------------------------------------------------
+
+// GetChannelSettings fetches the channel along with the settings that can be
+// updated after the channel is created.
+func (c Channels) GetChannelSettings(
+	ctx context.Context,
+	teamID, channelID string,
+) (models.Channelable, error) {
+	config := &teams.ItemChannelsChannelItemRequestBuilderGetRequestConfiguration{
+		QueryParameters: &teams.ItemChannelsChannelItemRequestBuilderGetQueryParameters{
+			Select: idAnd("displayName", "description", "isFavoriteByDefault"),
+		},
+	}
+
+	resp, err := c.Stable.
+		Client().
+		Teams().
+		ByTeamIdString(teamID).
+		Channels().
+		ByChannelIdString(channelID).
+		Get(ctx, config)
+	if err != nil {
+		return nil, graph.Stack(ctx, err)
+	}
+
+	return resp, nil
+}
+
+// PatchChannelSettings updates the channel with the settings in body.
+func (c Channels) PatchChannelSettings(
+	ctx context.Context,
+	teamID, channelID string,
+	body models.Channelable,
+) error {
+	_, err := c.Stable.
+		Client().
+		Teams().
+		ByTeamIdString(teamID).
+		Channels().
+		ByChannelIdString(channelID).
+		Patch(ctx, body, nil)
+	if err != nil {
+		return graph.Wrap(ctx, err, "updating channel settings")
+	}
+
+	return nil
+}
+
+// GetChannelTabs fetches all tabs in the channel, including the app
+// that backs each tab.
+func (c Channels) GetChannelTabs(
+	ctx context.Context,
+	teamID, channelID string,
+) ([]models.TeamsTabable, error) {
+	config := &teams.ItemChannelsItemTabsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &teams.ItemChannelsItemTabsRequestBuilderGetQueryParameters{
+			Expand: []string{"teamsApp"},
+		},
+	}
+
+	// graph doesn't page channel tabs.
+	resp, err := c.Stable.
+		Client().
+		Teams().
+		ByTeamIdString(teamID).
+		Channels().
+		ByChannelIdString(channelID).
+		Tabs().
+		Get(ctx, config)
+	if err != nil {
+		return nil, graph.Stack(ctx, err)
+	}
+
+	return resp.GetValue(), nil
+}
+
+// PostChannelTab adds the tab to the channel.  The tab's app is bound
+// by its app catalog ID, so the app must be installed in the team.
+func (c Channels) PostChannelTab(
+	ctx context.Context,
+	teamID, channelID, teamsAppID string,
+	body models.TeamsTabable,
+) (models.TeamsTabable, error) {
+	body.SetAdditionalData(map[string]any{
+		"teamsApp@odata.bind": "https://graph.microsoft.com/v1.0/appCatalogs/teamsApps/" + teamsAppID,
+	})
+
+	resp, err := c.Stable.
+		Client().
+		Teams().
+		ByTeamIdString(teamID).
+		Channels().
+		ByChannelIdString(channelID).
+		Tabs().
+		Post(ctx, body, nil)
+	if err != nil {
+		return nil, graph.Wrap(ctx, err, "creating channel tab")
+	}
+
+	return resp, nil
+}
+
 // ---------------------------------------------------------------------------
 // message
 // ---------------------------------------------------------------------------
@@ -121,7 +247,22 @@ func (c Channels) GetChannelMessage(
 		return nil, nil, graph.Stack(ctx, err)
 	}
 
-	replies, err := c.GetChannelMessageReplies(ctx, teamID, channelID, messageID)
+	return channelMessageWithReplies(
+		ctx,
+		message,
+		c.NewChannelMessageRepliesPager(teamID, channelID, messageID))
+}
+
+// channelMessageWithReplies replaces the replies embedded in the message
+// with every reply produced by the pager.  Graph only embeds the first
+// page of replies, so highly active threads would otherwise report a
+// truncated reply count and an outdated last reply time.
+func channelMessageWithReplies(
+	ctx context.Context,
+	message models.ChatMessageable,
+	pager Pager[models.ChatMessageable],
+) (models.ChatMessageable, *details.GroupsInfo, error) {
+	replies, err := enumerateItems[models.ChatMessageable](ctx, pager)
 	if err != nil {
 		return nil, nil, graph.Wrap(ctx, err, "retrieving message replies")
 	}
@@ -8,7 +8,6 @@ import (
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
 	"github.com/microsoftgraph/msgraph-sdk-go/teams"
 
-	"github.com/alcionai/corso/src/internal/common/ptr"
 	"github.com/alcionai/corso/src/internal/m365/graph"
 )
 
@@ -185,10 +184,6 @@ func (p *channelMessageRepliesPageCtrl) GetPage(
 	return resp, graph.Stack(ctx, err).OrNil()
 }
 
-func (p *channelMessageRepliesPageCtrl) GetOdataNextLink() *string {
-	return ptr.To("")
-}
-
 func (p *channelMessageRepliesPageCtrl) ValidModTimes() bool {
 	return true
 }
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+var _ Pager[models.ChatMessageable] = &testRepliesPager{}
+
+// testRepliesPager serves each of its pages in turn, linking to the
+// following page until the last one.
+type testRepliesPager struct {
+	pages [][]models.ChatMessageable
+	page  int
+}
+
+func (p *testRepliesPager) GetPage(
+	context.Context,
+) (NextLinkValuer[models.ChatMessageable], error) {
+	resp := models.NewChatMessageCollectionResponse()
+	resp.SetValue(p.pages[p.page])
+
+	if p.page < len(p.pages)-1 {
+		resp.SetOdataNextLink(ptr.To(fmt.Sprintf("page-%d", p.page+1)))
+	}
+
+	return resp, nil
+}
+
+func (p *testRepliesPager) SetNextLink(nextLink string) {
+	if len(nextLink) > 0 {
+		p.page++
+	}
+}
+
+func (p *testRepliesPager) ValidModTimes() bool { return true }
+
+type ChannelsUnitSuite struct {
+	tester.Suite
+}
+
+func TestChannelsUnitSuite(t *testing.T) {
+	suite.Run(t, &ChannelsUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *ChannelsUnitSuite) TestChannelMessageWithReplies_paginated() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		created  = time.Now().Add(-48 * time.Hour).UTC().Truncate(time.Second)
+		pages    = make([][]models.ChatMessageable, 3)
+		pageSize = 50
+		total    = 0
+		last     time.Time
+	)
+
+	for i := range pages {
+		// the final page is partially filled
+		n := pageSize
+		if i == len(pages)-1 {
+			n = 3
+		}
+
+		for j := 0; j < n; j++ {
+			last = created.Add(time.Duration(total+1) * time.Minute)
+
+			reply := models.NewChatMessage()
+			reply.SetId(ptr.To(fmt.Sprintf("reply-%d", total)))
+			reply.SetCreatedDateTime(ptr.To(last))
+
+			pages[i] = append(pages[i], reply)
+			total++
+		}
+	}
+
+	msg := models.NewChatMessage()
+	msg.SetId(ptr.To("message"))
+	msg.SetCreatedDateTime(ptr.To(created))
+	msg.SetLastModifiedDateTime(ptr.To(created))
+	// graph only embeds the first page of replies
+	msg.SetReplies(pages[0])
+
+	result, info, err := channelMessageWithReplies(ctx, msg, &testRepliesPager{pages: pages})
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Len(t, result.GetReplies(), total)
+	assert.Equal(t, total, info.ReplyCount)
+	assert.Equal(t, last, info.LastReplyAt)
+	assert.Equal(t, last, info.Modified)
+}
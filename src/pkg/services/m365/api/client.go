@@ -44,17 +44,17 @@ type Client struct {
 // NewClient produces a new exchange api client.  Must be used in
 // place of creating an ad-hoc client struct.
 func NewClient(creds account.M365Config, co control.Options) (Client, error) {
-	s, err := NewService(creds)
+	s, err := NewService(creds, graphOptions(co)...)
 	if err != nil {
 		return Client{}, err
 	}
 
-	li, err := newLargeItemService(creds)
+	li, err := newLargeItemService(creds, graphOptions(co)...)
 	if err != nil {
 		return Client{}, err
 	}
 
-	rqr := graph.NewNoTimeoutHTTPWrapper()
+	rqr := graph.NewNoTimeoutHTTPWrapper(graphOptions(co)...)
 
 	if co.DeltaPageSize < 1 || co.DeltaPageSize > maxDeltaPageSize {
 		co.DeltaPageSize = maxDeltaPageSize
@@ -77,7 +77,19 @@ func InitConcurrencyLimit(ctx context.Context, pst path.ServiceType) {
 // Most calls should use the Client.Stable property instead of calling this
 // func, unless it is explicitly necessary.
 func (c Client) Service() (graph.Servicer, error) {
-	return NewService(c.Credentials)
+	return NewService(c.Credentials, graphOptions(c.options)...)
+}
+
+// graphOptions produces the graph client options that are configured
+// by the control options.
+func graphOptions(co control.Options) []graph.Option {
+	return []graph.Option{
+		graph.MaxThrottleWait(co.MaxThrottleWait),
+		graph.CategoryTimeouts(graph.RequestTimeouts{
+			graph.MetadataRequests: co.RequestTimeouts.Metadata,
+			graph.DownloadRequests: co.RequestTimeouts.Download,
+		}),
+	}
 }
 
 func NewService(creds account.M365Config, opts ...graph.Option) (*graph.Service, error) {
@@ -93,8 +105,8 @@ func NewService(creds account.M365Config, opts ...graph.Option) (*graph.Service,
 	return graph.NewService(a), nil
 }
 
-func newLargeItemService(creds account.M365Config) (*graph.Service, error) {
-	a, err := NewService(creds, graph.NoTimeout())
+func newLargeItemService(creds account.M365Config, opts ...graph.Option) (*graph.Service, error) {
+	a, err := NewService(creds, append(opts, graph.NoTimeout())...)
 	if err != nil {
 		return nil, clues.Wrap(err, "generating no-timeout graph adapter")
 	}
@@ -39,6 +39,7 @@ const (
 // header keys
 const (
 	headerKeyConsistencyLevel = "ConsistencyLevel"
+	headerKeyIfMatch          = "If-Match"
 	headerKeyPrefer           = "Prefer"
 )
 
@@ -86,6 +87,13 @@ func newEventualConsistencyHeaders() *abstractions.RequestHeaders {
 	return headers
 }
 
+func newIfMatchHeaders(eTag string) *abstractions.RequestHeaders {
+	headers := abstractions.NewRequestHeaders()
+	headers.Add(headerKeyIfMatch, eTag)
+
+	return headers
+}
+
 // makes a slice with []string{"id", s...}
 func idAnd(ss ...string) []string {
 	id := []string{"id"}
@@ -106,6 +114,7 @@ func DriveItemSelectDefault() []string {
 		"content.downloadUrl",
 		"createdBy",
 		"createdDateTime",
+		"eTag",
 		"file",
 		"folder",
 		"lastModifiedDateTime",
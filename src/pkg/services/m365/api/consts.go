@@ -5,6 +5,7 @@ package api
 const (
 	DefaultCalendar = "Calendar"
 	DefaultContacts = "Contacts"
+	MailDrafts      = "drafts"
 	MailInbox       = "Inbox"
 	MsgFolderRoot   = "msgfolderroot"
 )
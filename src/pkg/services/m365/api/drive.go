@@ -2,7 +2,10 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/alcionai/clues"
 	"github.com/microsoftgraph/msgraph-sdk-go/drives"
@@ -107,12 +110,22 @@ func (c Drives) GetItem(
 	return di, nil
 }
 
+// NewItemContentUpload creates an upload session for the item's content.
+// If fsi is non-nil, the uploaded item's file system timestamps are set
+// to its values.
 func (c Drives) NewItemContentUpload(
 	ctx context.Context,
 	driveID, itemID string,
+	fsi models.FileSystemInfoable,
 ) (models.UploadSessionable, error) {
 	session := drives.NewItemItemsItemCreateUploadSessionPostRequestBody()
 
+	if fsi != nil {
+		props := models.NewDriveItemUploadableProperties()
+		props.SetFileSystemInfo(fsi)
+		session.SetItem(props)
+	}
+
 	r, err := c.Stable.
 		Client().
 		Drives().
@@ -172,6 +185,114 @@ func (c Drives) PostItemInContainer(
 	return newItem, nil
 }
 
+// copyMonitorDelay is the wait between checks on the progress of an
+// asynchronous copy.
+const copyMonitorDelay = time.Second
+
+// CopyItem copies the item into the parentFolderID folder under the given
+// name.  Graph copies drive items asynchronously: the copy request only
+// returns the url of a monitor, which gets polled until the copy completes.
+// ref: https://learn.microsoft.com/en-us/graph/api/driveitem-copy
+func (c Drives) CopyItem(
+	ctx context.Context,
+	driveID, itemID, parentFolderID, name string,
+) (models.DriveItemable, error) {
+	ref := models.NewItemReference()
+	ref.SetDriveId(ptr.To(driveID))
+	ref.SetId(ptr.To(parentFolderID))
+
+	body := drives.NewItemItemsItemCopyPostRequestBody()
+	body.SetParentReference(ref)
+	body.SetName(ptr.To(name))
+
+	// the monitor url is only found in the response headers.
+	rh := http.Header{}
+
+	_, err := c.Stable.
+		Client().
+		Drives().
+		ByDriveIdString(driveID).
+		Items().
+		ByDriveItemIdString(itemID).
+		Copy().
+		Post(graph.BindResponseHeaders(ctx, rh), body, nil)
+	if err != nil {
+		return nil, graph.Wrap(ctx, err, "copying drive item")
+	}
+
+	monitorURL := rh.Get("Location")
+	if len(monitorURL) == 0 {
+		return nil, clues.New("copy response is missing its monitor url").WithClues(ctx)
+	}
+
+	copiedID, err := awaitCopy(ctx, c, monitorURL, copyMonitorDelay)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	return c.GetItem(ctx, driveID, copiedID)
+}
+
+// copyStatus is the progress reported by an asynchronous copy's monitor.
+type copyStatus struct {
+	Status     string `json:"status"`
+	ResourceID string `json:"resourceId"`
+	Error      *struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// awaitCopy polls the monitor of an asynchronous copy until the copy
+// completes, and returns the id of the copied item.  The monitor url is
+// pre-authenticated, and doesn't get called through the graph client.
+func awaitCopy(
+	ctx context.Context,
+	g Getter,
+	monitorURL string,
+	delay time.Duration,
+) (string, error) {
+	for {
+		resp, err := g.Get(ctx, monitorURL, nil)
+		if err != nil {
+			return "", graph.Wrap(ctx, err, "getting copy status")
+		}
+
+		var cs copyStatus
+
+		err = json.NewDecoder(resp.Body).Decode(&cs)
+		resp.Body.Close()
+
+		if err != nil {
+			return "", clues.Wrap(err, "decoding copy status").WithClues(ctx)
+		}
+
+		switch cs.Status {
+		case "completed":
+			if len(cs.ResourceID) == 0 {
+				return "", clues.New("completed copy is missing its item id").WithClues(ctx)
+			}
+
+			return cs.ResourceID, nil
+
+		case "failed", "cancelled":
+			err := clues.New("copy did not complete").With("copy_status", cs.Status)
+
+			if cs.Error != nil {
+				err = err.With("copy_error_code", cs.Error.Code)
+			}
+
+			return "", err.WithClues(ctx)
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", clues.Wrap(ctx.Err(), "waiting for copy").WithClues(ctx)
+		case <-time.After(delay):
+		}
+	}
+}
+
 func (c Drives) PatchItem(
 	ctx context.Context,
 	driveID, itemID string,
@@ -238,6 +359,39 @@ func (c Drives) DeleteItem(
 	return nil
 }
 
+// DeleteItemIfMatch deletes the item only if its current eTag matches the
+// provided eTag.  Returns graph.ErrPreconditionFailed if the item changed.
+func (c Drives) DeleteItemIfMatch(
+	ctx context.Context,
+	driveID, itemID, eTag string,
+) error {
+	// deletes require unique http clients
+	// https://github.com/alcionai/corso/issues/2707
+	srv, err := c.Service()
+	if err != nil {
+		return graph.Wrap(ctx, err, "creating adapter to delete item")
+	}
+
+	err = srv.
+		Client().
+		Drives().
+		ByDriveIdString(driveID).
+		Items().
+		ByDriveItemIdString(itemID).
+		Delete(ctx, &drives.ItemItemsDriveItemItemRequestBuilderDeleteRequestConfiguration{
+			Headers: newIfMatchHeaders(eTag),
+		})
+	if graph.IsErrPreconditionFailed(err) {
+		return clues.Stack(graph.ErrPreconditionFailed, err).WithClues(ctx).With("item_id", itemID)
+	}
+
+	if err != nil {
+		return graph.Wrap(ctx, err, "deleting item").With("item_id", itemID)
+	}
+
+	return nil
+}
+
 // ---------------------------------------------------------------------------
 // Permissions
 // ---------------------------------------------------------------------------
@@ -68,6 +68,8 @@ func (p *driveItemPageCtrl) ValidModTimes() bool {
 type DriveItemIDType struct {
 	ItemID   string
 	IsFolder bool
+	// ETag is only populated when looking up items by collision key.
+	ETag string
 }
 
 func (c Drives) GetItemsInContainerByCollisionKey(
@@ -75,7 +77,7 @@ func (c Drives) GetItemsInContainerByCollisionKey(
 	driveID, containerID string,
 ) (map[string]DriveItemIDType, error) {
 	ctx = clues.Add(ctx, "container_id", containerID)
-	pager := c.NewDriveItemPager(driveID, containerID, idAnd("name")...)
+	pager := c.NewDriveItemPager(driveID, containerID, idAnd("name", "eTag")...)
 
 	items, err := enumerateItems(ctx, pager)
 	if err != nil {
@@ -88,6 +90,7 @@ func (c Drives) GetItemsInContainerByCollisionKey(
 		m[DriveItemCollisionKey(item)] = DriveItemIDType{
 			ItemID:   ptr.Val(item.GetId()),
 			IsFolder: item.GetFolder() != nil,
+			ETag:     ptr.Val(item.GetETag()),
 		}
 	}
 
@@ -0,0 +1,136 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+var _ Getter = &testMonitor{}
+
+// testMonitor serves each of its copy statuses in turn, repeating the
+// last one once they run out.
+type testMonitor struct {
+	statuses []string
+	gets     int
+	err      error
+}
+
+func (m *testMonitor) Get(
+	context.Context,
+	string,
+	map[string]string,
+) (*http.Response, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	status := m.statuses[min(m.gets, len(m.statuses)-1)]
+	m.gets++
+
+	return &http.Response{
+		StatusCode: http.StatusAccepted,
+		Body:       io.NopCloser(bytes.NewBufferString(status)),
+	}, nil
+}
+
+type DrivesUnitSuite struct {
+	tester.Suite
+}
+
+func TestDrivesUnitSuite(t *testing.T) {
+	suite.Run(t, &DrivesUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *DrivesUnitSuite) TestAwaitCopy() {
+	const (
+		inProgress = `{"status": "inProgress", "percentageComplete": 50}`
+		completed  = `{"status": "completed", "resourceId": "copied-id"}`
+	)
+
+	table := []struct {
+		name       string
+		monitor    *testMonitor
+		expectID   string
+		expectGets int
+		expectErr  assert.ErrorAssertionFunc
+	}{
+		{
+			name:       "completed",
+			monitor:    &testMonitor{statuses: []string{completed}},
+			expectID:   "copied-id",
+			expectGets: 1,
+			expectErr:  assert.NoError,
+		},
+		{
+			name:       "completes after polling",
+			monitor:    &testMonitor{statuses: []string{inProgress, inProgress, completed}},
+			expectID:   "copied-id",
+			expectGets: 3,
+			expectErr:  assert.NoError,
+		},
+		{
+			name: "failed",
+			monitor: &testMonitor{statuses: []string{
+				inProgress,
+				`{"status": "failed", "error": {"code": "nameAlreadyExists"}}`,
+			}},
+			expectGets: 2,
+			expectErr:  assert.Error,
+		},
+		{
+			name:       "completed without an item id",
+			monitor:    &testMonitor{statuses: []string{`{"status": "completed"}`}},
+			expectGets: 1,
+			expectErr:  assert.Error,
+		},
+		{
+			name:       "unreadable status",
+			monitor:    &testMonitor{statuses: []string{"not json"}},
+			expectGets: 1,
+			expectErr:  assert.Error,
+		},
+		{
+			name:      "monitor error",
+			monitor:   &testMonitor{err: assert.AnError},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			id, err := awaitCopy(ctx, test.monitor, "https://monitor", 0)
+			test.expectErr(t, err, clues.ToCore(err))
+
+			assert.Equal(t, test.expectID, id)
+			assert.Equal(t, test.expectGets, test.monitor.gets, "monitor polls")
+		})
+	}
+}
+
+func (suite *DrivesUnitSuite) TestAwaitCopy_canceled() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	monitor := &testMonitor{statuses: []string{`{"status": "inProgress"}`}}
+
+	_, err := awaitCopy(ctx, monitor, "https://monitor", 0)
+	assert.ErrorIs(t, err, context.Canceled, clues.ToCore(err))
+}
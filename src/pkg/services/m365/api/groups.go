@@ -93,6 +93,24 @@ func getGroups(
 	return results, el.Failure()
 }
 
+// CheckAccess makes a minimal request against the groups api to confirm that
+// the client's credentials are valid and authorized to read groups.
+func (c Groups) CheckAccess(ctx context.Context) error {
+	config := &groups.GroupsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &groups.GroupsRequestBuilderGetQueryParameters{
+			Select: idAnd(),
+			Top:    ptr.To[int32](1),
+		},
+	}
+
+	_, err := c.Stable.Client().Groups().Get(ctx, config)
+	if err != nil {
+		return graph.Wrap(ctx, err, "checking groups access")
+	}
+
+	return nil
+}
+
 const filterGroupByDisplayNameQueryTmpl = "displayName eq '%s'"
 
 // GetID can look up a group by either its canonical id (a uuid)
@@ -176,6 +194,78 @@ func (c Groups) GetRootSite(
 	return resp, graph.Stack(ctx, err).OrNil()
 }
 
+// GetAllSites returns the group's root site, followed by the sites
+// that teams create for each of their private and shared channels.
+// Only the root site is returned for groups that aren't teams.
+func (c Groups) GetAllSites(
+	ctx context.Context,
+	identifier string,
+	isTeam bool,
+	errs *fault.Bus,
+) ([]models.Siteable, error) {
+	root, err := c.GetRootSite(ctx, identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	sites := []models.Siteable{root}
+
+	if !isTeam {
+		return sites, nil
+	}
+
+	channels, err := Channels(c).GetChannels(ctx, identifier)
+	if err != nil {
+		return nil, clues.Wrap(err, "getting channels")
+	}
+
+	el := errs.Local()
+
+	for _, ch := range channels {
+		if el.Failure() != nil {
+			break
+		}
+
+		// standard channels store their files in the root site
+		if ptr.Val(ch.GetMembershipType()) == models.STANDARD_CHANNELMEMBERSHIPTYPE {
+			continue
+		}
+
+		ictx := clues.Add(ctx, "channel_id", ptr.Val(ch.GetId()))
+
+		folder, err := c.Stable.
+			Client().
+			Teams().
+			ByTeamIdString(identifier).
+			Channels().
+			ByChannelIdString(ptr.Val(ch.GetId())).
+			FilesFolder().
+			Get(ictx, nil)
+		if err != nil {
+			el.AddRecoverable(ictx, graph.Wrap(ictx, err, "getting channel files folder"))
+			continue
+		}
+
+		parent := folder.GetParentReference()
+		if parent == nil || len(ptr.Val(parent.GetSiteId())) == 0 {
+			el.AddRecoverable(ictx, clues.New("channel files folder has no site").WithClues(ictx))
+			continue
+		}
+
+		siteID := ptr.Val(parent.GetSiteId())
+
+		site, err := Sites(c).GetByID(ictx, siteID)
+		if err != nil {
+			el.AddRecoverable(ictx, clues.Wrap(err, "getting channel site"))
+			continue
+		}
+
+		sites = append(sites, site)
+	}
+
+	return sites, el.Failure()
+}
+
 // ---------------------------------------------------------------------------
 // helpers
 // ---------------------------------------------------------------------------
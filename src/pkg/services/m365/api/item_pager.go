@@ -213,6 +213,21 @@ func getAddedAndRemovedItemIDs[T any](
 	return a, pager.ValidModTimes(), r, du, graph.Stack(ctx, err).OrNil()
 }
 
+// AddedSince returns the subset of added items that were modified after
+// since.  Items without a mod time are always kept, since there's no way
+// to tell when they last changed.
+func AddedSince(added map[string]time.Time, since time.Time) map[string]time.Time {
+	result := make(map[string]time.Time, len(added))
+
+	for id, modTime := range added {
+		if modTime.IsZero() || modTime.After(since) {
+			result[id] = modTime
+		}
+	}
+
+	return result
+}
+
 type getIDer interface {
 	GetId() *string
 }
@@ -451,6 +451,71 @@ func (suite *PagerUnitSuite) TestGetAddedAndRemovedItemIDs() {
 	}
 }
 
+func (suite *PagerUnitSuite) TestGetAddedAndRemovedItemIDs_addedSince() {
+	var (
+		since = time.Now()
+		added = map[string]time.Time{
+			"before": since.Add(-time.Minute),
+			"at":     since,
+			"after":  since.Add(time.Minute),
+			"none":   {},
+		}
+		expect = map[string]time.Time{
+			"after": since.Add(time.Minute),
+			"none":  {},
+		}
+	)
+
+	tests := []struct {
+		name       string
+		pager      Pager[any]
+		deltaPager DeltaPager[any]
+		canDelta   bool
+	}{
+		{
+			name: "delta pager",
+			deltaPager: &testIDsDeltaPager{
+				t:             suite.T(),
+				added:         added,
+				removed:       []string{"gone"},
+				validModTimes: true,
+			},
+			canDelta: true,
+		},
+		{
+			name: "non-delta pager",
+			pager: &testIDsPager{
+				t:             suite.T(),
+				added:         added,
+				removed:       []string{"gone"},
+				validModTimes: true,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			a, validModTimes, removed, _, err := getAddedAndRemovedItemIDs[any](
+				ctx,
+				test.pager,
+				test.deltaPager,
+				"",
+				test.canDelta,
+				addedAndRemovedByAddtlData[any])
+			require.NoError(t, err, clues.ToCore(err))
+			require.True(t, validModTimes, "valid mod times")
+
+			assert.Equal(t, expect, AddedSince(a, since), "added since")
+			assert.Equal(t, []string{"gone"}, removed, "removed item IDs")
+		})
+	}
+}
+
 type testInput struct {
 	name         string
 	inputLink    *string
@@ -227,21 +227,16 @@ func (c Mail) PatchFolder(
 // items
 // ---------------------------------------------------------------------------
 
-// GetItem retrieves a Messageable item.  If the item contains an attachment, that
-// attachment is also downloaded.
-func (c Mail) GetItem(
+// getMessage retrieves a Messageable item, without its attachments.
+// Returns the item along with the size of its body content.
+func (c Mail) getMessage(
 	ctx context.Context,
 	userID, itemID string,
 	immutableIDs bool,
-	errs *fault.Bus,
-) (serialization.Parsable, *details.ExchangeInfo, error) {
-	var (
-		size     int64
-		mailBody models.ItemBodyable
-		config   = &users.ItemMessagesMessageItemRequestBuilderGetRequestConfiguration{
-			Headers: newPreferHeaders(preferImmutableIDs(immutableIDs)),
-		}
-	)
+) (models.Messageable, int64, error) {
+	config := &users.ItemMessagesMessageItemRequestBuilderGetRequestConfiguration{
+		Headers: newPreferHeaders(preferImmutableIDs(immutableIDs)),
+	}
 
 	mail, err := c.Stable.
 		Client().
@@ -251,17 +246,51 @@ func (c Mail) GetItem(
 		ByMessageIdString(itemID).
 		Get(ctx, config)
 	if err != nil {
-		return nil, nil, graph.Stack(ctx, err)
+		return nil, 0, graph.Stack(ctx, err)
 	}
 
-	mailBody = mail.GetBody()
-	if mailBody != nil {
-		content := ptr.Val(mailBody.GetContent())
-		if len(content) > 0 {
-			size = int64(len(content))
-		}
+	var size int64
+
+	if mailBody := mail.GetBody(); mailBody != nil {
+		size = int64(len(ptr.Val(mailBody.GetContent())))
 	}
 
+	return mail, size, nil
+}
+
+// GetItemWithoutAttachments retrieves a Messageable item without downloading
+// any of its attachments.  The size in the returned info only counts the
+// message body.
+func (c Mail) GetItemWithoutAttachments(
+	ctx context.Context,
+	userID, itemID string,
+	immutableIDs bool,
+) (serialization.Parsable, *details.ExchangeInfo, error) {
+	mail, size, err := c.getMessage(ctx, userID, itemID, immutableIDs)
+	if err != nil {
+		return nil, nil, clues.Stack(err)
+	}
+
+	mail.SetAttachments(nil)
+
+	return mail, MailInfo(mail, size), nil
+}
+
+// GetItem retrieves a Messageable item.  If the item contains an attachment, that
+// attachment is also downloaded.
+func (c Mail) GetItem(
+	ctx context.Context,
+	userID, itemID string,
+	immutableIDs bool,
+	errs *fault.Bus,
+) (serialization.Parsable, *details.ExchangeInfo, error) {
+	mail, size, err := c.getMessage(ctx, userID, itemID, immutableIDs)
+	if err != nil {
+		return nil, nil, clues.Stack(err)
+	}
+
+	mailBody := mail.GetBody()
+
 	if !ptr.Val(mail.GetHasAttachments()) && !HasAttachments(mailBody) {
 		return mail, MailInfo(mail, size), nil
 	}
@@ -373,6 +373,41 @@ func (suite *MailAPIIntgSuite) TestHugeAttachmentListDownload() {
 	}
 }
 
+func (suite *MailAPIIntgSuite) TestGetItemWithoutAttachments() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	defer gock.Off()
+
+	mid := "fake-message-id"
+	content := "message body"
+
+	body := models.NewItemBody()
+	body.SetContent(&content)
+
+	mitem := models.NewMessage()
+	mitem.SetId(&mid)
+	mitem.SetBody(body)
+	mitem.SetHasAttachments(ptr.To(true))
+
+	// only the message gets requested; attachments are never fetched.
+	interceptV1Path("users", "user", "messages", mid).
+		Reply(200).
+		JSON(parseableToMap(t, mitem))
+
+	item, info, err := suite.its.gockAC.Mail().GetItemWithoutAttachments(ctx, "user", mid, false)
+	require.NoError(t, err, clues.ToCore(err))
+
+	it, ok := item.(models.Messageable)
+	require.True(t, ok, "convert to messageable")
+
+	assert.Empty(t, it.GetAttachments(), "attachments")
+	assert.Equal(t, int64(len(content)), info.Size, "mail size")
+	assert.True(t, gock.IsDone(), "made all requests")
+}
+
 func (suite *MailAPIIntgSuite) TestMail_RestoreLargeAttachment() {
 	t := suite.T()
 
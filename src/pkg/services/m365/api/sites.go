@@ -54,6 +54,27 @@ func (c Sites) GetRoot(ctx context.Context) (models.Siteable, error) {
 	return resp, nil
 }
 
+// CheckAccess makes a minimal request against the sites api to confirm that
+// the client's credentials are valid and authorized to read sites.
+func (c Sites) CheckAccess(ctx context.Context) error {
+	options := &sites.SiteItemRequestBuilderGetRequestConfiguration{
+		QueryParameters: &sites.SiteItemRequestBuilderGetQueryParameters{
+			Select: idAnd(),
+		},
+	}
+
+	_, err := c.Stable.
+		Client().
+		Sites().
+		BySiteIdString("root").
+		Get(ctx, options)
+	if err != nil {
+		return graph.Wrap(ctx, err, "checking sites access")
+	}
+
+	return nil
+}
+
 // GetAll retrieves all sites.
 func (c Sites) GetAll(ctx context.Context, errs *fault.Bus) ([]models.Siteable, error) {
 	resp, err := c.Stable.Client().Sites().Get(ctx, nil)
@@ -112,6 +112,24 @@ func (c Users) GetAll(
 	return us, el.Failure()
 }
 
+// CheckAccess makes a minimal request against the users api to confirm that
+// the client's credentials are valid and authorized to read users.
+func (c Users) CheckAccess(ctx context.Context) error {
+	config := &users.UsersRequestBuilderGetRequestConfiguration{
+		QueryParameters: &users.UsersRequestBuilderGetQueryParameters{
+			Select: idAnd(),
+			Top:    ptr.To[int32](1),
+		},
+	}
+
+	_, err := c.Stable.Client().Users().Get(ctx, config)
+	if err != nil {
+		return graph.Wrap(ctx, err, "checking users access")
+	}
+
+	return nil
+}
+
 // GetByID looks up the user matching the given identifier.  The identifier can be either a
 // canonical user id or a princpalName.
 func (c Users) GetByID(ctx context.Context, identifier string) (models.Userable, error) {
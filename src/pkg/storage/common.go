@@ -2,6 +2,7 @@ package storage
 
 import (
 	"github.com/alcionai/clues"
+	"golang.org/x/exp/maps"
 
 	"github.com/alcionai/corso/src/pkg/credentials"
 )
@@ -42,6 +43,29 @@ func (s Storage) CommonConfig() (CommonConfig, error) {
 	return c, c.validate()
 }
 
+// SetCorsoPassphrase replaces the passphrase held in the storage config,
+// such as after the repository's passphrase changes.
+func (s *Storage) SetCorsoPassphrase(pass string) {
+	if s.Config == nil {
+		s.Config = map[string]string{}
+	}
+
+	s.Config[keyCommonCorsoPassphrase] = pass
+}
+
+// ResolveCredentials populates any missing credentials using the provider,
+// falling back to env vars.  A nil provider only checks the environment.
+func (c CommonConfig) ResolveCredentials(p credentials.Provider) (CommonConfig, error) {
+	corso, err := c.Corso.Resolve(p)
+	if err != nil {
+		return c, clues.Wrap(err, "resolving corso credentials")
+	}
+
+	c.Corso = corso
+
+	return c, nil
+}
+
 // ensures all required properties are present
 func (c CommonConfig) validate() error {
 	if len(c.CorsoPassphrase) == 0 {
@@ -51,3 +75,44 @@ func (c CommonConfig) validate() error {
 	// kopiaCfgFilePath is not required
 	return nil
 }
+
+// ResolveCredentials returns a copy of the storage with any credentials
+// missing from its config populated using the provider, falling back to
+// env vars.  A nil provider only checks the environment.
+func (s Storage) ResolveCredentials(p credentials.Provider) (Storage, error) {
+	cfg := maps.Clone(s.Config)
+	if cfg == nil {
+		cfg = map[string]string{}
+	}
+
+	c := CommonConfig{}
+	c.CorsoPassphrase = orEmptyString(cfg[keyCommonCorsoPassphrase])
+
+	c, err := c.ResolveCredentials(p)
+	if err != nil {
+		return s, err
+	}
+
+	cfg[keyCommonCorsoPassphrase] = c.CorsoPassphrase
+
+	if s.Provider == ProviderS3 {
+		aws := credentials.AWS{
+			AccessKey:    orEmptyString(cfg[keyS3AccessKey]),
+			SecretKey:    orEmptyString(cfg[keyS3SecretKey]),
+			SessionToken: orEmptyString(cfg[keyS3SessionToken]),
+		}
+
+		aws, err = aws.Resolve(p)
+		if err != nil {
+			return s, clues.Wrap(err, "resolving aws credentials")
+		}
+
+		cfg[keyS3AccessKey] = aws.AccessKey
+		cfg[keyS3SecretKey] = aws.SecretKey
+		cfg[keyS3SessionToken] = aws.SessionToken
+	}
+
+	s.Config = cfg
+
+	return s, nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/alcionai/clues"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/alcionai/corso/src/pkg/credentials"
@@ -94,3 +95,58 @@ func (suite *CommonCfgSuite) TestStorage_CommonConfig_InvalidCases() {
 		})
 	}
 }
+
+type passphraseProvider string
+
+func (pp passphraseProvider) Get(key string) (string, error) {
+	if key == credentials.CorsoPassphrase {
+		return string(pp), nil
+	}
+
+	return "", nil
+}
+
+func (suite *CommonCfgSuite) TestCommonConfig_ResolveCredentials() {
+	t := suite.T()
+
+	t.Setenv(credentials.CorsoPassphrase, "env-pass")
+
+	c, err := storage.CommonConfig{KopiaCfgDir: "dir"}.ResolveCredentials(passphraseProvider("provided"))
+	assert.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, "provided", c.CorsoPassphrase)
+	assert.Equal(t, "dir", c.KopiaCfgDir)
+
+	c, err = goodCommonConfig.ResolveCredentials(passphraseProvider("provided"))
+	assert.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, goodCommonConfig.CorsoPassphrase, c.CorsoPassphrase)
+}
+
+func (suite *CommonCfgSuite) TestStorage_ResolveCredentials() {
+	t := suite.T()
+
+	t.Setenv(credentials.CorsoPassphrase, "env-pass")
+	t.Setenv(credentials.AWSAccessKeyID, "env-key")
+	t.Setenv(credentials.AWSSecretAccessKey, "env-secret")
+	t.Setenv(credentials.AWSSessionToken, "")
+
+	st, err := storage.NewStorage(storage.ProviderS3, &storage.S3Config{Bucket: "bkt"})
+	require.NoError(t, err, clues.ToCore(err))
+
+	resolved, err := st.ResolveCredentials(passphraseProvider("provided"))
+	require.NoError(t, err, clues.ToCore(err))
+
+	c, err := resolved.CommonConfig()
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, "provided", c.CorsoPassphrase)
+
+	cfg, err := resolved.StorageConfig()
+	require.NoError(t, err, clues.ToCore(err))
+
+	s3, ok := cfg.(*storage.S3Config)
+	require.True(t, ok, "s3 config")
+	assert.Equal(t, "env-key", s3.AccessKey)
+	assert.Equal(t, "env-secret", s3.SecretKey)
+
+	_, err = st.CommonConfig()
+	assert.Error(t, err, "original storage is unchanged")
+}
@@ -19,6 +19,20 @@ type S3Config struct {
 	Prefix         string
 	DoNotUseTLS    bool
 	DoNotVerifyTLS bool
+
+	// CABundlePath is the path to a PEM file of certificate authorities
+	// used to verify the endpoint's TLS certificate, such as the private
+	// CA of an on-prem MinIO deployment.  The system's trusted CAs are
+	// used when empty.
+	CABundlePath string
+
+	// InsecureSkipVerify skips verification of the endpoint's TLS
+	// certificate, and is the same as DoNotVerifyTLS.  Without verification,
+	// anyone able to intercept the connection can read or alter the data
+	// sent to the repository, including its credentials.  Prefer a
+	// CABundlePath over this option whenever possible, and only skip
+	// verification for testing.
+	InsecureSkipVerify bool
 }
 
 // config key consts
@@ -31,6 +45,7 @@ const (
 	keyS3SessionToken   = "s3_session_token"
 	keyS3DoNotUseTLS    = "s3_donotusetls"
 	keyS3DoNotVerifyTLS = "s3_donotverifytls"
+	keyS3CABundlePath   = "s3_cabundlepath"
 )
 
 // config exported name consts
@@ -40,6 +55,7 @@ const (
 	Prefix         = "prefix"
 	DoNotUseTLS    = "donotusetls"
 	DoNotVerifyTLS = "donotverifytls"
+	CABundlePath   = "cabundlepath"
 )
 
 // config file keys
@@ -49,6 +65,7 @@ const (
 	PrefixKey                 = "prefix"
 	DisableTLSKey             = "disable_tls"
 	DisableTLSVerificationKey = "disable_tls_verification"
+	CABundlePathKey           = "ca_bundle_path"
 
 	AccessKey       = "aws_access_key_id"
 	SecretAccessKey = "aws_secret_access_key"
@@ -68,7 +85,8 @@ func (c *S3Config) normalize() S3Config {
 		Endpoint:       c.Endpoint,
 		Prefix:         common.NormalizePrefix(c.Prefix),
 		DoNotUseTLS:    c.DoNotUseTLS,
-		DoNotVerifyTLS: c.DoNotVerifyTLS,
+		DoNotVerifyTLS: c.DoNotVerifyTLS || c.InsecureSkipVerify,
+		CABundlePath:   c.CABundlePath,
 	}
 }
 
@@ -86,6 +104,7 @@ func (c *S3Config) StringConfig() (map[string]string, error) {
 		keyS3SessionToken:   c.SessionToken,
 		keyS3DoNotUseTLS:    strconv.FormatBool(cn.DoNotUseTLS),
 		keyS3DoNotVerifyTLS: strconv.FormatBool(cn.DoNotVerifyTLS),
+		keyS3CABundlePath:   cn.CABundlePath,
 	}
 
 	return cfg, cn.validate()
@@ -104,6 +123,7 @@ func buildS3ConfigFromMap(config map[string]string) (*S3Config, error) {
 		c.Prefix = orEmptyString(config[keyS3Prefix])
 		c.DoNotUseTLS = str.ParseBool(config[keyS3DoNotUseTLS])
 		c.DoNotVerifyTLS = str.ParseBool(config[keyS3DoNotVerifyTLS])
+		c.CABundlePath = orEmptyString(config[keyS3CABundlePath])
 	}
 
 	return c, c.validate()
@@ -139,6 +159,7 @@ func (c *S3Config) s3ConfigsFromStore(kvg Getter) {
 	c.Prefix = cast.ToString(kvg.Get(PrefixKey))
 	c.DoNotUseTLS = cast.ToBool(kvg.Get(DisableTLSKey))
 	c.DoNotVerifyTLS = cast.ToBool(kvg.Get(DisableTLSVerificationKey))
+	c.CABundlePath = cast.ToString(kvg.Get(CABundlePathKey))
 }
 
 func (c *S3Config) s3CredsFromStore(kvg Getter) {
@@ -207,6 +228,7 @@ func (c *S3Config) ApplyConfigOverrides(
 		overrides[DoNotVerifyTLS],
 		strconv.FormatBool(c.DoNotVerifyTLS),
 		"false"))
+	c.CABundlePath = str.First(overrides[CABundlePath], c.CABundlePath)
 
 	return c.validate()
 }
@@ -224,4 +246,5 @@ func (c *S3Config) WriteConfigToStore(
 	kvs.Set(PrefixKey, s3Config.Prefix)
 	kvs.Set(DisableTLSKey, s3Config.DoNotUseTLS)
 	kvs.Set(DisableTLSVerificationKey, s3Config.DoNotVerifyTLS)
+	kvs.Set(CABundlePathKey, s3Config.CABundlePath)
 }
@@ -35,6 +35,7 @@ var (
 		keyS3Prefix:         "pre/",
 		keyS3DoNotUseTLS:    "false",
 		keyS3DoNotVerifyTLS: "false",
+		keyS3CABundlePath:   "",
 		keyS3AccessKey:      "access",
 		keyS3SecretKey:      "secret",
 		keyS3SessionToken:   "token",
@@ -161,6 +162,28 @@ func (suite *S3CfgSuite) TestStorage_S3Config_StringConfig() {
 				keyS3Prefix:         "pre/",
 				keyS3DoNotUseTLS:    "true",
 				keyS3DoNotVerifyTLS: "true",
+				keyS3CABundlePath:   "",
+				keyS3AccessKey:      "",
+				keyS3SecretKey:      "",
+				keyS3SessionToken:   "",
+			},
+		},
+		{
+			name: "tls settings",
+			input: S3Config{
+				Bucket:             "bkt",
+				Endpoint:           "end",
+				Prefix:             "pre/",
+				CABundlePath:       "/etc/ca.pem",
+				InsecureSkipVerify: true,
+			},
+			expect: map[string]string{
+				keyS3Bucket:         "bkt",
+				keyS3Endpoint:       "end",
+				keyS3Prefix:         "pre/",
+				keyS3DoNotUseTLS:    "false",
+				keyS3DoNotVerifyTLS: "true",
+				keyS3CABundlePath:   "/etc/ca.pem",
 				keyS3AccessKey:      "",
 				keyS3SecretKey:      "",
 				keyS3SessionToken:   "",
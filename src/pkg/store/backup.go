@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/alcionai/clues"
 	"github.com/kopia/kopia/repo/manifest"
@@ -37,6 +38,22 @@ func Service(pst path.ServiceType) FilterOption {
 	}
 }
 
+// BackupVersion ensures the retrieved backups only match
+// the specified backup format version.
+func BackupVersion(v int) FilterOption {
+	return func(qf *queryFilters) {
+		qf.tags[model.BackupVersionTag] = strconv.Itoa(v)
+	}
+}
+
+// BackupGroup ensures the retrieved backups only match
+// the specified backup group.
+func BackupGroup(groupID string) FilterOption {
+	return func(qf *queryFilters) {
+		qf.tags[model.BackupGroupTag] = groupID
+	}
+}
+
 type (
 	BackupWrapper interface {
 		BackupGetterDeleter
@@ -55,6 +72,15 @@ type (
 		GetBackup(ctx context.Context, backupID model.StableID) (*backup.Backup, error)
 	}
 
+	BackupUpdater interface {
+		UpdateBackup(ctx context.Context, b *backup.Backup) error
+	}
+
+	BackupGetterUpdater interface {
+		BackupGetter
+		BackupUpdater
+	}
+
 	BackupDeleter interface {
 		DeleteBackup(ctx context.Context, backupID model.StableID) error
 	}
@@ -133,6 +159,11 @@ func (w wrapper) GetBackups(
 	return bs, nil
 }
 
+// UpdateBackup replaces the stored backup model with b.
+func (w wrapper) UpdateBackup(ctx context.Context, b *backup.Backup) error {
+	return clues.Wrap(w.Update(ctx, model.BackupSchema, b), "updating backup").OrNil()
+}
+
 // DeleteBackup deletes the backup and its details entry from the model store.
 func (w wrapper) DeleteBackup(ctx context.Context, backupID model.StableID) error {
 	return w.Delete(ctx, model.BackupSchema, backupID)